@@ -14,9 +14,20 @@ import (
 	"time"
 
 	chaincfg "github.com/calmw/bee-tron/pkg/config"
+	"github.com/calmw/bee-tron/pkg/crypto/hwwallet"
+	"github.com/calmw/bee-tron/pkg/file/joiner"
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/node"
+	"github.com/calmw/bee-tron/pkg/puller"
+	"github.com/calmw/bee-tron/pkg/pullsync"
+	"github.com/calmw/bee-tron/pkg/pusher"
+	"github.com/calmw/bee-tron/pkg/pushsync"
+	"github.com/calmw/bee-tron/pkg/retrieval"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	"github.com/calmw/bee-tron/pkg/storer"
 	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology/kademlia"
+	"github.com/calmw/bee-tron/pkg/transaction/failover"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -24,10 +35,28 @@ import (
 const (
 	optionNameDataDir                      = "data-dir"
 	optionNameCacheCapacity                = "cache-capacity"
+	optionNameCacheCapacityBytes           = "cache-capacity-bytes"
+	optionNameCacheEvictionPolicy          = "cache-eviction-policy"
+	optionNameCacheGatewayURL              = "cache-gateway-url"
 	optionNameDBOpenFilesLimit             = "db-open-files-limit"
 	optionNameDBBlockCacheCapacity         = "db-block-cache-capacity"
 	optionNameDBWriteBufferSize            = "db-write-buffer-size"
 	optionNameDBDisableSeeksCompaction     = "db-disable-seeks-compaction"
+	optionNameDBBloomFilterBits            = "db-bloom-filter-bits"
+	optionNameDBCompactionTableSize        = "db-compaction-table-size"
+	optionNameDBCompactionTotalSize        = "db-compaction-total-size"
+	optionNameDBCompression                = "db-compression"
+	optionNameDBIndexStore                 = "db-index-store"
+	optionNameDBPebbleCacheCapacity        = "db-pebble-cache-capacity"
+	optionNameDBPebbleMemTableSize         = "db-pebble-memtable-size"
+	optionNameDBPebbleOpenFilesLimit       = "db-pebble-open-files-limit"
+	optionNameDBCompactionInterval         = "db-compaction-interval"
+	optionNameDBCompactionThrottle         = "db-compaction-throttle"
+	optionNameDBMigrationDryRun            = "db-migration-dry-run"
+	optionNamePinningQuota                 = "pinning-quota"
+	optionNameUsageForecastInterval        = "usage-forecast-interval"
+	optionNameUsageAlertWebhook            = "usage-alert-webhook"
+	optionNameUsageAlertThreshold          = "usage-alert-threshold"
 	optionNamePassword                     = "password"
 	optionNamePasswordFile                 = "password-file"
 	optionNameAPIAddr                      = "api-addr"
@@ -50,13 +79,29 @@ const (
 	optionNameResolverEndpoints            = "resolver-options"
 	optionNameBootnodeMode                 = "bootnode-mode"
 	optionNameBlockchainRpcEndpoint        = "blockchain-rpc-endpoint"
+	optionNameBlockchainRpcFallbacks       = "blockchain-rpc-fallback-endpoints"
+	optionNameBlockchainRpcCheckInterval   = "blockchain-rpc-health-check-interval"
+	optionNameBlockchainRpcSubscribeHeads  = "blockchain-rpc-subscribe-heads"
 	optionNameSwapFactoryAddress           = "swap-factory-address"
 	optionNameSwapInitialDeposit           = "swap-initial-deposit"
 	optionNameSwapEnable                   = "swap-enable"
+	optionNameSwapRateTolerance            = "swap-rate-tolerance"
+	optionNameSwapWebhookURLs              = "swap-webhook-urls"
+	optionNameSwapWebhookSecret            = "swap-webhook-secret"
+	optionNameSwapTopUpFloor               = "swap-topup-floor"
+	optionNameSwapTopUpAmount              = "swap-topup-amount"
+	optionNameSwapTopUpMaxDaily            = "swap-topup-max-daily"
+	optionNameSwapAddressbookPruneAge      = "swap-addressbook-prune-age"
+	optionNameSwapLegacyChequeDomains      = "swap-legacy-cheque-domains"
+	optionNameSwapChequeDomainVersion      = "swap-cheque-domain-version"
 	optionNameChequebookEnable             = "chequebook-enable"
 	optionNameFullNode                     = "full-node"
 	optionNamePostageContractAddress       = "postage-stamp-address"
 	optionNamePostageContractStartBlock    = "postage-stamp-start-block"
+	optionNamePostageAutoDiluteThreshold   = "postage-auto-dilute-threshold"
+	optionNamePostageAutoDiluteMaxDepth    = "postage-auto-dilute-max-depth"
+	optionNamePostageAutoDiluteMaxGasPrice = "postage-auto-dilute-max-gas-price"
+	optionNamePostageAutoDiluteGasLimit    = "postage-auto-dilute-gas-limit"
 	optionNamePriceOracleAddress           = "price-oracle-address"
 	optionNameRedistributionAddress        = "redistribution-address"
 	optionNameStakingAddress               = "staking-address"
@@ -72,14 +117,60 @@ const (
 	optionNameAllowPrivateCIDRs            = "allow-private-cidrs"
 	optionNameSleepAfter                   = "sleep-after"
 	optionNameUsePostageSnapshot           = "use-postage-snapshot"
+	optionNamePostageSnapshotFile          = "postage-snapshot-file"
 	optionNameStorageIncentivesEnable      = "storage-incentives-enable"
 	optionNameStateStoreCacheCapacity      = "statestore-cache-capacity"
 	optionNameTargetNeighborhood           = "target-neighborhood"
 	optionNameNeighborhoodSuggester        = "neighborhood-suggester"
 	optionNameWhitelistedWithdrawalAddress = "withdrawal-addresses-whitelist"
 	optionNameTransactionDebugMode         = "transaction-debug-mode"
+	optionNameTransactionResubmitTimeout   = "transaction-resubmit-timeout"
+	optionNameTransactionResubmitMaxFee    = "transaction-resubmit-max-fee"
+	optionNameClefSignerEnable             = "clef-signer-enable"
+	optionNameClefSignerEndpoint           = "clef-signer-endpoint"
+	optionNameClefSignerEthereumAddress    = "clef-signer-ethereum-address"
+	optionNameHardwareWalletEnable         = "hardware-wallet-enable"
+	optionNameHardwareWalletDerivationPath = "hardware-wallet-derivation-path"
+	optionNameHardwareWalletPrompt         = "hardware-wallet-prompt"
 	optionMinimumStorageRadius             = "minimum-storage-radius"
 	optionReserveCapacityDoubling          = "reserve-capacity-doubling"
+	optionReserveSampleWorkers             = "reserve-sample-workers"
+	optionStorageRadiusPin                 = "storage-radius-pin"
+	optionStorageRadiusOffset              = "storage-radius-offset"
+	optionNameStaticPeers                  = "static-peers"
+	optionNameHealthCheckInterval          = "kademlia-health-check-interval"
+	optionNameHealthCheckTimeout           = "kademlia-health-check-timeout"
+	optionNameHealthCheckFailureThreshold  = "kademlia-health-check-failure-threshold"
+	optionNameSaturationPeers              = "kademlia-saturation-peers"
+	optionNameOverbookingFactor            = "kademlia-overbooking-factor"
+	optionNamePruneInterval                = "kademlia-prune-interval"
+	optionNameBalancingStrategy            = "kademlia-balancing-strategy"
+	optionNameMisbehaviorThreshold         = "kademlia-misbehavior-threshold"
+	optionNameMisbehaviorBlockDuration     = "kademlia-misbehavior-block-duration"
+	optionNameMisbehaviorPermanentAfter    = "kademlia-misbehavior-permanent-after"
+	optionNameRetrievalHedgeDelay          = "retrieval-hedge-delay"
+	optionNameRetrievalMaxAttempts         = "retrieval-max-attempts"
+	optionNameRetrievalAttemptTimeout      = "retrieval-attempt-timeout"
+	optionNameRetrievalRetryInterval       = "retrieval-retry-interval"
+	optionNameRetrievalBackoff             = "retrieval-backoff"
+	optionNameRetrievalNotFoundTTL         = "retrieval-not-found-ttl"
+	optionNameRetrievalSelectionCandidates = "retrieval-selection-candidates"
+	optionNameRetrievalPriceWeight         = "retrieval-price-weight"
+	optionNameRetrievalLatencyWeight       = "retrieval-latency-weight"
+	optionNameDownloadReadAhead            = "download-read-ahead"
+	optionNamePusherWorkers                = "pusher-workers"
+	optionNamePusherPeerInflight           = "pusher-peer-inflight"
+	optionNamePusherQueueCapacity          = "pusher-queue-capacity"
+	optionNamePushSyncReceiptMode          = "pushsync-receipt-mode"
+	optionNamePusherDeadLetterAttempts     = "pusher-dead-letter-attempts"
+	optionNamePusherDeadLetterRedrive      = "pusher-dead-letter-redrive-interval"
+	optionNamePullerChunksPerSecond        = "puller-chunks-per-second"
+	optionNamePullerPeerChunksPerSecond    = "puller-peer-chunks-per-second"
+	optionNamePullSyncBandwidth            = "pullsync-bandwidth"
+	optionNamePullSyncPeerBandwidth        = "pullsync-peer-bandwidth"
+	optionNamePullerIntervalPruneAge       = "puller-interval-prune-age"
+	optionNamePullerRadiusMargin           = "puller-radius-margin"
+	optionNamePullerLiveOnlyBins           = "puller-live-only-bins"
 )
 
 // nolint:gochecknoinits
@@ -234,10 +325,28 @@ func (c *command) setHomeDir() (err error) {
 func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().String(optionNameDataDir, filepath.Join(c.homeDir, ".bee"), "data directory")
 	cmd.Flags().Uint64(optionNameCacheCapacity, 1_000_000, fmt.Sprintf("cache capacity in chunks, multiply by %d to get approximate capacity in bytes", swarm.ChunkSize))
+	cmd.Flags().Uint64(optionNameCacheCapacityBytes, 0, "cache capacity in bytes in addition to the capacity in chunks, 0 for no limit")
+	cmd.Flags().String(optionNameCacheEvictionPolicy, storer.CacheLRUPolicy, "cache eviction policy, lru, clock or slru")
+	cmd.Flags().String(optionNameCacheGatewayURL, "", "API URL of a gateway or companion node queried for chunks before retrieving them from the network")
 	cmd.Flags().Uint64(optionNameDBOpenFilesLimit, 200, "number of open files allowed by database")
 	cmd.Flags().Uint64(optionNameDBBlockCacheCapacity, 32*1024*1024, "size of block cache of the database in bytes")
 	cmd.Flags().Uint64(optionNameDBWriteBufferSize, 32*1024*1024, "size of the database write buffer in bytes")
 	cmd.Flags().Bool(optionNameDBDisableSeeksCompaction, true, "disables db compactions triggered by seeks")
+	cmd.Flags().Int(optionNameDBBloomFilterBits, 64, "bits per key of the bloom filter of the levelDB index store")
+	cmd.Flags().Uint64(optionNameDBCompactionTableSize, 0, "size of the sorted tables of the levelDB index store in bytes, 0 for the default")
+	cmd.Flags().Uint64(optionNameDBCompactionTotalSize, 0, "total size of the tables of the first level of the levelDB index store triggering a compaction in bytes, 0 for the default")
+	cmd.Flags().String(optionNameDBCompression, storer.LdbSnappyCompression, "block compression of the levelDB index store, snappy or none")
+	cmd.Flags().String(optionNameDBIndexStore, storer.LevelDBIndexStore, "key-value backend of the localstore index, leveldb or pebble")
+	cmd.Flags().Uint64(optionNameDBPebbleCacheCapacity, 64*1024*1024, "size of the block cache of the pebble index store in bytes")
+	cmd.Flags().Uint64(optionNameDBPebbleMemTableSize, 64*1024*1024, "size of the memtable of the pebble index store in bytes")
+	cmd.Flags().Uint64(optionNameDBPebbleOpenFilesLimit, 1000, "number of open files allowed by the pebble index store")
+	cmd.Flags().Duration(optionNameDBCompactionInterval, 0, "period of the online sharky compaction rounds, 0 disables")
+	cmd.Flags().Duration(optionNameDBCompactionThrottle, 10*time.Millisecond, "pause before each chunk relocation of the online sharky compaction")
+	cmd.Flags().Bool(optionNameDBMigrationDryRun, false, "log the pending localstore migrations and exit without running them")
+	cmd.Flags().Uint64(optionNamePinningQuota, 0, "total size of the pinned chunks in bytes, 0 for no limit")
+	cmd.Flags().Duration(optionNameUsageForecastInterval, 10*time.Minute, "period of the storage usage samples of the usage forecasts, 0 disables")
+	cmd.Flags().String(optionNameUsageAlertWebhook, "", "URL receiving the storage usage alerts")
+	cmd.Flags().Duration(optionNameUsageAlertThreshold, 24*time.Hour, "projected time to full of a storage component under which the usage alert is fired")
 	cmd.Flags().String(optionNamePassword, "", "password for decrypting keys")
 	cmd.Flags().String(optionNamePasswordFile, "", "path to a file that contains password for decrypting keys")
 	cmd.Flags().String(optionNameAPIAddr, "127.0.0.1:1633", "HTTP API listen address")
@@ -260,13 +369,35 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSlice(optionNameResolverEndpoints, []string{}, "ENS compatible API endpoint for a TLD and with contract address, can be repeated, format [tld:][contract-addr@]url")
 	cmd.Flags().Bool(optionNameBootnodeMode, false, "cause the node to always accept incoming connections")
 	cmd.Flags().String(optionNameBlockchainRpcEndpoint, "", "rpc blockchain endpoint")
+	cmd.Flags().StringSlice(optionNameBlockchainRpcFallbacks, []string{}, "rpc blockchain endpoints used in order if the preferred endpoint fails")
+	cmd.Flags().Duration(optionNameBlockchainRpcCheckInterval, failover.DefaultHealthCheckInterval, "time between health checks of rpc blockchain endpoints if fallback endpoints are set")
+	cmd.Flags().Bool(optionNameBlockchainRpcSubscribeHeads, false, "subscribe to new blocks over websocket rpc endpoints instead of polling, falls back to polling if not supported")
 	cmd.Flags().String(optionNameSwapFactoryAddress, "", "swap factory addresses")
 	cmd.Flags().String(optionNameSwapInitialDeposit, "0", "initial deposit if deploying a new chequebook")
+	cmd.Flags().Uint64(optionNameSwapRateTolerance, 0, "accepted deviation of peer exchange rates in basis points")
+	cmd.Flags().StringSlice(optionNameSwapWebhookURLs, []string{}, "URLs receiving settlement events")
+	cmd.Flags().String(optionNameSwapWebhookSecret, "", "secret used to sign the timestamp and the body of settlement event webhooks")
+	cmd.Flags().String(optionNameSwapTopUpFloor, "0", "available chequebook balance below which the chequebook is topped up from the wallet, 0 disables")
+	cmd.Flags().String(optionNameSwapTopUpAmount, "0", "amount deposited into the chequebook per top-up, 0 defaults to the floor")
+	cmd.Flags().String(optionNameSwapTopUpMaxDaily, "0", "maximum amount deposited into the chequebook by top-ups per day, 0 defaults to the top-up amount")
+	cmd.Flags().Duration(optionNameSwapAddressbookPruneAge, 0, "remove swap addressbook entries of peers not seen for this long, 0 disables")
+	cmd.Flags().StringSlice(optionNameSwapLegacyChequeDomains, []string{}, "legacy cheque domains received cheques may be signed in, format [version:]chain-id")
+	cmd.Flags().String(optionNameSwapChequeDomainVersion, chequebook.ChequeDomainVersion, "EIP712 domain version of the chequebook contract cheques are signed in")
 	cmd.Flags().Bool(optionNameSwapEnable, false, "enable swap")
+	cmd.Flags().Bool(optionNameClefSignerEnable, false, "enable clef signer")
+	cmd.Flags().String(optionNameClefSignerEndpoint, "", "clef signer endpoint")
+	cmd.Flags().String(optionNameClefSignerEthereumAddress, "", "blockchain address to use from clef signer")
+	cmd.Flags().Bool(optionNameHardwareWalletEnable, false, "sign blockchain transactions and cheques with a ledger or trezor hardware wallet")
+	cmd.Flags().String(optionNameHardwareWalletDerivationPath, hwwallet.DefaultDerivationPath, "derivation path of the hardware wallet account")
+	cmd.Flags().Bool(optionNameHardwareWalletPrompt, false, "ask on the terminal to approve every request before it is forwarded to the hardware wallet")
 	cmd.Flags().Bool(optionNameChequebookEnable, true, "enable chequebook")
 	cmd.Flags().Bool(optionNameFullNode, false, "cause the node to start in full mode")
 	cmd.Flags().String(optionNamePostageContractAddress, "", "postage stamp contract address")
 	cmd.Flags().Uint64(optionNamePostageContractStartBlock, 0, "postage stamp contract start block number")
+	cmd.Flags().Uint(optionNamePostageAutoDiluteThreshold, 0, "fullness in percent of the fullest bucket at which owned batches are diluted, 0 disables")
+	cmd.Flags().Uint(optionNamePostageAutoDiluteMaxDepth, 0, "depth owned batches are not automatically diluted beyond, 0 means no maximum")
+	cmd.Flags().String(optionNamePostageAutoDiluteMaxGasPrice, "0", "gas price in wei above which automatic dilutions are postponed, 0 means no maximum")
+	cmd.Flags().Uint64(optionNamePostageAutoDiluteGasLimit, 0, "gas limit of automatic dilution transactions, 0 uses the default")
 	cmd.Flags().String(optionNamePriceOracleAddress, "", "price oracle contract address")
 	cmd.Flags().String(optionNameRedistributionAddress, "", "redistribution contract address")
 	cmd.Flags().String(optionNameStakingAddress, "", "staking contract address")
@@ -280,14 +411,54 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSlice(optionNameStaticNodes, []string{}, "protect nodes from getting kicked out on bootnode")
 	cmd.Flags().Bool(optionNameAllowPrivateCIDRs, false, "allow to advertise private CIDRs to the public network")
 	cmd.Flags().Bool(optionNameUsePostageSnapshot, false, "bootstrap node using postage snapshot from the network")
+	cmd.Flags().String(optionNamePostageSnapshotFile, "", "bootstrap an empty batch store from a snapshot file exported with \"bee db export batchstore\"")
 	cmd.Flags().Bool(optionNameStorageIncentivesEnable, true, "enable storage incentives feature")
 	cmd.Flags().Uint64(optionNameStateStoreCacheCapacity, 100_000, "lru memory caching capacity in number of statestore entries")
 	cmd.Flags().String(optionNameTargetNeighborhood, "", "neighborhood to target in binary format (ex: 111111001) for mining the initial overlay")
-	cmd.Flags().String(optionNameNeighborhoodSuggester, "https://api.swarmscan.io/v1/network/neighborhoods/suggestion", "suggester for target neighborhood")
+	cmd.Flags().String(optionNameNeighborhoodSuggester, "https://api.swarmscan.io/v1/network/neighborhoods/suggestion", "suggester for target neighborhood, the /topology/neighborhood endpoint of a running node may be used")
 	cmd.Flags().StringSlice(optionNameWhitelistedWithdrawalAddress, []string{}, "withdrawal target addresses")
 	cmd.Flags().Bool(optionNameTransactionDebugMode, false, "skips the gas estimate step for contract transactions")
+	cmd.Flags().Duration(optionNameTransactionResubmitTimeout, 0, "resubmit transactions pending for this long with a bumped fee, 0 disables")
+	cmd.Flags().String(optionNameTransactionResubmitMaxFee, "0", "maximum fee cap in wei per gas of resubmitted transactions, 0 means no maximum")
 	cmd.Flags().Uint(optionMinimumStorageRadius, 0, "minimum radius storage threshold")
 	cmd.Flags().Int(optionReserveCapacityDoubling, 0, "reserve capacity doubling")
+	cmd.Flags().Int(optionReserveSampleWorkers, 0, "number of workers computing the reserve sample, 0 for the number of CPUs")
+	cmd.Flags().Int(optionStorageRadiusPin, -1, "fixed storage radius not following the automatic adaptation, -1 disables")
+	cmd.Flags().Int(optionStorageRadiusOffset, 0, "offset added to the automatically adapted storage radius")
+	cmd.Flags().StringSlice(optionNameStaticPeers, []string{}, "peers always kept connected, never pruned nor blocklisted")
+	cmd.Flags().Duration(optionNameHealthCheckInterval, kademlia.DefaultHealthCheckInterval, "period of the health checks pinging the connected peers, 0 disables")
+	cmd.Flags().Duration(optionNameHealthCheckTimeout, kademlia.DefaultHealthCheckTimeout, "timeout of a peer health check ping")
+	cmd.Flags().Int(optionNameHealthCheckFailureThreshold, kademlia.DefaultHealthCheckFailureThreshold, "consecutive failed health checks after which a peer is marked unhealthy")
+	cmd.Flags().Int(optionNameSaturationPeers, kademlia.DefaultSaturationPeers, "number of peers in a bin the node connects to before the bin is saturated")
+	cmd.Flags().Float64(optionNameOverbookingFactor, kademlia.DefaultOverbookingFactor, "multiple of the saturation peers a bin may hold before it is pruned")
+	cmd.Flags().Duration(optionNamePruneInterval, kademlia.DefaultPruneInterval, "period of pruning the oversaturated bins")
+	cmd.Flags().String(optionNameBalancingStrategy, string(kademlia.DefaultBalancingStrategy), "peers kept per bin, uniform or closest-heavy favoring the bins closer to the node")
+	cmd.Flags().Float64(optionNameMisbehaviorThreshold, kademlia.DefaultMisbehaviorThreshold, "weight of the reported peer misbehavior at which the peer is blocklisted, 0 disables")
+	cmd.Flags().Duration(optionNameMisbehaviorBlockDuration, kademlia.DefaultMisbehaviorBlockDuration, "duration of the first blocklisting of a misbehaving peer, doubled on every repeated offence")
+	cmd.Flags().Int(optionNameMisbehaviorPermanentAfter, kademlia.DefaultMisbehaviorPermanentAfter, "number of temporary blocklistings after which a misbehaving peer is blocklisted permanently, 0 never")
+	cmd.Flags().Duration(optionNameRetrievalHedgeDelay, 0, "delay after which an unanswered chunk retrieval request is hedged by a request to the next closest peer, 0 disables")
+	cmd.Flags().Int(optionNameRetrievalMaxAttempts, retrieval.DefaultMaxAttempts, "maximum number of requests of a chunk retrieval originating at the node")
+	cmd.Flags().Duration(optionNameRetrievalAttemptTimeout, retrieval.DefaultAttemptTimeout, "timeout of a single chunk retrieval attempt")
+	cmd.Flags().Duration(optionNameRetrievalRetryInterval, retrieval.DefaultRetryInterval, "interval of the preemptive attempts of a chunk retrieval originating at the node")
+	cmd.Flags().StringSlice(optionNameRetrievalBackoff, []string{}, "delays before retrying the consecutive failed chunk retrieval attempts, the last one is repeated, retries immediately if empty")
+	cmd.Flags().Duration(optionNameRetrievalNotFoundTTL, retrieval.DefaultNotFoundTTL, "period a chunk not found in the network is not retrieved again, 0 disables")
+	cmd.Flags().Int(optionNameRetrievalSelectionCandidates, retrieval.DefaultSelectionCandidates, "number of the closest peers the one with the lowest price and latency cost is requested a chunk from, 1 requests the closest peer")
+	cmd.Flags().Float64(optionNameRetrievalPriceWeight, retrieval.DefaultSelectionPriceWeight, "weight of the relative chunk price in the retrieval peer cost")
+	cmd.Flags().Float64(optionNameRetrievalLatencyWeight, retrieval.DefaultSelectionLatencyWeight, "weight of the relative latency in the retrieval peer cost")
+	cmd.Flags().Int(optionNameDownloadReadAhead, joiner.DefaultReadAhead, "number of the data chunks prefetched ahead of the sequential reads of a download, 0 disables")
+	cmd.Flags().Int(optionNamePusherWorkers, pusher.DefaultWorkers, "number of chunks pushed to the network concurrently")
+	cmd.Flags().Int(optionNamePusherPeerInflight, pusher.DefaultPeerInflight, "number of chunks pushed concurrently to the same closest peer, 0 for no limit")
+	cmd.Flags().Int(optionNamePusherQueueCapacity, pusher.DefaultQueueCapacity, "number of chunks waiting to be pushed above which the uploads are rejected until the queue drains")
+	cmd.Flags().String(optionNamePushSyncReceiptMode, string(pushsync.DefaultReceiptMode), "verification of the receipts of the pushed chunks, signature, proximity of the storer or cross-check with a duplicate receipt")
+	cmd.Flags().Int(optionNamePusherDeadLetterAttempts, pusher.DefaultDeadLetterAttempts, "number of failed pushes after which a chunk is dead-lettered instead of retried, 0 retries forever")
+	cmd.Flags().Duration(optionNamePusherDeadLetterRedrive, pusher.DefaultDeadLetterRedriveInterval, "interval of pushing the dead-lettered chunks again, 0 disables")
+	cmd.Flags().Int(optionNamePullerChunksPerSecond, puller.DefaultChunksPerSecond, "number of chunks synced per second from all the peers")
+	cmd.Flags().Int(optionNamePullerPeerChunksPerSecond, puller.DefaultPeerChunksPerSecond, "number of chunks synced per second from a single peer, 0 for no limit")
+	cmd.Flags().Int(optionNamePullSyncBandwidth, pullsync.DefaultBandwidth, "number of bytes synced per second from all the peers, 0 for no limit")
+	cmd.Flags().Int(optionNamePullSyncPeerBandwidth, pullsync.DefaultPeerBandwidth, "number of bytes synced per second from a single peer, 0 for no limit")
+	cmd.Flags().Duration(optionNamePullerIntervalPruneAge, puller.DefaultIntervalPruneAge, "time after which the synced intervals of the peers not seen are pruned, 0 disables")
+	cmd.Flags().Int(optionNamePullerRadiusMargin, puller.DefaultRadiusMargin, "number of proximity orders below the storage radius of the peers whose proximity order bin is synced, 0 syncs only within the storage radius")
+	cmd.Flags().String(optionNamePullerLiveOnlyBins, "", "comma separated bins or bin ranges of which only the new chunks are synced, like 0,2-4")
 }
 
 func newLogger(cmd *cobra.Command, verbosity string) (log.Logger, error) {
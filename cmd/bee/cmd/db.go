@@ -20,6 +20,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/node"
 	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/postage/batchstore"
 	"github.com/calmw/bee-tron/pkg/puller"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storer"
@@ -34,6 +35,9 @@ const (
 	optionNameValidationPin  = "validate-pin"
 	optionNameCollectionPin  = "pin"
 	optionNameOutputLocation = "output"
+	optionNameIndexStoreFrom = "from"
+	optionNameIndexStoreTo   = "to"
+	optionNameCursor         = "cursor"
 )
 
 func (c *command) initDBCmd() {
@@ -47,6 +51,7 @@ func (c *command) initDBCmd() {
 	dbNukeCmd(cmd)
 	dbInfoCmd(cmd)
 	dbCompactCmd(cmd)
+	dbMigrateIndexCmd(cmd)
 	dbValidateCmd(cmd)
 	dbValidatePinsCmd(cmd)
 	dbRepairReserve(cmd)
@@ -182,6 +187,62 @@ func dbCompactCmd(cmd *cobra.Command) {
 	cmd.AddCommand(c)
 }
 
+func dbMigrateIndexCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "migrate-index",
+		Short: "Copies the localstore index to another key-value backend.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			from, err := cmd.Flags().GetString(optionNameIndexStoreFrom)
+			if err != nil {
+				return fmt.Errorf("get from: %w", err)
+			}
+			to, err := cmd.Flags().GetString(optionNameIndexStoreTo)
+			if err != nil {
+				return fmt.Errorf("get to: %w", err)
+			}
+
+			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
+
+			err = storer.MigrateIndexStore(cmd.Context(), localstorePath, from, to, &storer.Options{
+				Logger:                logger,
+				LdbOpenFilesLimit:     200,
+				LdbBlockCacheCapacity: 32 * 1024 * 1024,
+				LdbWriteBufferSize:    32 * 1024 * 1024,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+
+			logger.Info("start the node with the new backend selected with the --db-index-store option, the old index store may be removed afterwards")
+
+			return nil
+		},
+	}
+	c.Flags().String(optionNameDataDir, "", "data directory")
+	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().String(optionNameIndexStoreFrom, storer.LevelDBIndexStore, "index store backend to migrate from, leveldb or pebble")
+	c.Flags().String(optionNameIndexStoreTo, storer.PebbleIndexStore, "index store backend to migrate to, leveldb or pebble")
+	cmd.AddCommand(c)
+}
+
 func dbValidatePinsCmd(cmd *cobra.Command) {
 	c := &cobra.Command{
 		Use:   "validate-pin",
@@ -291,7 +352,7 @@ func dbRepairReserve(cmd *cobra.Command) {
 			}
 			defer db.Close()
 
-			err = migration.ReserveRepairer(db.Storage(), storage.ChunkType, logger)()
+			err = migration.ReserveRepairer(db.Storage(), storage.ChunkType, logger, nil)()
 			if err != nil {
 				return fmt.Errorf("repair: %w", err)
 			}
@@ -340,10 +401,16 @@ func dbValidateCmd(cmd *cobra.Command) {
 			logger.Warning("    Invalid chunks logged at Warning level.")
 			logger.Warning("    Progress logged at Info level.")
 			logger.Warning("    SOC chunks logged at Debug level.")
+			logger.Warning("    An interrupted validation can be resumed with the last logged cursor.")
+
+			cursor, err := cmd.Flags().GetString(optionNameCursor)
+			if err != nil {
+				return fmt.Errorf("get cursor: %w", err)
+			}
 
 			localstorePath := path.Join(dataDir, ioutil.DataPathLocalstore)
 
-			err = storer.ValidateRetrievalIndex(context.Background(), localstorePath, &storer.Options{
+			err = storer.ValidateRetrievalIndex(context.Background(), localstorePath, cursor, &storer.Options{
 				Logger:          logger,
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
@@ -358,6 +425,7 @@ func dbValidateCmd(cmd *cobra.Command) {
 	}
 	c.Flags().String(optionNameDataDir, "", "data directory")
 	c.Flags().String(optionNameVerbosity, "info", "verbosity level")
+	c.Flags().String(optionNameCursor, "", "resume the validation after the cursor")
 	cmd.AddCommand(c)
 }
 
@@ -371,6 +439,8 @@ func dbExportCmd(cmd *cobra.Command) {
 
 	dbExportReserveCmd(c)
 	dbExportPinningCmd(c)
+	dbExportBatchstoreCmd(c)
+	dbExportSnapshotCmd(c)
 	cmd.AddCommand(c)
 }
 
@@ -559,6 +629,130 @@ func dbExportPinningCmd(cmd *cobra.Command) {
 	cmd.AddCommand(c)
 }
 
+func dbExportBatchstoreCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "batchstore <filename>",
+		Short: "Export the postage batch store to a snapshot file. Use \"-\" as filename in order to write to STDOUT",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if (len(args)) != 1 {
+				return cmd.Help()
+			}
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			logger.Info("starting batch store export with data-dir", "path", dataDir)
+
+			stateStore, _, err := node.InitStateStore(logger, dataDir, 1000)
+			if err != nil {
+				return fmt.Errorf("new statestore: %w", err)
+			}
+			defer stateStore.Close()
+
+			batchStore, err := batchstore.New(stateStore, func([]byte) error { return nil }, storer.DefaultReserveCapacity, logger)
+			if err != nil {
+				return fmt.Errorf("batchstore: %w", err)
+			}
+
+			var out io.Writer
+			if args[0] == "-" {
+				out = os.Stdout
+			} else {
+				f, err := os.Create(args[0])
+				if err != nil {
+					return fmt.Errorf("opening output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			if err := batchStore.(postage.BatchSnapshotter).ExportSnapshot(out); err != nil {
+				return fmt.Errorf("exporting batch store: %w", err)
+			}
+			logger.Info("batch store exported successfully", "file", args[0], "block", batchStore.GetChainState().Block)
+			return nil
+		},
+	}
+	cmd.AddCommand(c)
+}
+
+func dbExportSnapshotCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "snapshot <filename>",
+		Short: "Export the reserve and the pinned content to a snapshot file. Use \"-\" as filename in order to write to STDOUT",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if (len(args)) != 1 {
+				return cmd.Help()
+			}
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			logger.Info("starting snapshot export with data-dir", "path", dataDir)
+
+			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      new(postage.NoOpBatchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				CacheCapacity:   1_000_000,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+			defer db.Close()
+
+			var out io.Writer
+			if args[0] == "-" {
+				out = os.Stdout
+			} else {
+				f, err := os.Create(args[0])
+				if err != nil {
+					return fmt.Errorf("opening output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			stat, err := db.ExportSnapshot(cmd.Context(), out)
+			if err != nil {
+				return fmt.Errorf("exporting snapshot: %w", err)
+			}
+			logger.Info("snapshot exported successfully", "file", args[0], "reserve_chunks", stat.ReserveChunks, "pin_collections", stat.PinCollections, "pin_chunks", stat.PinChunks)
+			return nil
+		},
+	}
+	cmd.AddCommand(c)
+}
+
 func dbImportCmd(cmd *cobra.Command) {
 	c := &cobra.Command{
 		Use:   "import",
@@ -569,6 +763,7 @@ func dbImportCmd(cmd *cobra.Command) {
 
 	dbImportReserveCmd(c)
 	dbImportPinningCmd(c)
+	dbImportSnapshotCmd(c)
 	cmd.AddCommand(c)
 }
 
@@ -764,6 +959,80 @@ func dbImportPinningCmd(cmd *cobra.Command) {
 	cmd.AddCommand(c)
 }
 
+func dbImportSnapshotCmd(cmd *cobra.Command) {
+	c := &cobra.Command{
+		Use:   "snapshot <filename>",
+		Short: "Import the reserve and the pinned content from a snapshot file, validating the postage stamps against the local batch store. Use \"-\" as filename in order to read from STDIN",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if (len(args)) != 1 {
+				return cmd.Help()
+			}
+			v, err := cmd.Flags().GetString(optionNameVerbosity)
+			if err != nil {
+				return fmt.Errorf("get verbosity: %w", err)
+			}
+			v = strings.ToLower(v)
+			logger, err := newLogger(cmd, v)
+			if err != nil {
+				return fmt.Errorf("new logger: %w", err)
+			}
+			dataDir, err := cmd.Flags().GetString(optionNameDataDir)
+			if err != nil {
+				return fmt.Errorf("get data-dir: %w", err)
+			}
+			if dataDir == "" {
+				return errors.New("no data-dir provided")
+			}
+
+			logger.Info("starting snapshot import with data-dir", "path", dataDir)
+
+			stateStore, _, err := node.InitStateStore(logger, dataDir, 1000)
+			if err != nil {
+				return fmt.Errorf("new statestore: %w", err)
+			}
+			defer stateStore.Close()
+
+			batchStore, err := batchstore.New(stateStore, func([]byte) error { return nil }, storer.DefaultReserveCapacity, logger)
+			if err != nil {
+				return fmt.Errorf("batchstore: %w", err)
+			}
+
+			db, err := storer.New(cmd.Context(), dataDir, &storer.Options{
+				Logger:          logger,
+				RadiusSetter:    noopRadiusSetter{},
+				Batchstore:      batchStore,
+				ValidStamp:      postage.ValidStamp(batchStore),
+				ReserveCapacity: storer.DefaultReserveCapacity,
+				CacheCapacity:   1_000_000,
+			})
+			if err != nil {
+				return fmt.Errorf("localstore: %w", err)
+			}
+			defer db.Close()
+
+			var in io.Reader
+			if args[0] == "-" {
+				in = os.Stdin
+			} else {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("opening input file: %w", err)
+				}
+				defer f.Close()
+				in = f
+			}
+
+			stat, err := db.ImportSnapshot(cmd.Context(), in)
+			if err != nil {
+				return fmt.Errorf("importing snapshot: %w", err)
+			}
+			logger.Info("snapshot imported successfully", "file", args[0], "reserve_chunks", stat.ReserveChunks, "pin_collections", stat.PinCollections, "pin_chunks", stat.PinChunks, "invalid", stat.Invalid)
+			return nil
+		},
+	}
+	cmd.AddCommand(c)
+}
+
 func dbNukeCmd(cmd *cobra.Command) {
 	const (
 		optionNameForgetOverlay = "forget-overlay"
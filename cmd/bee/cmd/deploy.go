@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/calmw/bee-tron/pkg/node"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/erc20"
 	"github.com/spf13/cobra"
 )
@@ -46,7 +47,10 @@ func (c *command) initDeployCmd() error {
 			if err != nil {
 				return err
 			}
-			signer := signerConfig.signer
+			signer, err := c.configureChainSigner(logger, signerConfig.signer)
+			if err != nil {
+				return err
+			}
 
 			ctx := cmd.Context()
 
@@ -59,6 +63,11 @@ func (c *command) initDeployCmd() error {
 				signer,
 				blocktime,
 				true,
+				c.config.GetStringSlice(optionNameBlockchainRpcFallbacks),
+				c.config.GetDuration(optionNameBlockchainRpcCheckInterval),
+				c.config.GetBool(optionNameBlockchainRpcSubscribeHeads),
+				0,
+				"0",
 			)
 			if err != nil {
 				return err
@@ -78,12 +87,18 @@ func (c *command) initDeployCmd() error {
 
 			erc20Service := erc20.New(transactionService, erc20Address)
 
+			chequeDomain := chequebook.ChequeDomain{
+				Version: c.config.GetString(optionNameSwapChequeDomainVersion),
+				ChainID: chainID,
+			}
+
 			_, err = node.InitChequebookService(
 				ctx,
 				logger,
 				stateStore,
 				signer,
 				chainID,
+				chequeDomain,
 				swapBackend,
 				overlayEthAddress,
 				transactionService,
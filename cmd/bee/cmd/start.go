@@ -12,6 +12,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -24,6 +25,8 @@ import (
 	"github.com/calmw/bee-tron/pkg/accesscontrol"
 	chaincfg "github.com/calmw/bee-tron/pkg/config"
 	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/crypto/clef"
+	"github.com/calmw/bee-tron/pkg/crypto/hwwallet"
 	"github.com/calmw/bee-tron/pkg/keystore"
 	filekeystore "github.com/calmw/bee-tron/pkg/keystore/file"
 	memkeystore "github.com/calmw/bee-tron/pkg/keystore/mem"
@@ -31,6 +34,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/node"
 	"github.com/calmw/bee-tron/pkg/resolver/multiresolver"
 	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/kardianos/service"
 	"github.com/spf13/cobra"
 )
@@ -222,6 +230,16 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		return nil, errors.New("boot node must be started as a full node")
 	}
 
+	chainSigner, err := c.configureChainSigner(logger, signerConfig.signer)
+	if err != nil {
+		return nil, fmt.Errorf("configure chain signer: %w", err)
+	}
+	// the stake is bound to the overlay computed from the address of the
+	// staking transactions, which must be the address of the node key
+	if c.config.GetBool(optionNameHardwareWalletEnable) && fullNode && c.config.GetBool(optionNameStorageIncentivesEnable) {
+		return nil, errors.New("hardware wallet can not be used with storage incentives")
+	}
+
 	mainnet := c.config.GetBool(optionNameMainNet)
 	userHasSetNetworkID := c.config.IsSet(optionNameNetworkID)
 
@@ -273,6 +291,17 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		return nil, errors.New("static nodes can only be configured on bootnodes")
 	}
 
+	retrievalBackoffOpt := c.config.GetStringSlice(optionNameRetrievalBackoff)
+	retrievalBackoff := make([]time.Duration, 0, len(retrievalBackoffOpt))
+	for _, v := range retrievalBackoffOpt {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retrieval backoff %q: %w", v, err)
+		}
+
+		retrievalBackoff = append(retrievalBackoff, d)
+	}
+
 	var neighborhoodSuggester string
 	if networkID == chaincfg.Mainnet.NetworkID {
 		neighborhoodSuggester = c.config.GetString(optionNameNeighborhoodSuggester)
@@ -281,10 +310,28 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 	b, err := node.NewBee(ctx, c.config.GetString(optionNameP2PAddr), signerConfig.publicKey, signerConfig.signer, networkID, logger, signerConfig.libp2pPrivateKey, signerConfig.pssPrivateKey, signerConfig.session, &node.Options{
 		DataDir:                       c.config.GetString(optionNameDataDir),
 		CacheCapacity:                 c.config.GetUint64(optionNameCacheCapacity),
+		CacheCapacityBytes:            c.config.GetUint64(optionNameCacheCapacityBytes),
+		CacheEvictionPolicy:           c.config.GetString(optionNameCacheEvictionPolicy),
+		CacheGatewayURL:               c.config.GetString(optionNameCacheGatewayURL),
 		DBOpenFilesLimit:              c.config.GetUint64(optionNameDBOpenFilesLimit),
 		DBBlockCacheCapacity:          c.config.GetUint64(optionNameDBBlockCacheCapacity),
 		DBWriteBufferSize:             c.config.GetUint64(optionNameDBWriteBufferSize),
 		DBDisableSeeksCompaction:      c.config.GetBool(optionNameDBDisableSeeksCompaction),
+		DBBloomFilterBits:             c.config.GetInt(optionNameDBBloomFilterBits),
+		DBCompactionTableSize:         c.config.GetUint64(optionNameDBCompactionTableSize),
+		DBCompactionTotalSize:         c.config.GetUint64(optionNameDBCompactionTotalSize),
+		DBCompression:                 c.config.GetString(optionNameDBCompression),
+		DBIndexStore:                  c.config.GetString(optionNameDBIndexStore),
+		DBPebbleCacheCapacity:         c.config.GetUint64(optionNameDBPebbleCacheCapacity),
+		DBPebbleMemTableSize:          c.config.GetUint64(optionNameDBPebbleMemTableSize),
+		DBPebbleOpenFilesLimit:        c.config.GetUint64(optionNameDBPebbleOpenFilesLimit),
+		DBCompactionInterval:          c.config.GetDuration(optionNameDBCompactionInterval),
+		DBCompactionThrottle:          c.config.GetDuration(optionNameDBCompactionThrottle),
+		DBMigrationDryRun:             c.config.GetBool(optionNameDBMigrationDryRun),
+		PinningQuota:                  c.config.GetUint64(optionNamePinningQuota),
+		UsageForecastInterval:         c.config.GetDuration(optionNameUsageForecastInterval),
+		UsageAlertWebhook:             c.config.GetString(optionNameUsageAlertWebhook),
+		UsageAlertThreshold:           c.config.GetDuration(optionNameUsageAlertThreshold),
 		APIAddr:                       c.config.GetString(optionNameAPIAddr),
 		Addr:                          c.config.GetString(optionNameP2PAddr),
 		NATAddr:                       c.config.GetString(optionNameNATAddr),
@@ -302,13 +349,30 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		ResolverConnectionCfgs:        resolverCfgs,
 		BootnodeMode:                  bootNode,
 		BlockchainRpcEndpoint:         c.config.GetString(optionNameBlockchainRpcEndpoint),
+		BlockchainRpcFallbacks:        c.config.GetStringSlice(optionNameBlockchainRpcFallbacks),
+		BlockchainRpcCheckInterval:    c.config.GetDuration(optionNameBlockchainRpcCheckInterval),
+		BlockchainRpcSubscribeHeads:   c.config.GetBool(optionNameBlockchainRpcSubscribeHeads),
+		ChainSigner:                   chainSigner,
 		SwapFactoryAddress:            c.config.GetString(optionNameSwapFactoryAddress),
 		SwapInitialDeposit:            c.config.GetString(optionNameSwapInitialDeposit),
 		SwapEnable:                    c.config.GetBool(optionNameSwapEnable),
+		SwapRateTolerance:             c.config.GetUint64(optionNameSwapRateTolerance),
+		SwapWebhookURLs:               c.config.GetStringSlice(optionNameSwapWebhookURLs),
+		SwapWebhookSecret:             c.config.GetString(optionNameSwapWebhookSecret),
+		SwapTopUpFloor:                c.config.GetString(optionNameSwapTopUpFloor),
+		SwapTopUpAmount:               c.config.GetString(optionNameSwapTopUpAmount),
+		SwapTopUpMaxDaily:             c.config.GetString(optionNameSwapTopUpMaxDaily),
+		SwapAddressbookPruneAge:       c.config.GetDuration(optionNameSwapAddressbookPruneAge),
+		SwapLegacyChequeDomains:       c.config.GetStringSlice(optionNameSwapLegacyChequeDomains),
+		SwapChequeDomainVersion:       c.config.GetString(optionNameSwapChequeDomainVersion),
 		ChequebookEnable:              c.config.GetBool(optionNameChequebookEnable),
 		FullNodeMode:                  fullNode,
 		PostageContractAddress:        c.config.GetString(optionNamePostageContractAddress),
 		PostageContractStartBlock:     c.config.GetUint64(optionNamePostageContractStartBlock),
+		PostageAutoDiluteThreshold:    c.config.GetUint(optionNamePostageAutoDiluteThreshold),
+		PostageAutoDiluteMaxDepth:     c.config.GetUint(optionNamePostageAutoDiluteMaxDepth),
+		PostageAutoDiluteMaxGasPrice:  c.config.GetString(optionNamePostageAutoDiluteMaxGasPrice),
+		PostageAutoDiluteGasLimit:     c.config.GetUint64(optionNamePostageAutoDiluteGasLimit),
 		PriceOracleAddress:            c.config.GetString(optionNamePriceOracleAddress),
 		RedistributionContractAddress: c.config.GetString(optionNameRedistributionAddress),
 		StakingContractAddress:        c.config.GetString(optionNameStakingAddress),
@@ -322,14 +386,54 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		StaticNodes:                   staticNodes,
 		AllowPrivateCIDRs:             c.config.GetBool(optionNameAllowPrivateCIDRs),
 		UsePostageSnapshot:            c.config.GetBool(optionNameUsePostageSnapshot),
+		PostageSnapshotFile:           c.config.GetString(optionNamePostageSnapshotFile),
 		EnableStorageIncentives:       c.config.GetBool(optionNameStorageIncentivesEnable),
 		StatestoreCacheCapacity:       c.config.GetUint64(optionNameStateStoreCacheCapacity),
 		TargetNeighborhood:            c.config.GetString(optionNameTargetNeighborhood),
 		NeighborhoodSuggester:         neighborhoodSuggester,
 		WhitelistedWithdrawalAddress:  c.config.GetStringSlice(optionNameWhitelistedWithdrawalAddress),
 		TrxDebugMode:                  c.config.GetBool(optionNameTransactionDebugMode),
+		TrxResubmitTimeout:            c.config.GetDuration(optionNameTransactionResubmitTimeout),
+		TrxResubmitMaxFee:             c.config.GetString(optionNameTransactionResubmitMaxFee),
 		MinimumStorageRadius:          c.config.GetUint(optionMinimumStorageRadius),
 		ReserveCapacityDoubling:       c.config.GetInt(optionReserveCapacityDoubling),
+		ReserveSampleWorkers:          c.config.GetInt(optionReserveSampleWorkers),
+		StorageRadiusPin:              c.config.GetInt(optionStorageRadiusPin),
+		StorageRadiusOffset:           c.config.GetInt(optionStorageRadiusOffset),
+		StaticPeers:                   c.config.GetStringSlice(optionNameStaticPeers),
+		HealthCheckInterval:           c.config.GetDuration(optionNameHealthCheckInterval),
+		HealthCheckTimeout:            c.config.GetDuration(optionNameHealthCheckTimeout),
+		HealthCheckFailureThreshold:   c.config.GetInt(optionNameHealthCheckFailureThreshold),
+		SaturationPeers:               c.config.GetInt(optionNameSaturationPeers),
+		OverbookingFactor:             c.config.GetFloat64(optionNameOverbookingFactor),
+		PruneInterval:                 c.config.GetDuration(optionNamePruneInterval),
+		BalancingStrategy:             c.config.GetString(optionNameBalancingStrategy),
+		MisbehaviorThreshold:          c.config.GetFloat64(optionNameMisbehaviorThreshold),
+		MisbehaviorBlockDuration:      c.config.GetDuration(optionNameMisbehaviorBlockDuration),
+		MisbehaviorPermanentAfter:     c.config.GetInt(optionNameMisbehaviorPermanentAfter),
+		RetrievalHedgeDelay:           c.config.GetDuration(optionNameRetrievalHedgeDelay),
+		RetrievalMaxAttempts:          c.config.GetInt(optionNameRetrievalMaxAttempts),
+		RetrievalAttemptTimeout:       c.config.GetDuration(optionNameRetrievalAttemptTimeout),
+		RetrievalRetryInterval:        c.config.GetDuration(optionNameRetrievalRetryInterval),
+		RetrievalBackoff:              retrievalBackoff,
+		RetrievalNotFoundTTL:          c.config.GetDuration(optionNameRetrievalNotFoundTTL),
+		RetrievalSelectionCandidates:  c.config.GetInt(optionNameRetrievalSelectionCandidates),
+		RetrievalPriceWeight:          c.config.GetFloat64(optionNameRetrievalPriceWeight),
+		RetrievalLatencyWeight:        c.config.GetFloat64(optionNameRetrievalLatencyWeight),
+		DownloadReadAhead:             c.config.GetInt(optionNameDownloadReadAhead),
+		PusherWorkers:                 c.config.GetInt(optionNamePusherWorkers),
+		PusherPeerInflight:            c.config.GetInt(optionNamePusherPeerInflight),
+		PusherQueueCapacity:           c.config.GetInt(optionNamePusherQueueCapacity),
+		PushSyncReceiptMode:           c.config.GetString(optionNamePushSyncReceiptMode),
+		PusherDeadLetterAttempts:      c.config.GetInt(optionNamePusherDeadLetterAttempts),
+		PusherRedriveInterval:         c.config.GetDuration(optionNamePusherDeadLetterRedrive),
+		PullerChunksPerSecond:         c.config.GetInt(optionNamePullerChunksPerSecond),
+		PullerPeerChunksPerSecond:     c.config.GetInt(optionNamePullerPeerChunksPerSecond),
+		PullSyncBandwidth:             c.config.GetInt(optionNamePullSyncBandwidth),
+		PullSyncPeerBandwidth:         c.config.GetInt(optionNamePullSyncPeerBandwidth),
+		PullerIntervalPruneAge:        c.config.GetDuration(optionNamePullerIntervalPruneAge),
+		PullerRadiusMargin:            c.config.GetInt(optionNamePullerRadiusMargin),
+		PullerLiveOnlyBins:            c.config.GetString(optionNamePullerLiveOnlyBins),
 	})
 
 	return b, err
@@ -401,13 +505,58 @@ func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config
 		}
 	}
 
-	swarmPrivateKey, _, err := keystore.Key("swarm", password, crypto.EDGSecp256_K1)
-	if err != nil {
-		return nil, fmt.Errorf("swarm key: %w", err)
+	if c.config.GetBool(optionNameClefSignerEnable) {
+		endpoint := c.config.GetString(optionNameClefSignerEndpoint)
+		if endpoint == "" {
+			endpoint, err = clef.DefaultIpcPath()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		externalSigner, err := external.NewExternalSigner(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("connect to clef signer: %w", err)
+		}
+
+		clientRPC, err := rpc.Dial(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("dial clef signer: %w", err)
+		}
+
+		wantedAddress := c.config.GetString(optionNameClefSignerEthereumAddress)
+		// if an address was specified use that, otherwise the first clef account is selected
+		var ethAddress *common.Address
+		if wantedAddress != "" {
+			if !common.IsHexAddress(wantedAddress) {
+				return nil, fmt.Errorf("invalid clef signer ethereum address %q", wantedAddress)
+			}
+			address := common.HexToAddress(wantedAddress)
+			ethAddress = &address
+		}
+
+		signer, err = clef.NewSigner(externalSigner, clientRPC, ethAddress)
+		if err != nil {
+			return nil, fmt.Errorf("clef signer: %w", err)
+		}
+
+		publicKey, err = signer.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		// the private key is held by clef, so no access control keys can be derived
+		session = accesscontrol.NewNoKeySession()
+		logger.Warning("clef signer is used, access control is not available")
+	} else {
+		swarmPrivateKey, _, err := keystore.Key("swarm", password, crypto.EDGSecp256_K1)
+		if err != nil {
+			return nil, fmt.Errorf("swarm key: %w", err)
+		}
+		signer = crypto.NewDefaultSigner(swarmPrivateKey)
+		publicKey = &swarmPrivateKey.PublicKey
+		session = accesscontrol.NewDefaultSession(swarmPrivateKey)
 	}
-	signer = crypto.NewDefaultSigner(swarmPrivateKey)
-	publicKey = &swarmPrivateKey.PublicKey
-	session = accesscontrol.NewDefaultSession(swarmPrivateKey)
 
 	logger.Info("swarm public key", "public_key", hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(publicKey)))
 
@@ -449,6 +598,70 @@ func (c *command) configureSigner(cmd *cobra.Command, logger log.Logger) (config
 	}, nil
 }
 
+// configureChainSigner returns the signer of the blockchain transactions and
+// cheques, which is the hardware wallet if it is enabled and the node signer
+// otherwise. The node identity is always signed by the node signer, as
+// hardware wallets do not sign arbitrary messages.
+func (c *command) configureChainSigner(logger log.Logger, signer crypto.Signer) (crypto.Signer, error) {
+	if !c.config.GetBool(optionNameHardwareWalletEnable) {
+		return signer, nil
+	}
+	if c.config.GetBool(optionNameClefSignerEnable) {
+		return nil, errors.New("hardware wallet and clef signer can not be enabled together")
+	}
+
+	hubs := []func() (*usbwallet.Hub, error){
+		usbwallet.NewLedgerHub,
+		usbwallet.NewTrezorHubWithHID,
+		usbwallet.NewTrezorHubWithWebUSB,
+	}
+	var wallet accounts.Wallet
+	for _, newHub := range hubs {
+		hub, err := newHub()
+		if err != nil {
+			logger.Debug("hardware wallet hub not available", "error", err)
+			continue
+		}
+		wallet, err = hwwallet.FirstWallet(hub)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, hwwallet.ErrWalletNotFound) {
+			return nil, err
+		}
+	}
+	if wallet == nil {
+		return nil, hwwallet.ErrWalletNotFound
+	}
+
+	approve := hardwareWalletApprover(logger, c.config.GetBool(optionNameHardwareWalletPrompt), c.root.InOrStdin(), c.root.OutOrStdout())
+	chainSigner, err := hwwallet.NewSigner(wallet, c.config.GetString(optionNameHardwareWalletDerivationPath), approve)
+	if err != nil {
+		return nil, fmt.Errorf("hardware wallet signer: %w", err)
+	}
+
+	address, err := chainSigner.EthereumAddress()
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("using hardware wallet for blockchain transactions and cheques", "url", wallet.URL(), "address", address)
+
+	return chainSigner, nil
+}
+
+// hardwareWalletApprover returns the function announcing the requests to the
+// hardware wallet, which asks the operator to approve them on in and out if
+// prompt is set, and only logs them otherwise.
+func hardwareWalletApprover(logger log.Logger, prompt bool, in io.Reader, out io.Writer) hwwallet.ApproveFunc {
+	if prompt {
+		return hwwallet.NewPromptApprover(in, out)
+	}
+	return func(req hwwallet.Request) error {
+		logger.Info("confirm the request on the hardware wallet", "request", req.String())
+		return nil
+	}
+}
+
 type networkConfig struct {
 	bootNodes []string
 	blockTime time.Duration
@@ -0,0 +1,56 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/calmw/bee-tron/cmd/bee/cmd"
+	"github.com/calmw/bee-tron/pkg/crypto/hwwallet"
+	"github.com/calmw/bee-tron/pkg/log"
+)
+
+func TestHardwareWalletApprover(t *testing.T) {
+	t.Parallel()
+
+	req := hwwallet.Request{Kind: hwwallet.RequestTypedData, PrimaryType: "Cheque"}
+
+	t.Run("prompt rejected", func(t *testing.T) {
+		t.Parallel()
+
+		out := new(bytes.Buffer)
+		approve := cmd.HardwareWalletApprover(log.Noop, true, strings.NewReader("n\n"), out)
+		if err := approve(req); err == nil {
+			t.Fatal("expected rejected request")
+		}
+		if !strings.Contains(out.String(), req.String()) {
+			t.Fatalf("request not shown to operator: %q", out.String())
+		}
+	})
+
+	t.Run("prompt approved", func(t *testing.T) {
+		t.Parallel()
+
+		approve := cmd.HardwareWalletApprover(log.Noop, true, strings.NewReader("y\n"), new(bytes.Buffer))
+		if err := approve(req); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("no prompt", func(t *testing.T) {
+		t.Parallel()
+
+		out := new(bytes.Buffer)
+		approve := cmd.HardwareWalletApprover(log.Noop, false, strings.NewReader("n\n"), out)
+		if err := approve(req); err != nil {
+			t.Fatal(err)
+		}
+		if out.Len() != 0 {
+			t.Fatalf("operator asked without prompt: %q", out.String())
+		}
+	})
+}
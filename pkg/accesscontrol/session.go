@@ -17,6 +17,8 @@ var (
 	ErrInvalidPublicKey = errors.New("invalid public key")
 	// ErrSecretKeyInfinity is an error that is returned when the shared secret is a point at infinity.
 	ErrSecretKeyInfinity = errors.New("shared secret is point at infinity")
+	// ErrNoSessionKey is an error that is returned when the node has no private key to derive keys from.
+	ErrNoSessionKey = errors.New("no session key available")
 )
 
 // Session represents an interface for a Diffie-Hellmann key derivation
@@ -64,3 +66,18 @@ func NewDefaultSession(key *ecdsa.PrivateKey) *SessionStruct {
 		key: key,
 	}
 }
+
+var _ Session = noKeySession{}
+
+// noKeySession is the session of a node whose private key is held by an external signer.
+type noKeySession struct{}
+
+// Key always fails as no key can be derived without the private key.
+func (noKeySession) Key(*ecdsa.PublicKey, [][]byte) ([][]byte, error) {
+	return nil, ErrNoSessionKey
+}
+
+// NewNoKeySession creates a session for nodes without access to their private key.
+func NewNoKeySession() Session {
+	return noKeySession{}
+}
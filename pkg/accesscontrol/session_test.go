@@ -9,6 +9,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"testing"
@@ -93,6 +94,18 @@ func TestSessionKeyWithoutNonces(t *testing.T) {
 	}
 }
 
+func TestSessionNoKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	assertNoError(t, "GenerateSecp256k1Key", err)
+
+	_, err = accesscontrol.NewNoKeySession().Key(&key.PublicKey, nil)
+	if !errors.Is(err, accesscontrol.ErrNoSessionKey) {
+		assert.FailNowf(t, "unexpected error", "got %v, want %v", err, accesscontrol.ErrNoSessionKey)
+	}
+}
+
 func TestSessionKeyFromKeystore(t *testing.T) {
 	t.Parallel()
 
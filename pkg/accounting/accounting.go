@@ -18,6 +18,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/p2p"
 	"github.com/calmw/bee-tron/pkg/pricing"
+	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -146,6 +147,8 @@ type accountingPeer struct {
 	fullNode                       bool     // the peer connected as full node or light node
 	totalDebtRepay                 *big.Int // since being connected, amount of cumulative debt settled by the peer
 	thresholdGrowAt                *big.Int // cumulative debt to be settled by the peer in order to give threshold upgrade
+	thresholdOverride              bool     // the payment threshold given to the peer is set by a settlement policy
+	swapDisabled                   bool     // monetary settlement with the peer is disabled by a settlement policy
 }
 
 // Accounting is the main implementation of the accounting interface.
@@ -185,6 +188,8 @@ type Accounting struct {
 	lightDisconnectLimit     *big.Int
 	lightThresholdGrowStep   *big.Int
 	lightThresholdGrowChange *big.Int
+	// operator configured per-peer settlement overrides
+	policies *settlement.PolicyStore
 }
 
 var (
@@ -469,7 +474,7 @@ func (a *Accounting) settle(peer swarm.Address, balance *accountingPeer) error {
 			}
 		}
 
-		if a.payFunction != nil && !balance.paymentOngoing {
+		if a.payFunction != nil && !balance.paymentOngoing && !balance.swapDisabled {
 			// if a settlement failed recently, wait until failedSettlementInterval before trying again
 			differenceInSeconds := now.Unix() - balance.lastSettlementFailureTimestamp
 			if differenceInSeconds > failedSettlementInterval {
@@ -636,6 +641,10 @@ func (a *Accounting) getAccountingPeer(peer swarm.Address) *accountingPeer {
 // to set the next checkpoint and increase the payment threshold given by 1 * refreshment rate
 // must be called under accountingPeer lock
 func (a *Accounting) notifyPaymentThresholdUpgrade(peer swarm.Address, accountingPeer *accountingPeer) {
+	// thresholds set by a settlement policy are not grown
+	if accountingPeer.thresholdOverride {
+		return
+	}
 
 	// get appropriate linear growth limit based on whether the peer is a full node or a light node
 	thresholdGrowChange := new(big.Int).Set(a.thresholdGrowChange)
@@ -1102,6 +1111,68 @@ func (a *Accounting) NotifyPaymentReceived(peer swarm.Address, amount *big.Int)
 	return nil
 }
 
+// NotifyPaymentBounced is called by Settlement when a payment previously
+// received from the peer turned out to be uncovered. It reverses the effect of
+// NotifyPaymentReceived, consuming surplus balance first and re-opening the
+// remainder as debt of the peer.
+func (a *Accounting) NotifyPaymentBounced(peer swarm.Address, amount *big.Int) error {
+	loggerV2 := a.logger.V(2).Register()
+
+	accountingPeer := a.getAccountingPeer(peer)
+
+	accountingPeer.lock.Lock()
+	defer accountingPeer.lock.Unlock()
+
+	accountingPeer.totalDebtRepay = new(big.Int).Sub(accountingPeer.totalDebtRepay, amount)
+	if accountingPeer.totalDebtRepay.Sign() < 0 {
+		accountingPeer.totalDebtRepay = big.NewInt(0)
+	}
+
+	surplus, err := a.SurplusBalance(peer)
+	if err != nil {
+		return fmt.Errorf("failed to get surplus balance: %w", err)
+	}
+
+	debt := new(big.Int).Set(amount)
+	if surplus.Sign() > 0 {
+		consumed := new(big.Int).Set(surplus)
+		if consumed.Cmp(debt) > 0 {
+			consumed.Set(debt)
+		}
+		decreasedSurplus := new(big.Int).Sub(surplus, consumed)
+
+		loggerV2.Debug("surplus debiting peer due to bounced payment", "peer_address", peer, "amount", consumed, "new_balance", decreasedSurplus)
+
+		err = a.store.Put(peerSurplusBalanceKey(peer), decreasedSurplus)
+		if err != nil {
+			return fmt.Errorf("failed to persist surplus balance: %w", err)
+		}
+		debt.Sub(debt, consumed)
+	}
+
+	if debt.Sign() == 0 {
+		return nil
+	}
+
+	currentBalance, err := a.Balance(peer)
+	if err != nil {
+		if !errors.Is(err, ErrPeerNoBalance) {
+			return err
+		}
+	}
+
+	nextBalance := new(big.Int).Add(currentBalance, debt)
+
+	loggerV2.Debug("debiting peer due to bounced payment", "peer_address", peer, "amount", debt, "new_balance", nextBalance)
+
+	err = a.store.Put(peerBalanceKey(peer), nextBalance)
+	if err != nil {
+		return fmt.Errorf("failed to persist balance: %w", err)
+	}
+
+	return nil
+}
+
 // NotifyRefreshmentSent is called by pseudosettle when refreshment is done or failed
 func (a *Accounting) NotifyRefreshmentSent(peer swarm.Address, attemptedAmount, amount *big.Int, timestamp int64, allegedInterval int64, receivedError error) {
 	accountingPeer := a.getAccountingPeer(peer)
@@ -1458,6 +1529,10 @@ func (a *Accounting) Connect(peer swarm.Address, fullNode bool) {
 	accountingPeer.thresholdGrowAt.Set(thresholdGrowStep)
 	accountingPeer.disconnectLimit.Set(disconnectLimit)
 
+	if a.applyPolicy(peer, accountingPeer) {
+		a.announcePaymentThreshold(peer, accountingPeer.paymentThresholdForPeer)
+	}
+
 	err := a.store.Put(peerBalanceKey(peer), zero)
 	if err != nil {
 		a.logger.Error(err, "failed to persist balance")
@@ -1536,6 +1611,86 @@ func (a *Accounting) Disconnect(peer swarm.Address) {
 	}
 }
 
+// applyPolicy applies the settlement policy configured for the peer on top of
+// the default thresholds. It reports whether the payment threshold given to the
+// peer is overridden. Must be called under accountingPeer lock.
+func (a *Accounting) applyPolicy(peer swarm.Address, accountingPeer *accountingPeer) bool {
+	accountingPeer.thresholdOverride = false
+	accountingPeer.swapDisabled = false
+
+	if a.policies == nil {
+		return false
+	}
+
+	policy, ok, err := a.policies.Policy(peer)
+	if err != nil {
+		a.logger.Error(err, "failed to load settlement policy", "peer_address", peer)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	accountingPeer.swapDisabled = !policy.SwapAllowed()
+	if policy.PaymentThreshold == nil {
+		return false
+	}
+
+	accountingPeer.thresholdOverride = true
+	accountingPeer.paymentThresholdForPeer.Set(policy.PaymentThreshold)
+	accountingPeer.disconnectLimit.Set(percentOf(100+a.paymentTolerance, policy.PaymentThreshold))
+	return true
+}
+
+// announcePaymentThreshold sends the payment threshold to the peer in the background.
+func (a *Accounting) announcePaymentThreshold(peer swarm.Address, paymentThreshold *big.Int) {
+	threshold := new(big.Int).Set(paymentThreshold)
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		err := a.pricing.AnnouncePaymentThreshold(context.Background(), peer, threshold)
+		if err != nil {
+			a.logger.Error(err, "announcing payment threshold", "value", threshold, "peer_address", peer)
+		}
+	}()
+}
+
+// NotifyPolicyChanged re-applies the settlement policy of a connected peer and
+// announces the resulting payment threshold to it.
+func (a *Accounting) NotifyPolicyChanged(peer swarm.Address) {
+	accountingPeer := a.getAccountingPeer(peer)
+
+	accountingPeer.lock.Lock()
+	defer accountingPeer.lock.Unlock()
+
+	if !accountingPeer.connected {
+		return
+	}
+
+	hadOverride := accountingPeer.thresholdOverride
+	if !a.applyPolicy(peer, accountingPeer) {
+		if !hadOverride {
+			return
+		}
+		// the override was removed, fall back to the default thresholds
+		paymentThreshold := a.paymentThreshold
+		disconnectLimit := a.disconnectLimit
+		if !accountingPeer.fullNode {
+			paymentThreshold = a.lightPaymentThreshold
+			disconnectLimit = a.lightDisconnectLimit
+		}
+		accountingPeer.paymentThresholdForPeer.Set(paymentThreshold)
+		accountingPeer.disconnectLimit.Set(disconnectLimit)
+	}
+
+	a.announcePaymentThreshold(peer, accountingPeer.paymentThresholdForPeer)
+}
+
+// SetPolicyStore sets the store of per-peer settlement policies.
+func (a *Accounting) SetPolicyStore(policies *settlement.PolicyStore) {
+	a.policies = policies
+}
+
 func (a *Accounting) SetRefreshFunc(f RefreshFunc) {
 	a.refreshFunction = f
 }
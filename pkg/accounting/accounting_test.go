@@ -16,6 +16,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/p2p"
 	p2pmock "github.com/calmw/bee-tron/pkg/p2p/mock"
+	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/statestore/mock"
 
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -1251,6 +1252,67 @@ func TestAccountingNotifyPaymentReceived(t *testing.T) {
 	}
 }
 
+func TestAccountingNotifyPaymentBounced(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	store := mock.NewStateStore()
+	defer store.Close()
+
+	pricing := &pricingMock{}
+
+	acc, err := accounting.NewAccounting(testPaymentThreshold, testPaymentTolerance, testPaymentEarly, logger, store, pricing, big.NewInt(testRefreshRate), testLightFactor, p2pmock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer1Addr, err := swarm.ParseHexAddress("00112233")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acc.Connect(peer1Addr, true)
+
+	debitAction, err := acc.PrepareDebit(context.Background(), peer1Addr, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = debitAction.Apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	debitAction.Cleanup()
+
+	// overpay by 20 so that the peer has a surplus balance
+	err = acc.NotifyPaymentReceived(peer1Addr, big.NewInt(120))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the bounce first consumes the surplus, the remainder is debt again
+	err = acc.NotifyPaymentBounced(peer1Addr, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	surplus, err := acc.SurplusBalance(peer1Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if surplus.Int64() != 0 {
+		t.Fatalf("got surplus balance %d, want %d", surplus, 0)
+	}
+
+	balance, err := acc.Balance(peer1Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != 30 {
+		t.Fatalf("got balance %d, want %d", balance, 30)
+	}
+}
+
 type pricingMock struct {
 	called           bool
 	peer             swarm.Address
@@ -2018,3 +2080,132 @@ func debitAndReceivePayment(t *testing.T, acc *accounting.Accounting, peer1Addr
 	}
 
 }
+
+type announcingPricingMock struct {
+	announced chan paymentCall
+}
+
+func (p *announcingPricingMock) AnnouncePaymentThreshold(ctx context.Context, peer swarm.Address, paymentThreshold *big.Int) error {
+	p.announced <- paymentCall{peer: peer, amount: paymentThreshold}
+	return nil
+}
+
+func TestAccountingSettlementPolicy(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	store := mock.NewStateStore()
+	defer store.Close()
+
+	pricing := &announcingPricingMock{announced: make(chan paymentCall, 2)}
+
+	acc, err := accounting.NewAccounting(testPaymentThreshold, testPaymentTolerance, testPaymentEarly, logger, store, pricing, big.NewInt(testRefreshRate), testLightFactor, p2pmock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies := settlement.NewPolicyStore(store)
+	policies.SetObserver(acc)
+	acc.SetPolicyStore(policies)
+
+	ts := int64(1000)
+	acc.SetTime(ts)
+
+	refreshchan := make(chan paymentCall, 1)
+	paychan := make(chan paymentCall, 1)
+
+	acc.SetRefreshFunc(func(ctx context.Context, peer swarm.Address, amount *big.Int) {
+		acc.NotifyRefreshmentSent(peer, amount, amount, ts*1000, 0, nil)
+		refreshchan <- paymentCall{peer: peer, amount: amount}
+	})
+
+	acc.SetPayFunc(func(ctx context.Context, peer swarm.Address, amount *big.Int) {
+		acc.NotifyPaymentSent(peer, amount, nil)
+		paychan <- paymentCall{peer: peer, amount: amount}
+	})
+
+	peer1Addr, err := swarm.ParseHexAddress("00112233")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	override := new(big.Int).Mul(testPaymentThreshold, big.NewInt(2))
+	err = policies.SetPolicy(peer1Addr, settlement.PeerPolicy{
+		ForcePseudosettle: true,
+		PaymentThreshold:  override,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acc.Connect(peer1Addr, true)
+
+	expectAnnounced := func(want *big.Int) {
+		t.Helper()
+		select {
+		case call := <-pricing.announced:
+			if call.amount.Cmp(want) != 0 {
+				t.Fatalf("announced wrong threshold. got %d wanted %d", call.amount, want)
+			}
+			if !call.peer.Equal(peer1Addr) {
+				t.Fatalf("announced to wrong peer. got %v wanted %v", call.peer, peer1Addr)
+			}
+		case <-time.After(1 * time.Second):
+			t.Fatal("timeout waiting for threshold announcement")
+		}
+	}
+
+	expectAnnounced(override)
+
+	info, err := acc.PeerAccounting()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info[peer1Addr.String()].ThresholdGiven; got.Cmp(override) != 0 {
+		t.Fatalf("got threshold given %d, want %d", got, override)
+	}
+
+	requestPrice := testPaymentThreshold.Uint64()
+
+	for i := 0; i < 2; i++ {
+		creditAction, err := acc.PrepareCredit(context.Background(), peer1Addr, requestPrice, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = creditAction.Apply()
+		if err != nil {
+			t.Fatal(err)
+		}
+		creditAction.Cleanup()
+
+		if i == 0 {
+			select {
+			case <-refreshchan:
+			case <-time.After(1 * time.Second):
+				t.Fatal("timeout waiting for refreshment")
+			}
+		}
+	}
+
+	select {
+	case call := <-paychan:
+		t.Fatalf("unexpected monetary settlement of %d", call.amount)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = policies.DeletePolicy(peer1Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectAnnounced(testPaymentThreshold)
+
+	info, err = acc.PeerAccounting()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info[peer1Addr.String()].ThresholdGiven; got.Cmp(testPaymentThreshold) != 0 {
+		t.Fatalf("got threshold given %d, want %d", got, testPaymentThreshold)
+	}
+}
@@ -28,10 +28,14 @@ import (
 	"github.com/calmw/bee-tron/pkg/accesscontrol"
 	"github.com/calmw/bee-tron/pkg/accounting"
 	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/feeds"
 	"github.com/calmw/bee-tron/pkg/file/pipeline"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/builder"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/compression"
+	enc "github.com/calmw/bee-tron/pkg/file/pipeline/encryption"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/file/splitter"
 	"github.com/calmw/bee-tron/pkg/gsoc"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/log"
@@ -40,16 +44,21 @@ import (
 	"github.com/calmw/bee-tron/pkg/postage"
 	"github.com/calmw/bee-tron/pkg/postage/postagecontract"
 	"github.com/calmw/bee-tron/pkg/pss"
+	"github.com/calmw/bee-tron/pkg/puller"
+	"github.com/calmw/bee-tron/pkg/pusher"
 	"github.com/calmw/bee-tron/pkg/resolver"
 	"github.com/calmw/bee-tron/pkg/resolver/client/ens"
+	"github.com/calmw/bee-tron/pkg/retrieval"
 	"github.com/calmw/bee-tron/pkg/sctx"
 	"github.com/calmw/bee-tron/pkg/settlement"
+	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
 	"github.com/calmw/bee-tron/pkg/settlement/swap"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/erc20"
 	"github.com/calmw/bee-tron/pkg/status"
 	"github.com/calmw/bee-tron/pkg/steward"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storageincentives"
 	"github.com/calmw/bee-tron/pkg/storageincentives/staking"
 	"github.com/calmw/bee-tron/pkg/storer"
@@ -73,11 +82,16 @@ const (
 	SwarmPinHeader                    = "Swarm-Pin"
 	SwarmTagHeader                    = "Swarm-Tag"
 	SwarmEncryptHeader                = "Swarm-Encrypt"
+	SwarmCompressionHeader            = "Swarm-Compression"
+	SwarmEncryptionSchemeHeader       = "Swarm-Encryption-Scheme"
+	SwarmStreamEncryptionKeyHeader    = "Swarm-Stream-Encryption-Key"
 	SwarmIndexDocumentHeader          = "Swarm-Index-Document"
 	SwarmErrorDocumentHeader          = "Swarm-Error-Document"
 	SwarmSocSignatureHeader           = "Swarm-Soc-Signature"
 	SwarmFeedIndexHeader              = "Swarm-Feed-Index"
 	SwarmFeedIndexNextHeader          = "Swarm-Feed-Index-Next"
+	SwarmFeedContentTypeHeader        = "Swarm-Feed-Content-Type"
+	SwarmFeedMetadataHeader           = "Swarm-Feed-Metadata"
 	SwarmLegacyFeedResolve            = "Swarm-Feed-Legacy-Resolve"
 	SwarmOnlyRootChunk                = "Swarm-Only-Root-Chunk"
 	SwarmCollectionHeader             = "Swarm-Collection"
@@ -89,14 +103,18 @@ const (
 	SwarmRedundancyFallbackModeHeader = "Swarm-Redundancy-Fallback-Mode"
 	SwarmChunkRetrievalTimeoutHeader  = "Swarm-Chunk-Retrieval-Timeout"
 	SwarmLookAheadBufferSizeHeader    = "Swarm-Lookahead-Buffer-Size"
+	SwarmReadAheadHeader              = "Swarm-Read-Ahead"
 	SwarmActHeader                    = "Swarm-Act"
 	SwarmActTimestampHeader           = "Swarm-Act-Timestamp"
 	SwarmActPublisherHeader           = "Swarm-Act-Publisher"
 	SwarmActHistoryAddressHeader      = "Swarm-Act-History-Address"
+	SwarmChunkingHeader               = "Swarm-Chunking"
 
 	ImmutableHeader = "Immutable"
 	GasPriceHeader  = "Gas-Price"
 	GasLimitHeader  = "Gas-Limit"
+	GasTipHeader    = "Gas-Tip"
+	NonceHeader     = "Transaction-Nonce"
 	ETagHeader      = "ETag"
 
 	AuthorizationHeader        = "Authorization"
@@ -129,6 +147,7 @@ var (
 	errActDownload                      = errors.New("act download failed")
 	errActUpload                        = errors.New("act upload failed")
 	errActGranteeList                   = errors.New("failed to create or update grantee list")
+	errSchemeRedundancy                 = errors.New("authenticated encryption schemes do not support redundancy")
 
 	batchIdOrStampSig = fmt.Sprintf("Either '%s' or '%s' header must be set in the request", SwarmPostageStampHeader, SwarmPostageBatchIdHeader)
 )
@@ -144,6 +163,10 @@ type Storer interface {
 	storer.RadiusChecker
 	storer.Debugger
 	storer.NeighborhoodStats
+	storer.EvictionController
+	storer.UsageForecaster
+	storer.DedupReporter
+	storer.RadiusOverrider
 }
 
 type PinIntegrity interface {
@@ -196,12 +219,24 @@ type Service struct {
 	stamperStore storage.Store
 	pinIntegrity PinIntegrity
 
+	pinVerifyJobs pinVerifyJobs
+
 	syncStatus func() (bool, error)
 
-	swap        swap.Interface
-	transaction transaction.Service
-	lightNodes  *lightnode.Container
-	blockTime   time.Duration
+	swap                   swap.Interface
+	settlementPolicies     *settlement.PolicyStore
+	swapAddressbook        swap.Addressbook
+	swapDeductions         *swap.DeductionLog
+	pseudosettleAllowances pseudosettle.Allowances
+	tokens                 *transaction.TokenRegistry
+	expiryWatcher          *postage.ExpiryWatcher
+	retrievalSkiplist      retrieval.Skiplist
+	pusher                 pusher.Controller
+	pusherDeadLetters      pusher.DeadLetterQueue
+	syncState              puller.SyncStater
+	transaction            transaction.Service
+	lightNodes             *lightnode.Container
+	blockTime              time.Duration
 
 	statusSem        *semaphore.Weighted
 	postageSem       *semaphore.Weighted
@@ -222,6 +257,8 @@ type Service struct {
 
 	statusService *status.Service
 	isWarmingUp   bool
+
+	migrationProgress *migration.Progress
 }
 
 func (s *Service) SetP2P(p2p p2p.DebugService) {
@@ -245,6 +282,9 @@ func (s *Service) SetRedistributionAgent(redistributionAgent *storageincentives.
 type Options struct {
 	CORSAllowedOrigins []string
 	WsPingPeriod       time.Duration
+	// ReadAhead is the number of the data chunks prefetched ahead of the
+	// sequential reads of the downloads, zero disables the read-ahead.
+	ReadAhead int
 }
 
 type ExtraOptions struct {
@@ -269,6 +309,26 @@ type ExtraOptions struct {
 	SyncStatus      func() (bool, error)
 	NodeStatus      *status.Service
 	PinIntegrity    PinIntegrity
+	// SettlementPolicies is the store of per-peer settlement overrides.
+	SettlementPolicies *settlement.PolicyStore
+	// SwapAddressbook maps peers to their beneficiaries and chequebooks.
+	SwapAddressbook swap.Addressbook
+	// SwapDeductions is the audit log of swap deductions.
+	SwapDeductions *swap.DeductionLog
+	// PseudosettleAllowances manages the time based allowance of peers.
+	PseudosettleAllowances pseudosettle.Allowances
+	// Tokens is the registry of tokens which can be withdrawn from the wallet.
+	Tokens *transaction.TokenRegistry
+	// ExpiryWatcher applies the expiry policies of the owned batches.
+	ExpiryWatcher *postage.ExpiryWatcher
+	// RetrievalSkiplist provides the peers the retrievals avoid.
+	RetrievalSkiplist retrieval.Skiplist
+	// Pusher controls the pusher limits and signals the upload backpressure.
+	Pusher pusher.Controller
+	// PusherDeadLetters manages the chunks which repeatedly failed to be pushed.
+	PusherDeadLetters pusher.DeadLetterQueue
+	// SyncState reports the progress of the syncing from the peers.
+	SyncState puller.SyncStater
 }
 
 func New(
@@ -356,6 +416,16 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	s.accounting = e.Accounting
 	s.chequebook = e.Chequebook
 	s.swap = e.Swap
+	s.settlementPolicies = e.SettlementPolicies
+	s.swapAddressbook = e.SwapAddressbook
+	s.swapDeductions = e.SwapDeductions
+	s.pseudosettleAllowances = e.PseudosettleAllowances
+	s.tokens = e.Tokens
+	s.expiryWatcher = e.ExpiryWatcher
+	s.retrievalSkiplist = e.RetrievalSkiplist
+	s.pusher = e.Pusher
+	s.pusherDeadLetters = e.PusherDeadLetters
+	s.syncState = e.SyncState
 	s.lightNodes = e.LightNodes
 	s.pseudosettle = e.Pseudosettle
 	s.blockTime = e.BlockTime
@@ -550,7 +620,7 @@ func (s *Service) observeUploadSpeed(w http.ResponseWriter, r *http.Request, sta
 }
 
 // gasConfigMiddleware can be used by the APIs that allow block chain transactions to set
-// gas price and gas limit through the HTTP API headers.
+// gas price, gas limit, gas tip and transaction nonce through the HTTP API headers.
 func (s *Service) gasConfigMiddleware(handlerName string) func(h http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -559,6 +629,8 @@ func (s *Service) gasConfigMiddleware(handlerName string) func(h http.Handler) h
 			headers := struct {
 				GasPrice *big.Int `map:"Gas-Price"`
 				GasLimit uint64   `map:"Gas-Limit"`
+				GasTip   *big.Int `map:"Gas-Tip"`
+				Nonce    *uint64  `map:"Transaction-Nonce"`
 			}{}
 			if response := s.mapStructure(r.Header, &headers); response != nil {
 				response("invalid header params", logger, w)
@@ -567,6 +639,8 @@ func (s *Service) gasConfigMiddleware(handlerName string) func(h http.Handler) h
 			ctx := r.Context()
 			ctx = sctx.SetGasPrice(ctx, headers.GasPrice)
 			ctx = sctx.SetGasLimit(ctx, headers.GasLimit)
+			ctx = sctx.SetGasTip(ctx, headers.GasTip)
+			ctx = sctx.SetNonce(ctx, headers.Nonce)
 
 			h.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -578,11 +652,11 @@ func (s *Service) corsHandler(h http.Handler) http.Handler {
 	allowedHeaders := []string{
 		"User-Agent", "Accept", "X-Requested-With", "Access-Control-Request-Headers", "Access-Control-Request-Method", "Accept-Ranges", "Content-Encoding",
 		AuthorizationHeader, AcceptEncodingHeader, ContentTypeHeader, ContentDispositionHeader, RangeHeader, OriginHeader,
-		SwarmTagHeader, SwarmPinHeader, SwarmEncryptHeader, SwarmIndexDocumentHeader, SwarmErrorDocumentHeader, SwarmCollectionHeader,
+		SwarmTagHeader, SwarmPinHeader, SwarmEncryptHeader, SwarmEncryptionSchemeHeader, SwarmStreamEncryptionKeyHeader, SwarmCompressionHeader, SwarmIndexDocumentHeader, SwarmErrorDocumentHeader, SwarmCollectionHeader,
 		SwarmPostageBatchIdHeader, SwarmPostageStampHeader, SwarmDeferredUploadHeader, SwarmRedundancyLevelHeader,
-		SwarmRedundancyStrategyHeader, SwarmRedundancyFallbackModeHeader, SwarmChunkRetrievalTimeoutHeader, SwarmLookAheadBufferSizeHeader,
-		SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmSocSignatureHeader, SwarmOnlyRootChunk, GasPriceHeader, GasLimitHeader, ImmutableHeader,
-		SwarmActHeader, SwarmActTimestampHeader, SwarmActPublisherHeader, SwarmActHistoryAddressHeader,
+		SwarmRedundancyStrategyHeader, SwarmRedundancyFallbackModeHeader, SwarmChunkRetrievalTimeoutHeader, SwarmLookAheadBufferSizeHeader, SwarmReadAheadHeader,
+		SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmSocSignatureHeader, SwarmOnlyRootChunk, GasPriceHeader, GasLimitHeader, GasTipHeader, NonceHeader, ImmutableHeader,
+		SwarmActHeader, SwarmActTimestampHeader, SwarmActPublisherHeader, SwarmActHistoryAddressHeader, SwarmChunkingHeader,
 	}
 	allowedHeadersStr := strings.Join(allowedHeaders, ", ")
 
@@ -727,10 +801,11 @@ func equalASCIIFold(s, t string) bool {
 }
 
 type putterOptions struct {
-	BatchID  []byte
-	TagID    uint64
-	Deferred bool
-	Pin      bool
+	BatchID       []byte
+	TagID         uint64
+	Deferred      bool
+	Pin           bool
+	IndexStrategy postage.IndexStrategy // nil for the default strategy of the stamper
 }
 
 type putterSessionWrapper struct {
@@ -760,7 +835,7 @@ func (p *putterSessionWrapper) Cleanup() error {
 	return errors.Join(p.PutterSession.Cleanup(), p.save())
 }
 
-func (s *Service) getStamper(batchID []byte) (postage.Stamper, func() error, error) {
+func (s *Service) getStamper(batchID []byte, opts ...postage.StamperOption) (postage.Stamper, func() error, error) {
 	exists, err := s.batchStore.Exists(batchID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("batch exists: %w", err)
@@ -775,7 +850,7 @@ func (s *Service) getStamper(batchID []byte) (postage.Stamper, func() error, err
 		return nil, nil, errBatchUnusable
 	}
 
-	return postage.NewStamper(s.stamperStore, issuer, s.signer), save, nil
+	return postage.NewStamper(s.stamperStore, issuer, s.signer, opts...), save, nil
 }
 
 func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (storer.PutterSession, error) {
@@ -783,7 +858,11 @@ func (s *Service) newStamperPutter(ctx context.Context, opts putterOptions) (sto
 		return nil, errUnsupportedDevNodeOperation
 	}
 
-	stamper, save, err := s.getStamper(opts.BatchID)
+	var stamperOpts []postage.StamperOption
+	if opts.IndexStrategy != nil {
+		stamperOpts = append(stamperOpts, postage.WithIndexStrategy(opts.IndexStrategy))
+	}
+	stamper, save, err := s.getStamper(opts.BatchID, stamperOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("get stamper: %w", err)
 	}
@@ -844,6 +923,67 @@ func requestPipelineFn(s storage.Putter, encrypt bool, rLevel redundancy.Level)
 	}
 }
 
+// requestSplitterFn returns a pipelineFunc which splits the data of the given
+// length with the splitter of the mode.
+func requestSplitterFn(s storage.Putter, mode splitter.Mode, length int64) pipelineFunc {
+	return func(ctx context.Context, r io.Reader) (swarm.Address, error) {
+		return splitter.New(s, mode).Split(ctx, io.NopCloser(r), length, false)
+	}
+}
+
+// requestCompressedPipelineFn returns a pipelineFunc which compresses the
+// data, and records the size of the data before the compression.
+func requestCompressedPipelineFn(s storage.Putter, encrypt bool, rLevel redundancy.Level, size *int64) pipelineFunc {
+	return func(ctx context.Context, r io.Reader) (swarm.Address, error) {
+		pipe, err := compression.NewCompressionWriter(builder.NewPipelineBuilder(ctx, s, encrypt, rLevel))
+		if err != nil {
+			return swarm.ZeroAddress, err
+		}
+		cr := &countingReader{r: r}
+		addr, err := builder.FeedPipeline(ctx, pipe, cr)
+		*size = cr.n
+		return addr, err
+	}
+}
+
+// requestStreamEncryptedPipelineFn returns a pipelineFunc which encrypts the
+// data as a stream with the key before it is split into chunks.
+func requestStreamEncryptedPipelineFn(s storage.Putter, encrypt bool, rLevel redundancy.Level, key encryption.Key) pipelineFunc {
+	return func(ctx context.Context, r io.Reader) (swarm.Address, error) {
+		pipe := enc.NewStreamEncryptionWriter(key, builder.NewPipelineBuilder(ctx, s, encrypt, rLevel))
+		return builder.FeedPipeline(ctx, pipe, r)
+	}
+}
+
+// countingReader counts the bytes read from the reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// withEncryptionScheme returns the context with the encryption scheme of the
+// given name for the encrypted uploads, the default scheme if empty. The
+// authenticated schemes are not allowed with redundancy.
+func withEncryptionScheme(ctx context.Context, name string, rLevel redundancy.Level) (context.Context, error) {
+	if name == "" {
+		return ctx, nil
+	}
+	scheme, err := encryption.ParseScheme(name)
+	if err != nil {
+		return nil, err
+	}
+	if scheme != encryption.KeccakCTR && rLevel != redundancy.NONE {
+		return nil, errSchemeRedundancy
+	}
+	return builder.SetEncryptionScheme(ctx, scheme)
+}
+
 func requestPipelineFactory(ctx context.Context, s storage.Putter, encrypt bool, rLevel redundancy.Level) func() pipeline.Interface {
 	return func() pipeline.Interface {
 		return builder.NewPipelineBuilder(ctx, s, encrypt, rLevel)
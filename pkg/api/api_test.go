@@ -41,10 +41,14 @@ import (
 	"github.com/calmw/bee-tron/pkg/postage/postagecontract"
 	contractMock "github.com/calmw/bee-tron/pkg/postage/postagecontract/mock"
 	"github.com/calmw/bee-tron/pkg/pss"
+	"github.com/calmw/bee-tron/pkg/puller"
 	"github.com/calmw/bee-tron/pkg/pusher"
 	"github.com/calmw/bee-tron/pkg/resolver"
 	resolverMock "github.com/calmw/bee-tron/pkg/resolver/mock"
+	"github.com/calmw/bee-tron/pkg/retrieval"
+	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
 	chequebookmock "github.com/calmw/bee-tron/pkg/settlement/swap/chequebook/mock"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/erc20"
 	erc20mock "github.com/calmw/bee-tron/pkg/settlement/swap/erc20/mock"
@@ -55,6 +59,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/steward"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	testingc "github.com/calmw/bee-tron/pkg/storage/testing"
 	"github.com/calmw/bee-tron/pkg/storageincentives"
 	"github.com/calmw/bee-tron/pkg/storageincentives/redistribution"
@@ -108,6 +113,7 @@ type testServerOptions struct {
 	WsHeaders          http.Header
 	DirectUpload       bool
 	Probe              *api.Probe
+	MigrationProgress  *migration.Progress
 
 	Overlay         swarm.Address
 	PublicKey       ecdsa.PublicKey
@@ -125,16 +131,26 @@ type testServerOptions struct {
 	BatchStore postage.Storer
 	SyncStatus func() (bool, error)
 
-	BackendOpts         []backendmock.Option
-	Erc20Opts           []erc20mock.Option
-	BeeMode             api.BeeNodeMode
-	RedistributionAgent *storageincentives.Agent
-	NodeStatus          *status.Service
-	PinIntegrity        api.PinIntegrity
-	WhitelistedAddr     string
-	FullAPIDisabled     bool
-	ChequebookDisabled  bool
-	SwapDisabled        bool
+	BackendOpts            []backendmock.Option
+	Erc20Opts              []erc20mock.Option
+	BeeMode                api.BeeNodeMode
+	RedistributionAgent    *storageincentives.Agent
+	NodeStatus             *status.Service
+	PinIntegrity           api.PinIntegrity
+	SettlementPolicies     *settlement.PolicyStore
+	SwapAddressbook        swap.Addressbook
+	SwapDeductions         *swap.DeductionLog
+	PseudosettleAllowances pseudosettle.Allowances
+	Tokens                 *transaction.TokenRegistry
+	ExpiryWatcher          *postage.ExpiryWatcher
+	RetrievalSkiplist      retrieval.Skiplist
+	Pusher                 pusher.Controller
+	PusherDeadLetters      pusher.DeadLetterQueue
+	SyncState              puller.SyncStater
+	WhitelistedAddr        string
+	FullAPIDisabled        bool
+	ChequebookDisabled     bool
+	SwapDisabled           bool
 }
 
 func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.Conn, string, *chanStorer) {
@@ -184,27 +200,37 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 	backend := backendmock.New(o.BackendOpts...)
 
 	extraOpts := api.ExtraOptions{
-		TopologyDriver:  topologyDriver,
-		Accounting:      acc,
-		Pseudosettle:    recipient,
-		LightNodes:      ln,
-		Swap:            settlement,
-		Chequebook:      chequebook,
-		Pingpong:        o.Pingpong,
-		BlockTime:       o.BlockTime,
-		Storer:          o.Storer,
-		Resolver:        o.Resolver,
-		Pss:             o.Pss,
-		Gsoc:            o.Gsoc,
-		FeedFactory:     o.Feeds,
-		Post:            o.Post,
-		AccessControl:   o.AccessControl,
-		PostageContract: o.PostageContract,
-		Steward:         o.Steward,
-		SyncStatus:      o.SyncStatus,
-		Staking:         o.StakingContract,
-		NodeStatus:      o.NodeStatus,
-		PinIntegrity:    o.PinIntegrity,
+		TopologyDriver:         topologyDriver,
+		Accounting:             acc,
+		Pseudosettle:           recipient,
+		LightNodes:             ln,
+		Swap:                   settlement,
+		Chequebook:             chequebook,
+		Pingpong:               o.Pingpong,
+		BlockTime:              o.BlockTime,
+		Storer:                 o.Storer,
+		Resolver:               o.Resolver,
+		Pss:                    o.Pss,
+		Gsoc:                   o.Gsoc,
+		FeedFactory:            o.Feeds,
+		Post:                   o.Post,
+		AccessControl:          o.AccessControl,
+		PostageContract:        o.PostageContract,
+		Steward:                o.Steward,
+		SyncStatus:             o.SyncStatus,
+		Staking:                o.StakingContract,
+		NodeStatus:             o.NodeStatus,
+		PinIntegrity:           o.PinIntegrity,
+		SettlementPolicies:     o.SettlementPolicies,
+		SwapAddressbook:        o.SwapAddressbook,
+		SwapDeductions:         o.SwapDeductions,
+		PseudosettleAllowances: o.PseudosettleAllowances,
+		Tokens:                 o.Tokens,
+		ExpiryWatcher:          o.ExpiryWatcher,
+		RetrievalSkiplist:      o.RetrievalSkiplist,
+		Pusher:                 o.Pusher,
+		PusherDeadLetters:      o.PusherDeadLetters,
+		SyncState:              o.SyncState,
 	}
 
 	// By default bee mode is set to full mode.
@@ -225,6 +251,7 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 
 	s.SetSwarmAddress(&o.Overlay)
 	s.SetProbe(o.Probe)
+	s.SetMigrationProgress(o.MigrationProgress)
 
 	noOpTracer, tracerCloser, _ := tracing.NewTracer(&tracing.Options{
 		Enabled: false,
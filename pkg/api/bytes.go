@@ -15,9 +15,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/accesscontrol"
 	"github.com/calmw/bee-tron/pkg/cac"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/file/splitter"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/postage"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storer"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/calmw/bee-tron/pkg/tracing"
 	"github.com/gorilla/mux"
@@ -40,9 +42,12 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
 		Encrypt        bool             `map:"Swarm-Encrypt"`
+		EncScheme      string           `map:"Swarm-Encryption-Scheme" validate:"omitempty,oneof=keccak-ctr aes-gcm chacha20-poly1305"`
+		StreamKey      []byte           `map:"Swarm-Stream-Encryption-Key" validate:"omitempty,len=32"`
 		RLevel         redundancy.Level `map:"Swarm-Redundancy-Level"`
 		Act            bool             `map:"Swarm-Act"`
 		HistoryAddress swarm.Address    `map:"Swarm-Act-History-Address"`
+		Chunking       string           `map:"Swarm-Chunking" validate:"omitempty,oneof=fixed cdc"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -55,6 +60,30 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		deferred = defaultUploadMethod(headers.Deferred)
 	)
 
+	chunking, err := splitter.ParseMode(headers.Chunking)
+	if err != nil {
+		logger.Debug("invalid chunking mode", "mode", headers.Chunking, "error", err)
+		logger.Error(nil, "invalid chunking mode")
+		jsonhttp.BadRequest(w, "invalid chunking mode")
+		return
+	}
+	// the content-defined chunking splits the known length of data into
+	// the unencrypted chunks without redundancy
+	if chunking == splitter.ModeCDC && (r.ContentLength <= 0 || headers.Encrypt || len(headers.StreamKey) > 0 || headers.RLevel != redundancy.NONE) {
+		logger.Debug("unsupported content-defined chunking upload", "content_length", r.ContentLength, "encrypt", headers.Encrypt, "redundancy_level", headers.RLevel)
+		logger.Error(nil, "unsupported content-defined chunking upload")
+		jsonhttp.BadRequest(w, "content-defined chunking requires the content length and no encryption or redundancy")
+		return
+	}
+
+	ctx, err = withEncryptionScheme(ctx, headers.EncScheme, headers.RLevel)
+	if err != nil {
+		logger.Debug("invalid encryption scheme", "scheme", headers.EncScheme, "error", err)
+		logger.Error(nil, "invalid encryption scheme")
+		jsonhttp.BadRequest(w, "invalid encryption scheme")
+		return
+	}
+
 	if deferred || headers.Pin {
 		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
 		if err != nil {
@@ -106,6 +135,12 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	p := requestPipelineFn(putter, headers.Encrypt, headers.RLevel)
+	if len(headers.StreamKey) > 0 {
+		p = requestStreamEncryptedPipelineFn(putter, headers.Encrypt, headers.RLevel, headers.StreamKey)
+	}
+	if chunking == splitter.ModeCDC {
+		p = requestSplitterFn(putter, chunking, r.ContentLength)
+	}
 	reference, err := p(ctx, r.Body)
 	if err != nil {
 		logger.Debug("split write all failed", "error", err)
@@ -113,6 +148,8 @@ func (s *Service) bytesUploadHandler(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(ow, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.InsufficientStorage(ow, "pinning quota exceeded")
 		default:
 			jsonhttp.InternalServerError(ow, "split write all failed")
 		}
@@ -179,6 +216,14 @@ func (s *Service) bytesGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	headers := struct {
+		StreamKey []byte `map:"Swarm-Stream-Encryption-Key" validate:"omitempty,len=32"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
 	address := paths.Address
 	if v := getAddressFromContext(r.Context()); !v.IsZero() {
 		address = v
@@ -188,7 +233,7 @@ func (s *Service) bytesGetHandler(w http.ResponseWriter, r *http.Request) {
 		ContentTypeHeader: {"application/octet-stream"},
 	}
 
-	s.downloadHandler(logger, w, r, address, additionalHeaders, true, false, nil)
+	s.downloadHandler(logger, w, r, address, additionalHeaders, true, false, nil, downloadOptions{streamKey: headers.StreamKey})
 }
 
 func (s *Service) bytesHeadHandler(w http.ResponseWriter, r *http.Request) {
@@ -10,9 +10,11 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
 	"github.com/calmw/bee-tron/pkg/log"
@@ -109,6 +111,71 @@ func TestBytes(t *testing.T) {
 		}
 	})
 
+	t.Run("upload-with-content-defined-chunking", func(t *testing.T) {
+		var resp api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmChunkingHeader, "cdc"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		// the segment index is downloaded as the data with the cdc mode
+		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmChunkingHeader, "cdc"),
+			jsonhttptest.WithExpectedContentLength(len(content)),
+			jsonhttptest.WithExpectedResponse(content),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmChunkingHeader, "cdc"),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "content-defined chunking requires the content length and no encryption or redundancy",
+			}),
+		)
+	})
+
+	t.Run("upload-with-stream-encryption", func(t *testing.T) {
+		key := strings.Repeat("ab", encryption.KeyLength)
+
+		var resp api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithExpectedContentLength(len(content)),
+			jsonhttptest.WithExpectedResponse(content),
+		)
+
+		// the stored data is the encrypted stream
+		var encrypted []byte
+		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithPutResponseBody(&encrypted),
+		)
+		if bytes.Contains(encrypted, content[:1000]) {
+			t.Fatal("stored data is not encrypted")
+		}
+
+		jsonhttptest.Request(t, client, http.MethodPost, resource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, "abcd"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(content)),
+		)
+	})
+
 	t.Run("download", func(t *testing.T) {
 		jsonhttptest.Request(t, client, http.MethodGet, resource+"/"+expHash, http.StatusOK,
 			jsonhttptest.WithExpectedContentLength(len(content)),
@@ -21,10 +21,12 @@ import (
 	olog "github.com/opentracing/opentracing-go/log"
 
 	"github.com/calmw/bee-tron/pkg/accesscontrol"
+	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/feeds"
 	"github.com/calmw/bee-tron/pkg/file"
 	"github.com/calmw/bee-tron/pkg/file/joiner"
 	"github.com/calmw/bee-tron/pkg/file/loadsave"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/compression"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
 	"github.com/calmw/bee-tron/pkg/file/redundancy/getter"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
@@ -71,6 +73,9 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 		Pin            bool             `map:"Swarm-Pin"`
 		Deferred       *bool            `map:"Swarm-Deferred-Upload"`
 		Encrypt        bool             `map:"Swarm-Encrypt"`
+		EncScheme      string           `map:"Swarm-Encryption-Scheme" validate:"omitempty,oneof=keccak-ctr aes-gcm chacha20-poly1305"`
+		Compression    string           `map:"Swarm-Compression" validate:"omitempty,oneof=zstd"`
+		StreamKey      []byte           `map:"Swarm-Stream-Encryption-Key" validate:"omitempty,len=32"`
 		IsDir          bool             `map:"Swarm-Collection"`
 		RLevel         redundancy.Level `map:"Swarm-Redundancy-Level"`
 		Act            bool             `map:"Swarm-Act"`
@@ -80,6 +85,24 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 		response("invalid header params", logger, w)
 		return
 	}
+	if headers.Compression != "" && (headers.IsDir || headers.ContentType == multiPartFormData) {
+		logger.Debug("compression of collections is not supported")
+		logger.Error(nil, "compression of collections is not supported")
+		jsonhttp.BadRequest(w, "compression of collections is not supported")
+		return
+	}
+	if len(headers.StreamKey) > 0 && (headers.IsDir || headers.ContentType == multiPartFormData) {
+		logger.Debug("stream encryption of collections is not supported")
+		logger.Error(nil, "stream encryption of collections is not supported")
+		jsonhttp.BadRequest(w, "stream encryption of collections is not supported")
+		return
+	}
+	if len(headers.StreamKey) > 0 && headers.Compression != "" {
+		logger.Debug("compression of encrypted streams is not supported")
+		logger.Error(nil, "compression of encrypted streams is not supported")
+		jsonhttp.BadRequest(w, "compression of encrypted streams is not supported")
+		return
+	}
 
 	var (
 		tag      uint64
@@ -87,6 +110,14 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 		deferred = defaultUploadMethod(headers.Deferred)
 	)
 
+	ctx, err = withEncryptionScheme(ctx, headers.EncScheme, headers.RLevel)
+	if err != nil {
+		logger.Debug("invalid encryption scheme", "scheme", headers.EncScheme, "error", err)
+		logger.Error(nil, "invalid encryption scheme")
+		jsonhttp.BadRequest(w, "invalid encryption scheme")
+		return
+	}
+
 	defer s.observeUploadSpeed(w, r, time.Now(), "bzz", deferred)
 
 	if deferred || headers.Pin {
@@ -141,7 +172,7 @@ func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 		s.dirUploadHandler(ctx, logger, span, ow, r, putter, r.Header.Get(ContentTypeHeader), headers.Encrypt, tag, headers.RLevel, headers.Act, headers.HistoryAddress)
 		return
 	}
-	s.fileUploadHandler(ctx, logger, span, ow, r, putter, headers.Encrypt, tag, headers.RLevel, headers.Act, headers.HistoryAddress)
+	s.fileUploadHandler(ctx, logger, span, ow, r, putter, headers.Encrypt, headers.Compression, headers.StreamKey, tag, headers.RLevel, headers.Act, headers.HistoryAddress)
 }
 
 // bzzUploadResponse is returned when an HTTP request to upload a file is successful
@@ -159,6 +190,8 @@ func (s *Service) fileUploadHandler(
 	r *http.Request,
 	putter storer.PutterSession,
 	encrypt bool,
+	compress string,
+	streamKey encryption.Key,
 	tagID uint64,
 	rLevel redundancy.Level,
 	act bool,
@@ -172,7 +205,22 @@ func (s *Service) fileUploadHandler(
 		return
 	}
 
+	var size int64
 	p := requestPipelineFn(putter, encrypt, rLevel)
+	switch {
+	case compress != "":
+		p = requestCompressedPipelineFn(putter, encrypt, rLevel, &size)
+	case streamKey != nil:
+		// the key of the file is derived from its name, which is
+		// therefore known before the upload
+		if queries.FileName == "" {
+			logger.Debug("encrypted stream without name")
+			logger.Error(nil, "encrypted stream without name")
+			jsonhttp.BadRequest(w, "encrypted streams require a name")
+			return
+		}
+		p = requestStreamEncryptedPipelineFn(putter, encrypt, rLevel, encryption.DeriveFileKey(streamKey, queries.FileName))
+	}
 
 	// first store the file and get its reference
 	fr, err := p(ctx, r.Body)
@@ -182,6 +230,8 @@ func (s *Service) fileUploadHandler(
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.InsufficientStorage(w, "pinning quota exceeded")
 		default:
 			jsonhttp.InternalServerError(w, errFileStore)
 		}
@@ -243,6 +293,13 @@ func (s *Service) fileUploadHandler(
 		manifest.EntryMetadataContentTypeKey: r.Header.Get(ContentTypeHeader), // Content-Type has already been validated.
 		manifest.EntryMetadataFilenameKey:    queries.FileName,
 	}
+	if compress != "" {
+		fileMtdt[manifest.EntryMetadataCompressionKey] = compress
+		fileMtdt[manifest.EntryMetadataDecompressedSizeKey] = strconv.FormatInt(size, 10)
+	}
+	if streamKey != nil {
+		fileMtdt[manifest.EntryMetadataStreamEncryptedKey] = "true"
+	}
 
 	err = m.Add(ctx, queries.FileName, manifest.NewEntry(fr, fileMtdt))
 	if err != nil {
@@ -440,9 +497,16 @@ FETCH:
 				jsonhttp.InternalServerError(w, "mapStructure feed update")
 				return
 			}
-			address = wc.Address()
-			// modify ls and init with non-existing wrapped chunk
-			ls = loadsave.NewReadonlyWithRootCh(s.storer.Download(cache), s.storer.Cache(), wc, rLevel)
+			payload, err := feeds.ParsePayload(wc)
+			if err == nil {
+				// the structured payload references the content
+				address = payload.Reference
+				ls = loadsave.NewReadonly(s.storer.Download(cache), s.storer.Cache(), rLevel)
+			} else {
+				address = wc.Address()
+				// modify ls and init with non-existing wrapped chunk
+				ls = loadsave.NewReadonlyWithRootCh(s.storer.Download(cache), s.storer.Cache(), wc, rLevel)
+			}
 
 			feedDereferenced = true
 			curBytes, err := cur.MarshalBinary()
@@ -459,6 +523,13 @@ FETCH:
 			// since different parts of handlers might be overriding others' values
 			// resulting in inconsistent headers in the response.
 			w.Header().Set(AccessControlExposeHeaders, SwarmFeedIndexHeader)
+			if payload != nil {
+				for name, values := range feedPayloadHeaders(payload) {
+					for _, value := range values {
+						w.Header().Add(name, value)
+					}
+				}
+			}
 			goto FETCH
 		}
 	}
@@ -564,18 +635,72 @@ func (s *Service) serveManifestEntry(
 		additionalHeaders[ContentTypeHeader] = []string{mimeType}
 	}
 
-	s.downloadHandler(logger, w, r, manifestEntry.Reference(), additionalHeaders, etag, headersOnly, nil)
+	var opts downloadOptions
+	rLevel, err := parseRedundancyLevel(mtdt[manifest.EntryMetadataRedundancyLevelKey])
+	if err != nil {
+		logger.Debug("invalid redundancy level", "error", err)
+		logger.Error(nil, "invalid redundancy level")
+		jsonhttp.InternalServerError(w, "invalid redundancy level")
+		return
+	}
+	opts.rLevel = rLevel
+
+	if alg, ok := mtdt[manifest.EntryMetadataCompressionKey]; ok {
+		size, err := strconv.ParseInt(mtdt[manifest.EntryMetadataDecompressedSizeKey], 10, 64)
+		if alg != compression.Zstd || err != nil || size < 0 {
+			logger.Debug("unsupported compression", "compression", alg, "size", mtdt[manifest.EntryMetadataDecompressedSizeKey])
+			logger.Error(nil, "unsupported compression")
+			jsonhttp.InternalServerError(w, "unsupported compression")
+			return
+		}
+		opts.decompressedSize = &size
+	}
+
+	if _, ok := mtdt[manifest.EntryMetadataStreamEncryptedKey]; ok {
+		headers := struct {
+			StreamKey []byte `map:"Swarm-Stream-Encryption-Key" validate:"omitempty,len=32"`
+		}{}
+		if response := s.mapStructure(r.Header, &headers); response != nil {
+			response("invalid header params", logger, w)
+			return
+		}
+		if len(headers.StreamKey) == 0 {
+			logger.Debug("missing stream encryption key")
+			logger.Error(nil, "missing stream encryption key")
+			jsonhttp.BadRequest(w, "missing stream encryption key")
+			return
+		}
+		opts.streamKey = encryption.DeriveFileKey(headers.StreamKey, mtdt[manifest.EntryMetadataFilenameKey])
+	}
+
+	s.downloadHandler(logger, w, r, manifestEntry.Reference(), additionalHeaders, etag, headersOnly, nil, opts)
+}
+
+// downloadOptions are the options of a download which are set by the
+// metadata of a manifest entry.
+type downloadOptions struct {
+	// rLevel is the redundancy level of the file, which takes precedence
+	// over the level of the request.
+	rLevel *redundancy.Level
+	// decompressedSize is set if the file is zstd compressed, in which
+	// case it is served decompressed.
+	decompressedSize *int64
+	// streamKey is set if the file is an encrypted stream, in which case it
+	// is served decrypted with the key.
+	streamKey encryption.Key
 }
 
-// downloadHandler contains common logic for downloading Swarm file from API
-func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *http.Request, reference swarm.Address, additionalHeaders http.Header, etag, headersOnly bool, rootCh swarm.Chunk) {
+// downloadHandler contains common logic for downloading Swarm file from API.
+func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *http.Request, reference swarm.Address, additionalHeaders http.Header, etag, headersOnly bool, rootCh swarm.Chunk, opts downloadOptions) {
 	headers := struct {
 		Strategy              *getter.Strategy  `map:"Swarm-Redundancy-Strategy"`
 		RLevel                *redundancy.Level `map:"Swarm-Redundancy-Level"`
 		FallbackMode          *bool             `map:"Swarm-Redundancy-Fallback-Mode"`
 		ChunkRetrievalTimeout *string           `map:"Swarm-Chunk-Retrieval-Timeout"`
 		LookaheadBufferSize   *int              `map:"Swarm-Lookahead-Buffer-Size"`
+		ReadAhead             *int              `map:"Swarm-Read-Ahead"`
 		Cache                 *bool             `map:"Swarm-Cache"`
+		Chunking              string            `map:"Swarm-Chunking" validate:"omitempty,oneof=fixed cdc"`
 	}{}
 
 	if response := s.mapStructure(r.Header, &headers); response != nil {
@@ -594,18 +719,29 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 		jsonhttp.BadRequest(w, "could not parse headers")
 		return
 	}
+	readAhead := s.ReadAhead
+	if headers.ReadAhead != nil {
+		readAhead = *headers.ReadAhead
+	}
+	ctx = joiner.SetReadAhead(ctx, readAhead)
 	rLevel := redundancy.DefaultLevel
 	if headers.RLevel != nil {
 		rLevel = *headers.RLevel
 	}
+	if opts.rLevel != nil {
+		rLevel = *opts.rLevel
+	}
 
 	var (
 		reader file.Joiner
 		l      int64
 	)
-	if rootCh != nil {
+	switch {
+	case headers.Chunking == "cdc":
+		reader, l, err = joiner.NewSegmentJoiner(ctx, s.storer.Download(cache), s.storer.Cache(), reference)
+	case rootCh != nil:
 		reader, l, err = joiner.NewJoiner(ctx, s.storer.Download(cache), s.storer.Cache(), reference, rootCh)
-	} else {
+	default:
 		reader, l, err = joiner.New(ctx, s.storer.Download(cache), s.storer.Cache(), reference, rLevel)
 	}
 	if err != nil {
@@ -630,6 +766,20 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	if etag {
 		w.Header().Set(ETagHeader, fmt.Sprintf("%q", reference))
 	}
+	if opts.decompressedSize != nil {
+		l = *opts.decompressedSize
+	}
+	var dr *joiner.DecryptingReader
+	if opts.streamKey != nil {
+		dr, err = joiner.NewDecryptingReader(reader, opts.streamKey, l)
+		if err != nil {
+			logger.Debug("api download: invalid encrypted stream", "address", reference, "error", err)
+			logger.Error(nil, "api download: invalid encrypted stream")
+			jsonhttp.BadRequest(w, "invalid encrypted stream")
+			return
+		}
+		l = dr.Size()
+	}
 	w.Header().Set(ContentLengthHeader, strconv.FormatInt(l, 10))
 	w.Header().Add(AccessControlExposeHeaders, ContentDispositionHeader)
 
@@ -638,6 +788,24 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 		return
 	}
 
+	if opts.decompressedSize != nil {
+		dr, err := joiner.NewDecompressingReader(reader, l)
+		if err != nil {
+			logger.Debug("api download: decompression failed", "address", reference, "error", err)
+			logger.Error(nil, "api download: decompression failed")
+			jsonhttp.InternalServerError(w, "decompression failed")
+			return
+		}
+		defer dr.Close()
+		http.ServeContent(w, r, "", time.Now(), dr)
+		return
+	}
+
+	if dr != nil {
+		http.ServeContent(w, r, "", time.Now(), dr)
+		return
+	}
+
 	bufSize := lookaheadBufferSize(l)
 	if headers.LookaheadBufferSize != nil {
 		bufSize = *(headers.LookaheadBufferSize)
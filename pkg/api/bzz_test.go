@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/file/loadsave"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
@@ -399,6 +400,68 @@ func TestBzzFiles(t *testing.T) {
 		)
 	})
 
+	t.Run("encrypt-decrypt range", func(t *testing.T) {
+		data := bytes.Repeat([]byte("encrypted content "), 10000)
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name=content.txt", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "True"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusPartialContent,
+			jsonhttptest.WithRequestHeader(api.RangeHeader, "bytes=150001-170000"),
+			jsonhttptest.WithExpectedResponse(data[150001:170001]),
+		)
+	})
+
+	t.Run("encryption scheme", func(t *testing.T) {
+		data := bytes.Repeat([]byte("authenticated content "), 1000)
+
+		for _, scheme := range []string{"aes-gcm", "chacha20-poly1305"} {
+			var resp api.BzzUploadResponse
+			jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name=content.txt", http.StatusCreated,
+				jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+				jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+				jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+				jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "True"),
+				jsonhttptest.WithRequestHeader(api.SwarmEncryptionSchemeHeader, scheme),
+				jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+				jsonhttptest.WithUnmarshalJSONResponse(&resp),
+			)
+			if got := len(resp.Reference.Bytes()); got != encryption.SchemeReferenceSize {
+				t.Fatalf("got reference length %d, want %d", got, encryption.SchemeReferenceSize)
+			}
+
+			jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusOK,
+				jsonhttptest.WithExpectedResponse(data),
+			)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name=content.txt", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "True"),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptionSchemeHeader, "aes-gcm"),
+			jsonhttptest.WithRequestHeader(api.SwarmRedundancyLevelHeader, "2"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name=content.txt", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "True"),
+			jsonhttptest.WithRequestHeader(api.SwarmEncryptionSchemeHeader, "aes-ctr"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+		)
+	})
+
 	t.Run("redundancy", func(t *testing.T) {
 		fileName := "my-pictures.jpeg"
 
@@ -421,6 +484,99 @@ func TestBzzFiles(t *testing.T) {
 		)
 	})
 
+	t.Run("compression", func(t *testing.T) {
+		fileName := "report.txt"
+		data := bytes.Repeat([]byte("this is a compressible text "), 4096)
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name="+fileName, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.SwarmCompressionHeader, "zstd"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusOK,
+			jsonhttptest.WithExpectedContentLength(len(data)),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithExpectedResponse(data),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusPartialContent,
+			jsonhttptest.WithRequestHeader(api.RangeHeader, "bytes=50000-59999"),
+			jsonhttptest.WithExpectedResponse(data[50000:60000]),
+		)
+
+		// the collections are not compressed
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmCompressionHeader, "zstd"),
+			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "compression of collections is not supported",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+
+	t.Run("stream encryption", func(t *testing.T) {
+		fileName := "secret.txt"
+		key := strings.Repeat("ab", encryption.KeyLength)
+		data := bytes.Repeat([]byte("this is a secret text "), 1024)
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource+"?name="+fileName, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithExpectedContentLength(len(data)),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithExpectedResponse(data),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, fileDownloadResource(resp.Reference.String()), http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "missing stream encryption key",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+
+		// the key of the file is derived from its name
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, "text/plain"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "encrypted streams require a name",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, fileUploadResource, http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmStreamEncryptionKeyHeader, key),
+			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+			jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "stream encryption of collections is not supported",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+
 	t.Run("filter out filename path", func(t *testing.T) {
 		fileName := "my-pictures.jpeg"
 		fileNameWithPath := "../../" + fileName
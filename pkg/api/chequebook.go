@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/gorilla/mux"
 )
 
@@ -248,6 +249,19 @@ func (s *Service) swapCashoutHandler(w http.ResponseWriter, r *http.Request) {
 		jsonhttp.MethodNotAllowed(w, err)
 		return
 	}
+	if errors.Is(err, chequebook.ErrLegacyCheque) {
+		logger.Debug("cash cheque failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "cash cheque failed", "peer_address", paths.Peer)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	var revertErr *transaction.RevertError
+	if errors.As(err, &revertErr) {
+		logger.Debug("cash cheque simulation reverted", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "cash cheque simulation reverted", "peer_address", paths.Peer)
+		jsonhttp.BadRequest(w, revertErr.Error())
+		return
+	}
 	if err != nil {
 		logger.Debug("cash cheque failed", "peer_address", paths.Peer, "error", err)
 		logger.Error(nil, "cash cheque failed", "peer_address", paths.Peer)
@@ -0,0 +1,48 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+)
+
+type dedupStatsResponse struct {
+	Chunks             uint64            `json:"chunks"`
+	SharedChunks       uint64            `json:"sharedChunks"`
+	References         uint64            `json:"references"`
+	PhysicalBytes      uint64            `json:"physicalBytes"`
+	LogicalBytes       uint64            `json:"logicalBytes"`
+	Ratio              float64           `json:"ratio"`
+	ReferencesBy       map[string]uint64 `json:"referencesBy"`
+	ReferenceHistogram map[uint32]uint64 `json:"referenceHistogram"`
+}
+
+// dedupStatsHandler returns how often the stored chunks are referenced by
+// the uploads, the pins, the cache and the reserve, and how many bytes the
+// deduplication saves.
+func (s *Service) dedupStatsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_dedup").Build()
+
+	stat, err := s.storer.DedupStats(r.Context())
+	if err != nil {
+		logger.Debug("dedup stats failed", "error", err)
+		logger.Error(nil, "dedup stats failed")
+		jsonhttp.InternalServerError(w, "dedup stats failed")
+		return
+	}
+
+	jsonhttp.OK(w, dedupStatsResponse{
+		Chunks:             stat.Chunks,
+		SharedChunks:       stat.SharedChunks,
+		References:         stat.References,
+		PhysicalBytes:      stat.PhysicalBytes,
+		LogicalBytes:       stat.LogicalBytes,
+		Ratio:              stat.Ratio(),
+		ReferencesBy:       stat.ReferencesBy,
+		ReferenceHistogram: stat.ReferenceHistogram,
+	})
+}
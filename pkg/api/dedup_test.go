@@ -0,0 +1,50 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/storer"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+)
+
+func TestDedupStats(t *testing.T) {
+	t.Parallel()
+
+	stat := storer.DedupStat{
+		Chunks:        4,
+		SharedChunks:  2,
+		References:    6,
+		PhysicalBytes: 400,
+		LogicalBytes:  600,
+		ReferencesBy: map[string]uint64{
+			storer.UploadReferences:  2,
+			storer.PinningReferences: 2,
+			storer.CacheReferences:   2,
+		},
+		ReferenceHistogram: map[uint32]uint64{1: 2, 2: 2},
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithDedupStat(stat),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/dedup", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.DedupStatsResponse{
+			Chunks:             4,
+			SharedChunks:       2,
+			References:         6,
+			PhysicalBytes:      400,
+			LogicalBytes:       600,
+			Ratio:              1.5,
+			ReferencesBy:       stat.ReferencesBy,
+			ReferenceHistogram: stat.ReferenceHistogram,
+		}),
+	)
+}
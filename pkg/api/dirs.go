@@ -34,7 +34,15 @@ import (
 	olog "github.com/opentracing/opentracing-go/log"
 )
 
-var errEmptyDir = errors.New("no files in root directory")
+// SwarmRedundancyLevelPAXRecord is the PAX record of the files of a tar
+// archive which sets their redundancy level, as the Swarm-Redundancy-Level
+// header of the parts of a multipart upload does.
+const SwarmRedundancyLevelPAXRecord = "SWARM.redundancy-level"
+
+var (
+	errEmptyDir               = errors.New("no files in root directory")
+	errInvalidRedundancyLevel = errors.New("invalid redundancy level")
+)
 
 // dirUploadHandler uploads a directory supplied as a tar in an HTTP request
 func (s *Service) dirUploadHandler(
@@ -90,8 +98,12 @@ func (s *Service) dirUploadHandler(
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.InsufficientStorage(w, "pinning quota exceeded")
 		case errors.Is(err, errEmptyDir):
 			jsonhttp.BadRequest(w, errEmptyDir)
+		case errors.Is(err, errInvalidRedundancyLevel):
+			jsonhttp.BadRequest(w, errInvalidRedundancyLevel)
 		case errors.Is(err, tar.ErrHeader):
 			jsonhttp.BadRequest(w, "invalid filename in tar archive")
 		default:
@@ -147,6 +159,7 @@ func (s *Service) dirUploadHandler(
 
 // storeDir stores all files recursively contained in the directory given as a tar/multipart
 // it returns the hash for the uploaded manifest corresponding to the uploaded dir
+// the files with their own redundancy level record it in the metadata of their entries
 func storeDir(
 	ctx context.Context,
 	encrypt bool,
@@ -161,7 +174,6 @@ func storeDir(
 	logger := tracing.NewLoggerWithTraceID(ctx, log)
 	loggerV1 := logger.V(1).Build()
 
-	p := requestPipelineFn(putter, encrypt, rLevel)
 	ls := loadsave.New(getter, putter, requestPipelineFactory(ctx, putter, encrypt, rLevel), rLevel)
 
 	dirManifest, err := manifest.NewDefaultManifest(ls, encrypt)
@@ -184,7 +196,12 @@ func storeDir(
 			return swarm.ZeroAddress, fmt.Errorf("read dir stream: %w", err)
 		}
 
-		fileReference, err := p(ctx, fileInfo.Reader)
+		fileLevel := rLevel
+		if fileInfo.RLevel != nil {
+			fileLevel = *fileInfo.RLevel
+		}
+
+		fileReference, err := requestPipelineFn(putter, encrypt, fileLevel)(ctx, fileInfo.Reader)
 		if err != nil {
 			return swarm.ZeroAddress, fmt.Errorf("store dir file: %w", err)
 		}
@@ -194,6 +211,9 @@ func storeDir(
 			manifest.EntryMetadataContentTypeKey: fileInfo.ContentType,
 			manifest.EntryMetadataFilenameKey:    fileInfo.Name,
 		}
+		if fileLevel != rLevel {
+			fileMtdt[manifest.EntryMetadataRedundancyLevelKey] = strconv.Itoa(int(fileLevel))
+		}
 		// add file entry to dir manifest
 		err = dirManifest.Add(ctx, fileInfo.Path, manifest.NewEntry(fileReference, fileMtdt))
 		if err != nil {
@@ -239,6 +259,7 @@ type FileInfo struct {
 	Name        string
 	ContentType string
 	Size        int64
+	RLevel      *redundancy.Level // overrides the redundancy level of the upload if set
 	Reader      io.Reader
 }
 
@@ -277,11 +298,17 @@ func (t *tarReader) Next() (*FileInfo, error) {
 			continue
 		}
 
+		rLevel, err := parseRedundancyLevel(fileHeader.PAXRecords[SwarmRedundancyLevelPAXRecord])
+		if err != nil {
+			return nil, err
+		}
+
 		return &FileInfo{
 			Path:        filePath,
 			Name:        fileName,
 			ContentType: contentType,
 			Size:        fileSize,
+			RLevel:      rLevel,
 			Reader:      t.r,
 		}, nil
 	}
@@ -312,11 +339,31 @@ func (m *multipartReader) Next() (*FileInfo, error) {
 
 	fileSize, _ := strconv.ParseInt(contentLength, 10, 64)
 
+	rLevel, err := parseRedundancyLevel(part.Header.Get(SwarmRedundancyLevelHeader))
+	if err != nil {
+		return nil, err
+	}
+
 	return &FileInfo{
 		Path:        filePath,
 		Name:        fileName,
 		ContentType: contentType,
 		Size:        fileSize,
+		RLevel:      rLevel,
 		Reader:      part,
 	}, nil
 }
+
+// parseRedundancyLevel parses the redundancy level of a file, which is
+// nil if the value is empty.
+func parseRedundancyLevel(v string) (*redundancy.Level, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 8)
+	if err != nil || redundancy.Level(n) > redundancy.PARANOID {
+		return nil, fmt.Errorf("%w: %q", errInvalidRedundancyLevel, v)
+	}
+	rLevel := redundancy.Level(n)
+	return &rLevel, nil
+}
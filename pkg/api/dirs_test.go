@@ -526,6 +526,124 @@ func TestDirsEmtpyDir(t *testing.T) {
 	)
 }
 
+func TestDirsRedundancyLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx             = context.Background()
+		storer          = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storer,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		files = []f{
+			{
+				data: []byte("upload level"),
+				name: "upload.txt",
+				header: http.Header{
+					api.ContentTypeHeader: {"text/plain"},
+				},
+			},
+			{
+				data: []byte("own level"),
+				name: "own.txt",
+				header: http.Header{
+					api.ContentTypeHeader:          {"text/plain"},
+					api.SwarmRedundancyLevelHeader: {"3"},
+				},
+			},
+		}
+	)
+
+	upload := func(t *testing.T, body io.Reader, contentType string, status int, opts ...jsonhttptest.Option) {
+		t.Helper()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/bzz", status,
+			append(opts,
+				jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+				jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+				jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
+				jsonhttptest.WithRequestHeader(api.SwarmRedundancyLevelHeader, "1"),
+				jsonhttptest.WithRequestHeader(api.ContentTypeHeader, contentType),
+				jsonhttptest.WithRequestBody(body),
+			)...,
+		)
+	}
+
+	for _, tc := range []struct {
+		name string
+		body func(t *testing.T) (io.Reader, string)
+	}{
+		{
+			name: "tar",
+			body: func(t *testing.T) (io.Reader, string) {
+				t.Helper()
+
+				return tarFiles(t, files), api.ContentTypeTar
+			},
+		},
+		{
+			name: "multipart",
+			body: func(t *testing.T) (io.Reader, string) {
+				t.Helper()
+
+				buf, boundary := multipartFiles(t, files)
+				return buf, fmt.Sprintf("multipart/form-data; boundary=%q", boundary)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp api.BzzUploadResponse
+			body, contentType := tc.body(t)
+			upload(t, body, contentType, http.StatusCreated, jsonhttptest.WithUnmarshalJSONResponse(&resp))
+
+			m, err := manifest.NewDefaultManifestReference(
+				resp.Reference,
+				loadsave.NewReadonly(storer.ChunkStore(), storer.Cache(), redundancy.DefaultLevel),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, file := range files {
+				entry, err := m.Lookup(ctx, file.name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// only the level which differs from the level of the upload is recorded
+				want := file.header.Get(api.SwarmRedundancyLevelHeader)
+				if got := entry.Metadata()[manifest.EntryMetadataRedundancyLevelKey]; got != want {
+					t.Fatalf("got redundancy level %q of %s, want %q", got, file.name, want)
+				}
+
+				jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/"+file.name, http.StatusOK,
+					jsonhttptest.WithExpectedResponse(file.data),
+				)
+			}
+		})
+	}
+
+	t.Run("invalid level", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := []f{{
+			data: []byte("invalid level"),
+			name: "invalid.txt",
+			header: http.Header{
+				api.SwarmRedundancyLevelHeader: {"5"},
+			},
+		}}
+		upload(t, tarFiles(t, invalid), api.ContentTypeTar, http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid redundancy level",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+}
+
 // tarFiles receives an array of test case files and creates a new tar with those files as a collection
 // it returns a bytes.Buffer which can be used to read the created tar
 func tarFiles(t *testing.T, files []f) *bytes.Buffer {
@@ -546,6 +664,9 @@ func tarFiles(t *testing.T, files []f) *bytes.Buffer {
 			Mode: 0600,
 			Size: int64(len(file.data)),
 		}
+		if level := file.header.Get(api.SwarmRedundancyLevelHeader); level != "" {
+			hdr.PAXRecords = map[string]string{api.SwarmRedundancyLevelPAXRecord: level}
+		}
 		if err := tw.WriteHeader(hdr); err != nil {
 			t.Fatal(err)
 		}
@@ -611,6 +732,9 @@ func multipartFiles(t *testing.T, files []f) (*bytes.Buffer, string) {
 			hdr.Set(api.ContentLengthHeader, strconv.Itoa(len(file.data)))
 
 		}
+		if level := file.header.Get(api.SwarmRedundancyLevelHeader); level != "" {
+			hdr.Set(api.SwarmRedundancyLevelHeader, level)
+		}
 		part, err := mw.CreatePart(hdr)
 		if err != nil {
 			t.Fatal(err)
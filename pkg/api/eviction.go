@@ -0,0 +1,106 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/gorilla/mux"
+)
+
+type evictionStatusResponse struct {
+	Worker      string     `json:"worker"`
+	Paused      bool       `json:"paused"`
+	PausedUntil *time.Time `json:"pausedUntil,omitempty"`
+}
+
+type evictionStatusesResponse struct {
+	Workers []evictionStatusResponse `json:"workers"`
+}
+
+func (s *Service) evictionStatusResponse() evictionStatusesResponse {
+	statuses := s.storer.EvictionStatus()
+	resp := evictionStatusesResponse{Workers: make([]evictionStatusResponse, 0, len(statuses))}
+	for _, st := range statuses {
+		r := evictionStatusResponse{Worker: st.Worker, Paused: st.Paused}
+		if !st.PausedUntil.IsZero() {
+			until := st.PausedUntil
+			r.PausedUntil = &until
+		}
+		resp.Workers = append(resp.Workers, r)
+	}
+	return resp
+}
+
+// evictionStatusHandler returns the pause state of the eviction workers.
+func (s *Service) evictionStatusHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, s.evictionStatusResponse())
+}
+
+// evictionPauseHandler pauses an eviction worker, for the given number of
+// seconds or until it is resumed.
+func (s *Service) evictionPauseHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_eviction_pause").Build()
+
+	paths := struct {
+		Worker string `map:"worker" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Duration uint64 `map:"duration"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	err := s.storer.PauseEviction(paths.Worker, time.Duration(queries.Duration)*time.Second)
+	if err != nil {
+		if errors.Is(err, storer.ErrUnknownEvictionWorker) {
+			jsonhttp.NotFound(w, "unknown eviction worker")
+			return
+		}
+		logger.Debug("pause eviction failed", "worker", paths.Worker, "error", err)
+		logger.Error(nil, "pause eviction failed", "worker", paths.Worker)
+		jsonhttp.InternalServerError(w, "pause eviction failed")
+		return
+	}
+
+	jsonhttp.OK(w, s.evictionStatusResponse())
+}
+
+// evictionResumeHandler resumes a paused eviction worker.
+func (s *Service) evictionResumeHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_eviction_resume").Build()
+
+	paths := struct {
+		Worker string `map:"worker" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.storer.ResumeEviction(paths.Worker); err != nil {
+		if errors.Is(err, storer.ErrUnknownEvictionWorker) {
+			jsonhttp.NotFound(w, "unknown eviction worker")
+			return
+		}
+		logger.Debug("resume eviction failed", "worker", paths.Worker, "error", err)
+		logger.Error(nil, "resume eviction failed", "worker", paths.Worker)
+		jsonhttp.InternalServerError(w, "resume eviction failed")
+		return
+	}
+
+	jsonhttp.OK(w, s.evictionStatusResponse())
+}
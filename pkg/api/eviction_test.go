@@ -0,0 +1,58 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+)
+
+func TestEviction(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/eviction", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.EvictionStatusesResponse{
+			Workers: []api.EvictionStatusResponse{
+				{Worker: "cache"},
+				{Worker: "reserve"},
+			},
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/eviction/reserve/pause", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.EvictionStatusesResponse{
+			Workers: []api.EvictionStatusResponse{
+				{Worker: "cache"},
+				{Worker: "reserve", Paused: true},
+			},
+		}),
+	)
+
+	var resp api.EvictionStatusesResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/eviction/cache/pause?duration=60", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if cache := resp.Workers[0]; !cache.Paused || cache.PausedUntil == nil {
+		t.Fatalf("unexpected cache eviction status: %+v", cache)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/eviction/reserve/resume", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if reserve := resp.Workers[1]; reserve.Paused {
+		t.Fatalf("unexpected reserve eviction status: %+v", reserve)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/eviction/unknown/pause", http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodPost, "/eviction/cache/pause?duration=abc", http.StatusBadRequest)
+}
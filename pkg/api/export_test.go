@@ -14,6 +14,7 @@ type (
 	ChunkAddressResponse  = chunkAddressResponse
 	SocPostResponse       = socPostResponse
 	FeedReferenceResponse = feedReferenceResponse
+	FeedUpdateMessage     = feedUpdateMessage
 	BzzUploadResponse     = bzzUploadResponse
 	TagRequest            = tagRequest
 	ListTagsResponse      = listTagsResponse
@@ -59,6 +60,8 @@ func (s *Service) ResolveNameOrAddress(str string) (swarm.Address, error) {
 
 type (
 	HealthStatusResponse              = healthStatusResponse
+	MigrationStatusResponse           = migrationStatusResponse
+	MigrationStatusesResponse         = migrationStatusesResponse
 	NodeResponse                      = nodeResponse
 	PingpongResponse                  = pingpongResponse
 	PeerConnectResponse               = peerConnectResponse
@@ -67,12 +70,41 @@ type (
 	AddressesResponse                 = addressesResponse
 	WelcomeMessageRequest             = welcomeMessageRequest
 	WelcomeMessageResponse            = welcomeMessageResponse
+	TopologyNeighborhoodResponse      = topologyNeighborhoodResponse
+	TopologyHistoryResponse           = topologyHistoryResponse
 	BalancesResponse                  = balancesResponse
 	PeerDataResponse                  = peerDataResponse
 	PeerData                          = peerData
 	BalanceResponse                   = balanceResponse
 	SettlementResponse                = settlementResponse
 	SettlementsResponse               = settlementsResponse
+	SettlementPolicyRequest           = settlementPolicyRequest
+	SettlementPolicyResponse          = settlementPolicyResponse
+	SettlementPoliciesResponse        = settlementPoliciesResponse
+	ExpiryPolicyRequest               = expiryPolicyRequest
+	ExpiryPolicyResponse              = expiryPolicyResponse
+	PostageExportRequest              = postageExportRequest
+	PostageExportConfirmRequest       = postageExportConfirmRequest
+	PostageImportRequest              = postageImportRequest
+	SwapAddressbookResponse           = swapAddressbookResponse
+	SwapAddressbookImportRequest      = swapAddressbookImportRequest
+	DeductionsResponse                = deductionsResponse
+	DeductionResponse                 = deductionResponse
+	SkiplistResponse                  = skiplistResponse
+	SkippedPeerResponse               = skippedPeerResponse
+	ClearSkiplistResponse             = clearSkiplistResponse
+	PusherLimitsRequest               = pusherLimitsRequest
+	PusherLimitsResponse              = pusherLimitsResponse
+	DeadLetterResponse                = deadLetterResponse
+	DeadLettersResponse               = deadLettersResponse
+	RedriveDeadLettersResponse        = redriveDeadLettersResponse
+	SyncStateResponse                 = syncStateResponse
+	SyncStatePeerResponse             = syncStatePeerResponse
+	SyncStateBinResponse              = syncStateBinResponse
+	AllowanceConfigRequest            = allowanceConfigRequest
+	AllowanceConfigResponse           = allowanceConfigResponse
+	PeerAllowanceResponse             = peerAllowanceResponse
+	PeerAllowancesResponse            = peerAllowancesResponse
 	ChequebookBalanceResponse         = chequebookBalanceResponse
 	ChequebookAddressResponse         = chequebookAddressResponse
 	ChequebookLastChequePeerResponse  = chequebookLastChequePeerResponse
@@ -85,7 +117,20 @@ type (
 	TransactionInfo                   = transactionInfo
 	TransactionPendingList            = transactionPendingList
 	TransactionHashResponse           = transactionHashResponse
+	NonceStatusResponse               = nonceStatusResponse
+	TransactionHistoryResponse        = transactionHistoryResponse
 	TagResponse                       = tagResponse
+	EvictionStatusResponse            = evictionStatusResponse
+	EvictionStatusesResponse          = evictionStatusesResponse
+	UsageForecastResponse             = usageForecastResponse
+	UsageForecastsResponse            = usageForecastsResponse
+	DedupStatsResponse                = dedupStatsResponse
+	ManifestDiffResponse              = manifestDiffResponse
+	VerifyResponse                    = verifyResponse
+	StorageRadiusResponse             = storageRadiusResponse
+	IncompleteTagResponse             = incompleteTagResponse
+	ListIncompleteTagsResponse        = listIncompleteTagsResponse
+	PurgeTagResponse                  = purgeTagResponse
 	ReserveStateResponse              = reserveStateResponse
 	ChainStateResponse                = chainStateResponse
 	PostageCreateResponse             = postageCreateResponse
@@ -93,6 +138,11 @@ type (
 	PostageStampsResponse             = postageStampsResponse
 	PostageBatchResponse              = postageBatchResponse
 	PostageStampBucketsResponse       = postageStampBucketsResponse
+	PostageEstimateResponse           = postageEstimateResponse
+	PinUsageResponse                  = pinUsageResponse
+	PinsUsageResponse                 = pinsUsageResponse
+	PinVerifyJobResponse              = pinVerifyJobResponse
+	PinVerifyJobsResponse             = pinVerifyJobsResponse
 	BucketData                        = bucketData
 	WalletResponse                    = walletResponse
 	WalletTxResponse                  = walletTxResponse
@@ -110,12 +160,19 @@ var (
 	ErrNoBalance             = errNoBalance
 	ErrCantSettlementsPeer   = errCantSettlementsPeer
 	ErrCantSettlements       = errCantSettlements
+	ErrNoSettlementPolicy    = errNoSettlementPolicy
+	ErrNoExpiryPolicy        = errNoExpiryPolicy
+	ErrNoPseudosettlePeer    = errNoPseudosettlePeer
+	ErrNoAddressbookEntry    = errNoAddressbookEntry
 	ErrChequebookBalance     = errChequebookBalance
 	ErrInvalidAddress        = errInvalidAddress
 	ErrUnknownTransaction    = errUnknownTransaction
 	ErrCantGetTransaction    = errCantGetTransaction
 	ErrCantResendTransaction = errCantResendTransaction
 	ErrAlreadyImported       = errAlreadyImported
+	ErrCantGetNonceStatus    = errCantGetNonceStatus
+	ErrCantRepairNonces      = errCantRepairNonces
+	ErrCantGetHistory        = errCantGetHistory
 )
 
 type (
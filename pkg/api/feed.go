@@ -6,10 +6,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -28,6 +31,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 )
 
 const (
@@ -142,6 +146,15 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 		AccessControlExposeHeaders: {SwarmFeedIndexHeader, SwarmFeedIndexNextHeader, SwarmSocSignatureHeader},
 	}
 
+	// the structured payload is resolved to the content it references, unless
+	// only the root chunk is requested
+	payload, err := feeds.ParsePayload(wc)
+	if err == nil {
+		for name, values := range feedPayloadHeaders(payload) {
+			additionalHeaders[name] = append(additionalHeaders[name], values...)
+		}
+	}
+
 	if headers.OnlyRootChunk {
 		w.Header().Set(ContentLengthHeader, strconv.Itoa(len(wc.Data())))
 		// include additional headers
@@ -154,7 +167,34 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc)
+	if payload != nil {
+		if payload.ContentType != "" {
+			additionalHeaders.Set(ContentTypeHeader, payload.ContentType)
+		}
+		s.downloadHandler(logger, w, r, payload.Reference, additionalHeaders, true, false, nil, downloadOptions{})
+		return
+	}
+
+	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc, downloadOptions{})
+}
+
+// feedPayloadHeaders returns the headers which surface the description of
+// the content of a structured feed update payload.
+func feedPayloadHeaders(p *feeds.Payload) http.Header {
+	h := http.Header{}
+	if p.ContentType != "" {
+		h.Set(SwarmFeedContentTypeHeader, p.ContentType)
+		h.Add(AccessControlExposeHeaders, SwarmFeedContentTypeHeader)
+	}
+	if len(p.Metadata) > 0 {
+		metadata := url.Values{}
+		for k, v := range p.Metadata {
+			metadata.Set(k, v)
+		}
+		h.Set(SwarmFeedMetadataHeader, metadata.Encode())
+		h.Add(AccessControlExposeHeaders, SwarmFeedMetadataHeader)
+	}
+	return h
 }
 
 func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
@@ -315,3 +355,167 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	jsonhttp.Created(w, feedReferenceResponse{Reference: encryptedReference})
 }
+
+type feedUpdateMessage struct {
+	Reference swarm.Address `json:"reference"`
+	Index     string        `json:"index"`
+	IndexNext string        `json:"indexNext"`
+}
+
+// feedWatchWsHandler sends the new updates of a feed as JSON messages over a
+// websocket, so that the applications do not have to poll the feed.
+func (s *Service) feedWatchWsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("feed_watch").Build()
+
+	paths := struct {
+		Owner common.Address `map:"owner" validate:"required"`
+		Topic []byte         `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		After    uint64 `map:"after"`
+		Interval uint64 `map:"interval"` // seconds
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	f := feeds.New(paths.Topic, paths.Owner)
+	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, f)
+	if err != nil {
+		logger.Debug("new lookup failed", "owner", paths.Owner, "error", err)
+		logger.Error(nil, "new lookup failed")
+		switch {
+		case errors.Is(err, feeds.ErrFeedTypeNotFound):
+			jsonhttp.NotFound(w, "feed type not found")
+		default:
+			jsonhttp.InternalServerError(w, "new lookup failed")
+		}
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     s.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("upgrade failed", "error", err)
+		logger.Error(nil, "upgrade failed")
+		jsonhttp.InternalServerError(w, "upgrade failed")
+		return
+	}
+
+	s.wsWg.Add(1)
+	go s.feedWatchWs(conn, lookup, time.Duration(queries.Interval)*time.Second, queries.After)
+}
+
+func (s *Service) feedWatchWs(conn *websocket.Conn, lookup feeds.Lookup, interval time.Duration, after uint64) {
+	defer s.wsWg.Done()
+
+	var (
+		updates = make(chan feeds.WatchUpdate)
+		gone    = make(chan struct{})
+		ticker  = time.NewTicker(s.WsPingPeriod)
+		err     error
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		ticker.Stop()
+		_ = conn.Close()
+	}()
+
+	go func() {
+		_ = feeds.Watch(ctx, lookup, interval, after, func(u feeds.WatchUpdate) error {
+			select {
+			case updates <- u:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		s.logger.Debug("feed watch ws: client gone", "code", code, "message", text)
+		close(gone)
+		return nil
+	})
+
+	for {
+		select {
+		case u := <-updates:
+			msg, err := newFeedUpdateMessage(u)
+			if err != nil {
+				s.logger.Debug("feed watch ws: invalid update", "error", err)
+				continue
+			}
+
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("feed watch ws: set write deadline failed", "error", err)
+				return
+			}
+
+			err = conn.WriteJSON(msg)
+			if err != nil {
+				s.logger.Debug("feed watch ws: write message failed", "error", err)
+				return
+			}
+
+		case <-s.quit:
+			// shutdown
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("feed watch ws: set write deadline failed", "error", err)
+				return
+			}
+			err = conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if err != nil {
+				s.logger.Debug("feed watch ws: write close message failed", "error", err)
+			}
+			return
+		case <-gone:
+			// client gone
+			return
+		case <-ticker.C:
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("feed watch ws: set write deadline failed", "error", err)
+				return
+			}
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// error encountered while pinging client. client probably gone
+				return
+			}
+		}
+	}
+}
+
+func newFeedUpdateMessage(u feeds.WatchUpdate) (*feedUpdateMessage, error) {
+	wc, err := feeds.FromChunk(u.Chunk)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := u.Index.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal current index: %w", err)
+	}
+	next, err := u.Next.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal next index: %w", err)
+	}
+	return &feedUpdateMessage{
+		Reference: wc.Address(),
+		Index:     hex.EncodeToString(cur),
+		IndexNext: hex.EncodeToString(next),
+	}, nil
+}
@@ -15,6 +15,7 @@ import (
 	"math/big"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/api"
 	"github.com/calmw/bee-tron/pkg/feeds"
@@ -116,6 +117,42 @@ func TestFeed_Get(t *testing.T) {
 		)
 	})
 
+	t.Run("structured payload", func(t *testing.T) {
+		t.Parallel()
+
+		payload, err := (&feeds.Payload{
+			Reference:   mockWrappedCh.Address(),
+			ContentType: "text/plain",
+			Metadata:    map[string]string{"title": "fixture"},
+		}).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			ch              = testingsoc.GenerateMockSOC(t, payload).Chunk()
+			look            = newMockLookup(-1, 2, ch, nil, &id{}, &id{})
+			factory         = newMockFactory(look)
+			client, _, _, _ = newTestServer(t, testServerOptions{
+				Storer: mockStorer,
+				Feeds:  factory,
+			})
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, feedResource(ownerString, "aabbcc", ""), http.StatusOK,
+			jsonhttptest.WithExpectedResponse(mockWrappedCh.Data()[swarm.SpanSize:]),
+			jsonhttptest.WithExpectedResponseHeader(api.SwarmFeedContentTypeHeader, "text/plain"),
+			jsonhttptest.WithExpectedResponseHeader(api.SwarmFeedMetadataHeader, "title=fixture"),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmFeedIndexHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmFeedIndexNextHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmSocSignatureHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmFeedContentTypeHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.SwarmFeedMetadataHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.AccessControlExposeHeaders, api.ContentDispositionHeader),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "text/plain"),
+		)
+	})
+
 	t.Run("chunk wrapping", func(t *testing.T) {
 		t.Parallel()
 
@@ -225,6 +262,42 @@ func TestFeed_Get(t *testing.T) {
 }
 
 // nolint:paralleltest
+func TestFeed_Watch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ch          = toChunk(t, 12121212, swarm.RandAddress(t).Bytes())
+		look        = newMockLookup(-1, 0, ch, nil, &id{}, &id{})
+		idBytes, _  = (&id{}).MarshalBinary()
+		_, cl, _, _ = newTestServer(t, testServerOptions{
+			WsPath: fmt.Sprintf("/feeds/%s/%s/watch", ownerString, "aabbcc"),
+			Feeds:  newMockFactory(look),
+		})
+	)
+
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cl.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got api.FeedUpdateMessage
+	if err := cl.ReadJSON(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := api.FeedUpdateMessage{
+		Reference: wc.Address(),
+		Index:     hex.EncodeToString(idBytes),
+		IndexNext: hex.EncodeToString(idBytes),
+	}
+	if !got.Reference.Equal(want.Reference) || got.Index != want.Index || got.IndexNext != want.IndexNext {
+		t.Fatalf("got update %+v, want %+v", got, want)
+	}
+}
+
 func TestFeed_Post(t *testing.T) {
 	// post to owner, tpoic, then expect a reference
 	// get the reference from the store, unmarshal to a
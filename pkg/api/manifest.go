@@ -0,0 +1,206 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/file/loadsave"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/manifest"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+	"github.com/gorilla/mux"
+)
+
+type manifestDiffResponse struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// manifestDiffHandler returns the paths of the entries which were added,
+// removed or changed in the other manifest compared to the base manifest.
+func (s *Service) manifestDiffHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_manifest_diff").Build()
+
+	paths := struct {
+		Base  swarm.Address `map:"base,resolve" validate:"required"`
+		Other swarm.Address `map:"other,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	ls := loadsave.NewReadonly(s.storer.Download(true), s.storer.Cache(), redundancy.DefaultLevel)
+	base, err := manifest.NewDefaultManifestReference(paths.Base, ls)
+	if err != nil {
+		logger.Debug("load base manifest failed", "address", paths.Base, "error", err)
+		logger.Error(nil, "load base manifest failed")
+		jsonhttp.InternalServerError(w, "load manifest failed")
+		return
+	}
+	other, err := manifest.NewDefaultManifestReference(paths.Other, ls)
+	if err != nil {
+		logger.Debug("load other manifest failed", "address", paths.Other, "error", err)
+		logger.Error(nil, "load other manifest failed")
+		jsonhttp.InternalServerError(w, "load manifest failed")
+		return
+	}
+
+	changes, err := manifest.Diff(r.Context(), base, other)
+	if err != nil {
+		logger.Debug("manifest diff failed", "base", paths.Base, "other", paths.Other, "error", err)
+		logger.Error(nil, "manifest diff failed")
+		switch {
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, topology.ErrNotFound):
+			jsonhttp.NotFound(w, "manifest not found")
+		default:
+			jsonhttp.InternalServerError(w, "manifest diff failed")
+		}
+		return
+	}
+
+	jsonhttp.OK(w, manifestDiffResponse{
+		Added:   changes.Added,
+		Removed: changes.Removed,
+		Changed: changes.Changed,
+	})
+}
+
+// manifestMergeHandler merges the entries of the patch manifest onto the base
+// manifest and stores the result. Only the modified nodes of the base are
+// uploaded.
+func (s *Service) manifestMergeHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_manifest_merge").Build()
+
+	paths := struct {
+		Base  swarm.Address `map:"base,resolve" validate:"required"`
+		Patch swarm.Address `map:"patch,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		BatchID  []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		Pin      bool   `map:"Swarm-Pin"`
+		Deferred *bool  `map:"Swarm-Deferred-Upload"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var (
+		tag      storer.SessionInfo
+		err      error
+		deferred = defaultUploadMethod(headers.Deferred)
+	)
+	if deferred || headers.Pin {
+		tag, err = s.storer.NewSession()
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, "tag not found")
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return
+		}
+	}
+
+	putter, err := s.newStamperPutter(r.Context(), putterOptions{
+		BatchID:  headers.BatchID,
+		TagID:    tag.TagID,
+		Pin:      headers.Pin,
+		Deferred: deferred,
+	})
+	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "batch with id not found")
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, "invalid batch id")
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	// the new nodes are encrypted if the base manifest is
+	encrypt := encryption.IsEncryptedReferenceSize(len(paths.Base.Bytes()))
+	ls := loadsave.New(s.storer.Download(true), s.storer.Cache(), requestPipelineFactory(r.Context(), putter, encrypt, redundancy.NONE), redundancy.DefaultLevel)
+	base, err := manifest.NewDefaultManifestReference(paths.Base, ls)
+	if err != nil {
+		logger.Debug("load base manifest failed", "address", paths.Base, "error", err)
+		logger.Error(nil, "load base manifest failed")
+		jsonhttp.InternalServerError(ow, "load manifest failed")
+		return
+	}
+	patch, err := manifest.NewDefaultManifestReference(paths.Patch, ls)
+	if err != nil {
+		logger.Debug("load patch manifest failed", "address", paths.Patch, "error", err)
+		logger.Error(nil, "load patch manifest failed")
+		jsonhttp.InternalServerError(ow, "load manifest failed")
+		return
+	}
+
+	if err := manifest.Merge(r.Context(), base, patch); err != nil {
+		logger.Debug("manifest merge failed", "base", paths.Base, "patch", paths.Patch, "error", err)
+		logger.Error(nil, "manifest merge failed")
+		switch {
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, topology.ErrNotFound):
+			jsonhttp.NotFound(ow, "manifest not found")
+		default:
+			jsonhttp.InternalServerError(ow, "manifest merge failed")
+		}
+		return
+	}
+
+	ref, err := base.Store(r.Context())
+	if err != nil {
+		logger.Debug("store manifest failed", "error", err)
+		logger.Error(nil, "store manifest failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, "batch is overissued")
+		default:
+			jsonhttp.InternalServerError(ow, "store manifest failed")
+		}
+		return
+	}
+
+	if err := putter.Done(ref); err != nil {
+		logger.Debug("done split failed", "error", err)
+		logger.Error(nil, "done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	jsonhttp.Created(w, bzzUploadResponse{Reference: ref})
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/calmw/bee-tron/pkg/postage/mock"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestManifestDiffMerge(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	upload := func(t *testing.T, files []f) swarm.Address {
+		t.Helper()
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithRequestBody(tarFiles(t, files)),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp.Reference
+	}
+
+	var (
+		index  = f{data: []byte("<h1>Swarm"), name: "index.html"}
+		index2 = f{data: []byte("<h1>Swarm 2"), name: "index.html"}
+		image  = f{data: []byte("image"), name: "1.png", dir: "img"}
+		style  = f{data: []byte("body {}"), name: "app.css", dir: "css"}
+		base   = upload(t, []f{index, image})
+		other  = upload(t, []f{index2, style})
+	)
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifests/"+base.String()+"/diff/"+other.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ManifestDiffResponse{
+				Added:   []string{"css/app.css"},
+				Removed: []string{"img/1.png"},
+				Changed: []string{"index.html"},
+			}),
+		)
+	})
+
+	t.Run("diff not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifests/"+base.String()+"/diff/"+swarm.RandAddress(t).String(), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "manifest not found",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/manifests/"+base.String()+"/merge/"+other.String(), http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		for _, file := range []f{index2, image, style} {
+			jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/"+path.Join(file.dir, file.name), http.StatusOK,
+				jsonhttptest.WithExpectedResponse(file.data),
+			)
+		}
+	})
+}
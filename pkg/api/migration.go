@@ -0,0 +1,54 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
+)
+
+type migrationStatusResponse struct {
+	Group       string  `json:"group"`
+	Version     uint64  `json:"version"`
+	Latest      uint64  `json:"latest"`
+	Description string  `json:"description"`
+	Estimated   uint64  `json:"estimated"`
+	Done        uint64  `json:"done"`
+	Percent     float64 `json:"percent"`
+	Running     bool    `json:"running"`
+	DryRun      bool    `json:"dryRun"`
+}
+
+type migrationStatusesResponse struct {
+	Migrations []migrationStatusResponse `json:"migrations"`
+}
+
+// SetMigrationProgress sets the progress of the localstore migrations which
+// run while the node starts.
+func (s *Service) SetMigrationProgress(p *migration.Progress) {
+	s.migrationProgress = p
+}
+
+func (s *Service) migrationStatusHandler(w http.ResponseWriter, _ *http.Request) {
+	statuses := s.migrationProgress.Status()
+
+	res := migrationStatusesResponse{Migrations: make([]migrationStatusResponse, 0, len(statuses))}
+	for _, st := range statuses {
+		res.Migrations = append(res.Migrations, migrationStatusResponse{
+			Group:       st.Group,
+			Version:     st.Version,
+			Latest:      st.Latest,
+			Description: st.Description,
+			Estimated:   st.Estimated,
+			Done:        st.Done,
+			Percent:     st.Percent(),
+			Running:     st.Running,
+			DryRun:      st.DryRun,
+		})
+	}
+	jsonhttp.OK(w, res)
+}
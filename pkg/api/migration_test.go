@@ -0,0 +1,71 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("progress not set", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/migration", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.MigrationStatusesResponse{
+				Migrations: []api.MigrationStatusResponse{},
+			}),
+		)
+	})
+
+	t.Run("migrated", func(t *testing.T) {
+		t.Parallel()
+
+		progress := migration.NewProgress(log.Noop, false)
+		steps := migration.Steps{
+			1: func() error {
+				progress.Add(2)
+				return nil
+			},
+		}
+		plans := migration.Plans{
+			1: {
+				Description: "test step",
+				Estimate:    func() (uint64, error) { return 4, nil },
+			},
+		}
+		if err := migration.MigrateWithProgress(inmemstore.New(), "migration", steps, plans, progress); err != nil {
+			t.Fatal(err)
+		}
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			MigrationProgress: progress,
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/migration", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.MigrationStatusesResponse{
+				Migrations: []api.MigrationStatusResponse{{
+					Group:       "migration",
+					Version:     1,
+					Latest:      1,
+					Description: "test step",
+					Estimated:   4,
+					Done:        2,
+					Percent:     50,
+				}},
+			}),
+		)
+	})
+}
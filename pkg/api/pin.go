@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"sort"
 	"sync"
 
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
@@ -99,11 +100,14 @@ func (s *Service) pinRootHash(w http.ResponseWriter, r *http.Request) {
 
 	if err := errors.Join(err, errTraverse); err != nil {
 		logger.Error(errors.Join(err, putter.Cleanup()), "pin collection failed")
-		if errors.Is(err, storage.ErrNotFound) {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
 			jsonhttp.NotFound(w, "pin collection failed")
-			return
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.InsufficientStorage(w, "pinning quota exceeded")
+		default:
+			jsonhttp.InternalServerError(w, "pin collection failed")
 		}
-		jsonhttp.InternalServerError(w, "pin collection failed")
 		return
 	}
 
@@ -203,6 +207,52 @@ func (s *Service) listPinnedRootHashes(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+type pinUsageResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Chunks    uint64        `json:"chunks"`
+	Bytes     uint64        `json:"bytes"`
+}
+
+type pinsUsageResponse struct {
+	Quota uint64             `json:"quota"`
+	Used  uint64             `json:"used"`
+	Pins  []pinUsageResponse `json:"pins"`
+}
+
+// pinsUsageHandler lists the disk usage of the pinned root hashes, the
+// largest first, together with the pinning quota.
+func (s *Service) pinsUsageHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_pins_usage").Build()
+
+	usage, err := s.storer.PinsUsage()
+	if err != nil {
+		logger.Debug("pins usage: unable to list usage", "error", err)
+		logger.Error(nil, "pins usage: unable to list usage")
+		jsonhttp.InternalServerError(w, "pins usage failed")
+		return
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].Bytes > usage[j].Bytes
+	})
+
+	pins := make([]pinUsageResponse, 0, len(usage))
+	for _, u := range usage {
+		pins = append(pins, pinUsageResponse{
+			Reference: u.Reference,
+			Chunks:    u.Chunks,
+			Bytes:     u.Bytes,
+		})
+	}
+
+	quota, used := s.storer.PinQuota()
+	jsonhttp.OK(w, pinsUsageResponse{
+		Quota: quota,
+		Used:  used,
+		Pins:  pins,
+	})
+}
+
 type PinIntegrityResponse struct {
 	Reference swarm.Address `json:"reference"`
 	Total     int           `json:"total"`
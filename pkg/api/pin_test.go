@@ -6,15 +6,18 @@ package api_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/api"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
 	"github.com/calmw/bee-tron/pkg/log"
 	mockpost "github.com/calmw/bee-tron/pkg/postage/mock"
+	"github.com/calmw/bee-tron/pkg/spinlock"
 	storage "github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
 	storer "github.com/calmw/bee-tron/pkg/storer"
@@ -198,6 +201,105 @@ func TestPinHandlersInvalidInputs(t *testing.T) {
 	}
 }
 
+func TestPinsUsageHandler(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pins/usage", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PinsUsageResponse{
+			Pins: make([]api.PinUsageResponse, 0),
+		}),
+	)
+
+	const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestHeader(api.SwarmPinHeader, "true"),
+		jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pins/usage", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PinsUsageResponse{
+			Pins: []api.PinUsageResponse{{
+				Reference: swarm.MustParseHexAddress(rootHash),
+			}},
+		}),
+	)
+}
+
+func TestPinVerifyHandlers(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	const rootHash = "838d0a193ecd1152d1bb1432d5ecc02398533b2494889e23b8bd5ace30ac2aeb"
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestHeader(api.SwarmPinHeader, "true"),
+		jsonhttptest.WithRequestBody(strings.NewReader("this is a simple text")),
+	)
+
+	t.Run("unknown pin", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+swarm.RandAddress(t).String()+"/verify", http.StatusNotFound)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/pins/verify/1000", http.StatusNotFound)
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pins/verify/1000", http.StatusNotFound)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		t.Parallel()
+
+		var job api.PinVerifyJobResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+rootHash+"/verify?repair=true", http.StatusAccepted,
+			jsonhttptest.WithUnmarshalJSONResponse(&job),
+		)
+		if !job.Reference.Equal(swarm.MustParseHexAddress(rootHash)) || !job.Repair {
+			t.Fatalf("unexpected job: %+v", job)
+		}
+
+		path := fmt.Sprintf("/pins/verify/%d", job.ID)
+		err := spinlock.Wait(time.Second, func() bool {
+			jsonhttptest.Request(t, client, http.MethodGet, path, http.StatusOK,
+				jsonhttptest.WithUnmarshalJSONResponse(&job),
+			)
+			return job.State == "done"
+		})
+		if err != nil {
+			t.Fatalf("job not done: %+v", job)
+		}
+
+		var jobs api.PinVerifyJobsResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/pins/verify", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&jobs),
+		)
+		found := false
+		for _, j := range jobs.Jobs {
+			found = found || j.ID == job.ID
+		}
+		if !found {
+			t.Fatalf("job %d not listed", job.ID)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, path, http.StatusOK)
+		jsonhttptest.Request(t, client, http.MethodGet, path, http.StatusNotFound)
+	})
+}
+
 const pinRef = "620fcd78c7ce54da2d1b7cc2274a02e190cbe8fecbc3bd244690ab6517ce8f39"
 
 func TestIntegrityHandler(t *testing.T) {
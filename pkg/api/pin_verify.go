@@ -0,0 +1,292 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+// pinVerifyMaxJobs is the number of pin verification jobs kept by the node.
+// The oldest finished jobs are dropped to make room for new ones.
+const pinVerifyMaxJobs = 100
+
+// States of a pin verification job.
+const (
+	pinVerifyRunning   = "running"
+	pinVerifyDone      = "done"
+	pinVerifyFailed    = "failed"
+	pinVerifyCancelled = "cancelled"
+)
+
+type pinVerifyJobResponse struct {
+	ID        uint64        `json:"id"`
+	Reference swarm.Address `json:"reference"`
+	Repair    bool          `json:"repair"`
+	State     string        `json:"state"`
+	Total     uint64        `json:"total"`
+	Checked   uint64        `json:"checked"`
+	Missing   uint64        `json:"missing"`
+	Invalid   uint64        `json:"invalid"`
+	Repaired  uint64        `json:"repaired"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type pinVerifyJobsResponse struct {
+	Jobs []pinVerifyJobResponse `json:"jobs"`
+}
+
+// pinVerifyJob is a pin verification running in the background.
+type pinVerifyJob struct {
+	id     uint64
+	repair bool
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	state    string
+	progress storer.PinVerification
+	err      error
+}
+
+func (j *pinVerifyJob) update(progress storer.PinVerification) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.progress = progress
+}
+
+func (j *pinVerifyJob) finish(progress storer.PinVerification, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.progress = progress
+	j.err = err
+	switch {
+	case errors.Is(err, context.Canceled):
+		j.state = pinVerifyCancelled
+	case err != nil:
+		j.state = pinVerifyFailed
+	default:
+		j.state = pinVerifyDone
+	}
+}
+
+func (j *pinVerifyJob) response() pinVerifyJobResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	resp := pinVerifyJobResponse{
+		ID:        j.id,
+		Reference: j.progress.Reference,
+		Repair:    j.repair,
+		State:     j.state,
+		Total:     j.progress.Total,
+		Checked:   j.progress.Checked,
+		Missing:   j.progress.Missing,
+		Invalid:   j.progress.Invalid,
+		Repaired:  j.progress.Repaired,
+	}
+	if j.err != nil {
+		resp.Error = j.err.Error()
+	}
+	return resp
+}
+
+func (j *pinVerifyJob) running() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state == pinVerifyRunning
+}
+
+// pinVerifyJobs keeps track of the pin verification jobs.
+type pinVerifyJobs struct {
+	mu     sync.Mutex
+	nextID uint64
+	jobs   map[uint64]*pinVerifyJob
+}
+
+// add registers a new running job, returning false if there is no room left.
+func (p *pinVerifyJobs) add(root swarm.Address, repair bool, cancel context.CancelFunc) (*pinVerifyJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.jobs == nil {
+		p.jobs = make(map[uint64]*pinVerifyJob)
+	}
+	if len(p.jobs) >= pinVerifyMaxJobs {
+		ids := make([]uint64, 0, len(p.jobs))
+		for id, job := range p.jobs {
+			if !job.running() {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			if len(p.jobs) < pinVerifyMaxJobs {
+				break
+			}
+			delete(p.jobs, id)
+		}
+		if len(p.jobs) >= pinVerifyMaxJobs {
+			return nil, false
+		}
+	}
+
+	p.nextID++
+	job := &pinVerifyJob{
+		id:       p.nextID,
+		repair:   repair,
+		cancel:   cancel,
+		state:    pinVerifyRunning,
+		progress: storer.PinVerification{Reference: root},
+	}
+	p.jobs[job.id] = job
+	return job, true
+}
+
+func (p *pinVerifyJobs) get(id uint64) (*pinVerifyJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.jobs[id]
+	return job, ok
+}
+
+func (p *pinVerifyJobs) remove(id uint64) (*pinVerifyJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.jobs[id]
+	delete(p.jobs, id)
+	return job, ok
+}
+
+func (p *pinVerifyJobs) list() []*pinVerifyJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	jobs := make([]*pinVerifyJob, 0, len(p.jobs))
+	for _, job := range p.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].id < jobs[j].id })
+	return jobs
+}
+
+// pinVerifyStartHandler starts a background job verifying that all the chunks
+// of the pinned root hash are stored and valid, optionally retrieving the
+// broken ones from the network.
+func (s *Service) pinVerifyStartHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pin_verify").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Repair bool `map:"repair"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	has, err := s.storer.HasPin(paths.Reference)
+	if err != nil {
+		logger.Debug("pin verify: has pin failed", "chunk_address", paths.Reference, "error", err)
+		logger.Error(nil, "pin verify: has pin failed")
+		jsonhttp.InternalServerError(w, "pin verify: checking of tracking pin failed")
+		return
+	}
+	if !has {
+		jsonhttp.NotFound(w, nil)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job, ok := s.pinVerifyJobs.add(paths.Reference, queries.Repair, cancel)
+	if !ok {
+		cancel()
+		jsonhttp.TooManyRequests(w, "too many pin verification jobs")
+		return
+	}
+
+	go func() {
+		select {
+		case <-s.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go func() {
+		defer cancel()
+		res, err := s.storer.VerifyPin(ctx, paths.Reference, queries.Repair, job.update)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.Debug("pin verify: verification failed", "chunk_address", paths.Reference, "error", err)
+			logger.Error(nil, "pin verify: verification failed")
+		}
+		job.finish(res, err)
+	}()
+
+	jsonhttp.Accepted(w, job.response())
+}
+
+// pinVerifyJobsHandler lists the pin verification jobs.
+func (s *Service) pinVerifyJobsHandler(w http.ResponseWriter, _ *http.Request) {
+	jobs := s.pinVerifyJobs.list()
+	resp := pinVerifyJobsResponse{Jobs: make([]pinVerifyJobResponse, 0, len(jobs))}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, job.response())
+	}
+	jsonhttp.OK(w, resp)
+}
+
+// pinVerifyJobHandler returns the progress of a pin verification job.
+func (s *Service) pinVerifyJobHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_pin_verify").Build()
+
+	paths := struct {
+		ID uint64 `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	job, ok := s.pinVerifyJobs.get(paths.ID)
+	if !ok {
+		jsonhttp.NotFound(w, "pin verification job not found")
+		return
+	}
+	jsonhttp.OK(w, job.response())
+}
+
+// pinVerifyCancelHandler cancels a running pin verification job and forgets it.
+func (s *Service) pinVerifyCancelHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pin_verify").Build()
+
+	paths := struct {
+		ID uint64 `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	job, ok := s.pinVerifyJobs.remove(paths.ID)
+	if !ok {
+		jsonhttp.NotFound(w, "pin verification job not found")
+		return
+	}
+	job.cancel()
+	jsonhttp.OK(w, nil)
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"math/big"
 	"net/http"
 	"time"
@@ -16,9 +15,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/bigint"
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/postage/batchstore"
 	"github.com/calmw/bee-tron/pkg/postage/postagecontract"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/tracing"
+	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/gorilla/mux"
 )
 
@@ -126,6 +127,13 @@ func (s *Service) postageCreateHandler(w http.ResponseWriter, r *http.Request) {
 			jsonhttp.BadRequest(w, "insufficient amount for 24h minimum validity")
 			return
 		}
+		var revertErr *transaction.RevertError
+		if errors.As(err, &revertErr) {
+			logger.Debug("create batch: simulation reverted", "error", err)
+			logger.Error(nil, "create batch: simulation reverted")
+			jsonhttp.BadRequest(w, revertErr.Error())
+			return
+		}
 		logger.Debug("create batch: create failed", "error", err)
 		logger.Error(nil, "create batch: create failed")
 		jsonhttp.InternalServerError(w, "cannot create batch")
@@ -167,10 +175,30 @@ type postageBatchResponse struct {
 	BatchTTL    int64          `json:"batchTTL"`
 }
 
+type postageBatchesResponse struct {
+	Batches []postageBatchResponse `json:"batches"`
+	Next    hexByte                `json:"next,omitempty"` // the cursor of the next page
+}
+
+type postageEstimateResponse struct {
+	Amount       *bigint.BigInt `json:"amount"`
+	Depth        uint8          `json:"depth"`
+	TotalCost    *bigint.BigInt `json:"totalCost"`
+	BatchTTL     int64          `json:"batchTTL"`
+	Commitment   uint64         `json:"commitment"`
+	Radius       uint8          `json:"storageRadius"`
+	ReserveShare float64        `json:"reserveShare"`
+}
+
 type postageStampBucketsResponse struct {
 	Depth            uint8        `json:"depth"`
 	BucketDepth      uint8        `json:"bucketDepth"`
 	BucketUpperBound uint32       `json:"bucketUpperBound"`
+	Immutable        bool         `json:"immutable"`
+	Histogram        []uint32     `json:"histogram"`
+	BestBucket       bucketData   `json:"bestBucket"`
+	WorstBucket      bucketData   `json:"worstBucket"`
+	Remaining        uint32       `json:"remaining"`
 	Buckets          []bucketData `json:"buckets"`
 }
 
@@ -222,14 +250,46 @@ func (s *Service) postageGetStampsHandler(w http.ResponseWriter, r *http.Request
 	jsonhttp.OK(w, resp)
 }
 
-func (s *Service) postageGetAllBatchesHandler(w http.ResponseWriter, _ *http.Request) {
+func (s *Service) postageGetAllBatchesHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_batches").Build()
 
-	batches := make([]postageBatchResponse, 0)
-	err := s.batchStore.Iterate(func(b *postage.Batch) (bool, error) {
+	queries := struct {
+		Owner     []byte `map:"owner" validate:"omitempty,len=20"`
+		Immutable *bool  `map:"immutable"`
+		MinTTL    int64  `map:"minTTL" validate:"min=0"`
+		Sort      string `map:"sort" validate:"omitempty,oneof=batchID ttl"`
+		Cursor    []byte `map:"cursor"`
+		Limit     int    `map:"limit" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	query := postage.BatchQuery{
+		Owner:       queries.Owner,
+		Immutable:   queries.Immutable,
+		MinValue:    s.minBatchValue(queries.MinTTL),
+		SortByValue: queries.Sort == "ttl",
+		Cursor:      queries.Cursor,
+		Limit:       queries.Limit,
+	}
+	page, next, err := s.batchStore.Query(query)
+	if err != nil {
+		logger.Debug("query batches: query failed", "error", err)
+		logger.Error(nil, "query batches: query failed")
+		jsonhttp.InternalServerError(w, "unable to query batches")
+		return
+	}
+
+	batches := make([]postageBatchResponse, 0, len(page))
+	for _, b := range page {
 		batchTTL, err := s.estimateBatchTTL(b)
 		if err != nil {
-			return false, fmt.Errorf("estimate batch ttl: %w", err)
+			logger.Debug("query batches: estimate batch ttl failed", "batch_id", hex.EncodeToString(b.ID), "error", err)
+			logger.Error(nil, "query batches: estimate batch ttl failed")
+			jsonhttp.InternalServerError(w, "unable to estimate batch ttl")
+			return
 		}
 
 		batches = append(batches, postageBatchResponse{
@@ -242,22 +302,12 @@ func (s *Service) postageGetAllBatchesHandler(w http.ResponseWriter, _ *http.Req
 			Immutable:   b.Immutable,
 			BatchTTL:    batchTTL,
 		})
-		return false, nil
-	})
-	if err != nil {
-		logger.Debug("iterate batches: iteration failed", "error", err)
-		logger.Error(nil, "iterate batches: iteration failed")
-		jsonhttp.InternalServerError(w, "unable to iterate all batches")
-		return
 	}
 
-	batchesRes := struct {
-		Batches []postageBatchResponse `json:"batches"`
-	}{
+	jsonhttp.OK(w, postageBatchesResponse{
 		Batches: batches,
-	}
-
-	jsonhttp.OK(w, batchesRes)
+		Next:    next,
+	})
 }
 
 func (s *Service) postageGetStampBucketsHandler(w http.ResponseWriter, r *http.Request) {
@@ -272,13 +322,11 @@ func (s *Service) postageGetStampBucketsHandler(w http.ResponseWriter, r *http.R
 	}
 	hexBatchID := hex.EncodeToString(paths.BatchID)
 
-	issuer, _, err := s.post.GetStampIssuer(paths.BatchID)
+	u, err := s.post.BucketUtilization(paths.BatchID)
 	if err != nil {
-		logger.Debug("get stamp issuer: get issuer failed", "batch_id", hexBatchID, "error", err)
-		logger.Error(nil, "get stamp issuer: get issuer failed")
+		logger.Debug("get stamp issuer: get bucket utilization failed", "batch_id", hexBatchID, "error", err)
+		logger.Error(nil, "get stamp issuer: get bucket utilization failed")
 		switch {
-		case errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.BadRequest(w, "batch not usable")
 		case errors.Is(err, postage.ErrNotFound):
 			jsonhttp.NotFound(w, "issuer does not exist")
 		default:
@@ -287,17 +335,23 @@ func (s *Service) postageGetStampBucketsHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	b := issuer.Buckets()
 	resp := postageStampBucketsResponse{
-		Depth:            issuer.Depth(),
-		BucketDepth:      issuer.BucketDepth(),
-		BucketUpperBound: issuer.BucketUpperBound(),
-		Buckets:          make([]bucketData, len(b)),
+		Depth:            u.Depth,
+		BucketDepth:      u.BucketDepth,
+		BucketUpperBound: u.BucketUpperBound,
+		Immutable:        u.Immutable,
+		Histogram:        u.Histogram,
+		Remaining:        u.Remaining,
+		Buckets:          make([]bucketData, len(u.Buckets)),
 	}
 
-	for i, v := range b {
+	for i, v := range u.Buckets {
 		resp.Buckets[i] = bucketData{BucketID: uint32(i), Collisions: v}
 	}
+	if len(resp.Buckets) > 0 {
+		resp.BestBucket = resp.Buckets[u.BestBucket]
+		resp.WorstBucket = resp.Buckets[u.WorstBucket]
+	}
 
 	jsonhttp.OK(w, resp)
 }
@@ -450,6 +504,68 @@ func (s *Service) estimateBatchTTL(batch *postage.Batch) (int64, error) {
 	return ttl.Int64(), nil
 }
 
+func (s *Service) postageEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamps_estimate").Build()
+
+	queries := struct {
+		Amount *big.Int `map:"amount" validate:"required"`
+		Depth  uint8    `map:"depth" validate:"required,min=17"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	estimate, err := s.batchStore.Estimate(queries.Depth, queries.Amount)
+	if err != nil {
+		if errors.Is(err, batchstore.ErrInvalidEstimate) {
+			logger.Debug("estimate batch: invalid parameters", "amount", queries.Amount, "depth", queries.Depth, "error", err)
+			logger.Error(nil, "estimate batch: invalid parameters")
+			jsonhttp.BadRequest(w, "invalid estimate parameters")
+			return
+		}
+		logger.Debug("estimate batch: estimate failed", "amount", queries.Amount, "depth", queries.Depth, "error", err)
+		logger.Error(nil, "estimate batch: estimate failed")
+		jsonhttp.InternalServerError(w, "cannot estimate batch")
+		return
+	}
+
+	batchTTL := estimate.Blocks
+	if batchTTL > 0 {
+		batchTTL *= int64(s.blockTime / time.Second)
+	}
+
+	jsonhttp.OK(w, &postageEstimateResponse{
+		Amount:       bigint.Wrap(queries.Amount),
+		Depth:        queries.Depth,
+		TotalCost:    bigint.Wrap(new(big.Int).Mul(queries.Amount, new(big.Int).SetUint64(estimate.Commitment))),
+		BatchTTL:     batchTTL,
+		Commitment:   estimate.Commitment,
+		Radius:       estimate.Radius,
+		ReserveShare: estimate.ReserveShare,
+	})
+}
+
+// minBatchValue returns the normalised balance batches need to live at least
+// ttl seconds, nil if every batch does.
+func (s *Service) minBatchValue(ttl int64) *big.Int {
+	state := s.batchStore.GetChainState()
+	if ttl == 0 || len(state.CurrentPrice.Bits()) == 0 {
+		return nil
+	}
+
+	value := new(big.Int).Mul(big.NewInt(ttl), state.CurrentPrice)
+	blockTime := big.NewInt(int64(s.blockTime / time.Second))
+	if blockTime.Sign() == 0 {
+		blockTime.SetInt64(1)
+	}
+	// round up so batches just short of the ttl are excluded
+	value.Add(value, new(big.Int).Sub(blockTime, big.NewInt(1)))
+	value.Div(value, blockTime)
+
+	return value.Add(value, state.TotalAmount)
+}
+
 func (s *Service) postageTopUpHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("patch_stamp_topup").Build()
 
@@ -0,0 +1,159 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/gorilla/mux"
+)
+
+const (
+	errCantExpiryPolicy       = "can not get expiry policy"
+	errCantSetExpiryPolicy    = "can not set expiry policy"
+	errCantDeleteExpiryPolicy = "can not delete expiry policy"
+	errNoExpiryPolicy         = "no expiry policy for batch"
+	errExpiryPolicyDisabled   = "expiry policies are not available"
+)
+
+type expiryPolicyRequest struct {
+	Threshold   int64          `json:"threshold"` // remaining time to live in seconds triggering the actions
+	TopUpAmount *bigint.BigInt `json:"topUpAmount,omitempty"`
+	Notify      bool           `json:"notify"`
+	Freeze      bool           `json:"freeze"`
+}
+
+type expiryPolicyResponse struct {
+	BatchID     string         `json:"batchID"`
+	Threshold   int64          `json:"threshold"`
+	TopUpAmount *bigint.BigInt `json:"topUpAmount,omitempty"`
+	Notify      bool           `json:"notify"`
+	Freeze      bool           `json:"freeze"`
+}
+
+func newExpiryPolicyResponse(batchID []byte, policy postage.ExpiryPolicy) expiryPolicyResponse {
+	resp := expiryPolicyResponse{
+		BatchID:   hex.EncodeToString(batchID),
+		Threshold: int64(policy.Threshold / time.Second),
+		Notify:    policy.Notify,
+		Freeze:    policy.Freeze,
+	}
+	if policy.TopUpAmount != nil {
+		resp.TopUpAmount = bigint.Wrap(policy.TopUpAmount)
+	}
+	return resp
+}
+
+func (s *Service) postageGetExpiryPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_stamp_expiry_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.expiryWatcher == nil {
+		jsonhttp.NotImplemented(w, errExpiryPolicyDisabled)
+		return
+	}
+
+	policy, ok, err := s.expiryWatcher.Policy(paths.BatchID)
+	if err != nil {
+		logger.Debug("get expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "get expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID))
+		jsonhttp.InternalServerError(w, errCantExpiryPolicy)
+		return
+	}
+	if !ok {
+		jsonhttp.NotFound(w, errNoExpiryPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, newExpiryPolicyResponse(paths.BatchID, policy))
+}
+
+func (s *Service) postageSetExpiryPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_stamp_expiry_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.expiryWatcher == nil {
+		jsonhttp.NotImplemented(w, errExpiryPolicyDisabled)
+		return
+	}
+
+	var req expiryPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	policy := postage.ExpiryPolicy{
+		Threshold: time.Duration(req.Threshold) * time.Second,
+		Notify:    req.Notify,
+		Freeze:    req.Freeze,
+	}
+	if req.TopUpAmount != nil {
+		policy.TopUpAmount = req.TopUpAmount.Int
+	}
+
+	err := s.expiryWatcher.SetPolicy(paths.BatchID, policy)
+	if errors.Is(err, postage.ErrInvalidExpiryPolicy) {
+		logger.Debug("set expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	if err != nil {
+		logger.Debug("set expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "set expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID))
+		jsonhttp.InternalServerError(w, errCantSetExpiryPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, newExpiryPolicyResponse(paths.BatchID, policy))
+}
+
+func (s *Service) postageDeleteExpiryPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_stamp_expiry_policy").Build()
+
+	paths := struct {
+		BatchID []byte `map:"batch_id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.expiryWatcher == nil {
+		jsonhttp.NotImplemented(w, errExpiryPolicyDisabled)
+		return
+	}
+
+	if err := s.expiryWatcher.DeletePolicy(paths.BatchID); err != nil {
+		logger.Debug("delete expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID), "error", err)
+		logger.Error(nil, "delete expiry policy failed", "batch_id", hex.EncodeToString(paths.BatchID))
+		jsonhttp.InternalServerError(w, errCantDeleteExpiryPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
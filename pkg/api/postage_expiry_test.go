@@ -0,0 +1,96 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/postage/batchstore/mock"
+	mockpost "github.com/calmw/bee-tron/pkg/postage/mock"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
+)
+
+func TestPostageExpiryPolicy(t *testing.T) {
+	t.Parallel()
+
+	watcher := postage.NewExpiryWatcher(log.Noop, mockpost.New(), mock.New(), statestore.NewStateStore(), nil, 5*time.Second)
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		ExpiryWatcher: watcher,
+	})
+
+	batchID := make([]byte, 32)
+	batchID[0] = 1
+	path := "/stamps/" + hex.EncodeToString(batchID) + "/expiry-policy"
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: api.ErrNoExpiryPolicy,
+			Code:    http.StatusNotFound,
+		}),
+	)
+
+	expected := api.ExpiryPolicyResponse{
+		BatchID:     hex.EncodeToString(batchID),
+		Threshold:   3600,
+		TopUpAmount: bigint.Wrap(big.NewInt(1000)),
+		Freeze:      true,
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodPut, path, http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.ExpiryPolicyRequest{
+			Threshold:   3600,
+			TopUpAmount: bigint.Wrap(big.NewInt(1000)),
+			Freeze:      true,
+		}),
+		jsonhttptest.WithExpectedJSONResponse(expected),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(expected),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, path, http.StatusOK)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusNotFound)
+}
+
+func TestPostageExpiryPolicyInvalid(t *testing.T) {
+	t.Parallel()
+
+	watcher := postage.NewExpiryWatcher(log.Noop, mockpost.New(), mock.New(), statestore.NewStateStore(), nil, 5*time.Second)
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		ExpiryWatcher: watcher,
+	})
+
+	path := "/stamps/" + hex.EncodeToString(make([]byte, 32)) + "/expiry-policy"
+
+	jsonhttptest.Request(t, testServer, http.MethodPut, path, http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.ExpiryPolicyRequest{
+			Threshold: 0,
+			Notify:    true,
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/stamps/01/expiry-policy", http.StatusBadRequest)
+}
+
+func TestPostageExpiryPolicyUnavailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	path := "/stamps/" + hex.EncodeToString(make([]byte, 32)) + "/expiry-policy"
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusNotImplemented)
+}
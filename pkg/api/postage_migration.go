@@ -0,0 +1,208 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type postageExportRequest struct {
+	BatchIDs []string `json:"batchIDs"`
+	Password string   `json:"password"`
+}
+
+type postageExportConfirmRequest struct {
+	BatchIDs []string `json:"batchIDs"`
+}
+
+type postageImportRequest struct {
+	Password string          `json:"password"`
+	File     json.RawMessage `json:"file"` // the file returned by the export
+}
+
+type postageImportResponse struct {
+	BatchIDs []hexByte `json:"batchIDs"`
+}
+
+// postageExportHandler exports the stamp issuers of the batches to an
+// encrypted file. No stamps are issued with the batches until the export is
+// confirmed, which removes the issuers from the node, or canceled.
+func (s *Service) postageExportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamps_export").Build()
+
+	var req postageExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	if req.Password == "" {
+		jsonhttp.BadRequest(w, "password is required")
+		return
+	}
+	batchIDs, err := parseBatchIDs(req.BatchIDs)
+	if err != nil {
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	issuers, err := s.post.Export(batchIDs)
+	if err != nil {
+		logger.Debug("export stamp issuers failed", "error", err)
+		logger.Error(nil, "export stamp issuers failed")
+		if errors.Is(err, postage.ErrNotFound) {
+			jsonhttp.NotFound(w, "issuer does not exist")
+			return
+		}
+		jsonhttp.InternalServerError(w, "export stamp issuers failed")
+		return
+	}
+
+	file, err := postage.EncryptIssuers(issuers, req.Password)
+	if err != nil {
+		logger.Debug("encrypt stamp issuers failed", "error", err)
+		logger.Error(nil, "encrypt stamp issuers failed")
+		// keep issuing stamps on this node as the issuers were not handed over
+		if err := s.post.CancelExport(batchIDs); err != nil {
+			logger.Error(err, "cancel stamp issuers export failed")
+		}
+		jsonhttp.InternalServerError(w, "export stamp issuers failed")
+		return
+	}
+
+	// the file is written as is, so that it can be imported on another node
+	w.Header().Set(ContentTypeHeader, jsonhttp.DefaultContentTypeHeader)
+	w.Header().Set(ContentDispositionHeader, `attachment; filename="stamp-issuers.json"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file); err != nil {
+		logger.Debug("write exported stamp issuers failed", "error", err)
+	}
+}
+
+// postageExportConfirmHandler removes the exported stamp issuers of the
+// batches once the client stored the exported file.
+func (s *Service) postageExportConfirmHandler(w http.ResponseWriter, r *http.Request) {
+	s.postageExportDone(w, r, "post_stamps_export_confirm", func(batchIDs [][]byte) error {
+		return s.post.ConfirmExport(r.Context(), batchIDs)
+	})
+}
+
+// postageExportCancelHandler resumes issuing stamps with the exported stamp
+// issuers of the batches.
+func (s *Service) postageExportCancelHandler(w http.ResponseWriter, r *http.Request) {
+	s.postageExportDone(w, r, "post_stamps_export_cancel", s.post.CancelExport)
+}
+
+// postageExportDone ends the export of the stamp issuers of the batches of
+// the request with the given function.
+func (s *Service) postageExportDone(w http.ResponseWriter, r *http.Request, name string, done func([][]byte) error) {
+	logger := s.logger.WithName(name).Build()
+
+	var req postageExportConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	batchIDs, err := parseBatchIDs(req.BatchIDs)
+	if err != nil {
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	if err := done(batchIDs); err != nil {
+		logger.Debug("end stamp issuers export failed", "error", err)
+		if errors.Is(err, postage.ErrNotExported) {
+			jsonhttp.BadRequest(w, err)
+			return
+		}
+		logger.Error(nil, "end stamp issuers export failed")
+		jsonhttp.InternalServerError(w, "end stamp issuers export failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+// parseBatchIDs parses the hex encoded batch IDs of a request.
+func parseBatchIDs(ids []string) ([][]byte, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("batch IDs are required")
+	}
+	batchIDs := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		batchID, err := hex.DecodeString(id)
+		if err != nil || len(batchID) != swarm.HashSize {
+			return nil, errors.New("invalid batch ID " + id)
+		}
+		batchIDs = append(batchIDs, batchID)
+	}
+	return batchIDs, nil
+}
+
+// postageImportHandler imports stamp issuers exported by another node. The
+// stamps are signed with the node key and the key is not part of the export,
+// so only the issuers of batches owned by the key of this node are imported,
+// as when the node is moved to a new host with its keys.
+func (s *Service) postageImportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_stamps_import").Build()
+
+	var req postageImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	issuers, err := postage.DecryptIssuers(req.File, req.Password)
+	if err != nil {
+		logger.Debug("decrypt stamp issuers failed", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	resp := postageImportResponse{BatchIDs: make([]hexByte, 0, len(issuers))}
+	for _, issuer := range issuers {
+		batch, err := s.batchStore.Get(issuer.ID())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				jsonhttp.NotFound(w, "batch "+hex.EncodeToString(issuer.ID())+" does not exist")
+				return
+			}
+			logger.Debug("get batch failed", "batch_id", hex.EncodeToString(issuer.ID()), "error", err)
+			logger.Error(nil, "get batch failed")
+			jsonhttp.InternalServerError(w, "get batch failed")
+			return
+		}
+		// stamps are signed with the node key, so only batches it owns can be used
+		if !bytes.Equal(batch.Owner, s.ethereumAddress.Bytes()) {
+			jsonhttp.BadRequest(w, "batch "+hex.EncodeToString(issuer.ID())+" is not owned by this node")
+			return
+		}
+		resp.BatchIDs = append(resp.BatchIDs, issuer.ID())
+	}
+
+	if err := s.post.Import(issuers); err != nil {
+		logger.Debug("import stamp issuers failed", "error", err)
+		logger.Error(nil, "import stamp issuers failed")
+		if errors.Is(err, postage.ErrIssuerConflict) {
+			jsonhttp.Conflict(w, err)
+			return
+		}
+		jsonhttp.InternalServerError(w, "import stamp issuers failed")
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
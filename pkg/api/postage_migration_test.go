@@ -0,0 +1,123 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/postage/batchstore/mock"
+	mockpost "github.com/calmw/bee-tron/pkg/postage/mock"
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPostageExportImport(t *testing.T) {
+	t.Parallel()
+
+	owner := common.HexToAddress("0xabcd")
+	batch := postagetesting.MustNewBatch(postagetesting.WithOwner(owner.Bytes()))
+	batchIDStr := hex.EncodeToString(batch.ID)
+
+	source := mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("label", "keyID", batch.ID, big.NewInt(3), 16, 8, 10, true)))
+	sourceServer, _, _, _ := newTestServer(t, testServerOptions{Post: source})
+
+	jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PostageExportRequest{BatchIDs: []string{batchIDStr}}),
+	)
+	jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export", http.StatusNotFound,
+		jsonhttptest.WithJSONRequestBody(api.PostageExportRequest{
+			BatchIDs: []string{hex.EncodeToString(postagetesting.MustNewID())},
+			Password: "secret",
+		}),
+	)
+
+	var file json.RawMessage
+	jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.PostageExportRequest{
+			BatchIDs: []string{batchIDStr},
+			Password: "secret",
+		}),
+		jsonhttptest.WithUnmarshalJSONResponse(&file),
+	)
+
+	// the issuer is kept until the export is confirmed
+	jsonhttptest.Request(t, sourceServer, http.MethodGet, "/stamps/"+batchIDStr+"/buckets", http.StatusOK)
+	jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export/confirm", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PostageExportConfirmRequest{
+			BatchIDs: []string{hex.EncodeToString(postagetesting.MustNewID())},
+		}),
+	)
+	jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export/confirm", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.PostageExportConfirmRequest{BatchIDs: []string{batchIDStr}}),
+	)
+
+	// the exported batch is no longer used by the source node
+	jsonhttptest.Request(t, sourceServer, http.MethodGet, "/stamps/"+batchIDStr+"/buckets", http.StatusNotFound)
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+
+		target := mockpost.New()
+		targetServer, _, _, _ := newTestServer(t, testServerOptions{
+			Post:            target,
+			BatchStore:      mock.New(mock.WithBatch(batch)),
+			EthereumAddress: owner,
+		})
+
+		jsonhttptest.Request(t, targetServer, http.MethodPost, "/stamps/import", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.PostageImportRequest{Password: "wrong", File: file}),
+		)
+
+		jsonhttptest.Request(t, targetServer, http.MethodPost, "/stamps/import", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(api.PostageImportRequest{Password: "secret", File: file}),
+			jsonhttptest.WithExpectedJSONResponse(map[string][]string{"batchIDs": {batchIDStr}}),
+		)
+
+		jsonhttptest.Request(t, targetServer, http.MethodGet, "/stamps/"+batchIDStr+"/buckets", http.StatusOK)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		source := mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("label", "keyID", batch.ID, big.NewInt(3), 16, 8, 10, true)))
+		sourceServer, _, _, _ := newTestServer(t, testServerOptions{Post: source})
+
+		jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(api.PostageExportRequest{
+				BatchIDs: []string{batchIDStr},
+				Password: "secret",
+			}),
+		)
+		jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export/cancel", http.StatusOK,
+			jsonhttptest.WithJSONRequestBody(api.PostageExportConfirmRequest{BatchIDs: []string{batchIDStr}}),
+		)
+		// the canceled export can not be confirmed
+		jsonhttptest.Request(t, sourceServer, http.MethodPost, "/stamps/export/confirm", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.PostageExportConfirmRequest{BatchIDs: []string{batchIDStr}}),
+		)
+		jsonhttptest.Request(t, sourceServer, http.MethodGet, "/stamps/"+batchIDStr+"/buckets", http.StatusOK)
+	})
+
+	t.Run("not owned", func(t *testing.T) {
+		t.Parallel()
+
+		targetServer, _, _, _ := newTestServer(t, testServerOptions{
+			Post:            mockpost.New(),
+			BatchStore:      mock.New(mock.WithBatch(batch)),
+			EthereumAddress: common.HexToAddress("0x1234"),
+		})
+
+		jsonhttptest.Request(t, targetServer, http.MethodPost, "/stamps/import", http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.PostageImportRequest{Password: "secret", File: file}),
+		)
+	})
+}
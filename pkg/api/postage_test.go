@@ -30,6 +30,7 @@ import (
 	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
 	"github.com/calmw/bee-tron/pkg/sctx"
 	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/calmw/bee-tron/pkg/transaction/backendmock"
 )
 
@@ -120,6 +121,26 @@ func TestPostageCreateStamp(t *testing.T) {
 		)
 	})
 
+	t.Run("simulation reverted", func(t *testing.T) {
+		t.Parallel()
+
+		contract := contractMock.New(
+			contractMock.WithCreateBatchFunc(func(ctx context.Context, ib *big.Int, d uint8, i bool, l string) (common.Hash, []byte, error) {
+				return common.Hash{}, nil, &transaction.RevertError{Reason: "ERC20: insufficient allowance"}
+			}),
+		)
+		ts, _, _, _ := newTestServer(t, testServerOptions{
+			PostageContract: contract,
+		})
+
+		jsonhttptest.Request(t, ts, http.MethodPost, createBatch(initialBalance, depth, label), http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "transaction would revert: ERC20: insufficient allowance",
+			}),
+		)
+	})
+
 	t.Run("depth less than bucket depth", func(t *testing.T) {
 		t.Parallel()
 
@@ -300,6 +321,41 @@ func TestGetAllBatches(t *testing.T) {
 			jsonhttptest.WithExpectedJSONResponse(oneBatch),
 		)
 	})
+
+	noBatches := struct {
+		Batches []api.PostageBatchResponse `json:"batches"`
+	}{
+		Batches: []api.PostageBatchResponse{},
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			query string
+			want  interface{}
+		}{
+			{"owner=" + hex.EncodeToString(b.Owner), oneBatch},
+			{"owner=" + hex.EncodeToString(make([]byte, 20)), noBatches},
+			{"immutable=true&sort=ttl&limit=10", oneBatch},
+			{"immutable=false", noBatches},
+			{"minTTL=15", oneBatch},
+			{"minTTL=16", noBatches},
+			{"cursor=" + hex.EncodeToString(b.ID), noBatches},
+		} {
+			jsonhttptest.Request(t, ts, http.MethodGet, "/batches?"+tc.query, http.StatusOK,
+				jsonhttptest.WithExpectedJSONResponse(tc.want),
+			)
+		}
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{"owner=01", "sort=depth", "minTTL=-1", "limit=-1", "cursor=zz"} {
+			jsonhttptest.Request(t, ts, http.MethodGet, "/batches?"+query, http.StatusBadRequest)
+		}
+	})
 }
 
 func TestPostageGetStamp(t *testing.T) {
@@ -353,6 +409,11 @@ func TestPostageGetBuckets(t *testing.T) {
 				Depth:            si.Depth(),
 				BucketDepth:      si.BucketDepth(),
 				BucketUpperBound: si.BucketUpperBound(),
+				Immutable:        true,
+				Histogram:        []uint32{1024, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				BestBucket:       api.BucketData{BucketID: 0},
+				WorstBucket:      api.BucketData{BucketID: 0},
+				Remaining:        si.BucketUpperBound(),
 				Buckets:          buckets,
 			}),
 		)
@@ -425,6 +486,55 @@ func TestChainState(t *testing.T) {
 	})
 }
 
+func TestPostageEstimate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		ts, _, _, _ := newTestServer(t, testServerOptions{
+			BatchStore: mock.New(mock.WithEstimate(&postage.BatchEstimate{
+				Blocks:       100,
+				Commitment:   1 << 17,
+				Radius:       2,
+				ReserveShare: 0.25,
+			})),
+			BlockTime: 5 * time.Second,
+		})
+		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/estimate?amount=10&depth=17", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(&api.PostageEstimateResponse{
+				Amount:       bigint.Wrap(big.NewInt(10)),
+				Depth:        17,
+				TotalCost:    bigint.Wrap(big.NewInt(10 << 17)),
+				BatchTTL:     500,
+				Commitment:   1 << 17,
+				Radius:       2,
+				ReserveShare: 0.25,
+			}),
+		)
+	})
+
+	t.Run("invalid depth", func(t *testing.T) {
+		t.Parallel()
+
+		ts, _, _, _ := newTestServer(t, testServerOptions{
+			BatchStore: mock.New(),
+		})
+		jsonhttptest.Request(t, ts, http.MethodGet, "/stamps/estimate?amount=10&depth=16", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid query params",
+				Reasons: []jsonhttp.Reason{
+					{
+						Field: "depth",
+						Error: "want min:17",
+					},
+				},
+			}),
+		)
+	})
+}
+
 func TestPostageTopUpStamp(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,190 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	errCantSetAllowanceConfig   = "can not set allowance configuration"
+	errCantPeerAllowances       = "can not get peer allowances"
+	errCantPeerAllowance        = "can not get peer allowance"
+	errAllowancesNotAvailable   = "pseudosettle allowances are not available"
+	errNoPseudosettlePeer       = "peer is not connected"
+	errNegativeAllowanceWindow  = "decay window must not be negative"
+	errAllowanceWindowOverflows = "decay window is too large"
+)
+
+type allowanceConfigRequest struct {
+	RefreshRate      *bigint.BigInt `json:"refreshRate,omitempty"`
+	LightRefreshRate *bigint.BigInt `json:"lightRefreshRate,omitempty"`
+	DecayWindow      *int64         `json:"decayWindow,omitempty"`
+}
+
+type allowanceConfigResponse struct {
+	RefreshRate      *bigint.BigInt `json:"refreshRate"`
+	LightRefreshRate *bigint.BigInt `json:"lightRefreshRate"`
+	DecayWindow      int64          `json:"decayWindow"`
+}
+
+type peerAllowanceResponse struct {
+	Peer            string         `json:"peer"`
+	FullNode        bool           `json:"fullNode"`
+	LastRefreshment int64          `json:"lastRefreshment"`
+	Available       *bigint.BigInt `json:"available"`
+	TotalReceived   *bigint.BigInt `json:"totalReceived"`
+}
+
+type peerAllowancesResponse struct {
+	Allowances []peerAllowanceResponse `json:"allowances"`
+}
+
+func newAllowanceConfigResponse(cfg pseudosettle.AllowanceConfig) allowanceConfigResponse {
+	return allowanceConfigResponse{
+		RefreshRate:      bigint.Wrap(cfg.RefreshRate),
+		LightRefreshRate: bigint.Wrap(cfg.LightRefreshRate),
+		DecayWindow:      int64(cfg.DecayWindow / time.Second),
+	}
+}
+
+func newPeerAllowanceResponse(status pseudosettle.AllowanceStatus) peerAllowanceResponse {
+	return peerAllowanceResponse{
+		Peer:            status.Peer.String(),
+		FullNode:        status.FullNode,
+		LastRefreshment: status.LastRefreshment,
+		Available:       bigint.Wrap(status.Available),
+		TotalReceived:   bigint.Wrap(status.TotalReceived),
+	}
+}
+
+func (s *Service) allowanceConfigHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.pseudosettleAllowances == nil {
+		jsonhttp.NotImplemented(w, errAllowancesNotAvailable)
+		return
+	}
+
+	jsonhttp.OK(w, newAllowanceConfigResponse(s.pseudosettleAllowances.AllowanceConfig()))
+}
+
+func (s *Service) setAllowanceConfigHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_timesettlements_allowance").Build()
+
+	if s.pseudosettleAllowances == nil {
+		jsonhttp.NotImplemented(w, errAllowancesNotAvailable)
+		return
+	}
+
+	var req allowanceConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	// fields missing from the request keep their current value
+	cfg := s.pseudosettleAllowances.AllowanceConfig()
+	if req.RefreshRate != nil {
+		cfg.RefreshRate = req.RefreshRate.Int
+	}
+	if req.LightRefreshRate != nil {
+		cfg.LightRefreshRate = req.LightRefreshRate.Int
+	}
+	if req.DecayWindow != nil {
+		if *req.DecayWindow < 0 {
+			jsonhttp.BadRequest(w, errNegativeAllowanceWindow)
+			return
+		}
+		if *req.DecayWindow > math.MaxInt64/int64(time.Second) {
+			jsonhttp.BadRequest(w, errAllowanceWindowOverflows)
+			return
+		}
+		cfg.DecayWindow = time.Duration(*req.DecayWindow) * time.Second
+	}
+
+	err := s.pseudosettleAllowances.SetAllowanceConfig(cfg)
+	if errors.Is(err, pseudosettle.ErrInvalidAllowanceConfig) {
+		logger.Debug("set allowance configuration failed", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	if err != nil {
+		logger.Debug("set allowance configuration failed", "error", err)
+		logger.Error(nil, "set allowance configuration failed")
+		jsonhttp.InternalServerError(w, errCantSetAllowanceConfig)
+		return
+	}
+
+	jsonhttp.OK(w, newAllowanceConfigResponse(s.pseudosettleAllowances.AllowanceConfig()))
+}
+
+func (s *Service) peerAllowancesHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_timesettlements_allowances").Build()
+
+	if s.pseudosettleAllowances == nil {
+		jsonhttp.NotImplemented(w, errAllowancesNotAvailable)
+		return
+	}
+
+	statuses, err := s.pseudosettleAllowances.PeerAllowances()
+	if err != nil {
+		logger.Debug("get peer allowances failed", "error", err)
+		logger.Error(nil, "get peer allowances failed")
+		jsonhttp.InternalServerError(w, errCantPeerAllowances)
+		return
+	}
+
+	resp := peerAllowancesResponse{Allowances: make([]peerAllowanceResponse, 0, len(statuses))}
+	for _, status := range statuses {
+		resp.Allowances = append(resp.Allowances, newPeerAllowanceResponse(status))
+	}
+	sort.Slice(resp.Allowances, func(i, j int) bool {
+		return resp.Allowances[i].Peer < resp.Allowances[j].Peer
+	})
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) peerAllowanceHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_timesettlements_allowance_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.pseudosettleAllowances == nil {
+		jsonhttp.NotImplemented(w, errAllowancesNotAvailable)
+		return
+	}
+
+	status, err := s.pseudosettleAllowances.PeerAllowance(paths.Peer)
+	if errors.Is(err, pseudosettle.ErrNoPseudoSettlePeer) {
+		jsonhttp.NotFound(w, errNoPseudosettlePeer)
+		return
+	}
+	if err != nil {
+		logger.Debug("get peer allowance failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "get peer allowance failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantPeerAllowance)
+		return
+	}
+
+	jsonhttp.OK(w, newPeerAllowanceResponse(status))
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type allowancesMock struct {
+	cfg      pseudosettle.AllowanceConfig
+	statuses []pseudosettle.AllowanceStatus
+}
+
+func (m *allowancesMock) AllowanceConfig() pseudosettle.AllowanceConfig {
+	return m.cfg
+}
+
+func (m *allowancesMock) SetAllowanceConfig(cfg pseudosettle.AllowanceConfig) error {
+	if cfg.RefreshRate.Sign() <= 0 {
+		return pseudosettle.ErrInvalidAllowanceConfig
+	}
+	m.cfg = cfg
+	return nil
+}
+
+func (m *allowancesMock) PeerAllowance(peer swarm.Address) (pseudosettle.AllowanceStatus, error) {
+	for _, status := range m.statuses {
+		if status.Peer.Equal(peer) {
+			return status, nil
+		}
+	}
+	return pseudosettle.AllowanceStatus{}, pseudosettle.ErrNoPseudoSettlePeer
+}
+
+func (m *allowancesMock) PeerAllowances() ([]pseudosettle.AllowanceStatus, error) {
+	return m.statuses, nil
+}
+
+func TestPseudosettleAllowance(t *testing.T) {
+	t.Parallel()
+
+	peer := swarm.MustParseHexAddress("abcd")
+	allowances := &allowancesMock{
+		cfg: pseudosettle.AllowanceConfig{
+			RefreshRate:      big.NewInt(100),
+			LightRefreshRate: big.NewInt(10),
+		},
+		statuses: []pseudosettle.AllowanceStatus{{
+			Peer:            peer,
+			LastRefreshment: 1000,
+			Available:       big.NewInt(50),
+			TotalReceived:   big.NewInt(500),
+		}},
+	}
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		PseudosettleAllowances: allowances,
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowance", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.AllowanceConfigResponse{
+			RefreshRate:      bigint.Wrap(big.NewInt(100)),
+			LightRefreshRate: bigint.Wrap(big.NewInt(10)),
+		}),
+	)
+
+	// fields missing from the request are kept
+	window := int64(60)
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/timesettlements/allowance", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.AllowanceConfigRequest{
+			LightRefreshRate: bigint.Wrap(big.NewInt(20)),
+			DecayWindow:      &window,
+		}),
+		jsonhttptest.WithExpectedJSONResponse(api.AllowanceConfigResponse{
+			RefreshRate:      bigint.Wrap(big.NewInt(100)),
+			LightRefreshRate: bigint.Wrap(big.NewInt(20)),
+			DecayWindow:      60,
+		}),
+	)
+	if allowances.cfg.DecayWindow != time.Minute {
+		t.Fatalf("got decay window %v, want %v", allowances.cfg.DecayWindow, time.Minute)
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/timesettlements/allowance", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.AllowanceConfigRequest{
+			RefreshRate: bigint.Wrap(big.NewInt(0)),
+		}),
+	)
+
+	expected := api.PeerAllowanceResponse{
+		Peer:            peer.String(),
+		LastRefreshment: 1000,
+		Available:       bigint.Wrap(big.NewInt(50)),
+		TotalReceived:   bigint.Wrap(big.NewInt(500)),
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowances", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PeerAllowancesResponse{
+			Allowances: []api.PeerAllowanceResponse{expected},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowances/"+peer.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(expected),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowances/1234", http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: api.ErrNoPseudosettlePeer,
+			Code:    http.StatusNotFound,
+		}),
+	)
+}
+
+func TestPseudosettleAllowanceNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowance", http.StatusNotImplemented)
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/timesettlements/allowances", http.StatusNotImplemented)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/pusher"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	errDeadLettersNotAvailable = "pusher dead letters are not available"
+	errCantDeleteDeadLetter    = "can not delete dead letter"
+)
+
+type deadLetterResponse struct {
+	Address  swarm.Address `json:"address"`
+	BatchID  string        `json:"batchID"`
+	Errors   []string      `json:"errors"`
+	Failed   int64         `json:"failed"`
+	Redrives int           `json:"redrives"`
+}
+
+type deadLettersResponse struct {
+	DeadLetters []deadLetterResponse `json:"deadLetters"`
+}
+
+type redriveDeadLettersResponse struct {
+	Redriven int `json:"redriven"`
+}
+
+func (s *Service) deadLettersHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.pusherDeadLetters == nil {
+		jsonhttp.NotImplemented(w, errDeadLettersNotAvailable)
+		return
+	}
+
+	letters := s.pusherDeadLetters.DeadLetters()
+	resp := deadLettersResponse{DeadLetters: make([]deadLetterResponse, 0, len(letters))}
+	for _, l := range letters {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterResponse{
+			Address:  l.Address,
+			BatchID:  hex.EncodeToString(l.BatchID()),
+			Errors:   l.Errors,
+			Failed:   time.Unix(0, l.Failed).Unix(),
+			Redrives: l.Redrives,
+		})
+	}
+	sort.Slice(resp.DeadLetters, func(i, j int) bool {
+		return resp.DeadLetters[i].Address.String() < resp.DeadLetters[j].Address.String()
+	})
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) redriveDeadLettersHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.pusherDeadLetters == nil {
+		jsonhttp.NotImplemented(w, errDeadLettersNotAvailable)
+		return
+	}
+
+	jsonhttp.OK(w, redriveDeadLettersResponse{Redriven: s.pusherDeadLetters.Redrive(swarm.ZeroAddress)})
+}
+
+func (s *Service) redriveDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pusher_deadletter").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.pusherDeadLetters == nil {
+		jsonhttp.NotImplemented(w, errDeadLettersNotAvailable)
+		return
+	}
+
+	n := s.pusherDeadLetters.Redrive(paths.Address)
+	if n == 0 {
+		jsonhttp.NotFound(w, pusher.ErrDeadLetterNotFound)
+		return
+	}
+
+	jsonhttp.OK(w, redriveDeadLettersResponse{Redriven: n})
+}
+
+func (s *Service) deleteDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pusher_deadletter").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.pusherDeadLetters == nil {
+		jsonhttp.NotImplemented(w, errDeadLettersNotAvailable)
+		return
+	}
+
+	if err := s.pusherDeadLetters.DeleteDeadLetter(paths.Address); err != nil {
+		logger.Debug("delete dead letter failed", "chunk_address", paths.Address, "error", err)
+		if errors.Is(err, pusher.ErrDeadLetterNotFound) {
+			jsonhttp.NotFound(w, err)
+			return
+		}
+		logger.Error(nil, "delete dead letter failed", "chunk_address", paths.Address)
+		jsonhttp.InternalServerError(w, errCantDeleteDeadLetter)
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
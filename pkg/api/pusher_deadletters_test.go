@@ -0,0 +1,116 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/pusher"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type deadLetterQueueMock struct {
+	mu       sync.Mutex
+	letters  []pusher.DeadLetter
+	redriven []swarm.Address
+}
+
+func (m *deadLetterQueueMock) DeadLetters() []pusher.DeadLetter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]pusher.DeadLetter(nil), m.letters...)
+}
+
+func (m *deadLetterQueueMock) Redrive(addr swarm.Address) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, l := range m.letters {
+		if addr.IsZero() || l.Address.Equal(addr) {
+			m.redriven = append(m.redriven, l.Address)
+			n++
+		}
+	}
+	return n
+}
+
+func (m *deadLetterQueueMock) DeleteDeadLetter(addr swarm.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, l := range m.letters {
+		if l.Address.Equal(addr) {
+			m.letters = append(m.letters[:i], m.letters[i+1:]...)
+			return nil
+		}
+	}
+	return pusher.ErrDeadLetterNotFound
+}
+
+func TestPusherDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chunk1  = swarm.MustParseHexAddress("ca1e")
+		chunk2  = swarm.MustParseHexAddress("abcd")
+		missing = swarm.MustParseHexAddress("bcde")
+		stamp   = make([]byte, 113)
+		batchID = stamp[:swarm.HashSize]
+		failed  = time.Now()
+	)
+	batchID[0] = 1
+
+	queue := &deadLetterQueueMock{
+		letters: []pusher.DeadLetter{
+			{Address: chunk1, Stamp: stamp, Errors: []string{"timeout"}, Failed: failed.UnixNano(), Redrives: 2},
+			{Address: chunk2, Errors: []string{"refused"}, Failed: failed.UnixNano()},
+		},
+	}
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		PusherDeadLetters: queue,
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/pusher/deadletters", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.DeadLettersResponse{
+			DeadLetters: []api.DeadLetterResponse{
+				{Address: chunk2, Errors: []string{"refused"}, Failed: failed.Unix()},
+				{Address: chunk1, BatchID: hex.EncodeToString(batchID), Errors: []string{"timeout"}, Failed: failed.Unix(), Redrives: 2},
+			},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/pusher/deadletters/"+chunk1.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.RedriveDeadLettersResponse{Redriven: 1}),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/pusher/deadletters/"+missing.String(), http.StatusNotFound)
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/pusher/deadletters", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.RedriveDeadLettersResponse{Redriven: 2}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/pusher/deadletters/"+chunk1.String(), http.StatusOK)
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/pusher/deadletters/"+chunk1.String(), http.StatusNotFound)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/pusher/deadletters", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.DeadLettersResponse{
+			DeadLetters: []api.DeadLetterResponse{
+				{Address: chunk2, Errors: []string{"refused"}, Failed: failed.Unix()},
+			},
+		}),
+	)
+}
+
+func TestPusherDeadLettersNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/pusher/deadletters", http.StatusNotImplemented)
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/pusher/deadletters/ca1e", http.StatusNotImplemented)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/pusher"
+)
+
+const (
+	errCantSetPusherLimits = "can not set pusher limits"
+	errPusherNotAvailable  = "pusher is not available"
+	errPusherSaturated     = "too many chunks waiting to be pushed, retry later"
+	pusherRetryAfter       = "1" // seconds the clients wait before retrying the rejected uploads
+)
+
+type pusherLimitsRequest struct {
+	Workers       *int `json:"workers,omitempty"`
+	PeerInflight  *int `json:"peerInflight,omitempty"`
+	QueueCapacity *int `json:"queueCapacity,omitempty"`
+}
+
+type pusherLimitsResponse struct {
+	Workers       int  `json:"workers"`
+	PeerInflight  int  `json:"peerInflight"`
+	QueueCapacity int  `json:"queueCapacity"`
+	Queued        int  `json:"queued"`
+	Saturated     bool `json:"saturated"`
+}
+
+func newPusherLimitsResponse(c pusher.Controller) pusherLimitsResponse {
+	l := c.Limits()
+	return pusherLimitsResponse{
+		Workers:       l.Workers,
+		PeerInflight:  l.PeerInflight,
+		QueueCapacity: l.QueueCapacity,
+		Queued:        c.Queued(),
+		Saturated:     c.Saturated(),
+	}
+}
+
+func (s *Service) pusherLimitsHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.pusher == nil {
+		jsonhttp.NotImplemented(w, errPusherNotAvailable)
+		return
+	}
+
+	jsonhttp.OK(w, newPusherLimitsResponse(s.pusher))
+}
+
+func (s *Service) setPusherLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_pusher_limits").Build()
+
+	if s.pusher == nil {
+		jsonhttp.NotImplemented(w, errPusherNotAvailable)
+		return
+	}
+
+	var req pusherLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	// fields missing from the request keep their current value
+	limits := s.pusher.Limits()
+	if req.Workers != nil {
+		limits.Workers = *req.Workers
+	}
+	if req.PeerInflight != nil {
+		limits.PeerInflight = *req.PeerInflight
+	}
+	if req.QueueCapacity != nil {
+		limits.QueueCapacity = *req.QueueCapacity
+	}
+
+	if err := s.pusher.SetLimits(limits); err != nil {
+		logger.Debug("set pusher limits failed", "error", err)
+		if errors.Is(err, pusher.ErrInvalidLimits) {
+			jsonhttp.BadRequest(w, err)
+			return
+		}
+		logger.Error(nil, "set pusher limits failed")
+		jsonhttp.InternalServerError(w, errCantSetPusherLimits)
+		return
+	}
+
+	jsonhttp.OK(w, newPusherLimitsResponse(s.pusher))
+}
+
+// checkPusherBackpressure rejects the uploads while the pusher queue is
+// full, so that the clients slow down until the queued chunks are pushed.
+func (s *Service) checkPusherBackpressure(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.pusher != nil && s.pusher.Saturated() {
+			w.Header().Set("Retry-After", pusherRetryAfter)
+			jsonhttp.TooManyRequests(w, errPusherSaturated)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
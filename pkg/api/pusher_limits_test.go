@@ -0,0 +1,133 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/pusher"
+)
+
+type pusherControllerMock struct {
+	mu        sync.Mutex
+	limits    pusher.Limits
+	queued    int
+	saturated bool
+}
+
+func (m *pusherControllerMock) Limits() pusher.Limits {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.limits
+}
+
+func (m *pusherControllerMock) SetLimits(l pusher.Limits) error {
+	if l.Workers <= 0 || l.PeerInflight < 0 || l.QueueCapacity <= 0 {
+		return pusher.ErrInvalidLimits
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limits = l
+	return nil
+}
+
+func (m *pusherControllerMock) Queued() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.queued
+}
+
+func (m *pusherControllerMock) Saturated() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saturated
+}
+
+func (m *pusherControllerMock) setQueued(queued int, saturated bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queued, m.saturated = queued, saturated
+}
+
+func TestPusherLimits(t *testing.T) {
+	t.Parallel()
+
+	controller := &pusherControllerMock{
+		limits: pusher.Limits{Workers: 128, QueueCapacity: 256},
+		queued: 10,
+	}
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		Pusher: controller,
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/pusher/limits", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.PusherLimitsResponse{
+			Workers:       128,
+			QueueCapacity: 256,
+			Queued:        10,
+		}),
+	)
+
+	workers, peerInflight := 16, 4
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/pusher/limits", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.PusherLimitsRequest{
+			Workers:      &workers,
+			PeerInflight: &peerInflight,
+		}),
+		jsonhttptest.WithExpectedJSONResponse(api.PusherLimitsResponse{
+			Workers:       16,
+			PeerInflight:  4,
+			QueueCapacity: 256,
+			Queued:        10,
+		}),
+	)
+
+	invalid := 0
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/pusher/limits", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.PusherLimitsRequest{
+			QueueCapacity: &invalid,
+		}),
+	)
+	if got := controller.Limits().QueueCapacity; got != 256 {
+		t.Fatalf("got queue capacity %d, want 256", got)
+	}
+}
+
+func TestPusherBackpressure(t *testing.T) {
+	t.Parallel()
+
+	controller := &pusherControllerMock{
+		limits: pusher.Limits{Workers: 128, QueueCapacity: 256},
+	}
+	controller.setQueued(256, true)
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		Pusher: controller,
+	})
+
+	for _, path := range []string{"/bytes", "/bzz", "/chunks"} {
+		jsonhttptest.Request(t, testServer, http.MethodPost, path, http.StatusTooManyRequests,
+			jsonhttptest.WithExpectedResponseHeader("Retry-After", "1"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusTooManyRequests,
+				Message: "too many chunks waiting to be pushed, retry later",
+			}),
+		)
+	}
+}
+
+func TestPusherLimitsNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/pusher/limits", http.StatusNotImplemented)
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/pusher/limits", http.StatusNotImplemented)
+}
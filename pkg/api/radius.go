@@ -0,0 +1,89 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+)
+
+type storageRadiusResponse struct {
+	StorageRadius   uint8  `json:"storageRadius"`
+	AutomaticRadius uint8  `json:"automaticRadius"`
+	Pinned          bool   `json:"pinned"`
+	PinnedRadius    *uint8 `json:"pinnedRadius,omitempty"`
+	Offset          int    `json:"offset"`
+}
+
+func (s *Service) storageRadiusResponse() storageRadiusResponse {
+	o := s.storer.RadiusOverride()
+	resp := storageRadiusResponse{
+		StorageRadius:   s.storer.StorageRadius(),
+		AutomaticRadius: s.storer.AutomaticRadius(),
+		Pinned:          o.Pinned,
+		Offset:          o.Offset,
+	}
+	if o.Pinned {
+		resp.PinnedRadius = &o.Radius
+	}
+	return resp
+}
+
+// storageRadiusHandler returns the storage radius, the automatic radius and
+// the override applied to it.
+func (s *Service) storageRadiusHandler(w http.ResponseWriter, _ *http.Request) {
+	jsonhttp.OK(w, s.storageRadiusResponse())
+}
+
+// storageRadiusOverrideHandler pins the storage radius to the pin query
+// parameter or offsets it from the automatic radius by the offset query
+// parameter.
+func (s *Service) storageRadiusOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_storageradius").Build()
+
+	queries := struct {
+		Pin    *uint8 `map:"pin"`
+		Offset int    `map:"offset"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	override := storer.RadiusOverride{Offset: queries.Offset}
+	if queries.Pin != nil {
+		override.Pinned = true
+		override.Radius = *queries.Pin
+	}
+	s.setRadiusOverride(w, override)
+}
+
+// storageRadiusResetHandler removes the override of the storage radius.
+func (s *Service) storageRadiusResetHandler(w http.ResponseWriter, _ *http.Request) {
+	s.setRadiusOverride(w, storer.RadiusOverride{})
+}
+
+func (s *Service) setRadiusOverride(w http.ResponseWriter, override storer.RadiusOverride) {
+	logger := s.logger.WithName("storageradius_override").Build()
+
+	if err := s.storer.SetRadiusOverride(override); err != nil {
+		switch {
+		case errors.Is(err, storer.ErrInvalidRadiusOverride):
+			jsonhttp.BadRequest(w, "invalid radius override")
+		case errors.Is(err, storer.ErrReserveDisabled):
+			jsonhttp.BadRequest(w, "reserve disabled")
+		default:
+			logger.Debug("set radius override failed", "error", err)
+			logger.Error(nil, "set radius override failed")
+			jsonhttp.InternalServerError(w, "set radius override failed")
+		}
+		return
+	}
+
+	jsonhttp.OK(w, s.storageRadiusResponse())
+}
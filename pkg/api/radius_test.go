@@ -0,0 +1,52 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+)
+
+func TestStorageRadiusOverride(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+	})
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/storageradius", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.StorageRadiusResponse{}),
+	)
+
+	pinned := uint8(8)
+	jsonhttptest.Request(t, client, http.MethodPut, "/storageradius?pin=8", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.StorageRadiusResponse{
+			Pinned:       true,
+			PinnedRadius: &pinned,
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/storageradius?offset=-1", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.StorageRadiusResponse{
+			Offset: -1,
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPut, "/storageradius?pin=32", http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid radius override",
+		}),
+	)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, "/storageradius", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.StorageRadiusResponse{}),
+	)
+}
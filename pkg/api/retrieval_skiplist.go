@@ -0,0 +1,92 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/skippeers"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const errSkiplistNotAvailable = "retrieval skiplist is not available"
+
+type skippedPeerResponse struct {
+	Peer   swarm.Address `json:"peer"`
+	Chunk  string        `json:"chunk"`
+	Expiry int64         `json:"expiry"`
+}
+
+type skiplistResponse struct {
+	Peers []skippedPeerResponse `json:"peers"`
+}
+
+type clearSkiplistResponse struct {
+	Removed int `json:"removed"`
+}
+
+func newSkippedPeerResponse(e skippeers.Entry) skippedPeerResponse {
+	resp := skippedPeerResponse{
+		Peer:  e.Peer,
+		Chunk: e.Chunk.String(),
+	}
+	if !e.Forever() {
+		resp.Expiry = time.Unix(0, e.Expiry).Unix()
+	}
+	return resp
+}
+
+func (s *Service) retrievalSkiplistHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.retrievalSkiplist == nil {
+		jsonhttp.NotImplemented(w, errSkiplistNotAvailable)
+		return
+	}
+
+	entries := s.retrievalSkiplist.SkippedPeers()
+	resp := skiplistResponse{Peers: make([]skippedPeerResponse, 0, len(entries))}
+	for _, e := range entries {
+		resp.Peers = append(resp.Peers, newSkippedPeerResponse(e))
+	}
+	sort.Slice(resp.Peers, func(i, j int) bool {
+		if a, b := resp.Peers[i].Peer.String(), resp.Peers[j].Peer.String(); a != b {
+			return a < b
+		}
+		return resp.Peers[i].Chunk < resp.Peers[j].Chunk
+	})
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) clearRetrievalSkiplistHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.retrievalSkiplist == nil {
+		jsonhttp.NotImplemented(w, errSkiplistNotAvailable)
+		return
+	}
+
+	jsonhttp.OK(w, clearSkiplistResponse{Removed: s.retrievalSkiplist.ClearSkippedPeers(swarm.ZeroAddress)})
+}
+
+func (s *Service) clearRetrievalSkiplistPeerHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_retrieval_skiplist_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.retrievalSkiplist == nil {
+		jsonhttp.NotImplemented(w, errSkiplistNotAvailable)
+		return
+	}
+
+	jsonhttp.OK(w, clearSkiplistResponse{Removed: s.retrievalSkiplist.ClearSkippedPeers(paths.Peer)})
+}
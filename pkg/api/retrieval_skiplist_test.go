@@ -0,0 +1,93 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/skippeers"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type skiplistMock struct {
+	*skippeers.List
+}
+
+func (m skiplistMock) SkippedPeers() []skippeers.Entry {
+	return m.Entries()
+}
+
+func (m skiplistMock) ClearSkippedPeers(peer swarm.Address) int {
+	return m.Clear(peer)
+}
+
+func TestRetrievalSkiplist(t *testing.T) {
+	t.Parallel()
+
+	list := skippeers.NewList(0)
+	t.Cleanup(func() { list.Close() })
+
+	chunk := swarm.MustParseHexAddress("ca1e")
+	peer1 := swarm.MustParseHexAddress("abcd")
+	peer2 := swarm.MustParseHexAddress("bcde")
+
+	list.Forever(chunk, peer1)
+	list.AddPeer(peer2, time.Hour)
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		RetrievalSkiplist: skiplistMock{list},
+	})
+
+	var peer2Expiry int64
+	for _, e := range list.Entries() {
+		if e.Peer.Equal(peer2) {
+			peer2Expiry = time.Unix(0, e.Expiry).Unix()
+		}
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/retrieval/skiplist", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SkiplistResponse{
+			Peers: []api.SkippedPeerResponse{
+				{Peer: peer1, Chunk: chunk.String()},
+				{Peer: peer2, Expiry: peer2Expiry},
+			},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/retrieval/skiplist/"+peer1.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ClearSkiplistResponse{Removed: 1}),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/retrieval/skiplist", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SkiplistResponse{
+			Peers: []api.SkippedPeerResponse{
+				{Peer: peer2, Expiry: peer2Expiry},
+			},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/retrieval/skiplist", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.ClearSkiplistResponse{Removed: 1}),
+	)
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/retrieval/skiplist", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SkiplistResponse{
+			Peers: []api.SkippedPeerResponse{},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/retrieval/skiplist/zz", http.StatusBadRequest)
+}
+
+func TestRetrievalSkiplistNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/retrieval/skiplist", http.StatusNotImplemented)
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/retrieval/skiplist", http.StatusNotImplemented)
+}
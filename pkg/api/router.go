@@ -176,6 +176,10 @@ func (s *Service) mountTechnicalDebug() {
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandlerFunc(s.healthHandler),
 	))
+
+	s.router.Handle("/migration", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.migrationStatusHandler),
+	})
 }
 
 func (s *Service) checkRouteAvailability(handler http.Handler) http.Handler {
@@ -234,6 +238,7 @@ func (s *Service) mountAPI() {
 
 	handle("/bytes", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
 			s.contentLengthMetricMiddleware(),
 			s.newTracingHandler("bytes-upload"),
 			web.FinalHandlerFunc(s.bytesUploadHandler),
@@ -257,12 +262,14 @@ func (s *Service) mountAPI() {
 
 	handle("/chunks", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
 			jsonhttp.NewMaxBodyBytesHandler(swarm.SocMaxChunkSize),
 			web.FinalHandlerFunc(s.chunkUploadHandler),
 		),
 	})
 
 	handle("/chunks/stream", web.ChainHandlers(
+		s.checkPusherBackpressure,
 		s.newTracingHandler("chunks-stream-upload"),
 		web.FinalHandlerFunc(s.chunkUploadStreamHandler),
 	))
@@ -285,6 +292,7 @@ func (s *Service) mountAPI() {
 	handle("/soc/{owner}/{id}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.socGetHandler),
 		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkWithSpanSize),
 			web.FinalHandlerFunc(s.socUploadHandler),
 		),
@@ -293,13 +301,17 @@ func (s *Service) mountAPI() {
 	handle("/feeds/{owner}/{topic}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.feedGetHandler),
 		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkWithSpanSize),
 			web.FinalHandlerFunc(s.feedPostHandler),
 		),
 	})
 
+	handle("/feeds/{owner}/{topic}/watch", http.HandlerFunc(s.feedWatchWsHandler))
+
 	handle("/bzz", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
 			s.contentLengthMetricMiddleware(),
 			s.newTracingHandler("bzz-upload"),
 			web.FinalHandlerFunc(s.bzzUploadHandler),
@@ -335,6 +347,17 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/manifests/{base}/diff/{other}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.manifestDiffHandler),
+	})
+
+	handle("/manifests/{base}/merge/{patch}", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			s.checkPusherBackpressure,
+			web.FinalHandlerFunc(s.manifestMergeHandler),
+		),
+	})
+
 	handle("/pss/send/{topic}/{targets}", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
@@ -356,6 +379,10 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/tags/incomplete", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.listIncompleteTagsHandler),
+	})
+
 	handle("/tags/{id}", jsonhttp.MethodHandler{
 		"GET":    http.HandlerFunc(s.getTagHandler),
 		"DELETE": http.HandlerFunc(s.deleteTagHandler),
@@ -365,6 +392,14 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/tags/{id}/resume", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.resumeTagHandler),
+	})
+
+	handle("/tags/{id}/purge", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.purgeTagHandler),
+	})
+
 	handle("/pins", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.listPinnedRootHashes),
 	})
@@ -373,6 +408,19 @@ func (s *Service) mountAPI() {
 		"GET": http.HandlerFunc(s.pinIntegrityHandler),
 	})
 
+	handle("/pins/usage", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.pinsUsageHandler),
+	})
+
+	handle("/pins/verify", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.pinVerifyJobsHandler),
+	})
+
+	handle("/pins/verify/{id}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.pinVerifyJobHandler),
+		"DELETE": http.HandlerFunc(s.pinVerifyCancelHandler),
+	})
+
 	handle("/pins/{reference}", jsonhttp.MethodHandler{
 		"GET":    http.HandlerFunc(s.getPinnedRootHash),
 		"POST":   http.HandlerFunc(s.pinRootHash),
@@ -380,10 +428,18 @@ func (s *Service) mountAPI() {
 	},
 	)
 
+	handle("/pins/{reference}/verify", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.pinVerifyStartHandler),
+	})
+
 	handle("/stewardship/{address}", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.stewardshipGetHandler),
 		"PUT": http.HandlerFunc(s.stewardshipPutHandler),
 	})
+
+	handle("/verify/{address}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.verifyHandler),
+	})
 }
 
 func (s *Service) mountBusinessDebug() {
@@ -398,6 +454,15 @@ func (s *Service) mountBusinessDebug() {
 			"GET": http.HandlerFunc(s.transactionListHandler),
 		})
 
+		handle("/transactions/history", jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.transactionHistoryHandler),
+		})
+
+		handle("/transactions/nonce", jsonhttp.MethodHandler{
+			"GET":  http.HandlerFunc(s.transactionNonceStatusHandler),
+			"POST": http.HandlerFunc(s.transactionNonceRepairHandler),
+		})
+
 		handle("/transactions/{hash}", jsonhttp.MethodHandler{
 			"GET":    http.HandlerFunc(s.transactionDetailHandler),
 			"POST":   http.HandlerFunc(s.transactionResendHandler),
@@ -417,6 +482,32 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.reserveStateHandler),
 	})
 
+	handle("/eviction", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.evictionStatusHandler),
+	})
+
+	handle("/eviction/{worker}/pause", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.evictionPauseHandler),
+	})
+
+	handle("/eviction/{worker}/resume", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.evictionResumeHandler),
+	})
+
+	handle("/usageforecast", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.usageForecastHandler),
+	})
+
+	handle("/dedup", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.dedupStatsHandler),
+	})
+
+	handle("/storageradius", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.storageRadiusHandler),
+		"PUT":    http.HandlerFunc(s.storageRadiusOverrideHandler),
+		"DELETE": http.HandlerFunc(s.storageRadiusResetHandler),
+	})
+
 	handle("/connect/{multi-address:.+}", jsonhttp.MethodHandler{
 		"POST": http.HandlerFunc(s.peerConnectHandler),
 	})
@@ -425,6 +516,34 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.blocklistedPeersHandler),
 	})
 
+	handle("/retrieval/skiplist", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.retrievalSkiplistHandler),
+		"DELETE": http.HandlerFunc(s.clearRetrievalSkiplistHandler),
+	})
+
+	handle("/retrieval/skiplist/{peer}", jsonhttp.MethodHandler{
+		"DELETE": http.HandlerFunc(s.clearRetrievalSkiplistPeerHandler),
+	})
+
+	handle("/pusher/limits", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.pusherLimitsHandler),
+		"PUT": http.HandlerFunc(s.setPusherLimitsHandler),
+	})
+
+	handle("/pusher/deadletters", jsonhttp.MethodHandler{
+		"GET":  http.HandlerFunc(s.deadLettersHandler),
+		"POST": http.HandlerFunc(s.redriveDeadLettersHandler),
+	})
+
+	handle("/pusher/deadletters/{address}", jsonhttp.MethodHandler{
+		"POST":   http.HandlerFunc(s.redriveDeadLetterHandler),
+		"DELETE": http.HandlerFunc(s.deleteDeadLetterHandler),
+	})
+
+	handle("/syncstate", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.syncStateHandler),
+	})
+
 	handle("/peers/{address}", jsonhttp.MethodHandler{
 		"DELETE": http.HandlerFunc(s.peerDisconnectHandler),
 	})
@@ -433,6 +552,16 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.topologyHandler),
 	})
 
+	handle("/topology/neighborhood", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.topologyNeighborhoodHandler),
+	})
+
+	handle("/topology/changes", http.HandlerFunc(s.topologyChangesWsHandler))
+
+	handle("/topology/history/{address}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.topologyHistoryHandler),
+	})
+
 	handle("/welcome-message", jsonhttp.MethodHandler{
 		"GET": http.HandlerFunc(s.getWelcomeMessageHandler),
 		"POST": web.ChainHandlers(
@@ -461,6 +590,19 @@ func (s *Service) mountBusinessDebug() {
 		"GET": http.HandlerFunc(s.settlementsHandlerPseudosettle),
 	})
 
+	handle("/timesettlements/allowance", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.allowanceConfigHandler),
+		"PUT": http.HandlerFunc(s.setAllowanceConfigHandler),
+	})
+
+	handle("/timesettlements/allowances", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.peerAllowancesHandler),
+	})
+
+	handle("/timesettlements/allowances/{peer}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.peerAllowanceHandler),
+	})
+
 	handle("/settlements", web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
@@ -468,6 +610,16 @@ func (s *Service) mountBusinessDebug() {
 		}),
 	))
 
+	handle("/settlements/policies", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.settlementPoliciesHandler),
+	})
+
+	handle("/settlements/policies/{peer}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.peerSettlementPolicyHandler),
+		"PUT":    http.HandlerFunc(s.setPeerSettlementPolicyHandler),
+		"DELETE": http.HandlerFunc(s.deletePeerSettlementPolicyHandler),
+	})
+
 	handle("/settlements/{peer}", web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
@@ -475,6 +627,29 @@ func (s *Service) mountBusinessDebug() {
 		}),
 	))
 
+	handle("/swap/deductions", web.ChainHandlers(
+		s.checkSwapAvailability,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.swapDeductionsHandler),
+		}),
+	))
+
+	handle("/swap/addressbook", web.ChainHandlers(
+		s.checkSwapAvailability,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":  http.HandlerFunc(s.swapAddressbookHandler),
+			"POST": http.HandlerFunc(s.swapAddressbookImportHandler),
+		}),
+	))
+
+	handle("/swap/addressbook/{peer}", web.ChainHandlers(
+		s.checkSwapAvailability,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.swapAddressbookPeerHandler),
+			"DELETE": http.HandlerFunc(s.swapAddressbookRemoveHandler),
+		}),
+	))
+
 	handle("/chequebook/cheque/{peer}", web.ChainHandlers(
 		s.checkSwapAvailability,
 		web.FinalHandler(jsonhttp.MethodHandler{
@@ -560,6 +735,43 @@ func (s *Service) mountBusinessDebug() {
 		})),
 	)
 
+	handle("/stamps/export", web.ChainHandlers(
+		s.postageAccessHandler,
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageExportHandler),
+		})),
+	)
+
+	handle("/stamps/export/confirm", web.ChainHandlers(
+		s.postageAccessHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageExportConfirmHandler),
+		})),
+	)
+
+	handle("/stamps/export/cancel", web.ChainHandlers(
+		s.postageAccessHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageExportCancelHandler),
+		})),
+	)
+
+	handle("/stamps/import", web.ChainHandlers(
+		s.postageAccessHandler,
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"POST": http.HandlerFunc(s.postageImportHandler),
+		})),
+	)
+
+	handle("/stamps/estimate", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.postageEstimateHandler),
+		})),
+	)
+
 	handle("/stamps/{batch_id}", web.ChainHandlers(
 		s.postageSyncStatusCheckHandler,
 		web.FinalHandler(jsonhttp.MethodHandler{
@@ -574,6 +786,15 @@ func (s *Service) mountBusinessDebug() {
 		})),
 	)
 
+	handle("/stamps/{batch_id}/expiry-policy", web.ChainHandlers(
+		s.postageSyncStatusCheckHandler,
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.postageGetExpiryPolicyHandler),
+			"PUT":    http.HandlerFunc(s.postageSetExpiryPolicyHandler),
+			"DELETE": http.HandlerFunc(s.postageDeleteExpiryPolicyHandler),
+		})),
+	)
+
 	handle("/stamps/{amount}/{depth}", web.ChainHandlers(
 		s.postageAccessHandler,
 		s.postageSyncStatusCheckHandler,
@@ -46,6 +46,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
+				{"/migration", []string{"GET"}, http.StatusNoContent},
 				{"/metrics", nil, http.StatusOK},
 				{"/not_found", nil, http.StatusNotFound},
 
@@ -60,6 +61,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/watch", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
@@ -68,20 +70,41 @@ func TestEndpointOptions(t *testing.T) {
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/tags/incomplete", []string{"GET"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
+				{"/tags/{id}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/tags/{id}/purge", []string{"POST"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/usage", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify/{id}", []string{"GET", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pins/{reference}/verify", []string{"POST"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
 				// routes from mountBusinessDebug
 				{"/transactions", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/history", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/nonce", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/transactions/{hash}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/peers", []string{"GET"}, http.StatusNoContent},
 				{"/pingpong/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/reservestate", []string{"GET"}, http.StatusNoContent},
+				{"/eviction", []string{"GET"}, http.StatusNoContent},
+				{"/eviction/{worker}/pause", []string{"POST"}, http.StatusNoContent},
+				{"/eviction/{worker}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/usageforecast", []string{"GET"}, http.StatusNoContent},
+				{"/dedup", []string{"GET"}, http.StatusNoContent},
+				{"/storageradius", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/connect/{multi-address:.+}", []string{"POST"}, http.StatusNoContent},
 				{"/blocklist", []string{"GET"}, http.StatusNoContent},
+				{"/retrieval/skiplist", []string{"GET", "DELETE"}, http.StatusNoContent},
+				{"/retrieval/skiplist/{peer}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pusher/limits", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/pusher/deadletters", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/pusher/deadletters/{address}", []string{"POST", "DELETE"}, http.StatusNoContent},
+				{"/syncstate", []string{"GET"}, http.StatusNoContent},
 				{"/peers/{address}", []string{"DELETE"}, http.StatusNoContent},
 				{"/topology", []string{"GET"}, http.StatusNoContent},
 				{"/welcome-message", []string{"GET", "POST"}, http.StatusNoContent},
@@ -90,8 +113,16 @@ func TestEndpointOptions(t *testing.T) {
 				{"/consumed", []string{"GET"}, http.StatusNoContent},
 				{"/consumed/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/timesettlements", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowance", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/timesettlements/allowances", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowances/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/settlements", []string{"GET"}, http.StatusNoContent},
 				{"/settlements/{peer}", []string{"GET"}, http.StatusNoContent},
+				{"/swap/deductions", []string{"GET"}, http.StatusNoContent},
+				{"/swap/addressbook", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/swap/addressbook/{peer}", []string{"GET", "DELETE"}, http.StatusNoContent},
+				{"/settlements/policies", []string{"GET"}, http.StatusNoContent},
+				{"/settlements/policies/{peer}", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/chequebook/cheque/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/chequebook/cheque", []string{"GET"}, http.StatusNoContent},
 				{"/chequebook/cashout/{peer}", []string{"GET", "POST"}, http.StatusNoContent},
@@ -102,8 +133,12 @@ func TestEndpointOptions(t *testing.T) {
 				{"/wallet", []string{"GET"}, http.StatusNoContent},
 				{"/wallet/withdraw/{coin}", []string{"POST"}, http.StatusNoContent},
 				{"/stamps", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/export", []string{"POST"}, http.StatusNoContent},
+				{"/stamps/estimate", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/import", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/{batch_id}", []string{"GET"}, http.StatusNoContent},
 				{"/stamps/{batch_id}/buckets", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/{batch_id}/expiry-policy", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/stamps/{amount}/{depth}", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/topup/{batch_id}/{amount}", []string{"PATCH"}, http.StatusNoContent},
 				{"/stamps/dilute/{batch_id}/{depth}", []string{"PATCH"}, http.StatusNoContent},
@@ -141,6 +176,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
+				{"/migration", []string{"GET"}, http.StatusNoContent},
 				{"/metrics", nil, http.StatusOK},
 				{"/not_found", nil, http.StatusNotFound},
 
@@ -155,6 +191,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", nil, http.StatusServiceUnavailable},
 				{"/soc/{owner}/{id}", nil, http.StatusServiceUnavailable},
 				{"/feeds/{owner}/{topic}", nil, http.StatusServiceUnavailable},
+				{"/feeds/{owner}/{topic}/watch", nil, http.StatusServiceUnavailable},
 				{"/bzz", nil, http.StatusServiceUnavailable},
 				{"/grantee", nil, http.StatusServiceUnavailable},
 				{"/grantee/{address}", nil, http.StatusServiceUnavailable},
@@ -163,20 +200,41 @@ func TestEndpointOptions(t *testing.T) {
 				{"/pss/send/{topic}/{targets}", nil, http.StatusServiceUnavailable},
 				{"/pss/subscribe/{topic}", nil, http.StatusServiceUnavailable},
 				{"/tags", nil, http.StatusServiceUnavailable},
+				{"/tags/incomplete", nil, http.StatusServiceUnavailable},
 				{"/tags/{id}", nil, http.StatusServiceUnavailable},
+				{"/tags/{id}/resume", nil, http.StatusServiceUnavailable},
+				{"/tags/{id}/purge", nil, http.StatusServiceUnavailable},
 				{"/pins", nil, http.StatusServiceUnavailable},
 				{"/pins/check", nil, http.StatusServiceUnavailable},
+				{"/pins/usage", nil, http.StatusServiceUnavailable},
+				{"/pins/verify", nil, http.StatusServiceUnavailable},
+				{"/pins/verify/{id}", nil, http.StatusServiceUnavailable},
 				{"/pins/{reference}", nil, http.StatusServiceUnavailable},
+				{"/pins/{reference}/verify", nil, http.StatusServiceUnavailable},
 				{"/stewardship/{address}", nil, http.StatusServiceUnavailable},
 
 				// routes from mountBusinessDebug
 				{"/transactions", nil, http.StatusServiceUnavailable},
+				{"/transactions/history", nil, http.StatusServiceUnavailable},
+				{"/transactions/nonce", nil, http.StatusServiceUnavailable},
 				{"/transactions/{hash}", nil, http.StatusServiceUnavailable},
 				{"/peers", nil, http.StatusServiceUnavailable},
 				{"/pingpong/{address}", nil, http.StatusServiceUnavailable},
 				{"/reservestate", nil, http.StatusServiceUnavailable},
+				{"/eviction", nil, http.StatusServiceUnavailable},
+				{"/eviction/{worker}/pause", nil, http.StatusServiceUnavailable},
+				{"/eviction/{worker}/resume", nil, http.StatusServiceUnavailable},
+				{"/usageforecast", nil, http.StatusServiceUnavailable},
+				{"/dedup", nil, http.StatusServiceUnavailable},
+				{"/storageradius", nil, http.StatusServiceUnavailable},
 				{"/connect/{multi-address:.+}", nil, http.StatusServiceUnavailable},
 				{"/blocklist", nil, http.StatusServiceUnavailable},
+				{"/retrieval/skiplist", nil, http.StatusServiceUnavailable},
+				{"/retrieval/skiplist/{peer}", nil, http.StatusServiceUnavailable},
+				{"/pusher/limits", nil, http.StatusServiceUnavailable},
+				{"/pusher/deadletters", nil, http.StatusServiceUnavailable},
+				{"/pusher/deadletters/{address}", nil, http.StatusServiceUnavailable},
+				{"/syncstate", nil, http.StatusServiceUnavailable},
 				{"/peers/{address}", nil, http.StatusServiceUnavailable},
 				{"/topology", nil, http.StatusServiceUnavailable},
 				{"/welcome-message", nil, http.StatusServiceUnavailable},
@@ -185,8 +243,16 @@ func TestEndpointOptions(t *testing.T) {
 				{"/consumed", nil, http.StatusServiceUnavailable},
 				{"/consumed/{peer}", nil, http.StatusServiceUnavailable},
 				{"/timesettlements", nil, http.StatusServiceUnavailable},
+				{"/timesettlements/allowance", nil, http.StatusServiceUnavailable},
+				{"/timesettlements/allowances", nil, http.StatusServiceUnavailable},
+				{"/timesettlements/allowances/{peer}", nil, http.StatusServiceUnavailable},
 				{"/settlements", nil, http.StatusServiceUnavailable},
 				{"/settlements/{peer}", nil, http.StatusServiceUnavailable},
+				{"/swap/deductions", nil, http.StatusServiceUnavailable},
+				{"/swap/addressbook", nil, http.StatusServiceUnavailable},
+				{"/swap/addressbook/{peer}", nil, http.StatusServiceUnavailable},
+				{"/settlements/policies", nil, http.StatusServiceUnavailable},
+				{"/settlements/policies/{peer}", nil, http.StatusServiceUnavailable},
 				{"/chequebook/cheque/{peer}", nil, http.StatusServiceUnavailable},
 				{"/chequebook/cheque", nil, http.StatusServiceUnavailable},
 				{"/chequebook/cashout/{peer}", nil, http.StatusServiceUnavailable},
@@ -197,8 +263,12 @@ func TestEndpointOptions(t *testing.T) {
 				{"/wallet", nil, http.StatusServiceUnavailable},
 				{"/wallet/withdraw/{coin}", nil, http.StatusServiceUnavailable},
 				{"/stamps", nil, http.StatusServiceUnavailable},
+				{"/stamps/export", nil, http.StatusServiceUnavailable},
+				{"/stamps/estimate", nil, http.StatusServiceUnavailable},
+				{"/stamps/import", nil, http.StatusServiceUnavailable},
 				{"/stamps/{batch_id}", nil, http.StatusServiceUnavailable},
 				{"/stamps/{batch_id}/buckets", nil, http.StatusServiceUnavailable},
+				{"/stamps/{batch_id}/expiry-policy", nil, http.StatusServiceUnavailable},
 				{"/stamps/{amount}/{depth}", nil, http.StatusServiceUnavailable},
 				{"/stamps/topup/{batch_id}/{amount}", nil, http.StatusServiceUnavailable},
 				{"/stamps/dilute/{batch_id}/{depth}", nil, http.StatusServiceUnavailable},
@@ -236,6 +306,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
+				{"/migration", []string{"GET"}, http.StatusNoContent},
 				{"/metrics", nil, http.StatusOK},
 				{"/not_found", nil, http.StatusNotFound},
 
@@ -250,6 +321,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/watch", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
@@ -258,20 +330,41 @@ func TestEndpointOptions(t *testing.T) {
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/tags/incomplete", []string{"GET"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
+				{"/tags/{id}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/tags/{id}/purge", []string{"POST"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/usage", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify/{id}", []string{"GET", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pins/{reference}/verify", []string{"POST"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
 				// routes from mountBusinessDebug
 				{"/transactions", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/history", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/nonce", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/transactions/{hash}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/peers", []string{"GET"}, http.StatusNoContent},
 				{"/pingpong/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/reservestate", []string{"GET"}, http.StatusNoContent},
+				{"/eviction", []string{"GET"}, http.StatusNoContent},
+				{"/eviction/{worker}/pause", []string{"POST"}, http.StatusNoContent},
+				{"/eviction/{worker}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/usageforecast", []string{"GET"}, http.StatusNoContent},
+				{"/dedup", []string{"GET"}, http.StatusNoContent},
+				{"/storageradius", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/connect/{multi-address:.+}", []string{"POST"}, http.StatusNoContent},
 				{"/blocklist", []string{"GET"}, http.StatusNoContent},
+				{"/retrieval/skiplist", []string{"GET", "DELETE"}, http.StatusNoContent},
+				{"/retrieval/skiplist/{peer}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pusher/limits", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/pusher/deadletters", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/pusher/deadletters/{address}", []string{"POST", "DELETE"}, http.StatusNoContent},
+				{"/syncstate", []string{"GET"}, http.StatusNoContent},
 				{"/peers/{address}", []string{"DELETE"}, http.StatusNoContent},
 				{"/topology", []string{"GET"}, http.StatusNoContent},
 				{"/welcome-message", []string{"GET", "POST"}, http.StatusNoContent},
@@ -280,8 +373,16 @@ func TestEndpointOptions(t *testing.T) {
 				{"/consumed", []string{"GET"}, http.StatusNoContent},
 				{"/consumed/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/timesettlements", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowance", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/timesettlements/allowances", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowances/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/settlements", nil, http.StatusNotImplemented},
 				{"/settlements/{peer}", nil, http.StatusNotImplemented},
+				{"/swap/deductions", nil, http.StatusNotImplemented},
+				{"/swap/addressbook", nil, http.StatusNotImplemented},
+				{"/swap/addressbook/{peer}", nil, http.StatusNotImplemented},
+				{"/settlements/policies", []string{"GET"}, http.StatusNoContent},
+				{"/settlements/policies/{peer}", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/chequebook/cheque/{peer}", nil, http.StatusNotImplemented},
 				{"/chequebook/cheque", nil, http.StatusNotImplemented},
 				{"/chequebook/cashout/{peer}", nil, http.StatusNotImplemented},
@@ -292,8 +393,12 @@ func TestEndpointOptions(t *testing.T) {
 				{"/wallet", nil, http.StatusNotImplemented},
 				{"/wallet/withdraw/{coin}", nil, http.StatusNotImplemented},
 				{"/stamps", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/export", []string{"POST"}, http.StatusNoContent},
+				{"/stamps/estimate", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/import", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/{batch_id}", []string{"GET"}, http.StatusNoContent},
 				{"/stamps/{batch_id}/buckets", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/{batch_id}/expiry-policy", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/stamps/{amount}/{depth}", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/topup/{batch_id}/{amount}", []string{"PATCH"}, http.StatusNoContent},
 				{"/stamps/dilute/{batch_id}/{depth}", []string{"PATCH"}, http.StatusNoContent},
@@ -331,6 +436,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
+				{"/migration", []string{"GET"}, http.StatusNoContent},
 				{"/metrics", nil, http.StatusOK},
 				{"/not_found", nil, http.StatusNotFound},
 
@@ -345,6 +451,7 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/watch", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
@@ -353,20 +460,41 @@ func TestEndpointOptions(t *testing.T) {
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/tags/incomplete", []string{"GET"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
+				{"/tags/{id}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/tags/{id}/purge", []string{"POST"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/usage", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify", []string{"GET"}, http.StatusNoContent},
+				{"/pins/verify/{id}", []string{"GET", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pins/{reference}/verify", []string{"POST"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
 				// routes from mountBusinessDebug
 				{"/transactions", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/history", []string{"GET"}, http.StatusNoContent},
+				{"/transactions/nonce", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/transactions/{hash}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/peers", []string{"GET"}, http.StatusNoContent},
 				{"/pingpong/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/reservestate", []string{"GET"}, http.StatusNoContent},
+				{"/eviction", []string{"GET"}, http.StatusNoContent},
+				{"/eviction/{worker}/pause", []string{"POST"}, http.StatusNoContent},
+				{"/eviction/{worker}/resume", []string{"POST"}, http.StatusNoContent},
+				{"/usageforecast", []string{"GET"}, http.StatusNoContent},
+				{"/dedup", []string{"GET"}, http.StatusNoContent},
+				{"/storageradius", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/connect/{multi-address:.+}", []string{"POST"}, http.StatusNoContent},
 				{"/blocklist", []string{"GET"}, http.StatusNoContent},
+				{"/retrieval/skiplist", []string{"GET", "DELETE"}, http.StatusNoContent},
+				{"/retrieval/skiplist/{peer}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pusher/limits", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/pusher/deadletters", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/pusher/deadletters/{address}", []string{"POST", "DELETE"}, http.StatusNoContent},
+				{"/syncstate", []string{"GET"}, http.StatusNoContent},
 				{"/peers/{address}", []string{"DELETE"}, http.StatusNoContent},
 				{"/topology", []string{"GET"}, http.StatusNoContent},
 				{"/welcome-message", []string{"GET", "POST"}, http.StatusNoContent},
@@ -375,8 +503,16 @@ func TestEndpointOptions(t *testing.T) {
 				{"/consumed", []string{"GET"}, http.StatusNoContent},
 				{"/consumed/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/timesettlements", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowance", []string{"GET", "PUT"}, http.StatusNoContent},
+				{"/timesettlements/allowances", []string{"GET"}, http.StatusNoContent},
+				{"/timesettlements/allowances/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/settlements", []string{"GET"}, http.StatusNoContent},
 				{"/settlements/{peer}", []string{"GET"}, http.StatusNoContent},
+				{"/swap/deductions", []string{"GET"}, http.StatusNoContent},
+				{"/swap/addressbook", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/swap/addressbook/{peer}", []string{"GET", "DELETE"}, http.StatusNoContent},
+				{"/settlements/policies", []string{"GET"}, http.StatusNoContent},
+				{"/settlements/policies/{peer}", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/chequebook/cheque/{peer}", []string{"GET"}, http.StatusNoContent},
 				{"/chequebook/cheque", []string{"GET"}, http.StatusNoContent},
 				{"/chequebook/cashout/{peer}", []string{"GET", "POST"}, http.StatusNoContent},
@@ -387,8 +523,12 @@ func TestEndpointOptions(t *testing.T) {
 				{"/wallet", nil, http.StatusNotImplemented},
 				{"/wallet/withdraw/{coin}", nil, http.StatusNotImplemented},
 				{"/stamps", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/export", []string{"POST"}, http.StatusNoContent},
+				{"/stamps/estimate", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/import", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/{batch_id}", []string{"GET"}, http.StatusNoContent},
 				{"/stamps/{batch_id}/buckets", []string{"GET"}, http.StatusNoContent},
+				{"/stamps/{batch_id}/expiry-policy", []string{"GET", "PUT", "DELETE"}, http.StatusNoContent},
 				{"/stamps/{amount}/{depth}", []string{"POST"}, http.StatusNoContent},
 				{"/stamps/topup/{batch_id}/{amount}", []string{"PATCH"}, http.StatusNoContent},
 				{"/stamps/dilute/{batch_id}/{depth}", []string{"PATCH"}, http.StatusNoContent},
@@ -0,0 +1,187 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/settlement"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	errCantSettlementPolicies     = "can not get settlement policies"
+	errCantSettlementPolicy       = "can not get settlement policy"
+	errCantSetSettlementPolicy    = "can not set settlement policy"
+	errNoSettlementPolicy         = "no settlement policy for peer"
+	errSettlementPolicyDisabled   = "settlement policies are not available"
+	errCantDeleteSettlementPolicy = "can not delete settlement policy"
+)
+
+type settlementPolicyRequest struct {
+	DisableSwap       bool           `json:"disableSwap"`
+	ForcePseudosettle bool           `json:"forcePseudosettle"`
+	PaymentThreshold  *bigint.BigInt `json:"paymentThreshold,omitempty"`
+}
+
+type settlementPolicyResponse struct {
+	Peer              string         `json:"peer"`
+	DisableSwap       bool           `json:"disableSwap"`
+	ForcePseudosettle bool           `json:"forcePseudosettle"`
+	PaymentThreshold  *bigint.BigInt `json:"paymentThreshold,omitempty"`
+}
+
+type settlementPoliciesResponse struct {
+	Policies []settlementPolicyResponse `json:"policies"`
+}
+
+func newSettlementPolicyResponse(peer string, policy settlement.PeerPolicy) settlementPolicyResponse {
+	resp := settlementPolicyResponse{
+		Peer:              peer,
+		DisableSwap:       policy.DisableSwap,
+		ForcePseudosettle: policy.ForcePseudosettle,
+	}
+	if policy.PaymentThreshold != nil {
+		resp.PaymentThreshold = bigint.Wrap(policy.PaymentThreshold)
+	}
+	return resp
+}
+
+func (s *Service) settlementPoliciesHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_settlement_policies").Build()
+
+	if s.settlementPolicies == nil {
+		jsonhttp.NotImplemented(w, errSettlementPolicyDisabled)
+		return
+	}
+
+	policies, err := s.settlementPolicies.Policies()
+	if err != nil {
+		logger.Debug("get settlement policies failed", "error", err)
+		logger.Error(nil, "get settlement policies failed")
+		jsonhttp.InternalServerError(w, errCantSettlementPolicies)
+		return
+	}
+
+	resp := settlementPoliciesResponse{Policies: make([]settlementPolicyResponse, 0, len(policies))}
+	for peer, policy := range policies {
+		resp.Policies = append(resp.Policies, newSettlementPolicyResponse(peer, policy))
+	}
+	sort.Slice(resp.Policies, func(i, j int) bool {
+		return resp.Policies[i].Peer < resp.Policies[j].Peer
+	})
+
+	jsonhttp.OK(w, resp)
+}
+
+func (s *Service) peerSettlementPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_settlement_policy_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.settlementPolicies == nil {
+		jsonhttp.NotImplemented(w, errSettlementPolicyDisabled)
+		return
+	}
+
+	policy, ok, err := s.settlementPolicies.Policy(paths.Peer)
+	if err != nil {
+		logger.Debug("get settlement policy failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "get settlement policy failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantSettlementPolicy)
+		return
+	}
+	if !ok {
+		jsonhttp.NotFound(w, errNoSettlementPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, newSettlementPolicyResponse(paths.Peer.String(), policy))
+}
+
+func (s *Service) setPeerSettlementPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("put_settlement_policy_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.settlementPolicies == nil {
+		jsonhttp.NotImplemented(w, errSettlementPolicyDisabled)
+		return
+	}
+
+	var req settlementPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+
+	policy := settlement.PeerPolicy{
+		DisableSwap:       req.DisableSwap,
+		ForcePseudosettle: req.ForcePseudosettle,
+	}
+	if req.PaymentThreshold != nil {
+		policy.PaymentThreshold = req.PaymentThreshold.Int
+	}
+
+	err := s.settlementPolicies.SetPolicy(paths.Peer, policy)
+	if errors.Is(err, settlement.ErrInvalidPolicy) {
+		logger.Debug("set settlement policy failed", "peer_address", paths.Peer, "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	if err != nil {
+		logger.Debug("set settlement policy failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "set settlement policy failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantSetSettlementPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, newSettlementPolicyResponse(paths.Peer.String(), policy))
+}
+
+func (s *Service) deletePeerSettlementPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_settlement_policy_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.settlementPolicies == nil {
+		jsonhttp.NotImplemented(w, errSettlementPolicyDisabled)
+		return
+	}
+
+	if err := s.settlementPolicies.DeletePolicy(paths.Peer); err != nil {
+		logger.Debug("delete settlement policy failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "delete settlement policy failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantDeleteSettlementPolicy)
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
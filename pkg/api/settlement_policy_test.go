@@ -0,0 +1,88 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/settlement"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestSettlementPolicies(t *testing.T) {
+	t.Parallel()
+
+	policies := settlement.NewPolicyStore(statestore.NewStateStore())
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		SettlementPolicies: policies,
+	})
+
+	peer := swarm.MustParseHexAddress("ff")
+	path := "/settlements/policies/" + peer.String()
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: api.ErrNoSettlementPolicy,
+			Code:    http.StatusNotFound,
+		}),
+	)
+
+	expected := api.SettlementPolicyResponse{
+		Peer:             peer.String(),
+		DisableSwap:      true,
+		PaymentThreshold: bigint.Wrap(big.NewInt(1000)),
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodPut, path, http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.SettlementPolicyRequest{
+			DisableSwap:      true,
+			PaymentThreshold: bigint.Wrap(big.NewInt(1000)),
+		}),
+		jsonhttptest.WithExpectedJSONResponse(expected),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(expected),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/settlements/policies", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SettlementPoliciesResponse{
+			Policies: []api.SettlementPolicyResponse{expected},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, path, http.StatusOK)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, path, http.StatusNotFound)
+}
+
+func TestSettlementPoliciesInvalidThreshold(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		SettlementPolicies: settlement.NewPolicyStore(statestore.NewStateStore()),
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodPut, "/settlements/policies/ff", http.StatusBadRequest,
+		jsonhttptest.WithJSONRequestBody(api.SettlementPolicyRequest{
+			PaymentThreshold: bigint.Wrap(big.NewInt(0)),
+		}),
+	)
+}
+
+func TestSettlementPoliciesUnavailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/settlements/policies", http.StatusNotImplemented)
+}
@@ -90,6 +90,9 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 			TagID:    0,
 			Pin:      false,
 			Deferred: false,
+			// updates of the same single owner chunk, such as feed updates,
+			// take the bucket slot of the previous update
+			IndexStrategy: postage.SOCIndexStrategy,
 		})
 	}
 	if err != nil {
@@ -278,5 +281,5 @@ func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc)
+	s.downloadHandler(logger, w, r, wc.Address(), additionalHeaders, true, false, wc, downloadOptions{})
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/storageincentives/staking"
+	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/gorilla/mux"
 )
 
@@ -72,6 +73,13 @@ func (s *Service) stakingDepositHandler(w http.ResponseWriter, r *http.Request)
 			jsonhttp.BadRequest(w, "out of funds")
 			return
 		}
+		var revertErr *transaction.RevertError
+		if errors.As(err, &revertErr) {
+			logger.Debug("simulation reverted", "error", err)
+			logger.Error(nil, "simulation reverted")
+			jsonhttp.BadRequest(w, revertErr.Error())
+			return
+		}
 		logger.Debug("deposit failed", "error", err)
 		logger.Error(nil, "deposit failed")
 		jsonhttp.InternalServerError(w, "cannot stake")
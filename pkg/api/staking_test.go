@@ -20,6 +20,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/sctx"
 	"github.com/calmw/bee-tron/pkg/storageincentives/staking"
 	stakingContractMock "github.com/calmw/bee-tron/pkg/storageincentives/staking/mock"
+	"github.com/calmw/bee-tron/pkg/transaction"
 )
 
 func TestDepositStake(t *testing.T) {
@@ -70,6 +71,19 @@ func TestDepositStake(t *testing.T) {
 		jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{Code: http.StatusBadRequest, Message: "out of funds"})
 	})
 
+	t.Run("simulation reverted", func(t *testing.T) {
+		t.Parallel()
+
+		contract := stakingContractMock.New(
+			stakingContractMock.WithDepositStake(func(ctx context.Context, stakedAmount *big.Int) (common.Hash, error) {
+				return common.Hash{}, fmt.Errorf("deposit stake: %w", &transaction.RevertError{Reason: "Stake: frozen"})
+			}),
+		)
+		ts, _, _, _ := newTestServer(t, testServerOptions{StakingContract: contract})
+		jsonhttptest.Request(t, ts, http.MethodPost, depositStake(minStake), http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(&jsonhttp.StatusResponse{Code: http.StatusBadRequest, Message: "transaction would revert: Stake: frozen"}))
+	})
+
 	t.Run("internal error", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,143 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/gorilla/mux"
+)
+
+const (
+	errCantAddressbook         = "cannot get swap addressbook"
+	errCantAddressbookPeer     = "cannot get swap addressbook entry for peer"
+	errCantImportAddressbook   = "cannot import swap addressbook"
+	errCantRemoveAddressbook   = "cannot remove swap addressbook entry"
+	errNoAddressbookEntry      = "no swap addressbook entry for peer"
+	errAddressbookNotAvailable = "swap addressbook is not available"
+)
+
+type swapAddressbookResponse struct {
+	Entries []swap.AddressbookEntry `json:"entries"`
+}
+
+type swapAddressbookImportRequest struct {
+	Entries []swap.AddressbookEntry `json:"entries"`
+}
+
+func (s *Service) swapAddressbookHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_swap_addressbook").Build()
+
+	if s.swapAddressbook == nil {
+		jsonhttp.NotImplemented(w, errAddressbookNotAvailable)
+		return
+	}
+
+	entries, err := s.swapAddressbook.Export()
+	if err != nil {
+		logger.Debug("export swap addressbook failed", "error", err)
+		logger.Error(nil, "export swap addressbook failed")
+		jsonhttp.InternalServerError(w, errCantAddressbook)
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Peer.String() < entries[j].Peer.String()
+	})
+
+	jsonhttp.OK(w, swapAddressbookResponse{Entries: entries})
+}
+
+func (s *Service) swapAddressbookImportHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_swap_addressbook").Build()
+
+	if s.swapAddressbook == nil {
+		jsonhttp.NotImplemented(w, errAddressbookNotAvailable)
+		return
+	}
+
+	var req swapAddressbookImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Debug("failed to read body", "error", err)
+		jsonhttp.BadRequest(w, err)
+		return
+	}
+	for _, entry := range req.Entries {
+		if entry.Peer.IsZero() {
+			jsonhttp.BadRequest(w, "missing peer address")
+			return
+		}
+	}
+
+	if err := s.swapAddressbook.Import(req.Entries); err != nil {
+		logger.Debug("import swap addressbook failed", "error", err)
+		logger.Error(nil, "import swap addressbook failed")
+		jsonhttp.InternalServerError(w, errCantImportAddressbook)
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+func (s *Service) swapAddressbookPeerHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_swap_addressbook_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.swapAddressbook == nil {
+		jsonhttp.NotImplemented(w, errAddressbookNotAvailable)
+		return
+	}
+
+	entry, known, err := s.swapAddressbook.Entry(paths.Peer)
+	if err != nil {
+		logger.Debug("get swap addressbook entry failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "get swap addressbook entry failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantAddressbookPeer)
+		return
+	}
+	if !known {
+		jsonhttp.NotFound(w, errNoAddressbookEntry)
+		return
+	}
+
+	jsonhttp.OK(w, entry)
+}
+
+func (s *Service) swapAddressbookRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_swap_addressbook_by_peer").Build()
+
+	paths := struct {
+		Peer swarm.Address `map:"peer" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if s.swapAddressbook == nil {
+		jsonhttp.NotImplemented(w, errAddressbookNotAvailable)
+		return
+	}
+
+	if err := s.swapAddressbook.Remove(paths.Peer); err != nil {
+		logger.Debug("remove swap addressbook entry failed", "peer_address", paths.Peer, "error", err)
+		logger.Error(nil, "remove swap addressbook entry failed", "peer_address", paths.Peer)
+		jsonhttp.InternalServerError(w, errCantRemoveAddressbook)
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSwapAddressbook(t *testing.T) {
+	t.Parallel()
+
+	addressbook := swap.NewAddressbook(statestore.NewStateStore())
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		SwapAddressbook: addressbook,
+	})
+
+	peer := swarm.MustParseHexAddress("abcd")
+	beneficiary := common.HexToAddress("0xab")
+	chequebook := common.HexToAddress("0xcd")
+	entry := swap.AddressbookEntry{
+		Peer:        peer,
+		Beneficiary: &beneficiary,
+		Chequebook:  &chequebook,
+		LastSeen:    1000,
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/addressbook/"+peer.String(), http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: api.ErrNoAddressbookEntry,
+			Code:    http.StatusNotFound,
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodPost, "/swap/addressbook", http.StatusOK,
+		jsonhttptest.WithJSONRequestBody(api.SwapAddressbookImportRequest{
+			Entries: []swap.AddressbookEntry{entry},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/addressbook/"+peer.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(entry),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/addressbook", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SwapAddressbookResponse{
+			Entries: []swap.AddressbookEntry{entry},
+		}),
+	)
+
+	jsonhttptest.Request(t, testServer, http.MethodDelete, "/swap/addressbook/"+peer.String(), http.StatusOK)
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/addressbook/"+peer.String(), http.StatusNotFound)
+
+	_, known, err := addressbook.ChequebookPeer(chequebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if known {
+		t.Fatal("expected chequebook mapping to be removed")
+	}
+}
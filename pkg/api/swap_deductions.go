@@ -0,0 +1,80 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	errCantDeductions         = "cannot get deductions"
+	errDeductionsNotAvailable = "deduction log is not available"
+)
+
+type deductionResponse struct {
+	Peer       string         `json:"peer"`
+	Direction  string         `json:"direction"`
+	Amount     *bigint.BigInt `json:"amount"`
+	Timestamp  int64          `json:"timestamp"`
+	ChequeHash common.Hash    `json:"chequeHash"`
+}
+
+type deductionsResponse struct {
+	Deductions []deductionResponse `json:"deductions"`
+}
+
+func (s *Service) swapDeductionsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_swap_deductions").Build()
+
+	queries := struct {
+		Peer      swarm.Address `map:"peer"`
+		Direction string        `map:"direction" validate:"omitempty,oneof=granted received"`
+		Offset    int           `map:"offset" validate:"min=0"`
+		Limit     int           `map:"limit" validate:"min=1,max=1000"`
+	}{
+		Limit: 100, // Default limit.
+	}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	if s.swapDeductions == nil {
+		jsonhttp.NotImplemented(w, errDeductionsNotAvailable)
+		return
+	}
+
+	records, err := s.swapDeductions.Deductions(swap.DeductionFilter{
+		Peer:      queries.Peer,
+		Direction: queries.Direction,
+		Offset:    queries.Offset,
+		Limit:     queries.Limit,
+	})
+	if err != nil {
+		logger.Debug("get deductions failed", "error", err)
+		logger.Error(nil, "get deductions failed")
+		jsonhttp.InternalServerError(w, errCantDeductions)
+		return
+	}
+
+	resp := deductionsResponse{Deductions: make([]deductionResponse, 0, len(records))}
+	for _, record := range records {
+		resp.Deductions = append(resp.Deductions, deductionResponse{
+			Peer:       record.Peer.String(),
+			Direction:  record.Direction,
+			Amount:     bigint.Wrap(record.Amount),
+			Timestamp:  record.Timestamp,
+			ChequeHash: record.ChequeHash,
+		})
+	}
+
+	jsonhttp.OK(w, resp)
+}
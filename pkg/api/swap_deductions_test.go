@@ -0,0 +1,77 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/bigint"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSwapDeductions(t *testing.T) {
+	t.Parallel()
+
+	deductions := swap.NewDeductionLog(statestore.NewStateStore())
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		SwapDeductions: deductions,
+	})
+
+	peer1 := swarm.MustParseHexAddress("abcd")
+	peer2 := swarm.MustParseHexAddress("dcba")
+	cheque := &chequebook.SignedCheque{Signature: []byte{1}}
+
+	if err := deductions.Record(peer1, swap.DeductionGranted, cheque, big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := deductions.Record(peer2, swap.DeductionReceived, cheque, big.NewInt(20)); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := deductions.Deductions(swap.DeductionFilter{Peer: peer2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?peer="+peer2.String(), http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.DeductionsResponse{
+			Deductions: []api.DeductionResponse{{
+				Peer:       peer2.String(),
+				Direction:  swap.DeductionReceived,
+				Amount:     bigint.Wrap(big.NewInt(20)),
+				Timestamp:  records[0].Timestamp,
+				ChequeHash: crypto.Keccak256Hash(cheque.Signature),
+			}},
+		}),
+	)
+
+	var resp api.DeductionsResponse
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?direction=granted", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if len(resp.Deductions) != 1 || resp.Deductions[0].Peer != peer1.String() {
+		t.Fatalf("unexpected granted deductions %+v", resp.Deductions)
+	}
+
+	resp = api.DeductionsResponse{}
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?offset=1&limit=1", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+	if len(resp.Deductions) != 1 || resp.Deductions[0].Peer != peer2.String() {
+		t.Fatalf("unexpected page of deductions %+v", resp.Deductions)
+	}
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?direction=unknown", http.StatusBadRequest)
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?offset=-1", http.StatusBadRequest)
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/swap/deductions?limit=0", http.StatusBadRequest)
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const errSyncStateNotAvailable = "sync state is not available"
+
+// unknownETA is the estimated completion time reported if it can not be
+// estimated, because nothing was synced recently.
+const unknownETA = -1
+
+type syncStateBinResponse struct {
+	Bin     uint8   `json:"bin"`
+	Syncing bool    `json:"syncing"`
+	Top     uint64  `json:"top"`
+	Synced  uint64  `json:"synced"`
+	Lag     uint64  `json:"lag"`
+	Rate    float64 `json:"rate"`
+	ETA     int64   `json:"eta"`
+}
+
+type syncStatePeerResponse struct {
+	Address        swarm.Address          `json:"address"`
+	ProximityOrder uint8                  `json:"proximityOrder"`
+	Lag            uint64                 `json:"lag"`
+	ETA            int64                  `json:"eta"`
+	Bins           []syncStateBinResponse `json:"bins"`
+}
+
+type syncStateResponse struct {
+	Lag   uint64                  `json:"lag"`
+	ETA   int64                   `json:"eta"`
+	Peers []syncStatePeerResponse `json:"peers"`
+}
+
+// etaSeconds returns the estimated completion time in seconds, rounded up.
+func etaSeconds(eta time.Duration) int64 {
+	if eta < 0 {
+		return unknownETA
+	}
+	return int64((eta + time.Second - 1) / time.Second)
+}
+
+// maxETA returns the later of the estimated completion times.
+func maxETA(a, b int64) int64 {
+	if a == unknownETA || b == unknownETA {
+		return unknownETA
+	}
+	return max(a, b)
+}
+
+// syncStateHandler summarizes the progress of the syncing per peer and bin.
+// The lag and the estimated completion time of the peers and the node only
+// account for the bins which are being synced.
+func (s *Service) syncStateHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.syncState == nil {
+		jsonhttp.NotImplemented(w, errSyncStateNotAvailable)
+		return
+	}
+
+	peers := s.syncState.SyncState()
+	resp := syncStateResponse{Peers: make([]syncStatePeerResponse, 0, len(peers))}
+	for _, p := range peers {
+		peer := syncStatePeerResponse{
+			Address:        p.Address,
+			ProximityOrder: p.PO,
+			Bins:           make([]syncStateBinResponse, 0, len(p.Bins)),
+		}
+		for _, b := range p.Bins {
+			bin := syncStateBinResponse{
+				Bin:     b.Bin,
+				Syncing: b.Syncing,
+				Top:     b.Top,
+				Synced:  b.Synced,
+				Lag:     b.Lag,
+				Rate:    b.Rate,
+				ETA:     etaSeconds(b.ETA),
+			}
+			if bin.Syncing {
+				peer.Lag += bin.Lag
+				peer.ETA = maxETA(peer.ETA, bin.ETA)
+			}
+			peer.Bins = append(peer.Bins, bin)
+		}
+		resp.Lag += peer.Lag
+		resp.ETA = maxETA(resp.ETA, peer.ETA)
+		resp.Peers = append(resp.Peers, peer)
+	}
+
+	jsonhttp.OK(w, resp)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/puller"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type syncStaterMock []puller.PeerSyncState
+
+func (m syncStaterMock) SyncState() []puller.PeerSyncState {
+	return m
+}
+
+func TestSyncState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer1 = swarm.MustParseHexAddress("ca1e")
+		peer2 = swarm.MustParseHexAddress("abcd")
+	)
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{
+		SyncState: syncStaterMock{
+			{Address: peer1, PO: 1, Bins: []puller.BinSyncState{
+				{Bin: 0, Top: 100, Lag: 100, ETA: -1},
+				{Bin: 1, Syncing: true, Top: 100, Synced: 40, Lag: 60, Rate: 2, ETA: 30 * time.Second},
+				{Bin: 2, Syncing: true, Top: 50, Synced: 50},
+			}},
+			{Address: peer2, PO: 2, Bins: []puller.BinSyncState{
+				{Bin: 2, Syncing: true, Top: 10, Lag: 10, ETA: 1500 * time.Millisecond, Rate: 6.5},
+			}},
+		},
+	})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/syncstate", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.SyncStateResponse{
+			Lag: 70,
+			ETA: 30,
+			Peers: []api.SyncStatePeerResponse{
+				{Address: peer1, ProximityOrder: 1, Lag: 60, ETA: 30, Bins: []api.SyncStateBinResponse{
+					{Bin: 0, Top: 100, Lag: 100, ETA: -1},
+					{Bin: 1, Syncing: true, Top: 100, Synced: 40, Lag: 60, Rate: 2, ETA: 30},
+					{Bin: 2, Syncing: true, Top: 50, Synced: 50},
+				}},
+				{Address: peer2, ProximityOrder: 2, Lag: 10, ETA: 2, Bins: []api.SyncStateBinResponse{
+					{Bin: 2, Syncing: true, Top: 10, Lag: 10, Rate: 6.5, ETA: 2},
+				}},
+			},
+		}),
+	)
+}
+
+func TestSyncStateNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+	jsonhttptest.Request(t, testServer, http.MethodGet, "/syncstate", http.StatusNotImplemented)
+}
@@ -216,3 +216,97 @@ func (s *Service) listTagsHandler(w http.ResponseWriter, r *http.Request) {
 		Tags: tags,
 	})
 }
+
+type incompleteTagResponse struct {
+	tagResponse
+	Pending uint64 `json:"pending"`
+	Dirty   bool   `json:"dirty"`
+	Deleted bool   `json:"deleted"`
+}
+
+type listIncompleteTagsResponse struct {
+	Tags []incompleteTagResponse `json:"tags"`
+}
+
+type purgeTagResponse struct {
+	Purged uint64 `json:"purged"`
+}
+
+func (s *Service) listIncompleteTagsHandler(w http.ResponseWriter, _ *http.Request) {
+	logger := s.logger.WithName("get_tags_incomplete").Build()
+
+	stats, err := s.storer.IncompleteSessions()
+	if err != nil {
+		logger.Debug("listing incomplete tags failed", "error", err)
+		logger.Error(nil, "listing incomplete tags failed")
+		jsonhttp.InternalServerError(w, "cannot list incomplete tags")
+		return
+	}
+
+	tags := make([]incompleteTagResponse, len(stats))
+	for i, st := range stats {
+		tags[i] = incompleteTagResponse{
+			tagResponse: newTagResponse(st.SessionInfo),
+			Pending:     st.Pending,
+			Dirty:       st.Dirty,
+			Deleted:     st.Deleted,
+		}
+	}
+
+	jsonhttp.OK(w, listIncompleteTagsResponse{
+		Tags: tags,
+	})
+}
+
+func (s *Service) resumeTagHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_tag_resume").Build()
+
+	paths := struct {
+		TagID uint64 `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.storer.ResumeSession(paths.TagID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			logger.Debug("no pending chunks of tag", "tag_id", paths.TagID)
+			jsonhttp.NotFound(w, "no pending chunks of tag")
+			return
+		}
+		logger.Debug("resume tag failed", "tag_id", paths.TagID, "error", err)
+		logger.Error(nil, "resume tag failed", "tag_id", paths.TagID)
+		jsonhttp.InternalServerError(w, "cannot resume tag")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
+func (s *Service) purgeTagHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_tag_purge").Build()
+
+	paths := struct {
+		TagID uint64 `map:"id" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	purged, err := s.storer.PurgeSession(paths.TagID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			logger.Debug("tag not found", "tag_id", paths.TagID)
+			jsonhttp.NotFound(w, "tag not present")
+			return
+		}
+		logger.Debug("purge tag failed", "tag_id", paths.TagID, "error", err)
+		logger.Error(nil, "purge tag failed", "tag_id", paths.TagID)
+		jsonhttp.InternalServerError(w, "cannot purge tag")
+		return
+	}
+
+	jsonhttp.OK(w, purgeTagResponse{Purged: purged})
+}
@@ -196,6 +196,48 @@ func TestTags(t *testing.T) {
 	})
 }
 
+// nolint:paralleltest
+func TestIncompleteTags(t *testing.T) {
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	t.Run("list incomplete tags", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/tags/incomplete", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.ListIncompleteTagsResponse{
+				Tags: []api.IncompleteTagResponse{},
+			}),
+		)
+	})
+
+	t.Run("resume non-existent tag", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, tagsWithIdResource(uint64(333))+"/resume", http.StatusNotFound)
+	})
+
+	t.Run("purge non-existent tag", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, tagsWithIdResource(uint64(333))+"/purge", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "tag not present",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+
+	t.Run("purge tag", func(t *testing.T) {
+		tRes := api.TagResponse{}
+		jsonhttptest.Request(t, client, http.MethodPost, "/tags", http.StatusCreated,
+			jsonhttptest.WithUnmarshalJSONResponse(&tRes),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodPost, tagsWithIdResource(tRes.Uid)+"/purge", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.PurgeTagResponse{Purged: 0}),
+		)
+
+		jsonhttptest.Request(t, client, http.MethodGet, tagsWithIdResource(tRes.Uid), http.StatusNotFound)
+	})
+}
+
 func TestTagsHandlersInvalidInputs(t *testing.T) {
 	t.Parallel()
 
@@ -9,8 +9,13 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 )
 
 func (s *Service) topologyHandler(w http.ResponseWriter, _ *http.Request) {
@@ -29,3 +34,149 @@ func (s *Service) topologyHandler(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set(ContentTypeHeader, jsonhttp.DefaultContentTypeHeader)
 	_, _ = io.Copy(w, bytes.NewBuffer(b))
 }
+
+type topologyNeighborhoodResponse struct {
+	Neighborhood string `json:"neighborhood"`
+}
+
+// topologyNeighborhoodHandler returns the least populated neighborhood in the
+// format expected by the neighborhood suggester of a new node.
+func (s *Service) topologyNeighborhoodHandler(w http.ResponseWriter, _ *http.Request) {
+	neighborhood := s.topologyDriver.SuggestNeighborhood()
+	if neighborhood == "" {
+		jsonhttp.NotFound(w, "no neighborhood suggestion available")
+		return
+	}
+	jsonhttp.OK(w, topologyNeighborhoodResponse{Neighborhood: neighborhood})
+}
+
+type topologyHistoryResponse struct {
+	Snapshots []topology.MetricsRecord `json:"snapshots"`
+}
+
+// topologyHistoryHandler returns the latest persisted metrics snapshots of
+// the peer, the newest first.
+func (s *Service) topologyHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_topology_history").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Limit int `map:"limit" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	snapshots, err := s.topologyDriver.MetricsHistory(paths.Address, queries.Limit)
+	if err != nil {
+		logger.Debug("get metrics history failed", "peer_address", paths.Address, "error", err)
+		logger.Error(nil, "get metrics history failed", "peer_address", paths.Address)
+		jsonhttp.InternalServerError(w, "unable to get metrics history")
+		return
+	}
+	if snapshots == nil {
+		snapshots = []topology.MetricsRecord{}
+	}
+
+	jsonhttp.OK(w, topologyHistoryResponse{Snapshots: snapshots})
+}
+
+// topologyChangesWsHandler streams the topology changes as JSON messages over
+// a websocket to the monitoring tools.
+func (s *Service) topologyChangesWsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("topology_changes").Build()
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     s.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("upgrade failed", "error", err)
+		logger.Error(nil, "upgrade failed")
+		jsonhttp.InternalServerError(w, "upgrade failed")
+		return
+	}
+
+	s.wsWg.Add(1)
+	go s.topologyChangesWs(conn)
+}
+
+func (s *Service) topologyChangesWs(conn *websocket.Conn) {
+	defer s.wsWg.Done()
+
+	var (
+		gone   = make(chan struct{})
+		ticker = time.NewTicker(s.WsPingPeriod)
+		err    error
+	)
+	defer func() {
+		ticker.Stop()
+		_ = conn.Close()
+	}()
+
+	changes, unsubscribe := s.topologyDriver.SubscribeTopologyChange()
+	defer unsubscribe()
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		s.logger.Debug("topology changes ws: client gone", "code", code, "message", text)
+		close(gone)
+		return nil
+	})
+
+	for {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology changes ws: set write deadline failed", "error", err)
+				return
+			}
+
+			err = conn.WriteJSON(change)
+			if err != nil {
+				s.logger.Debug("topology changes ws: write message failed", "error", err)
+				return
+			}
+
+		case <-s.quit:
+			// shutdown
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology changes ws: set write deadline failed", "error", err)
+				return
+			}
+			err = conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if err != nil {
+				s.logger.Debug("topology changes ws: write close message failed", "error", err)
+			}
+			return
+		case <-gone:
+			// client gone
+			return
+		case <-ticker.C:
+			err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("topology changes ws: set write deadline failed", "error", err)
+				return
+			}
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// error encountered while pinging client. client probably gone
+				return
+			}
+		}
+	}
+}
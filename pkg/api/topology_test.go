@@ -7,8 +7,14 @@ package api_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
 	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+	topologymock "github.com/calmw/bee-tron/pkg/topology/mock"
 )
 
 func TestTopologyOK(t *testing.T) {
@@ -24,3 +30,106 @@ func TestTopologyOK(t *testing.T) {
 		t.Error("empty response")
 	}
 }
+
+func TestTopologyNeighborhood(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TopologyOpts: []topologymock.Option{topologymock.WithSuggestedNeighborhood("0110")},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/neighborhood", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.TopologyNeighborhoodResponse{Neighborhood: "0110"}),
+		)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/neighborhood", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "no neighborhood suggestion available",
+				Code:    http.StatusNotFound,
+			}),
+		)
+	})
+}
+
+func TestTopologyChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := []topology.Change{
+		{Type: topology.ChangePeerAdded, Peer: swarm.RandAddress(t), Bin: 3, Reason: topology.ReasonConnected},
+		{Type: topology.ChangePeerRemoved, Peer: swarm.RandAddress(t), Bin: 5, Reason: topology.ReasonPruned},
+	}
+
+	_, cl, _, _ := newTestServer(t, testServerOptions{
+		WsPath:       "/topology/changes",
+		TopologyOpts: []topologymock.Option{topologymock.WithChanges(changes...)},
+	})
+
+	if err := cl.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range changes {
+		var got topology.Change
+		if err := cl.ReadJSON(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Type != want.Type || !got.Peer.Equal(want.Peer) || got.Bin != want.Bin || got.Reason != want.Reason {
+			t.Fatalf("got change %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestTopologyHistory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer    = swarm.RandAddress(t)
+		records = []topology.MetricsRecord{
+			{Timestamp: 300, Bin: 2, Metrics: &topology.MetricSnapshotView{LatencyEWMA: 30, Healthy: true}},
+			{Timestamp: 200, Bin: 2, Metrics: &topology.MetricSnapshotView{LatencyEWMA: 20, Healthy: true}},
+			{Timestamp: 100, Bin: 2, Metrics: &topology.MetricSnapshotView{LatencyEWMA: 10}},
+		}
+		testServer, _, _, _ = newTestServer(t, testServerOptions{
+			TopologyOpts: []topologymock.Option{topologymock.WithMetricsHistory(peer, records...)},
+		})
+	)
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/history/"+peer.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.TopologyHistoryResponse{Snapshots: records}),
+		)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/history/"+peer.String()+"?limit=2", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.TopologyHistoryResponse{Snapshots: records[:2]}),
+		)
+	})
+
+	t.Run("unknown peer", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/history/"+swarm.RandAddress(t).String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.TopologyHistoryResponse{Snapshots: []topology.MetricsRecord{}}),
+		)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/topology/history/"+peer.String()+"?limit=-1", http.StatusBadRequest)
+	})
+}
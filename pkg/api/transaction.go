@@ -24,6 +24,9 @@ const (
 	errUnknownTransaction    = "unknown transaction"
 	errAlreadyImported       = "already imported"
 	errCantResendTransaction = "can't resend transaction"
+	errCantGetNonceStatus    = "cannot get nonce status"
+	errCantRepairNonces      = "cannot repair nonces"
+	errCantGetHistory        = "cannot get transaction history"
 )
 
 type transactionInfo struct {
@@ -198,3 +201,155 @@ func (s *Service) transactionCancelHandler(w http.ResponseWriter, r *http.Reques
 		TransactionHash: txHash,
 	})
 }
+
+type nonceStatusResponse struct {
+	InSync            bool          `json:"inSync"`
+	LocalNonce        uint64        `json:"localNonce"`
+	PendingNonce      uint64        `json:"pendingNonce"`
+	ConfirmedNonce    uint64        `json:"confirmedNonce"`
+	MissingNonces     []uint64      `json:"missingNonces"`
+	StaleTransactions []common.Hash `json:"staleTransactions"`
+}
+
+func newNonceStatusResponse(status *transaction.NonceStatus) nonceStatusResponse {
+	resp := nonceStatusResponse{
+		InSync:            status.InSync(),
+		LocalNonce:        status.LocalNonce,
+		PendingNonce:      status.PendingNonce,
+		ConfirmedNonce:    status.ConfirmedNonce,
+		MissingNonces:     status.MissingNonces,
+		StaleTransactions: status.StaleTransactions,
+	}
+	if resp.MissingNonces == nil {
+		resp.MissingNonces = []uint64{}
+	}
+	if resp.StaleTransactions == nil {
+		resp.StaleTransactions = []common.Hash{}
+	}
+	return resp
+}
+
+func (s *Service) transactionNonceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_transactions_nonce").Build()
+
+	status, err := s.transaction.NonceStatus(r.Context())
+	if err != nil {
+		logger.Debug("get nonce status failed", "error", err)
+		logger.Error(nil, "get nonce status failed")
+		jsonhttp.InternalServerError(w, errCantGetNonceStatus)
+		return
+	}
+
+	jsonhttp.OK(w, newNonceStatusResponse(status))
+}
+
+func (s *Service) transactionNonceRepairHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_transactions_nonce").Build()
+
+	headers := struct {
+		GasPrice *big.Int `map:"Gas-Price"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+	ctx := sctx.SetGasPrice(r.Context(), headers.GasPrice)
+
+	status, err := s.transaction.RepairNonces(ctx)
+	if err != nil {
+		logger.Debug("repair nonces failed", "error", err)
+		logger.Error(nil, "repair nonces failed")
+		jsonhttp.InternalServerError(w, errCantRepairNonces)
+		return
+	}
+
+	jsonhttp.OK(w, newNonceStatusResponse(status))
+}
+
+type transactionHistoryEntry struct {
+	transactionInfo
+	Method            string         `json:"method"`
+	Status            string         `json:"status"`
+	BlockNumber       uint64         `json:"blockNumber"`
+	GasUsed           uint64         `json:"gasUsed"`
+	EffectiveGasPrice *bigint.BigInt `json:"effectiveGasPrice"`
+	Fee               *bigint.BigInt `json:"fee"`
+	Confirmed         *time.Time     `json:"confirmed,omitempty"`
+	ReplacedBy        *common.Hash   `json:"replacedBy,omitempty"`
+}
+
+type transactionHistoryResponse struct {
+	Transactions []transactionHistoryEntry `json:"transactions"`
+	TotalFee     *bigint.BigInt            `json:"totalFee"`
+}
+
+func (s *Service) transactionHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_transactions_history").Build()
+
+	queries := struct {
+		Contract *common.Address `map:"contract"`
+		From     int64           `map:"from"`
+		To       int64           `map:"to"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	filter := transaction.HistoryFilter{Contract: queries.Contract}
+	if queries.From > 0 {
+		filter.From = time.Unix(queries.From, 0)
+	}
+	if queries.To > 0 {
+		filter.To = time.Unix(queries.To, 0)
+	}
+
+	entries, err := s.transaction.History(filter)
+	if err != nil {
+		logger.Debug("get transaction history failed", "error", err)
+		logger.Error(nil, "get transaction history failed")
+		jsonhttp.InternalServerError(w, errCantGetHistory)
+		return
+	}
+
+	resp := transactionHistoryResponse{
+		Transactions: make([]transactionHistoryEntry, 0, len(entries)),
+		TotalFee:     bigint.Wrap(big.NewInt(0)),
+	}
+	for _, entry := range entries {
+		tx := entry.Transaction
+		historyEntry := transactionHistoryEntry{
+			transactionInfo: transactionInfo{
+				TransactionHash: entry.TxHash,
+				To:              tx.To,
+				Nonce:           tx.Nonce,
+				GasPrice:        bigint.Wrap(tx.GasPrice),
+				GasLimit:        tx.GasLimit,
+				GasFeeCap:       bigint.Wrap(tx.GasFeeCap),
+				GasTipCap:       bigint.Wrap(tx.GasTipCap),
+				GasTipBoost:     tx.GasTipBoost,
+				Data:            hexutil.Encode(tx.Data),
+				Created:         time.Unix(tx.Created, 0),
+				Description:     tx.Description,
+				Value:           bigint.Wrap(tx.Value),
+			},
+			Method:     entry.Method,
+			Status:     entry.Status,
+			ReplacedBy: entry.ReplacedBy,
+		}
+		if entry.Receipt != nil {
+			confirmed := time.Unix(entry.Receipt.Confirmed, 0)
+			historyEntry.BlockNumber = entry.Receipt.BlockNumber
+			historyEntry.GasUsed = entry.Receipt.GasUsed
+			historyEntry.EffectiveGasPrice = bigint.Wrap(entry.Receipt.EffectiveGasPrice)
+			historyEntry.Confirmed = &confirmed
+		}
+		if entry.Fee != nil {
+			historyEntry.Fee = bigint.Wrap(entry.Fee)
+			resp.TotalFee.Add(resp.TotalFee.Int, entry.Fee)
+		}
+		resp.Transactions = append(resp.Transactions, historyEntry)
+	}
+
+	jsonhttp.OK(w, resp)
+}
@@ -326,3 +326,194 @@ func TestTransactionResend(t *testing.T) {
 		)
 	})
 }
+
+func TestTransactionNonceStatus(t *testing.T) {
+	t.Parallel()
+
+	staleTx := common.HexToHash("abcd")
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithNonceStatusFunc(func(ctx context.Context) (*transaction.NonceStatus, error) {
+					return &transaction.NonceStatus{
+						LocalNonce:        12,
+						PendingNonce:      10,
+						ConfirmedNonce:    9,
+						MissingNonces:     []uint64{10},
+						StaleTransactions: []common.Hash{staleTx},
+					}, nil
+				}),
+			},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/nonce", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.NonceStatusResponse{
+				InSync:            false,
+				LocalNonce:        12,
+				PendingNonce:      10,
+				ConfirmedNonce:    9,
+				MissingNonces:     []uint64{10},
+				StaleTransactions: []common.Hash{staleTx},
+			}),
+		)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithNonceStatusFunc(func(ctx context.Context) (*transaction.NonceStatus, error) {
+					return nil, errors.New("err")
+				}),
+			},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/nonce", http.StatusInternalServerError,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusInternalServerError,
+				Message: api.ErrCantGetNonceStatus,
+			}),
+		)
+	})
+}
+
+func TestTransactionNonceRepair(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithRepairNoncesFunc(func(ctx context.Context) (*transaction.NonceStatus, error) {
+					return &transaction.NonceStatus{
+						LocalNonce:     12,
+						PendingNonce:   12,
+						ConfirmedNonce: 9,
+					}, nil
+				}),
+			},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodPost, "/transactions/nonce", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.NonceStatusResponse{
+				InSync:            true,
+				LocalNonce:        12,
+				PendingNonce:      12,
+				ConfirmedNonce:    9,
+				MissingNonces:     []uint64{},
+				StaleTransactions: []common.Hash{},
+			}),
+		)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithRepairNoncesFunc(func(ctx context.Context) (*transaction.NonceStatus, error) {
+					return nil, errors.New("err")
+				}),
+			},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodPost, "/transactions/nonce", http.StatusInternalServerError,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusInternalServerError,
+				Message: api.ErrCantRepairNonces,
+			}),
+		)
+	})
+}
+
+func TestTransactionHistory(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("fffe")
+	confirmedTx := common.HexToHash("abcd")
+	pendingTx := common.HexToHash("abce")
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		var gotFilter transaction.HistoryFilter
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithHistoryFunc(func(filter transaction.HistoryFilter) ([]transaction.HistoryEntry, error) {
+					gotFilter = filter
+					return []transaction.HistoryEntry{
+						{
+							TxHash:      confirmedTx,
+							Transaction: transaction.StoredTransaction{To: &contract, Nonce: 1, Created: 100},
+							Method:      "createBatch",
+							Status:      transaction.HistoryStatusSuccess,
+							Receipt: &transaction.StoredReceipt{
+								Status:            1,
+								BlockNumber:       10,
+								GasUsed:           21000,
+								EffectiveGasPrice: big.NewInt(2),
+								Confirmed:         110,
+							},
+							Fee: big.NewInt(42000),
+						},
+						{
+							TxHash:      pendingTx,
+							Transaction: transaction.StoredTransaction{To: &contract, Nonce: 2, Created: 200},
+							Status:      transaction.HistoryStatusPending,
+						},
+					}, nil
+				}),
+			},
+		})
+
+		var resp api.TransactionHistoryResponse
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/history?contract="+contract.Hex()+"&from=50&to=300", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		if gotFilter.Contract == nil || *gotFilter.Contract != contract || gotFilter.From.Unix() != 50 || gotFilter.To.Unix() != 300 {
+			t.Fatalf("got filter %+v", gotFilter)
+		}
+		if len(resp.Transactions) != 2 {
+			t.Fatalf("got %d transactions, want 2", len(resp.Transactions))
+		}
+		confirmed := resp.Transactions[0]
+		if confirmed.TransactionHash != confirmedTx || confirmed.Method != "createBatch" || confirmed.Status != transaction.HistoryStatusSuccess {
+			t.Fatalf("got confirmed transaction %+v", confirmed)
+		}
+		if confirmed.GasUsed != 21000 || confirmed.BlockNumber != 10 || confirmed.Fee.Cmp(big.NewInt(42000)) != 0 {
+			t.Fatalf("got confirmed transaction receipt %+v", confirmed)
+		}
+		pending := resp.Transactions[1]
+		if pending.TransactionHash != pendingTx || pending.Status != transaction.HistoryStatusPending || pending.Confirmed != nil {
+			t.Fatalf("got pending transaction %+v", pending)
+		}
+		if resp.TotalFee.Cmp(big.NewInt(42000)) != 0 {
+			t.Fatalf("got total fee %v, want 42000", resp.TotalFee)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		testServer, _, _, _ := newTestServer(t, testServerOptions{
+			TransactionOpts: []mock.Option{
+				mock.WithHistoryFunc(func(filter transaction.HistoryFilter) ([]transaction.HistoryEntry, error) {
+					return nil, errors.New("err")
+				}),
+			},
+		})
+
+		jsonhttptest.Request(t, testServer, http.MethodGet, "/transactions/history", http.StatusInternalServerError,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusInternalServerError,
+				Message: api.ErrCantGetHistory,
+			}),
+		)
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+)
+
+type usageForecastResponse struct {
+	Component  string  `json:"component"`
+	Size       uint64  `json:"size"`
+	Capacity   uint64  `json:"capacity"`
+	GrowthRate float64 `json:"growthRate"`
+	TimeToFull *int64  `json:"timeToFull,omitempty"`
+}
+
+type usageForecastsResponse struct {
+	Forecasts []usageForecastResponse `json:"forecasts"`
+}
+
+// usageForecastHandler returns the growth rate and the projected time until
+// the reserve, the cache and the pinning are full.
+func (s *Service) usageForecastHandler(w http.ResponseWriter, _ *http.Request) {
+	forecasts := s.storer.UsageForecast()
+
+	res := usageForecastsResponse{Forecasts: make([]usageForecastResponse, 0, len(forecasts))}
+	for _, f := range forecasts {
+		r := usageForecastResponse{
+			Component:  f.Component,
+			Size:       f.Size,
+			Capacity:   f.Capacity,
+			GrowthRate: f.GrowthRate,
+		}
+		if f.Projected() {
+			ttf := int64(f.TimeToFull.Seconds())
+			r.TimeToFull = &ttf
+		}
+		res.Forecasts = append(res.Forecasts, r)
+	}
+	jsonhttp.OK(w, res)
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	"github.com/calmw/bee-tron/pkg/storer"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+)
+
+func TestUsageForecast(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithUsageForecast([]storer.UsageForecast{
+			{Component: storer.ReserveUsage, Size: 100, Capacity: 200, GrowthRate: 0.5, TimeToFull: 200 * time.Second},
+			{Component: storer.PinningUsage, Size: 4096, TimeToFull: -1},
+		}),
+	})
+
+	ttf := int64(200)
+	jsonhttptest.Request(t, client, http.MethodGet, "/usageforecast", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.UsageForecastsResponse{
+			Forecasts: []api.UsageForecastResponse{
+				{Component: "reserve", Size: 100, Capacity: 200, GrowthRate: 0.5, TimeToFull: &ttf},
+				{Component: "pinning", Size: 4096},
+			},
+		}),
+	)
+}
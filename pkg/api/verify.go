@@ -0,0 +1,59 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/calmw/bee-tron/pkg/jsonhttp"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/verifier"
+	"github.com/gorilla/mux"
+)
+
+type verifyResponse struct {
+	Reference swarm.Address   `json:"reference"`
+	Total     int             `json:"total"`
+	Missing   []swarm.Address `json:"missing"`
+	Corrupt   []swarm.Address `json:"corrupt"`
+	Complete  bool            `json:"complete"`
+}
+
+// verifyHandler checks the integrity of every locally stored chunk of the
+// content of the given address against its address.
+func (s *Service) verifyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_verify").Build()
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	report, err := verifier.New(s.storer.ChunkStore()).Verify(r.Context(), paths.Address)
+	if err != nil {
+		logger.Debug("verify failed", "chunk_address", paths.Address, "error", err)
+		logger.Error(nil, "verify failed")
+		jsonhttp.InternalServerError(w, "verify failed")
+		return
+	}
+
+	resp := verifyResponse{
+		Reference: report.Reference,
+		Total:     report.Total,
+		Missing:   report.Missing,
+		Corrupt:   report.Corrupt,
+		Complete:  report.Complete,
+	}
+	if resp.Missing == nil {
+		resp.Missing = []swarm.Address{}
+	}
+	if resp.Corrupt == nil {
+		resp.Corrupt = []swarm.Address{}
+	}
+	jsonhttp.OK(w, resp)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/api"
+	"github.com/calmw/bee-tron/pkg/jsonhttp/jsonhttptest"
+	mockpost "github.com/calmw/bee-tron/pkg/postage/mock"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	mockstorer "github.com/calmw/bee-tron/pkg/storer/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// nolint:paralleltest
+func TestVerify(t *testing.T) {
+	var (
+		store           = inmemchunkstore.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: mockstorer.NewWithChunkStore(store),
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		data = bytes.Repeat([]byte("verify"), 1000)
+		resp api.BytesPostResponse
+	)
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+
+	t.Run("intact", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/verify/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.VerifyResponse{
+				Reference: resp.Reference,
+				Total:     3,
+				Missing:   []swarm.Address{},
+				Corrupt:   []swarm.Address{},
+				Complete:  true,
+			}),
+		)
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		ctx := context.Background()
+		root, err := store.Get(ctx, resp.Reference)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaf := swarm.NewAddress(root.Data()[swarm.SpanSize : swarm.SpanSize+swarm.HashSize])
+		if err := store.Replace(ctx, swarm.NewChunk(leaf, root.Data()), false); err != nil {
+			t.Fatal(err)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/verify/"+resp.Reference.String(), http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.VerifyResponse{
+				Reference: resp.Reference,
+				Total:     3,
+				Missing:   []swarm.Address{},
+				Corrupt:   []swarm.Address{leaf},
+				Complete:  true,
+			}),
+		)
+	})
+}
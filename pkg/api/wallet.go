@@ -5,6 +5,7 @@
 package api
 
 import (
+	"errors"
 	"math/big"
 	"net/http"
 	"strings"
@@ -81,13 +82,25 @@ func (s *Service) walletWithdrawHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var bzz bool
+	var (
+		bzz   bool
+		token *transaction.Token
+	)
 
 	if strings.EqualFold("BZZ", *path.Coin) {
 		bzz = true
 	} else if !strings.EqualFold("NativeToken", *path.Coin) {
-		jsonhttp.BadRequest(w, "only BZZ or NativeToken options are accepted")
-		return
+		if s.tokens == nil {
+			jsonhttp.BadRequest(w, "only BZZ or NativeToken options are accepted")
+			return
+		}
+		t, err := s.tokens.Lookup(r.Context(), *path.Coin)
+		if err != nil {
+			logger.Debug("unable to look up token", "coin", *path.Coin, "error", err)
+			jsonhttp.BadRequest(w, "only BZZ, NativeToken, a registered token symbol or a token contract address are accepted")
+			return
+		}
+		token = &t
 	}
 
 	if !slices.Contains(s.whitelistedWithdrawalAddress, *queries.Address) {
@@ -95,6 +108,35 @@ func (s *Service) walletWithdrawHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if token != nil {
+		currentBalance, err := s.tokens.BalanceOf(r.Context(), *token, s.ethereumAddress)
+		if err != nil {
+			logger.Error(err, "unable to get token balance", "token", token.Address)
+			jsonhttp.InternalServerError(w, "unable to get balance")
+			return
+		}
+
+		if queries.Amount.Cmp(currentBalance) > 0 {
+			jsonhttp.BadRequest(w, "not enough balance")
+			return
+		}
+
+		txHash, err := s.tokens.Transfer(r.Context(), *token, *queries.Address, queries.Amount)
+		var revertErr *transaction.RevertError
+		if errors.As(err, &revertErr) {
+			logger.Debug("token transfer simulation reverted", "token", token.Address, "error", err)
+			jsonhttp.BadRequest(w, revertErr.Error())
+			return
+		}
+		if err != nil {
+			logger.Error(err, "unable to transfer token", "token", token.Address)
+			jsonhttp.InternalServerError(w, "unable to transfer amount")
+			return
+		}
+		jsonhttp.OK(w, walletTxResponse{TransactionHash: txHash})
+		return
+	}
+
 	if bzz {
 		currentBalance, err := s.erc20Service.BalanceOf(r.Context(), s.ethereumAddress)
 		if err != nil {
@@ -18,9 +18,13 @@ import (
 	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/calmw/bee-tron/pkg/transaction/backendmock"
 	transactionmock "github.com/calmw/bee-tron/pkg/transaction/mock"
+	"github.com/calmw/bee-tron/pkg/util/abiutil"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/go-sw3-abi/sw3abi"
 )
 
+var tokenABI = abiutil.MustParseABI(sw3abi.ERC20ABIv0_6_5)
+
 func TestWallet(t *testing.T) {
 	t.Parallel()
 
@@ -276,4 +280,67 @@ func TestWalletWithdraw(t *testing.T) {
 				TransactionHash: txHash,
 			}))
 	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _, _, _ := newTestServer(t, testServerOptions{
+			WhitelistedAddr: "0xaf",
+			Tokens:          transaction.NewTokenRegistry(transactionmock.New()),
+		})
+
+		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/BTC?address=0xaf&amount=99999999", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "only BZZ, NativeToken, a registered token symbol or a token contract address are accepted",
+				Code:    400,
+			}))
+	})
+
+	t.Run("token ok", func(t *testing.T) {
+		t.Parallel()
+
+		txHash := common.HexToHash("0x00f")
+		usdt := transaction.Token{Symbol: "USDT", Address: common.HexToAddress("0xa614f803b6fd780986a42c78ec9c7f77e6ded13c"), Decimals: 6}
+		balance, err := tokenABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(100000000))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		srv, _, _, _ := newTestServer(t, testServerOptions{
+			WhitelistedAddr: "0xaf",
+			Tokens: transaction.NewTokenRegistry(transactionmock.New(
+				transactionmock.WithABICall(&tokenABI, usdt.Address, balance, "balanceOf", common.Address{}),
+				transactionmock.WithABISend(&tokenABI, txHash, usdt.Address, big.NewInt(0), "transfer", common.HexToAddress("0xaf"), big.NewInt(99999999)),
+			), usdt),
+		})
+
+		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t?address=0xaf&amount=99999999", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.WalletTxResponse{
+				TransactionHash: txHash,
+			}))
+	})
+
+	t.Run("token insufficient balance", func(t *testing.T) {
+		t.Parallel()
+
+		usdt := transaction.Token{Symbol: "USDT", Address: common.HexToAddress("0xa614f803b6fd780986a42c78ec9c7f77e6ded13c"), Decimals: 6}
+		balance, err := tokenABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(88888888))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		srv, _, _, _ := newTestServer(t, testServerOptions{
+			WhitelistedAddr: "0xaf",
+			Tokens: transaction.NewTokenRegistry(transactionmock.New(
+				transactionmock.WithABICall(&tokenABI, usdt.Address, balance, "balanceOf", common.Address{}),
+			), usdt),
+		})
+
+		jsonhttptest.Request(t, srv, http.MethodPost, "/wallet/withdraw/usdt?address=0xaf&amount=99999999", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "not enough balance",
+				Code:    400,
+			}))
+	})
+
 }
@@ -0,0 +1,203 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clef provides a crypto.Signer delegating all signing to an
+// external signer daemon speaking the clef RPC API, so that the private key
+// of the node never resides in its memory.
+package clef
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/crypto/eip712"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrNoAccounts is the error if the external signer does not manage any account.
+	ErrNoAccounts = errors.New("no accounts found in external signer")
+	// ErrAccountNotAvailable is the error if the requested account is not managed by the external signer.
+	ErrAccountNotAvailable = errors.New("account not available in external signer")
+	// ErrIncompatiblePrefix is the error if the external signer signs messages with an unexpected prefix.
+	ErrIncompatiblePrefix = errors.New("external signer uses an incompatible message prefix")
+
+	// recoveryMessage is signed on startup to learn the public key of the account.
+	recoveryMessage = []byte("public key recovery message")
+)
+
+// MimetypeRawHash is the content type of account_signData signing a hash as
+// is. The node signs the hashes of its own message prefix with it, as the
+// text/plain content type adds the ethereum message prefix.
+const MimetypeRawHash = "data/raw-hash"
+
+// ExternalSigner is the subset of the go-ethereum external signer client used by the signer.
+type ExternalSigner interface {
+	SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error)
+	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	Accounts() []accounts.Account
+}
+
+// Client is the low-level rpc client to the external signer. It is needed as
+// the external signer client does not expose account_signTypedData.
+type Client interface {
+	Call(result interface{}, method string, args ...interface{}) error
+}
+
+type clefSigner struct {
+	client  Client
+	clef    ExternalSigner
+	account accounts.Account // the account this signer uses
+	pubKey  *ecdsa.PublicKey // the public key of the account
+}
+
+// DefaultIpcPath returns the os-dependent default ipc path of clef.
+func DefaultIpcPath() (string, error) {
+	socket := "clef.ipc"
+	// on windows clef uses top level pipes
+	if runtime.GOOS == "windows" {
+		return `\\.\pipe\` + socket, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	// on mac os clef defaults to ~/Library/Signer/clef.ipc
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(home, "Library", "Signer", socket), nil
+	}
+
+	// on unix clef defaults to ~/.clef/clef.ipc
+	return filepath.Join(home, ".clef", socket), nil
+}
+
+// NewSigner creates a signer using the account with the given address, or
+// the first account of the external signer if ethAddress is nil. As the
+// external signer does not expose public keys, a text message, which the
+// external signer signs with the ethereum message prefix, is signed on
+// creation to recover it.
+func NewSigner(clef ExternalSigner, client Client, ethAddress *common.Address) (crypto.Signer, error) {
+	clefAccounts := clef.Accounts()
+	if len(clefAccounts) == 0 {
+		return nil, ErrNoAccounts
+	}
+
+	account := clefAccounts[0]
+	if ethAddress != nil {
+		found := false
+		for _, a := range clefAccounts {
+			if a.Address == *ethAddress {
+				account, found = a, true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrAccountNotAvailable
+		}
+	}
+
+	sig, err := clef.SignData(account, accounts.MimetypeTextPlain, recoveryMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := recoverHash(sig, accounts.TextHash(recoveryMessage))
+	if err != nil {
+		return nil, err
+	}
+
+	recovered, err := crypto.NewEthereumAddress(*pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if common.BytesToAddress(recovered) != account.Address {
+		return nil, ErrIncompatiblePrefix
+	}
+
+	return &clefSigner{
+		client:  client,
+		clef:    clef,
+		account: account,
+		pubKey:  pubKey,
+	}, nil
+}
+
+// PublicKey returns the public key recovered during creation.
+func (c *clefSigner) PublicKey() (*ecdsa.PublicKey, error) {
+	return c.pubKey, nil
+}
+
+// Sign signs the hash of data with the message prefix of the node as a raw
+// hash. An external signer which does not sign raw hashes adds its own
+// prefix, so the signature is verified before it is returned.
+func (c *clefSigner) Sign(data []byte) ([]byte, error) {
+	hash, err := crypto.HashWithPrefix(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := c.clef.SignData(c.account, MimetypeRawHash, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := recoverHash(sig, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !pubKey.Equal(c.pubKey) {
+		return nil, ErrIncompatiblePrefix
+	}
+	return sig, nil
+}
+
+// SignTx signs an ethereum transaction.
+func (c *clefSigner) SignTx(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return c.clef.SignTx(c.account, transaction, chainID)
+}
+
+// EthereumAddress returns the ethereum address this signer uses.
+func (c *clefSigner) EthereumAddress() (common.Address, error) {
+	return c.account.Address, nil
+}
+
+// SignTypedData signs data according to eip712.
+func (c *clefSigner) SignTypedData(typedData *eip712.TypedData) ([]byte, error) {
+	var sig hexutil.Bytes
+	err := c.client.Call(&sig, "account_signTypedData", c.account.Address, typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// recoverHash recovers the public key from a signature over the hash in the
+// ethereum (r,s,v) format.
+func recoverHash(signature, hash []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != 65 {
+		return nil, crypto.ErrInvalidLength
+	}
+	// convert to btcec input format with 'recovery id' v at the beginning
+	btcsig := make([]byte, 65)
+	btcsig[0] = signature[64]
+	copy(btcsig[1:], signature)
+
+	pbk, _, err := btcecdsa.RecoverCompact(btcsig, hash)
+	if err != nil {
+		return nil, err
+	}
+	return pbk.ToECDSA(), nil
+}
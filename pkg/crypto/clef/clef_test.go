@@ -0,0 +1,226 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clef_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/crypto/clef"
+	"github.com/calmw/bee-tron/pkg/crypto/eip712"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// mockClef signs like clef, adding the ethereum message prefix to text/plain
+// data and to the data of unknown content types. The raw hashes are signed
+// as is if rawHash is set.
+type mockClef struct {
+	accounts []accounts.Account
+	key      *ecdsa.PrivateKey
+	signer   crypto.Signer
+	rawHash  bool
+	signed   [][]byte
+}
+
+func (m *mockClef) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	m.signed = append(m.signed, data)
+
+	hash := accounts.TextHash(data)
+	if mimeType == clef.MimetypeRawHash && m.rawHash {
+		hash = data
+	}
+	sig, err := ethcrypto.Sign(hash, m.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func (m *mockClef) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return m.signer.SignTx(tx, chainID)
+}
+
+func (m *mockClef) Accounts() []accounts.Account {
+	return m.accounts
+}
+
+type mockClient struct {
+	signer crypto.Signer
+	method string
+}
+
+func (m *mockClient) Call(result interface{}, method string, args ...interface{}) error {
+	m.method = method
+	sig, err := m.signer.SignTypedData(args[1].(*eip712.TypedData))
+	if err != nil {
+		return err
+	}
+	*result.(*hexutil.Bytes) = sig
+	return nil
+}
+
+func newTestSigner(t *testing.T) (crypto.Signer, *ecdsa.PrivateKey, common.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(key)
+	address, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key, address
+}
+
+func TestNewClefSigner(t *testing.T) {
+	t.Parallel()
+
+	signer, key, address := newTestSigner(t)
+	otherAddress := common.HexToAddress("0x1234")
+
+	clefMock := &mockClef{
+		accounts: []accounts.Account{{Address: otherAddress}, {Address: address}},
+		key:      key,
+		signer:   signer,
+		rawHash:  true,
+	}
+
+	clefSigner, err := clef.NewSigner(clefMock, &mockClient{signer: signer}, &address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotAddress, err := clefSigner.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotAddress != address {
+		t.Fatalf("got address %x, want %x", gotAddress, address)
+	}
+
+	publicKey, err := clefSigner.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !publicKey.Equal(&key.PublicKey) {
+		t.Fatal("recovered wrong public key")
+	}
+
+	data := []byte("data")
+	sig, err := clefSigner.Sign(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := crypto.Recover(sig, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.Equal(&key.PublicKey) {
+		t.Fatal("signature recovers to wrong public key")
+	}
+	hash, err := crypto.HashWithPrefix(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clefMock.signed) != 2 || !bytes.Equal(clefMock.signed[1], hash) {
+		t.Fatalf("unexpected data signed by external signer %q", clefMock.signed)
+	}
+
+	_, err = clef.NewSigner(clefMock, &mockClient{signer: signer}, &common.Address{})
+	if !errors.Is(err, clef.ErrAccountNotAvailable) {
+		t.Fatalf("got error %v, want %v", err, clef.ErrAccountNotAvailable)
+	}
+}
+
+func TestNewClefSignerNoAccounts(t *testing.T) {
+	t.Parallel()
+
+	_, err := clef.NewSigner(&mockClef{}, &mockClient{}, nil)
+	if !errors.Is(err, clef.ErrNoAccounts) {
+		t.Fatalf("got error %v, want %v", err, clef.ErrNoAccounts)
+	}
+}
+
+func TestNewClefSignerIncompatiblePrefix(t *testing.T) {
+	t.Parallel()
+
+	signer, key, address := newTestSigner(t)
+	_, otherKey, _ := newTestSigner(t)
+
+	// the signature does not recover to the account, as if signed with another prefix
+	_, err := clef.NewSigner(&mockClef{
+		accounts: []accounts.Account{{Address: address}},
+		key:      otherKey,
+	}, &mockClient{signer: signer}, nil)
+	if !errors.Is(err, clef.ErrIncompatiblePrefix) {
+		t.Fatalf("got error %v, want %v", err, clef.ErrIncompatiblePrefix)
+	}
+
+	// an external signer without the raw hashes adds the ethereum prefix to them
+	clefSigner, err := clef.NewSigner(&mockClef{
+		accounts: []accounts.Account{{Address: address}},
+		key:      key,
+	}, &mockClient{signer: signer}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clefSigner.Sign([]byte("data")); !errors.Is(err, clef.ErrIncompatiblePrefix) {
+		t.Fatalf("got error %v, want %v", err, clef.ErrIncompatiblePrefix)
+	}
+}
+
+func TestClefSignTypedData(t *testing.T) {
+	t.Parallel()
+
+	signer, key, address := newTestSigner(t)
+	client := &mockClient{signer: signer}
+
+	clefSigner, err := clef.NewSigner(&mockClef{
+		accounts: []accounts.Account{{Address: address}},
+		key:      key,
+		signer:   signer,
+	}, client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typedData := &eip712.TypedData{
+		Domain: eip712.TypedDataDomain{Name: "test", Version: "1.0", ChainId: math.NewHexOrDecimal256(1)},
+		Types: eip712.Types{
+			"EIP712Domain": eip712.EIP712DomainType,
+			"Test":         []eip712.Type{{Name: "value", Type: "uint256"}},
+		},
+		Message:     eip712.TypedDataMessage{"value": "1"},
+		PrimaryType: "Test",
+	}
+
+	sig, err := clefSigner.SignTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.method != "account_signTypedData" {
+		t.Fatalf("called method %s, want account_signTypedData", client.method)
+	}
+
+	want, err := signer.SignTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, want) {
+		t.Fatalf("got signature %x, want %x", sig, want)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hwwallet provides a crypto.Signer backed by a hardware wallet such
+// as a Ledger or Trezor device. Every signing request is announced through an
+// approval function before it is forwarded to the device, where it has to be
+// confirmed by the operator.
+//
+// Hardware wallets only sign transactions and EIP-712 typed data, so the
+// signer can be used for blockchain transactions and cheques, but not for
+// signing the arbitrary messages the node identity requires.
+package hwwallet
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/crypto/eip712"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultDerivationPath is the derivation path of the first account of the
+// ethereum app of hardware wallets.
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+const (
+	// RequestTransaction is the kind of requests to sign a transaction.
+	RequestTransaction = "transaction"
+	// RequestTypedData is the kind of requests to sign EIP-712 typed data.
+	RequestTypedData = "typed-data"
+)
+
+var (
+	// ErrNotSupported is the error if an operation is not supported by hardware wallets.
+	ErrNotSupported = errors.New("operation not supported by hardware wallet")
+	// ErrRejected is the error if a signing request was rejected before reaching the device.
+	ErrRejected = errors.New("signing request rejected")
+	// ErrWalletNotFound is the error if no hardware wallet is connected.
+	ErrWalletNotFound = errors.New("no hardware wallet found")
+)
+
+// Request describes a signing request forwarded to the hardware wallet.
+type Request struct {
+	Kind    string
+	Account common.Address
+	// transaction requests
+	To      *common.Address
+	Value   *big.Int
+	Nonce   uint64
+	ChainID *big.Int
+	// typed data requests
+	PrimaryType string
+}
+
+// ApproveFunc is called before a request is forwarded to the device. It
+// typically instructs the operator to confirm the request on the device and
+// may reject it by returning an error.
+type ApproveFunc func(req Request) error
+
+type signer struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+	approve ApproveFunc
+}
+
+// FirstWallet returns the first wallet of the backend, opening it if needed.
+func FirstWallet(backend accounts.Backend) (accounts.Wallet, error) {
+	wallets := backend.Wallets()
+	if len(wallets) == 0 {
+		return nil, ErrWalletNotFound
+	}
+
+	wallet := wallets[0]
+	if err := wallet.Open(""); err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return nil, fmt.Errorf("open hardware wallet %s: %w", wallet.URL(), err)
+	}
+	return wallet, nil
+}
+
+// NewSigner creates a signer using the account at the derivation path of an
+// opened wallet. The path has the format of DefaultDerivationPath.
+func NewSigner(wallet accounts.Wallet, path string, approve ApproveFunc) (crypto.Signer, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("derivation path %q: %w", path, err)
+	}
+
+	account, err := wallet.Derive(derivationPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("derive account: %w", err)
+	}
+
+	return &signer{
+		wallet:  wallet,
+		account: account,
+		approve: approve,
+	}, nil
+}
+
+// Sign is not supported by hardware wallets.
+func (s *signer) Sign([]byte) ([]byte, error) {
+	return nil, ErrNotSupported
+}
+
+// SignTx signs an ethereum transaction after it was approved and confirmed on the device.
+func (s *signer) SignTx(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	err := s.request(Request{
+		Kind:    RequestTransaction,
+		Account: s.account.Address,
+		To:      transaction.To(),
+		Value:   transaction.Value(),
+		Nonce:   transaction.Nonce(),
+		ChainID: chainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wallet.SignTx(s.account, transaction, chainID)
+}
+
+// SignTypedData signs data according to eip712 after it was approved and confirmed on the device.
+func (s *signer) SignTypedData(typedData *eip712.TypedData) ([]byte, error) {
+	rawData, err := eip712.EncodeForSigning(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.request(Request{
+		Kind:        RequestTypedData,
+		Account:     s.account.Address,
+		PrimaryType: typedData.PrimaryType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wallet.SignData(s.account, accounts.MimetypeTypedData, rawData)
+}
+
+// PublicKey is not supported by hardware wallets.
+func (s *signer) PublicKey() (*ecdsa.PublicKey, error) {
+	return nil, ErrNotSupported
+}
+
+// EthereumAddress returns the address of the derived account.
+func (s *signer) EthereumAddress() (common.Address, error) {
+	return s.account.Address, nil
+}
+
+func (s *signer) request(req Request) error {
+	if s.approve == nil {
+		return nil
+	}
+	if err := s.approve(req); err != nil {
+		return fmt.Errorf("%w: %w", ErrRejected, err)
+	}
+	return nil
+}
+
+// String returns a human readable description of the request.
+func (r Request) String() string {
+	if r.Kind == RequestTypedData {
+		return fmt.Sprintf("sign %s typed data with account %s", r.PrimaryType, r.Account)
+	}
+
+	to := "contract creation"
+	if r.To != nil {
+		to = r.To.String()
+	}
+	return fmt.Sprintf("sign transaction with account %s to %s with value %s, nonce %d on chain %s", r.Account, to, r.Value, r.Nonce, r.ChainID)
+}
+
+// NewPromptApprover returns an ApproveFunc asking the operator to approve
+// every request on out and reading the answer from in. Approved requests
+// still have to be confirmed on the device.
+func NewPromptApprover(in io.Reader, out io.Writer) ApproveFunc {
+	var mu sync.Mutex
+	reader := bufio.NewReader(in)
+
+	return func(req Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if _, err := fmt.Fprintf(out, "Hardware wallet request: %s\nApprove? [y/N] ", req); err != nil {
+			return err
+		}
+		answer, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+		default:
+			return errors.New("declined by operator")
+		}
+
+		_, err = fmt.Fprintln(out, "Confirm the request on the hardware wallet.")
+		return err
+	}
+}
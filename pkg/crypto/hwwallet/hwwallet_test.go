@@ -0,0 +1,172 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hwwallet_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/crypto/eip712"
+	"github.com/calmw/bee-tron/pkg/crypto/hwwallet"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockWallet implements the parts of accounts.Wallet used by the signer.
+type mockWallet struct {
+	accounts.Wallet
+	address  common.Address
+	derived  accounts.DerivationPath
+	signedTx *types.Transaction
+	mimeType string
+	data     []byte
+}
+
+func (m *mockWallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	m.derived = path
+	return accounts.Account{Address: m.address}, nil
+}
+
+func (m *mockWallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	m.signedTx = tx
+	return tx, nil
+}
+
+func (m *mockWallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	m.mimeType = mimeType
+	m.data = data
+	return []byte{1}, nil
+}
+
+func testTypedData() *eip712.TypedData {
+	return &eip712.TypedData{
+		Domain: eip712.TypedDataDomain{Name: "test", Version: "1.0", ChainId: math.NewHexOrDecimal256(1)},
+		Types: eip712.Types{
+			"EIP712Domain": eip712.EIP712DomainType,
+			"Test":         []eip712.Type{{Name: "value", Type: "uint256"}},
+		},
+		Message:     eip712.TypedDataMessage{"value": "1"},
+		PrimaryType: "Test",
+	}
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	wallet := &mockWallet{address: common.HexToAddress("0xabcd")}
+	var requests []hwwallet.Request
+
+	signer, err := hwwallet.NewSigner(wallet, "m/44'/60'/0'/0/1", func(req hwwallet.Request) error {
+		requests = append(requests, req)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantPath, err := accounts.ParseDerivationPath("m/44'/60'/0'/0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.derived.String() != wantPath.String() {
+		t.Fatalf("derived path %s, want %s", wallet.derived, wantPath)
+	}
+
+	address, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != wallet.address {
+		t.Fatalf("got address %s, want %s", address, wallet.address)
+	}
+
+	to := common.HexToAddress("0x1234")
+	tx := types.NewTransaction(3, to, big.NewInt(10), 21000, big.NewInt(1), nil)
+	if _, err := signer.SignTx(tx, big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	if wallet.signedTx != tx {
+		t.Fatal("transaction not signed by wallet")
+	}
+
+	typedData := testTypedData()
+	if _, err := signer.SignTypedData(typedData); err != nil {
+		t.Fatal(err)
+	}
+	wantData, err := eip712.EncodeForSigning(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.mimeType != accounts.MimetypeTypedData || !bytes.Equal(wallet.data, wantData) {
+		t.Fatalf("unexpected typed data request %s %x", wallet.mimeType, wallet.data)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d approval requests, want 2", len(requests))
+	}
+	if r := requests[0]; r.Kind != hwwallet.RequestTransaction || *r.To != to || r.Nonce != 3 || r.Value.Cmp(big.NewInt(10)) != 0 || r.ChainID.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("unexpected transaction request %+v", r)
+	}
+	if r := requests[1]; r.Kind != hwwallet.RequestTypedData || r.PrimaryType != "Test" || r.Account != wallet.address {
+		t.Fatalf("unexpected typed data request %+v", r)
+	}
+
+	if _, err := signer.Sign([]byte("data")); !errors.Is(err, hwwallet.ErrNotSupported) {
+		t.Fatalf("got error %v, want %v", err, hwwallet.ErrNotSupported)
+	}
+	if _, err := signer.PublicKey(); !errors.Is(err, hwwallet.ErrNotSupported) {
+		t.Fatalf("got error %v, want %v", err, hwwallet.ErrNotSupported)
+	}
+}
+
+func TestSignerRejected(t *testing.T) {
+	t.Parallel()
+
+	wallet := &mockWallet{}
+	signer, err := hwwallet.NewSigner(wallet, hwwallet.DefaultDerivationPath, hwwallet.NewPromptApprover(strings.NewReader("n\n"), new(bytes.Buffer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	if _, err := signer.SignTx(tx, big.NewInt(1)); !errors.Is(err, hwwallet.ErrRejected) {
+		t.Fatalf("got error %v, want %v", err, hwwallet.ErrRejected)
+	}
+	if wallet.signedTx != nil {
+		t.Fatal("rejected transaction reached the wallet")
+	}
+}
+
+func TestSignerInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	_, err := hwwallet.NewSigner(&mockWallet{}, "m/invalid", nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPromptApprover(t *testing.T) {
+	t.Parallel()
+
+	out := new(bytes.Buffer)
+	approve := hwwallet.NewPromptApprover(strings.NewReader("y\nno\n"), out)
+	req := hwwallet.Request{Kind: hwwallet.RequestTypedData, PrimaryType: "Cheque"}
+
+	if err := approve(req); err != nil {
+		t.Fatal(err)
+	}
+	if err := approve(req); err == nil {
+		t.Fatal("expected declined request")
+	}
+	if !strings.Contains(out.String(), "sign Cheque typed data") {
+		t.Fatalf("request not shown to operator: %q", out.String())
+	}
+}
@@ -44,6 +44,11 @@ func hashWithEthereumPrefix(data []byte) ([]byte, error) {
 	return LegacyKeccak256(addEthereumPrefix(data))
 }
 
+// HashWithPrefix returns the hash which Sign signs for the given data.
+func HashWithPrefix(data []byte) ([]byte, error) {
+	return hashWithEthereumPrefix(data)
+}
+
 // Recover verifies signature with the data base provided.
 // It is using `btcec.RecoverCompact` function.
 func Recover(signature, data []byte) (*ecdsa.PublicKey, error) {
@@ -5,6 +5,8 @@
 package encryption
 
 import (
+	"errors"
+
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"golang.org/x/crypto/sha3"
 )
@@ -38,3 +40,28 @@ func NewSpanEncryption(key Key) Interface {
 func NewDataEncryption(key Key) Interface {
 	return New(key, int(swarm.ChunkSize), 0, sha3.NewLegacyKeccak256)
 }
+
+// ErrInvalidRange is returned if the range to decrypt is out of the data.
+var ErrInvalidRange = errors.New("encryption: invalid range")
+
+// DecryptDataRange decrypts length bytes of the encrypted chunk payload
+// from the given offset. Only the segments of the range are decrypted, using
+// the counters of their positions in the payload, so the preceding segments
+// need not be decrypted for random access.
+func DecryptDataRange(key Key, data []byte, off, length int) ([]byte, error) {
+	if off < 0 || length < 0 || off+length > len(data) {
+		return nil, ErrInvalidRange
+	}
+
+	e := &Encryption{key: key, keyLen: len(key), hashFunc: sha3.NewLegacyKeccak256}
+	start := off - off%e.keyLen
+	end := off + length
+	out := make([]byte, end-start)
+	for i := start; i < end; i += e.keyLen {
+		l := min(e.keyLen, end-i)
+		if err := e.Transcrypt(i/e.keyLen, data[i:i+l], out[i-start:i-start+l]); err != nil {
+			return nil, err
+		}
+	}
+	return out[off-start:], nil
+}
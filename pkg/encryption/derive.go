@@ -0,0 +1,41 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// The info of the HKDF-SHA256 derivations, which separates the keys of the
+// files from the keys of the chunks.
+const (
+	fileKeyInfo  = "swarm-file-key"
+	chunkKeyInfo = "swarm-chunk-key"
+)
+
+// DeriveFileKey derives the key of the file at the path from the master key,
+// so a single secret can be shared for the files of a collection while each
+// file is encrypted with a different key.
+func DeriveFileKey(master Key, path string) Key {
+	return deriveKey(master, append([]byte(fileKeyInfo), path...))
+}
+
+// DeriveChunkKey derives the key of the chunk at the index of a file from
+// the key of the file.
+func DeriveChunkKey(fileKey Key, index uint64) Key {
+	return deriveKey(fileKey, binary.LittleEndian.AppendUint64([]byte(chunkKeyInfo), index))
+}
+
+// deriveKey derives a key of KeyLength bytes from the secret and the info.
+func deriveKey(secret Key, info []byte) Key {
+	key := make(Key, KeyLength)
+	// the reads of HKDF fail only above 255 hash lengths
+	_, _ = io.ReadFull(hkdf.New(sha256.New, secret, nil, info), key)
+	return key
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+)
+
+func TestDeriveKeys(t *testing.T) {
+	t.Parallel()
+
+	master := testutil.RandBytes(t, encryption.KeyLength)
+
+	fileKey := encryption.DeriveFileKey(master, "img/1.png")
+	if len(fileKey) != encryption.KeyLength {
+		t.Fatalf("got file key length %d, want %d", len(fileKey), encryption.KeyLength)
+	}
+	if !bytes.Equal(fileKey, encryption.DeriveFileKey(master, "img/1.png")) {
+		t.Fatal("file key derivation is not deterministic")
+	}
+
+	keys := [][]byte{
+		master,
+		fileKey,
+		encryption.DeriveFileKey(master, "img/2.png"),
+		encryption.DeriveFileKey(testutil.RandBytes(t, encryption.KeyLength), "img/1.png"),
+		encryption.DeriveChunkKey(fileKey, 0),
+		encryption.DeriveChunkKey(fileKey, 1),
+		encryption.DeriveChunkKey(master, 0),
+	}
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			if bytes.Equal(keys[i], keys[j]) {
+				t.Fatalf("keys %d and %d are equal", i, j)
+			}
+		}
+	}
+
+	if !bytes.Equal(encryption.DeriveChunkKey(fileKey, 1), encryption.DeriveChunkKey(fileKey, 1)) {
+		t.Fatal("chunk key derivation is not deterministic")
+	}
+}
@@ -19,6 +19,7 @@ package encryption_test
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/encryption"
@@ -190,3 +191,31 @@ func TestEncryptSectioned(t *testing.T) {
 		}
 	}
 }
+
+// TestDecryptDataRange tests that the decrypted ranges of the chunk payload
+// are the same as the ones of the whole decrypted payload
+func TestDecryptDataRange(t *testing.T) {
+	t.Parallel()
+
+	data := testutil.RandBytes(t, 3000)
+	key := testutil.RandBytes(t, encryption.KeyLength)
+
+	encrypted, err := encryption.NewDataEncryption(key).Encrypt(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range [][2]int{{0, 0}, {0, 3000}, {32, 64}, {31, 2}, {100, 1}, {1000, 2000}, {2999, 1}} {
+		got, err := encryption.DecryptDataRange(key, encrypted, r[0], r[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := data[r[0] : r[0]+r[1]]; !bytes.Equal(got, want) {
+			t.Fatalf("range %v: expected %x, got %x", r, want, got)
+		}
+	}
+
+	if _, err := encryption.DecryptDataRange(key, encrypted, 4000, 100); !errors.Is(err, encryption.ErrInvalidRange) {
+		t.Fatalf("expected error %v, got %v", encryption.ErrInvalidRange, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Scheme is the encryption scheme of the chunks of an encrypted content.
+type Scheme uint8
+
+const (
+	// KeccakCTR is the legacy scheme, XOR-ing the chunk data with a
+	// Keccak256 based keystream in counter mode.
+	KeccakCTR Scheme = iota
+	// AESGCM is the AES-256-GCM authenticated encryption scheme.
+	AESGCM
+	// ChaCha20Poly1305 is the ChaCha20-Poly1305 authenticated encryption scheme.
+	ChaCha20Poly1305
+)
+
+// The key of an encrypted reference of the authenticated schemes is the
+// scheme identifier, followed by the 256-bit cipher key of the chunk and the
+// authentication tag of the chunk, as the chunk has no room for the tag. The
+// references of the authenticated schemes are longer than the legacy ones,
+// so the scheme of a reference is identified by its length.
+const (
+	TagLength = 16
+	// SchemeKeyLength is the length of the keys of the authenticated schemes.
+	SchemeKeyLength = 1 + KeyLength + TagLength
+	// SchemeReferenceSize is the length of the references of the
+	// authenticated schemes.
+	SchemeReferenceSize = swarm.HashSize + SchemeKeyLength
+)
+
+var (
+	ErrUnknownScheme  = errors.New("encryption: unknown scheme")
+	ErrAuthentication = errors.New("encryption: message authentication failed")
+)
+
+var schemeNames = map[Scheme]string{
+	KeccakCTR:        "keccak-ctr",
+	AESGCM:           "aes-gcm",
+	ChaCha20Poly1305: "chacha20-poly1305",
+}
+
+// String returns the name of the scheme.
+func (s Scheme) String() string {
+	if name, ok := schemeNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("scheme(%d)", uint8(s))
+}
+
+// ParseScheme returns the scheme of the given name.
+func ParseScheme(name string) (Scheme, error) {
+	for s, n := range schemeNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownScheme, name)
+}
+
+// ReferenceSize returns the length of the encrypted references of the scheme.
+func (s Scheme) ReferenceSize() int {
+	if s == KeccakCTR {
+		return ReferenceSize
+	}
+	return SchemeReferenceSize
+}
+
+// IsEncryptedReferenceSize reports whether the references of the given length
+// are encrypted references of any scheme.
+func IsEncryptedReferenceSize(size int) bool {
+	return size == ReferenceSize || size == SchemeReferenceSize
+}
+
+// KeyScheme returns the scheme identified by the key of an encrypted
+// reference. The keys of the legacy length are the keys of the legacy scheme,
+// the keys of the authenticated schemes start with the scheme identifier.
+func KeyScheme(key Key) Scheme {
+	if len(key) == SchemeKeyLength {
+		return Scheme(key[0])
+	}
+	return KeccakCTR
+}
+
+// NewSchemeChunkEncrypter returns the ChunkEncrypter of the scheme.
+func NewSchemeChunkEncrypter(s Scheme) (ChunkEncrypter, error) {
+	switch s {
+	case KeccakCTR:
+		return NewChunkEncrypter(), nil
+	case AESGCM, ChaCha20Poly1305:
+		return &aeadChunkEncrypter{scheme: s}, nil
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownScheme, s)
+}
+
+// aeadChunkEncrypter encrypts the span and the padded data of a chunk as a
+// single message. The chunk keeps its size, as the authentication tag is
+// stored in the key of the reference instead of the chunk.
+type aeadChunkEncrypter struct {
+	scheme Scheme
+}
+
+func (c *aeadChunkEncrypter) EncryptChunk(chunkData []byte) (Key, []byte, []byte, error) {
+	if len(chunkData) > swarm.SpanSize+swarm.ChunkSize {
+		return nil, nil, nil, fmt.Errorf("data length longer than chunk size, data length %v", len(chunkData))
+	}
+
+	key := make(Key, SchemeKeyLength)
+	key[0] = byte(c.scheme)
+	copy(key[1:], GenerateRandomKey(KeyLength))
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// the data is padded like in the legacy scheme
+	plaintext := make([]byte, swarm.SpanSize+swarm.ChunkSize)
+	n := copy(plaintext, chunkData)
+	pad(plaintext[n:])
+
+	sealed := aead.Seal(nil, make([]byte, aead.NonceSize()), plaintext, key[:1])
+	copy(key[1+KeyLength:], sealed[len(plaintext):])
+	return key, sealed[:swarm.SpanSize], sealed[swarm.SpanSize:len(plaintext)], nil
+}
+
+// DecryptChunk decrypts the encrypted span and data of a chunk with the key
+// of its reference, in the scheme identified by the key. The chunks of the
+// authenticated schemes which fail the authentication are not decrypted and
+// ErrAuthentication is returned.
+func DecryptChunk(key Key, chunkData []byte) (span, data []byte, err error) {
+	if KeyScheme(key) != KeccakCTR {
+		return decryptAEAD(key, chunkData)
+	}
+
+	span, err = NewSpanEncryption(key).Decrypt(chunkData[:swarm.SpanSize])
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err = NewDataEncryption(key).Decrypt(chunkData[swarm.SpanSize:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return span, data, nil
+}
+
+func decryptAEAD(key Key, chunkData []byte) ([]byte, []byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed := make([]byte, 0, len(chunkData)+TagLength)
+	sealed = append(sealed, chunkData...)
+	sealed = append(sealed, key[1+KeyLength:]...)
+	plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), sealed, key[:1])
+	if err != nil || len(plaintext) < swarm.SpanSize {
+		return nil, nil, ErrAuthentication
+	}
+	return plaintext[:swarm.SpanSize], plaintext[swarm.SpanSize:], nil
+}
+
+// newAEAD returns the cipher of the key of an authenticated scheme. The nonce
+// is zero, so a cipher key must encrypt a single chunk only: the cipher key
+// of every encrypted chunk is random and a key of a reference must never be
+// reused for another chunk.
+func newAEAD(key Key) (cipher.AEAD, error) {
+	cipherKey := key[1 : 1+KeyLength]
+	switch Scheme(key[0]) {
+	case AESGCM:
+		block, err := aes.NewCipher(cipherKey)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case ChaCha20Poly1305:
+		return chacha20poly1305.New(cipherKey)
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownScheme, key[0])
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+)
+
+func TestSchemeEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range []encryption.Scheme{encryption.KeccakCTR, encryption.AESGCM, encryption.ChaCha20Poly1305} {
+		t.Run(scheme.String(), func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := encryption.ParseScheme(scheme.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != scheme {
+				t.Fatalf("parsed scheme %v, want %v", parsed, scheme)
+			}
+
+			e, err := encryption.NewSchemeChunkEncrypter(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, length := range []int{0, 1, 100, swarm.ChunkSize} {
+				chunkData := make([]byte, swarm.SpanSize+length)
+				binary.LittleEndian.PutUint64(chunkData, uint64(length))
+				copy(chunkData[swarm.SpanSize:], testutil.RandBytes(t, length))
+
+				key, encryptedSpan, encryptedData, err := e.EncryptChunk(chunkData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(key) != scheme.ReferenceSize()-swarm.HashSize || len(encryptedSpan) != swarm.SpanSize || len(encryptedData) != swarm.ChunkSize {
+					t.Fatalf("got key length %d, span length %d, data length %d", len(key), len(encryptedSpan), len(encryptedData))
+				}
+				if got := encryption.KeyScheme(key); got != scheme {
+					t.Fatalf("got key scheme %v, want %v", got, scheme)
+				}
+
+				span, data, err := encryption.DecryptChunk(key, append(encryptedSpan, encryptedData...))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(span, chunkData[:swarm.SpanSize]) || !bytes.Equal(data[:length], chunkData[swarm.SpanSize:]) {
+					t.Fatalf("decrypted chunk of length %d does not match", length)
+				}
+
+				// every chunk is encrypted with its own key
+				key2, _, encryptedData2, err := e.EncryptChunk(chunkData)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if bytes.Equal(key, key2) || bytes.Equal(encryptedData, encryptedData2) {
+					t.Fatalf("chunk of length %d encrypted twice with the same key", length)
+				}
+			}
+		})
+	}
+}
+
+// TestSchemeAuthentication tests that the chunks of the authenticated schemes
+// are not decrypted if the chunk data or the authentication tag is modified.
+func TestSchemeAuthentication(t *testing.T) {
+	t.Parallel()
+
+	chunkData := make([]byte, swarm.SpanSize+100)
+	binary.LittleEndian.PutUint64(chunkData, 100)
+
+	for _, scheme := range []encryption.Scheme{encryption.AESGCM, encryption.ChaCha20Poly1305} {
+		t.Run(scheme.String(), func(t *testing.T) {
+			t.Parallel()
+
+			e, err := encryption.NewSchemeChunkEncrypter(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, encryptedSpan, encryptedData, err := e.EncryptChunk(chunkData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encrypted := append(encryptedSpan, encryptedData...)
+
+			tampered := bytes.Clone(encrypted)
+			tampered[len(tampered)-1]++
+			if _, _, err := encryption.DecryptChunk(key, tampered); !errors.Is(err, encryption.ErrAuthentication) {
+				t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+			}
+
+			wrongTag := bytes.Clone(key)
+			wrongTag[len(wrongTag)-1]++
+			if _, _, err := encryption.DecryptChunk(wrongTag, encrypted); !errors.Is(err, encryption.ErrAuthentication) {
+				t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+			}
+
+			// the scheme identifier is authenticated too
+			otherScheme := bytes.Clone(key)
+			otherScheme[0] = byte(encryption.AESGCM + encryption.ChaCha20Poly1305 - scheme)
+			if _, _, err := encryption.DecryptChunk(otherScheme, encrypted); !errors.Is(err, encryption.ErrAuthentication) {
+				t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+			}
+		})
+	}
+}
+
+// TestSchemeLegacyKey tests that the legacy keys are never taken for the keys
+// of the authenticated schemes, whatever their first byte is.
+func TestSchemeLegacyKey(t *testing.T) {
+	t.Parallel()
+
+	chunkData := make([]byte, swarm.SpanSize+100)
+	binary.LittleEndian.PutUint64(chunkData, 100)
+	copy(chunkData[swarm.SpanSize:], testutil.RandBytes(t, 100))
+
+	e := encryption.NewChunkEncrypter()
+	for _, first := range []byte{byte(encryption.AESGCM), byte(encryption.ChaCha20Poly1305)} {
+		key, _, _, err := e.EncryptChunk(chunkData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the legacy chunk is encrypted again with a key of the given first byte
+		key[0] = first
+		encryptedSpan, err := encryption.NewSpanEncryption(key).Encrypt(chunkData[:swarm.SpanSize])
+		if err != nil {
+			t.Fatal(err)
+		}
+		encryptedData, err := encryption.NewDataEncryption(key).Encrypt(chunkData[swarm.SpanSize:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := encryption.KeyScheme(key); got != encryption.KeccakCTR {
+			t.Fatalf("got key scheme %v, want %v", got, encryption.KeccakCTR)
+		}
+		span, data, err := encryption.DecryptChunk(key, append(encryptedSpan, encryptedData...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(span, chunkData[:swarm.SpanSize]) || !bytes.Equal(data[:100], chunkData[swarm.SpanSize:]) {
+			t.Fatalf("legacy chunk of key starting with %d does not match", first)
+		}
+	}
+}
+
+func TestParseScheme(t *testing.T) {
+	t.Parallel()
+
+	if _, err := encryption.ParseScheme("aes-ctr"); !errors.Is(err, encryption.ErrUnknownScheme) {
+		t.Fatalf("got error %v, want %v", err, encryption.ErrUnknownScheme)
+	}
+	if _, err := encryption.NewSchemeChunkEncrypter(encryption.Scheme(3)); !errors.Is(err, encryption.ErrUnknownScheme) {
+		t.Fatalf("got error %v, want %v", err, encryption.ErrUnknownScheme)
+	}
+}
@@ -29,7 +29,7 @@ func (s *decryptingStore) Get(ctx context.Context, addr swarm.Address) (ch swarm
 		// normal, unencrypted content
 		return s.Getter.Get(ctx, addr)
 
-	case encryption.ReferenceSize:
+	case encryption.ReferenceSize, encryption.SchemeReferenceSize:
 		// encrypted reference
 		ref := addr.Bytes()
 		address := swarm.NewAddress(ref[:swarm.HashSize])
@@ -49,8 +49,10 @@ func (s *decryptingStore) Get(ctx context.Context, addr swarm.Address) (ch swarm
 	}
 }
 
+// DecryptChunkData decrypts the chunk data with the key of its reference,
+// in the scheme identified by the key.
 func DecryptChunkData(chunkData []byte, encryptionKey encryption.Key) ([]byte, error) {
-	decryptedSpan, decryptedData, err := decrypt(chunkData, encryptionKey)
+	decryptedSpan, decryptedData, err := encryption.DecryptChunk(encryptionKey, chunkData)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +60,13 @@ func DecryptChunkData(chunkData []byte, encryptionKey encryption.Key) ([]byte, e
 	// removing extra bytes which were just added for padding
 	level, span := redundancy.DecodeSpan(decryptedSpan)
 	length := binary.LittleEndian.Uint64(span)
-	if length > swarm.ChunkSize {
+	switch {
+	case length <= swarm.ChunkSize:
+	case encryption.KeyScheme(encryptionKey) != encryption.KeccakCTR:
+		// the trees of the authenticated schemes have no redundancy
+		dataRefSize := swarm.HashSize + len(encryptionKey)
+		length = uint64(dataRefSize * file.DataReferenceCount(length, dataRefSize))
+	default:
 		dataRefSize := uint64(swarm.HashSize + encryption.KeyLength)
 		dataShards, parities := file.ReferenceCount(length, level, true)
 		length = dataRefSize*uint64(dataShards) + uint64(parities*swarm.HashSize)
@@ -70,15 +78,3 @@ func DecryptChunkData(chunkData []byte, encryptionKey encryption.Key) ([]byte, e
 
 	return c, nil
 }
-
-func decrypt(chunkData []byte, key encryption.Key) ([]byte, []byte, error) {
-	decryptedSpan, err := encryption.NewSpanEncryption(key).Decrypt(chunkData[:swarm.SpanSize])
-	if err != nil {
-		return nil, nil, err
-	}
-	decryptedData, err := encryption.NewDataEncryption(key).Decrypt(chunkData[swarm.SpanSize:])
-	if err != nil {
-		return nil, nil, err
-	}
-	return decryptedSpan, decryptedData, nil
-}
@@ -0,0 +1,232 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ErrClosed is returned on the writes to a closed stream writer.
+var ErrClosed = errors.New("encryption: write to closed stream")
+
+// The streams start with a random nonce of StreamNonceLength bytes, from
+// which and the key of the stream the file key of the stream is derived, so
+// the same key can encrypt any number of streams. The data follows in
+// records of swarm.ChunkSize bytes, each encrypted with ChaCha20-Poly1305
+// under the chunk key derived from the file key and the index of the record
+// with DeriveChunkKey, and followed by its authentication tag. The nonce of a
+// record flags the last record, so records which are modified, reordered,
+// dropped or appended fail the authentication.
+const (
+	// StreamNonceLength is the length of the nonce at the start of a stream.
+	StreamNonceLength = 32
+	// StreamOverhead is the length added to every record of a stream.
+	StreamOverhead = chacha20poly1305.Overhead
+
+	streamKeyInfo    = "swarm-stream-key"
+	streamRecordSize = swarm.ChunkSize + StreamOverhead
+)
+
+// StreamWriter encrypts the data written to it and writes it to the
+// underlying writer record by record.
+type StreamWriter struct {
+	w     io.Writer
+	key   Key    // the file key of the stream
+	nonce []byte // the nonce of the stream, written before the first record
+	index uint64
+	buf   []byte
+	err   error
+}
+
+// NewStreamWriter returns a StreamWriter writing the data encrypted with the
+// key to w. The writer must be closed to write the last record.
+func NewStreamWriter(w io.Writer, key Key) *StreamWriter {
+	nonce := GenerateRandomKey(StreamNonceLength)
+	return &StreamWriter{
+		w:     w,
+		key:   streamKey(key, nonce),
+		nonce: nonce,
+		buf:   make([]byte, 0, swarm.ChunkSize),
+	}
+}
+
+// Write implements io.Writer interface.
+func (s *StreamWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	n := 0
+	for len(p) > 0 {
+		// a full record is written once more data follows, as the last
+		// record is only known on close
+		if len(s.buf) == cap(s.buf) {
+			if err := s.flush(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(s.buf[len(s.buf):cap(s.buf)], p)
+		s.buf = s.buf[:len(s.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// Close writes the last record, it does not close the underlying writer.
+func (s *StreamWriter) Close() error {
+	if s.err != nil {
+		if errors.Is(s.err, ErrClosed) {
+			return nil
+		}
+		return s.err
+	}
+	if err := s.flush(true); err != nil {
+		return err
+	}
+	s.err = ErrClosed
+	return nil
+}
+
+func (s *StreamWriter) flush(last bool) error {
+	out := make([]byte, 0, len(s.nonce)+len(s.buf)+StreamOverhead)
+	out = append(out, s.nonce...)
+	out = recordAEAD(s.key, s.index).Seal(out, recordNonce(last), s.buf, nil)
+	if _, err := s.w.Write(out); err != nil {
+		s.err = err
+		return err
+	}
+	s.nonce = nil
+	s.index++
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// StreamReader decrypts the data read from the underlying reader record by
+// record. The data of a record is returned only once it is authenticated.
+type StreamReader struct {
+	r     *bufio.Reader
+	key   Key
+	fkey  Key // the file key of the stream, derived once the nonce is read
+	index uint64
+	buf   []byte // decrypted data of the current record not read yet
+	err   error
+}
+
+// NewStreamReader returns a StreamReader reading the data of r decrypted with
+// the key.
+func NewStreamReader(r io.Reader, key Key) *StreamReader {
+	return &StreamReader{
+		r:   bufio.NewReaderSize(r, streamRecordSize),
+		key: key,
+	}
+}
+
+// Read implements io.Reader interface. It returns ErrAuthentication if the
+// stream was modified or truncated.
+func (s *StreamReader) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if err := s.next(); err != nil {
+			s.err = err
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// next reads and decrypts the next record of the underlying reader. It
+// returns io.EOF after the last record.
+func (s *StreamReader) next() error {
+	if s.fkey == nil {
+		nonce := make([]byte, StreamNonceLength)
+		if _, err := io.ReadFull(s.r, nonce); err != nil {
+			return truncated(err)
+		}
+		s.fkey = streamKey(s.key, nonce)
+	}
+
+	record := make([]byte, streamRecordSize)
+	n, err := io.ReadFull(s.r, record)
+	last := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := s.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	out, err := recordAEAD(s.fkey, s.index).Open(record[:0], recordNonce(last), record[:n], nil)
+	if err != nil {
+		return ErrAuthentication
+	}
+	s.buf = out
+	s.index++
+	if last {
+		return io.EOF
+	}
+	return nil
+}
+
+// truncated returns ErrAuthentication for the errors of a stream which ends
+// before its nonce.
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrAuthentication
+	}
+	return err
+}
+
+// streamKey returns the file key of the stream of the key with the nonce.
+func streamKey(key Key, nonce []byte) Key {
+	return deriveKey(key, append([]byte(streamKeyInfo), nonce...))
+}
+
+// recordAEAD returns the cipher of the record of the given index of the
+// stream of the file key.
+func recordAEAD(fileKey Key, index uint64) cipher.AEAD {
+	// the chunk keys are always of the length of the cipher keys
+	aead, _ := chacha20poly1305.New(DeriveChunkKey(fileKey, index))
+	return aead
+}
+
+// recordNonce returns the nonce of a record of a stream, which flags the
+// last record as every record has its own key.
+func recordNonce(last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// StreamDataSize returns the length of the data of an encrypted stream of the
+// given length. It returns ErrAuthentication if no stream has the length.
+func StreamDataSize(size int64) (int64, error) {
+	size -= StreamNonceLength
+	if size < StreamOverhead {
+		return 0, ErrAuthentication
+	}
+	records := (size + streamRecordSize - 1) / streamRecordSize
+	if size-(records-1)*streamRecordSize < StreamOverhead {
+		return 0, ErrAuthentication
+	}
+	return size - records*StreamOverhead, nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// encryptStream encrypts the data with the key in writes of at most size bytes.
+func encryptStream(t *testing.T, key, data []byte, size int) []byte {
+	t.Helper()
+
+	encrypted := new(bytes.Buffer)
+	w := encryption.NewStreamWriter(encrypted, key)
+	for p := data; len(p) > 0; {
+		n := min(len(p), size)
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return encrypted.Bytes()
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+
+	for _, length := range []int{0, 1, swarm.ChunkSize, swarm.ChunkSize + 1, 3*swarm.ChunkSize + 5} {
+		t.Run(fmt.Sprintf("%d bytes", length), func(t *testing.T) {
+			t.Parallel()
+
+			data := testutil.RandBytes(t, length)
+
+			for _, size := range []int{1000, length + 1} {
+				encrypted := encryptStream(t, key, data, size)
+
+				records := (length + swarm.ChunkSize - 1) / swarm.ChunkSize
+				if records == 0 {
+					records = 1
+				}
+				if want := encryption.StreamNonceLength + length + records*encryption.StreamOverhead; len(encrypted) != want {
+					t.Fatalf("got encrypted length %d, want %d", len(encrypted), want)
+				}
+				// short data may appear in the encrypted stream by chance
+				if length > 32 && bytes.Contains(encrypted, data) {
+					t.Fatal("stream is not encrypted")
+				}
+
+				r := encryption.NewStreamReader(iotest.HalfReader(bytes.NewReader(encrypted)), key)
+				if err := iotest.TestReader(r, data); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestStreamNonce tests that the streams encrypted with the same key differ.
+func TestStreamNonce(t *testing.T) {
+	t.Parallel()
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+	data := testutil.RandBytes(t, swarm.ChunkSize)
+
+	first := encryptStream(t, key, data, len(data))
+	second := encryptStream(t, key, data, len(data))
+	if bytes.Equal(first[encryption.StreamNonceLength:], second[encryption.StreamNonceLength:]) {
+		t.Fatal("streams encrypted with the same key are equal")
+	}
+}
+
+// TestStreamChunkKeys tests that the records of a stream are encrypted with
+// the chunk keys derived from the file key of the stream.
+func TestStreamChunkKeys(t *testing.T) {
+	t.Parallel()
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+	data := testutil.RandBytes(t, 2*swarm.ChunkSize+10)
+	encrypted := encryptStream(t, key, data, len(data))
+
+	fileKey := encryption.StreamKey(key, encrypted[:encryption.StreamNonceLength])
+	records := encrypted[encryption.StreamNonceLength:]
+	for i := 0; len(records) > 0; i++ {
+		n := min(len(records), swarm.ChunkSize+encryption.StreamOverhead)
+		aead, err := chacha20poly1305.New(encryption.DeriveChunkKey(fileKey, uint64(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce := make([]byte, chacha20poly1305.NonceSize)
+		if n == len(records) {
+			nonce[len(nonce)-1] = 1
+		}
+		got, err := aead.Open(nil, nonce, records[:n], nil)
+		if err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+		if want := data[i*swarm.ChunkSize : i*swarm.ChunkSize+len(got)]; !bytes.Equal(got, want) {
+			t.Fatalf("record %d: got other data", i)
+		}
+		records = records[n:]
+	}
+}
+
+func TestStreamAuthentication(t *testing.T) {
+	t.Parallel()
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+	data := testutil.RandBytes(t, 2*swarm.ChunkSize+5)
+	encrypted := encryptStream(t, key, data, len(data))
+	record := swarm.ChunkSize + encryption.StreamOverhead
+	first := encryption.StreamNonceLength
+
+	for _, tc := range []struct {
+		name   string
+		stream func() []byte
+	}{
+		{
+			name: "modified record",
+			stream: func() []byte {
+				s := bytes.Clone(encrypted)
+				s[first+record+10] ^= 1
+				return s
+			},
+		},
+		{
+			name: "modified nonce",
+			stream: func() []byte {
+				s := bytes.Clone(encrypted)
+				s[0] ^= 1
+				return s
+			},
+		},
+		{
+			name: "truncated at a record",
+			stream: func() []byte {
+				return bytes.Clone(encrypted[:first+2*record])
+			},
+		},
+		{
+			name: "truncated in a record",
+			stream: func() []byte {
+				return bytes.Clone(encrypted[:len(encrypted)-1])
+			},
+		},
+		{
+			name: "truncated nonce",
+			stream: func() []byte {
+				return bytes.Clone(encrypted[:first-1])
+			},
+		},
+		{
+			name: "reordered records",
+			stream: func() []byte {
+				s := bytes.Clone(encrypted)
+				copy(s[first:], encrypted[first+record:first+2*record])
+				copy(s[first+record:], encrypted[first:first+record])
+				return s
+			},
+		},
+		{
+			name: "appended data",
+			stream: func() []byte {
+				return append(bytes.Clone(encrypted), 0)
+			},
+		},
+		{
+			name: "wrong key",
+			stream: func() []byte {
+				return encryptStream(t, testutil.RandBytes(t, encryption.KeyLength), data, len(data))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := io.ReadAll(encryption.NewStreamReader(bytes.NewReader(tc.stream()), key))
+			if !errors.Is(err, encryption.ErrAuthentication) {
+				t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+			}
+		})
+	}
+}
+
+func TestStreamWriterClosed(t *testing.T) {
+	t.Parallel()
+
+	w := encryption.NewStreamWriter(io.Discard, testutil.RandBytes(t, encryption.KeyLength))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte{1}); !errors.Is(err, encryption.ErrClosed) {
+		t.Fatalf("got error %v, want %v", err, encryption.ErrClosed)
+	}
+}
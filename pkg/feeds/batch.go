@@ -0,0 +1,100 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of the updates of a batch which are
+// signed and put concurrently.
+const DefaultBatchConcurrency = 16
+
+var errBatchLength = errors.New("number of indexes and payloads differ")
+
+// BatchUpdate is an update of a series of the updates of a feed.
+type BatchUpdate struct {
+	At      int64
+	Payload []byte
+}
+
+// BatchUpdater is the interface of the updaters which write a series of the
+// updates at once, for the applications which publish the updates at a high
+// frequency.
+type BatchUpdater interface {
+	Updater
+	// UpdateBatch pushes the updates in their order. If it fails, the
+	// updater continues after the leading updates which were put, and the
+	// updates after the failed one which were put nevertheless are replaced
+	// by the next updates.
+	UpdateBatch(ctx context.Context, updates []BatchUpdate) error
+}
+
+// PutBatch signs and pushes the updates at the indexes through the chunk
+// store putter concurrently, so that the updates share the putter session
+// and its stamper. It returns the number of the leading updates which were
+// put, all of them if the error is nil.
+func (u *Putter) PutBatch(ctx context.Context, indexes []Index, payloads [][]byte) (int, error) {
+	if len(indexes) != len(payloads) {
+		return 0, errBatchLength
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, DefaultBatchConcurrency)
+		errs = make([]error, len(indexes))
+	)
+
+LOOP:
+	for i := range indexes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(indexes); j++ {
+				errs[j] = ctx.Err()
+			}
+			break LOOP
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ch, err := u.sign(indexes[i], payloads[i])
+			if err == nil {
+				err = u.putter.Put(ctx, ch)
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("update %s: %w", indexes[i], err)
+				// the updates after the failed one are of no use
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	n := len(indexes)
+	var err error
+	for i, e := range errs {
+		if e == nil {
+			continue
+		}
+		n = min(n, i)
+		// the cause of the cancellation of the other updates is reported
+		if err == nil || errors.Is(err, context.Canceled) {
+			err = e
+		}
+	}
+	return n, err
+}
@@ -7,6 +7,7 @@ package epochs
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/feeds"
 	storage "github.com/calmw/bee-tron/pkg/storage"
@@ -93,12 +94,27 @@ type result struct {
 	path  *path
 	chunk swarm.Chunk
 	*epoch
+	at   int64
+	next *epoch // the epoch the lookups on the path continue with, if any
+}
+
+// AsyncOptions bound the speculative lookups of the async finder.
+type AsyncOptions struct {
+	// Concurrency is the number of the epochs looked up concurrently, zero
+	// for no limit.
+	Concurrency int
+	// Depth is the number of the levels of a path which are looked up ahead
+	// of the deepest epoch found, zero for all of them.
+	Depth int
+	// Timeout is the time budget of a lookup, zero for no limit.
+	Timeout time.Duration
 }
 
 // asyncFinder encapsulates a chunk store getter and a feed and provides
-// non-concurrent lookup methods
+// concurrent lookup methods
 type asyncFinder struct {
 	getter *feeds.Getter
+	opts   AsyncOptions
 }
 
 type path struct {
@@ -114,7 +130,13 @@ func newPath(at int64) *path {
 
 // NewAsyncFinder constructs an AsyncFinder
 func NewAsyncFinder(getter storage.Getter, feed *feeds.Feed) feeds.Lookup {
-	return &asyncFinder{feeds.NewGetter(getter, feed)}
+	return NewAsyncFinderWithOptions(getter, feed, AsyncOptions{})
+}
+
+// NewAsyncFinderWithOptions constructs an AsyncFinder which bounds its lookups
+// by the options.
+func NewAsyncFinderWithOptions(getter storage.Getter, feed *feeds.Feed, opts AsyncOptions) feeds.Lookup {
+	return &asyncFinder{feeds.NewGetter(getter, feed), opts}
 }
 
 func (f *asyncFinder) get(ctx context.Context, at int64, e *epoch) (swarm.Chunk, error) {
@@ -133,29 +155,47 @@ func (f *asyncFinder) get(ctx context.Context, at int64, e *epoch) (swarm.Chunk,
 	return u, nil
 }
 
-// at attempts to retrieve all epoch chunks on the path for `at` concurrently
-func (f *asyncFinder) at(ctx context.Context, at int64, p *path, e *epoch, c chan<- *result) {
-	for ; ; e = e.childAt(uint64(at)) {
+// at attempts to retrieve the epoch chunks on the path for `at` concurrently,
+// up to the lookahead depth, and the semaphore, if any, bounds the concurrent
+// retrievals
+func (f *asyncFinder) at(ctx context.Context, at int64, p *path, e *epoch, c chan<- *result, sem chan struct{}) {
+	for depth := 1; ; depth, e = depth+1, e.childAt(uint64(at)) {
 		select {
 		case <-p.cancel:
 			return
 		default:
 		}
+		var next *epoch
+		if e.level > 0 && depth == f.opts.Depth {
+			next = e.childAt(uint64(at))
+		}
 		go func(e *epoch) {
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-p.cancel:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
 			uch, err := f.get(ctx, at, e)
 			if err != nil {
 				return
 			}
 			select {
-			case c <- &result{p, uch, e}:
+			case c <- &result{p, uch, e, at, next}:
 			case <-p.cancel:
+			case <-ctx.Done():
 			}
 		}(e)
-		if e.level == 0 {
+		if e.level == 0 || next != nil {
 			return
 		}
 	}
 }
+
 func (f *asyncFinder) At(ctx context.Context, at int64, after uint64) (swarm.Chunk, feeds.Index, feeds.Index, error) {
 	// TODO: current and next index return values need to be implemented
 	ch, err := f.asyncAt(ctx, at, after)
@@ -165,10 +205,30 @@ func (f *asyncFinder) At(ctx context.Context, at int64, after uint64) (swarm.Chu
 // At looks up the version valid at time `at`
 // after is a unix time hint of the latest known update
 func (f *asyncFinder) asyncAt(ctx context.Context, at int64, _ uint64) (swarm.Chunk, error) {
+	var cancel context.CancelFunc
+	if f.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, f.opts.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	// the lookups still in flight are abandoned once the result is known
+	defer cancel()
+
+	var sem chan struct{}
+	if f.opts.Concurrency > 0 {
+		sem = make(chan struct{}, f.opts.Concurrency)
+	}
+
 	c := make(chan *result)
-	go f.at(ctx, at, newPath(at), &epoch{0, maxLevel}, c)
+	go f.at(ctx, at, newPath(at), &epoch{0, maxLevel}, c, sem)
 LOOP:
-	for r := range c {
+	for {
+		var r *result
+		select {
+		case r = <-c:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 		p := r.path
 		// ignore result from paths already  cancelled
 		select {
@@ -185,6 +245,10 @@ LOOP:
 				continue LOOP
 			}
 			p.top = r
+			// continue the lookups ahead of the deepest epoch found
+			if r.next != nil {
+				go f.at(ctx, r.at, p, r.next, c, sem)
+			}
 		} else { // update chunk for epoch not found
 			// if top level than return with no update found
 			if r.level == 32 {
@@ -206,9 +270,8 @@ LOOP:
 			}
 			// recursive call on new path through left sister
 			np := newPath(at)
-			np.top = &result{np, p.top.chunk, p.top.epoch}
-			go f.at(ctx, int64(p.bottom.start-1), np, p.bottom.left(), c)
+			np.top = &result{path: np, chunk: p.top.chunk, epoch: p.top.epoch}
+			go f.at(ctx, int64(p.bottom.start-1), np, p.bottom.left(), c, sem)
 		}
 	}
-	return nil, nil
 }
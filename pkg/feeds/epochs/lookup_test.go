@@ -5,13 +5,19 @@
 package epochs_test
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/crypto"
 	"github.com/calmw/bee-tron/pkg/feeds"
 	"github.com/calmw/bee-tron/pkg/feeds/epochs"
 	feedstesting "github.com/calmw/bee-tron/pkg/feeds/testing"
 	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
 func TestFinder_FLAKY(t *testing.T) {
@@ -43,4 +49,57 @@ func TestFinder_FLAKY(t *testing.T) {
 		t.Parallel()
 		testf(t, epochs.NewAsyncFinder, epochs.NewUpdater)
 	})
+	t.Run("async bounded", func(t *testing.T) {
+		t.Parallel()
+		finderf := func(getter storage.Getter, feed *feeds.Feed) feeds.Lookup {
+			return epochs.NewAsyncFinderWithOptions(getter, feed, epochs.AsyncOptions{Concurrency: 2, Depth: 3})
+		}
+		testf(t, finderf, epochs.NewUpdater)
+	})
+}
+
+// blockingGetter records the maximum number of the concurrent gets, which
+// block until they are released.
+type blockingGetter struct {
+	storage.Getter
+	release  chan struct{}
+	inflight atomic.Int64
+	max      atomic.Int64
+}
+
+func (g *blockingGetter) Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	n := g.inflight.Add(1)
+	defer g.inflight.Add(-1)
+	for m := g.max.Load(); n > m && !g.max.CompareAndSwap(m, n); m = g.max.Load() {
+	}
+	select {
+	case <-g.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return g.Getter.Get(ctx, addr)
+}
+
+func TestAsyncFinderOptions(t *testing.T) {
+	t.Parallel()
+
+	pk, _ := crypto.GenerateSecp256k1Key()
+	owner, err := crypto.NewDefaultSigner(pk).EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed := feeds.New(make([]byte, 32), owner)
+
+	g := &blockingGetter{Getter: inmemchunkstore.New(), release: make(chan struct{})}
+	finder := epochs.NewAsyncFinderWithOptions(g, feed, epochs.AsyncOptions{
+		Concurrency: 4,
+		Timeout:     100 * time.Millisecond,
+	})
+
+	if _, _, _, err := finder.At(context.Background(), time.Now().Unix(), 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := g.max.Load(); got != 4 {
+		t.Fatalf("got %d concurrent lookups, want 4", got)
+	}
 }
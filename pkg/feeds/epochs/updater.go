@@ -12,7 +12,10 @@ import (
 	storage "github.com/calmw/bee-tron/pkg/storage"
 )
 
-var _ feeds.Updater = (*updater)(nil)
+var (
+	_ feeds.Updater      = (*updater)(nil)
+	_ feeds.BatchUpdater = (*updater)(nil)
+)
 
 // Updater encapsulates a feeds putter to generate successive updates for epoch based feeds
 // it persists the last update
@@ -43,6 +46,27 @@ func (u *updater) Update(ctx context.Context, at int64, payload []byte) error {
 	return nil
 }
 
+// UpdateBatch pushes the updates to the feed at the epochs of their times
+func (u *updater) UpdateBatch(ctx context.Context, updates []feeds.BatchUpdate) error {
+	var (
+		indexes  = make([]feeds.Index, len(updates))
+		payloads = make([][]byte, len(updates))
+		e, last  = u.epoch, u.last
+	)
+	for i, up := range updates {
+		e = next(e, last, uint64(up.At))
+		last = up.At
+		indexes[i] = e
+		payloads[i] = up.Payload
+	}
+	n, err := u.PutBatch(ctx, indexes, payloads)
+	if n > 0 {
+		u.epoch = indexes[n-1]
+		u.last = updates[n-1].At
+	}
+	return err
+}
+
 func (u *updater) Feed() *feeds.Feed {
 	return u.Putter.Feed
 }
@@ -0,0 +1,112 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/feeds"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// maxCacheEntries is the number of the feeds whose resolved updates are
+// cached at most.
+const maxCacheEntries = 1024
+
+// cacheEntry is an update resolved by a lookup.
+type cacheEntry struct {
+	at, after     uint64 // the arguments of the lookup
+	chunk         swarm.Chunk
+	current, next feeds.Index
+	expires       time.Time
+}
+
+// cache holds the latest updates resolved for the feeds.
+type cache struct {
+	mtx     sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]*cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func cacheKey(t feeds.Type, feed *feeds.Feed) string {
+	return t.String() + string(feed.Owner.Bytes()) + string(feed.Topic)
+}
+
+// get returns the cached update of the feed if it is valid for the lookup,
+// which is the case if the lookup is not of an earlier time than the cached
+// one, so that the historical lookups are always resolved.
+func (c *cache) get(key string, at int64, after uint64) (*cacheEntry, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	if at < 0 || uint64(at) < e.at || after != e.after {
+		return nil, false
+	}
+	return e, true
+}
+
+func (c *cache) put(key string, e *cacheEntry) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCacheEntries {
+		for k, v := range c.entries {
+			if !now.Before(v.expires) {
+				delete(c.entries, k)
+			}
+		}
+		// the cache is full of the fresh entries, so any one goes
+		for k := range c.entries {
+			if len(c.entries) < maxCacheEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	e.expires = now.Add(c.ttl)
+	c.entries[key] = e
+}
+
+// cachedLookup serves the lookups of the feed from the cache while the
+// resolved update is fresh.
+type cachedLookup struct {
+	feeds.Lookup
+	key   string
+	cache *cache
+}
+
+func (l *cachedLookup) At(ctx context.Context, at int64, after uint64) (swarm.Chunk, feeds.Index, feeds.Index, error) {
+	if e, ok := l.cache.get(l.key, at, after); ok {
+		return e.chunk, e.current, e.next, nil
+	}
+
+	ch, cur, next, err := l.Lookup.At(ctx, at, after)
+	// the feeds with no updates are not cached, as they are about to be
+	// updated for the first time
+	if err == nil && ch != nil && at >= 0 {
+		l.cache.put(l.key, &cacheEntry{at: uint64(at), after: after, chunk: ch, current: cur, next: next})
+	}
+	return ch, cur, next, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/feeds"
+	"github.com/calmw/bee-tron/pkg/feeds/sequence"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type countingGetter struct {
+	*inmemchunkstore.ChunkStore
+	calls atomic.Int64
+}
+
+func (g *countingGetter) Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	g.calls.Add(1)
+	return g.ChunkStore.Get(ctx, addr)
+}
+
+func TestCachedLookup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		store  = &countingGetter{ChunkStore: inmemchunkstore.New()}
+		now    = time.Unix(1000, 0)
+		ttl    = time.Minute
+		pk, _  = crypto.GenerateSecp256k1Key()
+		signer = crypto.NewDefaultSigner(pk)
+	)
+
+	updater, err := sequence.NewUpdater(store, signer, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewWithCacheTTL(store, ttl).(*factory)
+	f.cache.now = func() time.Time { return now }
+
+	lookup, err := f.NewLookup(feeds.Sequence, updater.Feed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// resolves the updates of the feed, as long as it was not updated, and
+	// returns the resolved update from the cache
+	latest := func(t *testing.T, want string, cached bool) {
+		t.Helper()
+
+		calls := store.calls.Load()
+		ch, _, _, err := lookup.At(ctx, now.Unix(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want == "" {
+			if ch != nil {
+				t.Fatal("expected no update")
+			}
+		} else {
+			wc, err := feeds.FromChunk(ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(wc.Data()[swarm.SpanSize:]); got != want {
+				t.Fatalf("got update %q, want %q", got, want)
+			}
+		}
+		if got := store.calls.Load() == calls; got != cached {
+			t.Fatalf("got cached %t, want %t", got, cached)
+		}
+	}
+
+	latest(t, "", false)
+	latest(t, "", false)
+
+	if err := updater.Update(ctx, now.Unix(), []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	latest(t, "first", false)
+	latest(t, "first", true)
+
+	if err := updater.Update(ctx, now.Unix(), []byte("second")); err != nil {
+		t.Fatal(err)
+	}
+	latest(t, "first", true)
+
+	now = now.Add(ttl)
+	latest(t, "second", false)
+
+	// the lookups of an earlier time are not served from the cache
+	calls := store.calls.Load()
+	if _, _, _, err := lookup.At(ctx, now.Unix()-1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if store.calls.Load() == calls {
+		t.Fatal("expected lookup of an earlier time to be resolved")
+	}
+}
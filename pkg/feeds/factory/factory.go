@@ -5,27 +5,52 @@
 package factory
 
 import (
+	"time"
+
 	"github.com/calmw/bee-tron/pkg/feeds"
 	"github.com/calmw/bee-tron/pkg/feeds/epochs"
 	"github.com/calmw/bee-tron/pkg/feeds/sequence"
 	storage "github.com/calmw/bee-tron/pkg/storage"
 )
 
+// DefaultCacheTTL is the time the resolved feed updates are served from the
+// cache of the lookups.
+const DefaultCacheTTL = 5 * time.Second
+
 type factory struct {
 	storage.Getter
+	cache *cache
 }
 
+// New constructs the factory of the feed lookups which cache the resolved
+// updates for the DefaultCacheTTL.
 func New(getter storage.Getter) feeds.Factory {
-	return &factory{getter}
+	return NewWithCacheTTL(getter, DefaultCacheTTL)
+}
+
+// NewWithCacheTTL constructs the factory of the feed lookups which cache the
+// resolved updates for the given time, zero disables the caching.
+func NewWithCacheTTL(getter storage.Getter, ttl time.Duration) feeds.Factory {
+	f := &factory{Getter: getter}
+	if ttl > 0 {
+		f.cache = newCache(ttl)
+	}
+	return f
 }
 
 func (f *factory) NewLookup(t feeds.Type, feed *feeds.Feed) (feeds.Lookup, error) {
+	var l feeds.Lookup
 	switch t {
 	case feeds.Sequence:
-		return sequence.NewAsyncFinder(f.Getter, feed), nil
+		l = sequence.NewAsyncFinder(f.Getter, feed)
 	case feeds.Epoch:
-		return epochs.NewAsyncFinder(f.Getter, feed), nil
+		l = epochs.NewAsyncFinder(f.Getter, feed)
+	default:
+		return nil, feeds.ErrFeedTypeNotFound
 	}
 
-	return nil, feeds.ErrFeedTypeNotFound
+	if f.cache == nil {
+		return l, nil
+	}
+	return &cachedLookup{Lookup: l, key: cacheKey(t, feed), cache: f.cache}, nil
 }
@@ -0,0 +1,185 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hybrid provides a fault-tolerant feed lookup which races the
+// synchronous and the asynchronous finders of a feed and falls back to the
+// other indexing scheme if the feed was not found with the expected one, as
+// the clients do not always agree on the indexing scheme of a feed.
+package hybrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/feeds"
+	"github.com/calmw/bee-tron/pkg/feeds/epochs"
+	"github.com/calmw/bee-tron/pkg/feeds/sequence"
+	"github.com/calmw/bee-tron/pkg/soc"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// gracePeriod is the time the slower finders are waited for after an update
+// was found, so that a fresher update found by them is not missed.
+const gracePeriod = time.Second
+
+var _ feeds.Lookup = (*finder)(nil)
+
+// finder races the finders of the feed type and falls back to the finders of
+// the other type.
+type finder struct {
+	owner    []byte
+	primary  []feeds.Lookup
+	fallback []feeds.Lookup
+}
+
+// NewFinder constructs a hybrid finder of the feed, which is expected to be
+// of the given type.
+func NewFinder(getter storage.Getter, feed *feeds.Feed, t feeds.Type) (feeds.Lookup, error) {
+	seq := []feeds.Lookup{sequence.NewFinder(getter, feed), sequence.NewAsyncFinder(getter, feed)}
+	epo := []feeds.Lookup{epochs.NewFinder(getter, feed), epochs.NewAsyncFinder(getter, feed)}
+
+	f := &finder{owner: feed.Owner.Bytes()}
+	switch t {
+	case feeds.Sequence:
+		f.primary, f.fallback = seq, epo
+	case feeds.Epoch:
+		f.primary, f.fallback = epo, seq
+	default:
+		return nil, feeds.ErrFeedTypeNotFound
+	}
+	return f, nil
+}
+
+// At looks up the update valid at time `at` with the finders of the
+// expected feed type and, if no update is found, with the finders of the
+// other type. after is a unix time hint of the latest known update.
+func (f *finder) At(ctx context.Context, at int64, after uint64) (swarm.Chunk, feeds.Index, feeds.Index, error) {
+	r, err := f.race(ctx, f.primary, at, after)
+	if r.chunk != nil {
+		return r.chunk, r.current, r.next, nil
+	}
+
+	fr, ferr := f.race(ctx, f.fallback, at, after)
+	if fr.chunk != nil {
+		return fr.chunk, fr.current, fr.next, nil
+	}
+	if err != nil && ferr != nil {
+		return nil, nil, nil, errors.Join(err, ferr)
+	}
+	// report the indexes of the expected feed type for the feed with no
+	// updates, so that the next update is made in the expected scheme
+	return nil, r.current, r.next, nil
+}
+
+type result struct {
+	chunk         swarm.Chunk
+	current, next feeds.Index
+	rank          int // the position of the finder, the lower ones are preferred
+	err           error
+}
+
+// race runs the finders concurrently and returns the freshest verified
+// update found. The error is returned only if all the finders failed.
+func (f *finder) race(ctx context.Context, finders []feeds.Lookup, at int64, after uint64) (result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := make(chan result, len(finders))
+	for i, l := range finders {
+		go func() {
+			ch, cur, next, err := l.At(ctx, at, after)
+			c <- result{chunk: ch, current: cur, next: next, rank: i, err: err}
+		}()
+	}
+
+	var (
+		best   *result
+		errs   error
+		timer  *time.Timer
+		graceC <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for range finders {
+		select {
+		case r := <-c:
+			if r.err != nil {
+				errs = errors.Join(errs, r.err)
+				continue
+			}
+			if r.chunk != nil && !f.verify(r.chunk) {
+				continue
+			}
+			if best == nil || fresher(r, *best) {
+				best = &r
+			}
+			if best.chunk != nil && timer == nil {
+				timer = time.NewTimer(gracePeriod)
+				graceC = timer.C
+			}
+		case <-graceC:
+			return *best, nil
+		case <-ctx.Done():
+			if best != nil {
+				return *best, nil
+			}
+			return result{}, ctx.Err()
+		}
+	}
+
+	if best == nil {
+		return result{}, errs
+	}
+	return *best, nil
+}
+
+// verify reports whether the chunk is a valid single owner chunk of the
+// feed owner.
+func (f *finder) verify(ch swarm.Chunk) bool {
+	if !soc.Valid(ch) {
+		return false
+	}
+	s, err := soc.FromChunk(ch)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(s.OwnerAddress(), f.owner)
+}
+
+// fresher reports whether the result a holds a fresher update than b. The
+// updates are compared by their sequence indexes if both have one, otherwise
+// the update of the preferred finder is considered fresher.
+func fresher(a, b result) bool {
+	switch {
+	case a.chunk == nil:
+		return false
+	case b.chunk == nil:
+		return true
+	}
+	ai, aok := sequenceIndex(a.current)
+	bi, bok := sequenceIndex(b.current)
+	if aok && bok && ai != bi {
+		return ai > bi
+	}
+	return a.rank < b.rank
+}
+
+func sequenceIndex(i feeds.Index) (uint64, bool) {
+	if i == nil {
+		return 0, false
+	}
+	b, err := i.MarshalBinary()
+	if err != nil || len(b) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(b), true
+}
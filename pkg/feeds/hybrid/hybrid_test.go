@@ -0,0 +1,68 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hybrid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/feeds"
+	"github.com/calmw/bee-tron/pkg/feeds/epochs"
+	"github.com/calmw/bee-tron/pkg/feeds/hybrid"
+	"github.com/calmw/bee-tron/pkg/feeds/sequence"
+	feedstesting "github.com/calmw/bee-tron/pkg/feeds/testing"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+)
+
+func finderOf(t *testing.T, typ feeds.Type) func(storage.Getter, *feeds.Feed) feeds.Lookup {
+	t.Helper()
+
+	return func(getter storage.Getter, feed *feeds.Feed) feeds.Lookup {
+		f, err := hybrid.NewFinder(getter, feed, typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+}
+
+func TestFinder(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		typ      feeds.Type
+		updaterf func(putter storage.Putter, signer crypto.Signer, topic []byte) (feeds.Updater, error)
+	}{
+		{name: "sequence", typ: feeds.Sequence, updaterf: sequence.NewUpdater},
+		{name: "epoch", typ: feeds.Epoch, updaterf: epochs.NewUpdater},
+		// the feeds of the other type are found with the fallback finders
+		{name: "sequence as epoch", typ: feeds.Epoch, updaterf: sequence.NewUpdater},
+		{name: "epoch as sequence", typ: feeds.Sequence, updaterf: epochs.NewUpdater},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			feedstesting.TestFinderBasic(t, finderOf(t, tc.typ), tc.updaterf)
+		})
+	}
+
+	t.Run("sequence intervals", func(t *testing.T) {
+		i := 0
+		nextf := func() (bool, int64) {
+			i++
+			return i == 20, int64(i)
+		}
+		feedstesting.TestFinderFixIntervals(t, nextf, finderOf(t, feeds.Sequence), sequence.NewUpdater)
+	})
+}
+
+func TestNewFinderUnknownType(t *testing.T) {
+	t.Parallel()
+
+	_, err := hybrid.NewFinder(nil, feeds.New(nil, [20]byte{}), feeds.Type(-1))
+	if !errors.Is(err, feeds.ErrFeedTypeNotFound) {
+		t.Fatalf("got error %v, want %v", err, feeds.ErrFeedTypeNotFound)
+	}
+}
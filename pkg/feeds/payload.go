@@ -0,0 +1,186 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// PayloadVersion is the version of the encoding of the structured payloads.
+const PayloadVersion = 1
+
+// payloadMagic marks the feed update payloads which are structured.
+var payloadMagic = []byte("swfp")
+
+var (
+	// ErrNotStructuredPayload is returned if the payload of a feed update is
+	// not structured.
+	ErrNotStructuredPayload = errors.New("feed update payload is not structured")
+	// ErrPayloadVersion is returned if the version of a structured payload
+	// is not supported.
+	ErrPayloadVersion = errors.New("unsupported feed update payload version")
+
+	errInvalidPayload = errors.New("invalid feed update payload")
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Payload)(nil)
+	_ encoding.BinaryUnmarshaler = (*Payload)(nil)
+)
+
+// Payload is a structured feed update payload, which references the content
+// of the update and describes it.
+type Payload struct {
+	Reference   swarm.Address // the reference of the content, possibly encrypted
+	ContentType string
+	Metadata    map[string]string
+}
+
+// MarshalBinary implements the BinaryMarshaler interface. The metadata is
+// encoded in the order of the keys, so that the encoding is deterministic.
+func (p *Payload) MarshalBinary() ([]byte, error) {
+	ref := p.Reference.Bytes()
+	if len(ref) != swarm.HashSize && len(ref) != swarm.HashSize*2 {
+		return nil, fmt.Errorf("%w: reference length %d", errInvalidPayload, len(ref))
+	}
+	if len(p.Metadata) > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: too many metadata entries", errInvalidPayload)
+	}
+
+	buf := new(bytes.Buffer)
+	buf.Write(payloadMagic)
+	buf.WriteByte(PayloadVersion)
+	buf.WriteByte(byte(len(ref)))
+	buf.Write(ref)
+	if err := writeString(buf, p.ContentType); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(p.Metadata))
+	for k := range p.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(keys)))
+	for _, k := range keys {
+		if err := writeString(buf, k); err != nil {
+			return nil, err
+		}
+		if err := writeString(buf, p.Metadata[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	if buf.Len() > swarm.ChunkSize {
+		return nil, fmt.Errorf("%w: size %d exceeds the chunk size", errInvalidPayload, buf.Len())
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface.
+func (p *Payload) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, payloadMagic) {
+		return ErrNotStructuredPayload
+	}
+	r := bytes.NewReader(data[len(payloadMagic):])
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return errInvalidPayload
+	}
+	if version != PayloadVersion {
+		return fmt.Errorf("%w: %d", ErrPayloadVersion, version)
+	}
+
+	refLen, err := r.ReadByte()
+	if err != nil || (refLen != swarm.HashSize && refLen != swarm.HashSize*2) {
+		return errInvalidPayload
+	}
+	ref := make([]byte, refLen)
+	if _, err := io.ReadFull(r, ref); err != nil {
+		return errInvalidPayload
+	}
+
+	contentType, err := readString(r)
+	if err != nil {
+		return err
+	}
+
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return errInvalidPayload
+	}
+	var metadata map[string]string
+	if n > 0 {
+		metadata = make(map[string]string, n)
+	}
+	for range n {
+		k, err := readString(r)
+		if err != nil {
+			return err
+		}
+		v, err := readString(r)
+		if err != nil {
+			return err
+		}
+		metadata[k] = v
+	}
+	if r.Len() != 0 {
+		return errInvalidPayload
+	}
+
+	p.Reference = swarm.NewAddress(ref)
+	p.ContentType = contentType
+	p.Metadata = metadata
+	return nil
+}
+
+// ParsePayload parses the structured payload of the wrapped chunk of a feed
+// update. It returns ErrNotStructuredPayload if the payload is not
+// structured.
+func ParsePayload(wrappedChunk swarm.Chunk) (*Payload, error) {
+	data := wrappedChunk.Data()
+	if len(data) < swarm.SpanSize {
+		return nil, ErrNotStructuredPayload
+	}
+	p := new(Payload)
+	if err := p.UnmarshalBinary(data[swarm.SpanSize:]); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func writeString(buf *bytes.Buffer, s string) error {
+	if len(s) > math.MaxUint16 {
+		return fmt.Errorf("%w: string too long", errInvalidPayload)
+	}
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(s)))
+	buf.WriteString(s)
+	return nil
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return "", errInvalidPayload
+	}
+	if int(n) > r.Len() {
+		return "", errInvalidPayload
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", errInvalidPayload
+	}
+	return string(b), nil
+}
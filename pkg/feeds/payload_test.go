@@ -0,0 +1,99 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestPayload(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		payload Payload
+	}{
+		{
+			name:    "reference",
+			payload: Payload{Reference: swarm.RandAddress(t)},
+		},
+		{
+			name: "encrypted reference with metadata",
+			payload: Payload{
+				Reference:   swarm.NewAddress(append(swarm.RandAddress(t).Bytes(), swarm.RandAddress(t).Bytes()...)),
+				ContentType: "text/html",
+				Metadata:    map[string]string{"title": "home", "lang": "en", "empty": ""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := tc.payload.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := tc.payload.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, again) {
+				t.Fatal("encoding is not deterministic")
+			}
+
+			ch, err := cac.New(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParsePayload(ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tc.payload) {
+				t.Fatalf("got payload %+v, want %+v", *got, tc.payload)
+			}
+		})
+	}
+}
+
+func TestPayloadErrors(t *testing.T) {
+	t.Parallel()
+
+	valid, err := (&Payload{Reference: swarm.RandAddress(t), ContentType: "text/plain"}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := append([]byte{}, valid...)
+	version[len(payloadMagic)] = PayloadVersion + 1
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "not structured", data: []byte("plain content"), want: ErrNotStructuredPayload},
+		{name: "version", data: version, want: ErrPayloadVersion},
+		{name: "truncated", data: valid[:len(valid)-1], want: errInvalidPayload},
+		{name: "trailing", data: append(valid, 0), want: errInvalidPayload},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := new(Payload).UnmarshalBinary(tc.data); !errors.Is(err, tc.want) {
+				t.Fatalf("got error %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := (&Payload{Reference: swarm.NewAddress([]byte{1})}).MarshalBinary(); !errors.Is(err, errInvalidPayload) {
+		t.Fatalf("got error %v, want %v", err, errInvalidPayload)
+	}
+}
@@ -39,20 +39,24 @@ func NewPutter(putter storage.Putter, signer crypto.Signer, topic []byte) (*Putt
 
 // Put pushes an update to the feed through the chunk stores
 func (u *Putter) Put(ctx context.Context, i Index, payload []byte) error {
-	id, err := u.Feed.Update(i).Id()
+	ch, err := u.sign(i, payload)
 	if err != nil {
 		return err
 	}
-	cac, err := toChunk(payload)
+	return u.putter.Put(ctx, ch)
+}
+
+// sign returns the single owner chunk of the update at the index.
+func (u *Putter) sign(i Index, payload []byte) (swarm.Chunk, error) {
+	id, err := u.Feed.Update(i).Id()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	s := soc.New(id, cac)
-	ch, err := s.Sign(u.signer)
+	cac, err := toChunk(payload)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return u.putter.Put(ctx, ch)
+	return soc.New(id, cac).Sign(u.signer)
 }
 
 func toChunk(payload []byte) (swarm.Chunk, error) {
@@ -29,10 +29,11 @@ import (
 const DefaultLevels = 8
 
 var (
-	_ feeds.Index   = (*index)(nil)
-	_ feeds.Lookup  = (*finder)(nil)
-	_ feeds.Lookup  = (*asyncFinder)(nil)
-	_ feeds.Updater = (*updater)(nil)
+	_ feeds.Index        = (*index)(nil)
+	_ feeds.Lookup       = (*finder)(nil)
+	_ feeds.Lookup       = (*asyncFinder)(nil)
+	_ feeds.Updater      = (*updater)(nil)
+	_ feeds.BatchUpdater = (*updater)(nil)
 )
 
 // index just wraps a uint64. implements the feeds.Index interface
@@ -288,6 +289,19 @@ func (u *updater) Update(ctx context.Context, at int64, payload []byte) error {
 	return nil
 }
 
+// UpdateBatch pushes the updates to the feed at the successive indexes
+func (u *updater) UpdateBatch(ctx context.Context, updates []feeds.BatchUpdate) error {
+	indexes := make([]feeds.Index, len(updates))
+	payloads := make([][]byte, len(updates))
+	for i, up := range updates {
+		indexes[i] = &index{u.next + uint64(i)}
+		payloads[i] = up.Payload
+	}
+	n, err := u.PutBatch(ctx, indexes, payloads)
+	u.next += uint64(n)
+	return err
+}
+
 func (u *updater) Feed() *feeds.Feed {
 	return u.Putter.Feed
 }
@@ -0,0 +1,107 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sequence_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/feeds"
+	"github.com/calmw/bee-tron/pkg/feeds/sequence"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+var errPut = errors.New("put failed")
+
+// failingStore fails the puts of the update with the payload once.
+type failingStore struct {
+	*inmemchunkstore.ChunkStore
+	payload atomic.Value
+}
+
+func (s *failingStore) Put(ctx context.Context, ch swarm.Chunk) error {
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		return err
+	}
+	if s.payload.CompareAndSwap(string(wc.Data()[swarm.SpanSize:]), "") {
+		return errPut
+	}
+	return s.ChunkStore.Put(ctx, ch)
+}
+
+func batch(from, to int) []feeds.BatchUpdate {
+	updates := make([]feeds.BatchUpdate, 0, to-from)
+	for i := from; i < to; i++ {
+		updates = append(updates, feeds.BatchUpdate{At: int64(i), Payload: []byte(fmt.Sprintf("update %d", i))})
+	}
+	return updates
+}
+
+func TestUpdateBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		store  = &failingStore{ChunkStore: inmemchunkstore.New()}
+		pk, _  = crypto.GenerateSecp256k1Key()
+		signer = crypto.NewDefaultSigner(pk)
+	)
+	store.payload.Store("")
+
+	u, err := sequence.NewUpdater(store, signer, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater := u.(feeds.BatchUpdater)
+	finder := sequence.NewFinder(store, updater.Feed())
+
+	latest := func(t *testing.T, want int) {
+		t.Helper()
+
+		ch, cur, _, err := finder.At(ctx, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cur.String() != fmt.Sprint(want) {
+			t.Fatalf("got latest index %s, want %d", cur, want)
+		}
+		wc, err := feeds.FromChunk(ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(wc.Data()[swarm.SpanSize:]), fmt.Sprintf("update %d", want); got != want {
+			t.Fatalf("got payload %q, want %q", got, want)
+		}
+	}
+
+	if err := updater.UpdateBatch(ctx, batch(0, 40)); err != nil {
+		t.Fatal(err)
+	}
+	latest(t, 39)
+
+	// a single update follows the batch
+	if err := updater.Update(ctx, 40, []byte("update 40")); err != nil {
+		t.Fatal(err)
+	}
+	latest(t, 40)
+
+	// the update 45 fails, the ones after it may be put or not
+	store.payload.Store("update 45")
+	if err := updater.UpdateBatch(ctx, batch(41, 60)); !errors.Is(err, errPut) {
+		t.Fatalf("got error %v, want %v", err, errPut)
+	}
+
+	// the updater continues after the leading updates which were put
+	if err := updater.UpdateBatch(ctx, batch(45, 60)); err != nil {
+		t.Fatal(err)
+	}
+	latest(t, 59)
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"context"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// DefaultWatchInterval is the default interval of the lookups of the watched
+// feeds.
+const DefaultWatchInterval = 10 * time.Second
+
+// WatchUpdate is a new update of a watched feed.
+type WatchUpdate struct {
+	Chunk swarm.Chunk // the single owner chunk of the update
+	Index Index       // the index of the update
+	Next  Index       // the index of the next update
+}
+
+// Watch looks up the latest update of the feed in every interval and calls
+// the function with the updates which differ from the previously found one,
+// until the context is done or the function returns an error. The failed
+// lookups are retried in the next interval. after is a unix time hint of the
+// latest known update.
+func Watch(ctx context.Context, l Lookup, interval time.Duration, after uint64, f func(WatchUpdate) error) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := swarm.ZeroAddress
+	for {
+		ch, cur, next, err := l.At(ctx, time.Now().Unix(), after)
+		// a feed which was never updated has no chunk
+		if err == nil && ch != nil && !ch.Address().Equal(last) {
+			last = ch.Address()
+			if err := f(WatchUpdate{Chunk: ch, Index: cur, Next: next}); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
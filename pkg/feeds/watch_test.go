@@ -0,0 +1,85 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	soctesting "github.com/calmw/bee-tron/pkg/soc/testing"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type lookupFunc func() (swarm.Chunk, error)
+
+func (f lookupFunc) At(context.Context, int64, uint64) (swarm.Chunk, Index, Index, error) {
+	ch, err := f()
+	return ch, nil, nil, err
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first     = soctesting.GenerateMockSOC(t, []byte("first")).Chunk()
+		second    = soctesting.GenerateMockSOC(t, []byte("second")).Chunk()
+		errLookup = errors.New("lookup failed")
+		// never updated, two updates repeated and a failed lookup
+		results = []struct {
+			ch  swarm.Chunk
+			err error
+		}{{nil, nil}, {first, nil}, {first, nil}, {nil, errLookup}, {first, nil}, {second, nil}, {second, nil}}
+		calls int
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lookup := lookupFunc(func() (swarm.Chunk, error) {
+		if calls == len(results) {
+			cancel()
+			return nil, nil
+		}
+		r := results[calls]
+		calls++
+		return r.ch, r.err
+	})
+
+	var got []swarm.Address
+	err := Watch(ctx, lookup, time.Millisecond, 0, func(u WatchUpdate) error {
+		got = append(got, u.Chunk.Address())
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	want := []swarm.Address{first.Address(), second.Address()}
+	if len(got) != len(want) {
+		t.Fatalf("got %d updates, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("got update %d %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	t.Run("stops on error", func(t *testing.T) {
+		t.Parallel()
+
+		errStop := errors.New("stop")
+		lookup := lookupFunc(func() (swarm.Chunk, error) {
+			return first, nil
+		})
+		err := Watch(context.Background(), lookup, time.Millisecond, 0, func(WatchUpdate) error {
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Fatalf("got error %v, want %v", err, errStop)
+		}
+	})
+}
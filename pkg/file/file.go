@@ -15,6 +15,7 @@ import (
 type Reader interface {
 	io.ReadSeeker
 	io.ReaderAt
+	io.WriterTo
 }
 
 // Joiner provides the inverse functionality of the Splitter.
@@ -0,0 +1,112 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var errDecompressedSize = errors.New("decompressed size mismatch")
+
+// DecompressingReader reads the data decompressed from the zstd compressed
+// data of a joiner.
+type DecompressingReader struct {
+	r    io.ReadSeeker
+	dec  *zstd.Decoder
+	size int64 // the size of the decompressed data
+	off  int64 // the offset of the decompressed data read next
+	pos  int64 // the offset of the decompressed data of the decoder
+}
+
+// NewDecompressingReader returns a reader of the decompressed data of the
+// size of the compressed data of the reader. The decompressed data is read
+// sequentially, seeking backwards restarts the decompression from the start
+// of the data. The reader must be closed to release the decoder.
+func NewDecompressingReader(r io.ReadSeeker, size int64) (*DecompressingReader, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &DecompressingReader{r: r, dec: dec, size: size}, nil
+}
+
+// Read implements the io.Reader interface.
+func (d *DecompressingReader) Read(b []byte) (int, error) {
+	if d.off >= d.size {
+		return 0, io.EOF
+	}
+	if err := d.seek(); err != nil {
+		return 0, err
+	}
+
+	b = b[:min(int64(len(b)), d.size-d.off)]
+	n, err := d.dec.Read(b)
+	d.pos += int64(n)
+	d.off = d.pos
+	if errors.Is(err, io.EOF) && d.off < d.size {
+		return n, errDecompressedSize
+	}
+	return n, err
+}
+
+// seek moves the decoder to the offset of the data read next.
+func (d *DecompressingReader) seek() error {
+	if d.off < d.pos {
+		if _, err := d.r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := d.dec.Reset(d.r); err != nil {
+			return err
+		}
+		d.pos = 0
+	}
+	if d.off > d.pos {
+		n, err := io.CopyN(io.Discard, d.dec, d.off-d.pos)
+		d.pos += n
+		if errors.Is(err, io.EOF) {
+			return errDecompressedSize
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Seek implements the io.Seeker interface. The decoder is moved to the
+// offset by the next read.
+func (d *DecompressingReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += d.off
+	case io.SeekEnd:
+		offset += d.size
+	default:
+		return 0, errWhence
+	}
+	if offset < 0 {
+		return 0, errOffset
+	}
+	d.off = offset
+	return offset, nil
+}
+
+// Size returns the size of the decompressed data.
+func (d *DecompressingReader) Size() int64 {
+	return d.size
+}
+
+// Close releases the decoder.
+func (d *DecompressingReader) Close() error {
+	d.dec.Close()
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"errors"
+	"io"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+)
+
+// DecryptingReader reads the data decrypted from the encrypted stream of a
+// joiner, written by the stream encryption pipeline.
+type DecryptingReader struct {
+	r    io.ReadSeeker
+	key  encryption.Key
+	dec  *encryption.StreamReader
+	size int64 // the size of the decrypted data
+	off  int64 // the offset of the decrypted data read next
+	pos  int64 // the offset of the decrypted data of the decrypter
+}
+
+// NewDecryptingReader returns a reader of the data decrypted with the key
+// from the encrypted stream of the given size of the reader. The decrypted
+// data is read sequentially, seeking backwards restarts the decryption from
+// the start of the stream.
+func NewDecryptingReader(r io.ReadSeeker, key encryption.Key, size int64) (*DecryptingReader, error) {
+	dataSize, err := encryption.StreamDataSize(size)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &DecryptingReader{
+		r:    r,
+		key:  key,
+		dec:  encryption.NewStreamReader(r, key),
+		size: dataSize,
+	}, nil
+}
+
+// Read implements the io.Reader interface.
+func (d *DecryptingReader) Read(b []byte) (int, error) {
+	if d.off >= d.size {
+		return 0, io.EOF
+	}
+	if err := d.seek(); err != nil {
+		return 0, err
+	}
+
+	n, err := d.dec.Read(b)
+	d.pos += int64(n)
+	d.off = d.pos
+	return n, err
+}
+
+// seek moves the decrypter to the offset of the data read next.
+func (d *DecryptingReader) seek() error {
+	if d.off < d.pos {
+		if _, err := d.r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		d.dec = encryption.NewStreamReader(d.r, d.key)
+		d.pos = 0
+	}
+	if d.off > d.pos {
+		n, err := io.CopyN(io.Discard, d.dec, d.off-d.pos)
+		d.pos += n
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Seek implements the io.Seeker interface. The decrypter is moved to the
+// offset by the next read.
+func (d *DecryptingReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += d.off
+	case io.SeekEnd:
+		offset += d.size
+	default:
+		return 0, errWhence
+	}
+	if offset < 0 {
+		return 0, errOffset
+	}
+	d.off = offset
+	return offset, nil
+}
+
+// Size returns the size of the decrypted data.
+func (d *DecryptingReader) Size() int64 {
+	return d.size
+}
@@ -36,6 +36,7 @@ type joiner struct {
 	ctx         context.Context
 	decoders    *decoderCache
 	chunkToSpan func(data []byte) (redundancy.Level, int64) // returns parity and span value from chunkData
+	readAhead   *readAhead                                  // prefetches the data ahead of the sequential reads, nil if disabled
 }
 
 // decoderCache is cache of decoders for intermediate chunks
@@ -124,7 +125,7 @@ func NewJoiner(ctx context.Context, g storage.Getter, putter storage.Putter, add
 	chunkData := rootChunk.Data()
 	rootData := chunkData[swarm.SpanSize:]
 	refLength := len(address.Bytes())
-	encryption := refLength == encryption.ReferenceSize
+	encryption := encryption.IsEncryptedReferenceSize(refLength)
 	rLevel, span := chunkToSpan(chunkData)
 	rootParity := 0
 	maxBranching := swarm.ChunkSize / refLength
@@ -163,6 +164,9 @@ func NewJoiner(ctx context.Context, g storage.Getter, putter storage.Putter, add
 		maxBranching: maxBranching,
 		chunkToSpan:  spanFn,
 	}
+	if n, _ := ctx.Value(readAheadKey{}).(int); n > 0 {
+		j.readAhead = newReadAhead(n)
+	}
 
 	return j, span, nil
 }
@@ -179,13 +183,16 @@ func (j *joiner) Read(b []byte) (n int, err error) {
 	return read, err
 }
 
+// ReadAt implements the io.ReaderAt interface. The data chunks in the range
+// are retrieved concurrently, and it is safe to call it concurrently with
+// the buffers of the disjoint ranges.
 func (j *joiner) ReadAt(buffer []byte, off int64) (read int, err error) {
 	// since offset is int64 and swarm spans are uint64 it means we cannot seek beyond int64 max value
 	if off >= j.span {
 		return 0, io.EOF
 	}
 
-	readLen := int64(cap(buffer))
+	readLen := int64(len(buffer))
 	if readLen > j.span-off {
 		readLen = j.span - off
 	}
@@ -198,7 +205,9 @@ func (j *joiner) ReadAt(buffer []byte, off int64) (read int, err error) {
 		return 0, err
 	}
 
-	return int(atomic.LoadInt64(&bytesRead)), nil
+	read = int(atomic.LoadInt64(&bytesRead))
+	j.readAheadFrom(off, int64(read))
+	return read, nil
 }
 
 var ErrMalformedTrie = errors.New("malformed tree")
@@ -233,7 +242,8 @@ func (j *joiner) readAtOffset(
 	}
 
 	addrs, shardCnt := file.ChunkAddresses(data[:pSize], parity, j.refLength)
-	g := store.New(j.decoders.GetOrCreate(addrs, shardCnt))
+	dg := j.decoders.GetOrCreate(addrs, shardCnt)
+	g := store.New(dg)
 	for cursor := 0; cursor < len(data); cursor += j.refLength {
 		if bytesToRead == 0 {
 			break
@@ -264,14 +274,25 @@ func (j *joiner) readAtOffset(
 
 		func(address swarm.Address, b []byte, cur, subTrieSize, off, bufferOffset, bytesToRead, subtrieSpanLimit int64) {
 			eg.Go(func() error {
-				ch, err := g.Get(j.ctx, addr)
+				// the encrypted data chunks are decrypted in the read range only,
+				// unless they are read ahead, as the prefetched chunks are decrypted
+				if encryption.IsEncryptedReferenceSize(j.refLength) && subtrieSpanLimit <= swarm.ChunkSize && j.readAhead == nil {
+					n, err := j.readEncryptedLeaf(dg, address, b[bufferOffset:bufferOffset+currentReadSize], off-cur, subtrieSpanLimit)
+					if err != nil {
+						return err
+					}
+					atomic.AddInt64(bytesRead, int64(n))
+					return nil
+				}
+
+				ch, err := j.get(g, addr)
 				if err != nil {
 					return err
 				}
 
 				chunkData := ch.Data()[8:]
 				subtrieLevel, subtrieSpan := j.chunkToSpan(ch.Data())
-				_, subtrieParity := file.ReferenceCount(uint64(subtrieSpan), subtrieLevel, j.refLength == encryption.ReferenceSize)
+				_, subtrieParity := file.ReferenceCount(uint64(subtrieSpan), subtrieLevel, encryption.IsEncryptedReferenceSize(j.refLength))
 
 				if subtrieSpan > subtrieSpanLimit {
 					return ErrMalformedTrie
@@ -289,6 +310,45 @@ func (j *joiner) readAtOffset(
 	}
 }
 
+// readEncryptedLeaf copies the data from the offset of the encrypted data
+// chunk of the reference to the buffer. Only the segments of the read range
+// are decrypted instead of the whole chunk, unless the chunk is encrypted in
+// an authenticated scheme, which is decrypted as a whole to be authenticated.
+func (j *joiner) readEncryptedLeaf(g storage.Getter, ref swarm.Address, b []byte, off, spanLimit int64) (int, error) {
+	key := encryption.Key(ref.Bytes()[swarm.HashSize:])
+	ch, err := g.Get(j.ctx, swarm.NewAddress(ref.Bytes()[:swarm.HashSize]))
+	if err != nil {
+		return 0, err
+	}
+
+	if encryption.KeyScheme(key) != encryption.KeccakCTR {
+		chunkData, err := store.DecryptChunkData(ch.Data(), key)
+		if err != nil {
+			return 0, err
+		}
+		_, size := j.chunkToSpan(chunkData)
+		if size > spanLimit || off > size {
+			return 0, ErrMalformedTrie
+		}
+		return copy(b, chunkData[swarm.SpanSize+off:]), nil
+	}
+
+	span, err := encryption.NewSpanEncryption(key).Decrypt(ch.Data()[:swarm.SpanSize])
+	if err != nil {
+		return 0, err
+	}
+	_, size := j.chunkToSpan(span)
+	if size > spanLimit || off > size {
+		return 0, ErrMalformedTrie
+	}
+
+	data, err := encryption.DecryptDataRange(key, ch.Data()[swarm.SpanSize:], int(off), int(min(int64(len(b)), size-off)))
+	if err != nil {
+		return 0, err
+	}
+	return copy(b, data), nil
+}
+
 // getShards returns the effective reference number respective to the intermediate chunk payload length and its parities
 func (j *joiner) getShards(payloadSize, parities int) int {
 	return (payloadSize - parities*swarm.HashSize) / j.refLength
@@ -386,16 +446,16 @@ func (j *joiner) processChunkAddresses(ctx context.Context, fn swarm.AddressIter
 			return err
 		}
 		cursor := i * swarm.HashSize
-		if j.refLength == encryption.ReferenceSize {
-			cursor += swarm.HashSize * min(i, shardCnt)
+		if j.refLength != swarm.HashSize {
+			cursor += (j.refLength - swarm.HashSize) * min(i, shardCnt)
 		}
 		sec := j.subtrieSection(cursor, eSize, parity, subTrieSize)
 		if sec <= swarm.ChunkSize {
 			continue
 		}
 
-		if j.refLength == encryption.ReferenceSize && i < shardCnt {
-			addr = swarm.NewAddress(data[cursor : cursor+swarm.HashSize*2])
+		if j.refLength != swarm.HashSize && i < shardCnt {
+			addr = swarm.NewAddress(data[cursor : cursor+j.refLength])
 		}
 
 		// not a shard
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/file/joiner"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/builder"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
@@ -25,6 +26,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/file/splitter"
 	filetest "github.com/calmw/bee-tron/pkg/file/testing"
 	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/spinlock"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
 	testingc "github.com/calmw/bee-tron/pkg/storage/testing"
@@ -32,6 +34,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/calmw/bee-tron/pkg/util/testutil"
 	"github.com/calmw/bee-tron/pkg/util/testutil/pseudorand"
+	"github.com/klauspost/compress/zstd"
 	"gitlab.com/nolash/go-mockbytes"
 	"golang.org/x/sync/errgroup"
 )
@@ -290,6 +293,95 @@ func TestEncryptDecrypt(t *testing.T) {
 	}
 }
 
+// TestEncryptDecryptSchemes tests that the joiner selects the decryption of
+// the scheme of the encrypted reference.
+func TestEncryptDecryptSchemes(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range []encryption.Scheme{encryption.KeccakCTR, encryption.AESGCM, encryption.ChaCha20Poly1305} {
+		for _, length := range []int{100, 4097, 50*swarm.ChunkSize + 1, 300000} {
+			t.Run(fmt.Sprintf("%s %d bytes", scheme, length), func(t *testing.T) {
+				t.Parallel()
+
+				store := inmemchunkstore.New()
+				testData := testutil.RandBytes(t, length)
+
+				ctx, err := builder.SetEncryptionScheme(context.Background(), scheme)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pipe := builder.NewPipelineBuilder(ctx, store, true, 0)
+				addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(testData))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(addr.Bytes()) != scheme.ReferenceSize() {
+					t.Fatalf("got reference length %d, want %d", len(addr.Bytes()), scheme.ReferenceSize())
+				}
+				if got := encryption.KeyScheme(addr.Bytes()[swarm.HashSize:]); got != scheme {
+					t.Fatalf("got reference scheme %v, want %v", got, scheme)
+				}
+
+				reader, _, err := joiner.New(context.Background(), store, store, addr, redundancy.DefaultLevel)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := io.ReadAll(reader)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(testData, got) {
+					t.Fatal("input data and output data does not match")
+				}
+
+				b := make([]byte, 50)
+				n, err := reader.ReadAt(b, int64(length/2))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(b[:n], testData[length/2:length/2+n]) {
+					t.Fatal("data read at offset does not match")
+				}
+			})
+		}
+	}
+}
+
+// TestEncryptDecryptSchemeTampered tests that the tampered chunks of the
+// authenticated schemes are not decrypted.
+func TestEncryptDecryptSchemeTampered(t *testing.T) {
+	t.Parallel()
+
+	store := inmemchunkstore.New()
+	ctx, err := builder.SetEncryptionScheme(context.Background(), encryption.AESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipe := builder.NewPipelineBuilder(ctx, store, true, 0)
+	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(testutil.RandBytes(t, 100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunkAddr := swarm.NewAddress(addr.Bytes()[:swarm.HashSize])
+	ch, err := store.Get(context.Background(), chunkAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Clone(ch.Data())
+	tampered[swarm.SpanSize]++
+	if err := store.Delete(context.Background(), chunkAddr); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), swarm.NewChunk(chunkAddr, tampered)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := joiner.New(context.Background(), store, store, addr, redundancy.NONE); !errors.Is(err, encryption.ErrAuthentication) {
+		t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+	}
+}
+
 func TestSeek(t *testing.T) {
 	t.Parallel()
 
@@ -690,8 +782,403 @@ func TestJoinerReadAt(t *testing.T) {
 	}
 }
 
+// TestJoinerReadAtEncrypted tests that the ranges of encrypted content are
+// read retrieving only the chunks of the range.
+func TestJoinerReadAtEncrypted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	data := testutil.RandBytes(t, 100*swarm.ChunkSize+123)
+	pipe := builder.NewPipelineBuilder(ctx, store, true, 0)
+	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		off, length int64
+	}{
+		{0, 10},
+		{100, 4096},
+		{swarm.ChunkSize - 5, 10},
+		{64*swarm.ChunkSize + 33, 1000},
+		{int64(len(data)) - 100, 100},
+	} {
+		t.Run(fmt.Sprintf("off=%d length=%d", tc.off, tc.length), func(t *testing.T) {
+			// without redundancy no replicas of the root chunk are retrieved
+			g := &countingGetter{Getter: store, counts: make(map[string]int)}
+			j, _, err := joiner.New(ctx, g, store, addr, redundancy.NONE)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b := make([]byte, tc.length)
+			n, err := j.ReadAt(b, tc.off)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b[:n], data[tc.off:tc.off+int64(n)]) || int64(n) != tc.length {
+				t.Fatalf("read %d bytes at offset %d not equal to expected data", n, tc.off)
+			}
+
+			// the root, an intermediate chunk and the data chunks of the range
+			leaves := (tc.off+tc.length-1)/swarm.ChunkSize - tc.off/swarm.ChunkSize + 1
+			if got, want := g.total(), int(2+leaves); got != want {
+				t.Fatalf("got %d retrieved chunks, want %d", got, want)
+			}
+		})
+	}
+}
+
 // TestJoinerOneLevel tests the retrieval of two data chunks immediately
 // below the root chunk level.
+func TestJoinerReadAhead(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	const chunkCnt, readAhead = 40, 8
+	data := testutil.RandBytes(t, chunkCnt*swarm.ChunkSize)
+	s := splitter.NewSimpleSplitter(store)
+	addr, err := s.Split(ctx, io.NopCloser(bytes.NewReader(data)), int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootChunk, err := store.Get(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves := make([]swarm.Address, chunkCnt)
+	for i := range leaves {
+		cursor := swarm.SpanSize + i*swarm.HashSize
+		leaves[i] = swarm.NewAddress(rootChunk.Data()[cursor : cursor+swarm.HashSize])
+	}
+
+	g := &countingGetter{Getter: store, counts: make(map[string]int)}
+	j, _, err := joiner.New(joiner.SetReadAhead(ctx, readAhead), g, store, addr, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := make([]byte, swarm.ChunkSize)
+	if _, err := j.ReadAt(b, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// the chunks in the window ahead of the read are prefetched
+	err = spinlock.Wait(time.Second, func() bool {
+		for _, leaf := range leaves[1 : readAhead+1] {
+			if g.count(leaf) == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal("chunks were not prefetched")
+	}
+	if got := g.count(leaves[readAhead+1]); got != 0 {
+		t.Fatalf("chunk beyond the window fetched %d times", got)
+	}
+
+	// the prefetched chunk is read without fetching it again
+	n, err := j.ReadAt(b, swarm.ChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b[:n], data[swarm.ChunkSize:swarm.ChunkSize+n]) {
+		t.Fatal("buffer does not match the data")
+	}
+	if got := g.count(leaves[1]); got != 1 {
+		t.Fatalf("prefetched chunk fetched %d times, want 1", got)
+	}
+
+	// the rest of the data is read correctly
+	got := make([]byte, 0, len(data))
+	for off := int64(0); off < int64(len(data)); off += int64(n) {
+		n, err = j.ReadAt(b, off)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, b[:n]...)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("read data does not match the data")
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+// failingWriter fails the writes after the limit of the bytes is written.
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, errWrite
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestJoinerWriteTo(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	const chunkCnt, offset = 40, 1000
+	data := testutil.RandBytes(t, chunkCnt*swarm.ChunkSize+123)
+	s := splitter.NewSimpleSplitter(store)
+	addr, err := s.Split(ctx, io.NopCloser(bytes.NewReader(data)), int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootChunk, err := store.Get(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves := make([]swarm.Address, chunkCnt+1)
+	for i := range leaves {
+		cursor := swarm.SpanSize + i*swarm.HashSize
+		leaves[i] = swarm.NewAddress(rootChunk.Data()[cursor : cursor+swarm.HashSize])
+	}
+
+	g := &countingGetter{Getter: store, counts: make(map[string]int)}
+	j, _, err := joiner.New(ctx, g, store, addr, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.Seek(offset, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	n, err := j.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)-offset) {
+		t.Fatalf("wrote %d bytes, want %d", n, len(data)-offset)
+	}
+	if !bytes.Equal(buf.Bytes(), data[offset:]) {
+		t.Fatal("written data does not match the data")
+	}
+	// the data chunks are read in the aligned blocks
+	for i, leaf := range leaves {
+		if got := g.count(leaf); got != 1 {
+			t.Fatalf("chunk %d fetched %d times, want 1", i, got)
+		}
+	}
+
+	// the data is written from the current offset
+	if n, err := j.WriteTo(buf); n != 0 || err != nil {
+		t.Fatalf("got %d bytes and error %v at the end of the data", n, err)
+	}
+
+	// the offset is advanced by the bytes written before the error
+	if _, err := j.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	const limit = 3*swarm.ChunkSize + 5
+	if n, err := j.WriteTo(&failingWriter{limit: limit}); n != limit || !errors.Is(err, errWrite) {
+		t.Fatalf("got %d bytes and error %v, want %d bytes and error %v", n, err, limit, errWrite)
+	}
+	b := make([]byte, 10)
+	if _, err := j.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, data[limit:limit+10]) {
+		t.Fatal("read data does not match the data after the written bytes")
+	}
+}
+
+func TestDecompressingReader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	data := bytes.Repeat(testutil.RandBytes(t, 1000), 100)
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := enc.EncodeAll(data, nil)
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := splitter.NewSimpleSplitter(store)
+	addr, err := s.Split(ctx, io.NopCloser(bytes.NewReader(compressed)), int64(len(compressed)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, _, err := joiner.New(ctx, store, store, addr, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := joiner.NewDecompressingReader(j, int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("decompressed data does not match the data")
+	}
+
+	// the reads after seeking forward and backward
+	for _, off := range []int64{50000, 10, 99990} {
+		if _, err := r.Seek(off, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 100)
+		n, err := io.ReadFull(r, b)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b[:n], data[off:min(off+100, int64(len(data)))]) {
+			t.Fatalf("data read at offset %d does not match the data", off)
+		}
+	}
+
+	if size, err := r.Seek(0, io.SeekEnd); err != nil || size != int64(len(data)) {
+		t.Fatalf("got size %d and error %v, want %d", size, err, len(data))
+	}
+}
+
+func TestDecryptingReader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+	data := testutil.RandBytes(t, 3*swarm.ChunkSize+100)
+	encrypted := new(bytes.Buffer)
+	w := encryption.NewStreamWriter(encrypted, key)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := splitter.NewSimpleSplitter(store)
+	addr, err := s.Split(ctx, io.NopCloser(bytes.NewReader(encrypted.Bytes())), int64(encrypted.Len()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, size, err := joiner.New(ctx, store, store, addr, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := joiner.NewDecryptingReader(j, key, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Size() != int64(len(data)) {
+		t.Fatalf("got size %d, want %d", r.Size(), len(data))
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("decrypted data does not match the data")
+	}
+
+	// the reads after seeking forward and backward
+	for _, off := range []int64{2 * swarm.ChunkSize, 10, int64(len(data)) - 10} {
+		if _, err := r.Seek(off, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 100)
+		n, err := io.ReadFull(r, b)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b[:n], data[off:min(off+100, int64(len(data)))]) {
+			t.Fatalf("data read at offset %d does not match the data", off)
+		}
+	}
+
+	// the data is not returned with another key
+	r, err = joiner.NewDecryptingReader(j, testutil.RandBytes(t, encryption.KeyLength), size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); !errors.Is(err, encryption.ErrAuthentication) {
+		t.Fatalf("got error %v, want %v", err, encryption.ErrAuthentication)
+	}
+}
+
+func TestJoinerReadAtBuffer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := inmemchunkstore.New()
+	testutil.CleanupCloser(t, store)
+
+	data := testutil.RandBytes(t, 4*swarm.ChunkSize)
+	s := splitter.NewSimpleSplitter(store)
+	addr, err := s.Split(ctx, io.NopCloser(bytes.NewReader(data)), int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, _, err := joiner.New(ctx, store, store, addr, redundancy.DefaultLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the read is limited to the length of the buffer, not its capacity
+	buf := bytes.Repeat([]byte{0xff}, len(data))
+	n, err := j.ReadAt(buf[10:20], 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 10 {
+		t.Fatalf("read %d bytes, want 10", n)
+	}
+	if !bytes.Equal(buf[10:20], data[10:20]) {
+		t.Fatal("read data does not match the data")
+	}
+	if !bytes.Equal(buf[20:], bytes.Repeat([]byte{0xff}, len(data)-20)) {
+		t.Fatal("data read beyond the length of the buffer")
+	}
+}
+
 func TestJoinerOneLevel(t *testing.T) {
 	t.Parallel()
 
@@ -1447,3 +1934,34 @@ func (c *chunkStore) Iterate(_ context.Context, fn storage.IterateChunkFn) error
 func (c *chunkStore) Close() error {
 	return nil
 }
+
+// countingGetter counts the retrievals of the chunks.
+type countingGetter struct {
+	storage.Getter
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (g *countingGetter) Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	g.mu.Lock()
+	g.counts[addr.ByteString()]++
+	g.mu.Unlock()
+	return g.Getter.Get(ctx, addr)
+}
+
+func (g *countingGetter) count(addr swarm.Address) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.counts[addr.ByteString()]
+}
+
+func (g *countingGetter) total() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := 0
+	for _, c := range g.counts {
+		n += c
+	}
+	return n
+}
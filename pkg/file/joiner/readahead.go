@@ -0,0 +1,271 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/encryption/store"
+	"github.com/calmw/bee-tron/pkg/file"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultReadAhead is the default number of the data chunks prefetched
+// ahead of the sequential reads.
+const DefaultReadAhead = 32
+
+var errReadAheadFull = errors.New("read-ahead full")
+
+type readAheadKey struct{}
+
+// SetReadAhead sets the number of the data chunks the joiner prefetches
+// ahead of the sequential reads, zero disables the read-ahead.
+func SetReadAhead(ctx context.Context, chunks int) context.Context {
+	return context.WithValue(ctx, readAheadKey{}, chunks)
+}
+
+// readAhead keeps the chunks retrieved ahead of the sequential reads until
+// they are read. The reads are sequential if they start within the window
+// from the end of the previous reads, which tolerates the concurrent reads
+// of the consecutive ranges.
+type readAhead struct {
+	window int64 // the number of bytes prefetched ahead of the reads
+	limit  int   // the maximum number of the prefetched chunks kept
+
+	mu     sync.Mutex
+	gen    int                         // incremented when the reads are not sequential
+	next   int64                       // the offset the sequential reads continue from
+	until  int64                       // the offset the data is prefetched until
+	chunks map[string]*prefetchedChunk // the prefetched chunks not read yet
+}
+
+// prefetchedChunk is the result of the retrieval of a prefetched chunk.
+type prefetchedChunk struct {
+	done  chan struct{}
+	chunk swarm.Chunk
+	err   error
+}
+
+func newReadAhead(chunks int) *readAhead {
+	return &readAhead{
+		window: int64(chunks) * swarm.ChunkSize,
+		limit:  2 * chunks,
+		chunks: make(map[string]*prefetchedChunk),
+	}
+}
+
+// advance records the read of n bytes at the offset and returns the range
+// to prefetch and its generation, an empty range if the reads are not
+// sequential or the data up to the window is already prefetched.
+func (p *readAhead) advance(off, n, span int64) (start, end int64, gen int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if d := off - p.next; d < -p.window || d > p.window {
+		// not sequential, the prefetched chunks will not be read
+		p.gen++
+		p.next, p.until = off+n, 0
+		clear(p.chunks)
+		return 0, 0, p.gen
+	}
+
+	p.next = max(p.next, off+n)
+	start = max(p.next, p.until)
+	end = min(p.next+p.window, span)
+	// prefetch at least a chunk at once, unless it is the end of the data
+	if end-start < swarm.ChunkSize && end < span {
+		return 0, 0, p.gen
+	}
+	p.until = max(p.until, end)
+	return start, end, p.gen
+}
+
+// claim returns the prefetched chunk of the address and whether it was
+// claimed to be retrieved by the caller. It returns nil if the generation
+// is outdated or the limit of the prefetched chunks is reached.
+func (p *readAhead) claim(addr swarm.Address, gen int) (*prefetchedChunk, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if gen != p.gen {
+		return nil, false
+	}
+	if c, ok := p.chunks[addr.ByteString()]; ok {
+		return c, false
+	}
+	if len(p.chunks) >= p.limit {
+		return nil, false
+	}
+	c := &prefetchedChunk{done: make(chan struct{})}
+	p.chunks[addr.ByteString()] = c
+	return c, true
+}
+
+// take removes and returns the prefetched chunk of the address, nil if it
+// was not prefetched.
+func (p *readAhead) take(addr swarm.Address) *prefetchedChunk {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c := p.chunks[addr.ByteString()]
+	delete(p.chunks, addr.ByteString())
+	return c
+}
+
+// get returns the chunk read by the joiner, the prefetched one if there is
+// any.
+func (j *joiner) get(g storage.Getter, addr swarm.Address) (swarm.Chunk, error) {
+	if j.readAhead != nil {
+		if c := j.readAhead.take(addr); c != nil {
+			select {
+			case <-c.done:
+			case <-j.ctx.Done():
+				return nil, j.ctx.Err()
+			}
+			// the failed prefetch is retried by the read
+			if c.err == nil {
+				return c.chunk, nil
+			}
+		}
+	}
+	return g.Get(j.ctx, addr)
+}
+
+// prefetchGet returns the chunk of the address retrieving it once for all
+// the prefetches of the same generation.
+func (j *joiner) prefetchGet(g storage.Getter, addr swarm.Address, gen int) (swarm.Chunk, error) {
+	c, claimed := j.readAhead.claim(addr, gen)
+	if c == nil {
+		return nil, errReadAheadFull
+	}
+	if claimed {
+		c.chunk, c.err = g.Get(j.ctx, addr)
+		close(c.done)
+	}
+	select {
+	case <-c.done:
+	case <-j.ctx.Done():
+		return nil, j.ctx.Err()
+	}
+	return c.chunk, c.err
+}
+
+// readAheadFrom records the read of n bytes at the offset and starts the
+// prefetch of the data ahead of the sequential reads.
+func (j *joiner) readAheadFrom(off, n int64) {
+	if j.readAhead == nil {
+		return
+	}
+	start, end, gen := j.readAhead.advance(off, n, j.span)
+	if start >= end {
+		return
+	}
+	go func() {
+		var eg errgroup.Group
+		j.prefetch(j.rootData, 0, j.span, start, end, j.rootParity, gen, &eg)
+		_ = eg.Wait()
+	}()
+}
+
+// prefetch retrieves the chunks of the data in the range from the start to
+// the end offset of the subtrie with the given data starting at the cursor.
+// The data chunks referenced by the same intermediate chunk are retrieved
+// at once if the getter supports it.
+func (j *joiner) prefetch(data []byte, cur, subTrieSize, start, end int64, parity, gen int, eg *errgroup.Group) {
+	// we are at a leaf data chunk
+	if subTrieSize <= int64(len(data)) {
+		return
+	}
+	pSize, err := file.ChunkPayloadSize(data)
+	if err != nil {
+		return
+	}
+
+	addrs, shardCnt := file.ChunkAddresses(data[:pSize], parity, j.refLength)
+	getter := j.decoders.GetOrCreate(addrs, shardCnt)
+	g := store.New(getter)
+
+	var leaves []swarm.Address
+	for cursor := 0; cursor < len(data) && cur < end; cursor += j.refLength {
+		sec := j.subtrieSection(cursor, pSize, parity, subTrieSize)
+		if cur+sec <= start {
+			cur += sec
+			continue
+		}
+
+		addr := swarm.NewAddress(data[cursor : cursor+j.refLength])
+		if sec <= swarm.ChunkSize {
+			leaves = append(leaves, addr)
+			cur += sec
+			continue
+		}
+
+		func(addr swarm.Address, cur int64) {
+			eg.Go(func() error {
+				ch, err := j.prefetchGet(g, addr, gen)
+				if err != nil {
+					return err
+				}
+
+				subtrieLevel, subtrieSpan := j.chunkToSpan(ch.Data())
+				_, subtrieParity := file.ReferenceCount(uint64(subtrieSpan), subtrieLevel, encryption.IsEncryptedReferenceSize(j.refLength))
+				if subtrieSpan > sec {
+					return ErrMalformedTrie
+				}
+
+				j.prefetch(ch.Data()[swarm.SpanSize:], cur, subtrieSpan, start, end, subtrieParity, gen, eg)
+				return nil
+			})
+		}(addr, cur)
+		cur += sec
+	}
+
+	// the encrypted chunks are decrypted by the store getter one by one
+	if bg, ok := getter.(storage.BatchGetter); ok && j.refLength == swarm.HashSize && len(leaves) > 1 {
+		eg.Go(func() error {
+			return j.prefetchBatch(bg, leaves, gen)
+		})
+		return
+	}
+	for _, addr := range leaves {
+		eg.Go(func() error {
+			_, err := j.prefetchGet(g, addr, gen)
+			return err
+		})
+	}
+}
+
+// prefetchBatch retrieves the data chunks of the addresses which are not
+// prefetched yet at once, every chunk being handed over as soon as it arrives.
+func (j *joiner) prefetchBatch(bg storage.BatchGetter, addrs []swarm.Address, gen int) error {
+	var (
+		claimed []*prefetchedChunk
+		batch   []swarm.Address
+	)
+	for _, addr := range addrs {
+		c, ok := j.readAhead.claim(addr, gen)
+		if c == nil {
+			break
+		}
+		if ok {
+			claimed = append(claimed, c)
+			batch = append(batch, addr)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return bg.GetMany(j.ctx, batch, func(i int, ch swarm.Chunk, err error) {
+		c := claimed[i]
+		c.chunk, c.err = ch, err
+		close(c.done)
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/file"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// segmentJoiner joins the content-defined segments of a segment index, every
+// segment being read by its own joiner.
+type segmentJoiner struct {
+	ctx      context.Context
+	getter   storage.Getter
+	putter   storage.Putter
+	index    file.Joiner
+	segments []file.Segment
+	starts   []int64 // the offsets of the segments in the data
+	span     int64
+	off      int64
+
+	mu      sync.Mutex
+	joiners []file.Joiner // the joiners of the segments, created on the first read
+}
+
+// NewSegmentJoiner creates a new Joiner of the data of the segment index of
+// the address, written by the CDC splitter.
+func NewSegmentJoiner(ctx context.Context, g storage.Getter, putter storage.Putter, address swarm.Address) (file.Joiner, int64, error) {
+	index, _, err := New(ctx, g, putter, address, redundancy.NONE)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := io.ReadAll(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	segments, err := file.ParseSegmentIndex(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	j := &segmentJoiner{
+		ctx:      ctx,
+		getter:   g,
+		putter:   putter,
+		index:    index,
+		segments: segments,
+		starts:   make([]int64, len(segments)),
+		joiners:  make([]file.Joiner, len(segments)),
+	}
+	for i, seg := range segments {
+		if seg.Size <= 0 {
+			return nil, 0, file.ErrInvalidSegmentIndex
+		}
+		j.starts[i] = j.span
+		j.span += seg.Size
+	}
+	return j, j.span, nil
+}
+
+// segment returns the joiner of the i-th segment.
+func (j *segmentJoiner) segment(i int) (file.Joiner, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.joiners[i] != nil {
+		return j.joiners[i], nil
+	}
+	sj, size, err := New(j.ctx, j.getter, j.putter, j.segments[i].Address, redundancy.NONE)
+	if err != nil {
+		return nil, err
+	}
+	if size != j.segments[i].Size {
+		return nil, ErrMalformedTrie
+	}
+	j.joiners[i] = sj
+	return sj, nil
+}
+
+// Read is called by the consumer to retrieve the joined data.
+func (j *segmentJoiner) Read(b []byte) (n int, err error) {
+	read, err := j.ReadAt(b, j.off)
+	j.off += int64(read)
+	return read, err
+}
+
+// ReadAt implements the io.ReaderAt interface, reading the segments of the
+// range one after the other.
+func (j *segmentJoiner) ReadAt(b []byte, off int64) (int, error) {
+	if off >= j.span {
+		return 0, io.EOF
+	}
+	b = b[:min(int64(len(b)), j.span-off)]
+
+	// the last segment starting at or before the offset
+	i := sort.Search(len(j.starts), func(i int) bool { return j.starts[i] > off }) - 1
+	read := 0
+	for ; read < len(b) && i < len(j.segments); i++ {
+		sj, err := j.segment(i)
+		if err != nil {
+			return read, err
+		}
+		n, err := sj.ReadAt(b[read:], off+int64(read)-j.starts[i])
+		read += n
+		if err != nil && (!errors.Is(err, io.EOF) || n == 0) {
+			return read, err
+		}
+	}
+	return read, nil
+}
+
+// Seek implements the io.Seeker interface.
+func (j *segmentJoiner) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += j.off
+	case io.SeekEnd:
+		offset = j.span - offset
+		if offset < 0 {
+			return 0, io.EOF
+		}
+	default:
+		return 0, errWhence
+	}
+
+	if offset < 0 {
+		return 0, errOffset
+	}
+	if offset > j.span {
+		return 0, io.EOF
+	}
+	j.off = offset
+	return offset, nil
+}
+
+// WriteTo implements the io.WriterTo interface, writing the segments from
+// the current offset with their joiners.
+func (j *segmentJoiner) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	for i := range j.segments {
+		end := j.starts[i] + j.segments[i].Size
+		if j.off >= end {
+			continue
+		}
+		sj, err := j.segment(i)
+		if err != nil {
+			return written, err
+		}
+		if _, err := sj.Seek(j.off-j.starts[i], io.SeekStart); err != nil {
+			return written, err
+		}
+		n, err := sj.WriteTo(w)
+		written += n
+		j.off += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// IterateChunkAddresses iterates over the chunk addresses of the segment
+// index and of all the segments.
+func (j *segmentJoiner) IterateChunkAddresses(fn swarm.AddressIterFunc) error {
+	if err := j.index.IterateChunkAddresses(fn); err != nil {
+		return err
+	}
+	for i := range j.segments {
+		sj, err := j.segment(i)
+		if err != nil {
+			return err
+		}
+		if err := sj.IterateChunkAddresses(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size returns the length of the joined data.
+func (j *segmentJoiner) Size() int64 {
+	return j.span
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package joiner
+
+import (
+	"errors"
+	"io"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// writeToBlockChunks is the number of the data chunks read at once by
+// WriteTo.
+const writeToBlockChunks = 16
+
+// block is the data read at an offset for WriteTo.
+type block struct {
+	buf []byte
+	n   int
+	err error
+}
+
+// WriteTo implements the io.WriterTo interface. It writes the data from the
+// current offset until the end in the blocks aligned to the data chunks, so
+// that no data chunk is retrieved twice, and it reads the next block while
+// the previous one is written.
+func (j *joiner) WriteTo(w io.Writer) (int64, error) {
+	const blockSize = writeToBlockChunks * swarm.ChunkSize
+
+	var (
+		blocks = make(chan block)
+		free   = make(chan []byte, 2)
+		done   = make(chan struct{})
+	)
+	defer close(done)
+	free <- make([]byte, blockSize)
+	free <- make([]byte, blockSize)
+
+	go func(off int64) {
+		defer close(blocks)
+		for off < j.span {
+			var buf []byte
+			select {
+			case buf = <-free:
+			case <-done:
+				return
+			}
+			end := min((off/swarm.ChunkSize+writeToBlockChunks)*swarm.ChunkSize, j.span)
+			n, err := j.ReadAt(buf[:end-off], off)
+			if err == nil && n == 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			select {
+			case blocks <- block{buf, n, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+			off += int64(n)
+		}
+	}(j.off)
+
+	var written int64
+	for b := range blocks {
+		if b.err != nil && !errors.Is(b.err, io.EOF) {
+			return written, b.err
+		}
+		n, err := w.Write(b.buf[:b.n])
+		written += int64(n)
+		j.off += int64(n)
+		if err != nil {
+			return written, err
+		}
+		if n != b.n {
+			return written, io.ErrShortWrite
+		}
+		free <- b.buf
+	}
+	return written, nil
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
 
 	"github.com/calmw/bee-tron/pkg/encryption"
 	"github.com/calmw/bee-tron/pkg/file/pipeline"
@@ -16,6 +17,7 @@ import (
 	enc "github.com/calmw/bee-tron/pkg/file/pipeline/encryption"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/feeder"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/hashtrie"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/parallel"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/store"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
 	"github.com/calmw/bee-tron/pkg/storage"
@@ -30,15 +32,24 @@ func NewPipelineBuilder(ctx context.Context, s storage.Putter, encrypt bool, rLe
 	return newPipeline(ctx, s, rLevel)
 }
 
+// workers returns the number of the workers which hash and store the data
+// chunks concurrently.
+func workers() int {
+	return runtime.GOMAXPROCS(0)
+}
+
 // newPipeline creates a standard pipeline that only hashes content with BMT to create
 // a merkle-tree of hashes that represent the given arbitrary size byte stream. Partial
-// writes are supported. The pipeline flow is: Data -> Feeder -> BMT -> Storage -> HashTrie.
+// writes are supported. The pipeline flow is: Data -> Feeder -> BMT -> Storage -> HashTrie,
+// where the data chunks are hashed and stored by a pool of workers concurrently.
 func newPipeline(ctx context.Context, s storage.Putter, rLevel redundancy.Level) pipeline.Interface {
-	pipeline := newShortPipelineFunc(ctx, s)
-	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize, redundancy.New(rLevel, false, pipeline), pipeline, s, rLevel)
-	lsw := store.NewStoreWriter(ctx, s, tw)
-	b := bmt.NewBmtWriter(lsw)
-	return feeder.NewChunkFeederWriter(swarm.ChunkSize, b)
+	pipelineFn := newShortPipelineFunc(ctx, s)
+	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize, redundancy.New(rLevel, false, pipelineFn), pipelineFn, s, rLevel)
+	pw := parallel.NewParallelWriter(workers(), func(next pipeline.ChainWriter) pipeline.ChainWriter {
+		lsw := store.NewStoreWriter(ctx, s, next)
+		return bmt.NewBmtWriter(lsw)
+	}, tw)
+	return feeder.NewChunkFeederWriter(swarm.ChunkSize, pw)
 }
 
 // newShortPipelineFunc returns a constructor function for an ephemeral hashing pipeline
@@ -50,17 +61,49 @@ func newShortPipelineFunc(ctx context.Context, s storage.Putter) func() pipeline
 	}
 }
 
-// newEncryptionPipeline creates an encryption pipeline that encrypts using CTR, hashes content with BMT to create
+type encryptionSchemeKey struct{}
+
+// SetEncryptionScheme returns a context with the scheme in which the
+// encryption pipelines built with it encrypt the chunks, which is
+// encryption.KeccakCTR if not set. The trees of the authenticated schemes
+// have no redundancy, as their references are longer than the legacy ones.
+func SetEncryptionScheme(ctx context.Context, scheme encryption.Scheme) (context.Context, error) {
+	if _, err := encryption.NewSchemeChunkEncrypter(scheme); err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, encryptionSchemeKey{}, scheme), nil
+}
+
+// encryptionScheme returns the encryption scheme of the context.
+func encryptionScheme(ctx context.Context) encryption.Scheme {
+	scheme, _ := ctx.Value(encryptionSchemeKey{}).(encryption.Scheme)
+	return scheme
+}
+
+// chunkEncrypter returns the chunk encrypter of the scheme of the context.
+func chunkEncrypter(ctx context.Context) encryption.ChunkEncrypter {
+	e, err := encryption.NewSchemeChunkEncrypter(encryptionScheme(ctx))
+	if err != nil {
+		// not reached, as the scheme is validated when it is set
+		return encryption.NewChunkEncrypter()
+	}
+	return e
+}
+
+// newEncryptionPipeline creates an encryption pipeline that encrypts in the scheme of the context, hashes content with BMT to create
 // a merkle-tree of hashes that represent the given arbitrary size byte stream. Partial
-// writes are supported. The pipeline flow is: Data -> Feeder -> Encryption -> BMT -> Storage -> HashTrie.
+// writes are supported. The pipeline flow is: Data -> Feeder -> Encryption -> BMT -> Storage -> HashTrie,
+// where the data chunks are encrypted, hashed and stored by a pool of workers concurrently.
 // Note that the encryption writer will mutate the data to contain the encrypted span, but the span field
 // with the unencrypted span is preserved.
 func newEncryptionPipeline(ctx context.Context, s storage.Putter, rLevel redundancy.Level) pipeline.Interface {
-	tw := hashtrie.NewHashTrieWriter(ctx, swarm.HashSize+encryption.KeyLength, redundancy.New(rLevel, true, newShortPipelineFunc(ctx, s)), newShortEncryptionPipelineFunc(ctx, s), s, rLevel)
-	lsw := store.NewStoreWriter(ctx, s, tw)
-	b := bmt.NewBmtWriter(lsw)
-	enc := enc.NewEncryptionWriter(encryption.NewChunkEncrypter(), b)
-	return feeder.NewChunkFeederWriter(swarm.ChunkSize, enc)
+	tw := hashtrie.NewHashTrieWriter(ctx, encryptionScheme(ctx).ReferenceSize(), redundancy.New(rLevel, true, newShortPipelineFunc(ctx, s)), newShortEncryptionPipelineFunc(ctx, s), s, rLevel)
+	pw := parallel.NewParallelWriter(workers(), func(next pipeline.ChainWriter) pipeline.ChainWriter {
+		lsw := store.NewStoreWriter(ctx, s, next)
+		b := bmt.NewBmtWriter(lsw)
+		return enc.NewEncryptionWriter(chunkEncrypter(ctx), b)
+	}, tw)
+	return feeder.NewChunkFeederWriter(swarm.ChunkSize, pw)
 }
 
 // newShortEncryptionPipelineFunc returns a constructor function for an ephemeral hashing pipeline
@@ -69,7 +112,7 @@ func newShortEncryptionPipelineFunc(ctx context.Context, s storage.Putter) func(
 	return func() pipeline.ChainWriter {
 		lsw := store.NewStoreWriter(ctx, s, nil)
 		b := bmt.NewBmtWriter(lsw)
-		return enc.NewEncryptionWriter(encryption.NewChunkEncrypter(), b)
+		return enc.NewEncryptionWriter(chunkEncrypter(ctx), b)
 	}
 }
 
@@ -0,0 +1,45 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package compression provides a pipeline stage which compresses the data
+// before it is split into chunks.
+package compression
+
+import (
+	"github.com/calmw/bee-tron/pkg/file/pipeline"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Zstd is the name of the zstd compression of the data.
+const Zstd = "zstd"
+
+type compressionWriter struct {
+	next pipeline.Interface
+	enc  *zstd.Encoder
+}
+
+// NewCompressionWriter returns a pipeline which compresses the data with
+// zstd and writes the compressed stream to the next pipeline. The data is
+// compressed as a whole, so the chunks of the next pipeline are standard.
+func NewCompressionWriter(next pipeline.Interface) (pipeline.Interface, error) {
+	enc, err := zstd.NewWriter(next)
+	if err != nil {
+		return nil, err
+	}
+	return &compressionWriter{next: next, enc: enc}, nil
+}
+
+// Write compresses the data. The compressed data is written to the next
+// pipeline in blocks, so it is not necessarily written when Write returns.
+func (w *compressionWriter) Write(b []byte) (int, error) {
+	return w.enc.Write(b)
+}
+
+// Sum flushes the compressed data to the next pipeline and returns its sum.
+func (w *compressionWriter) Sum() ([]byte, error) {
+	if err := w.enc.Close(); err != nil {
+		return nil, err
+	}
+	return w.next.Sum()
+}
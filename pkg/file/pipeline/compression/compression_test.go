@@ -0,0 +1,64 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compression_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/file/pipeline/compression"
+	"github.com/klauspost/compress/zstd"
+)
+
+// buffer is a pipeline which keeps the data written to it.
+type buffer struct {
+	bytes.Buffer
+	sums int
+}
+
+func (b *buffer) Sum() ([]byte, error) {
+	b.sums++
+	return []byte("sum"), nil
+}
+
+func TestCompressionWriter(t *testing.T) {
+	t.Parallel()
+
+	next := new(buffer)
+	w, err := compression.NewCompressionWriter(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("compressible data "), 10000)
+	for i := 0; i < len(data); i += 1000 {
+		if _, err := w.Write(data[i:min(i+1000, len(data))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum, err := w.Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sum) != "sum" || next.sums != 1 {
+		t.Fatalf("got sum %q and %d sums of the next pipeline", sum, next.sums)
+	}
+	if next.Len() >= len(data)/10 {
+		t.Fatalf("compressed %d bytes into %d", len(data), next.Len())
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	got, err := dec.DecodeAll(next.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("decompressed data does not match the data")
+	}
+}
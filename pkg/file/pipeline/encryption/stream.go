@@ -0,0 +1,40 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption
+
+import (
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/file/pipeline"
+)
+
+type streamEncryptionWriter struct {
+	next pipeline.Interface
+	enc  *encryption.StreamWriter
+}
+
+// NewStreamEncryptionWriter returns a pipeline which encrypts the data as a
+// stream with the key and writes the encrypted stream to the next pipeline.
+// The data is encrypted as a whole, so the chunks of the next pipeline are
+// standard and hold the ciphertext only.
+func NewStreamEncryptionWriter(key encryption.Key, next pipeline.Interface) pipeline.Interface {
+	return &streamEncryptionWriter{
+		next: next,
+		enc:  encryption.NewStreamWriter(next, key),
+	}
+}
+
+// Write encrypts the data. The encrypted data is written to the next
+// pipeline in records, so it is not necessarily written when Write returns.
+func (w *streamEncryptionWriter) Write(b []byte) (int, error) {
+	return w.enc.Write(b)
+}
+
+// Sum writes the last record to the next pipeline and returns its sum.
+func (w *streamEncryptionWriter) Sum() ([]byte, error) {
+	if err := w.enc.Close(); err != nil {
+		return nil, err
+	}
+	return w.next.Sum()
+}
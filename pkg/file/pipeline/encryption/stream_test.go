@@ -0,0 +1,64 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encryption_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	enc "github.com/calmw/bee-tron/pkg/file/pipeline/encryption"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+)
+
+// buffer is a pipeline which keeps the data written to it.
+type buffer struct {
+	bytes.Buffer
+	sums int
+}
+
+func (b *buffer) Sum() ([]byte, error) {
+	b.sums++
+	return []byte("sum"), nil
+}
+
+func TestStreamEncryptionWriter(t *testing.T) {
+	t.Parallel()
+
+	key := testutil.RandBytes(t, encryption.KeyLength)
+	data := testutil.RandBytes(t, 10000)
+
+	next := new(buffer)
+	w := enc.NewStreamEncryptionWriter(key, next)
+	for i := 0; i < len(data); i += 1000 {
+		if _, err := w.Write(data[i:min(i+1000, len(data))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum, err := w.Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sum) != "sum" || next.sums != 1 {
+		t.Fatalf("got sum %q and %d sums of the next pipeline", sum, next.sums)
+	}
+
+	size, err := encryption.StreamDataSize(int64(next.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != int64(len(data)) {
+		t.Fatalf("got data size %d, want %d", size, len(data))
+	}
+
+	got, err := io.ReadAll(encryption.NewStreamReader(bytes.NewReader(next.Bytes()), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("decrypted data does not match the data")
+	}
+}
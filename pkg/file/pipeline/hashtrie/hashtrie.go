@@ -52,6 +52,11 @@ func NewHashTrieWriter(ctx context.Context, refLen int, rParams redundancy.Redun
 		maxChildrenChunks:      uint8(rParams.MaxShards() + rParams.Parities(rParams.MaxShards())),
 		replicaPutter:          replicas.NewPutter(replicaPutter, rLevel),
 	}
+	if maxRefs := swarm.ChunkSize / refLen; rParams.Level() == redundancy.NONE && maxRefs < int(h.maxChildrenChunks) {
+		// the intermediate chunks without redundancy are filled with the
+		// references of any length
+		h.maxChildrenChunks = uint8(maxRefs)
+	}
 	h.parityChunkFn = func(level int, span, address []byte) error {
 		return h.writeToIntermediateLevel(level, true, span, address, []byte{})
 	}
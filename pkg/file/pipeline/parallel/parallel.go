@@ -0,0 +1,147 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parallel provides a pipeline writer which processes the chunks
+// with a bounded pool of the chains of writers concurrently, and passes
+// them on to the next writer in the order they were written.
+package parallel
+
+import (
+	"github.com/calmw/bee-tron/pkg/file/pipeline"
+)
+
+// ChainFunc constructs a chain of writers of a worker, which ends with the
+// given writer.
+type ChainFunc func(next pipeline.ChainWriter) pipeline.ChainWriter
+
+// job is a chunk processed by a worker.
+type job struct {
+	args *pipeline.PipeWriteArgs
+	err  error
+	done chan struct{}
+}
+
+type parallelWriter struct {
+	next   pipeline.ChainWriter
+	chains []pipeline.ChainWriter
+	idle   chan pipeline.ChainWriter // the chains not processing a chunk
+	queue  []*job                    // the jobs not passed on yet, in the order they were written
+	limit  int                       // the maximum number of the jobs in the queue
+	err    error
+}
+
+// NewParallelWriter returns a writer which processes the chunks with the
+// chains of the given number of workers concurrently, and passes them on to
+// the next writer in the order they were written, so that the trie built by
+// the next writers is deterministic. The chains of the workers are summed
+// before the next writer.
+func NewParallelWriter(workers int, chainFn ChainFunc, next pipeline.ChainWriter) pipeline.ChainWriter {
+	workers = max(workers, 1)
+	w := &parallelWriter{
+		next:   next,
+		chains: make([]pipeline.ChainWriter, workers),
+		idle:   make(chan pipeline.ChainWriter, workers),
+		limit:  2 * workers,
+	}
+	for i := range w.chains {
+		w.chains[i] = chainFn(sink{})
+		w.idle <- w.chains[i]
+	}
+	return w
+}
+
+// ChainWrite starts the processing of the chunk and passes the processed
+// chunks on to the next writer. The data is copied, as the buffers of the
+// args may be reused by the writers up the chain.
+func (w *parallelWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	// pass on the leading chunks already processed and bound the chunks in
+	// flight
+	for len(w.queue) > 0 && (len(w.queue) >= w.limit || isDone(w.queue[0])) {
+		if err := w.pass(); err != nil {
+			return err
+		}
+	}
+
+	j := &job{
+		args: &pipeline.PipeWriteArgs{
+			Ref:  clone(p.Ref),
+			Key:  clone(p.Key),
+			Span: clone(p.Span),
+			Data: clone(p.Data),
+		},
+		done: make(chan struct{}),
+	}
+	w.queue = append(w.queue, j)
+
+	chain := <-w.idle
+	go func() {
+		j.err = chain.ChainWrite(j.args)
+		w.idle <- chain
+		close(j.done)
+	}()
+	return nil
+}
+
+// pass waits for the first job in the queue and passes its chunk on to the
+// next writer.
+func (w *parallelWriter) pass() error {
+	j := w.queue[0]
+	<-j.done
+	w.queue[0] = nil
+	w.queue = w.queue[1:]
+
+	err := j.err
+	if err == nil {
+		err = w.next.ChainWrite(j.args)
+	}
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+// Sum waits for the chunks in flight, sums the chains of the workers and
+// returns the sum of the next writer.
+func (w *parallelWriter) Sum() ([]byte, error) {
+	for len(w.queue) > 0 && w.err == nil {
+		// the jobs still in flight after an error end on their own
+		_ = w.pass()
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	for _, chain := range w.chains {
+		if _, err := chain.Sum(); err != nil {
+			return nil, err
+		}
+	}
+	return w.next.Sum()
+}
+
+func isDone(j *job) bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func clone(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// sink ends the chains of the workers, the chunks are passed on by the
+// parallel writer.
+type sink struct{}
+
+func (sink) ChainWrite(*pipeline.PipeWriteArgs) error { return nil }
+func (sink) Sum() ([]byte, error)                     { return nil, nil }
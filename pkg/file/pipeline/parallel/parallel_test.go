@@ -0,0 +1,139 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parallel_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/file/pipeline"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/bmt"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/parallel"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+var errChain = errors.New("chain failed")
+
+// recorder records the references of the chunks in the order they are
+// written.
+type recorder struct {
+	refs [][]byte
+	sums int
+}
+
+func (r *recorder) ChainWrite(p *pipeline.PipeWriteArgs) error {
+	r.refs = append(r.refs, p.Ref)
+	return nil
+}
+
+func (r *recorder) Sum() ([]byte, error) {
+	r.sums++
+	return []byte("sum"), nil
+}
+
+// delayWriter delays the chunks randomly, so that they are processed out of
+// order, and fails the chunk with the given span.
+type delayWriter struct {
+	next pipeline.ChainWriter
+	fail uint64
+}
+
+func (w *delayWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	if binary.LittleEndian.Uint64(p.Span) == w.fail {
+		return errChain
+	}
+	return w.next.ChainWrite(p)
+}
+
+func (w *delayWriter) Sum() ([]byte, error) {
+	return w.next.Sum()
+}
+
+func write(t *testing.T, w pipeline.ChainWriter, count int) error {
+	t.Helper()
+
+	// the buffer is reused by the writes
+	data := make([]byte, swarm.SpanSize+swarm.ChunkSize)
+	for i := 1; i <= count; i++ {
+		binary.LittleEndian.PutUint64(data[:swarm.SpanSize], uint64(i))
+		copy(data[swarm.SpanSize:], bytes.Repeat([]byte{byte(i)}, i))
+		if err := w.ChainWrite(&pipeline.PipeWriteArgs{Data: data[:swarm.SpanSize+i], Span: data[:swarm.SpanSize]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestParallelWriter(t *testing.T) {
+	t.Parallel()
+
+	const count = 100
+
+	want := new(recorder)
+	if err := write(t, bmt.NewBmtWriter(want), count); err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(recorder)
+	w := parallel.NewParallelWriter(4, func(next pipeline.ChainWriter) pipeline.ChainWriter {
+		return &delayWriter{next: bmt.NewBmtWriter(next)}
+	}, got)
+	if err := write(t, w, count); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := w.Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sum) != "sum" || got.sums != 1 {
+		t.Fatalf("got sum %q and %d sums of the next writer", sum, got.sums)
+	}
+
+	if len(got.refs) != count {
+		t.Fatalf("got %d chunks, want %d", len(got.refs), count)
+	}
+	for i := range got.refs {
+		if !bytes.Equal(got.refs[i], want.refs[i]) {
+			t.Fatalf("chunk %d: got reference %x, want %x", i, got.refs[i], want.refs[i])
+		}
+	}
+}
+
+func TestParallelWriterError(t *testing.T) {
+	t.Parallel()
+
+	const count, fail = 100, 30
+
+	got := new(recorder)
+	w := parallel.NewParallelWriter(4, func(next pipeline.ChainWriter) pipeline.ChainWriter {
+		return &delayWriter{next: bmt.NewBmtWriter(next), fail: fail}
+	}, got)
+
+	err := write(t, w, count)
+	if err == nil {
+		_, err = w.Sum()
+	}
+	if !errors.Is(err, errChain) {
+		t.Fatalf("got error %v, want %v", err, errChain)
+	}
+	// the chunks before the failed one are passed on
+	if len(got.refs) != fail-1 {
+		t.Fatalf("got %d chunks, want %d", len(got.refs), fail-1)
+	}
+	if _, err := w.Sum(); !errors.Is(err, errChain) {
+		t.Fatalf("got error %v, want %v", err, errChain)
+	}
+	if len(got.refs) != fail-1 {
+		t.Fatalf("got %d chunks after the error, want %d", len(got.refs), fail-1)
+	}
+	if got.sums != 0 {
+		t.Fatal("next writer summed after the error")
+	}
+}
@@ -15,22 +15,46 @@ import (
 
 var errInvalidData = errors.New("store: invalid data")
 
+// batchSize is the number of chunks put in one commit when the putter
+// supports batches.
+const batchSize = 16
+
 type storeWriter struct {
-	l    storage.Putter
-	ctx  context.Context
-	next pipeline.ChainWriter
+	l     storage.Putter
+	ctx   context.Context
+	next  pipeline.ChainWriter
+	batch []swarm.Chunk
 }
 
 // NewStoreWriter returns a storeWriter. It just writes the given data
-// to a given storage.Putter.
+// to a given storage.Putter. If the putter is a storage.BatchPutter, the
+// chunks are buffered and put in batches, the last one when the sum is
+// requested.
 func NewStoreWriter(ctx context.Context, l storage.Putter, next pipeline.ChainWriter) pipeline.ChainWriter {
-	return &storeWriter{ctx: ctx, l: l, next: next}
+	w := &storeWriter{ctx: ctx, l: l, next: next}
+	if _, ok := l.(storage.BatchPutter); ok && next != nil {
+		w.batch = make([]swarm.Chunk, 0, batchSize)
+	}
+	return w
 }
 
 func (w *storeWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	if p.Ref == nil || p.Data == nil {
 		return errInvalidData
 	}
+	if w.batch != nil {
+		// the buffers of the args may be reused by the writers up the chain
+		ref := append([]byte(nil), p.Ref...)
+		data := append([]byte(nil), p.Data...)
+		w.batch = append(w.batch, swarm.NewChunk(swarm.NewAddress(ref), data))
+		if len(w.batch) == batchSize {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+		return w.next.ChainWrite(p)
+	}
+
 	err := w.l.Put(w.ctx, swarm.NewChunk(swarm.NewAddress(p.Ref), p.Data))
 	if err != nil {
 		return err
@@ -42,6 +66,19 @@ func (w *storeWriter) ChainWrite(p *pipeline.PipeWriteArgs) error {
 	return w.next.ChainWrite(p)
 }
 
+// flush puts the buffered chunks.
+func (w *storeWriter) flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	_, err := storage.PutBatch(w.ctx, w.l, w.batch)
+	w.batch = w.batch[:0]
+	return err
+}
+
 func (w *storeWriter) Sum() ([]byte, error) {
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
 	return w.next.Sum()
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/file/pipeline"
 	mock "github.com/calmw/bee-tron/pkg/file/pipeline/mock"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/store"
+	storage "github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
@@ -66,6 +67,69 @@ func TestStoreWriter(t *testing.T) {
 	}
 }
 
+type batchPutter struct {
+	storage.Putter
+	batches int
+}
+
+func (p *batchPutter) PutBatch(ctx context.Context, chunks []swarm.Chunk) (int, error) {
+	p.batches++
+	for _, ch := range chunks {
+		if err := p.Put(ctx, ch); err != nil {
+			return 0, err
+		}
+	}
+	return len(chunks), nil
+}
+
+// TestStoreWriterBatch tests that the store writer puts the chunks in batches
+// if the putter supports them and stores the rest when the sum is requested.
+func TestStoreWriterBatch(t *testing.T) {
+	t.Parallel()
+
+	mockStore := inmemchunkstore.New()
+	putter := &batchPutter{Putter: mockStore}
+	mockChainWriter := mock.NewChainWriter()
+	ctx := context.Background()
+	writer := store.NewStoreWriter(ctx, putter, mockChainWriter)
+
+	const count = 20
+	ref := make([]byte, swarm.HashSize)
+	data := make([]byte, 8)
+	for i := 0; i < count; i++ {
+		// reuse the buffers like the writers up the chain do
+		ref[0], data[0] = byte(i), byte(i)
+		if err := writer.ChainWrite(&pipeline.PipeWriteArgs{Ref: ref, Data: data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if putter.batches != 1 {
+		t.Fatalf("wanted 1 batch before sum, got %d", putter.batches)
+	}
+
+	if _, err := writer.Sum(); err != nil {
+		t.Fatal(err)
+	}
+	if putter.batches != 2 {
+		t.Fatalf("wanted 2 batches after sum, got %d", putter.batches)
+	}
+	if calls := mockChainWriter.ChainWriteCalls(); calls != count {
+		t.Errorf("wanted %d ChainWrite calls, got %d", count, calls)
+	}
+
+	for i := 0; i < count; i++ {
+		addr := make([]byte, swarm.HashSize)
+		addr[0] = byte(i)
+		ch, err := mockStore.Get(ctx, swarm.NewAddress(addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ch.Data()[0] != byte(i) {
+			t.Fatal("data mismatch")
+		}
+	}
+}
+
 // TestSum tests that calling Sum on the store writer results in Sum on the next writer in the chain.
 func TestSum(t *testing.T) {
 	t.Parallel()
@@ -246,10 +246,14 @@ func (g *decoder) runStrategy(s Strategy) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	for _, i := range m {
-		go func(i int) {
-			c <- g.fetch(ctx, i, false)
-		}(i)
+	if bg, ok := g.fetcher.(storage.BatchGetter); ok {
+		go g.fetchBatch(ctx, bg, m, c)
+	} else {
+		for _, i := range m {
+			go func(i int) {
+				c <- g.fetch(ctx, i, false)
+			}(i)
+		}
 	}
 
 	for range c {
@@ -264,6 +268,47 @@ func (g *decoder) runStrategy(s Strategy) error {
 	return nil
 }
 
+// fetchBatch retrieves the chunks at the given indexes which are fetched for
+// the first time in a single batch and sends the result of each index to c as
+// soon as the chunk arrives.
+func (g *decoder) fetchBatch(ctx context.Context, bg storage.BatchGetter, m []int, c chan<- error) {
+	var (
+		idx   []int
+		addrs []swarm.Address
+	)
+	for _, i := range m {
+		if !g.fly(i) {
+			// already in flight, wait for its result
+			go func(i int) {
+				c <- g.fetch(ctx, i, false)
+			}(i)
+			continue
+		}
+		idx = append(idx, i)
+		addrs = append(addrs, g.addrs[i])
+	}
+	if len(idx) == 0 {
+		return
+	}
+
+	fctx, cancel := context.WithTimeout(ctx, g.config.FetchTimeout)
+	defer cancel()
+
+	_ = bg.GetMany(fctx, addrs, func(j int, ch swarm.Chunk, err error) {
+		i := idx[j]
+		if err != nil {
+			g.failedCnt.Add(1)
+			close(g.waits[i])
+			c <- err
+			return
+		}
+		g.setData(i, ch.Data())
+		close(g.waits[i])
+		g.fetchedCnt.Add(1)
+		c <- nil
+	})
+}
+
 // recover wraps the stages of data shard recovery:
 // 1. gather missing data shards
 // 2. decode using Reed-Solomon decoder
@@ -0,0 +1,65 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// segmentIndexVersion is the version of the encoding of the segment index.
+const segmentIndexVersion = 1
+
+var ErrInvalidSegmentIndex = errors.New("invalid segment index")
+
+// Segment is a content-defined segment of the data. The data of the segment
+// is a standard file with its own tree of chunks.
+type Segment struct {
+	Address swarm.Address
+	Size    int64
+}
+
+// EncodeSegmentIndex encodes the segments as the version and the length of
+// the references followed by the size and the reference of every segment.
+func EncodeSegmentIndex(index []Segment, refLength int) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(segmentIndexVersion)
+	buf.WriteByte(byte(refLength))
+	for _, seg := range index {
+		_ = binary.Write(buf, binary.BigEndian, uint64(seg.Size))
+		buf.Write(seg.Address.Bytes())
+	}
+	return buf.Bytes()
+}
+
+// ParseSegmentIndex parses the data of the segment index encoded by
+// EncodeSegmentIndex.
+func ParseSegmentIndex(data []byte) ([]Segment, error) {
+	if len(data) < 2 || data[0] != segmentIndexVersion {
+		return nil, ErrInvalidSegmentIndex
+	}
+	refLength := int(data[1])
+	if refLength != swarm.HashSize && !encryption.IsEncryptedReferenceSize(refLength) {
+		return nil, ErrInvalidSegmentIndex
+	}
+	data = data[2:]
+
+	size := 8 + refLength
+	if len(data)%size != 0 {
+		return nil, ErrInvalidSegmentIndex
+	}
+	index := make([]Segment, 0, len(data)/size)
+	for ; len(data) > 0; data = data[size:] {
+		index = append(index, Segment{
+			Address: swarm.NewAddress(append([]byte(nil), data[8:size]...)),
+			Size:    int64(binary.BigEndian.Uint64(data[:8])),
+		})
+	}
+	return index, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package splitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/file"
+	"github.com/calmw/bee-tron/pkg/file/splitter/cdc"
+	"github.com/calmw/bee-tron/pkg/file/splitter/internal"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// Mode is the way the splitter reads the data.
+type Mode int
+
+const (
+	// ModeFixed reads the data in chunks of swarm.ChunkSize.
+	ModeFixed Mode = iota
+	// ModeCDC reads the data in content-defined segments.
+	ModeCDC
+)
+
+var errUnknownMode = errors.New("unknown splitter mode")
+
+// ParseMode parses the name of a splitter mode, fixed or cdc.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "", "fixed":
+		return ModeFixed, nil
+	case "cdc":
+		return ModeCDC, nil
+	}
+	return ModeFixed, fmt.Errorf("%w: %q", errUnknownMode, s)
+}
+
+// New creates the splitter of the given mode, the CDC splitter with the
+// default segment sizes.
+func New(storePutter storage.Putter, mode Mode) file.Splitter {
+	if mode == ModeCDC {
+		return NewCDCSplitter(storePutter, cdc.DefaultOptions)
+	}
+	return NewSimpleSplitter(storePutter)
+}
+
+// cdcSplitter splits the data into the content-defined segments, so that
+// the segments of the versions of the data which did not change are the
+// same files, and their chunks are reused.
+type cdcSplitter struct {
+	putter storage.Putter
+	opts   cdc.Options
+}
+
+// NewCDCSplitter creates a new splitter which cuts the data into the
+// segments with the content-defined chunking, and splits every segment into
+// its own tree of chunks, starting at the first chunk of the segment. Its
+// Split returns the address of the segment index, a standard file which
+// lists the segments in their order and is read by joiner.NewSegmentJoiner.
+func NewCDCSplitter(storePutter storage.Putter, opts cdc.Options) file.Splitter {
+	return &cdcSplitter{
+		putter: storePutter,
+		opts:   opts,
+	}
+}
+
+// Split implements the file.Splitter interface.
+func (s *cdcSplitter) Split(ctx context.Context, r io.ReadCloser, dataLength int64, toEncrypt bool) (addr swarm.Address, err error) {
+	chunker, err := cdc.NewChunker(r, s.opts)
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+
+	var (
+		total int64
+		index []file.Segment
+	)
+	for {
+		seg, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return swarm.ZeroAddress, err
+		}
+		total += int64(len(seg))
+		if total > dataLength {
+			return swarm.ZeroAddress, fmt.Errorf("splitter received more than %d bytes of data", dataLength)
+		}
+		ref, err := s.split(ctx, seg, toEncrypt)
+		if err != nil {
+			return swarm.ZeroAddress, err
+		}
+		index = append(index, file.Segment{Address: ref, Size: int64(len(seg))})
+
+		select {
+		case <-ctx.Done():
+			return swarm.ZeroAddress, ctx.Err()
+		default:
+		}
+	}
+	if total < dataLength {
+		return swarm.ZeroAddress, fmt.Errorf("splitter only received %d bytes of data, expected %d bytes", total, dataLength)
+	}
+
+	refLength := swarm.HashSize
+	if toEncrypt {
+		refLength = encryption.ReferenceSize
+	}
+	return s.split(ctx, file.EncodeSegmentIndex(index, refLength), toEncrypt)
+}
+
+// split splits the data into the chunks of a standard file.
+func (s *cdcSplitter) split(ctx context.Context, data []byte, toEncrypt bool) (swarm.Address, error) {
+	j := internal.NewSimpleSplitterJob(ctx, s.putter, int64(len(data)), toEncrypt)
+	for len(data) > 0 {
+		n, err := j.Write(data[:min(len(data), swarm.ChunkSize)])
+		if err != nil {
+			return swarm.ZeroAddress, err
+		}
+		data = data[n:]
+	}
+	return swarm.NewAddress(j.Sum(nil)), nil
+}
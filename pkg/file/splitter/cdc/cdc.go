@@ -0,0 +1,132 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cdc provides the content-defined chunking of data streams. The
+// data is cut at the positions where a rolling hash of the content matches
+// a pattern, so that an insertion or a removal of data only moves the
+// boundaries of the segments around it, and the other segments of the
+// versions of the data are the same.
+package cdc
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// DefaultOptions are the segment sizes suitable for the backups of large
+// files.
+var DefaultOptions = Options{
+	MinSize: 16 * 1024,
+	AvgSize: 64 * 1024,
+	MaxSize: 256 * 1024,
+}
+
+var errInvalidOptions = errors.New("cdc: invalid options")
+
+// Options are the bounds of the sizes of the segments.
+type Options struct {
+	MinSize int // the minimum size of a segment, except the last one
+	AvgSize int // the expected size of a segment, a power of two
+	MaxSize int // the maximum size of a segment
+}
+
+func (o Options) validate() error {
+	if o.MinSize <= 0 || o.AvgSize <= o.MinSize || o.MaxSize <= o.AvgSize || bits.OnesCount(uint(o.AvgSize)) != 1 {
+		return errInvalidOptions
+	}
+	return nil
+}
+
+// gear is the table of the random values of the bytes for the gear hash,
+// generated by splitmix64 from a fixed seed so that it never changes.
+var gear = func() (t [256]uint64) {
+	x := uint64(0x5357_4152_4d43_4443)
+	for i := range t {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		t[i] = z ^ (z >> 31)
+	}
+	return t
+}()
+
+// Chunker reads the data and cuts it into the content-defined segments.
+type Chunker struct {
+	r    io.Reader
+	opts Options
+	mask uint64 // the pattern of the hash of a boundary
+	buf  []byte
+	off  int  // the start of the unread data in the buffer
+	end  int  // the end of the data in the buffer
+	eof  bool // whether the reader is exhausted
+}
+
+// NewChunker returns a chunker of the data of the reader.
+func NewChunker(r io.Reader, opts Options) (*Chunker, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	return &Chunker{
+		r:    r,
+		opts: opts,
+		// the highest bits of the hash depend on the most bytes
+		mask: ^uint64(0) << (64 - bits.TrailingZeros(uint(opts.AvgSize))),
+		buf:  make([]byte, 2*opts.MaxSize),
+	}, nil
+}
+
+// Next returns the next segment of the data, which is valid until the next
+// call, and io.EOF after the last one.
+func (c *Chunker) Next() ([]byte, error) {
+	if err := c.fill(); err != nil {
+		return nil, err
+	}
+	data := c.buf[c.off:c.end]
+	if len(data) == 0 {
+		return nil, io.EOF
+	}
+	n := c.cut(data)
+	c.off += n
+	return data[:n], nil
+}
+
+// fill reads the data until at least a segment of the maximum size is
+// buffered or the reader is exhausted.
+func (c *Chunker) fill() error {
+	if c.end-c.off >= c.opts.MaxSize || c.eof {
+		return nil
+	}
+	c.end = copy(c.buf, c.buf[c.off:c.end])
+	c.off = 0
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cut returns the length of the segment at the start of the data.
+func (c *Chunker) cut(data []byte) int {
+	if len(data) <= c.opts.MinSize {
+		return len(data)
+	}
+	data = data[:min(len(data), c.opts.MaxSize)]
+	var h uint64
+	for i := c.opts.MinSize; i < len(data); i++ {
+		h = h<<1 + gear[data[i]]
+		if h&c.mask == 0 {
+			return i + 1
+		}
+	}
+	return len(data)
+}
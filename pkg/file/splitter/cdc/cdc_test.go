@@ -0,0 +1,105 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cdc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/file/splitter/cdc"
+)
+
+var opts = cdc.Options{MinSize: 1024, AvgSize: 4096, MaxSize: 16384}
+
+func segments(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+
+	c, err := cdc.NewChunker(smallReader{bytes.NewReader(data)}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var segs [][]byte
+	for {
+		seg, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return segs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		segs = append(segs, append([]byte(nil), seg...))
+	}
+}
+
+// smallReader reads the data in the small pieces.
+type smallReader struct {
+	r io.Reader
+}
+
+func (r smallReader) Read(p []byte) (int, error) {
+	return r.r.Read(p[:min(len(p), 1000)])
+}
+
+func TestChunker(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	segs := segments(t, data)
+	if got := bytes.Join(segs, nil); !bytes.Equal(got, data) {
+		t.Fatal("segments do not match the data")
+	}
+	for i, seg := range segs {
+		if len(seg) > opts.MaxSize || (len(seg) < opts.MinSize && i < len(segs)-1) {
+			t.Fatalf("segment %d of size %d out of bounds", i, len(seg))
+		}
+	}
+	if avg := len(data) / len(segs); avg < opts.MinSize || avg > opts.MaxSize/2 {
+		t.Fatalf("average segment size %d", avg)
+	}
+
+	// an insertion only changes the segments around it
+	edited := append(append(append([]byte(nil), data[:1000]...), []byte("inserted")...), data[1000:]...)
+	seen := make(map[string]bool)
+	for _, seg := range segs {
+		seen[string(seg)] = true
+	}
+	var changed int
+	for _, seg := range segments(t, edited) {
+		if !seen[string(seg)] {
+			changed++
+		}
+	}
+	if changed > 2 {
+		t.Fatalf("%d segments changed by the insertion", changed)
+	}
+}
+
+func TestChunkerEmpty(t *testing.T) {
+	t.Parallel()
+
+	if segs := segments(t, nil); len(segs) != 0 {
+		t.Fatalf("got %d segments of no data", len(segs))
+	}
+}
+
+func TestChunkerOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, o := range []cdc.Options{
+		{},
+		{MinSize: 1024, AvgSize: 3000, MaxSize: 8192},
+		{MinSize: 4096, AvgSize: 4096, MaxSize: 8192},
+		{MinSize: 1024, AvgSize: 4096, MaxSize: 4096},
+	} {
+		if _, err := cdc.NewChunker(bytes.NewReader(nil), o); err == nil {
+			t.Fatalf("expected error for options %+v", o)
+		}
+	}
+}
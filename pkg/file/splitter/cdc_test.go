@@ -0,0 +1,161 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package splitter_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/file"
+	"github.com/calmw/bee-tron/pkg/file/joiner"
+	"github.com/calmw/bee-tron/pkg/file/splitter"
+	"github.com/calmw/bee-tron/pkg/file/splitter/cdc"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+)
+
+// TestCDCSplit tests that the content-defined segments are written into
+// their own trees, which are read back as the data by the segment joiner.
+func TestCDCSplit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		store = inmemchunkstore.New()
+		opts  = cdc.Options{MinSize: 1000, AvgSize: 8 * 1024, MaxSize: 20000}
+		s     = splitter.NewCDCSplitter(store, opts)
+	)
+
+	for _, size := range []int{1, swarm.ChunkSize, 3*swarm.ChunkSize + 1, 64 * swarm.ChunkSize} {
+		data := testutil.RandBytes(t, size)
+
+		for _, toEncrypt := range []bool{false, true} {
+			addr, err := s.Split(ctx, file.NewSimpleReadCloser(data), int64(len(data)), toEncrypt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			j, l, err := joiner.NewSegmentJoiner(ctx, store, store, addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l != int64(size) {
+				t.Fatalf("size %d, encrypted %v: got length %d", size, toEncrypt, l)
+			}
+			joined, err := io.ReadAll(j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(joined, data) {
+				t.Fatalf("size %d, encrypted %v: joined data does not match", size, toEncrypt)
+			}
+
+			// the reads across the segments
+			off := size / 3
+			b := make([]byte, min(size-off, 3*opts.MaxSize))
+			n, err := j.ReadAt(b, int64(off))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b[:n], data[off:off+n]) || n != len(b) {
+				t.Fatalf("size %d, encrypted %v: data read at offset %d does not match", size, toEncrypt, off)
+			}
+		}
+	}
+
+	data := testutil.RandBytes(t, 1<<16)
+	// the data length is checked
+	if _, err := s.Split(ctx, file.NewSimpleReadCloser(data), int64(len(data))+1, false); err == nil {
+		t.Fatal("expected error on EOF before full length write")
+	}
+	if _, err := s.Split(ctx, file.NewSimpleReadCloser(data), int64(len(data))-1, false); err == nil {
+		t.Fatal("expected error on write past the data length")
+	}
+}
+
+// TestCDCSplitInsertion tests that the chunks of the data are reused after
+// an insertion in the middle of the data, apart from the ones of the
+// segments around the insertion and of the segment index.
+func TestCDCSplitInsertion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		store = inmemchunkstore.New()
+		opts  = cdc.Options{MinSize: 1000, AvgSize: 8 * 1024, MaxSize: 20000}
+		s     = splitter.NewCDCSplitter(store, opts)
+	)
+
+	chunks := func(data []byte) map[string]struct{} {
+		t.Helper()
+
+		addr, err := s.Split(ctx, file.NewSimpleReadCloser(data), int64(len(data)), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		j, _, err := joiner.NewSegmentJoiner(ctx, store, store, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		joined, err := io.ReadAll(j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(joined, data) {
+			t.Fatal("joined data does not match")
+		}
+		addrs := make(map[string]struct{})
+		if err := j.IterateChunkAddresses(func(addr swarm.Address) error {
+			addrs[addr.ByteString()] = struct{}{}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return addrs
+	}
+
+	data := testutil.RandBytes(t, 1<<20)
+	inserted := append(append(bytes.Clone(data[:len(data)/2]), testutil.RandBytes(t, 100)...), data[len(data)/2:]...)
+
+	before := chunks(data)
+	after := chunks(inserted)
+
+	reused := 0
+	for addr := range after {
+		if _, ok := before[addr]; ok {
+			reused++
+		}
+	}
+	// with the fixed size chunks the half of the chunks after the
+	// insertion would all be new
+	if changed := len(after) - reused; changed > len(after)/10 {
+		t.Fatalf("%d of %d chunks changed by the insertion", changed, len(after))
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	t.Parallel()
+
+	for s, want := range map[string]splitter.Mode{
+		"":      splitter.ModeFixed,
+		"fixed": splitter.ModeFixed,
+		"cdc":   splitter.ModeCDC,
+	} {
+		mode, err := splitter.ParseMode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode != want {
+			t.Fatalf("parsed %q as %v, want %v", s, mode, want)
+		}
+	}
+
+	if _, err := splitter.ParseMode("rabin"); err == nil {
+		t.Fatal("expected error parsing an unknown mode")
+	}
+}
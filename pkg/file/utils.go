@@ -38,8 +38,9 @@ func ChunkAddresses(data []byte, parities, reflen int) (addrs []swarm.Address, s
 	for offset := 0; offset < len(data); offset += reflen {
 		addrs = append(addrs, swarm.NewAddress(data[offset:offset+swarm.HashSize]))
 		if len(addrs) == shardCnt && reflen != swarm.HashSize {
+			// skip the key of the last shard, the parities have no keys
+			offset += reflen - swarm.HashSize
 			reflen = swarm.HashSize
-			offset += reflen
 		}
 	}
 	return addrs, shardCnt
@@ -60,10 +61,26 @@ func ReferenceCount(span uint64, level redundancy.Level, encrytedChunk bool) (in
 	if encrytedChunk {
 		maxShards = level.GetMaxEncShards()
 	}
-	var (
-		branching  = uint64(maxShards) // branching factor is how many data shard references can fit into one intermediate chunk
-		branchSize = uint64(swarm.ChunkSize)
-	)
+	dataShardAddresses := referenceCount(span, uint64(maxShards))
+	parityAddresses := level.GetParities(dataShardAddresses)
+	if encrytedChunk {
+		parityAddresses = level.GetEncParities(dataShardAddresses)
+	}
+
+	return dataShardAddresses, parityAddresses
+}
+
+// DataReferenceCount returns the reference count of the intermediate chunk of
+// a subtree of the span without redundancy, which has the references of the
+// given length. Assumes span > swarm.ChunkSize.
+func DataReferenceCount(span uint64, refLength int) int {
+	return referenceCount(span, uint64(swarm.ChunkSize/refLength))
+}
+
+// referenceCount brute-forces the data shard count of a subtree of the span
+// with the given branching factor.
+func referenceCount(span, branching uint64) int {
+	branchSize := uint64(swarm.ChunkSize)
 	// search for branch level big enough to include span
 	branchLevel := 1
 	for {
@@ -86,11 +103,5 @@ func ReferenceCount(span uint64, level redundancy.Level, encrytedChunk bool) (in
 		spanOffset += referenceSize
 		dataShardAddresses++
 	}
-
-	parityAddresses := level.GetParities(dataShardAddresses)
-	if encrytedChunk {
-		parityAddresses = level.GetEncParities(dataShardAddresses)
-	}
-
-	return dataShardAddresses, parityAddresses
+	return dataShardAddresses
 }
@@ -328,3 +328,8 @@ func GatewayTimeout(w http.ResponseWriter, response interface{}) {
 func HTTPVersionNotSupported(w http.ResponseWriter, response interface{}) {
 	Respond(w, http.StatusHTTPVersionNotSupported, response)
 }
+
+// InsufficientStorage writes a response with status code 507.
+func InsufficientStorage(w http.ResponseWriter, response interface{}) {
+	Respond(w, http.StatusInsufficientStorage, response)
+}
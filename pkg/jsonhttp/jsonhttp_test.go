@@ -99,6 +99,7 @@ func TestRespond_statusResponse(t *testing.T) {
 		{code: http.StatusServiceUnavailable},
 		{code: http.StatusGatewayTimeout},
 		{code: http.StatusHTTPVersionNotSupported},
+		{code: http.StatusInsufficientStorage},
 	} {
 		w := httptest.NewRecorder()
 
@@ -294,6 +295,7 @@ func TestStandardHTTPResponds(t *testing.T) {
 		{f: jsonhttp.ServiceUnavailable, code: http.StatusServiceUnavailable},
 		{f: jsonhttp.GatewayTimeout, code: http.StatusGatewayTimeout},
 		{f: jsonhttp.HTTPVersionNotSupported, code: http.StatusHTTPVersionNotSupported},
+		{f: jsonhttp.InsufficientStorage, code: http.StatusInsufficientStorage},
 	} {
 		w := httptest.NewRecorder()
 		tc.f(w, nil)
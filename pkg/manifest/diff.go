@@ -0,0 +1,91 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sort"
+)
+
+// Changes are the differences of a manifest from a base manifest.
+type Changes struct {
+	Added   []string // paths of the entries which are not in the base
+	Removed []string // paths of the entries which are only in the base
+	Changed []string // paths of the entries with a different reference or metadata
+}
+
+// Empty returns true if the manifests have the same entries.
+func (c *Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Diff returns the changes of the other manifest from the base manifest,
+// with their paths in sorted order.
+func Diff(ctx context.Context, base, other Interface) (*Changes, error) {
+	entries := make(map[string]Entry)
+	err := base.IterateEntries(ctx, func(path string, entry Entry) error {
+		entries[path] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(Changes)
+	err = other.IterateEntries(ctx, func(path string, entry Entry) error {
+		baseEntry, ok := entries[path]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, path)
+		case !equalEntries(path, baseEntry, entry):
+			c.Changed = append(c.Changed, path)
+		}
+		delete(entries, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for path := range entries {
+		c.Removed = append(c.Removed, path)
+	}
+
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+	sort.Strings(c.Changed)
+	return c, nil
+}
+
+// Merge adds the entries of the patch manifest to the base manifest,
+// replacing the entries of the base at the same paths. The entries of the
+// base which are not in the patch are kept. As only the modified parts of a
+// manifest are saved when it is stored, storing the merged base uploads the
+// new nodes only and the unchanged content is referenced as it is.
+func Merge(ctx context.Context, base, patch Interface) error {
+	return patch.IterateEntries(ctx, func(path string, entry Entry) error {
+		baseEntry, err := base.Lookup(ctx, path)
+		switch {
+		case err == nil && equalEntries(path, baseEntry, entry):
+			// the nodes of an unchanged entry are not modified
+			return nil
+		case err != nil && !errors.Is(err, ErrNotFound):
+			return err
+		}
+		return base.Add(ctx, path, entry)
+	})
+}
+
+// equalEntries reports whether the entries at the path are equal. The root
+// metadata entry references no content and its reference is either empty or
+// zero depending on the order the manifest was built in, so only its
+// metadata is compared.
+func equalEntries(path string, a, b Entry) bool {
+	if path == RootPath {
+		return maps.Equal(a.Metadata(), b.Metadata())
+	}
+	return a.Reference().Equal(b.Reference()) && maps.Equal(a.Metadata(), b.Metadata())
+}
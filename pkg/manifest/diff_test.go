@@ -0,0 +1,170 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package manifest_test
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/file/loadsave"
+	"github.com/calmw/bee-tron/pkg/file/pipeline"
+	"github.com/calmw/bee-tron/pkg/file/pipeline/builder"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/manifest"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type countingStore struct {
+	*inmemchunkstore.ChunkStore
+	puts atomic.Int64
+}
+
+func (s *countingStore) Put(ctx context.Context, ch swarm.Chunk) error {
+	s.puts.Add(1)
+	return s.ChunkStore.Put(ctx, ch)
+}
+
+func TestDiffMerge(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		store = &countingStore{ChunkStore: inmemchunkstore.New()}
+		ls    = loadsave.New(store, store, func() pipeline.Interface {
+			return builder.NewPipelineBuilder(ctx, store, false, redundancy.NONE)
+		}, redundancy.NONE)
+		index   = swarm.RandAddress(t)
+		index2  = swarm.RandAddress(t)
+		image1  = swarm.RandAddress(t)
+		image2  = swarm.RandAddress(t)
+		style   = swarm.RandAddress(t)
+		website = map[string]string{manifest.WebsiteIndexDocumentSuffixKey: "index.html"}
+	)
+
+	newManifest := func(t *testing.T, entries map[string]manifest.Entry) swarm.Address {
+		t.Helper()
+
+		m, err := manifest.NewDefaultManifest(ls, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for path, entry := range entries {
+			if err := m.Add(ctx, path, entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+		ref, err := m.Store(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ref
+	}
+	load := func(t *testing.T, ref swarm.Address) manifest.Interface {
+		t.Helper()
+
+		m, err := manifest.NewDefaultManifestReference(ref, ls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	base := newManifest(t, map[string]manifest.Entry{
+		manifest.RootPath: manifest.NewEntry(swarm.ZeroAddress, website),
+		"index.html":      manifest.NewEntry(index, nil),
+		"img/1.png":       manifest.NewEntry(image1, nil),
+		"img/2.png":       manifest.NewEntry(image2, nil),
+	})
+	other := newManifest(t, map[string]manifest.Entry{
+		manifest.RootPath: manifest.NewEntry(swarm.ZeroAddress, website),
+		"index.html":      manifest.NewEntry(index2, nil),
+		"img/1.png":       manifest.NewEntry(image1, map[string]string{manifest.EntryMetadataContentTypeKey: "image/png"}),
+		"css/app.css":     manifest.NewEntry(style, nil),
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := manifest.Diff(ctx, load(t, base), load(t, other))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &manifest.Changes{
+			Added:   []string{"css/app.css"},
+			Removed: []string{"img/2.png"},
+			Changed: []string{"img/1.png", "index.html"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got changes %+v, want %+v", got, want)
+		}
+
+		same, err := manifest.Diff(ctx, load(t, base), load(t, base))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !same.Empty() {
+			t.Fatalf("got changes %+v of the same manifest", same)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+
+		patch := newManifest(t, map[string]manifest.Entry{
+			"index.html":  manifest.NewEntry(index2, nil),
+			"css/app.css": manifest.NewEntry(style, nil),
+		})
+
+		m := load(t, base)
+		if err := manifest.Merge(ctx, m, load(t, patch)); err != nil {
+			t.Fatal(err)
+		}
+		merged, err := m.Store(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := newManifest(t, map[string]manifest.Entry{
+			manifest.RootPath: manifest.NewEntry(swarm.ZeroAddress, website),
+			"index.html":      manifest.NewEntry(index2, nil),
+			"img/1.png":       manifest.NewEntry(image1, nil),
+			"img/2.png":       manifest.NewEntry(image2, nil),
+			"css/app.css":     manifest.NewEntry(style, nil),
+		})
+		changes, err := manifest.Diff(ctx, load(t, want), load(t, merged))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !changes.Empty() {
+			t.Fatalf("got changes %+v of the merged manifest", changes)
+		}
+	})
+
+	// not parallel, as the chunks stored by the other subtests are counted
+	t.Run("merge unchanged", func(t *testing.T) {
+		patch := newManifest(t, map[string]manifest.Entry{
+			"img/1.png": manifest.NewEntry(image1, nil),
+		})
+
+		m := load(t, base)
+		if err := manifest.Merge(ctx, m, load(t, patch)); err != nil {
+			t.Fatal(err)
+		}
+		puts := store.puts.Load()
+		merged, err := m.Store(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !merged.Equal(base) {
+			t.Fatalf("got merged manifest %s, want %s", merged, base)
+		}
+		if got := store.puts.Load(); got != puts {
+			t.Fatalf("got %d chunks stored, want none", got-puts)
+		}
+	})
+}
@@ -17,11 +17,15 @@ import (
 const DefaultManifestType = ManifestMantarayContentType
 
 const (
-	RootPath                      = "/"
-	WebsiteIndexDocumentSuffixKey = "website-index-document"
-	WebsiteErrorDocumentPathKey   = "website-error-document"
-	EntryMetadataContentTypeKey   = "Content-Type"
-	EntryMetadataFilenameKey      = "Filename"
+	RootPath                         = "/"
+	WebsiteIndexDocumentSuffixKey    = "website-index-document"
+	WebsiteErrorDocumentPathKey      = "website-error-document"
+	EntryMetadataContentTypeKey      = "Content-Type"
+	EntryMetadataFilenameKey         = "Filename"
+	EntryMetadataCompressionKey      = "Compression"
+	EntryMetadataDecompressedSizeKey = "Decompressed-Size"
+	EntryMetadataRedundancyLevelKey  = "Redundancy-Level"
+	EntryMetadataStreamEncryptedKey  = "Stream-Encrypted"
 )
 
 var (
@@ -58,8 +62,14 @@ type Interface interface {
 	// IterateAddresses is used to iterate over chunks addresses for
 	// the manifest.
 	IterateAddresses(context.Context, swarm.AddressIterFunc) error
+	// IterateEntries is used to iterate over the entries of the
+	// manifest with their paths.
+	IterateEntries(context.Context, EntryIterFunc) error
 }
 
+// EntryIterFunc is a callback on every entry of a manifest with its path.
+type EntryIterFunc func(path string, entry Entry) error
+
 // Entry represents a single manifest entry.
 type Entry interface {
 	// Reference returns the address of the file.
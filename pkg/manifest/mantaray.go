@@ -183,6 +183,27 @@ func (m *mantarayManifest) IterateAddresses(ctx context.Context, fn swarm.Addres
 	return nil
 }
 
+func (m *mantarayManifest) IterateEntries(ctx context.Context, fn EntryIterFunc) error {
+	walker := func(path []byte, node *mantaray.Node, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if node == nil || !node.IsValueType() {
+			return nil
+		}
+
+		return fn(string(path), NewEntry(swarm.NewAddress(node.Entry()), node.Metadata()))
+	}
+
+	err := m.trie.WalkNode(ctx, []byte{}, m.ls, walker)
+	if err != nil {
+		return fmt.Errorf("manifest iterate entries: %w", err)
+	}
+
+	return nil
+}
+
 type mantarayLoadSaver struct {
 	ls          file.LoadSaver
 	storeSizeFn []StoreSizeFunc
@@ -63,6 +63,47 @@ func TestPersistIdempotence(t *testing.T) {
 	}
 }
 
+func TestPersistAddAfterLookup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var ls mantaray.LoadSaver = newMockLoadSaver()
+
+	n := mantaray.New()
+	var v [32]byte
+	copy(v[:], "aaa")
+	if err := n.Add(ctx, []byte("aaa"), v[:], nil, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := n.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// the nodes loaded by the lookup are saved again after the addition
+	nn := mantaray.NewNodeRef(n.Reference())
+	if _, err := nn.Lookup(ctx, []byte("aaa"), ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	copy(v[:], "aab")
+	if err := nn.Add(ctx, []byte("aab"), v[:], nil, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := nn.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Equal(nn.Reference(), n.Reference()) {
+		t.Fatal("expected the reference to change")
+	}
+
+	m, err := mantaray.NewNodeRef(nn.Reference()).Lookup(ctx, []byte("aab"), ls)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !bytes.Equal(m, v[:]) {
+		t.Fatalf("expected value %x, got %x", v[:], m)
+	}
+}
+
 func TestPersistRemove(t *testing.T) {
 	t.Parallel()
 
@@ -144,6 +144,28 @@ func (m *simpleManifest) IterateAddresses(ctx context.Context, fn swarm.AddressI
 	return nil
 }
 
+func (m *simpleManifest) IterateEntries(_ context.Context, fn EntryIterFunc) error {
+	walker := func(path string, entry simple.Entry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ref, err := swarm.ParseHexAddress(entry.Reference())
+		if err != nil {
+			return err
+		}
+
+		return fn(path, NewEntry(ref, entry.Metadata()))
+	}
+
+	err := m.manifest.WalkEntry("", walker)
+	if err != nil {
+		return fmt.Errorf("manifest iterate entries: %w", err)
+	}
+
+	return nil
+}
+
 func (m *simpleManifest) load(ctx context.Context, reference swarm.Address) error {
 	buf, err := m.ls.Load(ctx, reference.Bytes())
 	if err != nil {
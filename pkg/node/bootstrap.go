@@ -174,7 +174,18 @@ func bootstrapNode(
 
 	radiusF := func() (uint8, error) { return swarm.MaxBins, nil }
 
-	retrieve := retrieval.New(swarmAddress, radiusF, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching)
+	retrieve := retrieval.New(swarmAddress, radiusF, localStore, p2ps, kad, logger, acc, pricer, tracer, retrieval.Options{
+		Caching:        o.RetrievalCaching,
+		HedgeDelay:     o.RetrievalHedgeDelay,
+		MaxAttempts:    o.RetrievalMaxAttempts,
+		AttemptTimeout: o.RetrievalAttemptTimeout,
+		RetryInterval:  o.RetrievalRetryInterval,
+		Backoff:        o.RetrievalBackoff,
+		NotFoundTTL:    o.RetrievalNotFoundTTL,
+
+		SelectionCandidates: o.RetrievalSelectionCandidates,
+		Cost:                retrieval.LinearCost(o.RetrievalPriceWeight, o.RetrievalLatencyWeight),
+	})
 	if err = p2ps.AddProtocol(retrieve.Protocol()); err != nil {
 		return nil, fmt.Errorf("retrieval service: %w", err)
 	}
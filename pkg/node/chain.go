@@ -9,7 +9,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/crypto"
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/p2p/libp2p"
+	"github.com/calmw/bee-tron/pkg/postage"
 	"github.com/calmw/bee-tron/pkg/postage/postagecontract"
 	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/settlement/swap"
@@ -26,7 +30,9 @@ import (
 	"github.com/calmw/bee-tron/pkg/settlement/swap/swapprotocol"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/transaction"
+	"github.com/calmw/bee-tron/pkg/transaction/failover"
 	"github.com/calmw/bee-tron/pkg/transaction/wrapped"
+	"github.com/calmw/bee-tron/pkg/util/abiutil"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -53,28 +59,48 @@ func InitChain(
 	signer crypto.Signer,
 	pollingInterval time.Duration,
 	chainEnabled bool,
+	fallbackEndpoints []string,
+	healthCheckInterval time.Duration,
+	subscribeHeads bool,
+	resubmitTimeout time.Duration,
+	resubmitMaxGasFeeCap string,
 ) (transaction.Backend, common.Address, int64, transaction.Monitor, transaction.Service, error) {
 	var backend transaction.Backend = &noOpChainBackend{
 		chainID: oChainID,
 	}
 
-	if chainEnabled {
+	if chainEnabled && len(fallbackEndpoints) == 0 {
 		// connect to the real one
-		rpcClient, err := rpc.DialContext(ctx, endpoint)
+		client, err := dialChainBackend(ctx, logger, endpoint)
 		if err != nil {
-			return nil, common.Address{}, 0, nil, nil, fmt.Errorf("dial blockchain client: %w", err)
+			logger.Info("could not connect to backend; in a swap-enabled network a working blockchain node (for xdai network in production, sepolia in testnet) is required; check your node or specify another node using --blockchain-rpc-endpoint.", "backend_endpoint", endpoint)
+			return nil, common.Address{}, 0, nil, nil, err
 		}
 
-		var versionString string
-		err = rpcClient.CallContext(ctx, &versionString, "web3_clientVersion")
-		if err != nil {
-			logger.Info("could not connect to backend; in a swap-enabled network a working blockchain node (for xdai network in production, sepolia in testnet) is required; check your node or specify another node using --blockchain-rpc-endpoint.", "backend_endpoint", endpoint)
-			return nil, common.Address{}, 0, nil, nil, fmt.Errorf("blockchain client get version: %w", err)
+		backend = wrapped.NewBackend(client)
+	} else if chainEnabled {
+		var endpoints []failover.Endpoint
+		for _, e := range append([]string{endpoint}, fallbackEndpoints...) {
+			client, err := dialChainBackend(ctx, logger, e)
+			if err != nil {
+				// an unreachable endpoint is skipped as long as another one is available
+				logger.Warning("skipping blockchain rpc endpoint", "backend_endpoint", chainEndpointName(e), "error", err)
+				continue
+			}
+			endpoints = append(endpoints, failover.Endpoint{Name: chainEndpointName(e), Backend: client})
+		}
+		if len(endpoints) == 0 {
+			logger.Info("could not connect to any backend; in a swap-enabled network a working blockchain node is required; check your nodes or specify other ones using --blockchain-rpc-endpoint and --blockchain-rpc-fallback-endpoints.")
+			return nil, common.Address{}, 0, nil, nil, errors.New("no blockchain rpc endpoint available")
 		}
 
-		logger.Info("connected to blockchain backend", "version", versionString)
+		failoverBackend, err := failover.NewBackend(logger, endpoints, maxDelay, healthCheckInterval)
+		if err != nil {
+			return nil, common.Address{}, 0, nil, nil, fmt.Errorf("failover backend: %w", err)
+		}
+		failoverBackend.Start()
 
-		backend = wrapped.NewBackend(ethclient.NewClient(rpcClient))
+		backend = wrapped.NewBackend(failoverBackend)
 	}
 
 	chainID, err := backend.ChainID(ctx)
@@ -87,16 +113,66 @@ func InitChain(
 		return nil, common.Address{}, 0, nil, nil, fmt.Errorf("blockchain address: %w", err)
 	}
 
-	transactionMonitor := transaction.NewMonitor(logger, backend, overlayEthAddress, pollingInterval, cancellationDepth)
+	transactionMonitor := transaction.NewMonitor(logger, backend, overlayEthAddress, pollingInterval, cancellationDepth, subscribeHeads)
 
 	transactionService, err := transaction.NewService(logger, overlayEthAddress, backend, signer, stateStore, chainID, transactionMonitor)
 	if err != nil {
 		return nil, common.Address{}, 0, nil, nil, fmt.Errorf("new transaction service: %w", err)
 	}
 
+	// name the methods of the token and chequebook contracts in the transaction history
+	for _, contractABI := range []string{sw3abi.ERC20ABIv0_6_5, sw3abi.ERC20SimpleSwapABIv0_6_5, sw3abi.SimpleSwapFactoryABIv0_6_5} {
+		transactionService.RegisterABI(abiutil.MustParseABI(contractABI))
+	}
+
+	if resubmitTimeout > 0 {
+		maxGasFeeCap, ok := new(big.Int).SetString(resubmitMaxGasFeeCap, 10)
+		if !ok {
+			return nil, common.Address{}, 0, nil, nil, fmt.Errorf("resubmit max gas fee cap \"%s\" cannot be parsed", resubmitMaxGasFeeCap)
+		}
+		// zero means the fee of resubmitted transactions is not capped
+		if maxGasFeeCap.Sign() == 0 {
+			maxGasFeeCap = nil
+		}
+
+		transactionMonitor.SetResubmitFunc(resubmitTimeout, func(ctx context.Context, txHash common.Hash) (common.Hash, error) {
+			return transactionService.ResubmitTransaction(ctx, txHash, maxGasFeeCap)
+		})
+		logger.Info("resubmitting stuck transactions", "stuck_timeout", resubmitTimeout, "max_gas_fee_cap", maxGasFeeCap)
+	}
+
 	return backend, overlayEthAddress, chainID.Int64(), transactionMonitor, transactionService, nil
 }
 
+// dialChainBackend connects to the blockchain rpc endpoint and verifies it responds.
+func dialChainBackend(ctx context.Context, logger log.Logger, endpoint string) (*ethclient.Client, error) {
+	rpcClient, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("dial blockchain client: %w", err)
+	}
+
+	var versionString string
+	err = rpcClient.CallContext(ctx, &versionString, "web3_clientVersion")
+	if err != nil {
+		rpcClient.Close()
+		return nil, fmt.Errorf("blockchain client get version: %w", err)
+	}
+
+	logger.Info("connected to blockchain backend", "version", versionString, "backend_endpoint", chainEndpointName(endpoint))
+
+	return ethclient.NewClient(rpcClient), nil
+}
+
+// chainEndpointName identifies an endpoint without exposing credentials
+// which are commonly part of the url of hosted blockchain nodes.
+func chainEndpointName(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Scheme + "://" + u.Host
+}
+
 // InitChequebookFactory will initialize the chequebook factory with the given
 // chain backend.
 func InitChequebookFactory(logger log.Logger, backend transaction.Backend, chainID int64, transactionService transaction.Service, factoryAddress string) (chequebook.Factory, error) {
@@ -128,6 +204,7 @@ func InitChequebookService(
 	stateStore storage.StateStorer,
 	signer crypto.Signer,
 	chainID int64,
+	chequeDomain chequebook.ChequeDomain,
 	backend transaction.Backend,
 	overlayEthAddress common.Address,
 	transactionService transaction.Service,
@@ -135,7 +212,7 @@ func InitChequebookService(
 	initialDeposit string,
 	erc20Service erc20.Service,
 ) (chequebook.Service, error) {
-	chequeSigner := chequebook.NewChequeSigner(signer, chainID)
+	chequeSigner := chequebook.NewDomainChequeSigner(signer, chequeDomain)
 
 	deposit, ok := new(big.Int).SetString(initialDeposit, 10)
 	if !ok {
@@ -162,21 +239,148 @@ func InitChequebookService(
 	return chequebookService, nil
 }
 
+// InitChequebookTopUp creates the watcher topping up the chequebook from the
+// wallet. It returns nil if no floor is configured.
+func InitChequebookTopUp(
+	logger log.Logger,
+	chequebookService chequebook.Service,
+	stateStore storage.StateStorer,
+	floor, amount, maxDaily string,
+) (*chequebook.TopUpWatcher, error) {
+	parse := func(name, value string) (*big.Int, error) {
+		v, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("%s \"%s\" cannot be parsed", name, value)
+		}
+		return v, nil
+	}
+
+	floorValue, err := parse("top-up floor", floor)
+	if err != nil {
+		return nil, err
+	}
+	if floorValue.Sign() == 0 {
+		return nil, nil
+	}
+	amountValue, err := parse("top-up amount", amount)
+	if err != nil {
+		return nil, err
+	}
+	maxDailyValue, err := parse("top-up daily limit", maxDaily)
+	if err != nil {
+		return nil, err
+	}
+
+	return chequebook.NewTopUpWatcher(logger, chequebookService, stateStore, floorValue, amountValue, maxDailyValue, chequebook.DefaultTopUpInterval)
+}
+
+// InitPostageAutoDilute creates the AutoDiluter of the owned batches.
+func InitPostageAutoDilute(
+	logger log.Logger,
+	post postage.Service,
+	diluter postage.BatchDiluter,
+	gasPricer postage.GasPriceSuggester,
+	threshold, maxDepth uint,
+	maxGasPrice string,
+	gasLimit uint64,
+) (*postage.AutoDiluter, error) {
+	if maxDepth > math.MaxUint8 {
+		return nil, fmt.Errorf("auto-dilute max depth %d out of range", maxDepth)
+	}
+
+	policy := postage.AutoDilutePolicy{
+		Threshold: threshold,
+		MaxDepth:  uint8(maxDepth),
+		GasLimit:  gasLimit,
+	}
+
+	maxGasPriceValue, ok := new(big.Int).SetString(maxGasPrice, 10)
+	if !ok {
+		return nil, fmt.Errorf("auto-dilute max gas price \"%s\" cannot be parsed", maxGasPrice)
+	}
+	if maxGasPriceValue.Sign() != 0 {
+		policy.MaxGasPrice = maxGasPriceValue
+	}
+
+	return postage.NewAutoDiluter(logger, post, diluter, gasPricer, policy, postage.DefaultAutoDiluteInterval)
+}
+
+// InitPostageSnapshotImport bootstraps the empty batch store from the
+// snapshot file exported by a trusted node.
+func InitPostageSnapshotImport(
+	ctx context.Context,
+	logger log.Logger,
+	batchStore postage.Storer,
+	backend transaction.Backend,
+	path string,
+) error {
+	snapshotter, ok := batchStore.(postage.BatchSnapshotter)
+	if !ok {
+		return errors.New("batch store does not support snapshots")
+	}
+
+	chainHead, err := backend.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("get chain head: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open postage snapshot: %w", err)
+	}
+	defer f.Close()
+
+	if err := snapshotter.ImportSnapshot(f, chainHead); err != nil {
+		return fmt.Errorf("import postage snapshot: %w", err)
+	}
+
+	logger.Info("postage state bootstrapped from snapshot", "path", path, "block", batchStore.GetChainState().Block, "chain_head", chainHead)
+	return nil
+}
+
+// InitChequeDomains records the given cheque domain and returns the legacy
+// domains received cheques may be signed in. These are the domains of
+// previously used chains and versions together with the configured ones,
+// whose version defaults to the version of the given domain.
+func InitChequeDomains(
+	logger log.Logger,
+	stateStore storage.StateStorer,
+	chequeDomain chequebook.ChequeDomain,
+	configured []string,
+) ([]chequebook.ChequeDomain, error) {
+	legacyDomains, err := chequebook.MigrateChequeDomain(logger, stateStore, chequeDomain)
+	if err != nil {
+		return nil, fmt.Errorf("cheque domain migration: %w", err)
+	}
+
+	for _, c := range configured {
+		domain, err := chequebook.ParseChequeDomain(c, chequeDomain.Version)
+		if err != nil {
+			return nil, err
+		}
+		legacyDomains = append(legacyDomains, domain)
+	}
+
+	return legacyDomains, nil
+}
+
 func initChequeStoreCashout(
 	stateStore storage.StateStorer,
 	swapBackend transaction.Backend,
 	chequebookFactory chequebook.Factory,
-	chainID int64,
+	chequeDomain chequebook.ChequeDomain,
 	overlayEthAddress common.Address,
 	transactionService transaction.Service,
+	legacyDomains []chequebook.ChequeDomain,
 ) (chequebook.ChequeStore, chequebook.CashoutService) {
 	chequeStore := chequebook.NewChequeStore(
 		stateStore,
 		chequebookFactory,
-		chainID,
+		chequeDomain,
 		overlayEthAddress,
 		transactionService,
 		chequebook.RecoverCheque,
+		legacyDomains...,
 	)
 
 	cashout := chequebook.NewCashoutService(
@@ -201,6 +405,7 @@ func InitSwap(
 	cashoutService chequebook.CashoutService,
 	accounting settlement.Accounting,
 	priceOracleAddress string,
+	rateTolerance uint64,
 	chainID int64,
 	transactionService transaction.Service,
 ) (*swap.Service, priceoracle.Service, error) {
@@ -218,6 +423,9 @@ func InitSwap(
 	priceOracle := priceoracle.New(logger, currentPriceOracleAddress, transactionService, 300)
 	priceOracle.Start()
 	swapProtocol := swapprotocol.New(p2ps, logger, overlayEthAddress, priceOracle)
+	if err := swapProtocol.SetRateTolerance(rateTolerance); err != nil {
+		return nil, nil, err
+	}
 	swapAddressBook := swap.NewAddressbook(stateStore)
 
 	cashoutAddress := overlayEthAddress
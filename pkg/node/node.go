@@ -50,9 +50,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/pullsync"
 	"github.com/calmw/bee-tron/pkg/pusher"
 	"github.com/calmw/bee-tron/pkg/pushsync"
+	"github.com/calmw/bee-tron/pkg/reachability"
 	"github.com/calmw/bee-tron/pkg/resolver/multiresolver"
 	"github.com/calmw/bee-tron/pkg/retrieval"
 	"github.com/calmw/bee-tron/pkg/salud"
+	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
 	"github.com/calmw/bee-tron/pkg/settlement/swap"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
@@ -60,6 +62,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/settlement/swap/priceoracle"
 	"github.com/calmw/bee-tron/pkg/status"
 	"github.com/calmw/bee-tron/pkg/steward"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storageincentives"
 	"github.com/calmw/bee-tron/pkg/storageincentives/redistribution"
 	"github.com/calmw/bee-tron/pkg/storageincentives/staking"
@@ -111,9 +114,16 @@ type Bee struct {
 	transactionCloser        io.Closer
 	listenerCloser           io.Closer
 	postageServiceCloser     io.Closer
+	postageExpiryCloser      io.Closer
+	postageDiluteCloser      io.Closer
 	priceOracleCloser        io.Closer
+	swapWebhookCloser        io.Closer
+	chequebookTopUpCloser    io.Closer
+	swapAddressbookCloser    io.Closer
+	swapCashoutCloser        io.Closer
 	hiveCloser               io.Closer
 	saludCloser              io.Closer
+	reachabilityCloser       io.Closer
 	storageIncetivesCloser   io.Closer
 	pushSyncCloser           io.Closer
 	retrievalCloser          io.Closer
@@ -126,10 +136,28 @@ type Bee struct {
 type Options struct {
 	DataDir                       string
 	CacheCapacity                 uint64
+	CacheCapacityBytes            uint64
+	CacheEvictionPolicy           string
+	CacheGatewayURL               string
 	DBOpenFilesLimit              uint64
 	DBWriteBufferSize             uint64
 	DBBlockCacheCapacity          uint64
 	DBDisableSeeksCompaction      bool
+	DBBloomFilterBits             int
+	DBCompactionTableSize         uint64
+	DBCompactionTotalSize         uint64
+	DBCompression                 string
+	DBIndexStore                  string
+	DBPebbleCacheCapacity         uint64
+	DBPebbleMemTableSize          uint64
+	DBPebbleOpenFilesLimit        uint64
+	DBCompactionInterval          time.Duration
+	DBCompactionThrottle          time.Duration
+	DBMigrationDryRun             bool
+	PinningQuota                  uint64
+	UsageForecastInterval         time.Duration
+	UsageAlertWebhook             string
+	UsageAlertThreshold           time.Duration
 	APIAddr                       string
 	Addr                          string
 	NATAddr                       string
@@ -148,13 +176,30 @@ type Options struct {
 	RetrievalCaching              bool
 	BootnodeMode                  bool
 	BlockchainRpcEndpoint         string
+	BlockchainRpcFallbacks        []string
+	BlockchainRpcCheckInterval    time.Duration
+	BlockchainRpcSubscribeHeads   bool
+	ChainSigner                   crypto.Signer // signs transactions and cheques, the node signer if nil
 	SwapFactoryAddress            string
 	SwapInitialDeposit            string
 	SwapEnable                    bool
+	SwapRateTolerance             uint64
+	SwapWebhookURLs               []string
+	SwapWebhookSecret             string
+	SwapTopUpFloor                string
+	SwapTopUpAmount               string
+	SwapTopUpMaxDaily             string
+	SwapAddressbookPruneAge       time.Duration
+	SwapLegacyChequeDomains       []string
+	SwapChequeDomainVersion       string
 	ChequebookEnable              bool
 	FullNodeMode                  bool
 	PostageContractAddress        string
 	PostageContractStartBlock     uint64
+	PostageAutoDiluteThreshold    uint
+	PostageAutoDiluteMaxDepth     uint
+	PostageAutoDiluteMaxGasPrice  string
+	PostageAutoDiluteGasLimit     uint64
 	StakingContractAddress        string
 	PriceOracleAddress            string
 	RedistributionContractAddress string
@@ -167,14 +212,54 @@ type Options struct {
 	StaticNodes                   []swarm.Address
 	AllowPrivateCIDRs             bool
 	UsePostageSnapshot            bool
+	PostageSnapshotFile           string
 	EnableStorageIncentives       bool
 	StatestoreCacheCapacity       uint64
 	TargetNeighborhood            string
 	NeighborhoodSuggester         string
 	WhitelistedWithdrawalAddress  []string
 	TrxDebugMode                  bool
+	TrxResubmitTimeout            time.Duration
+	TrxResubmitMaxFee             string
 	MinimumStorageRadius          uint
 	ReserveCapacityDoubling       int
+	ReserveSampleWorkers          int
+	StorageRadiusPin              int
+	StorageRadiusOffset           int
+	StaticPeers                   []string
+	HealthCheckInterval           time.Duration
+	HealthCheckTimeout            time.Duration
+	HealthCheckFailureThreshold   int
+	SaturationPeers               int
+	OverbookingFactor             float64
+	PruneInterval                 time.Duration
+	BalancingStrategy             string
+	MisbehaviorThreshold          float64
+	MisbehaviorBlockDuration      time.Duration
+	MisbehaviorPermanentAfter     int
+	RetrievalHedgeDelay           time.Duration
+	RetrievalMaxAttempts          int
+	RetrievalAttemptTimeout       time.Duration
+	RetrievalRetryInterval        time.Duration
+	RetrievalBackoff              []time.Duration
+	RetrievalNotFoundTTL          time.Duration
+	RetrievalSelectionCandidates  int
+	RetrievalPriceWeight          float64
+	RetrievalLatencyWeight        float64
+	DownloadReadAhead             int
+	PusherWorkers                 int
+	PusherPeerInflight            int
+	PusherQueueCapacity           int
+	PushSyncReceiptMode           string
+	PusherDeadLetterAttempts      int
+	PusherRedriveInterval         time.Duration
+	PullerChunksPerSecond         int
+	PullerPeerChunksPerSecond     int
+	PullSyncBandwidth             int
+	PullSyncPeerBandwidth         int
+	PullerIntervalPruneAge        time.Duration
+	PullerRadiusMargin            int
+	PullerLiveOnlyBins            string
 }
 
 const (
@@ -187,10 +272,14 @@ const (
 	minPaymentThreshold           = 2 * refreshRate           // minimal accepted payment threshold of full nodes
 	maxPaymentThreshold           = 24 * refreshRate          // maximal accepted payment threshold of full nodes
 	mainnetNetworkID              = uint64(1)                 //
+	swapAddressbookPruneInterval  = time.Hour                 // interval at which stale swap addressbook entries are pruned
+	swapCashoutWatchInterval      = 30 * time.Second          // interval at which the status of the submitted cashouts is checked
 	reserveWakeUpDuration         = 15 * time.Minute          // time to wait before waking up reserveWorker
 	reserveMinEvictCount          = 1_000
 	cacheMinEvictCount            = 10_000
 	maxAllowedDoubling            = 1
+	bzzDecimals                   = 16      // decimals of the BZZ token
+	validStampCacheSize           = 100_000 // recently validated stamps kept to skip signature recovery
 )
 
 func NewBee(
@@ -260,6 +349,21 @@ func NewBee(
 	}
 	shallowReceiptTolerance := maxAllowedDoubling - o.ReserveCapacityDoubling
 
+	receiptMode := pushsync.DefaultReceiptMode
+	if o.PushSyncReceiptMode != "" {
+		if receiptMode, err = pushsync.ParseReceiptMode(o.PushSyncReceiptMode); err != nil {
+			return nil, fmt.Errorf("pushsync: %w", err)
+		}
+	}
+
+	if o.PullerRadiusMargin < 0 || o.PullerRadiusMargin > int(swarm.MaxPO) {
+		return nil, fmt.Errorf("config puller radius margin has to be between 0 and %d", swarm.MaxPO)
+	}
+	binPolicy := puller.BinPolicy{RadiusMargin: uint8(o.PullerRadiusMargin)}
+	if binPolicy.LiveOnlyBins, err = puller.ParseBins(o.PullerLiveOnlyBins); err != nil {
+		return nil, fmt.Errorf("puller live only bins: %w", err)
+	}
+
 	reserveCapacity := (1 << o.ReserveCapacityDoubling) * storer.DefaultReserveCapacity
 
 	stateStore, stateStoreMetrics, err := InitStateStore(logger, o.DataDir, o.StatestoreCacheCapacity)
@@ -360,6 +464,7 @@ func NewBee(
 		chequeStore        chequebook.ChequeStore
 		cashoutService     chequebook.CashoutService
 		erc20Service       erc20.Service
+		tokenRegistry      *transaction.TokenRegistry
 	)
 
 	chainEnabled := isChainEnabled(o, o.BlockchainRpcEndpoint, logger)
@@ -381,15 +486,25 @@ func NewBee(
 		}
 	}
 
+	chainSigner := signer
+	if o.ChainSigner != nil {
+		chainSigner = o.ChainSigner
+	}
+
 	chainBackend, overlayEthAddress, chainID, transactionMonitor, transactionService, err = InitChain(
 		ctx,
 		logger,
 		stateStore,
 		o.BlockchainRpcEndpoint,
 		o.ChainID,
-		signer,
+		chainSigner,
 		o.BlockTime,
-		chainEnabled)
+		chainEnabled,
+		o.BlockchainRpcFallbacks,
+		o.BlockchainRpcCheckInterval,
+		o.BlockchainRpcSubscribeHeads,
+		o.TrxResubmitTimeout,
+		o.TrxResubmitMaxFee)
 	if err != nil {
 		return nil, fmt.Errorf("init chain: %w", err)
 	}
@@ -428,6 +543,8 @@ func NewBee(
 	}
 	b.stamperStoreCloser = stamperStore
 
+	migrationProgress := migration.NewProgress(logger, o.DBMigrationDryRun)
+
 	var apiService *api.Service
 
 	if o.APIAddr != "" {
@@ -461,6 +578,7 @@ func NewBee(
 
 		apiService.Mount()
 		apiService.SetProbe(probe)
+		apiService.SetMigrationProgress(migrationProgress)
 		apiService.SetIsWarmingUp(true)
 		apiService.SetSwarmAddress(&swarmAddress)
 
@@ -498,6 +616,8 @@ func NewBee(
 		}
 	}
 
+	tokenRegistry = transaction.NewTokenRegistry(transactionService)
+
 	if o.SwapEnable {
 		chequebookFactory, err = InitChequebookFactory(logger, chainBackend, chainID, transactionService, o.SwapFactoryAddress)
 		if err != nil {
@@ -510,14 +630,21 @@ func NewBee(
 		}
 
 		erc20Service = erc20.New(transactionService, erc20Address)
+		tokenRegistry.Register(transaction.Token{Symbol: "BZZ", Address: erc20Address, Decimals: bzzDecimals})
+
+		chequeDomain := chequebook.DefaultChequeDomain(chainID)
+		if o.SwapChequeDomainVersion != "" {
+			chequeDomain.Version = o.SwapChequeDomainVersion
+		}
 
 		if o.ChequebookEnable && chainEnabled {
 			chequebookService, err = InitChequebookService(
 				ctx,
 				logger,
 				stateStore,
-				signer,
+				chainSigner,
 				chainID,
+				chequeDomain,
 				chainBackend,
 				overlayEthAddress,
 				transactionService,
@@ -528,15 +655,32 @@ func NewBee(
 			if err != nil {
 				return nil, fmt.Errorf("init chequebook service: %w", err)
 			}
+
+			var topUp *chequebook.TopUpWatcher
+			topUp, err = InitChequebookTopUp(logger, chequebookService, stateStore, o.SwapTopUpFloor, o.SwapTopUpAmount, o.SwapTopUpMaxDaily)
+			if err != nil {
+				return nil, fmt.Errorf("init chequebook top-up: %w", err)
+			}
+			if topUp != nil {
+				topUp.Start()
+				b.chequebookTopUpCloser = topUp
+			}
+		}
+
+		var legacyDomains []chequebook.ChequeDomain
+		legacyDomains, err = InitChequeDomains(logger, stateStore, chequeDomain, o.SwapLegacyChequeDomains)
+		if err != nil {
+			return nil, fmt.Errorf("init cheque domains: %w", err)
 		}
 
 		chequeStore, cashoutService = initChequeStoreCashout(
 			stateStore,
 			chainBackend,
 			chequebookFactory,
-			chainID,
+			chequeDomain,
 			overlayEthAddress,
 			transactionService,
+			legacyDomains,
 		)
 	}
 
@@ -555,6 +699,15 @@ func NewBee(
 		bootnodes = append(bootnodes, addr)
 	}
 
+	staticPeers := make([]ma.Multiaddr, 0, len(o.StaticPeers))
+	for _, a := range o.StaticPeers {
+		addr, err := ma.NewMultiaddr(a)
+		if err != nil {
+			return nil, fmt.Errorf("static peer multiaddress %q: %w", a, err)
+		}
+		staticPeers = append(staticPeers, addr)
+	}
+
 	// Perform checks related to payment threshold calculations here to not duplicate
 	// the checks in bootstrap process
 	paymentThreshold, ok := new(big.Int).SetString(o.PaymentThreshold, 10)
@@ -620,6 +773,7 @@ func NewBee(
 		Nonce:           nonce,
 		ValidateOverlay: chainEnabled,
 		Registry:        registry,
+		StaticPeers:     staticPeers,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("p2p service: %w", err)
@@ -659,6 +813,7 @@ func NewBee(
 	}
 
 	postageStampContractABI := abiutil.MustParseABI(chainCfg.PostageStampABI)
+	transactionService.RegisterABI(postageStampContractABI)
 
 	bzzTokenAddress, err := postagecontract.LookupERC20Address(ctx, transactionService, postageStampContractAddress, postageStampContractABI, chainEnabled)
 	if err != nil {
@@ -677,7 +832,23 @@ func NewBee(
 		o.TrxDebugMode,
 	)
 
-	eventListener = listener.New(b.syncingStopped, logger, chainBackend, postageStampContractAddress, postageStampContractABI, o.BlockTime, postageSyncingStallingTimeout, postageSyncingBackoffTimeout)
+	expiryWatcher := postage.NewExpiryWatcher(logger, post, batchStore, stateStore, postageStampContractService, o.BlockTime)
+	if chainEnabled {
+		expiryWatcher.Start(o.BlockTime)
+	}
+	b.postageExpiryCloser = expiryWatcher
+
+	if chainEnabled && o.PostageAutoDiluteThreshold != 0 {
+		var autoDiluter *postage.AutoDiluter
+		autoDiluter, err = InitPostageAutoDilute(logger, post, postageStampContractService, chainBackend, o.PostageAutoDiluteThreshold, o.PostageAutoDiluteMaxDepth, o.PostageAutoDiluteMaxGasPrice, o.PostageAutoDiluteGasLimit)
+		if err != nil {
+			return nil, fmt.Errorf("init postage auto-dilute: %w", err)
+		}
+		autoDiluter.Start()
+		b.postageDiluteCloser = autoDiluter
+	}
+
+	eventListener = listener.New(b.syncingStopped, logger, chainBackend, postageStampContractAddress, postageStampContractABI, o.BlockTime, postageSyncingStallingTimeout, postageSyncingBackoffTimeout, o.BlockchainRpcSubscribeHeads)
 	b.listenerCloser = eventListener
 
 	batchSvc, err = batchservice.New(stateStore, batchStore, logger, eventListener, overlayEthAddress.Bytes(), post, sha3.New256, o.Resync)
@@ -702,7 +873,24 @@ func NewBee(
 	var swapService *swap.Service
 
 	kad, err := kademlia.New(swarmAddress, addressbook, hive, p2ps, logger,
-		kademlia.Options{Bootnodes: bootnodes, BootnodeMode: o.BootnodeMode, StaticNodes: o.StaticNodes, DataDir: o.DataDir})
+		kademlia.Options{
+			Bootnodes:                   bootnodes,
+			BootnodeMode:                o.BootnodeMode,
+			StaticNodes:                 o.StaticNodes,
+			StaticPeers:                 staticPeers,
+			DataDir:                     o.DataDir,
+			Pinger:                      pingPong,
+			HealthCheckInterval:         &o.HealthCheckInterval,
+			HealthCheckTimeout:          &o.HealthCheckTimeout,
+			HealthCheckFailureThreshold: &o.HealthCheckFailureThreshold,
+			SaturationPeers:             &o.SaturationPeers,
+			OverbookingFactor:           &o.OverbookingFactor,
+			PruneWakeup:                 &o.PruneInterval,
+			BalancingStrategy:           kademlia.BalancingStrategy(o.BalancingStrategy),
+			MisbehaviorThreshold:        &o.MisbehaviorThreshold,
+			MisbehaviorBlockDuration:    &o.MisbehaviorBlockDuration,
+			MisbehaviorPermanentAfter:   &o.MisbehaviorPermanentAfter,
+		})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create kademlia: %w", err)
 	}
@@ -711,6 +899,12 @@ func NewBee(
 	hive.SetAddPeersHandler(kad.AddPeers)
 	p2ps.SetPickyNotifier(kad)
 
+	reachabilityService := reachability.New(p2ps, p2ps.Addresses, addressbook, kad, p2ps, logger, reachability.Options{})
+	if err = p2ps.AddProtocol(reachabilityService.Protocol()); err != nil {
+		return nil, fmt.Errorf("reachability service: %w", err)
+	}
+	b.reachabilityCloser = reachabilityService
+
 	var path string
 
 	if o.DataDir != "" {
@@ -721,10 +915,27 @@ func NewBee(
 	lo := &storer.Options{
 		Address:                   swarmAddress,
 		CacheCapacity:             o.CacheCapacity,
+		CacheCapacityBytes:        o.CacheCapacityBytes,
+		CacheEvictionPolicy:       o.CacheEvictionPolicy,
 		LdbOpenFilesLimit:         o.DBOpenFilesLimit,
 		LdbBlockCacheCapacity:     o.DBBlockCacheCapacity,
 		LdbWriteBufferSize:        o.DBWriteBufferSize,
 		LdbDisableSeeksCompaction: o.DBDisableSeeksCompaction,
+		LdbBloomFilterBits:        o.DBBloomFilterBits,
+		LdbCompactionTableSize:    o.DBCompactionTableSize,
+		LdbCompactionTotalSize:    o.DBCompactionTotalSize,
+		LdbCompression:            o.DBCompression,
+		IndexStore:                o.DBIndexStore,
+		PebbleCacheCapacity:       o.DBPebbleCacheCapacity,
+		PebbleMemTableSize:        o.DBPebbleMemTableSize,
+		PebbleOpenFilesLimit:      o.DBPebbleOpenFilesLimit,
+		SharkyCompactionInterval:  o.DBCompactionInterval,
+		SharkyCompactionThrottle:  o.DBCompactionThrottle,
+		MigrationProgress:         migrationProgress,
+		PinningQuota:              o.PinningQuota,
+		UsageForecastInterval:     o.UsageForecastInterval,
+		UsageAlertWebhook:         o.UsageAlertWebhook,
+		UsageAlertThreshold:       o.UsageAlertThreshold,
 		Batchstore:                batchStore,
 		StateStore:                stateStore,
 		RadiusSetter:              kad,
@@ -735,6 +946,10 @@ func NewBee(
 		MinimumStorageRadius:      o.MinimumStorageRadius,
 	}
 
+	if o.CacheGatewayURL != "" {
+		lo.RemoteGetter = storer.NewGatewayGetter(o.CacheGatewayURL)
+	}
+
 	if o.FullNodeMode && !o.BootnodeMode {
 		// configure reserve only for full node
 		lo.ReserveCapacity = reserveCapacity
@@ -742,6 +957,12 @@ func NewBee(
 		lo.ReserveMinEvictCount = reserveMinEvictCount
 		lo.RadiusSetter = kad
 		lo.ReserveCapacityDoubling = o.ReserveCapacityDoubling
+		lo.ReserveSampleWorkers = o.ReserveSampleWorkers
+		lo.RadiusOverride = storer.RadiusOverride{Offset: o.StorageRadiusOffset}
+		if o.StorageRadiusPin >= 0 {
+			lo.RadiusOverride.Pinned = true
+			lo.RadiusOverride.Radius = uint8(o.StorageRadiusPin)
+		}
 	}
 
 	localStore, err := storer.New(ctx, path, lo)
@@ -777,6 +998,21 @@ func NewBee(
 		}
 	)
 
+	if o.PostageSnapshotFile != "" && chainEnabled {
+		switch {
+		case batchStoreExists:
+			logger.Warning("postage snapshot file ignored as the batch store is not empty", "path", o.PostageSnapshotFile)
+		case o.Resync:
+			logger.Warning("postage snapshot file ignored as a resync is requested", "path", o.PostageSnapshotFile)
+		default:
+			if err := InitPostageSnapshotImport(ctx, logger, batchStore, chainBackend, o.PostageSnapshotFile); err != nil {
+				return nil, fmt.Errorf("init postage snapshot: %w", err)
+			}
+			// the imported state supersedes the snapshot of the network
+			initBatchState = nil
+		}
+	}
+
 	if batchSvc != nil && chainEnabled {
 		logger.Info("waiting to sync postage contract data, this may take a while... more info available in Debug loglevel")
 
@@ -869,6 +1105,10 @@ func NewBee(
 	}
 	b.accountingCloser = acc
 
+	settlementPolicies := settlement.NewPolicyStore(stateStore)
+	settlementPolicies.SetObserver(acc)
+	acc.SetPolicyStore(settlementPolicies)
+
 	pseudosettleService := pseudosettle.New(p2ps, logger, stateStore, acc, new(big.Int).Set(enforcedRefreshRate), big.NewInt(lightRefreshRate), p2ps)
 	if err = p2ps.AddProtocol(pseudosettleService.Protocol()); err != nil {
 		return nil, fmt.Errorf("pseudosettle service: %w", err)
@@ -889,6 +1129,7 @@ func NewBee(
 			cashoutService,
 			acc,
 			o.PriceOracleAddress,
+			o.SwapRateTolerance,
 			chainID,
 			transactionService,
 		)
@@ -896,6 +1137,31 @@ func NewBee(
 			return nil, fmt.Errorf("init swap service: %w", err)
 		}
 		b.priceOracleCloser = priceOracle
+		swapService.SetPolicyStore(settlementPolicies)
+
+		if len(o.SwapWebhookURLs) > 0 {
+			webhooks := swap.NewWebhookDispatcher(logger, o.SwapWebhookURLs, o.SwapWebhookSecret)
+			swapService.SetEventNotifier(webhooks)
+			b.swapWebhookCloser = webhooks
+		}
+
+		cashoutWatcher := swap.NewCashoutWatcher(swapService, swapCashoutWatchInterval)
+		cashoutWatcher.Start()
+		b.swapCashoutCloser = cashoutWatcher
+
+		if o.SwapAddressbookPruneAge > 0 {
+			connected := func() []swarm.Address {
+				peers := p2ps.Peers()
+				addrs := make([]swarm.Address, 0, len(peers))
+				for _, p := range peers {
+					addrs = append(addrs, p.Address)
+				}
+				return addrs
+			}
+			pruner := swap.NewAddressbookPruner(logger, swapService.Addressbook(), swapService.HasUncashedCheques, connected, o.SwapAddressbookPruneAge, swapAddressbookPruneInterval)
+			pruner.Start()
+			b.swapAddressbookCloser = pruner
+		}
 
 		if o.ChequebookEnable {
 			acc.SetPayFunc(swapService.Pay)
@@ -909,7 +1175,10 @@ func NewBee(
 	b.pssCloser = pssService
 	b.gsocCloser = gsocService
 
-	validStamp := postage.ValidStamp(batchStore)
+	validStamp, err := postage.CachedValidStamp(batchStore, validStampCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("valid stamp cache: %w", err)
+	}
 
 	// metrics exposed on the status protocol
 	statusMetricsRegistry := prometheus.NewRegistry()
@@ -969,23 +1238,51 @@ func NewBee(
 		}
 	}
 
-	pushSyncProtocol := pushsync.New(swarmAddress, networkID, nonce, p2ps, localStore, waitNetworkRFunc, kad, o.FullNodeMode && !o.BootnodeMode, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, acc, pricer, signer, tracer, warmupTime, uint8(shallowReceiptTolerance))
+	pushSyncProtocol := pushsync.New(swarmAddress, networkID, nonce, p2ps, localStore, waitNetworkRFunc, kad, o.FullNodeMode && !o.BootnodeMode, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, acc, pricer, signer, tracer, warmupTime, uint8(shallowReceiptTolerance), receiptMode)
 	b.pushSyncCloser = pushSyncProtocol
 
 	// set the pushSyncer in the PSS
 	pssService.SetPushSyncer(pushSyncProtocol)
 
-	retrieval := retrieval.New(swarmAddress, waitNetworkRFunc, localStore, p2ps, kad, logger, acc, pricer, tracer, o.RetrievalCaching)
+	retrieval := retrieval.New(swarmAddress, waitNetworkRFunc, localStore, p2ps, kad, logger, acc, pricer, tracer, retrieval.Options{
+		Caching:        o.RetrievalCaching,
+		HedgeDelay:     o.RetrievalHedgeDelay,
+		MaxAttempts:    o.RetrievalMaxAttempts,
+		AttemptTimeout: o.RetrievalAttemptTimeout,
+		RetryInterval:  o.RetrievalRetryInterval,
+		Backoff:        o.RetrievalBackoff,
+		NotFoundTTL:    o.RetrievalNotFoundTTL,
+
+		SelectionCandidates: o.RetrievalSelectionCandidates,
+		Cost:                retrieval.LinearCost(o.RetrievalPriceWeight, o.RetrievalLatencyWeight),
+
+		StateStore: stateStore,
+	})
+	b.retrievalCloser = retrieval
 	localStore.SetRetrievalService(retrieval)
 
 	statusMetricsRegistry.MustRegister(retrieval.StatusMetrics()...)
 
-	pusherService := pusher.New(networkID, localStore, pushSyncProtocol, batchStore, logger, warmupTime, pusher.DefaultRetryCount)
+	pusherService := pusher.New(networkID, localStore, pushSyncProtocol, batchStore, logger, warmupTime, pusher.DefaultRetryCount, kad, pusher.Limits{
+		Workers:       o.PusherWorkers,
+		PeerInflight:  o.PusherPeerInflight,
+		QueueCapacity: o.PusherQueueCapacity,
+	}, pusher.DeadLetterOptions{
+		StateStore:      stateStore,
+		Attempts:        o.PusherDeadLetterAttempts,
+		RedriveInterval: o.PusherRedriveInterval,
+	})
 	b.pusherCloser = pusherService
 
+	statusMetricsRegistry.MustRegister(pushSyncProtocol.StatusMetrics()...)
+	statusMetricsRegistry.MustRegister(pusherService.StatusMetrics()...)
+
 	pusherService.AddFeed(localStore.PusherFeed())
 
-	pullSyncProtocol := pullsync.New(p2ps, localStore, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, pullsync.DefaultMaxPage)
+	pullSyncProtocol := pullsync.New(p2ps, localStore, pssService.TryUnwrap, gsocService.Handle, validStamp, logger, pullsync.DefaultMaxPage, pullsync.Budget{
+		Bandwidth:     o.PullSyncBandwidth,
+		PeerBandwidth: o.PullSyncPeerBandwidth,
+	})
 	b.pullSyncCloser = pullSyncProtocol
 
 	retrieveProtocolSpec := retrieval.Protocol()
@@ -1027,7 +1324,10 @@ func NewBee(
 		stakingContractAddress = common.HexToAddress(o.StakingContractAddress)
 	}
 
-	stakingContract := staking.New(overlayEthAddress, stakingContractAddress, abiutil.MustParseABI(chainCfg.StakingABI), bzzTokenAddress, transactionService, common.BytesToHash(nonce), o.TrxDebugMode, uint8(o.ReserveCapacityDoubling))
+	stakingContractABI := abiutil.MustParseABI(chainCfg.StakingABI)
+	transactionService.RegisterABI(stakingContractABI)
+
+	stakingContract := staking.New(overlayEthAddress, stakingContractAddress, stakingContractABI, bzzTokenAddress, transactionService, common.BytesToHash(nonce), o.TrxDebugMode, uint8(o.ReserveCapacityDoubling))
 
 	if chainEnabled {
 
@@ -1070,7 +1370,12 @@ func NewBee(
 	)
 
 	if o.FullNodeMode && !o.BootnodeMode {
-		pullerService = puller.New(swarmAddress, stateStore, kad, localStore, pullSyncProtocol, p2ps, logger, puller.Options{})
+		pullerService = puller.New(swarmAddress, stateStore, kad, localStore, pullSyncProtocol, p2ps, logger, puller.Options{
+			ChunksPerSecond:     o.PullerChunksPerSecond,
+			PeerChunksPerSecond: o.PullerPeerChunksPerSecond,
+			IntervalPruneAge:    o.PullerIntervalPruneAge,
+			BinPolicy:           &binPolicy,
+		})
 		b.pullerCloser = pullerService
 
 		localStore.StartReserveWorker(ctx, pullerService, waitNetworkRFunc)
@@ -1086,7 +1391,10 @@ func NewBee(
 				redistributionContractAddress = common.HexToAddress(o.RedistributionContractAddress)
 			}
 
-			redistributionContract := redistribution.New(swarmAddress, overlayEthAddress, logger, transactionService, redistributionContractAddress, abiutil.MustParseABI(chainCfg.RedistributionABI), o.TrxDebugMode)
+			redistributionContractABI := abiutil.MustParseABI(chainCfg.RedistributionABI)
+			transactionService.RegisterABI(redistributionContractABI)
+
+			redistributionContract := redistribution.New(swarmAddress, overlayEthAddress, logger, transactionService, redistributionContractAddress, redistributionContractABI, o.TrxDebugMode)
 
 			startWarmupPeriod := time.Now()
 			isFullySynced := func() bool {
@@ -1131,27 +1439,43 @@ func NewBee(
 	steward := steward.New(localStore, retrieval, localStore.Cache())
 
 	extraOpts := api.ExtraOptions{
-		Pingpong:        pingPong,
-		TopologyDriver:  kad,
-		LightNodes:      lightNodes,
-		Accounting:      acc,
-		Pseudosettle:    pseudosettleService,
-		Swap:            swapService,
-		Chequebook:      chequebookService,
-		BlockTime:       o.BlockTime,
-		Storer:          localStore,
-		Resolver:        multiResolver,
-		Pss:             pssService,
-		Gsoc:            gsocService,
-		FeedFactory:     feedFactory,
-		Post:            post,
-		AccessControl:   accesscontrol,
-		PostageContract: postageStampContractService,
-		Staking:         stakingContract,
-		Steward:         steward,
-		SyncStatus:      syncStatusFn,
-		NodeStatus:      nodeStatus,
-		PinIntegrity:    localStore.PinIntegrity(),
+		Pingpong:               pingPong,
+		TopologyDriver:         kad,
+		LightNodes:             lightNodes,
+		Accounting:             acc,
+		Pseudosettle:           pseudosettleService,
+		Swap:                   swapService,
+		Chequebook:             chequebookService,
+		BlockTime:              o.BlockTime,
+		Storer:                 localStore,
+		Resolver:               multiResolver,
+		Pss:                    pssService,
+		Gsoc:                   gsocService,
+		FeedFactory:            feedFactory,
+		Post:                   post,
+		AccessControl:          accesscontrol,
+		PostageContract:        postageStampContractService,
+		Staking:                stakingContract,
+		Steward:                steward,
+		SyncStatus:             syncStatusFn,
+		NodeStatus:             nodeStatus,
+		PinIntegrity:           localStore.PinIntegrity(),
+		SettlementPolicies:     settlementPolicies,
+		PseudosettleAllowances: pseudosettleService,
+		RetrievalSkiplist:      retrieval,
+		Pusher:                 pusherService,
+		PusherDeadLetters:      pusherService,
+		Tokens:                 tokenRegistry,
+		ExpiryWatcher:          expiryWatcher,
+	}
+
+	if pullerService != nil {
+		extraOpts.SyncState = pullerService
+	}
+
+	if swapService != nil {
+		extraOpts.SwapAddressbook = swapService.Addressbook()
+		extraOpts.SwapDeductions = swapService.Deductions()
 	}
 
 	if o.APIAddr != "" {
@@ -1162,6 +1486,7 @@ func NewBee(
 		apiService.MustRegisterMetrics(localStore.Metrics()...)
 		apiService.MustRegisterMetrics(kad.Metrics()...)
 		apiService.MustRegisterMetrics(saludService.Metrics()...)
+		apiService.MustRegisterMetrics(reachabilityService.Metrics()...)
 		apiService.MustRegisterMetrics(stateStoreMetrics.Metrics()...)
 
 		if pullerService != nil {
@@ -1203,6 +1528,7 @@ func NewBee(
 		apiService.Configure(signer, tracer, api.Options{
 			CORSAllowedOrigins: o.CORSAllowedOrigins,
 			WsPingPeriod:       60 * time.Second,
+			ReadAhead:          o.DownloadReadAhead,
 		}, extraOpts, chainID, erc20Service)
 
 		apiService.EnableFullAPI()
@@ -1281,7 +1607,7 @@ func (b *Bee) Shutdown() error {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(8)
+	wg.Add(9)
 	go func() {
 		defer wg.Done()
 		tryClose(b.pssCloser, "pss")
@@ -1316,11 +1642,20 @@ func (b *Bee) Shutdown() error {
 		defer wg.Done()
 		tryClose(b.saludCloser, "salud")
 	}()
+	go func() {
+		defer wg.Done()
+		tryClose(b.reachabilityCloser, "reachability")
+	}()
 
 	wg.Wait()
 
 	tryClose(b.p2pService, "p2p server")
+	tryClose(b.retrievalCloser, "retrieval")
 	tryClose(b.priceOracleCloser, "price oracle service")
+	tryClose(b.swapCashoutCloser, "swap cashout watcher")
+	tryClose(b.swapWebhookCloser, "swap webhooks")
+	tryClose(b.chequebookTopUpCloser, "chequebook top-up")
+	tryClose(b.swapAddressbookCloser, "swap addressbook pruner")
 
 	wg.Add(3)
 	go func() {
@@ -1334,6 +1669,8 @@ func (b *Bee) Shutdown() error {
 	}()
 	go func() {
 		defer wg.Done()
+		tryClose(b.postageExpiryCloser, "postage expiry watcher")
+		tryClose(b.postageDiluteCloser, "postage auto-dilute")
 		tryClose(b.postageServiceCloser, "postage service")
 	}()
 
@@ -88,6 +88,11 @@ type Service struct {
 	natAddrResolver   *staticAddressResolver
 	autonatDialer     host.Host
 	pingDialer        host.Host
+	dialBackDialer    host.Host
+	dialBackMu        sync.Mutex
+	dialBacks         map[libp2ppeer.ID]chan struct{} // the peers being dialed back
+	reachabilityEmit  event.Emitter
+	reachabilityFixed bool // the reachability is overridden as public
 	libp2pPeerstore   peerstore.Peerstore
 	metrics           metrics
 	networkID         uint64
@@ -109,6 +114,7 @@ type Service struct {
 	networkStatus     atomic.Int32
 	HeadersRWTimeout  time.Duration
 	autoNAT           autonat.AutoNAT
+	staticPeers       map[libp2ppeer.ID]struct{} // peers which are never blocklisted
 }
 
 type lightnodes interface {
@@ -131,6 +137,7 @@ type Options struct {
 	hostFactory      func(...libp2p.Option) (host.Host, error)
 	HeadersRWTimeout time.Duration
 	Registry         *prometheus.Registry
+	StaticPeers      []ma.Multiaddr
 }
 
 func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay swarm.Address, addr string, ab addressbook.Putter, storer storage.StateStorer, lightNodes *lightnode.Container, logger log.Logger, tracer *tracing.Tracer, o Options) (*Service, error) {
@@ -139,6 +146,15 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		return nil, fmt.Errorf("address: %w", err)
 	}
 
+	staticPeers := make(map[libp2ppeer.ID]struct{}, len(o.StaticPeers))
+	for _, addr := range o.StaticPeers {
+		info, err := libp2ppeer.AddrInfoFromP2pAddr(addr)
+		if err != nil {
+			return nil, fmt.Errorf("static peer %s: %w", addr, err)
+		}
+		staticPeers[info.ID] = struct{}{}
+	}
+
 	ip4Addr := "0.0.0.0"
 	ip6Addr := "::"
 
@@ -315,11 +331,26 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 	// the addresses used are not dialable and hence should be cleaned up. We should create
 	// this host with the same transports and security options to be able to dial to other
 	// peers.
-	pingDialer, err := o.hostFactory(append(transports, security, libp2p.NoListenAddrs)...)
+	dialBackOptions := append(transports, security, libp2p.NoListenAddrs)
+	pingDialer, err := o.hostFactory(dialBackOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dial backs use their own dialer, so that their connections are
+	// never shared with the connections of the node or of the pings.
+	dialBackDialer, err := o.hostFactory(dialBackOptions...)
 	if err != nil {
 		return nil, err
 	}
 
+	// The reachability probed by dialing back is reported in the same way
+	// as the reachability found by autonat.
+	reachabilityEmit, err := h.EventBus().Emitter(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		return nil, fmt.Errorf("reachability emitter: %w", err)
+	}
+
 	peerRegistry := newPeerRegistry()
 	s := &Service{
 		ctx:               ctx,
@@ -328,6 +359,10 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		natAddrResolver:   natAddrResolver,
 		autonatDialer:     dialer,
 		pingDialer:        pingDialer,
+		dialBackDialer:    dialBackDialer,
+		dialBacks:         make(map[libp2ppeer.ID]chan struct{}),
+		reachabilityEmit:  reachabilityEmit,
+		reachabilityFixed: val,
 		handshakeService:  handshakeService,
 		libp2pPeerstore:   libp2pPeerstore,
 		metrics:           newMetrics(),
@@ -343,6 +378,7 @@ func New(ctx context.Context, signer beecrypto.Signer, networkID uint64, overlay
 		lightNodes:        lightNodes,
 		HeadersRWTimeout:  o.HeadersRWTimeout,
 		autoNAT:           autoNAT,
+		staticPeers:       staticPeers,
 	}
 
 	peerRegistry.setDisconnecter(s)
@@ -681,6 +717,11 @@ func (s *Service) Blocklist(overlay swarm.Address, duration time.Duration, reaso
 		return p2p.ErrPeerNotFound
 	}
 
+	if _, ok := s.staticPeers[id]; ok {
+		loggerV1.Debug("libp2p static peer not blocklisted", "peer_address", overlay.String(), "reason", reason)
+		return nil
+	}
+
 	full, _ := s.peers.fullnode(id)
 
 	loggerV1.Debug("libp2p blocklisting peer", "peer_address", overlay.String(), "duration", duration, "reason", reason)
@@ -996,6 +1037,11 @@ func (s *Service) Close() error {
 	if err := s.pingDialer.Close(); err != nil {
 		return err
 	}
+	if err := s.dialBackDialer.Close(); err != nil {
+		return err
+	}
+	// the emitter reports an error only if it was already closed
+	_ = s.reachabilityEmit.Close()
 	if s.reacher != nil {
 		if err := s.reacher.Close(); err != nil {
 			return err
@@ -1055,6 +1101,76 @@ func (s *Service) Ping(ctx context.Context, addr ma.Multiaddr) (rtt time.Duratio
 	}
 }
 
+// DialBack pings the underlay address with the dial back dialer. The dial
+// backs of a peer are done one at a time and the dialer is disconnected from
+// the peer before and after the ping, so that only the given address is
+// dialed and the connection is not shared with the other dial backs.
+func (s *Service) DialBack(ctx context.Context, addr ma.Multiaddr) (rtt time.Duration, err error) {
+	info, err := libp2ppeer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return rtt, fmt.Errorf("unable to parse underlay address: %w", err)
+	}
+
+	release, err := s.lockDialBack(ctx, info.ID)
+	if err != nil {
+		return rtt, err
+	}
+	defer release()
+
+	_ = s.dialBackDialer.Network().ClosePeer(info.ID)
+	s.dialBackDialer.Peerstore().ClearAddrs(info.ID)
+	s.dialBackDialer.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+	defer func() {
+		_ = s.dialBackDialer.Network().ClosePeer(info.ID)
+		s.dialBackDialer.Peerstore().ClearAddrs(info.ID)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return rtt, ctx.Err()
+	case res := <-libp2pping.Ping(ctx, s.dialBackDialer, info.ID):
+		return res.RTT, res.Error
+	}
+}
+
+// lockDialBack waits for the dial back of the peer in progress and returns
+// the function which ends the dial back of the caller.
+func (s *Service) lockDialBack(ctx context.Context, id libp2ppeer.ID) (func(), error) {
+	for {
+		s.dialBackMu.Lock()
+		done, ok := s.dialBacks[id]
+		if !ok {
+			done = make(chan struct{})
+			s.dialBacks[id] = done
+			s.dialBackMu.Unlock()
+			return func() {
+				s.dialBackMu.Lock()
+				delete(s.dialBacks, id)
+				s.dialBackMu.Unlock()
+				close(done)
+			}, nil
+		}
+		s.dialBackMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-done:
+		}
+	}
+}
+
+// UpdateReachability reports the reachability of the node found by the
+// reachability probes to the subscribers of the reachability of the host.
+func (s *Service) UpdateReachability(status p2p.ReachabilityStatus) {
+	if s.reachabilityFixed {
+		return
+	}
+	if err := s.reachabilityEmit.Emit(event.EvtLocalReachabilityChanged{Reachability: network.Reachability(status)}); err != nil {
+		s.logger.Debug("emit reachability failed", "error", err)
+	}
+}
+
 // peerUserAgent returns User Agent string of the connected peer if the peer
 // provides it. It ignores the default libp2p user agent string
 // "github.com/libp2p/go-libp2p" and returns empty string in that case.
@@ -11,6 +11,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/p2p"
 	"github.com/libp2p/go-libp2p/core/network"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 var (
@@ -33,6 +34,10 @@ func (s *stream) Headers() p2p.Headers {
 	return s.headers
 }
 
+func (s *stream) RemoteMultiaddr() ma.Multiaddr {
+	return s.Conn().RemoteMultiaddr()
+}
+
 func (s *stream) ResponseHeaders() p2p.Headers {
 	return s.responseHeaders
 }
@@ -158,6 +158,19 @@ type StreamerPinger interface {
 	Pinger
 }
 
+// DialBacker pings an underlay address over a connection made by a dialer
+// dedicated to the call, so that the result depends only on the given
+// address and not on the other dials of the same peer.
+type DialBacker interface {
+	DialBack(ctx context.Context, addr ma.Multiaddr) (rtt time.Duration, err error)
+}
+
+// RemoteAddresser is implemented by the streams which know the observed
+// address of the remote peer.
+type RemoteAddresser interface {
+	RemoteMultiaddr() ma.Multiaddr
+}
+
 // Stream represent a bidirectional data Stream.
 type Stream interface {
 	io.ReadWriter
@@ -37,6 +37,7 @@ type Recorder struct {
 	middlewares        []p2p.HandlerMiddleware
 	streamErr          func(swarm.Address, string, string, string) error
 	pingErr            func(ma.Multiaddr) (time.Duration, error)
+	remoteAddr         ma.Multiaddr
 	protocolsWithPeers map[string]p2p.ProtocolSpec
 }
 
@@ -82,6 +83,14 @@ func WithPingErr(pingErr func(ma.Multiaddr) (time.Duration, error)) Option {
 	})
 }
 
+// WithRemoteAddr sets the remote address of the streams passed to the
+// protocol handlers.
+func WithRemoteAddr(addr ma.Multiaddr) Option {
+	return optionFunc(func(r *Recorder) {
+		r.remoteAddr = addr
+	})
+}
+
 func New(opts ...Option) *Recorder {
 	r := &Recorder{
 		records:  make(map[string][]*Record),
@@ -119,6 +128,7 @@ func (r *Recorder) NewStream(ctx context.Context, addr swarm.Address, h p2p.Head
 	recordOut := newRecord()
 	streamOut := newStream(recordIn, recordOut)
 	streamIn := newStream(recordOut, recordIn)
+	streamIn.remoteAddr = r.remoteAddr
 
 	var handler p2p.HandlerFunc
 	var headler p2p.HeadlerFunc
@@ -174,6 +184,10 @@ func (r *Recorder) Ping(ctx context.Context, addr ma.Multiaddr) (rtt time.Durati
 	return rtt, err
 }
 
+func (r *Recorder) DialBack(ctx context.Context, addr ma.Multiaddr) (rtt time.Duration, err error) {
+	return r.Ping(ctx, addr)
+}
+
 func (r *Recorder) Records(addr swarm.Address, protocolName, protocolVersio, streamName string) ([]*Record, error) {
 	id := addr.String() + p2p.NewSwarmStreamName(protocolName, protocolVersio, streamName)
 
@@ -251,6 +265,7 @@ type stream struct {
 	out             *record
 	headers         p2p.Headers
 	responseHeaders p2p.Headers
+	remoteAddr      ma.Multiaddr
 	closed          bool
 	lock            sync.Mutex
 }
@@ -275,6 +290,10 @@ func (s *stream) Write(p []byte) (int, error) {
 	return s.in.Write(p)
 }
 
+func (s *stream) RemoteMultiaddr() ma.Multiaddr {
+	return s.remoteAddr
+}
+
 func (s *stream) Headers() p2p.Headers {
 	return s.headers
 }
@@ -0,0 +1,52 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batchstore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+)
+
+// ErrInvalidEstimate is returned when a batch can not be estimated with the
+// given depth and amount.
+var ErrInvalidEstimate = errors.New("batchstore: invalid batch estimate parameters")
+
+// maxEstimateDepth bounds the depth of estimated batches so that the
+// commitment does not overflow.
+const maxEstimateDepth = 62
+
+// Estimate projects the lifetime and the reserve footprint of a batch with the
+// given depth and per chunk amount under the current price and commitment.
+func (s *store) Estimate(depth uint8, amount *big.Int) (*postage.BatchEstimate, error) {
+	if depth > maxEstimateDepth || amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidEstimate
+	}
+
+	commitment, err := s.Commitment()
+	if err != nil {
+		return nil, fmt.Errorf("batchstore: estimate commitment: %w", err)
+	}
+
+	batchCommitment := exp2(uint(depth))
+	radius := radiusOf(int(commitment)+batchCommitment, s.capacity)
+
+	// a node at radius R stores 1/2^R of the chunks of every batch
+	share := float64(batchCommitment) / float64(exp2(uint(radius))) / float64(s.capacity)
+
+	blocks := int64(-1)
+	if price := s.cs.Load().CurrentPrice; price != nil && price.Sign() > 0 {
+		blocks = new(big.Int).Div(amount, price).Int64()
+	}
+
+	return &postage.BatchEstimate{
+		Blocks:       blocks,
+		Commitment:   uint64(batchCommitment),
+		Radius:       radius,
+		ReserveShare: share,
+	}, nil
+}
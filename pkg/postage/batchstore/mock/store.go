@@ -30,6 +30,7 @@ type BatchStore struct {
 	saveErr               error
 	updateErrDelayCnt     int
 	resetCallCount        int
+	estimate              *postage.BatchEstimate
 
 	existsFn func([]byte) (bool, error)
 
@@ -101,6 +102,13 @@ func WithBatch(b *postage.Batch) Option {
 	}
 }
 
+// WithEstimate will set the estimate returned by the ChainStore mock.
+func WithEstimate(e *postage.BatchEstimate) Option {
+	return func(bs *BatchStore) {
+		bs.estimate = e
+	}
+}
+
 func WithExistsFunc(f func([]byte) (bool, error)) Option {
 	return func(bs *BatchStore) {
 		bs.existsFn = f
@@ -144,6 +152,28 @@ func (bs *BatchStore) Iterate(f func(*postage.Batch) (bool, error)) error {
 	return err
 }
 
+// Query mocks the Query method from the BatchStore.
+func (bs *BatchStore) Query(q postage.BatchQuery) ([]*postage.Batch, []byte, error) {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if bs.batch == nil || !q.Match(bs.batch) || (q.Cursor != nil && bytes.Compare(bs.batch.ID, q.Cursor) <= 0) {
+		return nil, nil, nil
+	}
+	return []*postage.Batch{bs.batch}, nil, nil
+}
+
+// Estimate mocks the Estimate method from the BatchStore.
+func (bs *BatchStore) Estimate(depth uint8, _ *big.Int) (*postage.BatchEstimate, error) {
+	if bs.estimate != nil {
+		return bs.estimate, nil
+	}
+	return &postage.BatchEstimate{
+		Blocks:     -1,
+		Commitment: 1 << depth,
+		Radius:     bs.Radius(),
+	}, nil
+}
+
 // Save mocks the Save method from the BatchStore.
 func (bs *BatchStore) Save(batch *postage.Batch) error {
 	bs.mtx.Lock()
@@ -0,0 +1,134 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batchstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+)
+
+const (
+	snapshotVersion = 1
+	batchSize       = 95 // size of a binary encoded batch
+)
+
+var (
+	// ErrSnapshotNotEmpty is returned when a snapshot is imported into a
+	// batch store which already holds batches.
+	ErrSnapshotNotEmpty = errors.New("batchstore: snapshot import requires an empty batch store")
+	// ErrInvalidSnapshot is returned when a snapshot is malformed or does not
+	// match the chain.
+	ErrInvalidSnapshot = errors.New("batchstore: invalid snapshot")
+)
+
+var _ postage.BatchSnapshotter = (*store)(nil)
+
+// snapshot is the file format of an exported batch store.
+type snapshot struct {
+	Version    int                 `json:"version"`
+	ChainState *postage.ChainState `json:"chainState"`
+	Batches    [][]byte            `json:"batches"` // binary encoded batches
+}
+
+// ExportSnapshot writes all batches and the chain state to w.
+func (s *store) ExportSnapshot(w io.Writer) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	snap := snapshot{
+		Version:    snapshotVersion,
+		ChainState: s.cs.Load(),
+		Batches:    make([][]byte, 0),
+	}
+	err := s.store.Iterate(batchKeyPrefix, func(_, value []byte) (bool, error) {
+		snap.Batches = append(snap.Batches, value)
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("batchstore: iterate batches: %w", err)
+	}
+
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// ImportSnapshot restores the batches and the chain state exported with
+// ExportSnapshot into the empty batch store. Snapshots of blocks beyond
+// chainHead are rejected as they can not stem from the same chain.
+func (s *store) ImportSnapshot(r io.Reader, chainHead uint64) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, snap.Version)
+	}
+	cs := snap.ChainState
+	if cs == nil || cs.TotalAmount == nil || cs.CurrentPrice == nil {
+		return fmt.Errorf("%w: missing chain state", ErrInvalidSnapshot)
+	}
+	if cs.Block > chainHead {
+		return fmt.Errorf("%w: snapshot block %d is ahead of chain head %d", ErrInvalidSnapshot, cs.Block, chainHead)
+	}
+
+	batches := make([]*postage.Batch, 0, len(snap.Batches))
+	for _, data := range snap.Batches {
+		if len(data) != batchSize {
+			return fmt.Errorf("%w: batch of size %d", ErrInvalidSnapshot, len(data))
+		}
+		b := new(postage.Batch)
+		if err := b.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if b.Start > cs.Block {
+			return fmt.Errorf("%w: batch %x created after snapshot block", ErrInvalidSnapshot, b.ID)
+		}
+		batches = append(batches, b)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	empty := true
+	err := s.store.Iterate(batchKeyPrefix, func(_, _ []byte) (bool, error) {
+		empty = false
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("batchstore: iterate batches: %w", err)
+	}
+	if !empty {
+		return ErrSnapshotNotEmpty
+	}
+
+	for _, b := range batches {
+		if err := s.store.Put(batchKey(b.ID), b); err != nil {
+			return fmt.Errorf("batchstore: put batch %x: %w", b.ID, err)
+		}
+		if err := s.store.Put(valueKey(b.Value, b.ID), nil); err != nil {
+			return fmt.Errorf("batchstore: allocate batch %x: %w", b.ID, err)
+		}
+		if err := s.store.Put(ownerKey(b.Owner, b.ID), nil); err != nil {
+			return fmt.Errorf("batchstore: index batch %x: %w", b.ID, err)
+		}
+	}
+
+	s.cs.Store(cs)
+
+	// the batches are evicted and the radius computed once for all batches
+	if err := s.cleanup(); err != nil {
+		return fmt.Errorf("batchstore: snapshot import clean up: %w", err)
+	}
+	if err := s.computeRadius(); err != nil {
+		return fmt.Errorf("batchstore: snapshot import adjust radius: %w", err)
+	}
+
+	s.logger.Info("batch store snapshot imported", "block", cs.Block, "batches", len(batches), "radius", s.radius.Load())
+
+	return s.store.Put(chainStateKey, cs)
+}
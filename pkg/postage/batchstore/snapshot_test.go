@@ -0,0 +1,99 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package batchstore_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/postage/batchstore"
+	postagetest "github.com/calmw/bee-tron/pkg/postage/testing"
+	"github.com/calmw/bee-tron/pkg/statestore/mock"
+)
+
+func TestBatchStore_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	cs := &postage.ChainState{
+		Block:        100,
+		TotalAmount:  big.NewInt(10),
+		CurrentPrice: big.NewInt(2),
+	}
+	batches := []*postage.Batch{
+		postagetest.MustNewBatch(postagetest.WithValue(20), postagetest.WithStart(10)),
+		postagetest.MustNewBatch(postagetest.WithValue(30), postagetest.WithStart(90)),
+	}
+
+	src, _ := batchstore.New(mock.NewStateStore(), noopEvictFn, defaultCapacity, log.Noop)
+	for _, b := range batches {
+		if err := src.Save(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	batchStorePutChainState(t, src, cs)
+
+	var buf bytes.Buffer
+	if err := src.(postage.BatchSnapshotter).ExportSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+
+		dst, _ := batchstore.New(mock.NewStateStore(), noopEvictFn, defaultCapacity, log.Noop)
+		if err := dst.(postage.BatchSnapshotter).ImportSnapshot(bytes.NewReader(data), 120); err != nil {
+			t.Fatal(err)
+		}
+
+		postagetest.CompareChainState(t, cs, dst.GetChainState())
+		for _, b := range batches {
+			postagetest.CompareBatches(t, b, batchStoreGetBatch(t, dst, b.ID))
+		}
+		if dst.Radius() != src.Radius() {
+			t.Fatalf("got radius %d, want %d", dst.Radius(), src.Radius())
+		}
+	})
+
+	t.Run("ahead of chain head", func(t *testing.T) {
+		t.Parallel()
+
+		dst, _ := batchstore.New(mock.NewStateStore(), noopEvictFn, defaultCapacity, log.Noop)
+		err := dst.(postage.BatchSnapshotter).ImportSnapshot(bytes.NewReader(data), 99)
+		if !errors.Is(err, batchstore.ErrInvalidSnapshot) {
+			t.Fatalf("got error %v, want %v", err, batchstore.ErrInvalidSnapshot)
+		}
+		if n := dst.GetChainState().Block; n != 0 {
+			t.Fatalf("got chain state block %d, want 0", n)
+		}
+	})
+
+	t.Run("not empty", func(t *testing.T) {
+		t.Parallel()
+
+		dst, _ := batchstore.New(mock.NewStateStore(), noopEvictFn, defaultCapacity, log.Noop)
+		if err := dst.Save(postagetest.MustNewBatch(postagetest.WithValue(20))); err != nil {
+			t.Fatal(err)
+		}
+		err := dst.(postage.BatchSnapshotter).ImportSnapshot(bytes.NewReader(data), 120)
+		if !errors.Is(err, batchstore.ErrSnapshotNotEmpty) {
+			t.Fatalf("got error %v, want %v", err, batchstore.ErrSnapshotNotEmpty)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		dst, _ := batchstore.New(mock.NewStateStore(), noopEvictFn, defaultCapacity, log.Noop)
+		err := dst.(postage.BatchSnapshotter).ImportSnapshot(bytes.NewReader([]byte(`{"version":1,"batches":["AAEC"]}`)), 120)
+		if !errors.Is(err, batchstore.ErrInvalidSnapshot) {
+			t.Fatalf("got error %v, want %v", err, batchstore.ErrInvalidSnapshot)
+		}
+	})
+}
@@ -5,12 +5,14 @@
 package batchstore
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -25,6 +27,7 @@ const loggerName = "batchstore"
 const (
 	batchKeyPrefix   = "batchstore_batch_"
 	valueKeyPrefix   = "batchstore_value_"
+	ownerKeyPrefix   = "batchstore_owner_"
 	chainStateKey    = "batchstore_chainstate"
 	reserveRadiusKey = "batchstore_radius"
 )
@@ -86,9 +89,46 @@ func New(st storage.StateStorer, ev evictFn, capacity int, logger log.Logger) (p
 
 	s.radius.Store(uint32(radius))
 
+	if err := s.buildOwnerIndex(); err != nil {
+		return nil, fmt.Errorf("build owner index: %w", err)
+	}
+
 	return s, nil
 }
 
+// buildOwnerIndex indexes the batches by owner if they were stored before
+// the owner index was introduced.
+func (s *store) buildOwnerIndex() error {
+	indexed := false
+	err := s.store.Iterate(ownerKeyPrefix, func(_, _ []byte) (bool, error) {
+		indexed = true
+		return true, nil
+	})
+	if err != nil || indexed {
+		return err
+	}
+
+	var keys []string
+	err = s.store.Iterate(batchKeyPrefix, func(_, value []byte) (bool, error) {
+		b := &postage.Batch{}
+		if err := b.UnmarshalBinary(value); err != nil {
+			return false, err
+		}
+		keys = append(keys, ownerKey(b.Owner, b.ID))
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.store.Put(key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *store) Radius() uint8 {
 	return uint8(s.radius.Load())
 }
@@ -140,6 +180,87 @@ func (s *store) Iterate(cb func(*postage.Batch) (bool, error)) error {
 	})
 }
 
+// Query is implementation of postage.Storer interface Query method.
+// Batches of an owner are looked up with the owner index and ordering by
+// value follows the value index, so only a page of batches is held in memory.
+func (s *store) Query(q postage.BatchQuery) (batches []*postage.Batch, next []byte, err error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	cursor := func(b *postage.Batch) []byte {
+		if q.SortByValue {
+			return []byte(valueKey(b.Value, b.ID)[len(valueKeyPrefix):])
+		}
+		return b.ID
+	}
+	after := func(c []byte) bool {
+		return q.Cursor == nil || bytes.Compare(c, q.Cursor) > 0
+	}
+	// add appends the matching batch to the page and reports whether the
+	// page is complete.
+	add := func(b *postage.Batch) bool {
+		if !q.Match(b) {
+			return false
+		}
+		if q.Limit > 0 && len(batches) == q.Limit {
+			next = cursor(batches[len(batches)-1])
+			return true
+		}
+		batches = append(batches, b)
+		return false
+	}
+
+	switch {
+	case q.Owner != nil:
+		var owned []*postage.Batch
+		err = s.store.Iterate(ownerKeyPrefix+string(q.Owner), func(key, _ []byte) (bool, error) {
+			b, err := s.get(key[len(ownerKeyPrefix)+len(q.Owner):])
+			if err != nil {
+				return false, err
+			}
+			owned = append(owned, b)
+			return false, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		slices.SortFunc(owned, func(a, b *postage.Batch) int {
+			return bytes.Compare(cursor(a), cursor(b))
+		})
+		for _, b := range owned {
+			if after(cursor(b)) && add(b) {
+				break
+			}
+		}
+	case q.SortByValue:
+		err = s.store.Iterate(valueKeyPrefix, func(key, _ []byte) (bool, error) {
+			if !after(key[len(valueKeyPrefix):]) {
+				return false, nil
+			}
+			b, err := s.get(valueKeyToID(key))
+			if err != nil {
+				return false, err
+			}
+			return add(b), nil
+		})
+	default:
+		err = s.store.Iterate(batchKeyPrefix, func(key, value []byte) (bool, error) {
+			if !after(key[len(batchKeyPrefix):]) {
+				return false, nil
+			}
+			b := &postage.Batch{}
+			if err := b.UnmarshalBinary(value); err != nil {
+				return false, err
+			}
+			return add(b), nil
+		})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return batches, next, nil
+}
+
 // Save is implementation of postage.Storer interface Save method.
 // This method has side effects; it also updates the radius of the node if successful.
 func (s *store) Save(batch *postage.Batch) error {
@@ -152,6 +273,10 @@ func (s *store) Save(batch *postage.Batch) error {
 			return err
 		}
 
+		if err := s.store.Put(ownerKey(batch.Owner, batch.ID), nil); err != nil {
+			return err
+		}
+
 		if err := s.saveBatch(batch); err != nil {
 			return err
 		}
@@ -336,6 +461,10 @@ func (s *store) cleanup() error {
 		if err != nil {
 			return fmt.Errorf("delete value key for batch %x: %w", b.ID, err)
 		}
+		err = s.store.Delete(ownerKey(b.Owner, b.ID))
+		if err != nil {
+			return fmt.Errorf("delete owner key for batch %x: %w", b.ID, err)
+		}
 		err = s.store.Delete(batchKey(b.ID))
 		if err != nil {
 			return fmt.Errorf("delete batch %x: %w", b.ID, err)
@@ -369,12 +498,7 @@ func (s *store) computeRadius() error {
 
 	s.metrics.Commitment.Set(float64(totalCommitment))
 
-	var radius uint8
-	if totalCommitment > s.capacity {
-		// totalCommitment/node_capacity = 2^R
-		// log2(totalCommitment/node_capacity) = R
-		radius = uint8(math.Ceil(math.Log2(float64(totalCommitment) / float64(s.capacity))))
-	}
+	radius := radiusOf(totalCommitment, s.capacity)
 
 	s.metrics.Radius.Set(float64(radius))
 	s.radius.Store(uint32(radius))
@@ -382,6 +506,16 @@ func (s *store) computeRadius() error {
 	return s.store.Put(reserveRadiusKey, &radius)
 }
 
+// radiusOf returns the radius R for which the commitment fits the node
+// capacity, using the formula totalCommitment/node_capacity = 2^R.
+func radiusOf(totalCommitment, capacity int) uint8 {
+	if totalCommitment <= capacity {
+		return 0
+	}
+	// log2(totalCommitment/node_capacity) = R
+	return uint8(math.Ceil(math.Log2(float64(totalCommitment) / float64(capacity))))
+}
+
 // exp2 returns the e-th power of 2
 func exp2(e uint) int {
 	return 1 << e
@@ -392,6 +526,11 @@ func batchKey(batchID []byte) string {
 	return batchKeyPrefix + string(batchID)
 }
 
+// ownerKey returns the index key for the batch ID used in the by-owner batch index.
+func ownerKey(owner, batchID []byte) string {
+	return ownerKeyPrefix + string(owner) + string(batchID)
+}
+
 // valueKey returns the index key for the batch ID used in the by-ID batch index.
 func valueKey(val *big.Int, batchID []byte) string {
 	value := make([]byte, 32)
@@ -5,9 +5,11 @@
 package batchstore_test
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/log"
@@ -115,6 +117,143 @@ func TestBatchStore_IterateStopsEarly(t *testing.T) {
 	}
 }
 
+func TestBatchStore_Query(t *testing.T) {
+	t.Parallel()
+
+	stateStore, err := leveldb.NewInMemoryStateStore(log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stateStore.Close() })
+
+	batchStore, _ := batchstore.New(stateStore, noopEvictFn, defaultCapacity, log.Noop)
+
+	owner := postagetest.MustNewAddress()
+	batches := []*postage.Batch{
+		postagetest.MustNewBatch(postagetest.WithOwner(owner), postagetest.WithValue(10)),
+		postagetest.MustNewBatch(postagetest.WithOwner(owner), postagetest.WithValue(30)),
+		postagetest.MustNewBatch(postagetest.WithOwner(owner), postagetest.WithValue(20)),
+		postagetest.MustNewBatch(postagetest.WithValue(40)),
+	}
+	batches[2].Immutable = false
+	for _, b := range batches {
+		if err := batchStore.Save(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// query pages through all the batches matching the query
+	query := func(t *testing.T, q postage.BatchQuery) []*postage.Batch {
+		t.Helper()
+
+		var all []*postage.Batch
+		for {
+			page, next, err := batchStore.Query(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.Limit > 0 && len(page) > q.Limit {
+				t.Fatalf("got page of %d batches, want at most %d", len(page), q.Limit)
+			}
+			all = append(all, page...)
+			if next == nil {
+				return all
+			}
+			q.Cursor = next
+		}
+	}
+	values := func(batches []*postage.Batch) []int64 {
+		v := make([]int64, len(batches))
+		for i, b := range batches {
+			v[i] = b.Value.Int64()
+		}
+		return v
+	}
+	immutable := false
+
+	for _, tc := range []struct {
+		name  string
+		query postage.BatchQuery
+		want  []int64
+	}{
+		{"by value", postage.BatchQuery{SortByValue: true, Limit: 3}, []int64{10, 20, 30, 40}},
+		{"owner", postage.BatchQuery{Owner: owner, SortByValue: true, Limit: 2}, []int64{10, 20, 30}},
+		{"mutable", postage.BatchQuery{Immutable: &immutable, SortByValue: true}, []int64{20}},
+		{"min value", postage.BatchQuery{MinValue: big.NewInt(25), SortByValue: true, Limit: 1}, []int64{30, 40}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := values(query(t, tc.query)); !slices.Equal(got, tc.want) {
+				t.Fatalf("got values %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("by id", func(t *testing.T) {
+		got := query(t, postage.BatchQuery{Limit: 3})
+		if len(got) != len(batches) {
+			t.Fatalf("got %d batches, want %d", len(got), len(batches))
+		}
+		if !slices.IsSortedFunc(got, func(a, b *postage.Batch) int { return bytes.Compare(a.ID, b.ID) }) {
+			t.Fatal("batches not ordered by id")
+		}
+	})
+
+	t.Run("owner index built on start", func(t *testing.T) {
+		stateStore, err := leveldb.NewInMemoryStateStore(log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = stateStore.Close() })
+
+		b := postagetest.MustNewBatch(postagetest.WithOwner(owner))
+		stateStorePut(t, stateStore, batchstore.BatchKey(b.ID), b)
+
+		batchStore, err := batchstore.New(stateStore, noopEvictFn, defaultCapacity, log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := batchStore.Query(postage.BatchQuery{Owner: owner})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("got %d batches, want 1", len(got))
+		}
+		postagetest.CompareBatches(t, b, got[0])
+	})
+}
+
+func TestBatchStore_Estimate(t *testing.T) {
+	t.Parallel()
+
+	// capacity 2^3 chunks, committed batch of 2^4 chunks at radius 1
+	batchStore := setupBatchStore(t, 8)
+	batchStorePutChainState(t, batchStore, &postage.ChainState{
+		TotalAmount:  big.NewInt(0),
+		CurrentPrice: big.NewInt(4),
+	})
+	addBatch(t, batchStore, 4, 200)
+	checkState(t, "estimate", batchStore, 1)
+
+	got, err := batchStore.Estimate(4, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &postage.BatchEstimate{
+		Blocks:       25,
+		Commitment:   16,
+		Radius:       2,
+		ReserveShare: 0.5,
+	}
+	if *got != *want {
+		t.Fatalf("got estimate %+v, want %+v", got, want)
+	}
+
+	if _, err := batchStore.Estimate(4, big.NewInt(0)); !errors.Is(err, batchstore.ErrInvalidEstimate) {
+		t.Fatalf("got error %v, want %v", err, batchstore.ErrInvalidEstimate)
+	}
+}
+
 func TestBatchStore_SaveAndUpdate(t *testing.T) {
 	t.Parallel()
 	testBatch := postagetest.MustNewBatch()
@@ -0,0 +1,241 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/sctx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultAutoDiluteInterval is the default interval at which the bucket
+// utilization of the batches is checked.
+const DefaultAutoDiluteInterval = 5 * time.Minute
+
+var (
+	// ErrInvalidAutoDilutePolicy is returned when the auto-dilute policy contains invalid settings.
+	ErrInvalidAutoDilutePolicy = errors.New("invalid auto-dilute policy")
+	// ErrGasPriceTooHigh is reported when a dilution is postponed because the
+	// gas price exceeds the cap of the policy.
+	ErrGasPriceTooHigh = errors.New("gas price exceeds cap")
+)
+
+// AutoDilutePolicy configures when batches are diluted automatically.
+type AutoDilutePolicy struct {
+	// Threshold is the fullness of the fullest bucket in percent at which a
+	// batch is diluted.
+	Threshold uint
+	// MaxDepth is the depth batches are not diluted beyond, 0 for no limit.
+	MaxDepth uint8
+	// MaxGasPrice postpones dilutions while the suggested gas price exceeds
+	// it, nil for no cap.
+	MaxGasPrice *big.Int
+	// GasLimit is the gas limit of the dilution transactions, 0 for the default.
+	GasLimit uint64
+}
+
+// DilutionEvent reports a dilution of a batch or why it was not diluted.
+type DilutionEvent struct {
+	BatchID     []byte
+	Utilization uint        // fullness of the fullest bucket in percent
+	NewDepth    uint8       // depth the batch is diluted to
+	Tx          common.Hash // the dilution transaction, zero if the batch was not diluted
+	Err         error       // the reason the batch was not diluted
+}
+
+// BatchDiluter dilutes batches on the blockchain.
+type BatchDiluter interface {
+	DiluteBatch(ctx context.Context, batchID []byte, newDepth uint8) (common.Hash, error)
+}
+
+// GasPriceSuggester suggests the gas price of new transactions.
+type GasPriceSuggester interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// AutoDiluter increases the depth of owned batches whose fullest bucket
+// crosses the utilization threshold.
+type AutoDiluter struct {
+	logger    log.Logger
+	service   Service
+	diluter   BatchDiluter
+	gasPricer GasPriceSuggester
+	policy    AutoDilutePolicy
+	interval  time.Duration
+
+	mu          sync.Mutex
+	pending     map[string]uint8 // depths of sent dilutions not yet seen on chain
+	subscribers map[chan DilutionEvent]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewAutoDiluter creates an AutoDiluter for the batches of the service.
+func NewAutoDiluter(logger log.Logger, service Service, diluter BatchDiluter, gasPricer GasPriceSuggester, policy AutoDilutePolicy, interval time.Duration) (*AutoDiluter, error) {
+	if policy.Threshold == 0 || policy.Threshold > 100 {
+		return nil, fmt.Errorf("%w: threshold must be between 1 and 100 percent", ErrInvalidAutoDilutePolicy)
+	}
+	if policy.MaxGasPrice != nil && policy.MaxGasPrice.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: gas price cap must be positive", ErrInvalidAutoDilutePolicy)
+	}
+	if interval <= 0 {
+		interval = DefaultAutoDiluteInterval
+	}
+
+	return &AutoDiluter{
+		logger:      logger.WithName(loggerName).Register(),
+		service:     service,
+		diluter:     diluter,
+		gasPricer:   gasPricer,
+		policy:      policy,
+		interval:    interval,
+		pending:     make(map[string]uint8),
+		subscribers: make(map[chan DilutionEvent]struct{}),
+		quit:        make(chan struct{}),
+	}, nil
+}
+
+// Subscribe returns a channel receiving the dilution events and a function
+// to cancel the subscription. Events are dropped if the channel is not
+// drained.
+func (d *AutoDiluter) Subscribe() (<-chan DilutionEvent, func()) {
+	c := make(chan DilutionEvent, 16)
+
+	d.mu.Lock()
+	d.subscribers[c] = struct{}{}
+	d.mu.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			d.mu.Lock()
+			delete(d.subscribers, c)
+			d.mu.Unlock()
+		})
+	}
+}
+
+func (d *AutoDiluter) publish(event DilutionEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for c := range d.subscribers {
+		select {
+		case c <- event:
+		default:
+		}
+	}
+}
+
+// Start checks the batches in the background until the diluter is closed.
+func (d *AutoDiluter) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-d.quit
+			cancel()
+		}()
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			d.check(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check dilutes the usable batches whose fullest bucket crossed the
+// threshold by one depth.
+func (d *AutoDiluter) check(ctx context.Context) {
+	for _, issuer := range d.service.StampIssuers() {
+		if ctx.Err() != nil {
+			return
+		}
+		if !d.service.IssuerUsable(issuer) {
+			continue
+		}
+
+		batchID := issuer.ID()
+		depth := issuer.Depth()
+
+		d.mu.Lock()
+		pending, ok := d.pending[string(batchID)]
+		if ok && depth >= pending {
+			delete(d.pending, string(batchID))
+			ok = false
+		}
+		d.mu.Unlock()
+		if ok {
+			continue
+		}
+
+		utilization := uint(uint64(issuer.Utilization()) * 100 / uint64(issuer.BucketUpperBound()))
+		if utilization < d.policy.Threshold {
+			continue
+		}
+
+		event := DilutionEvent{BatchID: batchID, Utilization: utilization, NewDepth: depth + 1}
+		if d.policy.MaxDepth != 0 && event.NewDepth > d.policy.MaxDepth {
+			continue
+		}
+
+		event.Tx, event.Err = d.dilute(ctx, batchID, event.NewDepth)
+		if event.Err != nil {
+			d.logger.Warning("batch auto-dilution failed", "batch_id", hex.EncodeToString(batchID), "utilization", utilization, "error", event.Err)
+		} else {
+			d.logger.Info("batch auto-diluted", "batch_id", hex.EncodeToString(batchID), "utilization", utilization, "new_depth", event.NewDepth, "tx", event.Tx)
+
+			d.mu.Lock()
+			d.pending[string(batchID)] = event.NewDepth
+			d.mu.Unlock()
+		}
+		d.publish(event)
+	}
+}
+
+// dilute sends the dilution of the batch unless the gas price exceeds the cap.
+func (d *AutoDiluter) dilute(ctx context.Context, batchID []byte, newDepth uint8) (common.Hash, error) {
+	if d.policy.MaxGasPrice != nil {
+		gasPrice, err := d.gasPricer.SuggestGasPrice(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("suggest gas price: %w", err)
+		}
+		if gasPrice.Cmp(d.policy.MaxGasPrice) > 0 {
+			return common.Hash{}, fmt.Errorf("%w: %d", ErrGasPriceTooHigh, gasPrice)
+		}
+		ctx = sctx.SetGasPrice(ctx, gasPrice)
+	}
+	if d.policy.GasLimit != 0 {
+		ctx = sctx.SetGasLimit(ctx, d.policy.GasLimit)
+	}
+	return d.diluter.DiluteBatch(ctx, batchID, newDepth)
+}
+
+// Close stops the diluter.
+func (d *AutoDiluter) Close() error {
+	close(d.quit)
+	d.wg.Wait()
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	pstoremock "github.com/calmw/bee-tron/pkg/postage/batchstore/mock"
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	"github.com/calmw/bee-tron/pkg/sctx"
+	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type diluterMock struct {
+	depths    []uint8
+	gasPrices []*big.Int
+}
+
+func (m *diluterMock) DiluteBatch(ctx context.Context, _ []byte, newDepth uint8) (common.Hash, error) {
+	m.depths = append(m.depths, newDepth)
+	m.gasPrices = append(m.gasPrices, sctx.GetGasPrice(ctx))
+	return common.HexToHash("0x01"), nil
+}
+
+type gasPriceMock big.Int
+
+func (m *gasPriceMock) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return (*big.Int)(m), nil
+}
+
+func TestAutoDiluter(t *testing.T) {
+	t.Parallel()
+
+	// newService returns a service with a usable batch of depth 16 and bucket
+	// depth 8 whose fullest bucket holds the given number of the 256 chunks.
+	newService := func(t *testing.T, fullest int) (postage.Service, []byte) {
+		t.Helper()
+
+		batch := postagetesting.MustNewBatch()
+		batch.Start = 100
+		chainState := &postage.ChainState{
+			Block:        batch.Start + uint64(postage.BlockThreshold) + 1,
+			TotalAmount:  big.NewInt(0),
+			CurrentPrice: big.NewInt(1),
+		}
+		batchStore := pstoremock.New(pstoremock.WithChainState(chainState), pstoremock.WithBatch(batch))
+
+		store := inmemstore.New()
+		t.Cleanup(func() { _ = store.Close() })
+		service, err := postage.NewService(log.Noop, store, batchStore, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		issuer := postage.NewStampIssuer("label", "keyID", batch.ID, big.NewInt(3), 16, 8, batch.Start, true)
+		addr := swarm.RandAddress(t)
+		for i := 0; i < fullest; i++ {
+			if _, _, err := issuer.Increment(addr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := service.Add(issuer); err != nil {
+			t.Fatal(err)
+		}
+		return service, batch.ID
+	}
+
+	t.Run("invalid policy", func(t *testing.T) {
+		t.Parallel()
+
+		service, _ := newService(t, 0)
+		for _, policy := range []postage.AutoDilutePolicy{
+			{Threshold: 0},
+			{Threshold: 101},
+			{Threshold: 90, MaxGasPrice: big.NewInt(0)},
+		} {
+			_, err := postage.NewAutoDiluter(log.Noop, service, &diluterMock{}, nil, policy, 0)
+			if !errors.Is(err, postage.ErrInvalidAutoDilutePolicy) {
+				t.Fatalf("policy %+v: got error %v, want %v", policy, err, postage.ErrInvalidAutoDilutePolicy)
+			}
+		}
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		t.Parallel()
+
+		service, _ := newService(t, 128)
+		diluter := &diluterMock{}
+		d, err := postage.NewAutoDiluter(log.Noop, service, diluter, nil, postage.AutoDilutePolicy{Threshold: 90}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d.Check(context.Background())
+		if len(diluter.depths) != 0 {
+			t.Fatalf("got dilutions %v, want none", diluter.depths)
+		}
+	})
+
+	t.Run("dilute once", func(t *testing.T) {
+		t.Parallel()
+
+		service, batchID := newService(t, 240)
+		diluter := &diluterMock{}
+		d, err := postage.NewAutoDiluter(log.Noop, service, diluter, nil, postage.AutoDilutePolicy{Threshold: 90}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, unsubscribe := d.Subscribe()
+		defer unsubscribe()
+
+		d.Check(context.Background())
+		event := <-events
+		if event.NewDepth != 17 || event.Utilization != 93 || event.Err != nil {
+			t.Fatalf("unexpected event %+v", event)
+		}
+
+		// the dilution is pending until the depth increase is seen on chain
+		d.Check(context.Background())
+		service.HandleDepthIncrease(batchID, 17)
+		d.Check(context.Background())
+
+		if len(diluter.depths) != 1 || diluter.depths[0] != 17 {
+			t.Fatalf("got dilutions %v, want [17]", diluter.depths)
+		}
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		t.Parallel()
+
+		service, _ := newService(t, 256)
+		diluter := &diluterMock{}
+		d, err := postage.NewAutoDiluter(log.Noop, service, diluter, nil, postage.AutoDilutePolicy{Threshold: 90, MaxDepth: 16}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d.Check(context.Background())
+		if len(diluter.depths) != 0 {
+			t.Fatalf("got dilutions %v, want none", diluter.depths)
+		}
+	})
+
+	t.Run("gas price cap", func(t *testing.T) {
+		t.Parallel()
+
+		service, _ := newService(t, 240)
+		diluter := &diluterMock{}
+		gasPrice := (*gasPriceMock)(big.NewInt(20))
+		d, err := postage.NewAutoDiluter(log.Noop, service, diluter, gasPrice, postage.AutoDilutePolicy{Threshold: 90, MaxGasPrice: big.NewInt(10)}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, unsubscribe := d.Subscribe()
+		defer unsubscribe()
+
+		d.Check(context.Background())
+		if event := <-events; !errors.Is(event.Err, postage.ErrGasPriceTooHigh) {
+			t.Fatalf("got error %v, want %v", event.Err, postage.ErrGasPriceTooHigh)
+		}
+		if len(diluter.depths) != 0 {
+			t.Fatalf("got dilutions %v, want none", diluter.depths)
+		}
+
+		(*big.Int)(gasPrice).SetInt64(5)
+		d.Check(context.Background())
+		if event := <-events; event.Err != nil {
+			t.Fatalf("unexpected error %v", event.Err)
+		}
+		if len(diluter.depths) != 1 || diluter.gasPrices[0].Int64() != 5 {
+			t.Fatalf("got dilutions %v with gas prices %v", diluter.depths, diluter.gasPrices)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const expiryPolicyPrefix = "postage_expiry_policy_"
+
+// ErrInvalidExpiryPolicy is returned when an expiry policy contains invalid settings.
+var ErrInvalidExpiryPolicy = errors.New("invalid expiry policy")
+
+// ExpiryPolicy configures the actions taken once the time to live of a batch
+// falls below the threshold.
+type ExpiryPolicy struct {
+	// Threshold is the remaining time to live triggering the actions.
+	Threshold time.Duration `json:"threshold"`
+	// TopUpAmount, if set, is the per chunk amount the batch is topped up with.
+	TopUpAmount *big.Int `json:"topUpAmount,omitempty"`
+	// Notify emits an expiry event to the subscribers.
+	Notify bool `json:"notify"`
+	// Freeze stops issuing new stamps with the batch until its time to live
+	// rises above the threshold again.
+	Freeze bool `json:"freeze"`
+}
+
+// ExpiryEvent reports the actions taken for a batch nearing its expiry.
+type ExpiryEvent struct {
+	BatchID    []byte
+	TTL        time.Duration // remaining time to live of the batch
+	Frozen     bool          // the batch was frozen
+	TopUpTx    common.Hash   // the top up transaction, zero if the batch was not topped up
+	TopUpError error         // the error of a failed top up
+}
+
+// BatchTopUpper tops up batches on the blockchain.
+type BatchTopUpper interface {
+	TopUpBatch(ctx context.Context, batchID []byte, topupBalance *big.Int) (common.Hash, error)
+}
+
+// ExpiryWatcher tracks the owned batches nearing their expiry and applies
+// the expiry policy configured for each of them.
+type ExpiryWatcher struct {
+	logger    log.Logger
+	service   Service
+	batches   Storer
+	store     storage.StateStorer
+	topUpper  BatchTopUpper
+	blockTime time.Duration
+
+	mu          sync.Mutex
+	triggered   map[string]bool // batches whose policy was applied, true once all actions succeeded
+	subscribers map[chan ExpiryEvent]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewExpiryWatcher creates a watcher for the batches of the service. Batches
+// are topped up with topUpper, which may be nil if top ups are unavailable.
+func NewExpiryWatcher(logger log.Logger, service Service, batches Storer, store storage.StateStorer, topUpper BatchTopUpper, blockTime time.Duration) *ExpiryWatcher {
+	return &ExpiryWatcher{
+		logger:      logger.WithName(loggerName).Register(),
+		service:     service,
+		batches:     batches,
+		store:       store,
+		topUpper:    topUpper,
+		blockTime:   blockTime,
+		triggered:   make(map[string]bool),
+		subscribers: make(map[chan ExpiryEvent]struct{}),
+		quit:        make(chan struct{}),
+	}
+}
+
+func expiryPolicyKey(batchID []byte) string {
+	return fmt.Sprintf("%s%x", expiryPolicyPrefix, batchID)
+}
+
+// Policy returns the expiry policy of the batch. The boolean is false if no
+// policy is configured.
+func (w *ExpiryWatcher) Policy(batchID []byte) (ExpiryPolicy, bool, error) {
+	var policy ExpiryPolicy
+	err := w.store.Get(expiryPolicyKey(batchID), &policy)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ExpiryPolicy{}, false, nil
+		}
+		return ExpiryPolicy{}, false, err
+	}
+	return policy, true, nil
+}
+
+// SetPolicy stores the expiry policy of the batch.
+func (w *ExpiryWatcher) SetPolicy(batchID []byte, policy ExpiryPolicy) error {
+	if policy.Threshold <= 0 {
+		return fmt.Errorf("%w: threshold must be positive", ErrInvalidExpiryPolicy)
+	}
+	if policy.TopUpAmount != nil && policy.TopUpAmount.Sign() <= 0 {
+		return fmt.Errorf("%w: top up amount must be positive", ErrInvalidExpiryPolicy)
+	}
+
+	if err := w.store.Put(expiryPolicyKey(batchID), policy); err != nil {
+		return err
+	}
+	w.reset(batchID)
+	return nil
+}
+
+// DeletePolicy removes the expiry policy of the batch and unfreezes it.
+func (w *ExpiryWatcher) DeletePolicy(batchID []byte) error {
+	if err := w.store.Delete(expiryPolicyKey(batchID)); err != nil {
+		return err
+	}
+	w.reset(batchID)
+	return nil
+}
+
+// Policies returns all configured policies keyed by hex encoded batch ID.
+func (w *ExpiryWatcher) Policies() (map[string]ExpiryPolicy, error) {
+	policies := make(map[string]ExpiryPolicy)
+	err := w.store.Iterate(expiryPolicyPrefix, func(key, val []byte) (stop bool, err error) {
+		var policy ExpiryPolicy
+		if err := json.Unmarshal(val, &policy); err != nil {
+			return false, fmt.Errorf("unmarshal policy %s: %w", string(key), err)
+		}
+		policies[strings.TrimPrefix(string(key), expiryPolicyPrefix)] = policy
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// reset unfreezes the batch so a changed policy is applied from scratch.
+func (w *ExpiryWatcher) reset(batchID []byte) {
+	w.mu.Lock()
+	delete(w.triggered, string(batchID))
+	w.mu.Unlock()
+
+	w.service.SetFrozen(batchID, false)
+}
+
+// Subscribe returns a channel receiving the expiry events of batches with
+// notifying policies, and a function to cancel the subscription. Events are
+// dropped if the channel is not drained.
+func (w *ExpiryWatcher) Subscribe() (<-chan ExpiryEvent, func()) {
+	c := make(chan ExpiryEvent, 16)
+
+	w.mu.Lock()
+	w.subscribers[c] = struct{}{}
+	w.mu.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			w.mu.Lock()
+			delete(w.subscribers, c)
+			w.mu.Unlock()
+		})
+	}
+}
+
+func (w *ExpiryWatcher) publish(event ExpiryEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for c := range w.subscribers {
+		select {
+		case c <- event:
+		default:
+		}
+	}
+}
+
+// Start checks the batches every interval until the watcher is closed.
+func (w *ExpiryWatcher) Start(interval time.Duration) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-w.quit
+			cancel()
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := w.check(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Warning("batch expiry check failed", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check applies the expiry policies of the owned batches whose time to live
+// fell below the threshold, and unfreezes recovered batches.
+func (w *ExpiryWatcher) check(ctx context.Context) error {
+	for _, issuer := range w.service.StampIssuers() {
+		batchID := issuer.ID()
+
+		policy, ok, err := w.Policy(batchID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		ttl, ok, err := w.ttl(batchID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		w.mu.Lock()
+		done, triggered := w.triggered[string(batchID)]
+		if ttl > policy.Threshold {
+			delete(w.triggered, string(batchID))
+		}
+		w.mu.Unlock()
+
+		if ttl > policy.Threshold {
+			if triggered && policy.Freeze {
+				w.logger.Info("batch recovered from expiry, unfreezing", "batch_id", hex.EncodeToString(batchID), "ttl", ttl)
+				w.service.SetFrozen(batchID, false)
+			}
+			continue
+		}
+		if done {
+			continue
+		}
+
+		w.apply(ctx, batchID, ttl, policy)
+	}
+	return nil
+}
+
+// apply takes the actions of the policy for the batch. A failed top up is
+// retried on the next check.
+func (w *ExpiryWatcher) apply(ctx context.Context, batchID []byte, ttl time.Duration, policy ExpiryPolicy) {
+	event := ExpiryEvent{BatchID: batchID, TTL: ttl}
+	w.logger.Info("batch nearing expiry", "batch_id", hex.EncodeToString(batchID), "ttl", ttl, "threshold", policy.Threshold)
+
+	if policy.Freeze {
+		w.service.SetFrozen(batchID, true)
+		event.Frozen = true
+	}
+
+	done := true
+	if policy.TopUpAmount != nil && w.topUpper != nil {
+		event.TopUpTx, event.TopUpError = w.topUpper.TopUpBatch(ctx, batchID, policy.TopUpAmount)
+		if event.TopUpError != nil {
+			w.logger.Warning("batch top up failed", "batch_id", hex.EncodeToString(batchID), "error", event.TopUpError)
+			done = false
+		}
+	}
+
+	w.mu.Lock()
+	w.triggered[string(batchID)] = done
+	w.mu.Unlock()
+
+	if policy.Notify {
+		w.publish(event)
+	}
+}
+
+// ttl estimates the remaining time to live of the batch. The boolean is false
+// if the batch does not exist or never expires.
+func (w *ExpiryWatcher) ttl(batchID []byte) (time.Duration, bool, error) {
+	batch, err := w.batches.Get(batchID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	state := w.batches.GetChainState()
+	if state.CurrentPrice == nil || state.CurrentPrice.Sign() == 0 {
+		return 0, false, nil
+	}
+
+	blocks := new(big.Int).Sub(batch.Value, state.TotalAmount)
+	blocks.Div(blocks, state.CurrentPrice)
+	if blocks.Sign() < 0 {
+		return 0, true, nil
+	}
+	ttl := blocks.Mul(blocks, big.NewInt(int64(w.blockTime)))
+	if !ttl.IsInt64() {
+		return time.Duration(math.MaxInt64), true, nil
+	}
+	return time.Duration(ttl.Int64()), true, nil
+}
+
+// Close stops the watcher.
+func (w *ExpiryWatcher) Close() error {
+	close(w.quit)
+	w.wg.Wait()
+	return nil
+}
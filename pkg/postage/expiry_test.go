@@ -0,0 +1,196 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	pstoremock "github.com/calmw/bee-tron/pkg/postage/batchstore/mock"
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type topUpperMock struct {
+	calls int
+	err   error
+}
+
+func (m *topUpperMock) TopUpBatch(context.Context, []byte, *big.Int) (common.Hash, error) {
+	m.calls++
+	if m.err != nil {
+		return common.Hash{}, m.err
+	}
+	return common.HexToHash("0x01"), nil
+}
+
+func TestExpiryWatcher(t *testing.T) {
+	t.Parallel()
+
+	blockTime := 5 * time.Second
+	policy := postage.ExpiryPolicy{
+		Threshold:   100 * time.Second,
+		TopUpAmount: big.NewInt(500),
+		Notify:      true,
+		Freeze:      true,
+	}
+
+	newWatcher := func(t *testing.T, topUpper postage.BatchTopUpper) (*postage.ExpiryWatcher, postage.Service, *postage.Batch) {
+		t.Helper()
+
+		batch := postagetesting.MustNewBatch()
+		batch.Start = 100
+		// 10 blocks or 50 seconds left
+		batch.Value = big.NewInt(1100)
+		chainState := &postage.ChainState{
+			Block:        batch.Start + uint64(postage.BlockThreshold) + 1,
+			TotalAmount:  big.NewInt(1000),
+			CurrentPrice: big.NewInt(10),
+		}
+		batchStore := pstoremock.New(pstoremock.WithChainState(chainState), pstoremock.WithBatch(batch))
+
+		store := inmemstore.New()
+		t.Cleanup(func() { _ = store.Close() })
+		service, err := postage.NewService(log.Noop, store, batchStore, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = service.Add(postage.NewStampIssuer("label", "keyID", batch.ID, big.NewInt(3), 16, 8, batch.Start, true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		watcher := postage.NewExpiryWatcher(log.Noop, service, batchStore, statestore.NewStateStore(), topUpper, blockTime)
+		return watcher, service, batch
+	}
+
+	t.Run("invalid policy", func(t *testing.T) {
+		t.Parallel()
+
+		watcher, _, batch := newWatcher(t, nil)
+		for _, policy := range []postage.ExpiryPolicy{
+			{},
+			{Threshold: time.Hour, TopUpAmount: big.NewInt(0)},
+		} {
+			if err := watcher.SetPolicy(batch.ID, policy); !errors.Is(err, postage.ErrInvalidExpiryPolicy) {
+				t.Fatalf("got error %v, want %v", err, postage.ErrInvalidExpiryPolicy)
+			}
+		}
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		t.Parallel()
+
+		topUpper := new(topUpperMock)
+		watcher, service, batch := newWatcher(t, topUpper)
+
+		if err := watcher.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if topUpper.calls != 0 {
+			t.Fatalf("got %d top ups, want none", topUpper.calls)
+		}
+		if _, _, err := service.GetStampIssuer(batch.ID); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("apply and recover", func(t *testing.T) {
+		t.Parallel()
+
+		topUpper := new(topUpperMock)
+		watcher, service, batch := newWatcher(t, topUpper)
+		events, unsubscribe := watcher.Subscribe()
+		defer unsubscribe()
+
+		if err := watcher.SetPolicy(batch.ID, policy); err != nil {
+			t.Fatal(err)
+		}
+		if err := watcher.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := service.GetStampIssuer(batch.ID); !errors.Is(err, postage.ErrFrozen) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrFrozen)
+		}
+		if topUpper.calls != 1 {
+			t.Fatalf("got %d top ups, want 1", topUpper.calls)
+		}
+		select {
+		case event := <-events:
+			if !event.Frozen || event.TTL != 50*time.Second || event.TopUpTx != common.HexToHash("0x01") || event.TopUpError != nil {
+				t.Fatalf("got event %+v", event)
+			}
+		default:
+			t.Fatal("no expiry event")
+		}
+
+		// the policy is applied once
+		if err := watcher.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if topUpper.calls != 1 {
+			t.Fatalf("got %d top ups, want 1", topUpper.calls)
+		}
+
+		// the top up landed
+		batch.Value = big.NewInt(2100)
+		if err := watcher.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := service.GetStampIssuer(batch.ID); err != nil {
+			t.Fatalf("batch not unfrozen: %v", err)
+		}
+	})
+
+	t.Run("top up retried", func(t *testing.T) {
+		t.Parallel()
+
+		topUpper := &topUpperMock{err: errors.New("out of funds")}
+		watcher, _, batch := newWatcher(t, topUpper)
+
+		if err := watcher.SetPolicy(batch.ID, policy); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := watcher.Check(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if topUpper.calls != 2 {
+			t.Fatalf("got %d top ups, want 2", topUpper.calls)
+		}
+	})
+
+	t.Run("delete policy unfreezes", func(t *testing.T) {
+		t.Parallel()
+
+		watcher, service, batch := newWatcher(t, nil)
+
+		if err := watcher.SetPolicy(batch.ID, postage.ExpiryPolicy{Threshold: time.Hour, Freeze: true}); err != nil {
+			t.Fatal(err)
+		}
+		if err := watcher.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := service.GetStampIssuer(batch.ID); !errors.Is(err, postage.ErrFrozen) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrFrozen)
+		}
+
+		if err := watcher.DeletePolicy(batch.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := service.GetStampIssuer(batch.ID); err != nil {
+			t.Fatalf("batch not unfrozen: %v", err)
+		}
+	})
+}
@@ -5,6 +5,8 @@
 package postage
 
 import (
+	"context"
+
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
@@ -51,3 +53,11 @@ func ModifyBuckets(st *StampIssuer, buckets []uint32) {
 func (si *StampIssuer) Increment(addr swarm.Address) ([]byte, []byte, error) {
 	return si.increment(addr)
 }
+
+func (w *ExpiryWatcher) Check(ctx context.Context) error {
+	return w.check(ctx)
+}
+
+func (d *AutoDiluter) Check(ctx context.Context) {
+	d.check(ctx)
+}
@@ -5,6 +5,7 @@
 package postage
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math/big"
@@ -52,6 +53,14 @@ type Storer interface {
 	// Iterate iterates through stored batches.
 	Iterate(func(*Batch) (bool, error)) error
 
+	// Query returns a page of the batches matching the query and the
+	// cursor of the next page, nil if it is the last page.
+	Query(BatchQuery) ([]*Batch, []byte, error)
+
+	// Estimate projects the lifetime and reserve footprint of a planned
+	// batch with the given depth and per chunk amount.
+	Estimate(depth uint8, amount *big.Int) (*BatchEstimate, error)
+
 	// Save stores given batch in the store. The call is idempotent, so
 	// a subsequent call would not create new batches if a batch with
 	// such ID already exists.
@@ -71,6 +80,38 @@ type Storer interface {
 	SetBatchExpiryHandler(BatchExpiryHandler)
 }
 
+// BatchQuery selects and orders the batches returned by Storer.Query.
+type BatchQuery struct {
+	Owner       []byte   // only batches of the owner, nil for all
+	Immutable   *bool    // only batches with the immutability, nil for all
+	MinValue    *big.Int // only batches with at least the normalised balance, nil for all
+	SortByValue bool     // order by normalised balance instead of batch ID
+	Cursor      []byte   // the cursor returned with the previous page, nil for the first page
+	Limit       int      // maximum number of batches of a page, 0 for no limit
+}
+
+// Match reports whether the batch passes the filters of the query.
+func (q BatchQuery) Match(b *Batch) bool {
+	if q.Owner != nil && !bytes.Equal(b.Owner, q.Owner) {
+		return false
+	}
+	if q.Immutable != nil && b.Immutable != *q.Immutable {
+		return false
+	}
+	if q.MinValue != nil && b.Value.Cmp(q.MinValue) < 0 {
+		return false
+	}
+	return true
+}
+
+// BatchEstimate is the projection of a planned batch under the current price.
+type BatchEstimate struct {
+	Blocks       int64   // blocks until the batch expires, -1 if it never does
+	Commitment   uint64  // chunks the batch commits to the network
+	Radius       uint8   // storage radius with the batch committed
+	ReserveShare float64 // share of a node reserve taken by the batch
+}
+
 type BatchExist interface {
 	// Exists reports whether batch referenced by the give id exists.
 	Exists([]byte) (bool, error)
@@ -102,3 +143,10 @@ type BatchEventListener interface {
 type BatchExpiryHandler interface {
 	HandleStampExpiry(context.Context, []byte) error
 }
+
+// BatchSnapshotter exports and imports the complete batch store so that
+// nodes can bootstrap the postage state without replaying chain events.
+type BatchSnapshotter interface {
+	ExportSnapshot(io.Writer) error
+	ImportSnapshot(r io.Reader, chainHead uint64) error
+}
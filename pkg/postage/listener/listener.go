@@ -7,6 +7,7 @@ package listener
 import (
 	"context"
 	"errors"
+	"math"
 	"math/big"
 	"strconv"
 	"sync"
@@ -60,6 +61,7 @@ type listener struct {
 	metrics                     metrics
 	stallingTimeout             time.Duration
 	backoffTime                 time.Duration
+	subscribeHeads              bool
 	syncingStopped              *syncutil.Signaler
 
 	// Cached postage stamp contract event topics.
@@ -79,6 +81,7 @@ func New(
 	blockTime time.Duration,
 	stallingTimeout time.Duration,
 	backoffTime time.Duration,
+	subscribeHeads bool,
 ) postage.Listener {
 	return &listener{
 		syncingStopped:              syncingStopped,
@@ -91,6 +94,7 @@ func New(
 		metrics:                     newMetrics(),
 		stallingTimeout:             stallingTimeout,
 		backoffTime:                 backoffTime,
+		subscribeHeads:              subscribeHeads,
 
 		batchCreatedTopic:       postageStampContractABI.Events["BatchCreated"].ID,
 		batchTopUpTopic:         postageStampContractABI.Events["BatchTopUp"].ID,
@@ -247,6 +251,16 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 	lastProgress := time.Now()
 	lastConfirmedBlock := uint64(0)
 
+	// with a head subscription the latest block number is pushed instead of
+	// polled, while the subscription is down the block number is polled
+	var (
+		heads      *transaction.Heads
+		latestHead uint64
+	)
+	if l.subscribeHeads {
+		heads = transaction.WatchHeads(ctx, l.logger, l.ev)
+	}
+
 	l.wg.Add(1)
 	listenf := func() error {
 		defer l.wg.Done()
@@ -267,28 +281,49 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 			// if we have a last blocknumber from the backend we can make a good estimate on when we need to requery
 			// otherwise we just use the backoff time
 			var expectedWaitTime time.Duration
+			minHead := uint64(math.MaxUint64) // head subscriptions wait for the block of the next batch, if known
 			if lastConfirmedBlock != 0 {
 				nextExpectedBatchBlock := (lastConfirmedBlock/batchFactor + 1) * batchFactor
 				remainingBlocks := nextExpectedBatchBlock - lastConfirmedBlock
 				expectedWaitTime = l.blockTime * time.Duration(remainingBlocks)
+				minHead = nextExpectedBatchBlock + tailSize
 			} else {
 				expectedWaitTime = l.backoffTime
 			}
 
-			if !paged {
+			if paged {
+				minHead = 0
+				expectedWaitTime = 0
+			} else {
 				l.logger.Debug("sleeping until next block batch", "duration", expectedWaitTime)
+			}
+
+			if heads != nil && heads.Subscribed() {
+				var err error
+				latestHead, err = waitHead(ctx, heads.C(), latestHead, minHead, expectedWaitTime)
+				if err != nil {
+					return err
+				}
+			} else if !paged {
 				select {
 				case <-time.After(expectedWaitTime):
 				case <-ctx.Done():
 					return ctx.Err()
 				}
 			}
+			if heads == nil || !heads.Subscribed() {
+				latestHead = 0
+			}
 			paged = false
 
 			start := time.Now()
 
-			l.metrics.BackendCalls.Inc()
-			to, err := l.ev.BlockNumber(ctx)
+			to := latestHead
+			var err error
+			if to == 0 {
+				l.metrics.BackendCalls.Inc()
+				to, err = l.ev.BlockNumber(ctx)
+			}
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return nil
@@ -364,6 +399,38 @@ func (l *listener) Listen(ctx context.Context, from uint64, updater postage.Even
 	return synced
 }
 
+// waitHead waits until a block number of at least minHead is received from
+// heads or the timeout elapsed and returns the latest block number received.
+func waitHead(ctx context.Context, heads <-chan uint64, latest, minHead uint64, timeout time.Duration) (uint64, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// pick up a block number already received
+	select {
+	case head, ok := <-heads:
+		if !ok {
+			return 0, ctx.Err()
+		}
+		latest = head
+	default:
+	}
+
+	for latest < minHead {
+		select {
+		case head, ok := <-heads:
+			if !ok {
+				return 0, ctx.Err()
+			}
+			latest = head
+		case <-timer.C:
+			return latest, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	return latest, nil
+}
+
 func (l *listener) Close() error {
 	close(l.quit)
 
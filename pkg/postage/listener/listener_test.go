@@ -79,6 +79,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -120,6 +121,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -161,6 +163,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 
@@ -201,6 +204,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -264,6 +268,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -344,6 +349,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			0,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -372,6 +378,7 @@ func TestListener(t *testing.T) {
 			1,
 			50*time.Millisecond,
 			0,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -399,6 +406,7 @@ func TestListener(t *testing.T) {
 			1,
 			stallingTimeout,
 			backoffTime,
+			false,
 		)
 		testutil.CleanupCloser(t, l)
 		<-l.Listen(context.Background(), 0, ev, nil)
@@ -516,6 +524,7 @@ func TestListenerBatchState(t *testing.T) {
 		1,
 		stallingTimeout,
 		backoffTime,
+		false,
 	)
 	testutil.CleanupCloser(t, l)
 	l.Listen(context.Background(), snapshot.LastBlockNumber+1, ev, snapshot)
@@ -0,0 +1,153 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	issuerExportVersion = 1
+	issuerExportKDF     = "scrypt"
+
+	exportScryptN      = 1 << 15
+	exportScryptR      = 8
+	exportScryptP      = 1
+	exportScryptKeyLen = 32
+)
+
+var (
+	// ErrExportPassword is returned when an export can not be decrypted with
+	// the given password.
+	ErrExportPassword = errors.New("invalid password or corrupted export")
+	// ErrIssuerConflict is returned when an imported stamp issuer diverged
+	// from the local stamp issuer of the same batch, so importing it risks
+	// issuing the same stamp index twice.
+	ErrIssuerConflict = errors.New("stamp issuer conflict")
+)
+
+// issuerExport is the encrypted file format of exported stamp issuers.
+type issuerExport struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"` // the sealed binary encoded issuers
+}
+
+// EncryptIssuers encodes the state of the stamp issuers, their batches,
+// owner identities and bucket counters, and encrypts it with the password.
+func EncryptIssuers(issuers []*StampIssuer, password string) ([]byte, error) {
+	plain := make([][]byte, 0, len(issuers))
+	for _, issuer := range issuers {
+		issuer.mtx.Lock()
+		data, err := issuer.MarshalBinary()
+		issuer.mtx.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("marshal issuer: %w", err)
+		}
+		plain = append(plain, data)
+	}
+	plaintext, err := json.Marshal(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("read random data: %w", err)
+	}
+	aead, err := exportCipher(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("read random data: %w", err)
+	}
+
+	return json.Marshal(issuerExport{
+		Version: issuerExportVersion,
+		KDF:     issuerExportKDF,
+		Salt:    salt,
+		Nonce:   nonce,
+		Data:    aead.Seal(nil, nonce, plaintext, nil),
+	})
+}
+
+// DecryptIssuers decrypts stamp issuers encrypted with EncryptIssuers.
+func DecryptIssuers(data []byte, password string) ([]*StampIssuer, error) {
+	var export issuerExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("unmarshal export: %w", err)
+	}
+	if export.Version != issuerExportVersion || export.KDF != issuerExportKDF {
+		return nil, fmt.Errorf("unsupported export version %d with kdf %q", export.Version, export.KDF)
+	}
+
+	aead, err := exportCipher(password, export.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(export.Nonce) != aead.NonceSize() {
+		return nil, ErrExportPassword
+	}
+	plaintext, err := aead.Open(nil, export.Nonce, export.Data, nil)
+	if err != nil {
+		return nil, ErrExportPassword
+	}
+
+	var plain [][]byte
+	if err := json.Unmarshal(plaintext, &plain); err != nil {
+		return nil, fmt.Errorf("unmarshal issuers: %w", err)
+	}
+	issuers := make([]*StampIssuer, 0, len(plain))
+	for _, data := range plain {
+		issuer := new(StampIssuer)
+		if err := issuer.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("unmarshal issuer: %w", err)
+		}
+		if len(issuer.data.Buckets) != 1<<issuer.data.BucketDepth {
+			return nil, fmt.Errorf("issuer of batch %x has %d buckets, want %d", issuer.data.BatchID, len(issuer.data.Buckets), 1<<issuer.data.BucketDepth)
+		}
+		issuers = append(issuers, issuer)
+	}
+	return issuers, nil
+}
+
+func exportCipher(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, exportScryptN, exportScryptR, exportScryptP, exportScryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// dominates reports whether every bucket counter of si is at least the
+// counter of other, meaning si continues the stamps issued by other.
+// Both must be mutex locked before usage.
+func (si *StampIssuer) dominates(other *StampIssuer) bool {
+	if len(si.data.Buckets) != len(other.data.Buckets) {
+		return false
+	}
+	for i, count := range other.data.Buckets {
+		if si.data.Buckets[i] < count {
+			return false
+		}
+	}
+	return true
+}
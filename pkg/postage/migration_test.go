@@ -0,0 +1,182 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	pstoremock "github.com/calmw/bee-tron/pkg/postage/batchstore/mock"
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncryptIssuers(t *testing.T) {
+	t.Parallel()
+
+	issuer := postage.NewStampIssuer("label", "keyID", postagetesting.MustNewID(), big.NewInt(3), 16, 8, 10, true)
+	for i := 0; i < 3; i++ {
+		if _, _, err := issuer.Increment(swarm.RandAddress(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := postage.EncryptIssuers([]*postage.StampIssuer{issuer}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = postage.DecryptIssuers(data, "wrong")
+	if !errors.Is(err, postage.ErrExportPassword) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrExportPassword)
+	}
+
+	issuers, err := postage.DecryptIssuers(data, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issuers) != 1 {
+		t.Fatalf("got %d issuers, want 1", len(issuers))
+	}
+	want, _ := issuer.MarshalBinary()
+	have, _ := issuers[0].MarshalBinary()
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("decrypted issuer mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestServiceExportImport(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T) postage.Service {
+		t.Helper()
+
+		store := inmemstore.New()
+		t.Cleanup(func() { _ = store.Close() })
+		service, err := postage.NewService(log.Noop, store, pstoremock.New(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return service
+	}
+	stamp := func(t *testing.T, issuer *postage.StampIssuer, addr swarm.Address) {
+		t.Helper()
+
+		if _, _, err := issuer.Increment(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	batchID := postagetesting.MustNewID()
+	addr := swarm.RandAddress(t)
+
+	source := newService(t)
+	issuer := postage.NewStampIssuer("label", "keyID", batchID, big.NewInt(3), 16, 8, 10, true)
+	stamp(t, issuer, addr)
+	if err := source.Add(issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	// a stamper of the issuer created before the export
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamper := postage.NewStamper(inmemstore.New(), issuer, crypto.NewDefaultSigner(privKey))
+	// an address in another bucket than the stamped one
+	other := swarm.NewAddress(append([]byte{addr.Bytes()[0] ^ 0x80}, addr.Bytes()[1:]...))
+
+	_, err = source.Export([][]byte{postagetesting.MustNewID()})
+	if !errors.Is(err, postage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrNotFound)
+	}
+
+	exported, err := source.Export([][]byte{batchID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 1 {
+		t.Fatalf("got %d exported issuers, want 1", len(exported))
+	}
+
+	// no stamps are issued with the exported issuer until the export ends
+	if _, _, err := source.GetStampIssuer(batchID); !errors.Is(err, postage.ErrFrozen) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrFrozen)
+	}
+	if _, err := stamper.Stamp(other, other); !errors.Is(err, postage.ErrFrozen) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrFrozen)
+	}
+
+	if err := source.CancelExport([][]byte{batchID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stamper.Stamp(other, other); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.ConfirmExport(context.Background(), [][]byte{batchID}); !errors.Is(err, postage.ErrNotExported) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrNotExported)
+	}
+
+	exported, err = source.Export([][]byte{batchID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the export is confirmed for all the batches or none
+	if err := source.ConfirmExport(context.Background(), [][]byte{batchID, postagetesting.MustNewID()}); !errors.Is(err, postage.ErrNotExported) {
+		t.Fatalf("got error %v, want %v", err, postage.ErrNotExported)
+	}
+	if err := source.ConfirmExport(context.Background(), [][]byte{batchID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := source.GetStampIssuer(batchID); !errors.Is(err, postage.ErrNotFound) {
+		t.Fatalf("exported issuer still present: %v", err)
+	}
+
+	t.Run("import", func(t *testing.T) {
+		t.Parallel()
+
+		target := newService(t)
+		// a recovered issuer without stamps is replaced
+		if err := target.Add(postage.NewStampIssuer("recovered", "keyID", batchID, big.NewInt(3), 16, 8, 10, true)); err != nil {
+			t.Fatal(err)
+		}
+		if err := target.Import(exported); err != nil {
+			t.Fatal(err)
+		}
+
+		u, err := target.BucketUtilization(batchID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Buckets[u.WorstBucket] != 1 {
+			t.Fatalf("got fullest bucket count %d, want 1", u.Buckets[u.WorstBucket])
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+
+		target := newService(t)
+		diverged := postage.NewStampIssuer("label", "keyID", batchID, big.NewInt(3), 16, 8, 10, true)
+		// the counter of the stamped bucket exceeds the exported one
+		stamp(t, diverged, addr)
+		stamp(t, diverged, addr)
+		if err := target.Add(diverged); err != nil {
+			t.Fatal(err)
+		}
+
+		err := target.Import(exported)
+		if !errors.Is(err, postage.ErrIssuerConflict) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrIssuerConflict)
+		}
+	})
+}
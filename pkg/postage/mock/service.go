@@ -25,6 +25,7 @@ func (f optionFunc) apply(r *mockPostage) { f(r) }
 func New(o ...Option) postage.Service {
 	m := &mockPostage{
 		issuersMap: make(map[string]*postage.StampIssuer),
+		exported:   make(map[string]struct{}),
 	}
 	for _, v := range o {
 		v.apply(m)
@@ -47,6 +48,7 @@ func WithIssuer(s *postage.StampIssuer) Option {
 
 type mockPostage struct {
 	issuersMap map[string]*postage.StampIssuer
+	exported   map[string]struct{}
 	issuerLock sync.Mutex
 	acceptAll  bool
 }
@@ -89,6 +91,9 @@ func (m *mockPostage) GetStampIssuer(id []byte) (*postage.StampIssuer, func() er
 	if !exists {
 		return nil, nil, postage.ErrNotFound
 	}
+	if _, exported := m.exported[string(id)]; exported {
+		return nil, nil, postage.ErrFrozen
+	}
 
 	return i, func() error {
 		return nil
@@ -99,6 +104,78 @@ func (m *mockPostage) IssuerUsable(_ *postage.StampIssuer) bool {
 	return true
 }
 
+func (m *mockPostage) BucketUtilization(id []byte) (*postage.BucketUtilization, error) {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	i, exists := m.issuersMap[string(id)]
+	if !exists {
+		return nil, postage.ErrNotFound
+	}
+	return i.BucketUtilization(), nil
+}
+
+func (m *mockPostage) Export(ids [][]byte) ([]*postage.StampIssuer, error) {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	issuers := make([]*postage.StampIssuer, 0, len(ids))
+	for _, id := range ids {
+		i, exists := m.issuersMap[string(id)]
+		if !exists {
+			return nil, postage.ErrNotFound
+		}
+		issuers = append(issuers, i)
+	}
+	for _, id := range ids {
+		m.exported[string(id)] = struct{}{}
+	}
+	return issuers, nil
+}
+
+func (m *mockPostage) ConfirmExport(_ context.Context, ids [][]byte) error {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	for _, id := range ids {
+		if _, exists := m.exported[string(id)]; !exists {
+			return postage.ErrNotExported
+		}
+	}
+	for _, id := range ids {
+		delete(m.exported, string(id))
+		delete(m.issuersMap, string(id))
+	}
+	return nil
+}
+
+func (m *mockPostage) CancelExport(ids [][]byte) error {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	for _, id := range ids {
+		if _, exists := m.exported[string(id)]; !exists {
+			return postage.ErrNotExported
+		}
+	}
+	for _, id := range ids {
+		delete(m.exported, string(id))
+	}
+	return nil
+}
+
+func (m *mockPostage) Import(issuers []*postage.StampIssuer) error {
+	m.issuerLock.Lock()
+	defer m.issuerLock.Unlock()
+
+	for _, i := range issuers {
+		m.issuersMap[string(i.ID())] = i
+	}
+	return nil
+}
+
+func (m *mockPostage) SetFrozen(_ []byte, _ bool) {}
+
 func (m *mockPostage) HandleCreate(_ *postage.Batch, _ *big.Int) error { return nil }
 
 func (m *mockPostage) HandleTopUp(_ []byte, _ *big.Int) {}
@@ -26,6 +26,12 @@ func (b *NoOpBatchStore) Exists([]byte) (bool, error) { return false, nil }
 
 func (b *NoOpBatchStore) Iterate(func(*Batch) (bool, error)) error { return nil }
 
+func (b *NoOpBatchStore) Query(BatchQuery) ([]*Batch, []byte, error) { return nil, nil, nil }
+
+func (b *NoOpBatchStore) Estimate(uint8, *big.Int) (*BatchEstimate, error) {
+	return nil, ErrChainDisabled
+}
+
 func (b *NoOpBatchStore) Save(*Batch) error { return nil }
 
 func (b *NoOpBatchStore) Update(*Batch, *big.Int, uint8) error { return nil }
@@ -171,6 +171,8 @@ func (c *postageContract) sendApproveTransaction(ctx context.Context, amount *bi
 		Data:        callData,
 		GasPrice:    sctx.GetGasPrice(ctx),
 		GasLimit:    max(sctx.GetGasLimit(ctx), c.gasLimit),
+		GasTipCap:   sctx.GetGasTip(ctx),
+		Nonce:       sctx.GetNonce(ctx),
 		Value:       big.NewInt(0),
 		Description: approveDescription,
 	}
@@ -201,16 +203,32 @@ func (c *postageContract) sendApproveTransaction(ctx context.Context, amount *bi
 	return receipt, nil
 }
 
+// nextNonce returns the context with the nonce following the explicit nonce
+// of the previous transaction, if the nonce was given.
+func nextNonce(ctx context.Context) context.Context {
+	if nonce := sctx.GetNonce(ctx); nonce != nil {
+		next := *nonce + 1
+		return sctx.SetNonce(ctx, &next)
+	}
+	return ctx
+}
+
 func (c *postageContract) sendTransaction(ctx context.Context, callData []byte, desc string) (receipt *types.Receipt, err error) {
 	request := &transaction.TxRequest{
 		To:          &c.postageStampContractAddress,
 		Data:        callData,
 		GasPrice:    sctx.GetGasPrice(ctx),
 		GasLimit:    max(sctx.GetGasLimit(ctx), c.gasLimit),
+		GasTipCap:   sctx.GetGasTip(ctx),
+		Nonce:       sctx.GetNonce(ctx),
 		Value:       big.NewInt(0),
 		Description: desc,
 	}
 
+	if err := c.transactionService.Simulate(ctx, request, c.postageStampContractABI.Errors); err != nil {
+		return nil, err
+	}
+
 	defer func() {
 		err = c.transactionService.UnwrapABIError(
 			ctx,
@@ -370,15 +388,20 @@ func (c *postageContract) CreateBatch(ctx context.Context, initialBalance *big.I
 		return
 	}
 
-	err = c.ExpireBatches(ctx)
-	if err != nil {
-		return
+	// expiring batches sends a varying number of transactions, so it is left
+	// to later calls when the transaction nonces are given explicitly
+	if sctx.GetNonce(ctx) == nil {
+		err = c.ExpireBatches(ctx)
+		if err != nil {
+			return
+		}
 	}
 
 	_, err = c.sendApproveTransaction(ctx, totalAmount)
 	if err != nil {
 		return
 	}
+	ctx = nextNonce(ctx)
 
 	nonce := make([]byte, 32)
 	_, err = rand.Read(nonce)
@@ -444,6 +467,7 @@ func (c *postageContract) TopUpBatch(ctx context.Context, batchID []byte, topupB
 	if err != nil {
 		return
 	}
+	ctx = nextNonce(ctx)
 
 	receipt, err := c.sendTopUpBatchTransaction(ctx, batch.ID, topupBalance)
 	if err != nil {
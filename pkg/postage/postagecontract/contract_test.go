@@ -23,9 +23,13 @@ import (
 	"github.com/calmw/bee-tron/pkg/util/abiutil"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/go-sw3-abi/sw3abi"
 )
 
-var postageStampContractABI = abiutil.MustParseABI(chaincfg.Testnet.PostageStampABI)
+var (
+	postageStampContractABI = abiutil.MustParseABI(chaincfg.Testnet.PostageStampABI)
+	erc20ABI                = abiutil.MustParseABI(sw3abi.ERC20ABIv0_6_5)
+)
 
 func TestCreateBatch(t *testing.T) {
 	defer func(b uint8) {
@@ -256,6 +260,43 @@ func TestCreateBatch(t *testing.T) {
 		}
 	})
 
+	t.Run("simulation reverted", func(t *testing.T) {
+		depth := uint8(10)
+
+		simulator := transactionMock.NewSimulator()
+		simulator.Contract(bzzTokenAddress, &erc20ABI).Set("balanceOf", big.NewInt(102400))
+		stamp := simulator.Contract(postageStampAddress, &postageStampContractABI)
+		stamp.Set("lastPrice", uint64(2))
+		stamp.Set("minimumValidityBlocks", uint64(25))
+		stamp.Set("expiredBatchesExist", false)
+		stamp.RevertIf("createBatch", "Pausable: paused", nil)
+
+		contract := postagecontract.New(
+			owner,
+			postageStampAddress,
+			postageStampContractABI,
+			bzzTokenAddress,
+			transactionMock.New(transactionMock.WithSimulator(simulator)),
+			postageMock.New(),
+			postagestoreMock.New(),
+			true,
+			false,
+		)
+
+		_, _, err := contract.CreateBatch(ctx, initialBalance, depth, false, label)
+		var revertErr *transaction.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("expected revert error. got %v", err)
+		}
+		if revertErr.Reason != "Pausable: paused" {
+			t.Fatalf("got revert reason %q", revertErr.Reason)
+		}
+
+		// only the approval was sent
+		if sent := simulator.Sent(); len(sent) != 1 || *sent[0].To != bzzTokenAddress {
+			t.Fatalf("got sent transactions %+v", sent)
+		}
+	})
 }
 
 func newCreateEvent(postageContractAddress common.Address, batchId common.Hash) *types.Log {
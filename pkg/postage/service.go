@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"slices"
 	"sync"
 
 	"github.com/calmw/bee-tron/pkg/log"
@@ -32,6 +33,12 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrNotUsable is the error returned when issuer with given batch ID is not usable.
 	ErrNotUsable = errors.New("not usable")
+	// ErrFrozen is the error returned when issuer with given batch ID is frozen
+	// because the batch is about to expire or is being exported.
+	ErrFrozen = fmt.Errorf("%w: frozen", ErrNotUsable)
+	// ErrNotExported is the error returned when an export of a batch which
+	// is not being exported is confirmed or canceled.
+	ErrNotExported = errors.New("not exported")
 )
 
 // Service is the postage service interface.
@@ -40,6 +47,25 @@ type Service interface {
 	StampIssuers() []*StampIssuer
 	GetStampIssuer([]byte) (*StampIssuer, func() error, error)
 	IssuerUsable(*StampIssuer) bool
+	// BucketUtilization returns the bucket utilization of the batch, whether
+	// it is usable or not.
+	BucketUtilization(batchID []byte) (*BucketUtilization, error)
+	// Export freezes the stamp issuers of the batches, so no more stamps are
+	// issued with them, and returns their snapshots for an import on another
+	// node. The issuers are kept until the export is confirmed or canceled.
+	Export(batchIDs [][]byte) ([]*StampIssuer, error)
+	// ConfirmExport removes the exported stamp issuers of the batches once
+	// the export was handed over. Either all of them are removed or none.
+	ConfirmExport(ctx context.Context, batchIDs [][]byte) error
+	// CancelExport resumes issuing stamps with the exported stamp issuers of
+	// the batches.
+	CancelExport(batchIDs [][]byte) error
+	// Import adds stamp issuers exported by another node. It fails with
+	// ErrIssuerConflict if a local issuer of the same batch issued stamps
+	// unknown to the imported one.
+	Import(issuers []*StampIssuer) error
+	// SetFrozen sets whether new stamps may be issued with the batch.
+	SetFrozen(batchID []byte, frozen bool)
 	BatchEventListener
 	BatchExpiryHandler
 	io.Closer
@@ -54,6 +80,8 @@ type service struct {
 	postageStore Storer
 	chainID      int64
 	issuers      []*StampIssuer
+	frozen       map[string]struct{}
+	exported     map[string]*StampIssuer // the issuers being exported
 }
 
 // NewService constructs a new Service.
@@ -63,6 +91,8 @@ func NewService(logger log.Logger, store storage.Store, postageStore Storer, cha
 		store:        store,
 		postageStore: postageStore,
 		chainID:      chainID,
+		frozen:       make(map[string]struct{}),
+		exported:     make(map[string]*StampIssuer),
 	}
 
 	return s, s.store.Iterate(
@@ -112,7 +142,9 @@ func (ps *service) HandleTopUp(batchID []byte, amount *big.Int) {
 
 	for _, v := range ps.issuers {
 		if bytes.Equal(v.data.BatchID, batchID) {
+			v.mtx.Lock()
 			v.data.BatchAmount.Add(v.data.BatchAmount, amount)
+			v.mtx.Unlock()
 			return
 		}
 	}
@@ -124,9 +156,11 @@ func (ps *service) HandleDepthIncrease(batchID []byte, newDepth uint8) {
 
 	for _, v := range ps.issuers {
 		if bytes.Equal(batchID, v.data.BatchID) {
+			v.mtx.Lock()
 			if newDepth > v.data.BatchDepth {
 				v.data.BatchDepth = newDepth
 			}
+			v.mtx.Unlock()
 			return
 		}
 	}
@@ -152,6 +186,18 @@ func (ps *service) IssuerUsable(st *StampIssuer) bool {
 	return true
 }
 
+// SetFrozen sets whether new stamps may be issued with the batch.
+func (ps *service) SetFrozen(batchID []byte, frozen bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if frozen {
+		ps.frozen[string(batchID)] = struct{}{}
+	} else {
+		delete(ps.frozen, string(batchID))
+	}
+}
+
 // GetStampIssuer finds a stamp issuer by batch ID.
 func (ps *service) GetStampIssuer(batchID []byte) (*StampIssuer, func() error, error) {
 	ps.mtx.Lock()
@@ -159,12 +205,22 @@ func (ps *service) GetStampIssuer(batchID []byte) (*StampIssuer, func() error, e
 
 	for _, st := range ps.issuers {
 		if bytes.Equal(batchID, st.data.BatchID) {
+			if _, ok := ps.exported[string(batchID)]; ok {
+				return nil, nil, ErrFrozen
+			}
 			if !ps.IssuerUsable(st) {
 				return nil, nil, ErrNotUsable
 			}
+			if _, ok := ps.frozen[string(batchID)]; ok {
+				return nil, nil, ErrFrozen
+			}
 			return st, func() error {
 				ps.mtx.Lock()
 				defer ps.mtx.Unlock()
+				if !slices.Contains(ps.issuers, st) {
+					// the issuer was exported in the meantime
+					return nil
+				}
 				return ps.save(st)
 			}, nil
 		}
@@ -172,6 +228,153 @@ func (ps *service) GetStampIssuer(batchID []byte) (*StampIssuer, func() error, e
 	return nil, nil, ErrNotFound
 }
 
+// BucketUtilization returns the bucket utilization of the batch.
+func (ps *service) BucketUtilization(batchID []byte) (*BucketUtilization, error) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	for _, st := range ps.issuers {
+		if bytes.Equal(batchID, st.data.BatchID) {
+			return st.BucketUtilization(), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Export implements the Service interface.
+func (ps *service) Export(batchIDs [][]byte) ([]*StampIssuer, error) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	issuers := make([]*StampIssuer, 0, len(batchIDs))
+	for _, batchID := range batchIDs {
+		i := slices.IndexFunc(ps.issuers, func(st *StampIssuer) bool {
+			return bytes.Equal(batchID, st.data.BatchID)
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("batch %s: %w", hex.EncodeToString(batchID), ErrNotFound)
+		}
+		issuers = append(issuers, ps.issuers[i])
+	}
+
+	snapshots := make([]*StampIssuer, 0, len(issuers))
+	for _, st := range issuers {
+		// the write lock waits for the stamps being issued, so the snapshot
+		// holds all the stamps issued with the issuer, also with the stampers
+		// created before the export
+		st.mtx.Lock()
+		st.exported = true
+		snapshots = append(snapshots, &StampIssuer{data: st.data.Clone()})
+		st.mtx.Unlock()
+		ps.exported[string(st.data.BatchID)] = st
+	}
+	return snapshots, nil
+}
+
+// ConfirmExport implements the Service interface.
+func (ps *service) ConfirmExport(ctx context.Context, batchIDs [][]byte) error {
+	ps.mtx.Lock()
+
+	issuers, err := ps.exportedIssuers(batchIDs)
+	if err != nil {
+		ps.mtx.Unlock()
+		return err
+	}
+
+	for j, st := range issuers {
+		if err := ps.store.Delete(&StampIssuerItem{Issuer: st}); err != nil {
+			// restore the issuers removed so far
+			for _, st := range issuers[:j] {
+				err = errors.Join(err, ps.store.Put(&StampIssuerItem{Issuer: st}))
+			}
+			ps.mtx.Unlock()
+			return fmt.Errorf("confirm export: delete stamp data for batch %s: %w", hex.EncodeToString(st.ID()), err)
+		}
+	}
+	for _, st := range issuers {
+		ps.issuers = slices.DeleteFunc(ps.issuers, func(v *StampIssuer) bool { return v == st })
+		delete(ps.exported, string(st.data.BatchID))
+		delete(ps.frozen, string(st.data.BatchID))
+	}
+	ps.mtx.Unlock()
+
+	for _, st := range issuers {
+		if err := ps.removeStampItems(ctx, st.data.BatchID); err != nil {
+			ps.logger.Warning("confirm export: remove stamp items failed", "batch_id", hex.EncodeToString(st.ID()), "error", err)
+		}
+	}
+	return nil
+}
+
+// CancelExport implements the Service interface.
+func (ps *service) CancelExport(batchIDs [][]byte) error {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	issuers, err := ps.exportedIssuers(batchIDs)
+	if err != nil {
+		return err
+	}
+	for _, st := range issuers {
+		st.mtx.Lock()
+		st.exported = false
+		st.mtx.Unlock()
+		delete(ps.exported, string(st.data.BatchID))
+	}
+	return nil
+}
+
+// exportedIssuers returns the stamp issuers being exported of all the
+// batches. Must be mutex locked before usage.
+func (ps *service) exportedIssuers(batchIDs [][]byte) ([]*StampIssuer, error) {
+	issuers := make([]*StampIssuer, 0, len(batchIDs))
+	for _, batchID := range batchIDs {
+		st, ok := ps.exported[string(batchID)]
+		if !ok {
+			return nil, fmt.Errorf("batch %s: %w", hex.EncodeToString(batchID), ErrNotExported)
+		}
+		issuers = append(issuers, st)
+	}
+	return issuers, nil
+}
+
+// Import implements the Service interface.
+func (ps *service) Import(issuers []*StampIssuer) error {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	local := make([]int, len(issuers))
+	for j, imported := range issuers {
+		local[j] = slices.IndexFunc(ps.issuers, func(st *StampIssuer) bool {
+			return bytes.Equal(imported.data.BatchID, st.data.BatchID)
+		})
+		if local[j] < 0 {
+			continue
+		}
+
+		st := ps.issuers[local[j]]
+		st.mtx.Lock()
+		ok := imported.dominates(st)
+		st.mtx.Unlock()
+		if !ok {
+			return fmt.Errorf("%w: batch %s", ErrIssuerConflict, hex.EncodeToString(imported.ID()))
+		}
+	}
+
+	for j, imported := range issuers {
+		if local[j] < 0 {
+			ps.issuers = append(ps.issuers, imported)
+		} else {
+			ps.issuers[local[j]] = imported
+		}
+		delete(ps.exported, string(imported.data.BatchID))
+		if err := ps.save(imported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // save persists the specified stamp issuer to the stamperstore.
 func (ps *service) save(st *StampIssuer) error {
 	st.mtx.Lock()
@@ -249,6 +452,9 @@ func (ps *service) removeIssuer(batchID []byte) (bool, error) {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
+	delete(ps.frozen, string(batchID))
+	delete(ps.exported, string(batchID))
+
 	for i, issuer := range ps.issuers {
 		if bytes.Equal(batchID, issuer.data.BatchID) {
 			if err := ps.store.Delete(&StampIssuerItem{Issuer: issuer}); err != nil {
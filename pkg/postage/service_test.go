@@ -158,6 +158,21 @@ func TestGetStampIssuer(t *testing.T) {
 			}
 		}
 	})
+	t.Run("bucket utilization", func(t *testing.T) {
+		// the utilization is reported for batches that are not usable yet
+		u, err := ps.BucketUtilization(ids[4])
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(u.Buckets) != 256 || u.BucketUpperBound != 256 || u.Remaining != 256 {
+			t.Fatalf("unexpected utilization %+v", u)
+		}
+
+		_, err = ps.BucketUtilization(ids[0])
+		if !errors.Is(err, postage.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
 	t.Run("recovered", func(t *testing.T) {
 		b := postagetesting.MustNewBatch()
 		b.Start = validBlockNumber
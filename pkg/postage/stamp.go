@@ -188,9 +188,25 @@ type ValidStampFn func(chunk swarm.Chunk) (swarm.Chunk, error)
 
 // ValidStamp returns a stampvalidator function passed to protocols with chunk entrypoints.
 func ValidStamp(batchStore Storer) ValidStampFn {
+	return validStamp(batchStore, nil)
+}
+
+// CachedValidStamp returns a stampvalidator function like ValidStamp which
+// caches the batches and the recovered signers of the size most recently
+// validated stamps, so chunks arriving repeatedly are validated without
+// ECDSA recovery.
+func CachedValidStamp(batchStore Storer, size int) (ValidStampFn, error) {
+	cache, err := newStampCache(size)
+	if err != nil {
+		return nil, err
+	}
+	return validStamp(batchStore, cache), nil
+}
+
+func validStamp(batchStore Storer, cache *stampCache) ValidStampFn {
 	return func(chunk swarm.Chunk) (swarm.Chunk, error) {
 		stamp := chunk.Stamp()
-		b, err := batchStore.Get(stamp.BatchID())
+		b, err := cache.batch(batchStore, stamp.BatchID())
 		if err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
 				return nil, fmt.Errorf("batchstore get: %w, %w", err, ErrNotFound)
@@ -198,7 +214,12 @@ func ValidStamp(batchStore Storer) ValidStampFn {
 			return nil, err
 		}
 
-		if err = NewStamp(stamp.BatchID(), stamp.Index(), stamp.Timestamp(), stamp.Sig()).Valid(chunk.Address(), b.Owner, b.Depth, b.BucketDepth, b.Immutable); err != nil {
+		s := NewStamp(stamp.BatchID(), stamp.Index(), stamp.Timestamp(), stamp.Sig())
+		signerAddr, err := cache.signer(chunk.Address(), s)
+		if err != nil {
+			return nil, err
+		}
+		if err = s.valid(chunk.Address(), signerAddr, b.Owner, b.Depth, b.BucketDepth); err != nil {
 			return nil, err
 		}
 		return chunk.WithStamp(stamp).WithBatch(b.Depth, b.BucketDepth, b.Immutable), nil
@@ -215,6 +236,11 @@ func (s *Stamp) Valid(chunkAddr swarm.Address, ownerAddr []byte, depth, bucketDe
 	if err != nil {
 		return err
 	}
+	return s.valid(chunkAddr, signerAddr, ownerAddr, depth, bucketDepth)
+}
+
+// valid checks the validity of the postage stamp signed by signerAddr.
+func (s *Stamp) valid(chunkAddr swarm.Address, signerAddr, ownerAddr []byte, depth, bucketDepth uint8) error {
 	bucket, index := BucketIndexFromBytes(s.index)
 	if toBucket(bucketDepth, chunkAddr) != bucket {
 		return ErrBucketMismatch
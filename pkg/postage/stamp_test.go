@@ -7,6 +7,7 @@ package postage_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -146,3 +147,73 @@ func TestValidStamp(t *testing.T) {
 		t.Fatalf("invalid batch immutablility added on chunk exp %t got %t", b.Immutable, ch.Immutable())
 	}
 }
+
+func TestCachedValidStamp(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := crypto.NewEthereumAddress(privKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := postagetesting.MustNewBatch(postagetesting.WithOwner(owner))
+	bs := mock.New(mock.WithBatch(b))
+	issuer := postage.NewStampIssuer("label", "keyID", b.ID, big.NewInt(3), b.Depth, b.BucketDepth, 1000, true)
+	stamper := postage.NewStamper(inmemstore.New(), issuer, crypto.NewDefaultSigner(privKey))
+
+	validStamp, err := postage.CachedValidStamp(bs, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := chunktesting.GenerateTestRandomChunk()
+	idAddress, err := storage.IdentityAddress(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := stamper.Stamp(ch.Address(), idAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// validating the same stamp repeatedly is served by the cache
+	for i := 0; i < 3; i++ {
+		got, err := validStamp(ch.WithStamp(st))
+		if err != nil {
+			t.Fatal(err)
+		}
+		compareStamps(t, st, got.Stamp().(*postage.Stamp))
+		if got.Depth() != b.Depth || got.BucketDepth() != b.BucketDepth {
+			t.Fatalf("got depth %d bucket depth %d, want %d %d", got.Depth(), got.BucketDepth(), b.Depth, b.BucketDepth)
+		}
+	}
+
+	t.Run("other signer", func(t *testing.T) {
+		t.Parallel()
+
+		otherKey, err := crypto.GenerateSecp256k1Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherIssuer := postage.NewStampIssuer("label", "keyID", b.ID, big.NewInt(3), b.Depth, b.BucketDepth, 1000, true)
+		other, err := postage.NewStamper(inmemstore.New(), otherIssuer, crypto.NewDefaultSigner(otherKey)).Stamp(ch.Address(), idAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := validStamp(ch.WithStamp(other)); !errors.Is(err, postage.ErrOwnerMismatch) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrOwnerMismatch)
+		}
+	})
+
+	t.Run("unknown batch", func(t *testing.T) {
+		t.Parallel()
+
+		unknown := postagetesting.MustNewStamp()
+		if _, err := validStamp(chunktesting.GenerateTestRandomChunk().WithStamp(unknown)); !errors.Is(err, postage.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, postage.ErrNotFound)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postage
+
+import (
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// stampCacheBatchTTL is how long batches are cached. It is kept short so
+// that expired batches and depth increases are noticed quickly.
+const stampCacheBatchTTL = 10 * time.Second
+
+// stampCache caches the lookups of stamp validation. A nil cache passes all
+// lookups through.
+type stampCache struct {
+	signers *lru.Cache[string, []byte]      // signer addresses by chunk address and stamp
+	batches *lru.Cache[string, cachedBatch] // batches by ID
+	now     func() time.Time
+}
+
+type cachedBatch struct {
+	batch   *Batch
+	expires time.Time
+}
+
+func newStampCache(size int) (*stampCache, error) {
+	signers, err := lru.New[string, []byte](size)
+	if err != nil {
+		return nil, err
+	}
+	batches, err := lru.New[string, cachedBatch](size)
+	if err != nil {
+		return nil, err
+	}
+	return &stampCache{
+		signers: signers,
+		batches: batches,
+		now:     time.Now,
+	}, nil
+}
+
+// batch returns the batch with the ID. Unknown batches are not cached so
+// new batches are accepted as soon as they are stored.
+func (c *stampCache) batch(batchStore Storer, id []byte) (*Batch, error) {
+	if c == nil {
+		return batchStore.Get(id)
+	}
+
+	if cached, ok := c.batches.Get(string(id)); ok && c.now().Before(cached.expires) {
+		return cached.batch, nil
+	}
+
+	b, err := batchStore.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	c.batches.Add(string(id), cachedBatch{batch: b, expires: c.now().Add(stampCacheBatchTTL)})
+	return b, nil
+}
+
+// signer returns the address which signed the stamp of the chunk.
+func (c *stampCache) signer(chunkAddr swarm.Address, stamp *Stamp) ([]byte, error) {
+	if c == nil {
+		return RecoverBatchOwner(chunkAddr, stamp)
+	}
+
+	stampBytes, err := stamp.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	key := string(chunkAddr.Bytes()) + string(stampBytes)
+	if signer, ok := c.signers.Get(key); ok {
+		return signer, nil
+	}
+
+	signer, err := RecoverBatchOwner(chunkAddr, stamp)
+	if err != nil {
+		return nil, err
+	}
+	c.signers.Add(key, signer)
+	return signer, nil
+}
@@ -26,30 +26,86 @@ type Stamper interface {
 	BatchId() []byte
 }
 
+// IndexStrategy selects the address under which the stamp index of a chunk
+// is kept. Chunks selecting the same address are stamped with the same bucket
+// slot, the stamp with the newer timestamp superseding the older one. A new
+// slot is issued if the kept index belongs to another bucket than the chunk.
+type IndexStrategy func(addr, idAddr swarm.Address) swarm.Address
+
+// IdentityIndexStrategy issues a slot for every distinct chunk. It is the
+// default strategy of the stampers.
+func IdentityIndexStrategy(_, idAddr swarm.Address) swarm.Address {
+	return idAddr
+}
+
+// SOCIndexStrategy issues a slot per chunk address, so that repeated updates
+// of the same single owner chunk reuse the slot of the first update instead
+// of exhausting the bucket of immutable batches.
+func SOCIndexStrategy(addr, _ swarm.Address) swarm.Address {
+	return addr
+}
+
+// StamperOption configures a Stamper.
+type StamperOption func(*stamper)
+
+// WithIndexStrategy sets the strategy selecting the bucket slots of chunks.
+func WithIndexStrategy(strategy IndexStrategy) StamperOption {
+	return func(st *stamper) {
+		st.strategy = strategy
+	}
+}
+
 // stamper connects a stampissuer with a signer.
 // A stamper is created for each upload session.
 type stamper struct {
-	store  storage.Store
-	issuer *StampIssuer
-	signer crypto.Signer
+	store    storage.Store
+	issuer   *StampIssuer
+	signer   crypto.Signer
+	strategy IndexStrategy
 }
 
 // NewStamper constructs a Stamper.
-func NewStamper(store storage.Store, issuer *StampIssuer, signer crypto.Signer) Stamper {
-	return &stamper{store, issuer, signer}
+func NewStamper(store storage.Store, issuer *StampIssuer, signer crypto.Signer, opts ...StamperOption) Stamper {
+	st := &stamper{
+		store:    store,
+		issuer:   issuer,
+		signer:   signer,
+		strategy: IdentityIndexStrategy,
+	}
+	for _, opt := range opts {
+		opt(st)
+	}
+	return st
 }
 
 // Stamp takes chunk, see if the chunk can be included in the batch and
 // signs it with the owner of the batch of this Stamp issuer.
 func (st *stamper) Stamp(addr, idAddr swarm.Address) (*Stamp, error) {
-	st.issuer.mtx.Lock()
-	defer st.issuer.mtx.Unlock()
+	st.issuer.mtx.RLock()
+	defer st.issuer.mtx.RUnlock()
+
+	if st.issuer.exported {
+		return nil, ErrFrozen
+	}
+
+	// the identity address determines the chunk address, so stamping the
+	// same chunk twice is serialized by the shard of its bucket
+	bucket := toBucket(st.issuer.BucketDepth(), addr)
+	shard := st.issuer.shard(bucket)
+	shard.Lock()
+	defer shard.Unlock()
 
 	item := &StampItem{
 		BatchID:      st.issuer.data.BatchID,
-		chunkAddress: idAddr,
+		chunkAddress: st.strategy(addr, idAddr),
+	}
+	err := st.store.Get(item)
+	if err == nil {
+		if b, _ := BucketIndexFromBytes(item.BatchIndex); b != bucket {
+			err = storage.ErrNotFound
+		}
 	}
-	switch err := st.store.Get(item); {
+	switch {
 	case err == nil:
 		item.BatchTimestamp = unixTime()
 		if err = st.store.Put(item); err != nil {
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"errors"
 	"math/big"
+	"sync"
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/crypto"
@@ -17,6 +18,170 @@ import (
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
+// TestStamperConcurrentStamping tests that stamping in parallel issues every
+// stamp index once and stamps the same chunk with the same index.
+func TestStamperConcurrentStamping(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+
+	const (
+		workers = 16
+		chunks  = 64
+	)
+
+	st := postage.NewStampIssuer("label", "keyID", make([]byte, 32), big.NewInt(3), 16, 8, 0, true)
+	stamper := postage.NewStamper(inmemstore.New(), st, signer)
+	shared := swarm.RandAddress(t)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		indices = make(map[string]struct{})
+		sharedC = make(chan []byte, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			stamp, err := stamper.Stamp(shared, shared)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			sharedC <- stamp.Index()
+
+			for j := 0; j < chunks; j++ {
+				addr := swarm.RandAddress(t)
+				stamp, err := stamper.Stamp(addr, addr)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				indices[string(stamp.Index())] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(sharedC)
+
+	sharedIndex := <-sharedC
+	for index := range sharedC {
+		if !bytes.Equal(index, sharedIndex) {
+			t.Fatalf("shared chunk stamped with indices %x and %x", sharedIndex, index)
+		}
+	}
+	if _, ok := indices[string(sharedIndex)]; ok {
+		t.Fatalf("index %x issued twice", sharedIndex)
+	}
+	if len(indices) != workers*chunks {
+		t.Fatalf("got %d distinct indices, want %d", len(indices), workers*chunks)
+	}
+
+	var total uint32
+	for _, count := range st.Buckets() {
+		total += count
+	}
+	if total != workers*chunks+1 {
+		t.Fatalf("got %d stamps counted, want %d", total, workers*chunks+1)
+	}
+}
+
+// TestStamperIndexStrategy tests that chunks selecting the same address with
+// the index strategy reuse their bucket slot.
+func TestStamperIndexStrategy(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := crypto.NewEthereumAddress(privKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+
+	// collision depth is 8, batch depth is 12, bucket volume 2^4
+	const updates = 1<<4 + 1
+	newIssuer := func(t *testing.T) *postage.StampIssuer {
+		t.Helper()
+		return postage.NewStampIssuer("", "", newTestStampIssuer(t, 1000).ID(), big.NewInt(3), 12, 8, 1000, true)
+	}
+
+	t.Run("soc updates", func(t *testing.T) {
+		t.Parallel()
+
+		st := newIssuer(t)
+		stamper := postage.NewStamper(inmemstore.New(), st, signer, postage.WithIndexStrategy(postage.SOCIndexStrategy))
+		addr := swarm.RandAddress(t)
+
+		var index []byte
+		for i := 0; i < updates; i++ {
+			stamp, err := stamper.Stamp(addr, swarm.RandAddress(t))
+			if err != nil {
+				t.Fatalf("update %d: %v", i, err)
+			}
+			if err := stamp.Valid(addr, owner, 12, 8, true); err != nil {
+				t.Fatalf("update %d: %v", i, err)
+			}
+			if index == nil {
+				index = stamp.Index()
+			}
+			if !bytes.Equal(stamp.Index(), index) {
+				t.Fatalf("update %d stamped with index %x, want %x", i, stamp.Index(), index)
+			}
+		}
+	})
+
+	t.Run("identity updates", func(t *testing.T) {
+		t.Parallel()
+
+		st := newIssuer(t)
+		stamper := postage.NewStamper(inmemstore.New(), st, signer)
+		addr := swarm.RandAddress(t)
+
+		for i := 0; i < updates-1; i++ {
+			if _, err := stamper.Stamp(addr, swarm.RandAddress(t)); err != nil {
+				t.Fatalf("update %d: %v", i, err)
+			}
+		}
+		if _, err := stamper.Stamp(addr, swarm.RandAddress(t)); !errors.Is(err, postage.ErrBucketFull) {
+			t.Fatalf("expected ErrBucketFull, got %v", err)
+		}
+	})
+
+	t.Run("other bucket", func(t *testing.T) {
+		t.Parallel()
+
+		key := swarm.RandAddress(t)
+		st := newIssuer(t)
+		stamper := postage.NewStamper(inmemstore.New(), st, signer, postage.WithIndexStrategy(func(_, _ swarm.Address) swarm.Address {
+			return key
+		}))
+
+		a := swarm.RandAddress(t)
+		b := a.Clone()
+		b.Bytes()[0] ^= 0xff
+		for _, addr := range []swarm.Address{a, b} {
+			stamp, err := stamper.Stamp(addr, addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := stamp.Valid(addr, owner, 12, 8, true); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+	})
+}
+
 // TestStamperStamping tests if the stamp created by the stamper is valid.
 func TestStamperStamping(t *testing.T) {
 	t.Parallel()
@@ -71,7 +236,8 @@ func TestStamperStamping(t *testing.T) {
 		// issue 1 stamp
 		chunkAddr, _ := createStamp(t, stamper)
 		// issue another 15
-		// collision depth is 8, committed batch depth is 12, bucket volume 2^4
+		// collision depth is 8, the stamps are validated with batch depth 11,
+		// so the bucket volume is 2^3 and the last index is out of range
 		for i := 0; i < 14; i++ {
 			randAddr := swarm.RandAddressAt(t, chunkAddr, 8)
 			_, err = stamper.Stamp(randAddr, randAddr)
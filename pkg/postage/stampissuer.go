@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"path"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	storage "github.com/calmw/bee-tron/pkg/storage"
@@ -135,24 +136,36 @@ type stampIssuerData struct {
 // Clone returns a deep copy of the stampIssuerData.
 func (s stampIssuerData) Clone() stampIssuerData {
 	return stampIssuerData{
-		Label:         s.Label,
-		KeyID:         s.KeyID,
-		BatchID:       append([]byte(nil), s.BatchID...),
-		BatchAmount:   new(big.Int).Set(s.BatchAmount),
-		BatchDepth:    s.BatchDepth,
-		BucketDepth:   s.BucketDepth,
-		Buckets:       append([]uint32(nil), s.Buckets...),
-		BlockNumber:   s.BlockNumber,
-		ImmutableFlag: s.ImmutableFlag,
+		Label:          s.Label,
+		KeyID:          s.KeyID,
+		BatchID:        append([]byte(nil), s.BatchID...),
+		BatchAmount:    new(big.Int).Set(s.BatchAmount),
+		BatchDepth:     s.BatchDepth,
+		BucketDepth:    s.BucketDepth,
+		Buckets:        append([]uint32(nil), s.Buckets...),
+		MaxBucketCount: s.MaxBucketCount,
+		BlockNumber:    s.BlockNumber,
+		ImmutableFlag:  s.ImmutableFlag,
 	}
 }
 
+// stampIssuerShards is the number of locks the stamping of the buckets of a
+// stamp issuer is sharded over.
+const stampIssuerShards = 256
+
 // StampIssuer is a local extension of a batch issuing stamps for uploads.
 // A StampIssuer instance extends a batch with bucket collision tracking
 // embedded in multiple Stampers, can be used concurrently.
+//
+// Stamping read locks mtx and locks the shard of the bucket of the chunk, so
+// chunks falling into different buckets are stamped in parallel. The bucket
+// counters are accessed atomically so they can be read while stamping;
+// operations on the whole issuer data write lock mtx.
 type StampIssuer struct {
-	data stampIssuerData
-	mtx  sync.Mutex
+	data     stampIssuerData
+	mtx      sync.RWMutex
+	shards   [stampIssuerShards]sync.Mutex
+	exported bool // no more stamps are issued once the issuer is exported
 }
 
 // NewStampIssuer constructs a StampIssuer as an extension of a batch for local
@@ -175,12 +188,18 @@ func NewStampIssuer(label, keyID string, batchID []byte, batchAmount *big.Int, b
 	}
 }
 
+// shard returns the lock serializing the stamping of chunks falling into
+// the bucket.
+func (si *StampIssuer) shard(bucket uint32) *sync.Mutex {
+	return &si.shards[bucket%stampIssuerShards]
+}
+
 // increment increments the count in the correct collision
 // bucket for a newly stamped chunk with given addr address.
-// Must be mutex locked before usage.
+// Must be read locked and the shard of the bucket locked before usage.
 func (si *StampIssuer) increment(addr swarm.Address) (batchIndex []byte, batchTimestamp []byte, err error) {
 	bIdx := toBucket(si.BucketDepth(), addr)
-	bCnt := si.data.Buckets[bIdx]
+	bCnt := atomic.LoadUint32(&si.data.Buckets[bIdx])
 
 	if bCnt == si.BucketUpperBound() {
 		if si.ImmutableFlag() {
@@ -188,12 +207,14 @@ func (si *StampIssuer) increment(addr swarm.Address) (batchIndex []byte, batchTi
 		}
 
 		bCnt = 0
-		si.data.Buckets[bIdx] = 0
 	}
 
-	si.data.Buckets[bIdx]++
-	if si.data.Buckets[bIdx] > si.data.MaxBucketCount {
-		si.data.MaxBucketCount = si.data.Buckets[bIdx]
+	atomic.StoreUint32(&si.data.Buckets[bIdx], bCnt+1)
+	for {
+		maxCount := atomic.LoadUint32(&si.data.MaxBucketCount)
+		if bCnt+1 <= maxCount || atomic.CompareAndSwapUint32(&si.data.MaxBucketCount, maxCount, bCnt+1) {
+			break
+		}
 	}
 
 	return indexToBytes(bIdx, bCnt), unixTime(), nil
@@ -218,7 +239,7 @@ func (si *StampIssuer) UnmarshalBinary(data []byte) error {
 // an integer between 0 and 4294967295. Batch fullness can be
 // calculated with: max_bucket_value / 2 ^ (batch_depth - bucket_depth)
 func (si *StampIssuer) Utilization() uint32 {
-	return si.data.MaxBucketCount
+	return atomic.LoadUint32(&si.data.MaxBucketCount)
 }
 
 // ID returns the BatchID for this batch.
@@ -261,13 +282,72 @@ func (si *StampIssuer) ImmutableFlag() bool {
 }
 
 func (si *StampIssuer) Buckets() []uint32 {
-	si.mtx.Lock()
-	defer si.mtx.Unlock()
+	si.mtx.RLock()
+	defer si.mtx.RUnlock()
 	b := make([]uint32, len(si.data.Buckets))
-	copy(b, si.data.Buckets)
+	for i := range si.data.Buckets {
+		b[i] = atomic.LoadUint32(&si.data.Buckets[i])
+	}
 	return b
 }
 
+// UtilizationHistogramBins is the number of fullness ranges of the bucket
+// utilization histogram.
+const UtilizationHistogramBins = 10
+
+// BucketUtilization describes how full the collision buckets of a batch are.
+type BucketUtilization struct {
+	Depth            uint8
+	BucketDepth      uint8
+	BucketUpperBound uint32
+	Immutable        bool
+	Buckets          []uint32 // number of stamped chunks per bucket
+	// Histogram counts the buckets per fullness range of equal width, full
+	// buckets are counted in the last range.
+	Histogram   []uint32
+	BestBucket  uint32 // the least filled bucket
+	WorstBucket uint32 // the most filled bucket
+	// Remaining is the number of chunks the worst bucket accepts before
+	// chunks falling into it are rejected, or overwrite older chunks if the
+	// batch is mutable.
+	Remaining uint32
+}
+
+// BucketUtilization returns the utilization of the collision buckets.
+func (si *StampIssuer) BucketUtilization() *BucketUtilization {
+	buckets := si.Buckets()
+	upperBound := si.BucketUpperBound()
+
+	u := &BucketUtilization{
+		Depth:            si.Depth(),
+		BucketDepth:      si.BucketDepth(),
+		BucketUpperBound: upperBound,
+		Immutable:        si.ImmutableFlag(),
+		Buckets:          buckets,
+		Histogram:        make([]uint32, UtilizationHistogramBins),
+	}
+
+	for i, count := range buckets {
+		bin := uint64(count) * UtilizationHistogramBins / uint64(upperBound)
+		if bin >= UtilizationHistogramBins {
+			bin = UtilizationHistogramBins - 1
+		}
+		u.Histogram[bin]++
+
+		if count < buckets[u.BestBucket] {
+			u.BestBucket = uint32(i)
+		}
+		if count > buckets[u.WorstBucket] {
+			u.WorstBucket = uint32(i)
+		}
+	}
+
+	if len(buckets) > 0 {
+		u.Remaining = upperBound - buckets[u.WorstBucket]
+	}
+	return u
+}
+
 // StampIssuerItem is a storage.Item implementation for StampIssuer.
 type StampIssuerItem struct {
 	Issuer *StampIssuer
@@ -220,6 +220,28 @@ func Test_StampIssuer_inc(t *testing.T) {
 	})
 }
 
+func TestBucketUtilization(t *testing.T) {
+	t.Parallel()
+
+	sti := postage.NewStampIssuer("label", "keyID", make([]byte, 32), big.NewInt(3), 4, 2, 0, true)
+	postage.ModifyBuckets(sti, []uint32{1, 0, 4, 2})
+
+	want := &postage.BucketUtilization{
+		Depth:            4,
+		BucketDepth:      2,
+		BucketUpperBound: 4,
+		Immutable:        true,
+		Buckets:          []uint32{1, 0, 4, 2},
+		Histogram:        []uint32{1, 0, 1, 0, 0, 1, 0, 0, 0, 1},
+		BestBucket:       1,
+		WorstBucket:      2,
+		Remaining:        0,
+	}
+	if diff := cmp.Diff(want, sti.BucketUtilization()); diff != "" {
+		t.Fatalf("unexpected utilization (-want +have):\n%s", diff)
+	}
+}
+
 func TestUtilization(t *testing.T) {
 	t.Skip("meant to be run for ad hoc testing")
 
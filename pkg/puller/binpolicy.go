@@ -0,0 +1,73 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// DefaultRadiusMargin is the default number of the proximity orders below
+// the storage radius of the peers whose proximity order bin is synced.
+const DefaultRadiusMargin = 2
+
+// BinPolicy selects the bins which are pull-synced and whether their full
+// history or only the new chunks are synced.
+type BinPolicy struct {
+	// RadiusMargin is the number of the proximity orders below the storage
+	// radius of the peers whose proximity order bin is synced, zero syncs
+	// only the bins within the storage radius from the neighbors.
+	RadiusMargin uint8
+	// LiveOnlyBins are the bins of which only the chunks stored after the
+	// peers connected are synced.
+	LiveOnlyBins []uint8
+}
+
+// DefaultBinPolicy returns the policy which syncs the full history of the
+// bins within the default margin below the storage radius.
+func DefaultBinPolicy() BinPolicy {
+	return BinPolicy{RadiusMargin: DefaultRadiusMargin}
+}
+
+// ParseBins parses the comma separated list of the bins or the inclusive
+// ranges of the bins, like "0,2-4".
+func ParseBins(s string) ([]uint8, error) {
+	var bins []uint8
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(f, "-")
+		start, err := parseBin(first)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseBin(last); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid bin range %q", f)
+			}
+		}
+		for bin := start; bin <= end; bin++ {
+			bins = append(bins, bin)
+		}
+	}
+	return bins, nil
+}
+
+func parseBin(s string) (uint8, error) {
+	bin, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8)
+	if err != nil || bin > uint64(swarm.MaxPO) {
+		return 0, fmt.Errorf("invalid bin %q", s)
+	}
+	return uint8(bin), nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/puller/intervalstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// DefaultIntervalPruneAge is the default time after which the intervals of
+// the peers which were not seen are pruned.
+const DefaultIntervalPruneAge = 14 * 24 * time.Hour
+
+// compactIntervalsDur is the interval of the compactions of the persisted
+// intervals.
+const compactIntervalsDur = time.Hour
+
+var peerSeenPrefix = IntervalPrefix + "_seen_"
+
+func peerSeenKey(peer swarm.Address) string {
+	return peerSeenPrefix + peer.ByteString()
+}
+
+// compactWorker compacts the persisted intervals at the start and
+// periodically afterwards.
+func (p *Puller) compactWorker(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(compactIntervalsDur)
+	defer ticker.Stop()
+
+	for {
+		if err := p.compactIntervals(time.Now()); err != nil {
+			p.logger.Debug("compact intervals failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// compactIntervals merges the adjacent ranges of the persisted intervals and,
+// if the pruning is enabled, deletes the intervals and the epochs of the
+// peers which were not seen for longer than the prune age.
+func (p *Puller) compactIntervals(now time.Time) error {
+	connected := make(map[string]struct{})
+	p.syncPeersMtx.Lock()
+	for key := range p.syncPeers {
+		connected[key] = struct{}{}
+	}
+	p.syncPeersMtx.Unlock()
+
+	p.intervalMtx.Lock()
+	defer p.intervalMtx.Unlock()
+
+	var (
+		prune  func(key string) bool
+		seen   = make(map[string]struct{}) // the peers seen recently
+		unseen = make(map[string]struct{}) // the peers with the intervals but without the seen time
+		stale  = make(map[string]struct{}) // the peers not seen for longer than the prune age
+	)
+
+	if p.intervalPruneAge > 0 {
+		err := p.statestore.Iterate(peerSeenPrefix, func(key, val []byte) (bool, error) {
+			addr := string(key[len(peerSeenPrefix):])
+			if _, ok := connected[addr]; ok {
+				return false, nil
+			}
+			var ts int64
+			if err := json.Unmarshal(val, &ts); err != nil {
+				return false, fmt.Errorf("unmarshal seen time: %w", err)
+			}
+			if now.Sub(time.Unix(ts, 0)) > p.intervalPruneAge {
+				stale[addr] = struct{}{}
+			} else {
+				seen[addr] = struct{}{}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return fmt.Errorf("iterate seen peers: %w", err)
+		}
+
+		prune = func(key string) bool {
+			addr := addressFromKey([]byte(key)).ByteString()
+			if _, ok := stale[addr]; ok {
+				return true
+			}
+			if _, ok := seen[addr]; !ok {
+				if _, ok := connected[addr]; !ok {
+					unseen[addr] = struct{}{}
+				}
+			}
+			return false
+		}
+	}
+
+	var (
+		res intervalstore.CompactResult
+		err error
+	)
+	for bin := uint8(0); bin < p.bins; bin++ {
+		r, e := intervalstore.Compact(p.statestore, binIntervalKey(bin), prune)
+		res.Compacted += r.Compacted
+		res.Pruned += r.Pruned
+		err = errors.Join(err, e)
+	}
+
+	if p.intervalPruneAge > 0 {
+		// the connected peers and the ones which had the intervals persisted
+		// before the seen times were recorded are seen now
+		for addr := range connected {
+			err = errors.Join(err, p.statestore.Put(peerSeenPrefix+addr, now.Unix()))
+		}
+		for addr := range unseen {
+			err = errors.Join(err, p.statestore.Put(peerSeenPrefix+addr, now.Unix()))
+		}
+		for addr := range stale {
+			peer := swarm.NewAddress([]byte(addr))
+			err = errors.Join(err, p.statestore.Delete(peerEpochKey(peer)), p.statestore.Delete(peerSeenKey(peer)))
+		}
+	}
+
+	p.metrics.IntervalsCompacted.Add(float64(res.Compacted))
+	p.metrics.IntervalsPruned.Add(float64(res.Pruned))
+	if res.Compacted > 0 || res.Pruned > 0 || len(stale) > 0 {
+		p.logger.Debug("intervals compacted", "compacted", res.Compacted, "pruned", res.Pruned, "stale_peers", len(stale))
+	}
+
+	return err
+}
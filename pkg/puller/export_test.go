@@ -4,9 +4,21 @@
 
 package puller
 
-import "github.com/calmw/bee-tron/pkg/swarm"
+import (
+	"time"
 
-var PeerIntervalKey = peerIntervalKey
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+var (
+	PeerIntervalKey = peerIntervalKey
+	PeerEpochKey    = peerEpochKey
+	PeerSeenKey     = peerSeenKey
+)
+
+func (p *Puller) CompactIntervals(now time.Time) error {
+	return p.compactIntervals(now)
+}
 
 func (p *Puller) IsSyncing(addr swarm.Address) bool {
 	p.syncPeersMtx.Lock()
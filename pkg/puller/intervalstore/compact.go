@@ -0,0 +1,73 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package intervalstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+)
+
+// CompactResult is the outcome of a compaction of the persisted intervals.
+type CompactResult struct {
+	Compacted int // number of the intervals rewritten with merged ranges
+	Pruned    int // number of the intervals deleted
+}
+
+// Compact rewrites the intervals persisted under the keys with the prefix in
+// their canonical form, in which the overlapping and adjacent ranges are
+// merged, and deletes the ones for which the prune function returns true.
+// The prune function may be nil. The keys with the prefix must all hold
+// intervals, and the caller must make sure that they are not modified
+// concurrently.
+func Compact(store storage.StateStorer, prefix string, prune func(key string) bool) (res CompactResult, err error) {
+	var (
+		deletes []string
+		puts    = make(map[string]*Intervals)
+	)
+
+	err = store.Iterate(prefix, func(key, val []byte) (bool, error) {
+		if prune != nil && prune(string(key)) {
+			deletes = append(deletes, string(key))
+			return false, nil
+		}
+
+		i := new(Intervals)
+		if err := i.UnmarshalBinary(val); err != nil {
+			return false, fmt.Errorf("unmarshal intervals %q: %w", key, err)
+		}
+		data, err := i.MarshalBinary()
+		if err != nil {
+			return false, fmt.Errorf("marshal intervals %q: %w", key, err)
+		}
+		if !bytes.Equal(data, val) {
+			puts[string(key)] = i
+		}
+		return false, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	// the store is modified after the iteration, as not all the stores allow
+	// the modifications while iterating
+	for key, i := range puts {
+		if e := store.Put(key, i); e != nil {
+			err = errors.Join(err, e)
+			continue
+		}
+		res.Compacted++
+	}
+	for _, key := range deletes {
+		if e := store.Delete(key); e != nil && !errors.Is(e, storage.ErrNotFound) {
+			err = errors.Join(err, e)
+			continue
+		}
+		res.Pruned++
+	}
+	return res, err
+}
@@ -99,3 +99,65 @@ func testStore(t *testing.T, s storage.StateStorer) {
 		t.Errorf("expected error %v, got %s", storage.ErrNotFound, err)
 	}
 }
+
+// rawIntervals is persisted as is, to store the intervals which are not in
+// their canonical form.
+type rawIntervals string
+
+func (r rawIntervals) MarshalBinary() ([]byte, error) {
+	return []byte(r), nil
+}
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	s := mock.NewStateStore()
+
+	for key, val := range map[string]rawIntervals{
+		"intervals_a": "1;1,5;6,a;c,f",  // adjacent ranges
+		"intervals_b": "1;1,5;3,8",      // overlapping ranges
+		"intervals_c": "1;1,5;7,8",      // canonical
+		"intervals_d": "1;1,5;6,8",      // pruned
+		"other":       "1;1,5;6,8;9,10", // not under the prefix
+	} {
+		if err := s.Put(key, val); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := Compact(s, "intervals_", func(key string) bool {
+		return key == "intervals_d"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (CompactResult{Compacted: 2, Pruned: 1}); res != want {
+		t.Fatalf("got result %+v, want %+v", res, want)
+	}
+
+	for key, want := range map[string]string{
+		"intervals_a": "[[1 10] [12 15]]",
+		"intervals_b": "[[1 8]]",
+		"intervals_c": "[[1 5] [7 8]]",
+	} {
+		i := new(Intervals)
+		if err := s.Get(key, i); err != nil {
+			t.Fatal(err)
+		}
+		if got := i.String(); got != want {
+			t.Fatalf("got intervals %s for %s, want %s", got, key, want)
+		}
+	}
+	if err := s.Get("intervals_d", new(Intervals)); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+
+	// the compacted intervals are not rewritten again
+	res, err = Compact(s, "intervals_", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (CompactResult{}); res != want {
+		t.Fatalf("got result %+v, want %+v", res, want)
+	}
+}
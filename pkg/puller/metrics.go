@@ -15,6 +15,8 @@ type metrics struct {
 	SyncedCounter         *prometheus.CounterVec // number of synced chunks
 	SyncWorkerErrCounter  prometheus.Counter     // count number of errors
 	MaxUintErrCounter     prometheus.Counter     // how many times we got maxuint as topmost
+	IntervalsCompacted    prometheus.Counter     // number of intervals rewritten with merged ranges
+	IntervalsPruned       prometheus.Counter     // number of intervals of long unseen peers deleted
 }
 
 func newMetrics() metrics {
@@ -51,6 +53,18 @@ func newMetrics() metrics {
 			Name:      "max_uint_errors",
 			Help:      "Total max uint errors.",
 		}),
+		IntervalsCompacted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "intervals_compacted",
+			Help:      "Total intervals rewritten with merged ranges.",
+		}),
+		IntervalsPruned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "intervals_pruned",
+			Help:      "Total intervals of long unseen peers pruned.",
+		}),
 	}
 }
 
@@ -39,13 +39,21 @@ const (
 	IntervalPrefix = "sync_interval"
 	recalcPeersDur = time.Minute * 5
 
-	maxChunksPerSecond = 1000 // roughly 4 MB/s
+	misbehaviorWeight = 1 // the weight of the misbehavior reported for peers syncing invalid chunks.
+)
 
-	maxPODelta = 2 // the lowest level of proximity order (of peers) subtracted from the storage radius allowed for chunk syncing.
+// Default syncing budget values.
+const (
+	DefaultChunksPerSecond     = 1000 // roughly 4 MB/s
+	DefaultPeerChunksPerSecond = 0
 )
 
 type Options struct {
-	Bins uint8
+	Bins                uint8
+	ChunksPerSecond     int           // chunks synced per second from all the peers, zero for the default
+	PeerChunksPerSecond int           // chunks synced per second from a single peer, zero for no limit
+	IntervalPruneAge    time.Duration // time after which the intervals of the peers not seen are pruned, zero for never
+	BinPolicy           *BinPolicy    // bins synced and how, nil for the default
 }
 
 type Puller struct {
@@ -74,7 +82,13 @@ type Puller struct {
 
 	start sync.Once
 
-	limiter *ratelimit.Limiter
+	limiter             *ratelimit.Limiter // limits the syncing from all the peers
+	peerChunksPerSecond int
+
+	intervalPruneAge time.Duration
+
+	radiusMargin uint8               // the lowest level of proximity order (of peers) subtracted from the storage radius allowed for chunk syncing.
+	liveOnly     [swarm.MaxBins]bool // bins of which the history is not synced
 }
 
 func New(
@@ -91,6 +105,10 @@ func New(
 	if o.Bins != 0 {
 		bins = o.Bins
 	}
+	chunksPerSecond := DefaultChunksPerSecond
+	if o.ChunksPerSecond > 0 {
+		chunksPerSecond = o.ChunksPerSecond
+	}
 	p := &Puller{
 		base:        addr,
 		statestore:  stateStore,
@@ -104,7 +122,21 @@ func New(
 		blockLister: blockLister,
 		rate:        rate.New(DefaultHistRateWindow),
 		cancel:      func() { /* Noop, since the context is initialized in the Start(). */ },
-		limiter:     ratelimit.NewLimiter(ratelimit.Every(time.Second/maxChunksPerSecond), maxChunksPerSecond),
+		limiter:     newChunksLimiter(chunksPerSecond),
+
+		peerChunksPerSecond: o.PeerChunksPerSecond,
+		intervalPruneAge:    o.IntervalPruneAge,
+	}
+
+	policy := DefaultBinPolicy()
+	if o.BinPolicy != nil {
+		policy = *o.BinPolicy
+	}
+	p.radiusMargin = policy.RadiusMargin
+	for _, bin := range policy.LiveOnlyBins {
+		if bin < swarm.MaxBins {
+			p.liveOnly[bin] = true
+		}
 	}
 
 	return p
@@ -117,6 +149,9 @@ func (p *Puller) Start(ctx context.Context) {
 
 		p.wg.Add(1)
 		go p.manage(cctx)
+
+		p.wg.Add(1)
+		go p.compactWorker(cctx)
 	})
 }
 
@@ -159,7 +194,7 @@ func (p *Puller) manage(ctx context.Context) {
 
 		_ = p.topology.EachConnectedPeerRev(func(addr swarm.Address, po uint8) (stop, jumpToNext bool, err error) {
 			if _, ok := p.syncPeers[addr.ByteString()]; !ok {
-				p.syncPeers[addr.ByteString()] = newSyncPeer(addr, p.bins, po)
+				p.syncPeers[addr.ByteString()] = newSyncPeer(addr, p.bins, po, p.peerChunksPerSecond)
 			}
 			delete(peersDisconnected, addr.ByteString())
 			return false, false, nil
@@ -184,6 +219,15 @@ func (p *Puller) manage(ctx context.Context) {
 			return
 		case <-tick.C:
 		case <-c:
+			// the peers are recalculated once for the pending changes
+		drain:
+			for {
+				select {
+				case <-c:
+				default:
+					break drain
+				}
+			}
 		}
 	}
 }
@@ -230,6 +274,9 @@ func (p *Puller) syncPeer(ctx context.Context, peer *syncPeer, storageRadius uin
 			return fmt.Errorf("could not get cursors from peer %s: %w", peer.address, err)
 		}
 		peer.cursors = cursors
+		for bin, cursor := range cursors[:min(len(cursors), int(p.bins))] {
+			peer.progress.advertise(uint8(bin), cursor)
+		}
 
 		storedEpoch, err := p.getPeerEpoch(peer.address)
 		if err != nil {
@@ -279,7 +326,7 @@ func (p *Puller) syncPeer(ctx context.Context, peer *syncPeer, storageRadius uin
 			}
 		}
 
-	} else if storageRadius-peer.po <= maxPODelta {
+	} else if storageRadius-peer.po <= p.radiusMargin {
 		// cancel all non-po bins, if any
 		for bin := uint8(0); bin < p.bins; bin++ {
 			if bin != peer.po {
@@ -312,6 +359,9 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 		defer peer.wg.Done()
 		defer p.metrics.SyncWorkerCounter.Dec()
 
+		peer.progress.working(bin, 1)
+		defer peer.progress.working(bin, -1)
+
 		var err error
 
 		for {
@@ -353,10 +403,18 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 					p.logger.Debug("syncWorker interval failed, quitting", "error", err, "peer_address", address, "bin", bin, "cursor", cursor, "start", start, "topmost", top)
 					return
 				}
+				if errors.Is(err, pullsync.ErrUnsolicitedChunk) || errors.Is(err, swarm.ErrInvalidChunk) {
+					p.topology.ReportMisbehavior(address, "invalid chunk synced", misbehaviorWeight)
+				}
 				loggerV2.Debug("syncWorker interval failed", "error", err, "peer_address", address, "bin", bin, "cursor", cursor, "start", start, "topmost", top)
 			}
 
-			_ = p.limiter.WaitN(ctx, count)
+			// slow down the syncing to stay within the peer and global budgets
+			waitChunks(ctx, peer.limiter, count)
+			waitChunks(ctx, p.limiter, count)
+
+			peer.progress.advertise(bin, top)
+			peer.progress.synced(bin, count)
 
 			if isHistorical {
 				p.metrics.SyncedCounter.WithLabelValues("historical").Add(float64(count))
@@ -378,7 +436,8 @@ func (p *Puller) syncPeerBin(parentCtx context.Context, peer *syncPeer, bin uint
 		}
 	}
 
-	if cursor > 0 {
+	// the history of the live only bins is not synced
+	if cursor > 0 && !p.liveOnly[bin] {
 		peer.wg.Add(1)
 		p.wg.Add(1)
 		go sync(true, peer.address, cursor)
@@ -542,17 +601,39 @@ type syncPeer struct {
 	binCancelFuncs map[uint8]func() // slice of context cancel funcs for historical sync. index is bin
 	po             uint8
 	cursors        []uint64
+	limiter        *ratelimit.Limiter // limits the syncing from the peer, nil for no limit
+	progress       *progress
 
 	mtx sync.Mutex
 	wg  sync.WaitGroup
 }
 
-func newSyncPeer(addr swarm.Address, bins, po uint8) *syncPeer {
-	return &syncPeer{
+func newSyncPeer(addr swarm.Address, bins, po uint8, chunksPerSecond int) *syncPeer {
+	p := &syncPeer{
 		address:        addr,
 		binCancelFuncs: make(map[uint8]func(), bins),
 		po:             po,
+		progress:       newProgress(),
+	}
+	if chunksPerSecond > 0 {
+		p.limiter = newChunksLimiter(chunksPerSecond)
+	}
+	return p
+}
+
+// newChunksLimiter returns a limiter of the synced chunks, which allows at
+// least a full page of chunks at once.
+func newChunksLimiter(chunksPerSecond int) *ratelimit.Limiter {
+	return ratelimit.NewLimiter(ratelimit.Limit(chunksPerSecond), max(chunksPerSecond, int(pullsync.DefaultMaxPage)))
+}
+
+// waitChunks blocks until the count of the synced chunks fits into the
+// limiter, the nil limiter does not block.
+func waitChunks(ctx context.Context, l *ratelimit.Limiter, count int) {
+	if l == nil {
+		return
 	}
+	_ = l.WaitN(ctx, min(count, l.Burst()))
 }
 
 // called when peer disconnects or on shutdown, cleans up ongoing sync operations
@@ -57,6 +57,64 @@ func TestOneSync(t *testing.T) {
 	waitSyncCalledBins(t, pullsync, addr, 1, 2)
 }
 
+func TestSyncState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = swarm.RandAddress(t)
+		cursors = []uint64{1000, 1000, 1000}
+		replies = []mockps.SyncReply{
+			{Bin: 1, Start: 1, Topmost: 500, Count: 500, Peer: addr},
+			{Bin: 2, Start: 1, Topmost: 1000, Count: 1000, Peer: addr},
+		}
+	)
+
+	p, _, kad, pullsync := newPuller(t, opts{
+		kad: []kadMock.Option{
+			kadMock.WithEachPeerRevCalls(
+				kadMock.AddrTuple{Addr: addr, PO: 1},
+			),
+		},
+		pullSync: []mockps.Option{mockps.WithCursors(cursors, 0), mockps.WithReplies(replies...)},
+		bins:     3,
+		rs:       resMock.NewReserve(resMock.WithRadius(1)),
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	kad.Trigger()
+
+	waitCursorsCalled(t, pullsync, addr)
+	waitSyncCalledBins(t, pullsync, addr, 1, 2)
+
+	var state []puller.PeerSyncState
+	err := spinlock.Wait(time.Second, func() bool {
+		state = p.SyncState()
+		return len(state) == 1 && len(state[0].Bins) == 3 &&
+			state[0].Bins[1].Synced == 500 && state[0].Bins[2].Synced == 1000
+	})
+	if err != nil {
+		t.Fatalf("timed out waiting for sync state, got %+v", state)
+	}
+
+	peer := state[0]
+	if !peer.Address.Equal(addr) || peer.PO != 1 {
+		t.Fatalf("got peer %s with po %d, want %s with po 1", peer.Address, peer.PO, addr)
+	}
+
+	// bin 0 is outside of the storage radius
+	if b := peer.Bins[0]; b.Syncing || b.Top != 1000 || b.Lag != 1000 || b.ETA >= 0 {
+		t.Fatalf("unexpected bin 0 state %+v", b)
+	}
+	// bin 1 is syncing the historical chunks
+	if b := peer.Bins[1]; !b.Syncing || b.Top != 1000 || b.Lag != 500 || b.Rate <= 0 || b.ETA <= 0 {
+		t.Fatalf("unexpected bin 1 state %+v", b)
+	}
+	// bin 2 caught up with the top
+	if b := peer.Bins[2]; !b.Syncing || b.Top != 1000 || b.Lag != 0 || b.ETA != 0 {
+		t.Fatalf("unexpected bin 2 state %+v", b)
+	}
+}
+
 func TestSyncOutsideDepth(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +151,86 @@ func TestSyncOutsideDepth(t *testing.T) {
 	waitSyncCalledBins(t, pullsync, addr2, 0)
 }
 
+func TestSyncBinPolicy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = swarm.RandAddress(t)
+		addr2   = swarm.RandAddress(t)
+		cursors = []uint64{1000, 1000, 1000, 1000}
+		replies = []mockps.SyncReply{
+			{Bin: 0, Start: 1, Topmost: 1000, Peer: addr2},
+			{Bin: 2, Start: 1, Topmost: 1000, Peer: addr},
+			{Bin: 3, Start: 1, Topmost: 1000, Peer: addr},
+			{Bin: 3, Start: 1001, Topmost: 1001, Peer: addr},
+		}
+	)
+
+	_, _, kad, pullsync := newPuller(t, opts{
+		kad: []kadMock.Option{
+			kadMock.WithEachPeerRevCalls(
+				kadMock.AddrTuple{Addr: addr, PO: 2},
+				kadMock.AddrTuple{Addr: addr2, PO: 0},
+			),
+		},
+		pullSync:  []mockps.Option{mockps.WithCursors(cursors, 0), mockps.WithReplies(replies...)},
+		bins:      4,
+		rs:        resMock.NewReserve(resMock.WithRadius(2)),
+		binPolicy: &puller.BinPolicy{LiveOnlyBins: []uint8{3}},
+	})
+
+	time.Sleep(100 * time.Millisecond)
+	kad.Trigger()
+
+	waitCursorsCalled(t, pullsync, addr)
+	waitCursorsCalled(t, pullsync, addr2)
+	waitSyncCalledBins(t, pullsync, addr, 2, 3)
+	time.Sleep(100 * time.Millisecond)
+
+	// only the new chunks of the live only bin are synced
+	for _, c := range pullsync.SyncCalls(addr) {
+		if c.Bin == 3 && c.Start != 1001 {
+			t.Fatalf("got history sync of live only bin 3 from %d", c.Start)
+		}
+	}
+	// the peers outside of the storage radius are not synced without the margin
+	if calls := pullsync.SyncCalls(addr2); len(calls) != 0 {
+		t.Fatalf("got %d sync calls outside of the storage radius, want none", len(calls))
+	}
+}
+
+func TestParseBins(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in   string
+		want []uint8
+		err  bool
+	}{
+		{in: "", want: nil},
+		{in: "3", want: []uint8{3}},
+		{in: "0, 2-4,7", want: []uint8{0, 2, 3, 4, 7}},
+		{in: "4-2", err: true},
+		{in: "32", err: true},
+		{in: "a", err: true},
+		{in: "1-", err: true},
+	} {
+		got, err := puller.ParseBins(tc.in)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("%q: expected error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", tc.in, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Fatalf("%q: mismatch (-want +have):\n%s", tc.in, diff)
+		}
+	}
+}
+
 func TestSyncIntervals(t *testing.T) {
 	t.Parallel()
 
@@ -472,6 +610,83 @@ func TestRadiusIncrease(t *testing.T) {
 
 // TestContinueSyncing adds a single peer with PO 0 to hist and live sync only a peer
 // to test that when Sync returns an error, the syncing does not terminate.
+// rawIntervals is persisted as is, to store the intervals which are not in
+// their canonical form.
+type rawIntervals string
+
+func (r rawIntervals) MarshalBinary() ([]byte, error) {
+	return []byte(r), nil
+}
+
+func TestCompactIntervals(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now    = time.Now()
+		s      = mock.NewStateStore()
+		stale  = swarm.RandAddress(t)
+		recent = swarm.RandAddress(t)
+		unseen = swarm.RandAddress(t)
+	)
+
+	for _, addr := range []swarm.Address{stale, recent, unseen} {
+		for bin := uint8(0); bin < 2; bin++ {
+			if err := s.Put(puller.PeerIntervalKey(addr, bin), rawIntervals("1;1,5;6,a")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Put(puller.PeerEpochKey(addr), uint64(1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Put(puller.PeerSeenKey(stale), now.Add(-48*time.Hour).Unix()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(puller.PeerSeenKey(recent), now.Add(-time.Hour).Unix()); err != nil {
+		t.Fatal(err)
+	}
+
+	p, _, _ := newPullerWithState(t, s, opts{
+		bins:     2,
+		rs:       resMock.NewReserve(resMock.WithRadius(1)),
+		pruneAge: 24 * time.Hour,
+	})
+
+	if err := p.CompactIntervals(now); err != nil {
+		t.Fatal(err)
+	}
+
+	for bin := uint8(0); bin < 2; bin++ {
+		// the intervals of the stale peer are pruned
+		if err := s.Get(puller.PeerIntervalKey(stale, bin), new(intervalstore.Intervals)); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+		}
+		// the intervals of the other peers are compacted
+		checkIntervals(t, s, recent, "[[1 10]]", bin)
+		checkIntervals(t, s, unseen, "[[1 10]]", bin)
+	}
+
+	var epoch uint64
+	if err := s.Get(puller.PeerEpochKey(stale), &epoch); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	if err := s.Get(puller.PeerEpochKey(recent), &epoch); err != nil {
+		t.Fatal(err)
+	}
+
+	var seen int64
+	if err := s.Get(puller.PeerSeenKey(stale), &seen); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	// the peer with the intervals persisted before the seen times is seen now
+	if err := s.Get(puller.PeerSeenKey(unseen), &seen); err != nil {
+		t.Fatal(err)
+	}
+	if seen < now.Unix() {
+		t.Fatalf("got seen time %d, want at least %d", seen, now.Unix())
+	}
+}
+
 func TestContinueSyncing(t *testing.T) {
 	t.Parallel()
 
@@ -640,6 +855,8 @@ type opts struct {
 	rs           *resMock.ReserveStore
 	bins         uint8
 	syncSleepDur time.Duration
+	pruneAge     time.Duration
+	binPolicy    *puller.BinPolicy
 }
 
 func newPuller(t *testing.T, ops opts) (*puller.Puller, storage.StateStorer, *kadMock.Mock, *mockps.PullSyncMock) {
@@ -656,7 +873,9 @@ func newPuller(t *testing.T, ops opts) (*puller.Puller, storage.StateStorer, *ka
 	kad := kadMock.NewMockKademlia(ops.kad...)
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:             ops.bins,
+		IntervalPruneAge: ops.pruneAge,
+		BinPolicy:        ops.binPolicy,
 	}
 	p := puller.New(swarm.RandAddress(t), s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -680,7 +899,9 @@ func newPulleAddr(t *testing.T, addr swarm.Address, ops opts) (*puller.Puller, s
 	kad := kadMock.NewMockKademlia(ops.kad...)
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:             ops.bins,
+		IntervalPruneAge: ops.pruneAge,
+		BinPolicy:        ops.binPolicy,
 	}
 	p := puller.New(addr, s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -698,7 +919,9 @@ func newPullerWithState(t *testing.T, s storage.StateStorer, ops opts) (*puller.
 	logger := log.Noop
 
 	o := puller.Options{
-		Bins: ops.bins,
+		Bins:             ops.bins,
+		IntervalPruneAge: ops.pruneAge,
+		BinPolicy:        ops.binPolicy,
 	}
 	p := puller.New(swarm.RandAddress(t), s, kad, ops.rs, ps, nil, logger, o)
 	p.Start(context.Background())
@@ -0,0 +1,186 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package puller
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/puller/intervalstore"
+	"github.com/calmw/bee-tron/pkg/rate"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// syncStateRateWindow is the window of the rate the completion of the
+// syncing is estimated from.
+const syncStateRateWindow = time.Minute
+
+var _ SyncStater = (*Puller)(nil)
+
+// SyncStater reports the progress of the syncing.
+type SyncStater interface {
+	// SyncState returns the progress of the syncing from the connected peers.
+	SyncState() []PeerSyncState
+}
+
+// PeerSyncState is the progress of the syncing from a peer.
+type PeerSyncState struct {
+	Address swarm.Address
+	PO      uint8
+	Bins    []BinSyncState
+}
+
+// BinSyncState is the progress of the syncing of a bin from a peer.
+type BinSyncState struct {
+	Bin     uint8
+	Syncing bool          // whether the bin is being synced
+	Top     uint64        // the highest bin ID advertised by the peer
+	Synced  uint64        // the bin ID all the chunks up to which are synced
+	Lag     uint64        // the number of the bin IDs left to sync up to the top
+	Rate    float64       // the chunks synced per second recently
+	ETA     time.Duration // the estimated time to catch up with the top, zero if caught up, negative if unknown
+}
+
+// binProgress tracks the syncing of a bin of a peer.
+type binProgress struct {
+	top     uint64
+	workers int
+	rate    *rate.Rate
+}
+
+// progress tracks the syncing of the bins of a peer. It has its own lock, so
+// that it can be read and updated by the workers while the peer is locked.
+type progress struct {
+	mtx  sync.Mutex
+	bins map[uint8]*binProgress
+}
+
+func newProgress() *progress {
+	return &progress{bins: make(map[uint8]*binProgress)}
+}
+
+// Must be called under lock.
+func (p *progress) bin(bin uint8) *binProgress {
+	b, ok := p.bins[bin]
+	if !ok {
+		b = &binProgress{rate: rate.New(syncStateRateWindow)}
+		p.bins[bin] = b
+	}
+	return b
+}
+
+// advertise records the top of the bin advertised by the peer.
+func (p *progress) advertise(bin uint8, top uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	b := p.bin(bin)
+	b.top = max(b.top, top)
+}
+
+// synced records the number of the chunks synced from the bin.
+func (p *progress) synced(bin uint8, count int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.bin(bin).rate.Add(count)
+}
+
+// working records the start, or the end with the negative delta, of a
+// worker syncing the bin.
+func (p *progress) working(bin uint8, delta int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.bin(bin).workers += delta
+}
+
+// SyncState implements the SyncStater interface.
+func (p *Puller) SyncState() []PeerSyncState {
+	p.syncPeersMtx.Lock()
+	peers := make([]*syncPeer, 0, len(p.syncPeers))
+	for _, peer := range p.syncPeers {
+		peers = append(peers, peer)
+	}
+	p.syncPeersMtx.Unlock()
+
+	states := make([]PeerSyncState, 0, len(peers))
+	for _, peer := range peers {
+		state := PeerSyncState{Address: peer.address, PO: peer.po}
+
+		peer.progress.mtx.Lock()
+		for bin, b := range peer.progress.bins {
+			state.Bins = append(state.Bins, BinSyncState{
+				Bin:     bin,
+				Syncing: b.workers > 0,
+				Top:     b.top,
+				Rate:    b.rate.Rate(),
+			})
+		}
+		peer.progress.mtx.Unlock()
+
+		for i := range state.Bins {
+			b := &state.Bins[i]
+			start, err := p.peerIntervalStart(peer.address, b.Bin)
+			if err != nil {
+				p.logger.Debug("sync state interval failed", "peer_address", peer.address, "bin", b.Bin, "error", err)
+				continue
+			}
+			// the intervals start at 1, so the start is always positive
+			b.Synced = start - 1
+			if b.Top > b.Synced {
+				b.Lag = b.Top - b.Synced
+			}
+			b.ETA = estimateCompletion(b.Lag, b.Rate)
+		}
+		sort.Slice(state.Bins, func(i, j int) bool {
+			return state.Bins[i].Bin < state.Bins[j].Bin
+		})
+
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Address.Compare(states[j].Address) < 0
+	})
+
+	return states
+}
+
+// peerIntervalStart returns the start of the first interval of the bin left
+// to sync from the peer, without creating the intervals of the bin.
+func (p *Puller) peerIntervalStart(peer swarm.Address, bin uint8) (uint64, error) {
+	p.intervalMtx.Lock()
+	defer p.intervalMtx.Unlock()
+
+	itv := &intervalstore.Intervals{}
+	if err := p.statestore.Get(peerIntervalKey(peer, bin), itv); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 1, nil
+		}
+		return 0, err
+	}
+	start, _, _ := itv.Next(0)
+	return start, nil
+}
+
+// estimateCompletion returns the time to sync the lag at the rate, negative
+// if it can not be estimated.
+func estimateCompletion(lag uint64, chunksPerSecond float64) time.Duration {
+	if lag == 0 {
+		return 0
+	}
+	if chunksPerSecond <= 0 {
+		return -1
+	}
+	secs := float64(lag) / chunksPerSecond
+	if secs >= math.MaxInt64/float64(time.Second) {
+		return -1
+	}
+	return time.Duration(secs * float64(time.Second))
+}
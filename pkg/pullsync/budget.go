@@ -0,0 +1,75 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pullsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/ratelimit"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"golang.org/x/time/rate"
+)
+
+// Default bandwidth budget values.
+const (
+	DefaultBandwidth     = 0
+	DefaultPeerBandwidth = 0
+)
+
+// maxDeliverySize is the size of the largest valid chunk delivery, the
+// bandwidth limiters must allow at least this many bytes at once.
+const maxDeliverySize = swarm.SocMaxChunkSize + postage.StampSize
+
+// Budget limits the bandwidth used to sync the chunks from the downstream
+// peers, so that the syncing does not starve the other traffic. The zero
+// values are for no limit.
+type Budget struct {
+	Bandwidth     int // bytes per second synced from all the peers
+	PeerBandwidth int // bytes per second synced from a single peer
+}
+
+// bandwidth enforces the Budget on the received deliveries.
+type bandwidth struct {
+	global *rate.Limiter      // nil for no limit
+	peer   *ratelimit.Limiter // nil for no limit
+}
+
+func newBandwidth(b Budget) bandwidth {
+	var bw bandwidth
+	if b.Bandwidth > 0 {
+		bw.global = rate.NewLimiter(rate.Limit(b.Bandwidth), max(b.Bandwidth, maxDeliverySize))
+	}
+	if b.PeerBandwidth > 0 {
+		bw.peer = ratelimit.New(time.Second/time.Duration(b.PeerBandwidth), max(b.PeerBandwidth, maxDeliverySize))
+	}
+	return bw
+}
+
+// wait blocks until n bytes received from the peer fit into the budget and
+// returns the time waited.
+func (b bandwidth) wait(ctx context.Context, peer swarm.Address, n int) (time.Duration, error) {
+	n = min(n, maxDeliverySize)
+	start := time.Now()
+	if b.peer != nil {
+		if _, err := b.peer.Wait(ctx, peer.ByteString(), n); err != nil {
+			return 0, err
+		}
+	}
+	if b.global != nil {
+		if err := b.global.WaitN(ctx, n); err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start), nil
+}
+
+// clear drops the state kept for the peer.
+func (b bandwidth) clear(peer swarm.Address) {
+	if b.peer != nil {
+		b.peer.Clear(peer.ByteString())
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pullsync
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/pullsync/pb"
+	"github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// maxReceivedPerPeer is the number of the most recent chunks received from a
+// peer which are remembered to be left out of the delta offers to the peer.
+const maxReceivedPerPeer = 16 * DefaultMaxPage
+
+var errInvalidBatchIndex = errors.New("invalid batch index")
+
+// received remembers the chunks most recently received from the peers. The
+// chunks a peer has sent are known to be stored by the peer, so the offers to
+// the peer in the return direction can leave them out.
+type received struct {
+	mtx   sync.Mutex
+	peers map[string]*receivedSet
+}
+
+// receivedSet is a bounded set of the chunk identifiers, in which the oldest
+// identifiers are dropped first.
+type receivedSet struct {
+	ids  map[string]struct{}
+	ring []string
+	next int
+}
+
+func newReceived() *received {
+	return &received{peers: make(map[string]*receivedSet)}
+}
+
+// add records the chunks received from the peer.
+func (r *received) add(peer swarm.Address, chs []swarm.Chunk) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	set, ok := r.peers[peer.ByteString()]
+	if !ok {
+		set = &receivedSet{ids: make(map[string]struct{})}
+		r.peers[peer.ByteString()] = set
+	}
+	for _, ch := range chs {
+		stampHash, err := ch.Stamp().Hash()
+		if err != nil {
+			continue
+		}
+		id := chunkID(ch.Address(), ch.Stamp().BatchID(), stampHash)
+		if _, ok := set.ids[id]; ok {
+			continue
+		}
+		if len(set.ring) < int(maxReceivedPerPeer) {
+			set.ring = append(set.ring, id)
+		} else {
+			delete(set.ids, set.ring[set.next])
+			set.ring[set.next] = id
+			set.next = (set.next + 1) % len(set.ring)
+		}
+		set.ids[id] = struct{}{}
+	}
+}
+
+// omit returns the chunks which were not received from the peer and the
+// number of the chunks left out.
+func (r *received) omit(peer swarm.Address, chs []*storer.BinC) ([]*storer.BinC, int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	set, ok := r.peers[peer.ByteString()]
+	if !ok {
+		return chs, 0
+	}
+	rest := make([]*storer.BinC, 0, len(chs))
+	for _, ch := range chs {
+		if _, ok := set.ids[chunkID(ch.Address, ch.BatchID, ch.StampHash)]; !ok {
+			rest = append(rest, ch)
+		}
+	}
+	return rest, len(chs) - len(rest)
+}
+
+// clear forgets the chunks received from the peer.
+func (r *received) clear(peer swarm.Address) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.peers, peer.ByteString())
+}
+
+func chunkID(addr swarm.Address, batchID, stampHash []byte) string {
+	return addr.ByteString() + string(batchID) + string(stampHash)
+}
+
+// compactOffer returns the offer in which the batch IDs of the chunks are
+// replaced by the indexes into the list of the distinct batch IDs, as the
+// chunks of an offer are mostly stamped by a few batches.
+func compactOffer(o *pb.Offer) *pb.Offer {
+	c := &pb.Offer{Topmost: o.Topmost, Chunks: make([]*pb.Chunk, 0, len(o.Chunks))}
+	batches := make(map[string]uint32)
+	for _, ch := range o.Chunks {
+		idx, ok := batches[string(ch.BatchID)]
+		if !ok {
+			idx = uint32(len(c.BatchIDs))
+			batches[string(ch.BatchID)] = idx
+			c.BatchIDs = append(c.BatchIDs, ch.BatchID)
+		}
+		c.Chunks = append(c.Chunks, &pb.Chunk{Address: ch.Address, StampHash: ch.StampHash, BatchIndex: idx})
+	}
+	return c
+}
+
+// expandOffer resolves the batch IDs of the chunks of a compact offer. The
+// offers which are not compact are left as they are.
+func expandOffer(o *pb.Offer) error {
+	if len(o.BatchIDs) == 0 {
+		return nil
+	}
+	for _, ch := range o.Chunks {
+		if int(ch.BatchIndex) >= len(o.BatchIDs) {
+			return errInvalidBatchIndex
+		}
+		ch.BatchID = o.BatchIDs[ch.BatchIndex]
+	}
+	return nil
+}
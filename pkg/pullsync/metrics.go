@@ -21,6 +21,8 @@ type metrics struct {
 	Sent                 prometheus.Counter     // number of chunks sent
 	DuplicateRuid        prometheus.Counter     // number of duplicate RUID requests we got
 	LastReceived         *prometheus.CounterVec // last timestamp of the received chunks per bin
+	BandwidthWaitTime    prometheus.Counter     // time spent waiting for the bandwidth budget
+	SentOmitted          prometheus.Counter     // number of chunks left out of the delta offers
 }
 
 func newMetrics() metrics {
@@ -94,6 +96,18 @@ func newMetrics() metrics {
 				Name:      "last_received",
 				Help:      `The last timestamp of the received chunks per bin.`,
 			}, []string{"bin"}),
+		BandwidthWaitTime: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "bandwidth_wait_seconds",
+			Help:      "Total time spent waiting for the syncing bandwidth budget.",
+		}),
+		SentOmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "chunks_sent_omitted",
+			Help:      "Total chunks left out of the delta offers as they were received from the peer.",
+		}),
 	}
 }
 
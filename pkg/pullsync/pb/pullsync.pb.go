@@ -111,8 +111,10 @@ func (m *Ack) GetEpoch() uint64 {
 }
 
 type Get struct {
-	Bin   int32  `protobuf:"varint,1,opt,name=Bin,proto3" json:"Bin,omitempty"`
-	Start uint64 `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	Bin     int32  `protobuf:"varint,1,opt,name=Bin,proto3" json:"Bin,omitempty"`
+	Start   uint64 `protobuf:"varint,2,opt,name=Start,proto3" json:"Start,omitempty"`
+	Compact bool   `protobuf:"varint,3,opt,name=Compact,proto3" json:"Compact,omitempty"`
+	Delta   bool   `protobuf:"varint,4,opt,name=Delta,proto3" json:"Delta,omitempty"`
 }
 
 func (m *Get) Reset()         { *m = Get{} }
@@ -162,10 +164,25 @@ func (m *Get) GetStart() uint64 {
 	return 0
 }
 
+func (m *Get) GetCompact() bool {
+	if m != nil {
+		return m.Compact
+	}
+	return false
+}
+
+func (m *Get) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
 type Chunk struct {
-	Address   []byte `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
-	BatchID   []byte `protobuf:"bytes,2,opt,name=BatchID,proto3" json:"BatchID,omitempty"`
-	StampHash []byte `protobuf:"bytes,3,opt,name=StampHash,proto3" json:"StampHash,omitempty"`
+	Address    []byte `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	BatchID    []byte `protobuf:"bytes,2,opt,name=BatchID,proto3" json:"BatchID,omitempty"`
+	StampHash  []byte `protobuf:"bytes,3,opt,name=StampHash,proto3" json:"StampHash,omitempty"`
+	BatchIndex uint32 `protobuf:"varint,4,opt,name=BatchIndex,proto3" json:"BatchIndex,omitempty"`
 }
 
 func (m *Chunk) Reset()         { *m = Chunk{} }
@@ -222,9 +239,17 @@ func (m *Chunk) GetStampHash() []byte {
 	return nil
 }
 
+func (m *Chunk) GetBatchIndex() uint32 {
+	if m != nil {
+		return m.BatchIndex
+	}
+	return 0
+}
+
 type Offer struct {
-	Topmost uint64   `protobuf:"varint,1,opt,name=Topmost,proto3" json:"Topmost,omitempty"`
-	Chunks  []*Chunk `protobuf:"bytes,2,rep,name=Chunks,proto3" json:"Chunks,omitempty"`
+	Topmost  uint64   `protobuf:"varint,1,opt,name=Topmost,proto3" json:"Topmost,omitempty"`
+	Chunks   []*Chunk `protobuf:"bytes,2,rep,name=Chunks,proto3" json:"Chunks,omitempty"`
+	BatchIDs [][]byte `protobuf:"bytes,3,rep,name=BatchIDs,proto3" json:"BatchIDs,omitempty"`
 }
 
 func (m *Offer) Reset()         { *m = Offer{} }
@@ -274,6 +299,13 @@ func (m *Offer) GetChunks() []*Chunk {
 	return nil
 }
 
+func (m *Offer) GetBatchIDs() [][]byte {
+	if m != nil {
+		return m.BatchIDs
+	}
+	return nil
+}
+
 type Want struct {
 	BitVector []byte `protobuf:"bytes,1,opt,name=BitVector,proto3" json:"BitVector,omitempty"`
 }
@@ -391,27 +423,30 @@ func init() {
 func init() { proto.RegisterFile("pullsync.proto", fileDescriptor_d1dee042cf9c065c) }
 
 var fileDescriptor_d1dee042cf9c065c = []byte{
-	// 319 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0xbb, 0x4e, 0xc3, 0x30,
-	0x14, 0x86, 0xeb, 0x3a, 0x29, 0xe5, 0x50, 0x01, 0xb2, 0x18, 0x32, 0x54, 0x51, 0x64, 0x21, 0x91,
-	0x85, 0x0e, 0x20, 0x1e, 0xa0, 0x69, 0x11, 0x97, 0x01, 0x24, 0x17, 0x81, 0x60, 0x73, 0xd3, 0x54,
-	0xa9, 0x68, 0x63, 0xcb, 0x76, 0x91, 0xfa, 0x16, 0x3c, 0x16, 0x63, 0x47, 0x46, 0xd4, 0xbc, 0x08,
-	0x8a, 0x1b, 0xd3, 0x8d, 0xed, 0x7c, 0xe7, 0xf6, 0xfb, 0x3f, 0x86, 0x43, 0xb9, 0x9c, 0xcf, 0xf5,
-	0xaa, 0x48, 0x7b, 0x52, 0x09, 0x23, 0x48, 0xdb, 0x31, 0xf5, 0x01, 0x8f, 0x56, 0x05, 0xbd, 0x02,
-	0xdc, 0x4f, 0xdf, 0x49, 0x00, 0x7b, 0x83, 0xa5, 0xd2, 0x42, 0xe9, 0x00, 0x45, 0x38, 0xf6, 0x98,
-	0x43, 0x72, 0x02, 0xfe, 0xb5, 0x14, 0x69, 0x1e, 0x34, 0x23, 0x14, 0x7b, 0x6c, 0x0b, 0xf4, 0x1c,
-	0xf0, 0x4d, 0x66, 0xc8, 0x31, 0xe0, 0x64, 0x56, 0x04, 0x28, 0x42, 0xb1, 0xcf, 0xaa, 0xb0, 0x6a,
-	0x1f, 0x19, 0xae, 0x8c, 0x6b, 0xb7, 0x40, 0x5f, 0xc1, 0x1f, 0xe4, 0xcb, 0xc2, 0xea, 0xf4, 0x27,
-	0x13, 0x95, 0x69, 0x6d, 0x87, 0x3a, 0xcc, 0x61, 0x55, 0x49, 0xb8, 0x49, 0xf3, 0xbb, 0xa1, 0x1d,
-	0xed, 0x30, 0x87, 0xa4, 0x0b, 0xfb, 0x23, 0xc3, 0x17, 0xf2, 0x96, 0xeb, 0x3c, 0xc0, 0xb6, 0xb6,
-	0x4b, 0xd0, 0x7b, 0xf0, 0x1f, 0xa7, 0xd3, 0x4c, 0x55, 0x0b, 0x9e, 0x84, 0x5c, 0x08, 0x6d, 0xec,
-	0x6a, 0x8f, 0x39, 0x24, 0x67, 0xd0, 0xb2, 0xea, 0x3a, 0x68, 0x46, 0x38, 0x3e, 0xb8, 0x38, 0xea,
-	0xfd, 0x5d, 0xc5, 0xe6, 0x59, 0x5d, 0xa6, 0xa7, 0xe0, 0xbd, 0xf0, 0xc2, 0x54, 0x8a, 0xc9, 0xcc,
-	0x3c, 0x67, 0xa9, 0x11, 0xaa, 0x7e, 0xe7, 0x2e, 0x41, 0x1f, 0xa0, 0x3d, 0xcc, 0xe6, 0xb3, 0x8f,
-	0x4c, 0xad, 0xfe, 0xf1, 0x43, 0xc0, 0x1b, 0x72, 0xc3, 0x6b, 0x33, 0x36, 0xae, 0x8f, 0xb3, 0x90,
-	0xb5, 0x8b, 0x2d, 0x24, 0xdd, 0xaf, 0x4d, 0x88, 0xd6, 0x9b, 0x10, 0xfd, 0x6c, 0x42, 0xf4, 0x59,
-	0x86, 0x8d, 0x75, 0x19, 0x36, 0xbe, 0xcb, 0xb0, 0xf1, 0xd6, 0x94, 0xe3, 0x71, 0xcb, 0x7e, 0xdc,
-	0xe5, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x02, 0x2f, 0x9a, 0xca, 0x01, 0x00, 0x00,
+	// 357 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7d, 0x92, 0xcf, 0x4a, 0xc3, 0x40,
+	0x10, 0xc6, 0x4d, 0x37, 0xa9, 0x71, 0xac, 0x7f, 0x58, 0x3c, 0x04, 0x29, 0xa5, 0x04, 0xc1, 0x9e,
+	0x7a, 0x50, 0x7c, 0x80, 0xa6, 0x15, 0xf5, 0xa2, 0xb0, 0x15, 0x05, 0xc1, 0x43, 0x9a, 0xa6, 0xa4,
+	0xd8, 0x64, 0x97, 0xdd, 0xad, 0x98, 0xb7, 0xf0, 0xb1, 0x3c, 0xf6, 0xe8, 0x51, 0xf4, 0x45, 0x9c,
+	0x6c, 0x92, 0xd6, 0x93, 0x87, 0x81, 0xfd, 0x7d, 0x33, 0xd9, 0x6f, 0xf2, 0x25, 0xb0, 0x2f, 0x96,
+	0x8b, 0x85, 0xca, 0xb3, 0xa8, 0x2f, 0x24, 0xd7, 0x9c, 0xba, 0x35, 0xfb, 0x0e, 0x90, 0x71, 0x9e,
+	0xf9, 0x17, 0x40, 0x06, 0xd1, 0x0b, 0xf5, 0x60, 0x7b, 0xb8, 0x94, 0x8a, 0x4b, 0xe5, 0x59, 0x5d,
+	0xd2, 0xb3, 0x59, 0x8d, 0xf4, 0x08, 0x9c, 0x4b, 0xc1, 0xa3, 0xc4, 0x6b, 0x74, 0x2d, 0xd4, 0x4b,
+	0xf0, 0x9f, 0x81, 0x5c, 0xc5, 0x9a, 0x1e, 0x02, 0x09, 0xe6, 0x19, 0x3e, 0x62, 0xf5, 0x1c, 0x56,
+	0x1c, 0x8b, 0xf1, 0xb1, 0x0e, 0xa5, 0xae, 0xc7, 0x0d, 0x98, 0xeb, 0x79, 0x2a, 0xc2, 0x48, 0x7b,
+	0x04, 0x75, 0x97, 0xd5, 0x58, 0xcc, 0x8f, 0xe2, 0x85, 0x0e, 0x3d, 0xdb, 0xe8, 0x25, 0xf8, 0x39,
+	0x38, 0xc3, 0x64, 0x99, 0x99, 0xbd, 0x06, 0xd3, 0xa9, 0x8c, 0x95, 0x32, 0x26, 0x2d, 0x56, 0x63,
+	0xd1, 0x09, 0x42, 0x1d, 0x25, 0x37, 0x23, 0x63, 0x85, 0x9d, 0x0a, 0x69, 0x1b, 0x76, 0xd0, 0x35,
+	0x15, 0xd7, 0xa1, 0x4a, 0x8c, 0x5d, 0x8b, 0x6d, 0x04, 0xda, 0x01, 0x28, 0x07, 0xb3, 0x69, 0xfc,
+	0x66, 0x5c, 0xf7, 0xd8, 0x1f, 0xc5, 0x9f, 0x81, 0x73, 0x37, 0x9b, 0xc5, 0xb2, 0x30, 0xb8, 0xe7,
+	0x22, 0xe5, 0x4a, 0x1b, 0x6b, 0x8c, 0xa4, 0x42, 0x7a, 0x0a, 0x4d, 0xb3, 0x9d, 0x42, 0x67, 0xd2,
+	0xdb, 0x3d, 0x3b, 0xe8, 0xaf, 0x53, 0x36, 0x3a, 0xab, 0xda, 0xf4, 0x18, 0xdc, 0x6a, 0x29, 0x85,
+	0x8b, 0x10, 0x5c, 0x64, 0xcd, 0xfe, 0x09, 0xd8, 0x8f, 0x61, 0xa6, 0x8b, 0x6d, 0x83, 0xb9, 0x7e,
+	0x88, 0x23, 0xcd, 0x65, 0xf5, 0x8e, 0x1b, 0xc1, 0xbf, 0x05, 0x17, 0x13, 0x99, 0xbf, 0xc6, 0x32,
+	0xff, 0x27, 0x0b, 0x0a, 0xf6, 0x28, 0xc4, 0x0c, 0xcb, 0x20, 0xcc, 0xb9, 0xfa, 0x10, 0xa9, 0xa8,
+	0x12, 0x28, 0x21, 0x68, 0x7f, 0x7c, 0x77, 0xac, 0x15, 0xd6, 0x17, 0xd6, 0xfb, 0x4f, 0x67, 0x6b,
+	0x85, 0xf5, 0x89, 0xf5, 0xd4, 0x10, 0x93, 0x49, 0xd3, 0xfc, 0x24, 0xe7, 0xbf, 0xe3, 0xb9, 0x03,
+	0x2a, 0x36, 0x02, 0x00, 0x00,
 }
 
 func (m *Syn) Marshal() (dAtA []byte, err error) {
@@ -503,6 +538,26 @@ func (m *Get) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Delta {
+		i--
+		if m.Delta {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Compact {
+		i--
+		if m.Compact {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Start != 0 {
 		i = encodeVarintPullsync(dAtA, i, uint64(m.Start))
 		i--
@@ -536,6 +591,11 @@ func (m *Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BatchIndex != 0 {
+		i = encodeVarintPullsync(dAtA, i, uint64(m.BatchIndex))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.StampHash) > 0 {
 		i -= len(m.StampHash)
 		copy(dAtA[i:], m.StampHash)
@@ -580,6 +640,15 @@ func (m *Offer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.BatchIDs) > 0 {
+		for iNdEx := len(m.BatchIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BatchIDs[iNdEx])
+			copy(dAtA[i:], m.BatchIDs[iNdEx])
+			i = encodeVarintPullsync(dAtA, i, uint64(len(m.BatchIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Chunks) > 0 {
 		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -727,6 +796,12 @@ func (m *Get) Size() (n int) {
 	if m.Start != 0 {
 		n += 1 + sovPullsync(uint64(m.Start))
 	}
+	if m.Compact {
+		n += 2
+	}
+	if m.Delta {
+		n += 2
+	}
 	return n
 }
 
@@ -748,6 +823,9 @@ func (m *Chunk) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPullsync(uint64(l))
 	}
+	if m.BatchIndex != 0 {
+		n += 1 + sovPullsync(uint64(m.BatchIndex))
+	}
 	return n
 }
 
@@ -766,6 +844,12 @@ func (m *Offer) Size() (n int) {
 			n += 1 + l + sovPullsync(uint64(l))
 		}
 	}
+	if len(m.BatchIDs) > 0 {
+		for _, b := range m.BatchIDs {
+			l = len(b)
+			n += 1 + l + sovPullsync(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1077,6 +1161,46 @@ func (m *Get) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compact", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPullsync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compact = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delta", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPullsync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Delta = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPullsync(dAtA[iNdEx:])
@@ -1232,6 +1356,25 @@ func (m *Chunk) Unmarshal(dAtA []byte) error {
 				m.StampHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchIndex", wireType)
+			}
+			m.BatchIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPullsync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPullsync(dAtA[iNdEx:])
@@ -1338,6 +1481,38 @@ func (m *Offer) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPullsync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPullsync
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPullsync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BatchIDs = append(m.BatchIDs, make([]byte, postIndex-iNdEx))
+			copy(m.BatchIDs[len(m.BatchIDs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPullsync(dAtA[iNdEx:])
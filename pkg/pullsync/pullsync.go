@@ -78,7 +78,9 @@ type Syncer struct {
 
 	maxPage uint64
 
-	limiter *ratelimit.Limiter
+	limiter   *ratelimit.Limiter
+	bandwidth bandwidth
+	received  *received
 
 	Interface
 	io.Closer
@@ -92,6 +94,7 @@ func New(
 	validStamp postage.ValidStampFn,
 	logger log.Logger,
 	maxPage uint64,
+	budget Budget,
 ) *Syncer {
 
 	return &Syncer{
@@ -105,6 +108,8 @@ func New(
 		quit:        make(chan struct{}),
 		maxPage:     maxPage,
 		limiter:     ratelimit.New(handleRequestsLimitRate, int(maxPage)),
+		bandwidth:   newBandwidth(budget),
+		received:    newReceived(),
 	}
 }
 
@@ -170,12 +175,16 @@ func (s *Syncer) handler(streamCtx context.Context, p p2p.Peer, stream p2p.Strea
 	w, r := protobuf.NewWriterAndReader(stream)
 
 	// make an offer to the upstream peer in return for the requested range
-	offer, err := s.makeOffer(ctx, rn)
+	offer, err := s.makeOffer(ctx, p.Address, rn)
 	if err != nil {
 		return fmt.Errorf("make offer: %w", err)
 	}
 
-	if err := w.WriteMsgWithContext(ctx, offer); err != nil {
+	sent := offer
+	if rn.Compact {
+		sent = compactOffer(offer)
+	}
+	if err := w.WriteMsgWithContext(ctx, sent); err != nil {
 		return fmt.Errorf("write offer: %w", err)
 	}
 
@@ -245,7 +254,7 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 
 	w, r := protobuf.NewWriterAndReader(stream)
 
-	rangeMsg := &pb.Get{Bin: int32(bin), Start: start}
+	rangeMsg := &pb.Get{Bin: int32(bin), Start: start, Compact: true, Delta: true}
 	if err = w.WriteMsgWithContext(ctx, rangeMsg); err != nil {
 		return 0, 0, fmt.Errorf("write get range: %w", err)
 	}
@@ -254,6 +263,9 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 	if err = r.ReadMsgWithContext(ctx, &offer); err != nil {
 		return 0, 0, fmt.Errorf("read offer: %w", err)
 	}
+	if err = expandOffer(&offer); err != nil {
+		return 0, 0, fmt.Errorf("expand offer: %w", err)
+	}
 
 	// empty interval (no chunks present in interval).
 	// return the end of the requested range as topmost.
@@ -321,6 +333,13 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 			return 0, 0, errors.Join(chunkErr, fmt.Errorf("read delivery: %w", err))
 		}
 
+		// slow down the syncing to stay within the bandwidth budget
+		var waitDur time.Duration
+		if waitDur, err = s.bandwidth.wait(ctx, peer, delivery.Size()); err != nil {
+			return 0, 0, errors.Join(chunkErr, fmt.Errorf("bandwidth budget: %w", err))
+		}
+		s.metrics.BandwidthWaitTime.Add(waitDur.Seconds())
+
 		addr := swarm.NewAddress(delivery.Address)
 		if addr.Equal(swarm.ZeroAddress) {
 			s.logger.Debug("received zero address chunk", "peer_address", peer)
@@ -382,26 +401,28 @@ func (s *Syncer) Sync(ctx context.Context, peer swarm.Address, bin uint8, start
 		s.metrics.Delivered.Add(float64(len(chunksToPut)))
 		s.metrics.LastReceived.WithLabelValues(fmt.Sprintf("%d", bin)).Add(float64(len(chunksToPut)))
 
-		for _, c := range chunksToPut {
-			if err := s.store.ReservePutter().Put(ctx, c); err != nil {
-				// in case of these errors, no new items are added to the storage, so it
-				// is safe to continue with the next chunk
-				if errors.Is(err, storage.ErrOverwriteNewerChunk) {
-					s.logger.Debug("overwrite newer chunk", "error", err, "peer_address", peer, "chunk", c)
-					chunkErr = errors.Join(chunkErr, err)
-					continue
-				}
+		// the chunks are stored in a single commit to reduce the write amplification
+		n, err := storage.PutBatch(ctx, s.store.ReservePutter(), chunksToPut)
+		if err != nil {
+			// in case of these errors, no new items are added to the storage for
+			// the rejected chunks, so it is safe to keep the rest of the batch
+			if !errors.Is(err, storage.ErrOverwriteNewerChunk) {
 				return 0, 0, errors.Join(chunkErr, err)
 			}
-			chunksPut++
+			s.logger.Debug("overwrite newer chunk", "error", err, "peer_address", peer)
+			chunkErr = errors.Join(chunkErr, err)
 		}
+		chunksPut = n
+		// the peer has the chunks it sent, whether or not they were stored
+		s.received.add(peer, chunksToPut)
 	}
 
 	return topmost, chunksPut, chunkErr
 }
 
 // makeOffer tries to assemble an offer for a given requested interval.
-func (s *Syncer) makeOffer(ctx context.Context, rn pb.Get) (*pb.Offer, error) {
+// The delta offers leave out the chunks which were received from the peer.
+func (s *Syncer) makeOffer(ctx context.Context, peer swarm.Address, rn pb.Get) (*pb.Offer, error) {
 
 	ctx, cancel := context.WithTimeout(ctx, makeOfferTimeout)
 	defer cancel()
@@ -411,6 +432,12 @@ func (s *Syncer) makeOffer(ctx context.Context, rn pb.Get) (*pb.Offer, error) {
 		return nil, err
 	}
 
+	if rn.Delta {
+		var omitted int
+		addrs, omitted = s.received.omit(peer, addrs)
+		s.metrics.SentOmitted.Add(float64(omitted))
+	}
+
 	o := new(pb.Offer)
 	o.Topmost = top
 	o.Chunks = make([]*pb.Chunk, 0, len(addrs))
@@ -583,6 +610,8 @@ func (s *Syncer) cursorHandler(ctx context.Context, p p2p.Peer, stream p2p.Strea
 
 func (s *Syncer) disconnect(peer p2p.Peer) error {
 	s.limiter.Clear(peer.Address.ByteString())
+	s.bandwidth.clear(peer.Address)
+	s.received.clear(peer.Address)
 	return nil
 }
 
@@ -149,6 +149,78 @@ func TestIncoming_WantAll(t *testing.T) {
 	}
 }
 
+func TestIncoming_DeltaOffer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		topMost            = uint64(4)
+		peer               = swarm.RandAddress(t)
+		upstream, _        = newPullSync(t, nil, 5, mock.WithSubscribeResp(results, nil), mock.WithChunks(chunks...))
+		upRecorder         = streamtest.New(streamtest.WithProtocols(upstream.Protocol()))
+		ps, _              = newPullSync(t, upRecorder, 5, mock.WithSubscribeResp(results, nil))
+		recorder           = streamtest.New(streamtest.WithProtocols(ps.Protocol()), streamtest.WithBaseAddr(peer))
+		psClient, clientDb = newPullSync(t, recorder, 0)
+	)
+
+	// the chunks are received from the peer
+	if _, _, err := ps.Sync(context.Background(), peer, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// so they are left out of the offer to the same peer
+	topmost, count, err := psClient.Sync(context.Background(), swarm.ZeroAddress, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topmost != topMost {
+		t.Fatalf("got offer topmost %d but want %d", topmost, topMost)
+	}
+	if count != 0 {
+		t.Fatalf("got %d chunks but want none", count)
+	}
+	if p := clientDb.PutCalls(); p != 0 {
+		t.Fatalf("want no puts but got %d", p)
+	}
+}
+
+func TestIncoming_BandwidthBudget(t *testing.T) {
+	t.Parallel()
+
+	validStamp := func(ch swarm.Chunk) (swarm.Chunk, error) {
+		return ch, nil
+	}
+
+	for _, tc := range []struct {
+		name   string
+		budget pullsync.Budget
+	}{
+		{name: "global", budget: pullsync.Budget{Bandwidth: 1}},
+		{name: "peer", budget: pullsync.Budget{PeerBandwidth: 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ps, _              = newPullSync(t, nil, 5, mock.WithSubscribeResp(results, nil), mock.WithChunks(chunks...))
+				recorder           = streamtest.New(streamtest.WithProtocols(ps.Protocol()))
+				psClient, clientDb = newPullSyncWithBudget(t, recorder, 0, validStamp, tc.budget)
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			// the budget allows a single chunk before the deadline
+			_, _, err := psClient.Sync(ctx, swarm.ZeroAddress, 0, 0)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if p := clientDb.PutCalls(); p != 0 {
+				t.Fatalf("want no puts but got %d", p)
+			}
+		})
+	}
+}
+
 func TestIncoming_WantErrors(t *testing.T) {
 	t.Parallel()
 
@@ -351,6 +423,19 @@ func newPullSyncWithStamperValidator(
 ) (*pullsync.Syncer, *mock.ReserveStore) {
 	t.Helper()
 
+	return newPullSyncWithBudget(t, s, maxPage, validStamp, pullsync.Budget{}, o...)
+}
+
+func newPullSyncWithBudget(
+	t *testing.T,
+	s p2p.Streamer,
+	maxPage uint64,
+	validStamp postage.ValidStampFn,
+	budget pullsync.Budget,
+	o ...mock.Option,
+) (*pullsync.Syncer, *mock.ReserveStore) {
+	t.Helper()
+
 	storage := mock.NewReserve(o...)
 	logger := log.Noop
 	unwrap := func(swarm.Chunk) {}
@@ -363,6 +448,7 @@ func newPullSyncWithStamperValidator(
 		validStamp,
 		logger,
 		maxPage,
+		budget,
 	)
 
 	t.Cleanup(func() {
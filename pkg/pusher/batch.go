@@ -0,0 +1,137 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pusher
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/calmw/bee-tron/pkg/pushsync"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// batcher collects the chunks pushed by the workers while a batch is being
+// pushed and pushes them at once, so that the chunks sharing the closest peer
+// are pushed in a single stream. A chunk pushed while no batch is being
+// pushed is pushed right away.
+type batcher struct {
+	pushSyncer pushsync.BatchPushSyncer
+	reqC       chan *batchRequest
+}
+
+type batchRequest struct {
+	ctx     context.Context
+	chunk   swarm.Chunk
+	resultC chan batchResult
+}
+
+type batchResult struct {
+	receipt *pushsync.Receipt
+	err     error
+}
+
+func newBatcher(pushSyncer pushsync.BatchPushSyncer) *batcher {
+	return &batcher{
+		pushSyncer: pushSyncer,
+		reqC:       make(chan *batchRequest),
+	}
+}
+
+// push adds the chunk to the next batch and waits for its receipt.
+func (b *batcher) push(ctx context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+	req := &batchRequest{ctx: ctx, chunk: ch, resultC: make(chan batchResult, 1)}
+	select {
+	case b.reqC <- req:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case res := <-req.resultC:
+		return res.receipt, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run collects the chunks while a batch is being pushed, and pushes them once
+// no batch is being pushed or the batch is full, until the context is done.
+func (b *batcher) run(ctx context.Context) {
+	var (
+		pending  []*batchRequest
+		inflight int
+		doneC    = make(chan struct{})
+	)
+
+	flush := func() {
+		reqs := pending
+		pending = nil
+		inflight++
+		go func() {
+			b.flush(reqs)
+			select {
+			case doneC <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	for {
+		select {
+		case req := <-b.reqC:
+			pending = append(pending, req)
+			if inflight == 0 || len(pending) == pushsync.MaxBatchSize {
+				flush()
+			}
+		case <-doneC:
+			inflight--
+			if inflight == 0 && len(pending) > 0 {
+				flush()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flush pushes the chunks of the requests and hands them their receipts.
+func (b *batcher) flush(reqs []*batchRequest) {
+	ctx, cancel := batchContext(reqs)
+	defer cancel()
+
+	chunks := make([]swarm.Chunk, len(reqs))
+	for i, req := range reqs {
+		chunks[i] = req.chunk
+	}
+	receipts, errs := b.pushSyncer.PushChunksToClosest(ctx, chunks)
+	for i, req := range reqs {
+		req.resultC <- batchResult{receipt: receipts[i], err: errs[i]}
+	}
+}
+
+// batchContext returns the context of the push of the chunks of the
+// requests, which is done once the contexts of all the requests are done.
+func batchContext(reqs []*batchRequest) (context.Context, context.CancelFunc) {
+	if len(reqs) == 1 {
+		return context.WithCancel(reqs[0].ctx)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(reqs[0].ctx))
+	var pending atomic.Int32
+	pending.Store(int32(len(reqs)))
+	stops := make([]func() bool, len(reqs))
+	for i, req := range reqs {
+		stops[i] = context.AfterFunc(req.ctx, func() {
+			if pending.Add(-1) == 0 {
+				cancel()
+			}
+		})
+	}
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pusher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/pushsync"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+)
+
+const deadLetterPrefix = "pusher_dead_letter_"
+
+// Default dead-letter option values.
+const (
+	DefaultDeadLetterAttempts        = 32
+	DefaultDeadLetterRedriveInterval = time.Hour
+)
+
+// maxDeadLetterErrors limits the error history kept for a chunk.
+const maxDeadLetterErrors = 16
+
+// ErrDeadLetterNotFound is the error returned if the chunk is not
+// dead-lettered.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+var _ DeadLetterQueue = (*Service)(nil)
+
+// DeadLetterQueue manages the chunks which repeatedly failed to be pushed
+// and are not retried anymore.
+type DeadLetterQueue interface {
+	// DeadLetters returns the dead-lettered chunks.
+	DeadLetters() []DeadLetter
+	// Redrive pushes the dead-lettered chunk again, all of them if the
+	// address is zero, and returns the number of the chunks re-driven. The
+	// chunks are pushed in the background and the ones which fail again
+	// stay dead-lettered.
+	Redrive(addr swarm.Address) int
+	// DeleteDeadLetter drops the dead-lettered chunk.
+	DeleteDeadLetter(addr swarm.Address) error
+}
+
+// DeadLetterOptions configures the dead-lettering of the chunks which
+// repeatedly fail to be pushed.
+type DeadLetterOptions struct {
+	StateStore      storage.StateStorer // persists the dead letters, they are kept in memory only if nil
+	Attempts        int                 // failed attempts after which a chunk is dead-lettered, zero retries forever
+	RedriveInterval time.Duration       // interval of the scheduled re-drives, zero disables them
+}
+
+// DeadLetter is a chunk which repeatedly failed to be pushed.
+type DeadLetter struct {
+	Address  swarm.Address `json:"address"`
+	Data     []byte        `json:"data"`
+	Stamp    []byte        `json:"stamp"`
+	Errors   []string      `json:"errors"`   // the errors of the last failed attempts, the latest last
+	Failed   int64         `json:"failed"`   // unix time in nanoseconds of the last failure
+	Redrives int           `json:"redrives"` // the number of the failed re-drives
+}
+
+// BatchID returns the batch of the stamp of the chunk.
+func (l DeadLetter) BatchID() []byte {
+	if len(l.Stamp) < swarm.HashSize {
+		return nil
+	}
+	return l.Stamp[:swarm.HashSize]
+}
+
+func (l DeadLetter) chunk() (swarm.Chunk, error) {
+	stamp := new(postage.Stamp)
+	if err := stamp.UnmarshalBinary(l.Stamp); err != nil {
+		return nil, fmt.Errorf("unmarshal stamp: %w", err)
+	}
+	return swarm.NewChunk(l.Address, l.Data).WithStamp(stamp), nil
+}
+
+func deadLetterKey(addr swarm.Address) string {
+	return deadLetterPrefix + addr.String()
+}
+
+// deadLetters keeps the failures of the chunks being retried and the
+// chunks dead-lettered after too many of them.
+type deadLetters struct {
+	opts DeadLetterOptions
+
+	mu        sync.Mutex
+	failures  map[string][]string   // errors of the chunks being retried by their identity addresses
+	letters   map[string]DeadLetter // dead letters by the chunk addresses
+	redriving map[string]struct{}   // dead letters being re-driven
+}
+
+func newDeadLetters(opts DeadLetterOptions) (*deadLetters, error) {
+	d := &deadLetters{
+		opts:      opts,
+		failures:  make(map[string][]string),
+		letters:   make(map[string]DeadLetter),
+		redriving: make(map[string]struct{}),
+	}
+	if opts.StateStore == nil {
+		return d, nil
+	}
+
+	err := opts.StateStore.Iterate(deadLetterPrefix, func(key, val []byte) (bool, error) {
+		var l DeadLetter
+		if err := json.Unmarshal(val, &l); err != nil {
+			return false, fmt.Errorf("unmarshal dead letter %s: %w", string(key), err)
+		}
+		d.letters[l.Address.ByteString()] = l
+		return false, nil
+	})
+	if err != nil {
+		return d, fmt.Errorf("load dead letters: %w", err)
+	}
+	return d, nil
+}
+
+// fail records the failed attempt of the chunk and returns the dead letter
+// of the chunk and true if the chunk ran out of the attempts.
+func (d *deadLetters) fail(idAddress swarm.Address, chunk swarm.Chunk, cause error) (DeadLetter, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	errs := append(d.failures[idAddress.ByteString()], cause.Error())
+	if len(errs) < d.opts.Attempts {
+		d.failures[idAddress.ByteString()] = errs
+		return DeadLetter{}, false, nil
+	}
+	delete(d.failures, idAddress.ByteString())
+
+	stamp, err := chunk.Stamp().MarshalBinary()
+	if err != nil {
+		return DeadLetter{}, false, fmt.Errorf("marshal stamp: %w", err)
+	}
+	l := DeadLetter{
+		Address: chunk.Address(),
+		Data:    chunk.Data(),
+		Stamp:   stamp,
+		Errors:  errs[max(0, len(errs)-maxDeadLetterErrors):],
+		Failed:  time.Now().UnixNano(),
+	}
+	if err := d.put(l); err != nil {
+		return DeadLetter{}, false, err
+	}
+	return l, true, nil
+}
+
+// forget drops the failures of the chunk which was pushed.
+func (d *deadLetters) forget(idAddress swarm.Address) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.failures, idAddress.ByteString())
+}
+
+// claim returns the dead letters to re-drive, all the ones which are not
+// being re-driven already if the address is zero, and marks them as being
+// re-driven.
+func (d *deadLetters) claim(addr swarm.Address) []DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var letters []DeadLetter
+	for key, l := range d.letters {
+		if !addr.IsZero() && key != addr.ByteString() {
+			continue
+		}
+		if _, ok := d.redriving[key]; ok {
+			continue
+		}
+		d.redriving[key] = struct{}{}
+		letters = append(letters, l)
+	}
+	return letters
+}
+
+// redriven records the result of the re-drive of the dead letter, which is
+// removed if the chunk was pushed.
+func (d *deadLetters) redriven(l DeadLetter, cause error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.redriving, l.Address.ByteString())
+	if _, ok := d.letters[l.Address.ByteString()]; !ok {
+		// deleted while being re-driven
+		return nil
+	}
+	if cause == nil {
+		return d.delete(l.Address)
+	}
+
+	l.Errors = append(l.Errors, cause.Error())
+	l.Errors = l.Errors[max(0, len(l.Errors)-maxDeadLetterErrors):]
+	l.Failed = time.Now().UnixNano()
+	l.Redrives++
+	return d.put(l)
+}
+
+// release gives up the re-drive of the dead letter.
+func (d *deadLetters) release(l DeadLetter) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.redriving, l.Address.ByteString())
+}
+
+func (d *deadLetters) list() []DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	letters := make([]DeadLetter, 0, len(d.letters))
+	for _, l := range d.letters {
+		letters = append(letters, l)
+	}
+	return letters
+}
+
+func (d *deadLetters) remove(addr swarm.Address) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.letters[addr.ByteString()]; !ok {
+		return ErrDeadLetterNotFound
+	}
+	return d.delete(addr)
+}
+
+// put stores the dead letter, it must be called with the lock held.
+func (d *deadLetters) put(l DeadLetter) error {
+	if d.opts.StateStore != nil {
+		if err := d.opts.StateStore.Put(deadLetterKey(l.Address), l); err != nil {
+			return fmt.Errorf("put dead letter: %w", err)
+		}
+	}
+	d.letters[l.Address.ByteString()] = l
+	return nil
+}
+
+// delete removes the dead letter, it must be called with the lock held.
+func (d *deadLetters) delete(addr swarm.Address) error {
+	if d.opts.StateStore != nil {
+		if err := d.opts.StateStore.Delete(deadLetterKey(addr)); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("delete dead letter: %w", err)
+		}
+	}
+	delete(d.letters, addr.ByteString())
+	return nil
+}
+
+// DeadLetters implements the DeadLetterQueue interface.
+func (s *Service) DeadLetters() []DeadLetter {
+	return s.deadLetters.list()
+}
+
+// Redrive implements the DeadLetterQueue interface.
+func (s *Service) Redrive(addr swarm.Address) int {
+	letters := s.deadLetters.claim(addr)
+	for _, l := range letters {
+		s.redriveWg.Add(1)
+		go func() {
+			defer s.redriveWg.Done()
+			s.redrive(l)
+		}()
+	}
+	return len(letters)
+}
+
+// DeleteDeadLetter implements the DeadLetterQueue interface.
+func (s *Service) DeleteDeadLetter(addr swarm.Address) error {
+	return s.deadLetters.remove(addr)
+}
+
+// deadLetter records the failed push of the deferred chunk and reports
+// whether the chunk was dead-lettered, in which case it is not retried. The
+// failures caused by the lack of the peers or the shallow receipts, which
+// have their own retry budget, are not counted.
+func (s *Service) deadLetter(ctx context.Context, op *Op, cause error) bool {
+	if s.deadLetters.opts.Attempts <= 0 || cause == nil ||
+		errors.Is(cause, topology.ErrNotFound) ||
+		errors.Is(cause, pushsync.ErrShallowReceipt) ||
+		errors.Is(cause, context.Canceled) {
+		return false
+	}
+
+	l, ok, err := s.deadLetters.fail(op.identityAddress, op.Chunk, cause)
+	if err != nil {
+		s.logger.Error(err, "pusher: failed to dead-letter chunk", "chunk_address", op.Chunk.Address())
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	s.metrics.DeadLettered.Inc()
+	s.logger.Warning("chunk dead-lettered after too many failed pushes", "chunk_address", l.Address, "error", cause)
+	// the chunk is kept by the dead letter, so it can be removed from the upload store
+	if err := s.storer.Report(ctx, op.Chunk, storage.ChunkCouldNotSync); err != nil {
+		s.logger.Debug("pusher: failed to report dead-lettered chunk", "chunk_address", l.Address, "error", err)
+	}
+	return true
+}
+
+// redrive pushes the dead-lettered chunk directly and records the result.
+func (s *Service) redrive(l DeadLetter) {
+	chunk, err := l.chunk()
+	if err == nil {
+		op := &Op{Chunk: chunk, Err: make(chan error, 1), Direct: true}
+		select {
+		case s.redriveC <- op:
+		case <-s.quit:
+			s.deadLetters.release(l)
+			return
+		}
+		select {
+		case err = <-op.Err:
+		case <-s.quit:
+			s.deadLetters.release(l)
+			return
+		}
+	}
+
+	if err == nil {
+		s.metrics.Redriven.Inc()
+	}
+	if err := s.deadLetters.redriven(l, err); err != nil {
+		s.logger.Error(err, "pusher: failed to record re-drive", "chunk_address", l.Address)
+	}
+}
+
+// redriveWorker re-drives all the dead letters periodically.
+func (s *Service) redriveWorker(interval time.Duration) {
+	defer s.redriveWg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := s.Redrive(swarm.ZeroAddress); n > 0 {
+				s.logger.Debug("re-driving dead letters", "count", n)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
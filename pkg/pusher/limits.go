@@ -0,0 +1,195 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pusher
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+)
+
+// Default concurrency and queue limit values.
+const (
+	DefaultWorkers       = ConcurrentPushes
+	DefaultPeerInflight  = 0
+	DefaultQueueCapacity = 2 * ConcurrentPushes
+)
+
+// ErrInvalidLimits is the error returned if the limits are rejected.
+var ErrInvalidLimits = errors.New("invalid pusher limits")
+
+var _ Controller = (*Service)(nil)
+
+// Controller adjusts the pusher limits at runtime and reports the state of
+// its queue.
+type Controller interface {
+	// Limits returns the current limits.
+	Limits() Limits
+	// SetLimits validates and applies the limits, the chunks already being
+	// pushed are not affected.
+	SetLimits(l Limits) error
+	// Queued returns the number of the chunks waiting to be pushed.
+	Queued() int
+	// Saturated reports whether the queue is full, in which case the
+	// uploads should be slowed down.
+	Saturated() bool
+}
+
+// Limits are the concurrency and queue limits of the pusher.
+type Limits struct {
+	Workers       int // number of chunks pushed concurrently
+	PeerInflight  int // number of chunks pushed concurrently to the same closest peer, 0 for no limit
+	QueueCapacity int // number of chunks waiting for a worker
+}
+
+func (l Limits) validate() error {
+	if l.Workers <= 0 {
+		return fmt.Errorf("%w: workers must be positive", ErrInvalidLimits)
+	}
+	if l.PeerInflight < 0 {
+		return fmt.Errorf("%w: peer inflight must not be negative", ErrInvalidLimits)
+	}
+	if l.QueueCapacity <= 0 {
+		return fmt.Errorf("%w: queue capacity must be positive", ErrInvalidLimits)
+	}
+	return nil
+}
+
+// Limits implements the Controller interface.
+func (s *Service) Limits() Limits {
+	return Limits{
+		Workers:       s.workers.getLimit(),
+		PeerInflight:  s.peerInflight.getLimit(),
+		QueueCapacity: s.queue.getLimit(),
+	}
+}
+
+// SetLimits implements the Controller interface.
+func (s *Service) SetLimits(l Limits) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	s.workers.setLimit(l.Workers)
+	s.peerInflight.setLimit(l.PeerInflight)
+	s.queue.setLimit(l.QueueCapacity)
+	s.logger.Info("pusher limits changed", "workers", l.Workers, "peer_inflight", l.PeerInflight, "queue_capacity", l.QueueCapacity)
+	return nil
+}
+
+// Queued implements the Controller interface.
+func (s *Service) Queued() int {
+	return s.queue.count(globalKey)
+}
+
+// Saturated implements the Controller interface.
+func (s *Service) Saturated() bool {
+	return s.queue.full(globalKey)
+}
+
+// closestPeer returns the peer the chunk is expected to be pushed to and
+// whether it is limited, the peer is the closest one selected the same way
+// as by the pushsync.
+func (s *Service) closestPeer(addr swarm.Address) (swarm.Address, bool) {
+	if s.peers == nil || s.peerInflight.getLimit() <= 0 {
+		return swarm.ZeroAddress, false
+	}
+	peer, err := s.peers.ClosestPeer(addr, false, topology.Select{Reachable: true, Healthy: true})
+	if err != nil {
+		return swarm.ZeroAddress, false
+	}
+	return peer, true
+}
+
+// globalKey is the key of the limiters which are not kept per peer.
+const globalKey = ""
+
+// limiter limits the number of the concurrent operations per key. The limit
+// can be changed at any time and applies to the operations started
+// afterwards.
+type limiter struct {
+	mu     sync.Mutex
+	limit  int // zero for no limit
+	counts map[string]int
+	wake   chan struct{} // closed when a slot may have become free
+}
+
+func newLimiter(limit int) *limiter {
+	return &limiter{
+		limit:  limit,
+		counts: make(map[string]int),
+		wake:   make(chan struct{}),
+	}
+}
+
+// acquire waits for a free slot of the key and takes it. It returns false
+// if the quit channel is closed first.
+func (l *limiter) acquire(key string, quit <-chan struct{}) bool {
+	for {
+		l.mu.Lock()
+		if l.limit <= 0 || l.counts[key] < l.limit {
+			l.counts[key]++
+			l.mu.Unlock()
+			return true
+		}
+		wake := l.wake
+		l.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-quit:
+			return false
+		}
+	}
+}
+
+// release frees the slot of the key taken by acquire.
+func (l *limiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.counts[key]--; l.counts[key] <= 0 {
+		delete(l.counts, key)
+	}
+	l.notify()
+}
+
+func (l *limiter) setLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = limit
+	l.notify()
+}
+
+func (l *limiter) getLimit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limit
+}
+
+func (l *limiter) count(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.counts[key]
+}
+
+// full reports whether all the slots of the key are taken.
+func (l *limiter) full(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limit > 0 && l.counts[key] >= l.limit
+}
+
+// notify wakes up the waiting acquires, it must be called with the lock held.
+func (l *limiter) notify() {
+	close(l.wake)
+	l.wake = make(chan struct{})
+}
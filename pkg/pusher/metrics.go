@@ -16,6 +16,8 @@ type metrics struct {
 	MarkAndSweepTime prometheus.Histogram
 	SyncTime         prometheus.Histogram
 	ErrorTime        prometheus.Histogram
+	DeadLettered     prometheus.Counter
+	Redriven         prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -54,9 +56,30 @@ func newMetrics() metrics {
 			Help:      "Histogram of time spent before giving up on syncing a chunk.",
 			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 60},
 		}),
+		DeadLettered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "dead_lettered",
+			Help:      "Total chunks dead-lettered after too many failed pushes.",
+		}),
+		Redriven: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "redriven",
+			Help:      "Total dead-lettered chunks pushed successfully when re-driven.",
+		}),
 	}
 }
 
 func (s *Service) Metrics() []prometheus.Collector {
 	return m.PrometheusCollectorsFromFields(s.metrics)
 }
+
+// StatusMetrics exposes metrics that are exposed on the status protocol.
+func (s *Service) StatusMetrics() []prometheus.Collector {
+	return []prometheus.Collector{
+		s.metrics.TotalSynced,
+		s.metrics.TotalErrors,
+		s.metrics.SyncTime,
+	}
+}
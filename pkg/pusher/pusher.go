@@ -51,6 +51,7 @@ type Service struct {
 	networkID         uint64
 	storer            Storer
 	pushSyncer        pushsync.PushSyncer
+	batcher           *batcher
 	batchExist        postage.BatchExist
 	logger            log.Logger
 	metrics           metrics
@@ -59,6 +60,13 @@ type Service struct {
 	inflight          *inflight
 	attempts          *attempts
 	smuggler          chan OpChan
+	peers             topology.ClosestPeerer
+	workers           *limiter
+	peerInflight      *limiter
+	queue             *limiter
+	deadLetters       *deadLetters
+	redriveC          chan *Op
+	redriveWg         sync.WaitGroup
 }
 
 const (
@@ -75,7 +83,20 @@ func New(
 	logger log.Logger,
 	warmupTime time.Duration,
 	retryCount int,
+	peers topology.ClosestPeerer,
+	limits Limits,
+	deadLetter DeadLetterOptions,
 ) *Service {
+	if limits.Workers <= 0 {
+		limits.Workers = DefaultWorkers
+	}
+	if limits.PeerInflight < 0 {
+		limits.PeerInflight = DefaultPeerInflight
+	}
+	if limits.QueueCapacity <= 0 {
+		limits.QueueCapacity = DefaultQueueCapacity
+	}
+
 	p := &Service{
 		networkID:         networkID,
 		storer:            storer,
@@ -88,8 +109,28 @@ func New(
 		inflight:          newInflight(),
 		attempts:          &attempts{retryCount: retryCount, attempts: make(map[string]int)},
 		smuggler:          make(chan OpChan),
+		peers:             peers,
+		workers:           newLimiter(limits.Workers),
+		peerInflight:      newLimiter(limits.PeerInflight),
+		queue:             newLimiter(limits.QueueCapacity),
+		redriveC:          make(chan *Op),
+	}
+
+	// the deferred uploads are pushed in batches if the push syncer supports it
+	if b, ok := pushSyncer.(pushsync.BatchPushSyncer); ok {
+		p.batcher = newBatcher(b)
+	}
+
+	var err error
+	if p.deadLetters, err = newDeadLetters(deadLetter); err != nil {
+		p.logger.Warning("failed to load dead letters", "error", err)
 	}
+
 	go p.chunksWorker(warmupTime)
+	if deadLetter.RedriveInterval > 0 {
+		p.redriveWg.Add(1)
+		go p.redriveWorker(deadLetter.RedriveInterval)
+	}
 	return p
 }
 
@@ -105,20 +146,37 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 
 	var (
 		ctx, cancel = context.WithCancel(context.Background())
-		sem         = make(chan struct{}, ConcurrentPushes)
 		cc          = make(chan *Op)
 	)
 
-	// inflight.set handles the backpressure for the maximum amount of inflight chunks
-	// and duplicate handling.
+	// the queue limiter handles the backpressure for the maximum amount of chunks
+	// waiting for a worker, inflight.set handles the duplicates.
 	chunks, unsubscribe := s.storer.SubscribePush(ctx)
 	defer func() {
 		unsubscribe()
 		cancel()
 	}()
 
+	if s.batcher != nil {
+		go s.batcher.run(ctx)
+	}
+
 	var wg sync.WaitGroup
 
+	// enqueue waits for a free slot in the queue and hands the op to the loop
+	// below, which releases the slot once the op gets a worker or is dropped.
+	enqueue := func(op *Op) bool {
+		if !s.queue.acquire(globalKey, s.quit) {
+			return false
+		}
+		select {
+		case cc <- op:
+			return true
+		case <-s.quit:
+			return false
+		}
+	}
+
 	push := func(op *Op) {
 		var (
 			err      error
@@ -136,12 +194,9 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 			}
 
 			wg.Done()
-			<-sem
+			s.workers.release(globalKey)
 			if doRepeat {
-				select {
-				case cc <- op:
-				case <-s.quit:
-				}
+				_ = enqueue(op)
 			}
 		}()
 
@@ -155,10 +210,23 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 			op.Span = opentracing.NoopTracer{}.StartSpan("noOp")
 		}
 
+		// limit the chunks pushed concurrently to the same peer
+		if peer, ok := s.closestPeer(op.Chunk.Address()); ok {
+			if !s.peerInflight.acquire(peer.ByteString(), s.quit) {
+				return
+			}
+			defer s.peerInflight.release(peer.ByteString())
+		}
+
 		if op.Direct {
 			err = s.pushDirect(spanCtx, s.logger, op)
 		} else {
 			doRepeat, err = s.pushDeferred(spanCtx, s.logger, op)
+			if !doRepeat {
+				s.deadLetters.forget(op.identityAddress)
+			} else if s.deadLetter(ctx, op, err) {
+				doRepeat = false
+			}
 		}
 
 		if err != nil {
@@ -181,9 +249,11 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 					chunks = nil
 					continue
 				}
-				select {
-				case cc <- &Op{Chunk: ch, Direct: false}:
-				case <-s.quit:
+				if !enqueue(&Op{Chunk: ch, Direct: false}) {
+					return
+				}
+			case op := <-s.redriveC:
+				if !enqueue(op) {
 					return
 				}
 			case apiC := <-s.smuggler:
@@ -191,9 +261,7 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 					for {
 						select {
 						case op := <-apiC:
-							select {
-							case cc <- op:
-							case <-s.quit:
+							if !enqueue(op) {
 								return
 							}
 						case <-s.quit:
@@ -214,11 +282,13 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 		case op := <-cc:
 			idAddress, err := storage.IdentityAddress(op.Chunk)
 			if err != nil {
+				s.queue.release(globalKey)
 				op.Err <- err
 				continue
 			}
 			op.identityAddress = idAddress
 			if s.inflight.set(idAddress, op.Chunk.Stamp().BatchID()) {
+				s.queue.release(globalKey)
 				if op.Direct {
 					select {
 					case op.Err <- nil:
@@ -228,13 +298,12 @@ func (s *Service) chunksWorker(warmupTime time.Duration) {
 				}
 				continue
 			}
-			select {
-			case sem <- struct{}{}:
-				wg.Add(1)
-				go push(op)
-			case <-s.quit:
+			if !s.workers.acquire(globalKey, s.quit) {
 				return
 			}
+			s.queue.release(globalKey)
+			wg.Add(1)
+			go push(op)
 		case <-s.quit:
 			return
 		}
@@ -258,7 +327,7 @@ func (s *Service) pushDeferred(ctx context.Context, logger log.Logger, op *Op) (
 		return false, errors.Join(err, s.storer.Report(ctx, op.Chunk, storage.ChunkCouldNotSync))
 	}
 
-	switch _, err := s.pushSyncer.PushChunkToClosest(ctx, op.Chunk); {
+	switch _, err := s.pushDeferredChunk(ctx, op.Chunk); {
 	case errors.Is(err, topology.ErrWantSelf):
 		// store the chunk
 		loggerV1.Debug("chunk stays here, i'm the closest node", "chunk_address", op.Chunk.Address())
@@ -293,6 +362,15 @@ func (s *Service) pushDeferred(ctx context.Context, logger log.Logger, op *Op) (
 	return false, nil
 }
 
+// pushDeferredChunk pushes the chunk of a deferred upload in a batch with the
+// other chunks being pushed, or on its own if the batches are not supported.
+func (s *Service) pushDeferredChunk(ctx context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+	if s.batcher != nil {
+		return s.batcher.push(ctx, ch)
+	}
+	return s.pushSyncer.PushChunkToClosest(ctx, ch)
+}
+
 func (s *Service) pushDirect(ctx context.Context, logger log.Logger, op *Op) error {
 	loggerV1 := logger.V(1).Build()
 
@@ -366,5 +444,6 @@ func (s *Service) Close() error {
 	case <-s.chunksWorkerQuitC:
 	case <-time.After(10 * time.Second):
 	}
+	s.redriveWg.Wait()
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/pushsync"
 	pushsyncmock "github.com/calmw/bee-tron/pkg/pushsync/mock"
 	"github.com/calmw/bee-tron/pkg/spinlock"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
 	storage "github.com/calmw/bee-tron/pkg/storage"
 	testingc "github.com/calmw/bee-tron/pkg/storage/testing"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -167,6 +168,111 @@ func TestChunkSyncing(t *testing.T) {
 	})
 }
 
+type batchPushSyncer struct {
+	pushsync.PushSyncer
+
+	mu      sync.Mutex
+	batches [][]swarm.Chunk
+	gate    chan struct{} // the first batch waits until it is closed, if set
+}
+
+func (b *batchPushSyncer) PushChunksToClosest(ctx context.Context, chunks []swarm.Chunk) ([]*pushsync.Receipt, []error) {
+	b.mu.Lock()
+	b.batches = append(b.batches, chunks)
+	first := len(b.batches) == 1
+	b.mu.Unlock()
+
+	if first && b.gate != nil {
+		<-b.gate
+	}
+
+	receipts := make([]*pushsync.Receipt, len(chunks))
+	errs := make([]error, len(chunks))
+	for i, ch := range chunks {
+		receipts[i], errs[i] = b.PushChunkToClosest(ctx, ch)
+	}
+	return receipts, errs
+}
+
+func (b *batchPushSyncer) batchCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.batches)
+}
+
+func (b *batchPushSyncer) largestBatch() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := 0
+	for _, batch := range b.batches {
+		n = max(n, len(batch))
+	}
+	return n
+}
+
+// TestChunkSyncingBatches checks that the chunks of the deferred uploads are
+// pushed in batches and handled one by one by their results.
+func TestChunkSyncingBatches(t *testing.T) {
+	t.Parallel()
+
+	stored := testingc.GenerateTestRandomChunk()
+
+	pushSyncService := &batchPushSyncer{
+		PushSyncer: pushsyncmock.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+			if chunk.Equal(stored) {
+				return nil, topology.ErrWantSelf
+			}
+			return &pushsync.Receipt{Address: chunk.Address()}, nil
+		}),
+		gate: make(chan struct{}),
+	}
+
+	storer := &mockStorer{
+		chunks: make(chan swarm.Chunk),
+	}
+
+	_ = createPusher(
+		t,
+		storer,
+		pushSyncService,
+		defaultMockBatchStore,
+		defaultRetryCount,
+	)
+
+	chunks := testingc.GenerateTestRandomChunks(10)
+	storer.chunks <- chunks[0]
+
+	// the lone chunk is pushed right away, the others are collected
+	// while it is being pushed
+	err := spinlock.Wait(spinTimeout, func() bool {
+		return pushSyncService.batchCount() == 1
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range append(chunks[1:], stored) {
+		storer.chunks <- ch
+	}
+	close(pushSyncService.gate)
+
+	err = spinlock.Wait(spinTimeout, func() bool {
+		for _, ch := range chunks {
+			if !storer.isReported(ch, storage.ChunkSynced) {
+				return false
+			}
+		}
+		return storer.isReported(stored, storage.ChunkStored)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := pushSyncService.largestBatch(); n < 2 {
+		t.Fatalf("got largest batch of %d chunks, want more than one", n)
+	}
+}
+
 func TestChunkStored(t *testing.T) {
 	t.Parallel()
 
@@ -410,6 +516,135 @@ func TestChunkWithInvalidStampSkipped(t *testing.T) {
 	})
 }
 
+func TestPusherLimits(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	pushSyncService := pushsyncmock.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return &pushsync.Receipt{Address: chunk.Address()}, nil
+	})
+
+	storer := &mockStorer{
+		chunks: make(chan swarm.Chunk),
+	}
+
+	limits := pusher.Limits{Workers: 1, QueueCapacity: 1}
+	pusherSvc := pusher.New(1, storer, pushSyncService, defaultMockBatchStore, log.Noop, 0, defaultRetryCount, nil, limits, pusher.DeadLetterOptions{})
+	testutil.CleanupCloser(t, pusherSvc)
+
+	if got := pusherSvc.Limits(); got != limits {
+		t.Fatalf("got limits %+v, want %+v", got, limits)
+	}
+	if err := pusherSvc.SetLimits(pusher.Limits{QueueCapacity: 1}); !errors.Is(err, pusher.ErrInvalidLimits) {
+		t.Fatalf("got error %v, want %v", err, pusher.ErrInvalidLimits)
+	}
+
+	// the first chunk occupies the only worker and the second one the queue
+	chunks := []swarm.Chunk{testingc.GenerateTestRandomChunk(), testingc.GenerateTestRandomChunk()}
+	for _, chunk := range chunks {
+		storer.chunks <- chunk
+	}
+
+	err := spinlock.Wait(spinTimeout, pusherSvc.Saturated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pusherSvc.Queued(); got != 1 {
+		t.Fatalf("got %d queued chunks, want 1", got)
+	}
+
+	if err := pusherSvc.SetLimits(pusher.Limits{Workers: 2, QueueCapacity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	err = spinlock.Wait(spinTimeout, func() bool {
+		return !pusherSvc.Saturated()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	close(release)
+	for _, chunk := range chunks {
+		err := spinlock.Wait(spinTimeout, func() bool {
+			return storer.isReported(chunk, storage.ChunkSynced)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+	fail.Store(true)
+	pushSyncService := pushsyncmock.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
+		if fail.Load() {
+			return nil, errors.New("push failed")
+		}
+		return &pushsync.Receipt{Address: chunk.Address()}, nil
+	})
+
+	deadLetter := pusher.DeadLetterOptions{StateStore: statestore.NewStateStore(), Attempts: 2}
+	storer := &mockStorer{
+		chunks: make(chan swarm.Chunk),
+	}
+	pusherSvc := pusher.New(1, storer, pushSyncService, defaultMockBatchStore, log.Noop, 0, defaultRetryCount, nil, pusher.Limits{}, deadLetter)
+	testutil.CleanupCloser(t, pusherSvc)
+
+	chunk := testingc.GenerateTestRandomChunk()
+	storer.chunks <- chunk
+
+	// the chunk is removed from the upload store once it is dead-lettered
+	err := spinlock.Wait(spinTimeout, func() bool {
+		return storer.isReported(chunk, storage.ChunkCouldNotSync)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	letters := pusherSvc.DeadLetters()
+	if len(letters) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(letters))
+	}
+	if !letters[0].Address.Equal(chunk.Address()) {
+		t.Fatalf("got dead letter %s, want %s", letters[0].Address, chunk.Address())
+	}
+	if len(letters[0].Errors) != 2 {
+		t.Fatalf("got %d errors, want 2", len(letters[0].Errors))
+	}
+
+	// the dead letters are persisted
+	restarted := pusher.New(1, &mockStorer{chunks: make(chan swarm.Chunk)}, pushSyncService, defaultMockBatchStore, log.Noop, 0, defaultRetryCount, nil, pusher.Limits{}, deadLetter)
+	if got := len(restarted.DeadLetters()); got != 1 {
+		t.Fatalf("got %d persisted dead letters, want 1", got)
+	}
+	if err := restarted.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pusherSvc.DeleteDeadLetter(swarm.RandAddress(t)); !errors.Is(err, pusher.ErrDeadLetterNotFound) {
+		t.Fatalf("got error %v, want %v", err, pusher.ErrDeadLetterNotFound)
+	}
+
+	fail.Store(false)
+	if got := pusherSvc.Redrive(swarm.ZeroAddress); got != 1 {
+		t.Fatalf("got %d re-driven chunks, want 1", got)
+	}
+	err = spinlock.Wait(spinTimeout, func() bool {
+		return len(pusherSvc.DeadLetters()) == 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func createPusher(
 	t *testing.T,
 	storer pusher.Storer,
@@ -419,7 +654,7 @@ func createPusher(
 ) *pusher.Service {
 	t.Helper()
 
-	pusherService := pusher.New(1, storer, pushSyncService, validStamp, log.Noop, 0, retryCount)
+	pusherService := pusher.New(1, storer, pushSyncService, validStamp, log.Noop, 0, retryCount, nil, pusher.Limits{}, pusher.DeadLetterOptions{})
 	testutil.CleanupCloser(t, pusherService)
 
 	return pusherService
@@ -0,0 +1,372 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pushsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/accounting"
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/p2p/protobuf"
+	"github.com/calmw/bee-tron/pkg/pushsync/pb"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+)
+
+const batchStreamName = "pushsync-batch"
+
+// MaxBatchSize is the maximum number of chunks pushed to a peer in a single
+// stream.
+const MaxBatchSize = 64
+
+var _ BatchPushSyncer = (*PushSync)(nil)
+
+// BatchPushSyncer pushes multiple chunks in a single stream to each of the
+// peers.
+type BatchPushSyncer interface {
+	// PushChunksToClosest pushes the chunks to the network. The chunks which
+	// share the closest peer are pushed in a single stream and receipted at
+	// once, the ones the peer fails to receipt are pushed one by one. The
+	// receipts and the errors are returned in the order of the chunks, with
+	// the same meaning as the ones returned by PushChunkToClosest.
+	PushChunksToClosest(ctx context.Context, chunks []swarm.Chunk) ([]*Receipt, []error)
+}
+
+// PushChunksToClosest implements the BatchPushSyncer interface.
+func (ps *PushSync) PushChunksToClosest(ctx context.Context, chunks []swarm.Chunk) ([]*Receipt, []error) {
+	receipts := make([]*Receipt, len(chunks))
+	errs := make([]error, len(chunks))
+
+	// the receipts can not be cross-checked in the batches, so the chunks
+	// are pushed one by one in the cross-check mode
+	if ps.warmedUp() && ps.receiptMode != ReceiptCrossCheck {
+		// group the chunks by the closest peer
+		var (
+			peers   []swarm.Address
+			batches = make(map[string][]int)
+		)
+		for i, ch := range chunks {
+			idAddress, err := storage.IdentityAddress(ch)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			peer, err := ps.closestPeer(ch.Address(), true, ps.errSkip.ChunkPeers(idAddress))
+			if err != nil {
+				// left to be pushed on its own
+				continue
+			}
+			if _, ok := batches[peer.ByteString()]; !ok {
+				peers = append(peers, peer)
+			}
+			batches[peer.ByteString()] = append(batches[peer.ByteString()], i)
+		}
+
+		var wg sync.WaitGroup
+		for _, peer := range peers {
+			batch := batches[peer.ByteString()]
+			// a single chunk is pushed on its own right away
+			for len(batch) > 1 {
+				n := min(len(batch), MaxBatchSize)
+				wg.Add(1)
+				go func(idx []int) {
+					defer wg.Done()
+					ps.pushBatch(ctx, peer, chunks, idx, receipts)
+				}(batch[:n])
+				batch = batch[n:]
+			}
+		}
+		wg.Wait()
+	}
+
+	// the chunks which were not receipted in the batches are pushed one by one
+	var wg sync.WaitGroup
+	for i, ch := range chunks {
+		if receipts[i] != nil || errs[i] != nil {
+			continue
+		}
+		ps.metrics.BatchFallbackCounter.Inc()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			receipts[i], errs[i] = ps.PushChunkToClosest(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	return receipts, errs
+}
+
+// pushBatch pushes the chunks at the given indexes to the peer and stores
+// the receipts of the chunks which passed the checks at the same indexes.
+func (ps *PushSync) pushBatch(ctx context.Context, peer swarm.Address, chunks []swarm.Chunk, idx []int, receipts []*Receipt) {
+	ps.metrics.BatchPushCounter.Inc()
+
+	var (
+		req     = &pb.BatchDelivery{Deliveries: make([]*pb.Delivery, 0, len(idx))}
+		pos     = make([]int, 0, len(idx))
+		actions = make([]accounting.Action, 0, len(idx))
+	)
+	defer func() {
+		for _, action := range actions {
+			action.Cleanup()
+		}
+	}()
+	for _, i := range idx {
+		stamp, err := chunks[i].Stamp().MarshalBinary()
+		if err != nil {
+			continue
+		}
+		action, err := ps.prepareCredit(ctx, peer, chunks[i], true)
+		if err != nil {
+			continue
+		}
+		req.Deliveries = append(req.Deliveries, &pb.Delivery{
+			Address: chunks[i].Address().Bytes(),
+			Data:    chunks[i].Data(),
+			Stamp:   stamp,
+		})
+		pos = append(pos, i)
+		actions = append(actions, action)
+	}
+	if len(req.Deliveries) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTTL)
+	defer cancel()
+
+	ps.metrics.TotalSendAttempts.Inc()
+
+	now := time.Now()
+	var err error
+	defer func() {
+		ps.measurePushPeer(now, peer, err)
+		if err != nil {
+			ps.metrics.TotalFailedSendAttempts.Inc()
+			ps.logger.Debug("batch push failed", "peer_address", peer, "error", err)
+		}
+	}()
+
+	stream, err := ps.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, batchStreamName)
+	if err != nil {
+		err = fmt.Errorf("new stream for peer %s: %w", peer.String(), err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	if err = w.WriteMsgWithContext(ctx, req); err != nil {
+		err = fmt.Errorf("write batch delivery: %w peer %s", err, peer.String())
+		return
+	}
+
+	// the tagged chunks are from the local deferred uploads
+	for _, i := range pos {
+		if chunks[i].TagID() == 0 {
+			continue
+		}
+		if err := ps.store.Report(ctx, chunks[i], storage.ChunkSent); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			ps.logger.Debug("batch push report", "chunk_address", chunks[i].Address(), "error", err)
+		}
+	}
+
+	var resp pb.BatchReceipt
+	if err = r.ReadMsgWithContext(ctx, &resp); err != nil {
+		err = fmt.Errorf("read batch receipt: %w peer %s", err, peer.String())
+		return
+	}
+	if len(resp.Receipts) != len(pos) {
+		err = fmt.Errorf("invalid number of batch receipts %d peer %s", len(resp.Receipts), peer.String())
+		return
+	}
+
+	for j, receipt := range resp.Receipts {
+		i := pos[j]
+		if receipt.Err != "" {
+			ps.skipPeer(chunks[i], peer)
+			continue
+		}
+		if !chunks[i].Address().Equal(swarm.NewAddress(receipt.Address)) {
+			ps.topologyDriver.ReportMisbehavior(peer, "invalid receipt", invalidReceiptWeight)
+			ps.skipPeer(chunks[i], peer)
+			continue
+		}
+
+		// the peer is credited for every chunk it receipted, as it is debited
+		// for them, like in the push of a single chunk
+		ps.metrics.TotalSent.Inc()
+		if err := actions[j].Apply(); err != nil {
+			ps.logger.Debug("apply batch credit", "peer_address", peer, "chunk_address", chunks[i].Address(), "error", err)
+			continue
+		}
+
+		// the shallow receipts are left to be handled by the push on its own
+		if err := ps.checkReceipt(receipt); err != nil {
+			continue
+		}
+		receipts[i] = &Receipt{
+			Address:   swarm.NewAddress(receipt.Address),
+			Signature: receipt.Signature,
+			Nonce:     receipt.Nonce,
+		}
+	}
+}
+
+// skipPeer skips the peer in the pushes of the chunk for a while.
+func (ps *PushSync) skipPeer(ch swarm.Chunk, peer swarm.Address) {
+	if idAddress, err := storage.IdentityAddress(ch); err == nil {
+		ps.errSkip.Add(idAddress, peer, skiplistDur)
+	}
+}
+
+// batchResult is the receipt of a single chunk of the batch with the debit
+// to apply once it is written and the stored chunk to forward afterwards.
+type batchResult struct {
+	receipt *pb.Receipt
+	debit   accounting.Action
+	forward swarm.Chunk
+}
+
+func (ps *PushSync) batchHandler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	now := time.Now()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTTL)
+	defer cancel()
+
+	defer func() {
+		if err != nil {
+			ps.metrics.TotalHandlerTime.WithLabelValues("failure").Observe(time.Since(now).Seconds())
+			ps.metrics.TotalHandlerErrors.Inc()
+			_ = stream.Reset()
+		} else {
+			ps.metrics.TotalHandlerTime.WithLabelValues("success").Observe(time.Since(now).Seconds())
+			_ = stream.FullClose()
+		}
+	}()
+
+	var req pb.BatchDelivery
+	if err := r.ReadMsgWithContext(ctx, &req); err != nil {
+		return fmt.Errorf("pushsync read batch delivery: %w", err)
+	}
+	if len(req.Deliveries) == 0 || len(req.Deliveries) > MaxBatchSize {
+		return fmt.Errorf("invalid batch size %d pushed by peer %s", len(req.Deliveries), p.Address.String())
+	}
+
+	ps.metrics.TotalReceived.Add(float64(len(req.Deliveries)))
+
+	results := make([]batchResult, len(req.Deliveries))
+	var wg sync.WaitGroup
+	for i, d := range req.Deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = ps.batchReceipt(ctx, p.Address, d)
+		}()
+	}
+	wg.Wait()
+
+	resp := &pb.BatchReceipt{Receipts: make([]*pb.Receipt, 0, len(results))}
+	for _, res := range results {
+		resp.Receipts = append(resp.Receipts, res.receipt)
+	}
+	err = w.WriteMsgWithContext(ctx, resp)
+	for _, res := range results {
+		if res.debit == nil {
+			continue
+		}
+		if err == nil {
+			if err := res.debit.Apply(); err != nil {
+				ps.logger.Debug("apply batch debit", "peer_address", p.Address, "error", err)
+			}
+		}
+		res.debit.Cleanup()
+	}
+	if err != nil {
+		return fmt.Errorf("send batch receipt to peer %s: %w", p.Address.String(), err)
+	}
+
+	// the chunks stored within the neighborhood are forwarded to the closest
+	// peer after the receipts are sent, the peer will get them via pullsync
+	// otherwise
+	for _, res := range results {
+		if res.forward == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ps.pushToClosest(ctx, res.forward, false)
+			if err != nil && !errors.Is(err, topology.ErrNotFound) && !errors.Is(err, topology.ErrWantSelf) {
+				ps.logger.Error(nil, "failed to forward to closest peer", "chunk_address", res.forward.Address(), "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// batchReceipt stores or forwards the delivered chunk the same way as the
+// handler and returns the receipt of the chunk, or its error.
+func (ps *PushSync) batchReceipt(ctx context.Context, peer swarm.Address, d *pb.Delivery) batchResult {
+	errReceipt := func(err error) batchResult {
+		return batchResult{receipt: &pb.Receipt{Address: d.Address, Err: err.Error()}}
+	}
+
+	chunk, err := ps.deliveredChunk(peer, swarm.NewChunk(swarm.NewAddress(d.Address), d.Data), d.Stamp)
+	if err != nil {
+		return errReceipt(err)
+	}
+	chunkAddress := chunk.Address()
+
+	rad, err := ps.radius()
+	if err != nil {
+		return errReceipt(fmt.Errorf("pushsync: storage radius: %w", err))
+	}
+
+	var (
+		receipt *pb.Receipt
+		forward swarm.Chunk
+	)
+	if ps.topologyDriver.IsReachable() && swarm.Proximity(ps.address.Bytes(), chunkAddress.Bytes()) >= rad {
+		if receipt, err = ps.storeChunk(ctx, chunk, rad); err != nil {
+			return errReceipt(err)
+		}
+		forward = chunk
+	} else {
+		switch receipt, err = ps.pushToClosest(ctx, chunk, false); {
+		case errors.Is(err, topology.ErrWantSelf):
+			if receipt, err = ps.storeChunk(ctx, chunk, rad); err != nil {
+				return errReceipt(err)
+			}
+		case err == nil:
+			ps.metrics.Forwarder.Inc()
+		default:
+			ps.metrics.Forwarder.Inc()
+			return errReceipt(fmt.Errorf("handler: push to closest chunk %s: %w", chunkAddress, err))
+		}
+	}
+
+	debit, err := ps.accounting.PrepareDebit(ctx, peer, ps.pricer.Price(chunkAddress))
+	if err != nil {
+		return errReceipt(fmt.Errorf("prepare debit to peer %s before writeback: %w", peer.String(), err))
+	}
+
+	return batchResult{receipt: receipt, debit: debit, forward: forward}
+}
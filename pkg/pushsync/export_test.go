@@ -8,4 +8,5 @@ var (
 	ProtocolName    = protocolName
 	ProtocolVersion = protocolVersion
 	StreamName      = streamName
+	BatchStreamName = batchStreamName
 )
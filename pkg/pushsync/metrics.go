@@ -24,10 +24,17 @@ type metrics struct {
 	Storer                  prometheus.Counter
 	TotalHandlerTime        *prometheus.HistogramVec
 	PushToPeerTime          *prometheus.HistogramVec
+	PushPeerBin             *prometheus.CounterVec
+	ReceiptBinTime          *prometheus.HistogramVec
 
 	ReceiptDepth        *prometheus.CounterVec
 	ShallowReceiptDepth *prometheus.CounterVec
 	ShallowReceipt      prometheus.Counter
+	ReceiptCrossChecks  prometheus.Counter
+	ReceiptMismatches   prometheus.Counter
+
+	BatchPushCounter     prometheus.Counter
+	BatchFallbackCounter prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -122,6 +129,23 @@ func newMetrics() metrics {
 				Help:      "Histogram for time taken to push a chunk to a peer.",
 			}, []string{"status"},
 		),
+		PushPeerBin: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "push_peer_bin",
+				Help:      "Counter of pushes to peers in different proximity bins by their outcome.",
+			}, []string{"bin", "status"},
+		),
+		ReceiptBinTime: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "receipt_bin_time",
+				Help:      "Histogram for time taken to receive a receipt from peers in different proximity bins.",
+				Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			}, []string{"bin"},
+		),
 		ShallowReceiptDepth: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
@@ -137,6 +161,30 @@ func newMetrics() metrics {
 			Name:      "shallow_receipt",
 			Help:      "Total shallow receipts.",
 		}),
+		ReceiptCrossChecks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "receipt_cross_checks",
+			Help:      "Total receipts cross-checked against their duplicates.",
+		}),
+		ReceiptMismatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "receipt_mismatches",
+			Help:      "Total receipts which did not agree with their duplicates.",
+		}),
+		BatchPushCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_push_count",
+			Help:      "Total no of batches of chunks pushed to a single peer.",
+		}),
+		BatchFallbackCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_fallback_count",
+			Help:      "Total no of chunks not receipted in a batch and pushed on their own.",
+		}),
 		ReceiptDepth: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
@@ -152,3 +200,11 @@ func newMetrics() metrics {
 func (s *PushSync) Metrics() []prometheus.Collector {
 	return m.PrometheusCollectorsFromFields(s.metrics)
 }
+
+// StatusMetrics exposes metrics that are exposed on the status protocol.
+func (s *PushSync) StatusMetrics() []prometheus.Collector {
+	return []prometheus.Collector{
+		s.metrics.PushPeerBin,
+		s.metrics.ReceiptBinTime,
+	}
+}
@@ -52,6 +52,7 @@ const (
 const (
 	maxMultiplexForwards = 2 // number of extra peers to forward the request from the multiplex node
 	maxPushErrors        = 32
+	invalidReceiptWeight = 1 // weight of the misbehavior reported for peers replying with invalid receipts
 )
 
 var (
@@ -98,6 +99,7 @@ type PushSync struct {
 	warmupPeriod   time.Time
 
 	shallowReceiptTolerance uint8
+	receiptMode             ReceiptMode
 }
 
 type receiptResult struct {
@@ -126,7 +128,12 @@ func New(
 	tracer *tracing.Tracer,
 	warmupTime time.Duration,
 	shallowReceiptTolerance uint8,
+	receiptMode ReceiptMode,
 ) *PushSync {
+	if receiptMode == "" {
+		receiptMode = DefaultReceiptMode
+	}
+
 	ps := &PushSync{
 		address:                 address,
 		radius:                  radius,
@@ -147,6 +154,7 @@ func New(
 		errSkip:                 skippeers.NewList(time.Minute),
 		warmupPeriod:            time.Now().Add(warmupTime),
 		shallowReceiptTolerance: shallowReceiptTolerance,
+		receiptMode:             receiptMode,
 	}
 
 	ps.validStamp = ps.validStampWrapper(validStamp)
@@ -162,6 +170,10 @@ func (s *PushSync) Protocol() p2p.ProtocolSpec {
 				Name:    streamName,
 				Handler: s.handler,
 			},
+			{
+				Name:    batchStreamName,
+				Handler: s.batchHandler,
+			},
 		},
 	}
 }
@@ -223,24 +235,8 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 		span.Finish()
 	}()
 
-	stamp := new(postage.Stamp)
-	err = stamp.UnmarshalBinary(ch.Stamp)
-	if err != nil {
-		return fmt.Errorf("pushsync stamp unmarshall: %w", err)
-	}
-	chunk.WithStamp(stamp)
-
-	if cac.Valid(chunk) {
-		go ps.unwrap(chunk)
-	} else if chunk, err := soc.FromChunk(chunk); err == nil {
-		addr, err := chunk.Address()
-		if err != nil {
-			return err
-		}
-		ps.logger.Debug("handle gsoc", "peer_address", p.Address, "chunk_address", addr, "wrapped_chunk_address", chunk.WrappedChunk().Address())
-		ps.gsocHandler(chunk)
-	} else {
-		return swarm.ErrInvalidChunk
+	if chunk, err = ps.deliveredChunk(p.Address, chunk, ch.Stamp); err != nil {
+		return err
 	}
 
 	price := ps.pricer.Price(chunkAddress)
@@ -251,21 +247,9 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 	}
 
 	store := func(ctx context.Context) error {
-		ps.metrics.Storer.Inc()
-
-		chunkToPut, err := ps.validStamp(chunk)
+		receipt, err := ps.storeChunk(ctx, chunk, rad)
 		if err != nil {
-			return fmt.Errorf("invalid stamp: %w", err)
-		}
-
-		err = ps.store.ReservePutter().Put(ctx, chunkToPut)
-		if err != nil {
-			return fmt.Errorf("reserve put: %w", err)
-		}
-
-		signature, err := ps.signer.Sign(chunkToPut.Address().Bytes())
-		if err != nil {
-			return fmt.Errorf("receipt signature: %w", err)
+			return err
 		}
 
 		// return back receipt
@@ -277,8 +261,7 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 
 		attemptedWrite = true
 
-		receipt := pb.Receipt{Address: chunkToPut.Address().Bytes(), Signature: signature, Nonce: ps.nonce, StorageRadius: uint32(rad)}
-		if err := w.WriteMsgWithContext(ctx, &receipt); err != nil {
+		if err := w.WriteMsgWithContext(ctx, receipt); err != nil {
 			return fmt.Errorf("send receipt to peer %s: %w", p.Address.String(), err)
 		}
 
@@ -331,6 +314,54 @@ func (ps *PushSync) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream)
 	}
 }
 
+// deliveredChunk stamps the delivered chunk and hands it to the trojan or
+// the gsoc handlers, it returns an error if the chunk is not valid.
+func (ps *PushSync) deliveredChunk(peer swarm.Address, chunk swarm.Chunk, stampBytes []byte) (swarm.Chunk, error) {
+	stamp := new(postage.Stamp)
+	if err := stamp.UnmarshalBinary(stampBytes); err != nil {
+		return nil, fmt.Errorf("pushsync stamp unmarshall: %w", err)
+	}
+	chunk = chunk.WithStamp(stamp)
+
+	if cac.Valid(chunk) {
+		go ps.unwrap(chunk)
+	} else if chunk, err := soc.FromChunk(chunk); err == nil {
+		addr, err := chunk.Address()
+		if err != nil {
+			return nil, err
+		}
+		ps.logger.Debug("handle gsoc", "peer_address", peer, "chunk_address", addr, "wrapped_chunk_address", chunk.WrappedChunk().Address())
+		ps.gsocHandler(chunk)
+	} else {
+		return nil, swarm.ErrInvalidChunk
+	}
+
+	return chunk, nil
+}
+
+// storeChunk puts the chunk with a valid stamp to the reserve and returns
+// the signed receipt.
+func (ps *PushSync) storeChunk(ctx context.Context, chunk swarm.Chunk, rad uint8) (*pb.Receipt, error) {
+	ps.metrics.Storer.Inc()
+
+	chunkToPut, err := ps.validStamp(chunk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stamp: %w", err)
+	}
+
+	err = ps.store.ReservePutter().Put(ctx, chunkToPut)
+	if err != nil {
+		return nil, fmt.Errorf("reserve put: %w", err)
+	}
+
+	signature, err := ps.signer.Sign(chunkToPut.Address().Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("receipt signature: %w", err)
+	}
+
+	return &pb.Receipt{Address: chunkToPut.Address().Bytes(), Signature: signature, Nonce: ps.nonce, StorageRadius: uint32(rad)}, nil
+}
+
 // PushChunkToClosest sends chunk to the closest peer by opening a stream. It then waits for
 // a receipt from that peer and returns error or nil based on the receiving and
 // the validity of the receipt.
@@ -388,6 +419,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 
 	resultChan := make(chan receiptResult)
 
+	// the valid receipt waiting for its duplicate in the cross-check mode
+	var crossCheck *receiptResult
+
 	retryC := make(chan struct{}, max(1, parallelForwards))
 
 	retry := func() {
@@ -425,6 +459,10 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 			if errors.Is(err, topology.ErrNotFound) {
 				if skip.PruneExpiresAfter(idAddress, overDraftRefresh) == 0 { //no overdraft peers, we have depleted ALL peers
 					if inflight == 0 {
+						if crossCheck != nil {
+							ps.logger.Debug("receipt not cross-checked, no peers left", "chunk_address", ch.Address())
+							return crossCheck.receipt, nil
+						}
 						if ps.fullNode {
 							if cac.Valid(ch) {
 								go ps.unwrap(ch)
@@ -450,6 +488,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 
 			if err != nil {
 				if inflight == 0 {
+					if crossCheck != nil {
+						return crossCheck.receipt, nil
+					}
 					return nil, err
 				}
 				// inflight request in progress, wait for it's result
@@ -482,7 +523,7 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 		case result := <-resultChan:
 			inflight--
 
-			ps.measurePushPeer(result.pushTime, result.err)
+			ps.measurePushPeer(result.pushTime, result.peer, result.err)
 
 			if result.err == nil {
 
@@ -491,8 +532,24 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 				}
 
 				switch err := ps.checkReceipt(result.receipt); {
-				case err == nil:
+				case err == nil && ps.receiptMode != ReceiptCrossCheck:
 					return result.receipt, nil
+				case err == nil && crossCheck == nil:
+					// push the chunk to another peer for the duplicate receipt,
+					// unless there is a parallel push already
+					crossCheck = &result
+					if inflight == 0 {
+						retry()
+					}
+					continue
+				case err == nil:
+					if err := ps.crossCheckReceipts(crossCheck.receipt, result.receipt); err != nil {
+						ps.errSkip.Add(idAddress, crossCheck.peer, skiplistDur)
+						crossCheck = nil
+						result.err = err
+						break
+					}
+					return crossCheck.receipt, nil
 				case errors.Is(err, ErrShallowReceipt):
 					ps.errSkip.Add(idAddress, result.peer, skiplistDur)
 					return result.receipt, err
@@ -509,6 +566,9 @@ func (ps *PushSync) pushToClosest(ctx context.Context, ch swarm.Chunk, origin bo
 		}
 	}
 
+	if crossCheck != nil {
+		return crossCheck.receipt, nil
+	}
 	return nil, ErrNoPush
 }
 
@@ -574,18 +634,18 @@ func (ps *PushSync) checkReceipt(receipt *pb.Receipt) error {
 
 	addr := swarm.NewAddress(receipt.Address)
 
-	publicKey, err := crypto.Recover(receipt.Signature, addr.Bytes())
+	peer, err := ps.receiptStorer(receipt)
 	if err != nil {
-		return fmt.Errorf("pushsync: receipt recover: %w", err)
-	}
-
-	peer, err := crypto.NewOverlayAddress(*publicKey, ps.networkID, receipt.Nonce)
-	if err != nil {
-		return fmt.Errorf("pushsync: receipt storer address: %w", err)
+		return err
 	}
 
 	po := swarm.Proximity(addr.Bytes(), peer.Bytes())
 
+	if ps.receiptMode == ReceiptSignature {
+		ps.logger.Debug("chunk pushed", "chunk_address", addr, "peer_address", peer, "proximity_order", po)
+		return nil
+	}
+
 	r, err := ps.radius()
 	if err != nil {
 		return fmt.Errorf("pushsync: storage radius: %w", err)
@@ -656,6 +716,7 @@ func (ps *PushSync) pushChunkToPeer(ctx context.Context, peer swarm.Address, ch
 	}
 
 	if !ch.Address().Equal(swarm.NewAddress(rec.Address)) {
+		ps.topologyDriver.ReportMisbehavior(peer, "invalid receipt", invalidReceiptWeight)
 		return nil, fmt.Errorf("invalid receipt. chunk %s, peer %s", ch.Address(), peer)
 	}
 
@@ -671,14 +732,23 @@ func (ps *PushSync) prepareCredit(ctx context.Context, peer swarm.Address, ch sw
 	return creditAction, nil
 }
 
-func (ps *PushSync) measurePushPeer(t time.Time, err error) {
+// measurePushPeer records the outcome of the push to the peer, also by the
+// proximity bin of the peer, so that the slow neighborhoods stand out.
+func (ps *PushSync) measurePushPeer(t time.Time, peer swarm.Address, err error) {
 	var status string
 	if err != nil {
 		status = "failure"
 	} else {
 		status = "success"
 	}
-	ps.metrics.PushToPeerTime.WithLabelValues(status).Observe(time.Since(t).Seconds())
+	elapsed := time.Since(t).Seconds()
+	ps.metrics.PushToPeerTime.WithLabelValues(status).Observe(elapsed)
+
+	bin := strconv.Itoa(int(swarm.Proximity(ps.address.Bytes(), peer.Bytes())))
+	ps.metrics.PushPeerBin.WithLabelValues(bin, status).Inc()
+	if err == nil {
+		ps.metrics.ReceiptBinTime.WithLabelValues(bin).Observe(elapsed)
+	}
 }
 
 func (ps *PushSync) validStampWrapper(f postage.ValidStampFn) postage.ValidStampFn {
@@ -221,13 +221,13 @@ func TestShallowReceipt(t *testing.T) {
 
 	// peer is the node responding to the chunk receipt message
 	// mock should return ErrWantSelf since there's no one to forward to
-	psPeer, _ := createPushSyncNodeWithRadius(t, closestPeer, defaultPrices, nil, nil, defaultSigner(chunk), highPO, 0, mock.WithClosestPeerErr(topology.ErrWantSelf))
+	psPeer, _ := createPushSyncNodeWithRadius(t, closestPeer, defaultPrices, nil, nil, defaultSigner(chunk), highPO, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
 
 	recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
 
 	// pivot node needs the streamer since the chunk is intercepted by
 	// the chunk worker, then gets sent by opening a new stream
-	psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, defaultSigner(chunk), highPO, 0, mock.WithClosestPeer(closestPeer))
+	psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, defaultSigner(chunk), highPO, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeer(closestPeer))
 
 	// Trigger the sending of chunk to the closest node
 	receipt, err := psPivot.PushChunkToClosest(context.Background(), chunk)
@@ -280,13 +280,13 @@ func TestShallowReceiptTolerance(t *testing.T) {
 
 	// peer is the node responding to the chunk receipt message
 	// mock should return ErrWantSelf since there's no one to forward to
-	psPeer, _ := createPushSyncNodeWithRadius(t, closestPeer, defaultPrices, nil, nil, signer, uint8(storerRadius), 0, mock.WithClosestPeerErr(topology.ErrWantSelf))
+	psPeer, _ := createPushSyncNodeWithRadius(t, closestPeer, defaultPrices, nil, nil, signer, uint8(storerRadius), 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
 
 	recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
 
 	// pivot node needs the streamer since the chunk is intercepted by
 	// the chunk worker, then gets sent by opening a new stream
-	psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, nil, uint8(pivotRadius), pivotTolerance, mock.WithClosestPeer(closestPeer))
+	psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, nil, uint8(pivotRadius), pivotTolerance, pushsync.DefaultReceiptMode, mock.WithClosestPeer(closestPeer))
 
 	// Trigger the sending of chunk to the closest node
 	receipt, err := psPivot.PushChunkToClosest(context.Background(), chunk)
@@ -307,6 +307,113 @@ func TestShallowReceiptTolerance(t *testing.T) {
 	waitOnRecordAndTest(t, closestPeer, recorder, chunk.Address(), nil)
 }
 
+// TestReceiptModes checks that the receipts are verified according to the
+// receipt mode of the origin node.
+func TestReceiptModes(t *testing.T) {
+	t.Parallel()
+
+	pivotNode := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+
+	t.Run("signature", func(t *testing.T) {
+		t.Parallel()
+
+		var highPO uint8 = 31
+		chunk := testingc.FixtureChunk("7000")
+		closestPeer := swarm.MustParseHexAddress("6000000000000000000000000000000000000000000000000000000000000000")
+
+		psPeer, _ := createPushSyncNodeWithRadius(t, closestPeer, defaultPrices, nil, nil, defaultSigner(chunk), highPO, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
+		recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
+
+		// the receipt is shallow, but only its signature is verified
+		psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, defaultSigner(chunk), highPO, 0, pushsync.ReceiptSignature, mock.WithClosestPeer(closestPeer))
+
+		receipt, err := psPivot.PushChunkToClosest(context.Background(), chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !chunk.Address().Equal(receipt.Address) {
+			t.Fatal("invalid receipt")
+		}
+	})
+
+	t.Run("cross-check", func(t *testing.T) {
+		t.Parallel()
+
+		signer1, peer1 := newReceiptSigner(t)
+		signer2, peer2 := newReceiptSigner(t)
+		chunk := testingc.GenerateTestRandomChunk()
+
+		ps1, _ := createPushSyncNodeWithRadius(t, peer1, defaultPrices, nil, nil, signer1, 0, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
+		ps2, _ := createPushSyncNodeWithRadius(t, peer2, defaultPrices, nil, nil, signer2, 0, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
+		recorder := streamtest.New(
+			streamtest.WithPeerProtocols(map[string]p2p.ProtocolSpec{
+				peer1.String(): ps1.Protocol(),
+				peer2.String(): ps2.Protocol(),
+			}),
+			streamtest.WithBaseAddr(pivotNode),
+		)
+
+		psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, nil, 0, 0, pushsync.ReceiptCrossCheck, mock.WithPeers(peer1, peer2))
+
+		receipt, err := psPivot.PushChunkToClosest(context.Background(), chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !chunk.Address().Equal(receipt.Address) {
+			t.Fatal("invalid receipt")
+		}
+
+		// the chunk is pushed to both peers for the duplicate receipt
+		waitOnRecordAndTest(t, peer1, recorder, chunk.Address(), chunk.Data())
+		waitOnRecordAndTest(t, peer2, recorder, chunk.Address(), chunk.Data())
+	})
+
+	t.Run("cross-check mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		signer1, peer1 := newReceiptSigner(t)
+		signer2, peer2 := newReceiptSigner(t)
+		chunk := testingc.GenerateValidRandomChunkAt(t, peer2, 8)
+
+		// the storers disagree on the storage radius
+		ps1, _ := createPushSyncNodeWithRadius(t, peer1, defaultPrices, nil, nil, signer1, 0, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
+		ps2, _ := createPushSyncNodeWithRadius(t, peer2, defaultPrices, nil, nil, signer2, 1, 0, pushsync.DefaultReceiptMode, mock.WithClosestPeerErr(topology.ErrWantSelf))
+		recorder := streamtest.New(
+			streamtest.WithPeerProtocols(map[string]p2p.ProtocolSpec{
+				peer1.String(): ps1.Protocol(),
+				peer2.String(): ps2.Protocol(),
+			}),
+			streamtest.WithBaseAddr(pivotNode),
+		)
+
+		psPivot, _ := createPushSyncNodeWithRadius(t, pivotNode, defaultPrices, recorder, nil, nil, 0, 0, pushsync.ReceiptCrossCheck, mock.WithPeers(peer1, peer2))
+
+		// both receipts are rejected and no peers are left
+		_, err := psPivot.PushChunkToClosest(context.Background(), chunk)
+		if !errors.Is(err, topology.ErrWantSelf) {
+			t.Fatalf("got error %v, want %v", err, topology.ErrWantSelf)
+		}
+	})
+}
+
+// newReceiptSigner returns a signer and the overlay address of the storer
+// signing the receipts with it.
+func newReceiptSigner(t *testing.T) (crypto.Signer, swarm.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(key)
+
+	addr, err := crypto.NewOverlayAddress(key.PublicKey, 1, blockHash.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, addr
+}
+
 // TestForwardToClosest checks that the chunk is forwarded to the closest peer after storing it.
 // Chunk moves from TriggerPeer -> PivotPeer (store) -> ClosestPeer
 func TestForwardToClosest(t *testing.T) {
@@ -470,6 +577,120 @@ func TestPushChunkToClosest(t *testing.T) {
 	}
 }
 
+// TestPushChunksToClosest checks that the chunks sharing the closest peer are
+// pushed in a single batch and receipted at once.
+func TestPushChunksToClosest(t *testing.T) {
+	t.Parallel()
+
+	chunks := []swarm.Chunk{
+		testingc.FixtureChunk("7000").WithTagID(1),
+		testingc.FixtureChunk("0033"),
+		testingc.FixtureChunk("0025"),
+	}
+
+	pivotNode := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+	signer, closestPeer := newReceiptSigner(t)
+
+	psPeer, peerStorer, peerAccounting := createPushSyncNode(t, closestPeer, defaultPrices, nil, nil, signer, mock.WithClosestPeerErr(topology.ErrWantSelf))
+	recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
+
+	psPivot, pivotStorer, pivotAccounting := createPushSyncNode(t, pivotNode, defaultPrices, recorder, nil, signer, mock.WithClosestPeer(closestPeer))
+
+	receipts, errs := psPivot.PushChunksToClosest(context.Background(), chunks)
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != len(chunks) {
+		t.Fatalf("got %d receipts, want %d", len(receipts), len(chunks))
+	}
+	for i, ch := range chunks {
+		if receipts[i] == nil || !ch.Address().Equal(receipts[i].Address) {
+			t.Fatalf("invalid receipt of chunk %s", ch.Address())
+		}
+		if !peerStorer.hasChunk(t, ch.Address()) {
+			t.Fatalf("chunk %s not stored", ch.Address())
+		}
+	}
+
+	// all the chunks are pushed in a single batch
+	records, err := recorder.Records(closestPeer, pushsync.ProtocolName, pushsync.ProtocolVersion, pushsync.BatchStreamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(records); l != 1 {
+		t.Fatalf("got %v batch records, want %v", l, 1)
+	}
+	if _, err := recorder.Records(closestPeer, pushsync.ProtocolName, pushsync.ProtocolVersion, pushsync.StreamName); !errors.Is(err, streamtest.ErrRecordsNotFound) {
+		t.Fatalf("got error %v, want %v", err, streamtest.ErrRecordsNotFound)
+	}
+
+	if found, count := pivotStorer.hasReported(t, chunks[0].Address()); !found || count != 1 {
+		t.Fatalf("chunk %s reported %d times, want once", chunks[0].Address(), count)
+	}
+
+	want := int64(len(chunks)) * int64(fixedPrice)
+	balance, err := pivotAccounting.Balance(closestPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != -want {
+		t.Fatalf("unexpected balance on pivot. want %d got %d", -want, balance)
+	}
+
+	balance, err = peerAccounting.Balance(pivotNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != want {
+		t.Fatalf("unexpected balance on peer. want %d got %d", want, balance)
+	}
+}
+
+// TestPushChunksToClosestShallowCredit tests that the peer is credited for
+// the chunks of the batch it receipted with the shallow receipts, as it is
+// debited for them.
+func TestPushChunksToClosestShallowCredit(t *testing.T) {
+	t.Parallel()
+
+	chunks := []swarm.Chunk{
+		testingc.FixtureChunk("7000"),
+		testingc.FixtureChunk("0033"),
+	}
+
+	pivotNode := swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+	signer, closestPeer := newReceiptSigner(t)
+
+	psPeer, _, peerAccounting := createPushSyncNode(t, closestPeer, defaultPrices, nil, nil, signer, mock.WithClosestPeerErr(topology.ErrWantSelf))
+	recorder := streamtest.New(streamtest.WithProtocols(psPeer.Protocol()), streamtest.WithBaseAddr(pivotNode))
+
+	// the receipts are shallow in the high radius of the pivot
+	pivotAccounting := accountingmock.NewAccounting()
+	psPivot := pushsync.New(pivotNode, 1, blockHash.Bytes(), streamtest.NewRecorderDisconnecter(recorder), &testStorer{
+		chunksPut:      make(map[string]swarm.Chunk),
+		chunksReported: make(map[string]int),
+	}, func() (uint8, error) { return 31, nil }, mock.NewTopologyDriver(mock.WithClosestPeer(closestPeer)), true, func(swarm.Chunk) {}, func(*soc.SOC) {}, func(ch swarm.Chunk) (swarm.Chunk, error) { return ch, nil }, log.Noop, pivotAccounting, pricermock.NewMockService(defaultPrices.price, defaultPrices.peerPrice), signer, nil, -1, 0, pushsync.DefaultReceiptMode)
+	t.Cleanup(func() { psPivot.Close() })
+
+	_, errs := psPivot.PushChunksToClosest(context.Background(), chunks)
+	for _, err := range errs {
+		if !errors.Is(err, pushsync.ErrShallowReceipt) {
+			t.Fatalf("got error %v, want %v", err, pushsync.ErrShallowReceipt)
+		}
+	}
+
+	debit, err := peerAccounting.Balance(pivotNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credit, err := pivotAccounting.Balance(closestPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if debit.Sign() <= 0 || credit.Int64() != -debit.Int64() {
+		t.Fatalf("pivot credited %d, peer debited %d", -credit.Int64(), debit)
+	}
+}
+
 func TestPushChunkToNextClosest(t *testing.T) {
 	t.Parallel()
 	t.Skip("flaky test")
@@ -1014,6 +1235,7 @@ func createPushSyncNodeWithRadius(
 	signer crypto.Signer,
 	radius uint8,
 	shallowReceiptTolerance uint8,
+	receiptMode pushsync.ReceiptMode,
 	mockOpts ...mock.Option,
 ) (*pushsync.PushSync, *testStorer) {
 	t.Helper()
@@ -1036,7 +1258,7 @@ func createPushSyncNodeWithRadius(
 
 	radiusFunc := func() (uint8, error) { return radius, nil }
 
-	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, func(*soc.SOC) {}, validStamp, log.Noop, accountingmock.NewAccounting(), mockPricer, signer, nil, -1, shallowReceiptTolerance)
+	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, func(*soc.SOC) {}, validStamp, log.Noop, accountingmock.NewAccounting(), mockPricer, signer, nil, -1, shallowReceiptTolerance, receiptMode)
 	t.Cleanup(func() { ps.Close() })
 
 	return ps, storer
@@ -1077,7 +1299,7 @@ func createPushSyncNodeWithAccounting(
 
 	radiusFunc := func() (uint8, error) { return 0, nil }
 
-	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, gsocListener, validStamp, logger, acct, mockPricer, signer, nil, -1, 0)
+	ps := pushsync.New(addr, 1, blockHash.Bytes(), recorderDisconnecter, storer, radiusFunc, mockTopology, true, unwrap, gsocListener, validStamp, logger, acct, mockPricer, signer, nil, -1, 0, pushsync.DefaultReceiptMode)
 	t.Cleanup(func() { ps.Close() })
 
 	return ps, storer
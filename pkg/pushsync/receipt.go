@@ -0,0 +1,77 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pushsync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/calmw/bee-tron/pkg/crypto"
+	"github.com/calmw/bee-tron/pkg/pushsync/pb"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// ReceiptMode decides how strictly the receipts of the chunks pushed from the
+// node are verified. The stricter modes cost more pushes and round trips,
+// the looser ones suit the private swarms of trusted nodes.
+type ReceiptMode string
+
+const (
+	// ReceiptSignature verifies only that the receipt is signed by a storer.
+	ReceiptSignature ReceiptMode = "signature"
+	// ReceiptProximity also verifies that the storer is within the storage
+	// radius of the chunk, the receipts of the too distant storers are
+	// shallow.
+	ReceiptProximity ReceiptMode = "proximity"
+	// ReceiptCrossCheck also requires a duplicate receipt of the chunk pushed
+	// to another peer, whose storer must report the same storage radius
+	// within the shallow receipt tolerance. The receipt is accepted without
+	// the duplicate if there are no other peers to push the chunk to.
+	ReceiptCrossCheck ReceiptMode = "cross-check"
+)
+
+// DefaultReceiptMode is the default receipt verification mode.
+const DefaultReceiptMode = ReceiptProximity
+
+// ErrReceiptMismatch is the error returned if the duplicate receipts of a
+// chunk do not agree.
+var ErrReceiptMismatch = errors.New("receipt mismatch")
+
+// ParseReceiptMode returns the receipt verification mode with the given name.
+func ParseReceiptMode(s string) (ReceiptMode, error) {
+	switch v := ReceiptMode(s); v {
+	case ReceiptSignature, ReceiptProximity, ReceiptCrossCheck:
+		return v, nil
+	}
+	return "", fmt.Errorf("unknown receipt mode %q", s)
+}
+
+// receiptStorer returns the overlay address of the storer which signed the
+// receipt.
+func (ps *PushSync) receiptStorer(receipt *pb.Receipt) (swarm.Address, error) {
+	publicKey, err := crypto.Recover(receipt.Signature, receipt.Address)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("pushsync: receipt recover: %w", err)
+	}
+
+	peer, err := crypto.NewOverlayAddress(*publicKey, ps.networkID, receipt.Nonce)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("pushsync: receipt storer address: %w", err)
+	}
+	return peer, nil
+}
+
+// crossCheckReceipts verifies that the valid receipts of the same chunk agree
+// on the storage radius of the neighborhood storing it.
+func (ps *PushSync) crossCheckReceipts(first, second *pb.Receipt) error {
+	ps.metrics.ReceiptCrossChecks.Inc()
+
+	r1, r2 := first.StorageRadius, second.StorageRadius
+	if max(r1, r2)-min(r1, r2) > uint32(ps.shallowReceiptTolerance) {
+		ps.metrics.ReceiptMismatches.Inc()
+		return fmt.Errorf("%w: storage radius %d and %d", ErrReceiptMismatch, r1, r2)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reachability
+
+import (
+	m "github.com/calmw/bee-tron/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	// all metrics fields must be exported
+	// to be able to return them by Metrics()
+	// using reflection
+	ProbeCount            prometheus.Counter
+	DialBackSentCount     prometheus.Counter
+	DialBackReceivedCount prometheus.Counter
+	DialBackFailedCount   prometheus.Counter
+	TransportReachable    *prometheus.GaugeVec
+}
+
+func newMetrics() metrics {
+	subsystem := "reachability"
+
+	return metrics{
+		ProbeCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "probe_count",
+			Help:      "Number of reachability probes.",
+		}),
+		DialBackSentCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "dial_back_sent_count",
+			Help:      "Number of dial back requests sent.",
+		}),
+		DialBackReceivedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "dial_back_received_count",
+			Help:      "Number of dial back requests received.",
+		}),
+		DialBackFailedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "dial_back_failed_count",
+			Help:      "Number of failed dial backs to the requesting peers.",
+		}),
+		TransportReachable: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "transport_reachable",
+				Help:      "Whether the node is reachable over the transport, 1 if it is.",
+			},
+			[]string{"transport"},
+		),
+	}
+}
+
+func (s *Service) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(s.metrics)
+}
@@ -0,0 +1,455 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package reachability verifies the inbound connectivity of the node by
+// periodically asking a few connected peers to dial back its underlay
+// addresses. Unlike autonat, the result is collected per transport and a
+// dial back is only confirmed when a fresh connection to the node succeeds.
+// The reachability of the node is reported to the p2p service, which reports
+// it in the same way as the reachability found by autonat.
+package reachability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/addressbook"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/p2p/protobuf"
+	"github.com/calmw/bee-tron/pkg/ratelimit"
+	"github.com/calmw/bee-tron/pkg/reachability/pb"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "reachability"
+
+const (
+	protocolName    = "reachability"
+	protocolVersion = "1.0.0"
+	streamName      = "dialback"
+)
+
+const (
+	DefaultWarmup   = time.Minute
+	DefaultInterval = 10 * time.Minute
+	DefaultPeers    = 3
+
+	maxUnderlays    = 8               // the number of underlays dialed back per request
+	dialBackTimeout = 5 * time.Second // the timeout of a single dial back, they are dialed one at a time
+	requestTimeout  = time.Minute
+
+	limitBurst = 2               // the number of dial back requests a peer may send at once
+	limitRate  = 5 * time.Minute // the interval at which a peer may send another request
+)
+
+var (
+	errUnknownPeer          = errors.New("unknown peer underlay")
+	errUnknownRemoteAddress = errors.New("unknown remote address")
+
+	ErrRateLimitExceeded = errors.New("rate limit exceeded")
+)
+
+type streamerDialBacker interface {
+	p2p.Streamer
+	p2p.DialBacker
+}
+
+// Options are the reachability prober options, zero values are replaced with
+// the defaults.
+type Options struct {
+	Warmup   time.Duration // the delay of the first probe
+	Interval time.Duration // the interval between the probes
+	Peers    int           // the number of peers asked to dial back per probe
+}
+
+type Service struct {
+	streamer    streamerDialBacker
+	addresses   func() ([]ma.Multiaddr, error)
+	addressBook addressbook.Getter
+	topology    topology.PeerIterator
+	updater     p2p.ReachabilityUpdater
+	limiter     *ratelimit.Limiter
+	logger      log.Logger
+	metrics     metrics
+	opts        Options
+
+	mu           sync.Mutex
+	status       map[string]p2p.ReachabilityStatus // transport -> status
+	reachability p2p.ReachabilityStatus            // the last reported reachability
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// New creates the reachability service and starts the prober. The addresses
+// function returns the underlays of the node which are verified and the
+// probed reachability is reported to the updater.
+func New(
+	streamer streamerDialBacker,
+	addresses func() ([]ma.Multiaddr, error),
+	addressBook addressbook.Getter,
+	topology topology.PeerIterator,
+	updater p2p.ReachabilityUpdater,
+	logger log.Logger,
+	o Options,
+) *Service {
+	if o.Warmup <= 0 {
+		o.Warmup = DefaultWarmup
+	}
+	if o.Interval <= 0 {
+		o.Interval = DefaultInterval
+	}
+	if o.Peers <= 0 {
+		o.Peers = DefaultPeers
+	}
+
+	s := &Service{
+		streamer:    streamer,
+		addresses:   addresses,
+		addressBook: addressBook,
+		topology:    topology,
+		updater:     updater,
+		limiter:     ratelimit.New(limitRate, limitBurst),
+		logger:      logger.WithName(loggerName).Register(),
+		metrics:     newMetrics(),
+		opts:        o,
+		status:      make(map[string]p2p.ReachabilityStatus),
+		quit:        make(chan struct{}),
+	}
+
+	if topology != nil && updater != nil && addresses != nil {
+		s.wg.Add(1)
+		go s.worker()
+	}
+
+	return s
+}
+
+func (s *Service) Protocol() p2p.ProtocolSpec {
+	return p2p.ProtocolSpec{
+		Name:    protocolName,
+		Version: protocolVersion,
+		StreamSpecs: []p2p.StreamSpec{
+			{
+				Name:    streamName,
+				Handler: s.handler,
+			},
+		},
+		DisconnectIn:  s.disconnect,
+		DisconnectOut: s.disconnect,
+	}
+}
+
+func (s *Service) disconnect(peer p2p.Peer) error {
+	s.limiter.Clear(peer.Address.ByteString())
+	return nil
+}
+
+func (s *Service) worker() {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.quit
+		cancel()
+	}()
+
+	select {
+	case <-s.quit:
+		return
+	case <-time.After(s.opts.Warmup):
+	}
+
+	for {
+		s.probe(ctx)
+
+		select {
+		case <-s.quit:
+			return
+		case <-time.After(s.opts.Interval):
+		}
+	}
+}
+
+// probe asks a few random reachable peers to dial back the node underlays and
+// updates the reachability status of every transport. The status is left as
+// it is if no peer answered.
+func (s *Service) probe(ctx context.Context) {
+	addrs, err := s.addresses()
+	if err != nil {
+		s.logger.Debug("unable to get node underlays", "error", err)
+		return
+	}
+	if len(addrs) > maxUnderlays {
+		addrs = addrs[:maxUnderlays]
+	}
+	if len(addrs) == 0 {
+		return
+	}
+
+	var peers []swarm.Address
+	_ = s.topology.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		peers = append(peers, addr)
+		return false, false, nil
+	}, topology.Select{Reachable: true})
+
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+
+	var (
+		answered  int
+		confirmed = make(map[string]struct{})
+	)
+	for _, peer := range peers {
+		if answered == s.opts.Peers {
+			break
+		}
+		reachable, err := s.DialBack(ctx, peer, addrs)
+		if err != nil {
+			s.logger.Debug("dial back request failed", "peer_address", peer, "error", err)
+			continue
+		}
+		answered++
+		for _, addr := range reachable {
+			confirmed[addr.String()] = struct{}{}
+		}
+	}
+	s.metrics.ProbeCount.Inc()
+
+	if answered == 0 {
+		s.logger.Debug("no peer answered the dial back request")
+		return
+	}
+
+	status := make(map[string]p2p.ReachabilityStatus)
+	reachability := p2p.ReachabilityStatusPrivate
+	for _, addr := range addrs {
+		t := transport(addr)
+		if _, ok := confirmed[addr.String()]; ok {
+			status[t] = p2p.ReachabilityStatusPublic
+			reachability = p2p.ReachabilityStatusPublic
+		} else if status[t] != p2p.ReachabilityStatusPublic {
+			status[t] = p2p.ReachabilityStatusPrivate
+		}
+	}
+
+	for t, st := range status {
+		var v float64
+		if st == p2p.ReachabilityStatusPublic {
+			v = 1
+		}
+		s.metrics.TransportReachable.WithLabelValues(t).Set(v)
+	}
+
+	s.mu.Lock()
+	s.status = status
+	changed := s.reachability != reachability
+	s.reachability = reachability
+	s.mu.Unlock()
+
+	s.logger.Debug("reachability probed", "reachability", reachability, "transports", status, "peers", answered)
+	if changed {
+		s.updater.UpdateReachability(reachability)
+	}
+}
+
+// DialBack asks the peer to dial the given underlays and returns the ones the
+// peer was able to connect to.
+func (s *Service) DialBack(ctx context.Context, peer swarm.Address, addrs []ma.Multiaddr) ([]ma.Multiaddr, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
+	if err != nil {
+		return nil, fmt.Errorf("new stream: %w", err)
+	}
+	defer func() {
+		go stream.FullClose()
+	}()
+
+	req := pb.DialBack{Underlays: make([][]byte, 0, len(addrs))}
+	for _, addr := range addrs {
+		req.Underlays = append(req.Underlays, addr.Bytes())
+	}
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	if err := w.WriteMsgWithContext(ctx, &req); err != nil {
+		return nil, fmt.Errorf("write message: %w", err)
+	}
+	s.metrics.DialBackSentCount.Inc()
+
+	var resp pb.DialBackResult
+	if err := r.ReadMsgWithContext(ctx, &resp); err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	var reachable []ma.Multiaddr
+	for _, b := range resp.Reachable {
+		addr, err := ma.NewMultiaddrBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid underlay: %w", err)
+		}
+		// only the requested underlays may be confirmed
+		for _, a := range addrs {
+			if a.Equal(addr) {
+				reachable = append(reachable, addr)
+				break
+			}
+		}
+	}
+	return reachable, nil
+}
+
+func (s *Service) handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	w, r := protobuf.NewWriterAndReader(stream)
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var req pb.DialBack
+	if err := r.ReadMsgWithContext(ctx, &req); err != nil {
+		return fmt.Errorf("read message: %w", err)
+	}
+	s.metrics.DialBackReceivedCount.Inc()
+
+	if !s.limiter.Allow(p.Address.ByteString(), 1) {
+		s.logger.Debug("dial back request rejected", "peer_address", p.Address, "error", ErrRateLimitExceeded)
+		return ErrRateLimitExceeded
+	}
+
+	// only the underlays with the peer id of the requesting peer and the ip
+	// address it is connected from are dialed, so that the protocol can not
+	// be used to make the node dial arbitrary hosts
+	peerID, err := s.peerID(p.Address)
+	if err != nil {
+		return fmt.Errorf("peer %s: %w", p.Address, err)
+	}
+	peerIP, err := remoteIP(stream)
+	if err != nil {
+		return fmt.Errorf("peer %s: %w", p.Address, err)
+	}
+
+	underlays := req.Underlays
+	if len(underlays) > maxUnderlays {
+		underlays = underlays[:maxUnderlays]
+	}
+
+	// the underlays are dialed one at a time, each by its own dialer, so
+	// that a dial back is confirmed only by a connection to that underlay
+	var reachable [][]byte
+	for _, b := range underlays {
+		addr, err := ma.NewMultiaddrBytes(b)
+		if err != nil {
+			continue
+		}
+		if id, err := addr.ValueForProtocol(ma.P_P2P); err != nil || id != peerID {
+			continue
+		}
+		if ip, err := manet.ToIP(addr); err != nil || !ip.Equal(peerIP) {
+			continue
+		}
+
+		if s.dialBack(ctx, addr) {
+			reachable = append(reachable, b)
+		}
+	}
+
+	if err := w.WriteMsgWithContext(ctx, &pb.DialBackResult{Reachable: reachable}); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	return nil
+}
+
+// dialBack reports whether the underlay could be dialed.
+func (s *Service) dialBack(ctx context.Context, addr ma.Multiaddr) bool {
+	ctx, cancel := context.WithTimeout(ctx, dialBackTimeout)
+	defer cancel()
+
+	if _, err := s.streamer.DialBack(ctx, addr); err != nil {
+		s.metrics.DialBackFailedCount.Inc()
+		s.logger.Debug("dial back failed", "underlay", addr, "error", err)
+		return false
+	}
+	return true
+}
+
+// remoteIP returns the ip address the peer of the stream is connected from.
+func remoteIP(stream p2p.Stream) (net.IP, error) {
+	ra, ok := stream.(p2p.RemoteAddresser)
+	if !ok || ra.RemoteMultiaddr() == nil {
+		return nil, errUnknownRemoteAddress
+	}
+	ip, err := manet.ToIP(ra.RemoteMultiaddr())
+	if err != nil {
+		return nil, fmt.Errorf("remote address %s: %w", ra.RemoteMultiaddr(), err)
+	}
+	return ip, nil
+}
+
+// peerID returns the libp2p peer id of the peer from its known underlay.
+func (s *Service) peerID(overlay swarm.Address) (string, error) {
+	addr, err := s.addressBook.Get(overlay)
+	if err != nil {
+		if errors.Is(err, addressbook.ErrNotFound) {
+			return "", errUnknownPeer
+		}
+		return "", err
+	}
+	id, err := addr.Underlay.ValueForProtocol(ma.P_P2P)
+	if err != nil {
+		return "", errUnknownPeer
+	}
+	return id, nil
+}
+
+// Status returns the reachability status of every transport of the node
+// from the last probe.
+func (s *Service) Status() map[string]p2p.ReachabilityStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := make(map[string]p2p.ReachabilityStatus, len(s.status))
+	for t, st := range s.status {
+		status[t] = st
+	}
+	return status
+}
+
+func (s *Service) Close() error {
+	close(s.quit)
+	s.wg.Wait()
+	return nil
+}
+
+// transport returns the name of the network and transport protocols of the
+// underlay, for example ip4/tcp or ip6/tcp/ws.
+func transport(addr ma.Multiaddr) string {
+	var names []string
+	for _, p := range addr.Protocols() {
+		switch p.Code {
+		case ma.P_IP4, ma.P_IP6, ma.P_DNS, ma.P_DNS4, ma.P_DNS6,
+			ma.P_TCP, ma.P_UDP, ma.P_WS, ma.P_WSS, ma.P_QUIC, ma.P_QUIC_V1:
+			names = append(names, p.Name)
+		}
+	}
+	return strings.Join(names, "/")
+}
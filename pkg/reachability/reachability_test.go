@@ -0,0 +1,202 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reachability_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/addressbook"
+	"github.com/calmw/bee-tron/pkg/bzz"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/p2p/streamtest"
+	"github.com/calmw/bee-tron/pkg/reachability"
+	"github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	topologymock "github.com/calmw/bee-tron/pkg/topology/mock"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func mustMultiaddr(t *testing.T, s string) ma.Multiaddr {
+	t.Helper()
+
+	a, err := ma.NewMultiaddr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestProbe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tcp     = mustMultiaddr(t, "/ip4/1.2.3.4/tcp/1634/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+		ws      = mustMultiaddr(t, "/ip4/1.2.3.4/tcp/1635/ws/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+		foreign = mustMultiaddr(t, "/ip4/5.6.7.8/tcp/1634/p2p/16Uiu2HAkx8ULY8cTXhdVAcMmLcH9AsTKz6uBQ7DPLKRjMLgBVYkS")
+		// the peer id of the client at an ip address it is not connected from
+		spoofed = mustMultiaddr(t, "/ip4/5.6.7.8/tcp/1634/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+		remote  = mustMultiaddr(t, "/ip4/1.2.3.4/tcp/50123")
+	)
+
+	for _, tc := range []struct {
+		name       string
+		reachable  []ma.Multiaddr
+		wantStatus map[string]p2p.ReachabilityStatus
+		want       p2p.ReachabilityStatus
+	}{
+		{
+			name:      "public over tcp",
+			reachable: []ma.Multiaddr{tcp, foreign, spoofed},
+			wantStatus: map[string]p2p.ReachabilityStatus{
+				"ip4/tcp":    p2p.ReachabilityStatusPublic,
+				"ip4/tcp/ws": p2p.ReachabilityStatusPrivate,
+			},
+			want: p2p.ReachabilityStatusPublic,
+		},
+		{
+			name: "private",
+			wantStatus: map[string]p2p.ReachabilityStatus{
+				"ip4/tcp":    p2p.ReachabilityStatusPrivate,
+				"ip4/tcp/ws": p2p.ReachabilityStatusPrivate,
+			},
+			want: p2p.ReachabilityStatusPrivate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				clientAddr = swarm.RandAddress(t)
+				serverAddr = swarm.RandAddress(t)
+
+				mu       sync.Mutex
+				dialed   []ma.Multiaddr
+				inflight atomic.Int32
+			)
+
+			// the server knows the client by its tcp underlay
+			ab := addressbook.New(mock.NewStateStore())
+			if err := ab.Put(clientAddr, bzz.Address{Overlay: clientAddr, Underlay: tcp}); err != nil {
+				t.Fatal(err)
+			}
+
+			serverStreamer := streamtest.New(streamtest.WithPingErr(func(addr ma.Multiaddr) (time.Duration, error) {
+				if inflight.Add(1) > 1 {
+					t.Error("concurrent dial backs")
+				}
+				defer inflight.Add(-1)
+
+				mu.Lock()
+				dialed = append(dialed, addr)
+				mu.Unlock()
+				for _, a := range tc.reachable {
+					if a.Equal(addr) {
+						return time.Millisecond, nil
+					}
+				}
+				return 0, errors.New("dial failed")
+			}))
+			server := reachability.New(serverStreamer, nil, ab, nil, nil, log.Noop, reachability.Options{})
+			testutil.CleanupCloser(t, server)
+
+			recorder := streamtest.New(
+				streamtest.WithProtocols(server.Protocol()),
+				streamtest.WithBaseAddr(clientAddr),
+				streamtest.WithRemoteAddr(remote),
+			)
+			topology := topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddr))
+			addresses := func() ([]ma.Multiaddr, error) { return []ma.Multiaddr{tcp, ws, foreign, spoofed}, nil }
+
+			client := reachability.New(recorder, addresses, nil, topology, topology, log.Noop, reachability.Options{Warmup: time.Hour})
+			testutil.CleanupCloser(t, client)
+
+			client.Probe(context.Background())
+
+			if have, want := topology.Reachability(), tc.want; have != want {
+				t.Fatalf("reachability: have %v, want %v", have, want)
+			}
+
+			status := client.Status()
+			if len(status) != len(tc.wantStatus) {
+				t.Fatalf("status: have %v, want %v", status, tc.wantStatus)
+			}
+			for transport, want := range tc.wantStatus {
+				if have := status[transport]; have != want {
+					t.Fatalf("transport %s: have %v, want %v", transport, have, want)
+				}
+			}
+
+			// the underlays of other nodes and other ip addresses must never
+			// be dialed
+			mu.Lock()
+			defer mu.Unlock()
+			for _, addr := range dialed {
+				if addr.Equal(foreign) || addr.Equal(spoofed) {
+					t.Fatalf("underlay %s dialed", addr)
+				}
+			}
+			if len(dialed) != 2 {
+				t.Fatalf("dialed %d underlays, want 2", len(dialed))
+			}
+		})
+	}
+}
+
+func TestDialBackRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tcp        = mustMultiaddr(t, "/ip4/1.2.3.4/tcp/1634/p2p/16Uiu2HAmTBuJT9LvNmBiQiNoTsxE5mtNy6YG3paw79m94CRa9sRb")
+		remote     = mustMultiaddr(t, "/ip4/1.2.3.4/tcp/50123")
+		clientAddr = swarm.RandAddress(t)
+		serverAddr = swarm.RandAddress(t)
+	)
+
+	ab := addressbook.New(mock.NewStateStore())
+	if err := ab.Put(clientAddr, bzz.Address{Overlay: clientAddr, Underlay: tcp}); err != nil {
+		t.Fatal(err)
+	}
+
+	var dialed atomic.Int32
+	serverStreamer := streamtest.New(streamtest.WithPingErr(func(ma.Multiaddr) (time.Duration, error) {
+		dialed.Add(1)
+		return time.Millisecond, nil
+	}))
+	server := reachability.New(serverStreamer, nil, ab, nil, nil, log.Noop, reachability.Options{})
+	testutil.CleanupCloser(t, server)
+
+	recorder := streamtest.New(
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithBaseAddr(clientAddr),
+		streamtest.WithRemoteAddr(remote),
+	)
+	client := reachability.New(recorder, nil, nil, nil, nil, log.Noop, reachability.Options{})
+	testutil.CleanupCloser(t, client)
+
+	for i := 0; i < 2; i++ {
+		reachable, err := client.DialBack(context.Background(), serverAddr, []ma.Multiaddr{tcp})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reachable) != 1 {
+			t.Fatalf("got %d reachable underlays, want 1", len(reachable))
+		}
+	}
+
+	// the requests over the burst are rejected without dialing back
+	if _, err := client.DialBack(context.Background(), serverAddr, []ma.Multiaddr{tcp}); err == nil {
+		t.Fatal("expected the request to be rejected")
+	}
+	if n := dialed.Load(); n != 2 {
+		t.Fatalf("dialed back %d times, want 2", n)
+	}
+}
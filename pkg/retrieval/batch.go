@@ -0,0 +1,323 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/accounting"
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/p2p/protobuf"
+	pb "github.com/calmw/bee-tron/pkg/retrieval/pb"
+	"github.com/calmw/bee-tron/pkg/soc"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const batchStreamName = "retrieval-batch"
+
+// MaxBatchSize is the maximum number of chunks requested from a peer in a
+// single round trip.
+const MaxBatchSize = 64
+
+var _ BatchRetriever = (*Service)(nil)
+
+// BatchRetriever retrieves multiple chunks in a single round trip to each of
+// the peers.
+type BatchRetriever interface {
+	// RetrieveChunks retrieves the chunks from the network. The chunks which
+	// share the closest peer are requested in a single round trip, the ones
+	// the peer fails to deliver are retrieved one by one. The function is
+	// called with the index of every address and its chunk or error as soon
+	// as the chunk is delivered or fails, once for every address and possibly
+	// concurrently. RetrieveChunks returns after the last call with the errors
+	// of the chunks which could not be retrieved joined.
+	RetrieveChunks(ctx context.Context, addrs []swarm.Address, fn func(int, swarm.Chunk, error)) error
+}
+
+// RetrieveChunks implements the BatchRetriever interface.
+func (s *Service) RetrieveChunks(ctx context.Context, addrs []swarm.Address, fn func(int, swarm.Chunk, error)) error {
+	delivered := make([]bool, len(addrs))
+	errs := make([]error, len(addrs))
+
+	// group the chunks by the closest peer
+	var (
+		peers   []swarm.Address
+		batches = make(map[string][]int)
+	)
+	for i, addr := range addrs {
+		if addr.IsZero() || addr.IsEmpty() || !addr.IsValidLength() {
+			errs[i] = fmt.Errorf("invalid address queried")
+			fn(i, nil, errs[i])
+			continue
+		}
+		if s.notFound.has(addr) {
+			s.metrics.NotFoundCacheHits.Inc()
+			errs[i] = storage.ErrNotFound
+			fn(i, nil, errs[i])
+			continue
+		}
+		peer, err := s.closestPeer(addr, s.errSkip.ChunkPeers(addr), true)
+		if err != nil {
+			// left to be retrieved on its own
+			continue
+		}
+		if _, ok := batches[peer.ByteString()]; !ok {
+			peers = append(peers, peer)
+		}
+		batches[peer.ByteString()] = append(batches[peer.ByteString()], i)
+	}
+
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		batch := batches[peer.ByteString()]
+		for len(batch) > 0 {
+			n := min(len(batch), MaxBatchSize)
+			wg.Add(1)
+			go func(idx []int) {
+				defer wg.Done()
+				s.retrieveBatch(ctx, peer, addrs, idx, func(i int, ch swarm.Chunk) {
+					delivered[i] = true
+					fn(i, ch, nil)
+				})
+			}(batch[:n])
+			batch = batch[n:]
+		}
+	}
+	wg.Wait()
+
+	// the chunks which were not delivered in the batches are retrieved one by one
+	for i, addr := range addrs {
+		if delivered[i] || errs[i] != nil {
+			continue
+		}
+		s.metrics.BatchFallbackCounter.Inc()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var ch swarm.Chunk
+			ch, errs[i] = s.RetrieveChunk(ctx, addr, swarm.ZeroAddress)
+			fn(i, ch, errs[i])
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// retrieveBatch requests the chunks of the addresses at the given indexes
+// from the peer and calls deliver with the index of every valid chunk as soon
+// as it is delivered.
+func (s *Service) retrieveBatch(ctx context.Context, peer swarm.Address, addrs []swarm.Address, idx []int, deliver func(int, swarm.Chunk)) {
+	s.metrics.BatchRequestCounter.Inc()
+
+	var (
+		req     = &pb.BatchRequest{Addrs: make([][]byte, 0, len(idx))}
+		pos     = make([]int, 0, len(idx))
+		actions = make([]accounting.Action, 0, len(idx))
+	)
+	defer func() {
+		for _, action := range actions {
+			action.Cleanup()
+		}
+	}()
+	for _, i := range idx {
+		action, err := s.prepareCredit(ctx, peer, addrs[i], true)
+		if err != nil {
+			continue
+		}
+		req.Addrs = append(req.Addrs, addrs[i].Bytes())
+		pos = append(pos, i)
+		actions = append(actions, action)
+	}
+	if len(req.Addrs) == 0 {
+		return
+	}
+
+	var err error
+	defer func() {
+		if err != nil {
+			s.logger.Debug("batch retrieval failed", "peer_address", peer, "error", err)
+			s.metrics.TotalErrors.Inc()
+		}
+	}()
+
+	// the request is sent within the attempt timeout and every delivery is
+	// awaited for the attempt timeout, as the chunks of the batch are
+	// delivered one after the other
+	actx, cancel := context.WithTimeout(ctx, s.opts.AttemptTimeout)
+	defer cancel()
+
+	stream, err := s.streamer.NewStream(actx, peer, nil, protocolName, protocolVersion, batchStreamName)
+	if err != nil {
+		err = fmt.Errorf("new stream: %w", err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	if err = w.WriteMsgWithContext(actx, req); err != nil {
+		err = fmt.Errorf("write batch request: %w peer %s", err, peer.String())
+		return
+	}
+
+	delivered := make([]bool, len(pos))
+	for range req.Addrs {
+		var d pb.BatchDelivery
+		if err = s.readDelivery(ctx, r, &d); err != nil {
+			err = fmt.Errorf("read batch delivery: %w peer %s", err, peer.String())
+			return
+		}
+		if int(d.Index) >= len(pos) {
+			err = fmt.Errorf("invalid batch delivery index %d peer %s", d.Index, peer.String())
+			return
+		}
+		i := pos[d.Index]
+		if d.Err != "" || delivered[d.Index] {
+			continue
+		}
+
+		chunk := swarm.NewChunk(addrs[i], d.Data)
+		if !cac.Valid(chunk) && !soc.Valid(chunk) {
+			s.metrics.InvalidChunkRetrieved.Inc()
+			continue
+		}
+		if err := actions[d.Index].Apply(); err != nil {
+			continue
+		}
+
+		s.metrics.TotalRetrieved.Inc()
+		delivered[d.Index] = true
+		deliver(i, chunk)
+	}
+}
+
+// readDelivery reads the next delivery of the batch within the attempt timeout.
+func (s *Service) readDelivery(ctx context.Context, r protobuf.Reader, d *pb.BatchDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.AttemptTimeout)
+	defer cancel()
+	return r.ReadMsgWithContext(ctx, d)
+}
+
+// batchResult is the delivery of a single chunk of the batch with the debit
+// to apply once it is written and the forwarded chunk to cache.
+type batchResult struct {
+	delivery *pb.BatchDelivery
+	debit    accounting.Action
+	cache    swarm.Chunk
+}
+
+func (s *Service) batchHandler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
+	ctx, cancel := context.WithTimeout(p2pctx, RetrieveChunkTimeout)
+	defer cancel()
+
+	w, r := protobuf.NewWriterAndReader(stream)
+	defer func() {
+		if err != nil {
+			_ = stream.Reset()
+		} else {
+			_ = stream.FullClose()
+		}
+	}()
+
+	var req pb.BatchRequest
+	if err := r.ReadMsgWithContext(ctx, &req); err != nil {
+		return fmt.Errorf("read batch request: %w peer %s", err, p.Address.String())
+	}
+	if len(req.Addrs) == 0 || len(req.Addrs) > MaxBatchSize {
+		return fmt.Errorf("invalid batch size %d queried by peer %s", len(req.Addrs), p.Address.String())
+	}
+
+	// the chunks are delivered in the order they become available
+	results := make(chan batchResult, len(req.Addrs))
+	for i, a := range req.Addrs {
+		go func() {
+			results <- s.batchDelivery(ctx, p.Address, uint32(i), swarm.NewAddress(a))
+		}()
+	}
+
+	for n := range req.Addrs {
+		res := <-results
+		err := w.WriteMsgWithContext(ctx, res.delivery)
+		if res.debit != nil {
+			if err == nil {
+				if err := res.debit.Apply(); err != nil {
+					s.logger.Debug("apply batch debit", "peer_address", p.Address, "error", err)
+				}
+			}
+			res.debit.Cleanup()
+		}
+		if err != nil {
+			// release the debits of the deliveries which will not be written
+			go func(pending int) {
+				for range pending {
+					if res := <-results; res.debit != nil {
+						res.debit.Cleanup()
+					}
+				}
+			}(len(req.Addrs) - n - 1)
+			return fmt.Errorf("write batch delivery: %w peer %s", err, p.Address.String())
+		}
+
+		// cache the chunk after the delivery, so that putting to the localstore does not slow down the request flow
+		if res.cache != nil {
+			if err := s.storer.Cache().Put(p2pctx, res.cache); err != nil {
+				s.logger.Debug("retrieve cache put", "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// batchDelivery returns the delivery of the chunk, retrieving it from the
+// network if it is not stored locally.
+func (s *Service) batchDelivery(ctx context.Context, peer swarm.Address, index uint32, addr swarm.Address) batchResult {
+	errDelivery := func(err error) batchResult {
+		return batchResult{delivery: &pb.BatchDelivery{Index: index, Err: err.Error()}}
+	}
+
+	if addr.IsZero() || addr.IsEmpty() || !addr.IsValidLength() {
+		return errDelivery(fmt.Errorf("invalid address queried by peer %s", peer.String()))
+	}
+
+	var forwarded bool
+	chunk, err := s.storer.Lookup().Get(ctx, addr)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return errDelivery(fmt.Errorf("get from store: %w", err))
+		}
+		// forward the request
+		chunk, err = s.RetrieveChunk(ctx, addr, peer)
+		if err != nil {
+			return errDelivery(fmt.Errorf("retrieve chunk: %w", err))
+		}
+		forwarded = true
+	}
+
+	debit, err := s.accounting.PrepareDebit(ctx, peer, s.pricer.Price(addr))
+	if err != nil {
+		return errDelivery(fmt.Errorf("prepare debit to peer %s before writeback: %w", peer.String(), err))
+	}
+
+	res := batchResult{
+		delivery: &pb.BatchDelivery{Index: index, Data: chunk.Data()},
+		debit:    debit,
+	}
+	if s.opts.Caching && forwarded {
+		res.cache = chunk
+	}
+	return res
+}
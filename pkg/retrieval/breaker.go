@@ -0,0 +1,82 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/skippeers"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// Default circuit breaker option values.
+const (
+	DefaultBreakerFailureRate = 0.8
+	DefaultBreakerMinAttempts = 10
+	DefaultBreakerWindow      = 5 * time.Minute
+	DefaultBreakerCooldown    = time.Minute
+)
+
+// peerBreaker opens the circuit of the peers which failed too many of their
+// recent attempts. The peers with the open circuit are added to the skiplist
+// for all the chunks until the cooldown expires, after which their attempts
+// are counted anew.
+type peerBreaker struct {
+	failureRate float64
+	minAttempts int
+	window      time.Duration
+	cooldown    time.Duration
+	skip        *skippeers.List
+
+	mu    sync.Mutex
+	peers map[string]*peerAttempts
+}
+
+// peerAttempts counts the attempts of the peer since the window start.
+type peerAttempts struct {
+	start    time.Time
+	attempts int
+	failures int
+}
+
+func newPeerBreaker(o Options, skip *skippeers.List) *peerBreaker {
+	return &peerBreaker{
+		failureRate: o.BreakerFailureRate,
+		minAttempts: o.BreakerMinAttempts,
+		window:      o.BreakerWindow,
+		cooldown:    o.BreakerCooldown,
+		skip:        skip,
+		peers:       make(map[string]*peerAttempts),
+	}
+}
+
+// record counts the attempt of the peer and reports whether it opened the
+// circuit of the peer.
+func (b *peerBreaker) record(peer swarm.Address, failed bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	a, ok := b.peers[peer.ByteString()]
+	if !ok || now.Sub(a.start) > b.window {
+		a = &peerAttempts{start: now}
+		b.peers[peer.ByteString()] = a
+	}
+
+	a.attempts++
+	if failed {
+		a.failures++
+	}
+
+	if a.attempts < b.minAttempts || float64(a.failures)/float64(a.attempts) < b.failureRate {
+		return false
+	}
+
+	delete(b.peers, peer.ByteString())
+	b.skip.AddPeer(peer, b.cooldown)
+	return true
+}
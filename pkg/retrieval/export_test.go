@@ -6,6 +6,7 @@ package retrieval
 
 import (
 	"context"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/p2p"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -18,3 +19,7 @@ func (s *Service) Handler(ctx context.Context, p p2p.Peer, stream p2p.Stream) er
 func (s *Service) ClosestPeer(addr swarm.Address, skipPeers []swarm.Address, allowUpstream bool) (swarm.Address, error) {
 	return s.closestPeer(addr, skipPeers, allowUpstream)
 }
+
+func (s *Service) SkipPeer(chunk, peer swarm.Address, expire time.Duration) {
+	s.errSkip.Add(chunk, peer, expire)
+}
@@ -20,7 +20,14 @@ type metrics struct {
 	RequestFailureCounter prometheus.Counter
 	RequestDurationTime   prometheus.Histogram
 	RequestAttempts       prometheus.Histogram
+	SuccessfulAttempt     prometheus.Histogram
 	PeerRequestCounter    prometheus.Counter
+	HedgedRequestCounter  prometheus.Counter
+	BreakerOpenCounter    prometheus.Counter
+	NotFoundCacheHits     prometheus.Counter
+	BatchRequestCounter   prometheus.Counter
+	BatchFallbackCounter  prometheus.Counter
+	CostSelectedCounter   prometheus.Counter
 	TotalRetrieved        prometheus.Counter
 	InvalidChunkRetrieved prometheus.Counter
 	ChunkPrice            prometheus.Summary
@@ -62,12 +69,55 @@ func newMetrics() metrics {
 			Name:      "request_attempts",
 			Help:      "Histogram for total retrieval attempts pre each request.",
 		}),
+		SuccessfulAttempt: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "successful_attempt",
+			Help:      "Histogram for the number of the attempt which retrieved the chunk.",
+			Buckets:   []float64{1, 2, 3, 4, 6, 8, 12, 16, 24, 32},
+		}),
 		PeerRequestCounter: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
 			Name:      "peer_request_count",
 			Help:      "Number of request to single peer.",
 		}),
+		HedgedRequestCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "hedged_request_count",
+			Help:      "Number of requests hedged by a request to the next closest peer.",
+		}),
+		BreakerOpenCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "breaker_open_count",
+			Help:      "Number of times the circuit of a peer was opened due to its failure rate.",
+		}),
+		NotFoundCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "not_found_cache_hits",
+			Help:      "Number of requests for chunks recently not found in the network.",
+		}),
+		BatchRequestCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_request_count",
+			Help:      "Number of batch requests to single peer.",
+		}),
+		BatchFallbackCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "batch_fallback_count",
+			Help:      "Number of chunks not delivered in a batch and retrieved on their own.",
+		}),
+		CostSelectedCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "cost_selected_count",
+			Help:      "Number of requests sent to a peer other than the closest one for its lower cost.",
+		}),
 		TotalRetrieved: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
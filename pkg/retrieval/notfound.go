@@ -0,0 +1,67 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// DefaultNotFoundTTL is how long the chunks which were not found in the
+// network are not retrieved again.
+const DefaultNotFoundTTL = 10 * time.Second
+
+// notFoundCacheSize is the number of the missing chunks remembered.
+const notFoundCacheSize = 10_000
+
+// notFoundCache records the addresses of the chunks which were recently not
+// found in the network, so that the repeated requests for the same missing
+// content fail fast. A nil cache records nothing.
+type notFoundCache struct {
+	expirations *lru.Cache[string, time.Time] // expirations by chunk address
+	ttl         time.Duration
+	now         func() time.Time
+}
+
+// newNotFoundCache returns the cache of the missing chunks, or nil if the ttl
+// is not positive.
+func newNotFoundCache(ttl time.Duration) *notFoundCache {
+	if ttl <= 0 {
+		return nil
+	}
+	// the error is returned only for a non-positive size
+	expirations, _ := lru.New[string, time.Time](notFoundCacheSize)
+	return &notFoundCache{
+		expirations: expirations,
+		ttl:         ttl,
+		now:         time.Now,
+	}
+}
+
+// add records that the chunk was not found.
+func (c *notFoundCache) add(addr swarm.Address) {
+	if c == nil {
+		return
+	}
+	c.expirations.Add(addr.ByteString(), c.now().Add(c.ttl))
+}
+
+// has reports whether the chunk was recently not found.
+func (c *notFoundCache) has(addr swarm.Address) bool {
+	if c == nil {
+		return false
+	}
+	expires, ok := c.expirations.Get(addr.ByteString())
+	if !ok {
+		return false
+	}
+	if !c.now().Before(expires) {
+		c.expirations.Remove(addr.ByteString())
+		return false
+	}
+	return true
+}
@@ -54,9 +54,10 @@ type Interface interface {
 }
 
 type retrievalResult struct {
-	chunk swarm.Chunk
-	peer  swarm.Address
-	err   error
+	chunk   swarm.Chunk
+	peer    swarm.Address
+	attempt int
+	err     error
 }
 
 type Storer interface {
@@ -64,6 +65,36 @@ type Storer interface {
 	Lookup() storage.Getter
 }
 
+// Default retrieval attempt option values.
+const (
+	DefaultMaxAttempts    = 32
+	DefaultAttemptTimeout = RetrieveChunkTimeout
+	DefaultRetryInterval  = time.Second
+)
+
+// Options are the retrieval service options, zero values of the attempt
+// options are replaced with the defaults.
+type Options struct {
+	Caching        bool            // cache the chunks forwarded to the other peers
+	HedgeDelay     time.Duration   // the delay after which a request is hedged, zero disables hedging
+	MaxAttempts    int             // the number of requests the retrievals originating at the node may send
+	AttemptTimeout time.Duration   // the timeout of a single attempt
+	RetryInterval  time.Duration   // the interval of the preemptive attempts of the retrievals originating at the node
+	Backoff        []time.Duration // the delays before retrying after the consecutive failed attempts, the last one is repeated, none retries immediately
+
+	BreakerFailureRate float64       // the rate of the failed attempts at which the circuit of the peer is opened
+	BreakerMinAttempts int           // the number of the attempts of the peer before its failure rate is considered
+	BreakerWindow      time.Duration // the period the attempts of the peer are counted for
+	BreakerCooldown    time.Duration // the period the peer with the open circuit is skipped for
+
+	NotFoundTTL time.Duration // the period the chunks not found in the network are not retrieved again, zero disables
+
+	SelectionCandidates int      // the number of the closest peers the cheapest one is requested from, one selects the closest peer
+	Cost                CostFunc // the cost of the selection candidates
+
+	StateStore storage.StateStorer // persists the skiplist across the restarts, nil disables
+}
+
 type Service struct {
 	addr          swarm.Address
 	radiusFunc    func() (uint8, error)
@@ -76,8 +107,11 @@ type Service struct {
 	metrics       metrics
 	pricer        pricer.Interface
 	tracer        *tracing.Tracer
-	caching       bool
+	opts          Options
 	errSkip       *skippeers.List
+	breaker       *peerBreaker
+	notFound      *notFoundCache
+	latency       topology.PeerLatencyer
 }
 
 func New(
@@ -90,9 +124,40 @@ func New(
 	accounting accounting.Interface,
 	pricer pricer.Interface,
 	tracer *tracing.Tracer,
-	forwarderCaching bool,
+	o Options,
 ) *Service {
-	return &Service{
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.AttemptTimeout <= 0 {
+		o.AttemptTimeout = DefaultAttemptTimeout
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = DefaultRetryInterval
+	}
+	if o.BreakerFailureRate <= 0 {
+		o.BreakerFailureRate = DefaultBreakerFailureRate
+	}
+	if o.BreakerMinAttempts <= 0 {
+		o.BreakerMinAttempts = DefaultBreakerMinAttempts
+	}
+	if o.BreakerWindow <= 0 {
+		o.BreakerWindow = DefaultBreakerWindow
+	}
+	if o.BreakerCooldown <= 0 {
+		o.BreakerCooldown = DefaultBreakerCooldown
+	}
+	if o.SelectionCandidates <= 0 {
+		o.SelectionCandidates = DefaultSelectionCandidates
+	}
+	if o.Cost == nil {
+		o.Cost = LinearCost(DefaultSelectionPriceWeight, DefaultSelectionLatencyWeight)
+	}
+
+	errSkip := skippeers.NewList(time.Minute)
+	latency, _ := chunkPeerer.(topology.PeerLatencyer)
+
+	s := &Service{
 		addr:          addr,
 		radiusFunc:    radiusFunc,
 		streamer:      streamer,
@@ -103,9 +168,16 @@ func New(
 		pricer:        pricer,
 		metrics:       newMetrics(),
 		tracer:        tracer,
-		caching:       forwarderCaching,
-		errSkip:       skippeers.NewList(time.Minute),
+		opts:          o,
+		errSkip:       errSkip,
+		breaker:       newPeerBreaker(o, errSkip),
+		notFound:      newNotFoundCache(o.NotFoundTTL),
+		latency:       latency,
+	}
+	if err := s.loadSkiplist(); err != nil {
+		s.logger.Warning("unable to restore the retrieval skiplist", "error", err)
 	}
+	return s
 }
 
 func (s *Service) Protocol() p2p.ProtocolSpec {
@@ -117,20 +189,26 @@ func (s *Service) Protocol() p2p.ProtocolSpec {
 				Name:    streamName,
 				Handler: s.handler,
 			},
+			{
+				Name:    batchStreamName,
+				Handler: s.batchHandler,
+			},
 		},
 	}
 }
 
 const (
 	RetrieveChunkTimeout = time.Second * 30
-	preemptiveInterval   = time.Second
 	overDraftRefresh     = time.Millisecond * 600
 	skiplistDur          = time.Minute
 	originSuffix         = "_origin"
-	maxOriginErrors      = 32
 	maxMultiplexForwards = 2
 )
 
+// RetrieveChunk retrieves the chunk from the closest peers. If the hedge delay
+// is set, a request which is not answered within the delay is hedged by a
+// request to the next closest peer, the first successful response is taken
+// and the other requests are cancelled.
 func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr swarm.Address) (swarm.Chunk, error) {
 	loggerV1 := s.logger
 
@@ -142,6 +220,11 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 		return nil, fmt.Errorf("invalid address queried")
 	}
 
+	if s.notFound.has(chunkAddr) {
+		s.metrics.NotFoundCacheHits.Inc()
+		return nil, storage.ErrNotFound
+	}
+
 	flightRoute := chunkAddr.String()
 	if origin {
 		flightRoute = chunkAddr.String() + originSuffix
@@ -170,15 +253,37 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 		quit := make(chan struct{})
 		defer close(quit)
 
+		// the hedged requests still in flight are cancelled once the chunk is retrieved
+		requestCtx := spanCtx
+		if s.opts.HedgeDelay > 0 {
+			var cancel context.CancelFunc
+			requestCtx, cancel = context.WithCancel(spanCtx)
+			defer cancel()
+		}
+
+		var (
+			hedgeC   <-chan time.Time
+			hedged   bool
+			backoffC <-chan time.Time
+			failures int
+			started  int // requests sent to the peers
+		)
+
+		// the retrievals originating at the node start at most MaxAttempts
+		// requests, including the preemptive, hedged and forwarded ones
+		attemptsExhausted := func() bool {
+			return origin && started >= s.opts.MaxAttempts
+		}
+
 		var forwards = maxMultiplexForwards
 
 		// if we are the origin node, allow many preemptive retries to speed up the retrieval of the chunk.
 		errorsLeft := 1
 		if origin {
-			ticker := time.NewTicker(preemptiveInterval)
+			ticker := time.NewTicker(s.opts.RetryInterval)
 			defer ticker.Stop()
 			preemptiveTicker = ticker.C
-			errorsLeft = maxOriginErrors
+			errorsLeft = s.opts.MaxAttempts
 		}
 
 		resultC := make(chan retrievalResult, 1)
@@ -203,8 +308,26 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 				return nil, ctx.Err()
 			case <-preemptiveTicker:
 				retry()
+			case <-backoffC:
+				backoffC = nil
+				retry()
+			case <-hedgeC:
+				hedgeC = nil
+				if inflight > 0 {
+					hedged = true
+					errorsLeft++
+					s.metrics.HedgedRequestCounter.Inc()
+					retry()
+				}
 			case <-retryC:
 
+				if attemptsExhausted() {
+					if inflight == 0 {
+						return nil, storage.ErrNotFound
+					}
+					continue // wait for the results of the inflight requests
+				}
+
 				totalRetrieveAttempts++
 				s.metrics.PeerRequestCounter.Inc()
 
@@ -258,12 +381,19 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 				}
 				skip.Forever(chunkAddr, peer)
 
+				started++
 				inflight++
 
+				if s.opts.HedgeDelay > 0 && !hedged && hedgeC == nil {
+					hedgeC = time.After(s.opts.HedgeDelay)
+				}
+
+				attempt := totalRetrieveAttempts
+
 				go func() {
-					span, _, ctx := s.tracer.FollowSpanFromContext(spanCtx, "retrieve-chunk", s.logger, opentracing.Tag{Key: "address", Value: chunkAddr.String()})
+					span, _, ctx := s.tracer.FollowSpanFromContext(requestCtx, "retrieve-chunk", s.logger, opentracing.Tag{Key: "address", Value: chunkAddr.String()})
 					defer span.Finish()
-					s.retrieveChunk(ctx, quit, chunkAddr, peer, resultC, action, span)
+					s.retrieveChunk(ctx, quit, chunkAddr, peer, attempt, resultC, action, span)
 				}()
 
 			case res := <-resultC:
@@ -272,6 +402,8 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 				if res.err == nil {
 					loggerV1.Debug("retrieved chunk", "chunk_address", chunkAddr, "peer_address", res.peer, "peer_proximity", swarm.Proximity(res.peer.Bytes(), chunkAddr.Bytes()))
+					s.metrics.SuccessfulAttempt.Observe(float64(res.attempt))
+					s.breaker.record(res.peer, false)
 					return res.chunk, nil
 				}
 
@@ -280,6 +412,23 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 
 				errorsLeft--
 				s.errSkip.Add(chunkAddr, res.peer, skiplistDur)
+
+				// the peers not having the chunk are not at fault
+				var deliveryErr *p2p.ChunkDeliveryError
+				if !errors.As(res.err, &deliveryErr) && s.breaker.record(res.peer, true) {
+					loggerV1.Debug("peer circuit opened", "peer_address", res.peer, "cooldown", s.opts.BreakerCooldown)
+					s.metrics.BreakerOpenCounter.Inc()
+				}
+
+				if attemptsExhausted() && inflight == 0 {
+					return nil, storage.ErrNotFound
+				}
+
+				failures++
+				if d := s.backoff(failures); d > 0 {
+					backoffC = time.After(d)
+					continue
+				}
 				retry()
 			}
 		}
@@ -289,6 +438,10 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 	if err != nil {
 		s.metrics.RequestFailureCounter.Inc()
 		s.logger.Debug("retrieval failed", "chunk_address", chunkAddr, "error", err)
+		// all the attempts failed, the peers do not have the chunk
+		if errors.Is(err, storage.ErrNotFound) {
+			s.notFound.add(chunkAddr)
+		}
 		return nil, err
 	}
 
@@ -297,7 +450,7 @@ func (s *Service) RetrieveChunk(ctx context.Context, chunkAddr, sourcePeerAddr s
 	return v, nil
 }
 
-func (s *Service) retrieveChunk(ctx context.Context, quit chan struct{}, chunkAddr, peer swarm.Address, result chan retrievalResult, action accounting.Action, span opentracing.Span) {
+func (s *Service) retrieveChunk(ctx context.Context, quit chan struct{}, chunkAddr, peer swarm.Address, attempt int, result chan retrievalResult, action accounting.Action, span opentracing.Span) {
 
 	var (
 		startTime = time.Now()
@@ -314,13 +467,13 @@ func (s *Service) retrieveChunk(ctx context.Context, quit chan struct{}, chunkAd
 			span.LogFields(olog.Bool("success", true))
 		}
 		select {
-		case result <- retrievalResult{err: err, chunk: chunk, peer: peer}:
+		case result <- retrievalResult{err: err, chunk: chunk, peer: peer, attempt: attempt}:
 		case <-quit:
 			return
 		}
 	}()
 
-	ctx, cancel := context.WithTimeout(ctx, RetrieveChunkTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.opts.AttemptTimeout)
 	defer cancel()
 
 	stream, err := s.streamer.NewStream(ctx, peer, nil, protocolName, protocolVersion, streamName)
@@ -369,6 +522,15 @@ func (s *Service) retrieveChunk(ctx context.Context, quit chan struct{}, chunkAd
 	err = action.Apply()
 }
 
+// backoff returns the delay before retrying after the given number of
+// consecutive failed attempts.
+func (s *Service) backoff(failures int) time.Duration {
+	if len(s.opts.Backoff) == 0 {
+		return 0
+	}
+	return s.opts.Backoff[min(failures, len(s.opts.Backoff))-1]
+}
+
 func (s *Service) prepareCredit(ctx context.Context, peer, chunk swarm.Address, origin bool) (accounting.Action, error) {
 
 	price := s.pricer.PeerPrice(peer, chunk)
@@ -386,19 +548,24 @@ func (s *Service) prepareCredit(ctx context.Context, peer, chunk swarm.Address,
 // provided address addr. This function will ignore peers with addresses
 // provided in skipPeers and if allowUpstream is true, peers that are further of
 // the chunk than this node is, could also be returned, allowing the upstream
-// retrieve request.
+// retrieve request. The upstream requests prefer the peers with the lowest
+// latency among the closest ones. If there are more selection candidates,
+// the peer with the lowest cost among them is returned.
 func (s *Service) closestPeer(addr swarm.Address, skipPeers []swarm.Address, allowUpstream bool) (swarm.Address, error) {
 
 	var (
 		closest swarm.Address
+		filter  = topology.Select{Reachable: true, Healthy: true, LowLatency: allowUpstream}
 		err     error
 	)
 
-	closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{Reachable: true, Healthy: true}, skipPeers...)
+	closest, err = s.peerSuggester.ClosestPeer(addr, false, filter, skipPeers...)
 	if errors.Is(err, topology.ErrNotFound) {
-		closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{Reachable: true}, skipPeers...)
+		filter = topology.Select{Reachable: true, LowLatency: allowUpstream}
+		closest, err = s.peerSuggester.ClosestPeer(addr, false, filter, skipPeers...)
 		if errors.Is(err, topology.ErrNotFound) {
-			closest, err = s.peerSuggester.ClosestPeer(addr, false, topology.Select{}, skipPeers...)
+			filter = topology.Select{LowLatency: allowUpstream}
+			closest, err = s.peerSuggester.ClosestPeer(addr, false, filter, skipPeers...)
 		}
 	}
 
@@ -407,7 +574,7 @@ func (s *Service) closestPeer(addr swarm.Address, skipPeers []swarm.Address, all
 	}
 
 	if allowUpstream {
-		return closest, nil
+		return s.selectPeer(addr, closest, filter, skipPeers, true), nil
 	}
 
 	closer, err := closest.Closer(addr, s.addr)
@@ -418,7 +585,7 @@ func (s *Service) closestPeer(addr swarm.Address, skipPeers []swarm.Address, all
 		return swarm.Address{}, topology.ErrNotFound
 	}
 
-	return closest, nil
+	return s.selectPeer(addr, closest, filter, skipPeers, false), nil
 }
 
 func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream) (err error) {
@@ -497,7 +664,7 @@ func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream)
 	}
 
 	// cache the request last, so that putting to the localstore does not slow down the request flow
-	if s.caching && forwarded {
+	if s.opts.Caching && forwarded {
 		if err := s.storer.Cache().Put(p2pctx, chunk); err != nil {
 			s.logger.Debug("retrieve cache put", "error", err)
 		}
@@ -507,5 +674,8 @@ func (s *Service) handler(p2pctx context.Context, p p2p.Peer, stream p2p.Stream)
 }
 
 func (s *Service) Close() error {
+	if err := s.saveSkiplist(); err != nil {
+		s.logger.Warning("unable to persist the retrieval skiplist", "error", err)
+	}
 	return s.errSkip.Close()
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/retrieval"
 	pb "github.com/calmw/bee-tron/pkg/retrieval/pb"
 	"github.com/calmw/bee-tron/pkg/spinlock"
+	statestore "github.com/calmw/bee-tron/pkg/statestore/mock"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
 	testingc "github.com/calmw/bee-tron/pkg/storage/testing"
@@ -72,7 +73,7 @@ func TestDelivery(t *testing.T) {
 	}
 
 	// create the server that will handle the request and will serve the response
-	server := createRetrieval(t, swarm.MustParseHexAddress("0034"), mockStorer, nil, nil, logger, serverMockAccounting, pricerMock, nil, false)
+	server := createRetrieval(t, swarm.MustParseHexAddress("0034"), mockStorer, nil, nil, logger, serverMockAccounting, pricerMock, nil, retrieval.Options{})
 	recorder := streamtest.New(
 		streamtest.WithProtocols(server.Protocol()),
 		streamtest.WithBaseAddr(clientAddr),
@@ -86,7 +87,7 @@ func TestDelivery(t *testing.T) {
 
 	mt := topologymock.NewTopologyDriver(topologymock.WithClosestPeer(serverAddr))
 
-	client := createRetrieval(t, clientAddr, clientMockStorer, recorder, mt, logger, clientMockAccounting, pricerMock, nil, false)
+	client := createRetrieval(t, clientAddr, clientMockStorer, recorder, mt, logger, clientMockAccounting, pricerMock, nil, retrieval.Options{})
 	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
 	defer cancel()
 	v, err := client.RetrieveChunk(ctx, chunk.Address(), swarm.ZeroAddress)
@@ -178,9 +179,9 @@ func TestWaitForInflight(t *testing.T) {
 	}
 
 	// create the server that will handle the request and will serve the response
-	server := createRetrieval(t, serverAddr, mockStorer, nil, nil, logger, serverMockAccounting, pricerMock, nil, false)
+	server := createRetrieval(t, serverAddr, mockStorer, nil, nil, logger, serverMockAccounting, pricerMock, nil, retrieval.Options{})
 
-	badServer := createRetrieval(t, badServerAddr, badMockStorer, nil, nil, logger, badServerMockAccounting, pricerMock, nil, false)
+	badServer := createRetrieval(t, badServerAddr, badMockStorer, nil, nil, logger, badServerMockAccounting, pricerMock, nil, retrieval.Options{})
 
 	var fail = true
 	var lock sync.Mutex
@@ -213,7 +214,7 @@ func TestWaitForInflight(t *testing.T) {
 
 	mt := topologymock.NewTopologyDriver(topologymock.WithPeers(badServerAddr, serverAddr))
 
-	client := createRetrieval(t, clientAddr, clientMockStorer, recorder, mt, logger, clientMockAccounting, pricerMock, nil, false)
+	client := createRetrieval(t, clientAddr, clientMockStorer, recorder, mt, logger, clientMockAccounting, pricerMock, nil, retrieval.Options{})
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*30)
 	defer cancel()
@@ -251,12 +252,12 @@ func TestRetrieveChunk(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		server := createRetrieval(t, serverAddress, serverStorer, nil, nil, logger, accountingmock.NewAccounting(), pricer, nil, false)
+		server := createRetrieval(t, serverAddress, serverStorer, nil, nil, logger, accountingmock.NewAccounting(), pricer, nil, retrieval.Options{})
 		recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
 
 		mt := topologymock.NewTopologyDriver(topologymock.WithClosestPeer(serverAddress))
 
-		client := createRetrieval(t, clientAddress, nil, recorder, mt, logger, accountingmock.NewAccounting(), pricer, nil, false)
+		client := createRetrieval(t, clientAddress, nil, recorder, mt, logger, accountingmock.NewAccounting(), pricer, nil, retrieval.Options{})
 
 		got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
 		if err != nil {
@@ -292,7 +293,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			false,
+			retrieval.Options{},
 		)
 
 		forwarderStore := &testStorer{ChunkStore: inmemchunkstore.New()}
@@ -306,7 +307,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			true, // note explicit caching
+			retrieval.Options{Caching: true}, // note explicit caching
 		)
 
 		client := createRetrieval(t,
@@ -318,7 +319,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			false,
+			retrieval.Options{},
 		)
 
 		if got, _ := forwarderStore.Has(context.Background(), chunk.Address()); got {
@@ -363,7 +364,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			false,
+			retrieval.Options{},
 		)
 
 		forwarderStore := &testStorer{ChunkStore: inmemchunkstore.New()}
@@ -377,7 +378,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			true, // note explicit caching
+			retrieval.Options{Caching: true}, // note explicit caching
 		)
 
 		client := createRetrieval(t,
@@ -389,7 +390,7 @@ func TestRetrieveChunk(t *testing.T) {
 			accountingmock.NewAccounting(),
 			pricer,
 			nil,
-			false,
+			retrieval.Options{},
 		)
 
 		_, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
@@ -440,8 +441,8 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 	noClosestPeer := topologymock.NewTopologyDriver()
 	closetPeers := topologymock.NewTopologyDriver(topologymock.WithPeers(peers...))
 
-	server1 := createRetrieval(t, serverAddress1, serverStorer1, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
-	server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+	server1 := createRetrieval(t, serverAddress1, serverStorer1, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+	server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
 
 	t.Run("peer not reachable", func(t *testing.T) {
 		t.Parallel()
@@ -471,7 +472,7 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 			streamtest.WithBaseAddr(clientAddress),
 		)
 
-		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
 
 		got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
 		if err != nil {
@@ -509,7 +510,7 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 			),
 		)
 
-		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
 
 		got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
 		if err != nil {
@@ -540,8 +541,8 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 		server1MockAccounting := accountingmock.NewAccounting()
 		server2MockAccounting := accountingmock.NewAccounting()
 
-		server1 := createRetrieval(t, serverAddress1, serverStorer1, nil, noClosestPeer, logger, server1MockAccounting, pricerMock, nil, false)
-		server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, server2MockAccounting, pricerMock, nil, false)
+		server1 := createRetrieval(t, serverAddress1, serverStorer1, nil, noClosestPeer, logger, server1MockAccounting, pricerMock, nil, retrieval.Options{})
+		server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, server2MockAccounting, pricerMock, nil, retrieval.Options{})
 
 		// NOTE: must be more than retry duration
 		// (here one second more)
@@ -576,7 +577,7 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 
 		clientMockAccounting := accountingmock.NewAccounting()
 
-		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, clientMockAccounting, pricerMock, nil, false)
+		client := createRetrieval(t, clientAddress, nil, recorder, closetPeers, logger, clientMockAccounting, pricerMock, nil, retrieval.Options{})
 
 		got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
 		if err != nil {
@@ -616,21 +617,21 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 		t.Parallel()
 
 		// server 2 has the chunk
-		server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+		server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
 
 		server1Recorder := streamtest.New(
 			streamtest.WithProtocols(server2.Protocol()),
 		)
 
 		// server 1 will forward request to server 2
-		server1 := createRetrieval(t, serverAddress1, serverStorer1, server1Recorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress2)), logger, accountingmock.NewAccounting(), pricerMock, nil, true)
+		server1 := createRetrieval(t, serverAddress1, serverStorer1, server1Recorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress2)), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{Caching: true})
 
 		clientRecorder := streamtest.New(
 			streamtest.WithProtocols(server1.Protocol()),
 		)
 
 		// client only knows about server 1
-		client := createRetrieval(t, clientAddress, nil, clientRecorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress1)), logger, accountingmock.NewAccounting(), pricerMock, nil, false)
+		client := createRetrieval(t, clientAddress, nil, clientRecorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress1)), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
 
 		if got, _ := serverStorer1.Has(context.Background(), chunk.Address()); got {
 			t.Fatalf("forwarder node already has chunk")
@@ -654,6 +655,469 @@ func TestRetrievePreemptiveRetry(t *testing.T) {
 	})
 }
 
+func TestRetrieveHedged(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	chunk := testingc.FixtureChunk("0025")
+
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	clientAddress := swarm.MustParseHexAddress("1010")
+
+	serverAddress1 := swarm.MustParseHexAddress("1000000000000000000000000000000000000000000000000000000000000000")
+	serverAddress2 := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+
+	serverStorer1 := &testStorer{ChunkStore: inmemchunkstore.New()}
+	serverStorer2 := &testStorer{ChunkStore: inmemchunkstore.New()}
+
+	// both peers have the chunk
+	if err := serverStorer1.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+	if err := serverStorer2.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	noClosestPeer := topologymock.NewTopologyDriver()
+
+	server1 := createRetrieval(t, serverAddress1, serverStorer1, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+	server2 := createRetrieval(t, serverAddress2, serverStorer2, nil, noClosestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+
+	// NOTE: must be more than the preemptive retry interval
+	server2ResponseDelayDuration := 2 * time.Second
+
+	ranOnce := true
+	ranMux := sync.Mutex{}
+	recorder := streamtest.New(
+		streamtest.WithProtocols(
+			server1.Protocol(),
+			server2.Protocol(),
+		),
+		streamtest.WithMiddlewares(
+			func(h p2p.HandlerFunc) p2p.HandlerFunc {
+				return func(ctx context.Context, peer p2p.Peer, stream p2p.Stream) error {
+					ranMux.Lock()
+					if ranOnce {
+						ranOnce = false
+						ranMux.Unlock()
+						time.Sleep(server2ResponseDelayDuration)
+						// server2 is picked first because it's address is closer to the chunk than server1
+						return server2.Handler(ctx, peer, stream)
+					}
+					ranMux.Unlock()
+
+					return server1.Handler(ctx, peer, stream)
+				}
+			},
+		),
+	)
+
+	clientMockAccounting := accountingmock.NewAccounting()
+
+	client := createRetrieval(t, clientAddress, nil, recorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress1, serverAddress2)), logger, clientMockAccounting, pricerMock, nil, retrieval.Options{HedgeDelay: 100 * time.Millisecond})
+
+	start := time.Now()
+	got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got.Data(), chunk.Data()) {
+		t.Fatalf("got data %x, want %x", got.Data(), chunk.Data())
+	}
+	if elapsed := time.Since(start); elapsed >= server2ResponseDelayDuration {
+		t.Fatalf("retrieval took %s, want less than %s", elapsed, server2ResponseDelayDuration)
+	}
+
+	// wait for the slower peer to respond
+	time.Sleep(server2ResponseDelayDuration)
+
+	clientServer1Balance, _ := clientMockAccounting.Balance(serverAddress1)
+	if clientServer1Balance.Int64() != -int64(defaultPrice) {
+		t.Fatalf("unexpected balance on client. want %d got %d", -int64(defaultPrice), clientServer1Balance)
+	}
+
+	// the request to the slower peer is cancelled and never paid
+	clientServer2Balance, _ := clientMockAccounting.Balance(serverAddress2)
+	if clientServer2Balance.Int64() != 0 {
+		t.Fatalf("unexpected balance on client. want %d got %d", 0, clientServer2Balance)
+	}
+}
+
+func TestRetrieveAttempts(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	chunk := testingc.FixtureChunk("0025")
+
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	clientAddress := swarm.MustParseHexAddress("1010")
+
+	serverAddress1 := swarm.MustParseHexAddress("1000000000000000000000000000000000000000000000000000000000000000")
+	serverAddress2 := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+	serverAddress3 := swarm.MustParseHexAddress("0300000000000000000000000000000000000000000000000000000000000000")
+
+	serverStorer := &testStorer{ChunkStore: inmemchunkstore.New()}
+	if err := serverStorer.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	server := createRetrieval(t, serverAddress1, serverStorer, nil, topologymock.NewTopologyDriver(), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+
+	closestPeers := topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress1, serverAddress2, serverAddress3))
+
+	t.Run("max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			attempts int
+			mu       sync.Mutex
+		)
+		recorder := streamtest.New(
+			streamtest.WithProtocols(server.Protocol()),
+			streamtest.WithMiddlewares(
+				func(h p2p.HandlerFunc) p2p.HandlerFunc {
+					return func(ctx context.Context, peer p2p.Peer, stream p2p.Stream) error {
+						mu.Lock()
+						defer mu.Unlock()
+						attempts++
+						return fmt.Errorf("peer not reachable: %s", peer.Address.String())
+					}
+				},
+			),
+		)
+
+		client := createRetrieval(t, clientAddress, nil, recorder, closestPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{MaxAttempts: 2})
+
+		_, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if attempts != 2 {
+			t.Fatalf("got %d attempts, want %d", attempts, 2)
+		}
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		t.Parallel()
+
+		backoff := 300 * time.Millisecond
+
+		ranOnce := true
+		ranMux := sync.Mutex{}
+		recorder := streamtest.New(
+			streamtest.WithProtocols(server.Protocol()),
+			streamtest.WithMiddlewares(
+				func(h p2p.HandlerFunc) p2p.HandlerFunc {
+					return func(ctx context.Context, peer p2p.Peer, stream p2p.Stream) error {
+						ranMux.Lock()
+						defer ranMux.Unlock()
+						if ranOnce {
+							ranOnce = false
+							return fmt.Errorf("peer not reachable: %s", peer.Address.String())
+						}
+
+						return server.Handler(ctx, peer, stream)
+					}
+				},
+			),
+		)
+
+		client := createRetrieval(t, clientAddress, nil, recorder, closestPeers, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{Backoff: []time.Duration{backoff}})
+
+		start := time.Now()
+		got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got.Data(), chunk.Data()) {
+			t.Fatalf("got data %x, want %x", got.Data(), chunk.Data())
+		}
+		if elapsed := time.Since(start); elapsed < backoff {
+			t.Fatalf("retrieval took %s, want at least %s", elapsed, backoff)
+		}
+	})
+}
+
+func TestRetrieveCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	chunks := []swarm.Chunk{
+		testingc.FixtureChunk("0025"),
+		testingc.FixtureChunk("0033"),
+		testingc.FixtureChunk("7000"),
+	}
+
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	clientAddress := swarm.MustParseHexAddress("1010")
+
+	serverAddress1 := swarm.MustParseHexAddress("1000000000000000000000000000000000000000000000000000000000000000")
+	serverAddress2 := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+
+	serverStorer := &testStorer{ChunkStore: inmemchunkstore.New()}
+	for _, ch := range chunks {
+		if err := serverStorer.Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := createRetrieval(t, serverAddress2, serverStorer, nil, topologymock.NewTopologyDriver(), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+
+	var (
+		attempts int
+		mu       sync.Mutex
+	)
+	recorder := streamtest.New(
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithStreamError(func(addr swarm.Address, _, _, _ string) error {
+			if !addr.Equal(serverAddress1) {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			attempts++
+			return fmt.Errorf("peer not reachable: %s", addr.String())
+		}),
+	)
+
+	// server1 is always tried first unless it is skipped
+	closestPeer := topologymock.NewTopologyDriver(topologymock.WithClosestPeer(serverAddress1), topologymock.WithPeers(serverAddress1, serverAddress2))
+
+	client := createRetrieval(t, clientAddress, nil, recorder, closestPeer, logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{BreakerFailureRate: 1, BreakerMinAttempts: 2})
+
+	for _, ch := range chunks {
+		got, err := client.RetrieveChunk(context.Background(), ch.Address(), swarm.ZeroAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got.Data(), ch.Data()) {
+			t.Fatalf("got data %x, want %x", got.Data(), ch.Data())
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the circuit of server1 is opened after its second failed attempt
+	if attempts != 2 {
+		t.Fatalf("got %d attempts to the failing peer, want %d", attempts, 2)
+	}
+}
+
+func TestRetrieveNotFoundCache(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	chunk := testingc.FixtureChunk("0025")
+
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	clientAddress := swarm.MustParseHexAddress("1010")
+	serverAddress1 := swarm.MustParseHexAddress("1000000000000000000000000000000000000000000000000000000000000000")
+	serverAddress2 := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+
+	serverStorer := &testStorer{ChunkStore: inmemchunkstore.New()}
+	server := createRetrieval(t, serverAddress2, serverStorer, nil, topologymock.NewTopologyDriver(), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+
+	var (
+		requests int
+		mu       sync.Mutex
+	)
+	recorder := streamtest.New(
+		streamtest.WithProtocols(server.Protocol()),
+		streamtest.WithMiddlewares(
+			func(h p2p.HandlerFunc) p2p.HandlerFunc {
+				return func(ctx context.Context, peer p2p.Peer, stream p2p.Stream) error {
+					mu.Lock()
+					requests++
+					mu.Unlock()
+					return h(ctx, peer, stream)
+				}
+			},
+		),
+	)
+
+	ttl := 300 * time.Millisecond
+
+	// both peers are served by the same server, the peer which failed is
+	// skipped by the later retrievals of the chunk
+	client := createRetrieval(t, clientAddress, nil, recorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress1, serverAddress2)), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{MaxAttempts: 1, NotFoundTTL: ttl})
+
+	wantRequests := func(want int) {
+		t.Helper()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if requests != want {
+			t.Fatalf("got %d requests, want %d", requests, want)
+		}
+	}
+
+	_, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	wantRequests(1)
+
+	// the missing chunk is not requested again until the ttl expires
+	_, err = client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	wantRequests(1)
+
+	if err := serverStorer.Put(context.Background(), chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(ttl)
+
+	got, err := client.RetrieveChunk(context.Background(), chunk.Address(), swarm.ZeroAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Data(), chunk.Data()) {
+		t.Fatalf("got data %x, want %x", got.Data(), chunk.Data())
+	}
+	wantRequests(2)
+}
+
+func TestRetrieveChunks(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+
+	chunks := []swarm.Chunk{
+		testingc.FixtureChunk("0025"),
+		testingc.FixtureChunk("0033"),
+		testingc.FixtureChunk("7000"),
+	}
+	missing := testingc.GenerateTestRandomChunk()
+
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	clientAddress := swarm.MustParseHexAddress("1010")
+	serverAddress := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+
+	serverStorer := &testStorer{ChunkStore: inmemchunkstore.New()}
+	for _, ch := range chunks {
+		if err := serverStorer.Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := createRetrieval(t, serverAddress, serverStorer, nil, topologymock.NewTopologyDriver(), logger, accountingmock.NewAccounting(), pricerMock, nil, retrieval.Options{})
+	recorder := streamtest.New(streamtest.WithProtocols(server.Protocol()))
+
+	clientMockAccounting := accountingmock.NewAccounting()
+	client := createRetrieval(t, clientAddress, nil, recorder, topologymock.NewTopologyDriver(topologymock.WithPeers(serverAddress)), logger, clientMockAccounting, pricerMock, nil, retrieval.Options{MaxAttempts: 1})
+
+	addrs := []swarm.Address{chunks[0].Address(), missing.Address(), chunks[1].Address(), chunks[2].Address()}
+
+	var (
+		mu    sync.Mutex
+		got   = make([]swarm.Chunk, len(addrs))
+		calls = make([]int, len(addrs))
+		order []int
+	)
+	err := client.RetrieveChunks(context.Background(), addrs, func(i int, ch swarm.Chunk, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[i]++
+		order = append(order, i)
+		if err == nil {
+			got[i] = ch
+		}
+	})
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+	for i, n := range calls {
+		if n != 1 {
+			t.Fatalf("got %d results for chunk %d, want 1", n, i)
+		}
+	}
+	// the delivered chunks are handed over before the missing one is retried
+	if order[len(order)-1] != 1 {
+		t.Fatalf("got results in order %v, want the missing chunk last", order)
+	}
+	if got[1] != nil {
+		t.Fatal("missing chunk retrieved")
+	}
+	for i, ch := range []swarm.Chunk{got[0], got[2], got[3]} {
+		if ch == nil || !bytes.Equal(ch.Data(), chunks[i].Data()) {
+			t.Fatalf("chunk %s not retrieved", chunks[i].Address())
+		}
+	}
+
+	// all the chunks are requested in a single batch
+	records, err := recorder.Records(serverAddress, "retrieval", "1.4.0", "retrieval-batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(records); l != 1 {
+		t.Fatalf("got %v batch records, want %v", l, 1)
+	}
+
+	// the missing chunk is requested once more on its own
+	records, err = recorder.Records(serverAddress, "retrieval", "1.4.0", "retrieval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(records); l != 1 {
+		t.Fatalf("got %v records, want %v", l, 1)
+	}
+
+	clientBalance, _ := clientMockAccounting.Balance(serverAddress)
+	if want := -int64(len(chunks)) * int64(defaultPrice); clientBalance.Int64() != want {
+		t.Fatalf("unexpected balance on client. want %d got %d", want, clientBalance)
+	}
+}
+
+func TestSkiplistPersistence(t *testing.T) {
+	t.Parallel()
+
+	stateStore := statestore.NewStateStore()
+
+	addr := swarm.MustParseHexAddress("0100000000000000000000000000000000000000000000000000000000000000")
+	chunk := swarm.RandAddress(t)
+	peer1 := swarm.RandAddress(t)
+	peer2 := swarm.RandAddress(t)
+
+	ret := retrieval.New(addr, nil, nil, nil, topologymock.NewTopologyDriver(), log.Noop, nil, nil, nil, retrieval.Options{StateStore: stateStore})
+	ret.SkipPeer(chunk, peer1, time.Hour)
+	ret.SkipPeer(chunk, peer2, -time.Hour)
+	if err := ret.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the entries which have not expired are restored
+	ret = createRetrieval(t, addr, nil, nil, topologymock.NewTopologyDriver(), log.Noop, nil, nil, nil, retrieval.Options{StateStore: stateStore})
+	entries := ret.SkippedPeers()
+	if len(entries) != 1 || !entries[0].Peer.Equal(peer1) || !entries[0].Chunk.Equal(chunk) {
+		t.Fatalf("got entries %v, want peer %s skipped for chunk %s", entries, peer1, chunk)
+	}
+
+	if n := ret.ClearSkippedPeers(swarm.ZeroAddress); n != 1 {
+		t.Fatalf("cleared %d entries, want 1", n)
+	}
+	if entries := ret.SkippedPeers(); len(entries) != 0 {
+		t.Fatalf("got entries %v, want none", entries)
+	}
+}
+
 func TestClosestPeer(t *testing.T) {
 	t.Parallel()
 
@@ -663,7 +1127,7 @@ func TestClosestPeer(t *testing.T) {
 	addr2 := swarm.MustParseHexAddress("0300000000000000000000000000000000000000000000000000000000000000")
 	addr3 := swarm.MustParseHexAddress("0400000000000000000000000000000000000000000000000000000000000000")
 
-	ret := createRetrieval(t, srvAd, nil, nil, topologymock.NewTopologyDriver(topologymock.WithPeers(addr1, addr2, addr3)), log.Noop, nil, nil, nil, false)
+	ret := createRetrieval(t, srvAd, nil, nil, topologymock.NewTopologyDriver(topologymock.WithPeers(addr1, addr2, addr3)), log.Noop, nil, nil, nil, retrieval.Options{})
 
 	t.Run("closest", func(t *testing.T) {
 		t.Parallel()
@@ -699,6 +1163,83 @@ func TestClosestPeer(t *testing.T) {
 	})
 }
 
+func TestSelectPeer(t *testing.T) {
+	t.Parallel()
+
+	srvAd := swarm.MustParseHexAddress("0100000000000000000000000000000000000000000000000000000000000000")
+
+	addr1 := swarm.MustParseHexAddress("0200000000000000000000000000000000000000000000000000000000000000")
+	addr2 := swarm.MustParseHexAddress("0300000000000000000000000000000000000000000000000000000000000000")
+	addr3 := swarm.MustParseHexAddress("0400000000000000000000000000000000000000000000000000000000000000")
+
+	peerer := latencyTopology{
+		ClosestPeerer: topologymock.NewTopologyDriver(topologymock.WithPeers(addr1, addr2, addr3)),
+		latencies: map[string]time.Duration{
+			addr1.ByteString(): 100 * time.Millisecond,
+			addr2.ByteString(): 10 * time.Millisecond,
+			addr3.ByteString(): time.Millisecond,
+		},
+	}
+	pricerMock := pricermock.NewMockService(defaultPrice, defaultPrice)
+
+	for _, tc := range []struct {
+		name          string
+		opts          retrieval.Options
+		allowUpstream bool
+		want          swarm.Address
+	}{
+		{
+			name: "closest by default",
+			want: addr1,
+		},
+		{
+			name: "lowest latency",
+			opts: retrieval.Options{SelectionCandidates: 2},
+			want: addr2,
+		},
+		{
+			name: "equal price keeps closest",
+			opts: retrieval.Options{SelectionCandidates: 2, Cost: retrieval.LinearCost(1, 0)},
+			want: addr1,
+		},
+		{
+			name: "further than base addr not selected",
+			opts: retrieval.Options{SelectionCandidates: 3},
+			want: addr2,
+		},
+		{
+			name:          "further than base addr selected upstream",
+			opts:          retrieval.Options{SelectionCandidates: 3},
+			allowUpstream: true,
+			want:          addr3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ret := createRetrieval(t, srvAd, nil, nil, peerer, log.Noop, nil, pricerMock, nil, tc.opts)
+
+			addr, err := ret.ClosestPeer(addr1, nil, tc.allowUpstream)
+			if err != nil {
+				t.Fatal("closest peer", err)
+			}
+			if !addr.Equal(tc.want) {
+				t.Fatalf("want %s, got %s", tc.want.String(), addr.String())
+			}
+		})
+	}
+}
+
+// latencyTopology is the topology which provides the peer latencies.
+type latencyTopology struct {
+	topology.ClosestPeerer
+	latencies map[string]time.Duration
+}
+
+func (t latencyTopology) PeerLatency(peer swarm.Address) time.Duration {
+	return t.latencies[peer.ByteString()]
+}
+
 func createRetrieval(
 	t *testing.T,
 	addr swarm.Address,
@@ -709,13 +1250,13 @@ func createRetrieval(
 	accounting accounting.Interface,
 	pricer pricer.Interface,
 	tracer *tracing.Tracer,
-	forwarderCaching bool,
+	o retrieval.Options,
 ) *retrieval.Service {
 	t.Helper()
 
 	radiusF := func() (uint8, error) { return swarm.MaxBins, nil }
 
-	ret := retrieval.New(addr, radiusF, storer, streamer, chunkPeerer, logger, accounting, pricer, tracer, forwarderCaching)
+	ret := retrieval.New(addr, radiusF, storer, streamer, chunkPeerer, logger, accounting, pricer, tracer, o)
 	t.Cleanup(func() { ret.Close() })
 	return ret
 }
@@ -0,0 +1,100 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"math"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+)
+
+// Default peer selection option values.
+const (
+	DefaultSelectionCandidates    = 1
+	DefaultSelectionPriceWeight   = 1.0
+	DefaultSelectionLatencyWeight = 1.0
+)
+
+// CostFunc returns the cost of retrieving a chunk from a peer, the peer with
+// the lowest cost among the candidates is requested. The price and the
+// latency of the peer are relative to the lowest ones among the candidates,
+// so the lowest ones are one.
+type CostFunc func(price, latency float64) float64
+
+// LinearCost returns the cost function which is the weighted sum of the
+// relative price and latency.
+func LinearCost(priceWeight, latencyWeight float64) CostFunc {
+	return func(price, latency float64) float64 {
+		return priceWeight*price + latencyWeight*latency
+	}
+}
+
+// selectPeer returns the peer with the lowest cost among the closest peer
+// and the next closest peers, up to the selection candidates. The further
+// peers are considered only if they are closer to the chunk than this node,
+// unless the upstream request is allowed. The latencies not measured yet
+// are considered as high as the attempt timeout.
+func (s *Service) selectPeer(addr, closest swarm.Address, filter topology.Select, skipPeers []swarm.Address, allowUpstream bool) swarm.Address {
+	if s.opts.SelectionCandidates <= 1 {
+		return closest
+	}
+
+	candidates := []swarm.Address{closest}
+	skip := append(skipPeers[:len(skipPeers):len(skipPeers)], closest)
+	for len(candidates) < s.opts.SelectionCandidates {
+		peer, err := s.peerSuggester.ClosestPeer(addr, false, filter, skip...)
+		if err != nil {
+			break
+		}
+		if !allowUpstream {
+			if closer, err := peer.Closer(addr, s.addr); err != nil || !closer {
+				break
+			}
+		}
+		candidates = append(candidates, peer)
+		skip = append(skip, peer)
+	}
+	if len(candidates) == 1 {
+		return closest
+	}
+
+	var (
+		prices     = make([]float64, len(candidates))
+		latencies  = make([]float64, len(candidates))
+		minPrice   = math.Inf(1)
+		minLatency = math.Inf(1)
+	)
+	for i, peer := range candidates {
+		prices[i] = float64(s.pricer.PeerPrice(peer, addr))
+		latency := s.opts.AttemptTimeout
+		if s.latency != nil {
+			if l := s.latency.PeerLatency(peer); l > 0 {
+				latency = l
+			}
+		}
+		latencies[i] = float64(latency)
+		minPrice = min(minPrice, prices[i])
+		minLatency = min(minLatency, latencies[i])
+	}
+
+	best, bestCost := 0, math.Inf(1)
+	for i := range candidates {
+		cost := s.opts.Cost(relative(prices[i], minPrice), relative(latencies[i], minLatency))
+		if cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	if best > 0 {
+		s.metrics.CostSelectedCounter.Inc()
+	}
+	return candidates[best]
+}
+
+// relative returns the value relative to the lowest one, which are shifted
+// by one to allow for zero values.
+func relative(v, lowest float64) float64 {
+	return (v + 1) / (lowest + 1)
+}
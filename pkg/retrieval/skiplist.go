@@ -0,0 +1,76 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retrieval
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/calmw/bee-tron/pkg/skippeers"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const skiplistKey = "retrieval_skiplist"
+
+var _ Skiplist = (*Service)(nil)
+
+// Skiplist provides the inspection of the peers the retrievals avoid for
+// failing recently.
+type Skiplist interface {
+	// SkippedPeers returns the peers skipped for the chunks, the ones with
+	// an empty chunk address are skipped for all the chunks.
+	SkippedPeers() []skippeers.Entry
+	// ClearSkippedPeers stops skipping the peer, all the peers if it is
+	// zero, and returns the number of the removed entries.
+	ClearSkippedPeers(peer swarm.Address) int
+}
+
+// SkippedPeers implements the Skiplist interface.
+func (s *Service) SkippedPeers() []skippeers.Entry {
+	return s.errSkip.Entries()
+}
+
+// ClearSkippedPeers implements the Skiplist interface.
+func (s *Service) ClearSkippedPeers(peer swarm.Address) int {
+	return s.errSkip.Clear(peer)
+}
+
+// loadSkiplist restores the skiplist persisted by the previous run.
+func (s *Service) loadSkiplist() error {
+	if s.opts.StateStore == nil {
+		return nil
+	}
+
+	var entries []skippeers.Entry
+	err := s.opts.StateStore.Get(skiplistKey, &entries)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get skiplist: %w", err)
+	}
+	s.errSkip.Restore(entries)
+	return nil
+}
+
+// saveSkiplist persists the skiplist entries which have not expired.
+func (s *Service) saveSkiplist() error {
+	if s.opts.StateStore == nil {
+		return nil
+	}
+
+	entries := s.errSkip.Entries()
+	if len(entries) == 0 {
+		if err := s.opts.StateStore.Delete(skiplistKey); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("delete skiplist: %w", err)
+		}
+		return nil
+	}
+	if err := s.opts.StateStore.Put(skiplistKey, entries); err != nil {
+		return fmt.Errorf("put skiplist: %w", err)
+	}
+	return nil
+}
@@ -22,6 +22,8 @@ type (
 	requestHostKey   struct{}
 	gasPriceKey      struct{}
 	gasLimitKey      struct{}
+	gasTipKey        struct{}
+	nonceKey         struct{}
 )
 
 // SetHost sets the http request host in the context
@@ -70,3 +72,30 @@ func GetGasPrice(ctx context.Context) *big.Int {
 	}
 	return nil
 }
+
+func SetGasTip(ctx context.Context, tip *big.Int) context.Context {
+	return context.WithValue(ctx, gasTipKey{}, tip)
+}
+
+func GetGasTip(ctx context.Context) *big.Int {
+	v, ok := ctx.Value(gasTipKey{}).(*big.Int)
+	if ok {
+		return v
+	}
+	return nil
+}
+
+// SetNonce sets the nonce of the next transaction sent with the context.
+func SetNonce(ctx context.Context, nonce *uint64) context.Context {
+	return context.WithValue(ctx, nonceKey{}, nonce)
+}
+
+// GetNonce gets the nonce of the next transaction, nil if the next free
+// nonce should be used.
+func GetNonce(ctx context.Context) *uint64 {
+	v, ok := ctx.Value(nonceKey{}).(*uint64)
+	if ok {
+		return v
+	}
+	return nil
+}
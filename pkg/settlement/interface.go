@@ -30,6 +30,7 @@ type Interface interface {
 type Accounting interface {
 	PeerDebt(peer swarm.Address) (*big.Int, error)
 	NotifyPaymentReceived(peer swarm.Address, amount *big.Int) error
+	NotifyPaymentBounced(peer swarm.Address, amount *big.Int) error
 	NotifyPaymentSent(peer swarm.Address, amount *big.Int, receivedError error)
 	NotifyRefreshmentReceived(peer swarm.Address, amount *big.Int, timestamp int64) error
 	NotifyRefreshmentSent(peer swarm.Address, attemptedAmount, amount *big.Int, timestamp, interval int64, receivedError error)
@@ -0,0 +1,137 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package settlement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const peerPolicyPrefix = "settlement_peer_policy_"
+
+var (
+	// ErrInvalidPolicy is returned when a policy contains contradicting or invalid settings.
+	ErrInvalidPolicy = errors.New("invalid settlement policy")
+)
+
+// PeerPolicy holds operator configured settlement overrides for a single peer.
+type PeerPolicy struct {
+	// DisableSwap disables sending and accepting cheques to and from the peer.
+	DisableSwap bool `json:"disableSwap"`
+	// ForcePseudosettle makes the node settle debt with the peer through time
+	// based settlement only. Cheques from the peer are still accepted.
+	ForcePseudosettle bool `json:"forcePseudosettle"`
+	// PaymentThreshold, if set, replaces the payment threshold given to the peer.
+	PaymentThreshold *big.Int `json:"paymentThreshold,omitempty"`
+}
+
+// SwapAllowed reports whether cheques may be sent to the peer.
+func (p PeerPolicy) SwapAllowed() bool {
+	return !p.DisableSwap && !p.ForcePseudosettle
+}
+
+// PolicyObserver is notified whenever the policy of a peer changes.
+type PolicyObserver interface {
+	NotifyPolicyChanged(peer swarm.Address)
+}
+
+// PolicyStore persists per-peer settlement policies in the state store.
+type PolicyStore struct {
+	store storage.StateStorer
+
+	mu       sync.Mutex
+	observer PolicyObserver
+}
+
+// NewPolicyStore creates a new PolicyStore.
+func NewPolicyStore(store storage.StateStorer) *PolicyStore {
+	return &PolicyStore{store: store}
+}
+
+func peerPolicyKey(peer swarm.Address) string {
+	return fmt.Sprintf("%s%s", peerPolicyPrefix, peer.String())
+}
+
+// SetObserver sets the observer notified about policy changes.
+func (s *PolicyStore) SetObserver(observer PolicyObserver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = observer
+}
+
+// Policy returns the policy for the peer. The boolean is false if no policy is configured.
+func (s *PolicyStore) Policy(peer swarm.Address) (PeerPolicy, bool, error) {
+	var policy PeerPolicy
+	err := s.store.Get(peerPolicyKey(peer), &policy)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return PeerPolicy{}, false, nil
+		}
+		return PeerPolicy{}, false, err
+	}
+	return policy, true, nil
+}
+
+// SetPolicy stores the policy for the peer.
+func (s *PolicyStore) SetPolicy(peer swarm.Address, policy PeerPolicy) error {
+	if policy.PaymentThreshold != nil && policy.PaymentThreshold.Sign() <= 0 {
+		return fmt.Errorf("%w: payment threshold must be positive", ErrInvalidPolicy)
+	}
+
+	if err := s.store.Put(peerPolicyKey(peer), policy); err != nil {
+		return err
+	}
+	s.notify(peer)
+	return nil
+}
+
+// DeletePolicy removes the policy for the peer.
+func (s *PolicyStore) DeletePolicy(peer swarm.Address) error {
+	if err := s.store.Delete(peerPolicyKey(peer)); err != nil {
+		return err
+	}
+	s.notify(peer)
+	return nil
+}
+
+// Policies returns all configured policies keyed by peer address.
+func (s *PolicyStore) Policies() (map[string]PeerPolicy, error) {
+	policies := make(map[string]PeerPolicy)
+	err := s.store.Iterate(peerPolicyPrefix, func(key, val []byte) (stop bool, err error) {
+		addr, err := swarm.ParseHexAddress(strings.TrimPrefix(string(key), peerPolicyPrefix))
+		if err != nil {
+			return false, fmt.Errorf("parse address from key: %s: %w", string(key), err)
+		}
+
+		var policy PeerPolicy
+		if err := json.Unmarshal(val, &policy); err != nil {
+			return false, fmt.Errorf("unmarshal policy for %s: %w", addr, err)
+		}
+
+		policies[addr.String()] = policy
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (s *PolicyStore) notify(peer swarm.Address) {
+	s.mu.Lock()
+	observer := s.observer
+	s.mu.Unlock()
+
+	if observer != nil {
+		observer.NotifyPolicyChanged(peer)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package settlement_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/settlement"
+	"github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+type policyObserver struct {
+	notified []swarm.Address
+}
+
+func (o *policyObserver) NotifyPolicyChanged(peer swarm.Address) {
+	o.notified = append(o.notified, peer)
+}
+
+func TestPolicyStore(t *testing.T) {
+	t.Parallel()
+
+	store := mock.NewStateStore()
+	policies := settlement.NewPolicyStore(store)
+	observer := &policyObserver{}
+	policies.SetObserver(observer)
+
+	peer := swarm.MustParseHexAddress("abcd")
+
+	_, ok, err := policies.Policy(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected no policy")
+	}
+
+	want := settlement.PeerPolicy{
+		DisableSwap:      true,
+		PaymentThreshold: big.NewInt(1000),
+	}
+	if err := policies.SetPolicy(peer, want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := policies.Policy(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected policy")
+	}
+	if got.DisableSwap != want.DisableSwap || got.ForcePseudosettle != want.ForcePseudosettle || got.PaymentThreshold.Cmp(want.PaymentThreshold) != 0 {
+		t.Fatalf("got policy %+v, want %+v", got, want)
+	}
+	if got.SwapAllowed() {
+		t.Fatal("expected swap not to be allowed")
+	}
+
+	all, err := policies.Policies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("got %d policies, want 1", len(all))
+	}
+	if _, ok := all[peer.String()]; !ok {
+		t.Fatalf("policy for peer %s not listed", peer)
+	}
+
+	if err := policies.DeletePolicy(peer); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err = policies.Policy(peer); err != nil || ok {
+		t.Fatalf("expected policy to be deleted, got ok %v err %v", ok, err)
+	}
+
+	if len(observer.notified) != 2 {
+		t.Fatalf("got %d notifications, want 2", len(observer.notified))
+	}
+}
+
+func TestPolicyStoreInvalidThreshold(t *testing.T) {
+	t.Parallel()
+
+	policies := settlement.NewPolicyStore(mock.NewStateStore())
+
+	err := policies.SetPolicy(swarm.MustParseHexAddress("abcd"), settlement.PeerPolicy{PaymentThreshold: big.NewInt(0)})
+	if !errors.Is(err, settlement.ErrInvalidPolicy) {
+		t.Fatalf("got error %v, want %v", err, settlement.ErrInvalidPolicy)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pseudosettle
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// allowanceConfigKey is the persistence key of the allowance configuration.
+const allowanceConfigKey = "pseudosettle_allowance_config"
+
+// ErrInvalidAllowanceConfig is the error returned if an allowance configuration is rejected.
+var ErrInvalidAllowanceConfig = errors.New("invalid allowance configuration")
+
+// Allowances manages the time based allowance granted to peers.
+type Allowances interface {
+	// AllowanceConfig returns the current allowance configuration.
+	AllowanceConfig() AllowanceConfig
+	// SetAllowanceConfig validates, persists and applies an allowance configuration.
+	SetAllowanceConfig(cfg AllowanceConfig) error
+	// PeerAllowance returns the allowance status of a connected peer.
+	PeerAllowance(peer swarm.Address) (AllowanceStatus, error)
+	// PeerAllowances returns the allowance status of all connected peers.
+	PeerAllowances() ([]AllowanceStatus, error)
+}
+
+// AllowanceConfig configures the time based allowance granted to peers.
+// Peers expect at least the network refresh rates, so lower rates lead to
+// payments being rejected by them.
+type AllowanceConfig struct {
+	RefreshRate      *big.Int      // allowance per second granted to full nodes
+	LightRefreshRate *big.Int      // allowance per second granted to light nodes
+	DecayWindow      time.Duration // unused allowance older than this expires, 0 disables the decay
+}
+
+// AllowanceStatus is the allowance of a peer at a point in time.
+type AllowanceStatus struct {
+	Peer            swarm.Address
+	FullNode        bool
+	LastRefreshment int64    // unix time of the last accepted refreshment, 0 if there was none
+	Available       *big.Int // time based allowance available to the peer, 0 before the first refreshment
+	TotalReceived   *big.Int // total amount received from the peer
+}
+
+func (c AllowanceConfig) validate() error {
+	if c.RefreshRate == nil || c.RefreshRate.Sign() <= 0 {
+		return fmt.Errorf("%w: refresh rate must be positive", ErrInvalidAllowanceConfig)
+	}
+	if c.LightRefreshRate == nil || c.LightRefreshRate.Sign() <= 0 {
+		return fmt.Errorf("%w: light refresh rate must be positive", ErrInvalidAllowanceConfig)
+	}
+	if c.DecayWindow < 0 {
+		return fmt.Errorf("%w: decay window must not be negative", ErrInvalidAllowanceConfig)
+	}
+	if c.DecayWindow > 0 && c.DecayWindow < time.Second {
+		return fmt.Errorf("%w: decay window must be at least one second", ErrInvalidAllowanceConfig)
+	}
+	return nil
+}
+
+// timeAllowance computes the allowance accrued during elapsed seconds.
+func (c AllowanceConfig) timeAllowance(elapsed int64, fullNode bool) *big.Int {
+	if window := int64(c.DecayWindow / time.Second); window > 0 && elapsed > window {
+		elapsed = window
+	}
+
+	refreshRate := c.LightRefreshRate
+	if fullNode {
+		refreshRate = c.RefreshRate
+	}
+
+	return new(big.Int).Mul(big.NewInt(elapsed), refreshRate)
+}
+
+// loadAllowanceConfig replaces the configured allowance with the persisted one, if any.
+func (s *Service) loadAllowanceConfig() {
+	var cfg AllowanceConfig
+	err := s.store.Get(allowanceConfigKey, &cfg)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.logger.Error(err, "failed to load allowance configuration")
+		}
+		return
+	}
+	if err := cfg.validate(); err != nil {
+		s.logger.Error(err, "ignoring persisted allowance configuration")
+		return
+	}
+
+	s.allowance = cfg
+	s.logger.Info("using persisted allowance configuration", "refresh_rate", cfg.RefreshRate, "light_refresh_rate", cfg.LightRefreshRate, "decay_window", cfg.DecayWindow)
+}
+
+// AllowanceConfig returns the current allowance configuration.
+func (s *Service) AllowanceConfig() AllowanceConfig {
+	s.allowanceMu.Lock()
+	defer s.allowanceMu.Unlock()
+
+	return AllowanceConfig{
+		RefreshRate:      new(big.Int).Set(s.allowance.RefreshRate),
+		LightRefreshRate: new(big.Int).Set(s.allowance.LightRefreshRate),
+		DecayWindow:      s.allowance.DecayWindow,
+	}
+}
+
+// SetAllowanceConfig validates, persists and applies an allowance configuration.
+func (s *Service) SetAllowanceConfig(cfg AllowanceConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	cfg = AllowanceConfig{
+		RefreshRate:      new(big.Int).Set(cfg.RefreshRate),
+		LightRefreshRate: new(big.Int).Set(cfg.LightRefreshRate),
+		DecayWindow:      cfg.DecayWindow,
+	}
+
+	s.allowanceMu.Lock()
+	defer s.allowanceMu.Unlock()
+
+	if err := s.store.Put(allowanceConfigKey, cfg); err != nil {
+		return err
+	}
+	s.allowance = cfg
+	return nil
+}
+
+// PeerAllowance returns the allowance status of a connected peer.
+func (s *Service) PeerAllowance(peer swarm.Address) (AllowanceStatus, error) {
+	s.peersMu.Lock()
+	peerData, ok := s.peers[peer.String()]
+	s.peersMu.Unlock()
+	if !ok {
+		return AllowanceStatus{}, ErrNoPseudoSettlePeer
+	}
+
+	return s.allowanceStatus(peer, peerData.fullNode)
+}
+
+// PeerAllowances returns the allowance status of all connected peers.
+func (s *Service) PeerAllowances() ([]AllowanceStatus, error) {
+	s.peersMu.Lock()
+	peers := make(map[string]bool, len(s.peers))
+	for peer, peerData := range s.peers {
+		peers[peer] = peerData.fullNode
+	}
+	s.peersMu.Unlock()
+
+	statuses := make([]AllowanceStatus, 0, len(peers))
+	for peer, fullNode := range peers {
+		addr, err := swarm.ParseHexAddress(peer)
+		if err != nil {
+			return nil, err
+		}
+		status, err := s.allowanceStatus(addr, fullNode)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *Service) allowanceStatus(peer swarm.Address, fullNode bool) (AllowanceStatus, error) {
+	var lastTime lastPayment
+	err := s.store.Get(totalKey(peer, SettlementReceivedPrefix), &lastTime)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return AllowanceStatus{}, err
+		}
+		lastTime.Total = big.NewInt(0)
+	}
+
+	// the allowance accrues from the first refreshment on
+	available := big.NewInt(0)
+	if lastTime.Timestamp != 0 {
+		available = s.AllowanceConfig().timeAllowance(s.timeNow().Unix()-lastTime.Timestamp, fullNode)
+	}
+
+	return AllowanceStatus{
+		Peer:            peer,
+		FullNode:        fullNode,
+		LastRefreshment: lastTime.Timestamp,
+		Available:       available,
+		TotalReceived:   lastTime.Total,
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pseudosettle_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/settlement/pseudosettle"
+	"github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestAllowanceConfig(t *testing.T) {
+	t.Parallel()
+
+	store := mock.NewStateStore()
+	observer := newTestObserver(map[string]*big.Int{}, map[string]*big.Int{})
+
+	service := pseudosettle.New(nil, log.Noop, store, observer, big.NewInt(100), big.NewInt(10), nil)
+
+	cfg := service.AllowanceConfig()
+	if cfg.RefreshRate.Int64() != 100 || cfg.LightRefreshRate.Int64() != 10 || cfg.DecayWindow != 0 {
+		t.Fatalf("unexpected default config %+v", cfg)
+	}
+
+	err := service.SetAllowanceConfig(pseudosettle.AllowanceConfig{
+		RefreshRate:      big.NewInt(0),
+		LightRefreshRate: big.NewInt(10),
+	})
+	if !errors.Is(err, pseudosettle.ErrInvalidAllowanceConfig) {
+		t.Fatalf("got error %v, want %v", err, pseudosettle.ErrInvalidAllowanceConfig)
+	}
+
+	want := pseudosettle.AllowanceConfig{
+		RefreshRate:      big.NewInt(200),
+		LightRefreshRate: big.NewInt(20),
+		DecayWindow:      time.Minute,
+	}
+	if err := service.SetAllowanceConfig(want); err != nil {
+		t.Fatal(err)
+	}
+
+	// a restarted service uses the persisted configuration
+	restarted := pseudosettle.New(nil, log.Noop, store, observer, big.NewInt(100), big.NewInt(10), nil)
+	cfg = restarted.AllowanceConfig()
+	if cfg.RefreshRate.Cmp(want.RefreshRate) != 0 || cfg.LightRefreshRate.Cmp(want.LightRefreshRate) != 0 || cfg.DecayWindow != want.DecayWindow {
+		t.Fatalf("got config %+v, want %+v", cfg, want)
+	}
+}
+
+func TestPeerAllowanceDecay(t *testing.T) {
+	t.Parallel()
+
+	store := mock.NewStateStore()
+	observer := newTestObserver(map[string]*big.Int{}, map[string]*big.Int{})
+	peer := swarm.MustParseHexAddress("9ee7add7")
+
+	service := pseudosettle.New(nil, log.Noop, store, observer, big.NewInt(100), big.NewInt(10), nil)
+	service.SetTime(1000)
+
+	_, err := service.PeerAllowance(peer)
+	if !errors.Is(err, pseudosettle.ErrNoPseudoSettlePeer) {
+		t.Fatalf("got error %v, want %v", err, pseudosettle.ErrNoPseudoSettlePeer)
+	}
+
+	if err := service.Init(context.Background(), p2p.Peer{Address: peer, FullNode: false}); err != nil {
+		t.Fatal(err)
+	}
+
+	// no allowance accrued before the first refreshment
+	status, err := service.PeerAllowance(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.FullNode || status.LastRefreshment != 0 || status.Available.Sign() != 0 {
+		t.Fatalf("got status %+v, want light node without allowance", status)
+	}
+
+	if err := service.SetLastRefreshment(peer, 900, big.NewInt(50)); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = service.PeerAllowance(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.LastRefreshment != 900 || status.TotalReceived.Int64() != 50 || status.Available.Int64() != 100*10 {
+		t.Fatalf("got status %+v, want allowance %d", status, 100*10)
+	}
+
+	err = service.SetAllowanceConfig(pseudosettle.AllowanceConfig{
+		RefreshRate:      big.NewInt(100),
+		LightRefreshRate: big.NewInt(10),
+		DecayWindow:      30 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := service.PeerAllowances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || !statuses[0].Peer.Equal(peer) {
+		t.Fatalf("got statuses %+v, want one for peer %s", statuses, peer)
+	}
+	// allowance older than the decay window expired
+	if statuses[0].Available.Int64() != 30*10 {
+		t.Fatalf("got allowance %d, want %d", statuses[0].Available, 30*10)
+	}
+}
@@ -6,9 +6,11 @@ package pseudosettle
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
 func (s *Service) SetTimeNow(f func() time.Time) {
@@ -28,3 +30,7 @@ func (s *Service) Init(ctx context.Context, peer p2p.Peer) error {
 func (s *Service) Terminate(peer p2p.Peer) error {
 	return s.terminate(peer)
 }
+
+func (s *Service) SetLastRefreshment(peer swarm.Address, timestamp int64, total *big.Int) error {
+	return s.store.Put(totalKey(peer, SettlementReceivedPrefix), lastPayment{Timestamp: timestamp, Total: total})
+}
@@ -46,17 +46,17 @@ var (
 )
 
 type Service struct {
-	streamer         p2p.Streamer
-	logger           log.Logger
-	store            storage.StateStorer
-	accounting       settlement.Accounting
-	metrics          metrics
-	refreshRate      *big.Int
-	lightRefreshRate *big.Int
-	p2pService       p2p.Service
-	timeNow          func() time.Time
-	peersMu          sync.Mutex
-	peers            map[string]*pseudoSettlePeer
+	streamer    p2p.Streamer
+	logger      log.Logger
+	store       storage.StateStorer
+	accounting  settlement.Accounting
+	metrics     metrics
+	allowanceMu sync.Mutex
+	allowance   AllowanceConfig
+	p2pService  p2p.Service
+	timeNow     func() time.Time
+	peersMu     sync.Mutex
+	peers       map[string]*pseudoSettlePeer
 }
 
 type pseudoSettlePeer struct {
@@ -70,19 +70,25 @@ type lastPayment struct {
 	Total          *big.Int
 }
 
+// New creates a new pseudosettle service. The given refresh rates are used
+// unless an allowance configuration was persisted with SetAllowanceConfig.
 func New(streamer p2p.Streamer, logger log.Logger, store storage.StateStorer, accounting settlement.Accounting, refreshRate, lightRefreshRate *big.Int, p2pService p2p.Service) *Service {
-	return &Service{
-		streamer:         streamer,
-		logger:           logger.WithName(loggerName).Register(),
-		metrics:          newMetrics(),
-		store:            store,
-		accounting:       accounting,
-		p2pService:       p2pService,
-		refreshRate:      refreshRate,
-		lightRefreshRate: lightRefreshRate,
-		timeNow:          time.Now,
-		peers:            make(map[string]*pseudoSettlePeer),
+	s := &Service{
+		streamer:   streamer,
+		logger:     logger.WithName(loggerName).Register(),
+		metrics:    newMetrics(),
+		store:      store,
+		accounting: accounting,
+		p2pService: p2pService,
+		allowance: AllowanceConfig{
+			RefreshRate:      refreshRate,
+			LightRefreshRate: lightRefreshRate,
+		},
+		timeNow: time.Now,
+		peers:   make(map[string]*pseudoSettlePeer),
 	}
+	s.loadAllowanceConfig()
+	return s
 }
 
 func (s *Service) Protocol() p2p.ProtocolSpec {
@@ -157,15 +163,7 @@ func (s *Service) peerAllowance(peer swarm.Address, fullNode bool) (limit *big.I
 		return nil, 0, ErrSettlementTooSoon
 	}
 
-	var refreshRateUsed *big.Int
-
-	if fullNode {
-		refreshRateUsed = s.refreshRate
-	} else {
-		refreshRateUsed = s.lightRefreshRate
-	}
-
-	maxAllowance := new(big.Int).Mul(big.NewInt(currentTime-lastTime.Timestamp), refreshRateUsed)
+	maxAllowance := s.AllowanceConfig().timeAllowance(currentTime-lastTime.Timestamp, fullNode)
 
 	peerDebt, err := s.accounting.PeerDebt(peer)
 	if err != nil {
@@ -7,7 +7,11 @@ package swap
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,8 +24,17 @@ var (
 	peerBeneficiaryPrefix = "swap_peer_beneficiary_"
 	deductedForPeerPrefix = "swap_deducted_for_peer_"
 	deductedByPeerPrefix  = "swap_deducted_by_peer_"
+	peerLastSeenPrefix    = "swap_peer_last_seen_"
 )
 
+// AddressbookEntry is the exported addressbook information of a single peer.
+type AddressbookEntry struct {
+	Peer        swarm.Address   `json:"peer"`
+	Beneficiary *common.Address `json:"beneficiary,omitempty"`
+	Chequebook  *common.Address `json:"chequebook,omitempty"`
+	LastSeen    int64           `json:"lastSeen,omitempty"`
+}
+
 // Addressbook maps peers to beneficaries, chequebooks and in reverse.
 type Addressbook interface {
 	// Beneficiary returns the beneficiary for the given peer.
@@ -46,16 +59,31 @@ type Addressbook interface {
 	GetDeductionBy(peer swarm.Address) (bool, error)
 	// MigratePeer returns whether a peer have already received a cheque that has been deducted
 	MigratePeer(oldPeer, newPeer swarm.Address) error
+	// Touch records the peer as seen now.
+	Touch(peer swarm.Address) error
+	// Entry returns the addressbook entry for the given peer.
+	Entry(peer swarm.Address) (entry AddressbookEntry, known bool, err error)
+	// Export returns the entries for all known peers.
+	Export() ([]AddressbookEntry, error)
+	// Import stores the given entries, overwriting existing mappings of the peers.
+	Import(entries []AddressbookEntry) error
+	// Remove deletes the beneficiary and chequebook mapping of the peer.
+	Remove(peer swarm.Address) error
+	// Prune removes all the records of peers not seen for longer than maxAge,
+	// except the ones keep reports to be kept, and returns their number.
+	Prune(maxAge time.Duration, keep func(peer swarm.Address) (bool, error)) (int, error)
 }
 
 type addressbook struct {
 	store storage.StateStorer
+	now   func() time.Time
 }
 
 // NewAddressbook creates a new addressbook using the store.
 func NewAddressbook(store storage.StateStorer) Addressbook {
 	return &addressbook{
 		store: store,
+		now:   time.Now,
 	}
 }
 
@@ -191,6 +219,259 @@ func (a *addressbook) GetDeductionBy(peer swarm.Address) (bool, error) {
 	return true, nil
 }
 
+// Touch records the peer as seen now.
+func (a *addressbook) Touch(peer swarm.Address) error {
+	return a.store.Put(peerLastSeenKey(peer), a.now().Unix())
+}
+
+func (a *addressbook) lastSeen(peer swarm.Address) (int64, error) {
+	var lastSeen int64
+	err := a.store.Get(peerLastSeenKey(peer), &lastSeen)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return 0, err
+	}
+	return lastSeen, nil
+}
+
+// Entry returns the addressbook entry for the given peer.
+func (a *addressbook) Entry(peer swarm.Address) (entry AddressbookEntry, known bool, err error) {
+	entry.Peer = peer
+
+	beneficiary, beneficiaryKnown, err := a.Beneficiary(peer)
+	if err != nil {
+		return AddressbookEntry{}, false, err
+	}
+	if beneficiaryKnown {
+		entry.Beneficiary = &beneficiary
+	}
+
+	chequebook, chequebookKnown, err := a.Chequebook(peer)
+	if err != nil {
+		return AddressbookEntry{}, false, err
+	}
+	if chequebookKnown {
+		entry.Chequebook = &chequebook
+	}
+
+	if !beneficiaryKnown && !chequebookKnown {
+		return AddressbookEntry{}, false, nil
+	}
+
+	entry.LastSeen, err = a.lastSeen(peer)
+	if err != nil {
+		return AddressbookEntry{}, false, err
+	}
+
+	return entry, true, nil
+}
+
+// Export returns the entries for all known peers.
+func (a *addressbook) Export() ([]AddressbookEntry, error) {
+	peers := make(map[string]swarm.Address)
+	for _, prefix := range []string{peerBeneficiaryPrefix, peerPrefix} {
+		err := a.store.Iterate(prefix, func(key, _ []byte) (stop bool, err error) {
+			peer, err := swarm.ParseHexAddress(strings.TrimPrefix(string(key), prefix))
+			if err != nil {
+				return false, fmt.Errorf("parse address from key: %s: %w", string(key), err)
+			}
+			peers[peer.ByteString()] = peer
+			return false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]AddressbookEntry, 0, len(peers))
+	for _, peer := range peers {
+		entry, known, err := a.Entry(peer)
+		if err != nil {
+			return nil, err
+		}
+		if known {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// Import stores the given entries, overwriting existing mappings of the peers.
+// Entries without a last seen time are treated as seen now.
+func (a *addressbook) Import(entries []AddressbookEntry) error {
+	for _, entry := range entries {
+		if entry.Beneficiary != nil {
+			if err := a.PutBeneficiary(entry.Peer, *entry.Beneficiary); err != nil {
+				return err
+			}
+		}
+		if entry.Chequebook != nil {
+			if err := a.PutChequebook(entry.Peer, *entry.Chequebook); err != nil {
+				return err
+			}
+		}
+
+		lastSeen := entry.LastSeen
+		if lastSeen == 0 {
+			lastSeen = a.now().Unix()
+		}
+		current, err := a.lastSeen(entry.Peer)
+		if err != nil {
+			return err
+		}
+		if lastSeen > current {
+			if err := a.store.Put(peerLastSeenKey(entry.Peer), lastSeen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Remove deletes the beneficiary and chequebook mapping of the peer.
+func (a *addressbook) Remove(peer swarm.Address) error {
+	beneficiary, known, err := a.Beneficiary(peer)
+	if err != nil {
+		return err
+	}
+	if known {
+		if err := a.store.Delete(beneficiaryPeerKey(beneficiary)); err != nil {
+			return err
+		}
+		if err := a.store.Delete(peerBeneficiaryKey(peer)); err != nil {
+			return err
+		}
+	}
+
+	chequebook, known, err := a.Chequebook(peer)
+	if err != nil {
+		return err
+	}
+	if known {
+		if err := a.store.Delete(chequebookPeerKey(chequebook)); err != nil {
+			return err
+		}
+		if err := a.store.Delete(peerKey(peer)); err != nil {
+			return err
+		}
+	}
+
+	return a.store.Delete(peerLastSeenKey(peer))
+}
+
+// Prune removes all the records of peers not seen for longer than maxAge,
+// except the ones keep reports to be kept, which are given maxAge from now.
+// Peers which were never seen are given maxAge from the first pruning run.
+func (a *addressbook) Prune(maxAge time.Duration, keep func(peer swarm.Address) (bool, error)) (int, error) {
+	entries, err := a.Export()
+	if err != nil {
+		return 0, err
+	}
+
+	now := a.now()
+	pruned := 0
+	for _, entry := range entries {
+		if entry.LastSeen == 0 {
+			if err := a.Touch(entry.Peer); err != nil {
+				return pruned, err
+			}
+			continue
+		}
+		if now.Sub(time.Unix(entry.LastSeen, 0)) <= maxAge {
+			continue
+		}
+		if keep != nil {
+			kept, err := keep(entry.Peer)
+			if err != nil {
+				return pruned, err
+			}
+			if kept {
+				if err := a.Touch(entry.Peer); err != nil {
+					return pruned, err
+				}
+				continue
+			}
+		}
+		if err := a.Remove(entry.Peer); err != nil {
+			return pruned, err
+		}
+		for _, key := range []string{peerDeductedForKey(entry.Peer), peerDeductedByKey(entry.Peer)} {
+			if err := a.store.Delete(key); err != nil {
+				return pruned, err
+			}
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// AddressbookPruner periodically prunes stale entries from the addressbook.
+type AddressbookPruner struct {
+	logger      log.Logger
+	addressbook Addressbook
+	keep        func(peer swarm.Address) (bool, error)
+	connected   func() []swarm.Address
+	maxAge      time.Duration
+	interval    time.Duration
+	quit        chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewAddressbookPruner creates a new AddressbookPruner removing entries of
+// peers not seen for longer than maxAge every interval. The connected peers
+// are recorded as seen on every run and the peers keep reports to be kept,
+// such as the ones with uncashed cheques, are not removed.
+func NewAddressbookPruner(logger log.Logger, addressbook Addressbook, keep func(peer swarm.Address) (bool, error), connected func() []swarm.Address, maxAge, interval time.Duration) *AddressbookPruner {
+	return &AddressbookPruner{
+		logger:      logger.WithName(loggerName).Register(),
+		addressbook: addressbook,
+		keep:        keep,
+		connected:   connected,
+		maxAge:      maxAge,
+		interval:    interval,
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start starts pruning in the background.
+func (p *AddressbookPruner) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			for _, peer := range p.connected() {
+				if err := p.addressbook.Touch(peer); err != nil {
+					p.logger.Debug("touch connected peer failed", "peer_address", peer, "error", err)
+				}
+			}
+
+			pruned, err := p.addressbook.Prune(p.maxAge, p.keep)
+			if err != nil {
+				p.logger.Error(err, "pruning swap addressbook failed")
+			} else if pruned > 0 {
+				p.logger.Debug("pruned swap addressbook", "entries", pruned)
+			}
+
+			select {
+			case <-p.quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Close stops the pruner.
+func (p *AddressbookPruner) Close() error {
+	close(p.quit)
+	p.wg.Wait()
+	return nil
+}
+
 // peerKey computes the key where to store the chequebook from a peer.
 func peerKey(peer swarm.Address) string {
 	return fmt.Sprintf("%s%s", peerPrefix, peer)
@@ -211,6 +492,11 @@ func beneficiaryPeerKey(peer common.Address) string {
 	return fmt.Sprintf("%s%x", beneficiaryPeerPrefix, peer)
 }
 
+// peerLastSeenKey computes the key where to store the time a peer was last seen.
+func peerLastSeenKey(peer swarm.Address) string {
+	return fmt.Sprintf("%s%s", peerLastSeenPrefix, peer)
+}
+
 func peerDeductedByKey(peer swarm.Address) string {
 	return fmt.Sprintf("%s%s", deductedByPeerPrefix, peer.String())
 }
@@ -0,0 +1,141 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	mockstore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAddressbookExportImport(t *testing.T) {
+	t.Parallel()
+
+	addressbook := swap.NewAddressbook(mockstore.NewStateStore())
+	now := time.Unix(1_000_000, 0)
+	swap.SetAddressbookTimeNow(addressbook, func() time.Time { return now })
+
+	peer := swarm.MustParseHexAddress("abcd")
+	beneficiary := common.HexToAddress("0xab")
+	chequebook := common.HexToAddress("0xcd")
+
+	if err := addressbook.PutBeneficiary(peer, beneficiary); err != nil {
+		t.Fatal(err)
+	}
+	if err := addressbook.PutChequebook(peer, chequebook); err != nil {
+		t.Fatal(err)
+	}
+	if err := addressbook.Touch(peer); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := addressbook.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if !entry.Peer.Equal(peer) || entry.Beneficiary == nil || *entry.Beneficiary != beneficiary || entry.Chequebook == nil || *entry.Chequebook != chequebook || entry.LastSeen != now.Unix() {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	imported := swap.NewAddressbook(mockstore.NewStateStore())
+	if err := imported.Import(entries); err != nil {
+		t.Fatal(err)
+	}
+
+	gotPeer, known, err := imported.ChequebookPeer(chequebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !known || !gotPeer.Equal(peer) {
+		t.Fatalf("got chequebook peer %v (known %v), want %v", gotPeer, known, peer)
+	}
+
+	gotPeer, known, err = imported.BeneficiaryPeer(beneficiary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !known || !gotPeer.Equal(peer) {
+		t.Fatalf("got beneficiary peer %v (known %v), want %v", gotPeer, known, peer)
+	}
+}
+
+func TestAddressbookPrune(t *testing.T) {
+	t.Parallel()
+
+	addressbook := swap.NewAddressbook(mockstore.NewStateStore())
+	now := time.Unix(1_000_000, 0)
+	swap.SetAddressbookTimeNow(addressbook, func() time.Time { return now })
+
+	stale := swarm.MustParseHexAddress("aa")
+	fresh := swarm.MustParseHexAddress("bb")
+	unseen := swarm.MustParseHexAddress("cc")
+	kept := swarm.MustParseHexAddress("dd")
+
+	beneficiaries := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03"), common.HexToAddress("0x04")}
+	for i, peer := range []swarm.Address{stale, fresh, unseen, kept} {
+		if err := addressbook.PutBeneficiary(peer, beneficiaries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := addressbook.AddDeductionFor(stale); err != nil {
+		t.Fatal(err)
+	}
+	if err := addressbook.AddDeductionBy(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, peer := range []swarm.Address{stale, kept} {
+		if err := addressbook.Touch(peer); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now = now.Add(2 * time.Hour)
+	if err := addressbook.Touch(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	keep := func(peer swarm.Address) (bool, error) {
+		return peer.Equal(kept), nil
+	}
+	pruned, err := addressbook.Prune(time.Hour, keep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != 1 {
+		t.Fatalf("got %d pruned entries, want 1", pruned)
+	}
+
+	if _, known, err := addressbook.Beneficiary(stale); err != nil || known {
+		t.Fatalf("expected stale peer to be pruned, known %v err %v", known, err)
+	}
+	if deducted, err := addressbook.GetDeductionFor(stale); err != nil || deducted {
+		t.Fatalf("expected deduction for stale peer to be pruned, deducted %v err %v", deducted, err)
+	}
+	if deducted, err := addressbook.GetDeductionBy(stale); err != nil || deducted {
+		t.Fatalf("expected deduction by stale peer to be pruned, deducted %v err %v", deducted, err)
+	}
+	for _, peer := range []swarm.Address{fresh, unseen, kept} {
+		if _, known, err := addressbook.Beneficiary(peer); err != nil || !known {
+			t.Fatalf("expected peer %s to be kept, known %v err %v", peer, known, err)
+		}
+	}
+
+	// the kept peer is given the maximum age from now
+	entry, _, err := addressbook.Entry(kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.LastSeen != now.Unix() {
+		t.Fatalf("got last seen %d, want %d", entry.LastSeen, now.Unix())
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CashoutWatcher periodically checks the status of the submitted cashouts
+// until they are final, so that bounced cheques are credited back and
+// confirmations are reported without the cashout status being requested.
+type CashoutWatcher struct {
+	service  *Service
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewCashoutWatcher creates a new CashoutWatcher checking the cashouts of the
+// service every interval. The cashouts submitted before a restart are
+// resumed as they are persisted in the state store.
+func NewCashoutWatcher(service *Service, interval time.Duration) *CashoutWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &CashoutWatcher{
+		service:  service,
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start starts watching in the background.
+func (w *CashoutWatcher) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			w.service.checkCashouts(w.ctx)
+		}
+	}()
+}
+
+// Close stops the watcher.
+func (w *CashoutWatcher) Close() error {
+	w.cancel()
+	w.wg.Wait()
+	return nil
+}
@@ -22,6 +22,8 @@ import (
 var (
 	// ErrNoCashout is the error if there has not been any cashout action for the chequebook
 	ErrNoCashout = errors.New("no prior cashout")
+	// ErrLegacyCheque is the error if the cheque to cash out was signed in a legacy domain
+	ErrLegacyCheque = errors.New("cheque signed in a legacy domain")
 )
 
 // CashoutService is the service responsible for managing cashout actions
@@ -45,6 +47,7 @@ type LastCashout struct {
 	Cheque   SignedCheque // the cheque that was used to cashout which may be different from the latest cheque
 	Result   *CashChequeResult
 	Reverted bool
+	Unpaid   *big.Int // part of the cashed cheque not paid out because the cashout bounced, a reverted cheque can still be cashed
 }
 
 // CashoutStatus is information about the last cashout and uncashed amounts
@@ -66,8 +69,9 @@ type CashChequeResult struct {
 
 // cashoutAction is the data we store for a cashout
 type cashoutAction struct {
-	TxHash common.Hash
-	Cheque SignedCheque // the cheque that was used to cashout which may be different from the latest cheque
+	TxHash         common.Hash
+	Cheque         SignedCheque // the cheque that was used to cashout which may be different from the latest cheque
+	PreviousPayout *big.Int     // cumulative payout of the cheque used in the previous cashout
 }
 type chequeCashedEvent struct {
 	Beneficiary      common.Address
@@ -136,6 +140,15 @@ func (s *cashoutService) CashCheque(ctx context.Context, chequebook, recipient c
 		return common.Hash{}, err
 	}
 
+	// the chequebook contract verifies the cheque in the current domain only
+	legacy, err := s.chequeStore.LastChequeLegacy(ctx, chequebook)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if legacy {
+		return common.Hash{}, ErrLegacyCheque
+	}
+
 	callData, err := chequebookABI.Pack("cashChequeBeneficiary", recipient, cheque.CumulativePayout, cheque.Signature)
 	if err != nil {
 		return common.Hash{}, err
@@ -149,14 +162,30 @@ func (s *cashoutService) CashCheque(ctx context.Context, chequebook, recipient c
 		Description: "cheque cashout",
 	}
 
+	previousPayout := big.NewInt(0)
+	var previousAction cashoutAction
+	err = s.store.Get(cashoutActionKey(chequebook), &previousAction)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return common.Hash{}, err
+		}
+	} else {
+		previousPayout = previousAction.Cheque.CumulativePayout
+	}
+
+	if err := s.transactionService.Simulate(ctx, request, chequebookABI.Errors); err != nil {
+		return common.Hash{}, err
+	}
+
 	txHash, err := s.transactionService.Send(ctx, request, transaction.DefaultTipBoostPercent)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
 	err = s.store.Put(cashoutActionKey(chequebook), &cashoutAction{
-		TxHash: txHash,
-		Cheque: *cheque,
+		TxHash:         txHash,
+		Cheque:         *cheque,
+		PreviousPayout: previousPayout,
 	})
 	if err != nil {
 		return common.Hash{}, err
@@ -235,12 +264,20 @@ func (s *cashoutService) CashoutStatus(ctx context.Context, chequebookAddress co
 		return nil, err
 	}
 
+	var unpaid *big.Int
+	if result.Bounced && action.PreviousPayout != nil {
+		// the chequebook marks the full cheque value as paid out even if it could only cover part of it
+		unpaid = new(big.Int).Sub(action.Cheque.CumulativePayout, action.PreviousPayout)
+		unpaid.Sub(unpaid, result.TotalPayout)
+	}
+
 	return &CashoutStatus{
 		Last: &LastCashout{
 			TxHash:   action.TxHash,
 			Cheque:   action.Cheque,
 			Result:   result,
 			Reverted: false,
+			Unpaid:   unpaid,
 		},
 		// uncashed is the difference since the last sent (and confirmed) cashout.
 		UncashedAmount: new(big.Int).Sub(cheque.CumulativePayout, result.CumulativePayout),
@@ -6,6 +6,7 @@ package chequebook_test
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -127,6 +128,37 @@ func TestCashout(t *testing.T) {
 	})
 }
 
+func TestCashoutLegacyCheque(t *testing.T) {
+	t.Parallel()
+
+	chequebookAddress := common.HexToAddress("abcd")
+
+	cashoutService := chequebook.NewCashoutService(
+		storemock.NewStateStore(),
+		backendmock.New(),
+		transactionmock.New(),
+		chequestoremock.NewChequeStore(
+			chequestoremock.WithLastChequeFunc(func(c common.Address) (*chequebook.SignedCheque, error) {
+				return &chequebook.SignedCheque{
+					Cheque: chequebook.Cheque{
+						Beneficiary:      common.HexToAddress("aaaa"),
+						CumulativePayout: big.NewInt(500),
+						Chequebook:       chequebookAddress,
+					},
+				}, nil
+			}),
+			chequestoremock.WithLastChequeLegacyFunc(func(c common.Address) (bool, error) {
+				return true, nil
+			}),
+		),
+	)
+
+	_, err := cashoutService.CashCheque(context.Background(), chequebookAddress, common.HexToAddress("efff"))
+	if !errors.Is(err, chequebook.ErrLegacyCheque) {
+		t.Fatalf("got error %v, want %v", err, chequebook.ErrLegacyCheque)
+	}
+}
+
 func TestCashoutBounced(t *testing.T) {
 	t.Parallel()
 
@@ -227,6 +259,7 @@ func TestCashoutBounced(t *testing.T) {
 				Bounced:          true,
 			},
 			Reverted: false,
+			Unpaid:   new(big.Int).Sub(cumulativePayout, totalPayout),
 		},
 		UncashedAmount: big.NewInt(0),
 	})
@@ -401,6 +434,14 @@ func verifyStatus(t *testing.T, status *chequebook.CashoutStatus, expected chequ
 				t.Fatalf("wrong result. wanted %v, got %v", expected.Last.Result, status.Last.Result)
 			}
 		}
+
+		if expected.Last.Unpaid == nil {
+			if status.Last.Unpaid != nil {
+				t.Fatalf("unexpected unpaid amount %d", status.Last.Unpaid)
+			}
+		} else if status.Last.Unpaid == nil || status.Last.Unpaid.Cmp(expected.Last.Unpaid) != 0 {
+			t.Fatalf("wrong unpaid amount. wanted %d, got %d", expected.Last.Unpaid, status.Last.Unpaid)
+		}
 	}
 
 	if status.UncashedAmount.Cmp(expected.UncashedAmount) != 0 {
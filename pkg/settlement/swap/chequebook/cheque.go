@@ -28,12 +28,12 @@ type SignedCheque struct {
 	Signature []byte
 }
 
-// chequebookDomain computes chainId-dependent EIP712 domain
-func chequebookDomain(chainID int64) eip712.TypedDataDomain {
+// chequebookDomain computes the version and chainId dependent EIP712 domain
+func chequebookDomain(domain ChequeDomain) eip712.TypedDataDomain {
 	return eip712.TypedDataDomain{
 		Name:    "Chequebook",
-		Version: "1.0",
-		ChainId: math.NewHexOrDecimal256(chainID),
+		Version: domain.Version,
+		ChainId: math.NewHexOrDecimal256(domain.ChainID),
 	}
 }
 
@@ -63,22 +63,27 @@ type ChequeSigner interface {
 }
 
 type chequeSigner struct {
-	signer  crypto.Signer // the underlying signer used
-	chainID int64         // the chainID used for EIP712
+	signer crypto.Signer // the underlying signer used
+	domain ChequeDomain  // the domain used for EIP712
 }
 
 // NewChequeSigner creates a new cheque signer for the given chainID.
 func NewChequeSigner(signer crypto.Signer, chainID int64) ChequeSigner {
+	return NewDomainChequeSigner(signer, DefaultChequeDomain(chainID))
+}
+
+// NewDomainChequeSigner creates a new cheque signer for the given domain.
+func NewDomainChequeSigner(signer crypto.Signer, domain ChequeDomain) ChequeSigner {
 	return &chequeSigner{
-		signer:  signer,
-		chainID: chainID,
+		signer: signer,
+		domain: domain,
 	}
 }
 
 // eip712DataForCheque converts a cheque into the correct TypedData structure.
-func eip712DataForCheque(cheque *Cheque, chainID int64) *eip712.TypedData {
+func eip712DataForCheque(cheque *Cheque, domain ChequeDomain) *eip712.TypedData {
 	return &eip712.TypedData{
-		Domain: chequebookDomain(chainID),
+		Domain: chequebookDomain(domain),
 		Types:  ChequeTypes,
 		Message: eip712.TypedDataMessage{
 			"chequebook":       cheque.Chequebook.Hex(),
@@ -91,7 +96,7 @@ func eip712DataForCheque(cheque *Cheque, chainID int64) *eip712.TypedData {
 
 // Sign signs a cheque.
 func (s *chequeSigner) Sign(cheque *Cheque) ([]byte, error) {
-	return s.signer.SignTypedData(eip712DataForCheque(cheque, s.chainID))
+	return s.signer.SignTypedData(eip712DataForCheque(cheque, s.domain))
 }
 
 func (cheque *Cheque) String() string {
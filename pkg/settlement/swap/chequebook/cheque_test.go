@@ -106,3 +106,35 @@ func TestSignChequeIntegration(t *testing.T) {
 		t.Fatalf("returned wrong signature. wanted %x, got %x", expectedSignature, result)
 	}
 }
+
+func TestSignChequeDomain(t *testing.T) {
+	t.Parallel()
+
+	domain := chequebook.ChequeDomain{Version: "2.0", ChainID: 728126428}
+	signature := common.Hex2Bytes("abcd")
+
+	signer := signermock.New(
+		signermock.WithSignTypedDataFunc(func(data *eip712.TypedData) ([]byte, error) {
+			if data.Domain.Version != domain.Version {
+				t.Fatalf("signing cheque with wrong domain version. wanted %s, got %s", domain.Version, data.Domain.Version)
+			}
+			if chainID := (*big.Int)(data.Domain.ChainId).Int64(); chainID != domain.ChainID {
+				t.Fatalf("signing cheque with wrong chain id. wanted %d, got %d", domain.ChainID, chainID)
+			}
+			return signature, nil
+		}),
+	)
+
+	result, err := chequebook.NewDomainChequeSigner(signer, domain).Sign(&chequebook.Cheque{
+		Chequebook:       common.HexToAddress("0xeeee"),
+		Beneficiary:      common.HexToAddress("0xffff"),
+		CumulativePayout: big.NewInt(10),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(result, signature) {
+		t.Fatalf("returned wrong signature. wanted %x, got %x", signature, result)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 
 	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
@@ -200,17 +201,13 @@ func TestChequebookIssue(t *testing.T) {
 	sig := common.Hex2Bytes("0xffff")
 	chequeSigner := &chequeSignerMock{}
 
+	simulator := transactionmock.NewSimulator()
+	contract := simulator.Contract(address, &chequebookABI)
+	contract.Set("balance", big.NewInt(100))
+	contract.Set("totalPaidOut", big.NewInt(0))
+
 	chequebookService, err := chequebook.New(
-		transactionmock.New(
-			transactionmock.WithABICallSequence(
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(100).FillBytes(make([]byte, 32)), "balance"),
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(0).FillBytes(make([]byte, 32)), "totalPaidOut"),
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(100).FillBytes(make([]byte, 32)), "balance"),
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(0).FillBytes(make([]byte, 32)), "totalPaidOut"),
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(100).FillBytes(make([]byte, 32)), "balance"),
-				transactionmock.ABICall(&chequebookABI, address, big.NewInt(0).FillBytes(make([]byte, 32)), "totalPaidOut"),
-			),
-		),
+		transactionmock.New(transactionmock.WithSimulator(simulator)),
 		address,
 		ownerAdress,
 		store,
@@ -491,6 +488,58 @@ func TestChequebookWithdrawInsufficientFunds(t *testing.T) {
 	}
 }
 
+func TestChequebookWithdrawUpdatesBalance(t *testing.T) {
+	t.Parallel()
+
+	address := common.HexToAddress("0xabcd")
+	ownerAdress := common.HexToAddress("0xfff")
+	withdrawAmount := big.NewInt(20)
+
+	var mu sync.Mutex
+	balance := big.NewInt(30)
+
+	simulator := transactionmock.NewSimulator()
+	contract := simulator.Contract(address, &chequebookABI)
+	contract.Set("totalPaidOut", big.NewInt(0))
+	contract.Handle("balance", func([]interface{}) ([]interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return []interface{}{new(big.Int).Set(balance)}, nil
+	})
+	contract.OnSend("withdraw", func(args []interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		balance.Sub(balance, args[0].(*big.Int))
+		return nil
+	})
+
+	chequebookService, err := chequebook.New(
+		transactionmock.New(transactionmock.WithSimulator(simulator)),
+		address,
+		ownerAdress,
+		storemock.NewStateStore(),
+		&chequeSignerMock{},
+		erc20mock.New(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := chequebookService.Withdraw(context.Background(), withdrawAmount); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first withdrawal left only 10 in the chequebook
+	_, err = chequebookService.Withdraw(context.Background(), withdrawAmount)
+	if !errors.Is(err, chequebook.ErrInsufficientFunds) {
+		t.Fatalf("got wrong error. wanted %v, got %v", chequebook.ErrInsufficientFunds, err)
+	}
+
+	if sent := simulator.Sent(); len(sent) != 1 {
+		t.Fatalf("got %d sent transactions, want 1", len(sent))
+	}
+}
+
 func TestStateStoreKeys(t *testing.T) {
 	t.Parallel()
 
@@ -21,6 +21,10 @@ import (
 const (
 	// prefix for the persistence key
 	lastReceivedChequePrefix = "swap_chequebook_last_received_cheque_"
+	// prefix for the bounced cheque persistence key
+	bouncedChequePrefix = "swap_chequebook_bounced_cheque_"
+	// prefix for the persistence key of the domain of the last received cheque
+	receivedChequeDomainPrefix = "swap_chequebook_received_cheque_domain_"
 )
 
 var (
@@ -46,32 +50,59 @@ type ChequeStore interface {
 	LastCheque(chequebook common.Address) (*SignedCheque, error)
 	// LastCheques returns the last received cheques from every known chequebook.
 	LastCheques() (map[common.Address]*SignedCheque, error)
+	// MarkBounced records that the cashout transaction txHash of a cheque
+	// bounced, leaving amount unpaid. It returns false if this cashout was
+	// already recorded as bounced.
+	MarkBounced(txHash common.Hash, cheque *SignedCheque, amount *big.Int) (bool, error)
+	// BouncedCheque returns the last bounced cheque of a specific chequebook.
+	BouncedCheque(chequebook common.Address) (*BouncedCheque, error)
+	// LastChequeLegacy reports whether the last cheque received from a
+	// chequebook was signed in a legacy domain, before a migration.
+	LastChequeLegacy(ctx context.Context, chequebook common.Address) (bool, error)
+}
+
+// BouncedCheque is a received cheque whose cashout bounced.
+type BouncedCheque struct {
+	TxHash common.Hash  // hash of the bounced cashout transaction
+	Cheque SignedCheque // the cheque that was cashed
+	Amount *big.Int     // amount of the cheque left unpaid
 }
 
 type chequeStore struct {
 	lock               sync.Mutex
 	store              storage.StateStorer
 	factory            Factory
-	chaindID           int64
+	domain             ChequeDomain   // the domain cheques are issued in
+	legacyDomains      []ChequeDomain // the domains cheques were issued in before a migration
 	transactionService transaction.Service
 	beneficiary        common.Address // the beneficiary we expect in cheques sent to us
 	recoverChequeFunc  RecoverChequeFunc
 }
 
-type RecoverChequeFunc func(cheque *SignedCheque, chainID int64) (common.Address, error)
+type RecoverChequeFunc func(cheque *SignedCheque, domain ChequeDomain) (common.Address, error)
 
-// NewChequeStore creates new ChequeStore
+// NewChequeStore creates new ChequeStore. Received cheques are verified in
+// the given domain or, if they were issued before a migration, in one of the
+// legacyDomains. The domain of every received cheque is stored with it.
 func NewChequeStore(
 	store storage.StateStorer,
 	factory Factory,
-	chainID int64,
+	domain ChequeDomain,
 	beneficiary common.Address,
 	transactionService transaction.Service,
-	recoverChequeFunc RecoverChequeFunc) ChequeStore {
+	recoverChequeFunc RecoverChequeFunc,
+	legacyDomains ...ChequeDomain) ChequeStore {
+	var legacy []ChequeDomain
+	for _, d := range legacyDomains {
+		if d != domain {
+			legacy = append(legacy, d)
+		}
+	}
 	return &chequeStore{
 		store:              store,
 		factory:            factory,
-		chaindID:           chainID,
+		domain:             domain,
+		legacyDomains:      legacy,
 		transactionService: transactionService,
 		beneficiary:        beneficiary,
 		recoverChequeFunc:  recoverChequeFunc,
@@ -83,6 +114,16 @@ func lastReceivedChequeKey(chequebook common.Address) string {
 	return fmt.Sprintf("%s_%x", lastReceivedChequePrefix, chequebook)
 }
 
+// receivedChequeDomainKey computes the key where to store the domain of the last cheque received from a chequebook.
+func receivedChequeDomainKey(chequebook common.Address) string {
+	return fmt.Sprintf("%s_%x", receivedChequeDomainPrefix, chequebook)
+}
+
+// bouncedChequeKey computes the key where to store the last bounced cheque of a chequebook.
+func bouncedChequeKey(chequebook common.Address) string {
+	return fmt.Sprintf("%s_%x", bouncedChequePrefix, chequebook)
+}
+
 // LastCheque returns the last cheque we received from a specific chequebook.
 func (s *chequeStore) LastCheque(chequebook common.Address) (*SignedCheque, error) {
 	var cheque *SignedCheque
@@ -152,15 +193,11 @@ func (s *chequeStore) ReceiveCheque(ctx context.Context, cheque *SignedCheque, e
 	}
 
 	// verify the cheque signature
-	issuer, err := s.recoverChequeFunc(cheque, s.chaindID)
+	domain, err := s.verifyDomain(cheque, expectedIssuer)
 	if err != nil {
 		return nil, err
 	}
 
-	if issuer != expectedIssuer {
-		return nil, ErrChequeInvalid
-	}
-
 	// basic liquidity check
 	// could be omitted as it is not particularly useful
 	balance, err := contract.Balance(ctx)
@@ -177,7 +214,11 @@ func (s *chequeStore) ReceiveCheque(ctx context.Context, cheque *SignedCheque, e
 		return nil, ErrBouncingCheque
 	}
 
-	// store the accepted cheque
+	// store the accepted cheque together with its domain
+	err = s.store.Put(receivedChequeDomainKey(cheque.Chequebook), domain)
+	if err != nil {
+		return nil, err
+	}
 	err = s.store.Put(lastReceivedChequeKey(cheque.Chequebook), cheque)
 	if err != nil {
 		return nil, err
@@ -186,9 +227,67 @@ func (s *chequeStore) ReceiveCheque(ctx context.Context, cheque *SignedCheque, e
 	return amount, nil
 }
 
-// RecoverCheque recovers the issuer ethereum address from a signed cheque
-func RecoverCheque(cheque *SignedCheque, chaindID int64) (common.Address, error) {
-	eip712Data := eip712DataForCheque(&cheque.Cheque, chaindID)
+// verifyDomain returns the domain the cheque was signed in by the issuer,
+// trying the current domain first and then the legacy ones.
+func (s *chequeStore) verifyDomain(cheque *SignedCheque, expectedIssuer common.Address) (ChequeDomain, error) {
+	for _, domain := range append([]ChequeDomain{s.domain}, s.legacyDomains...) {
+		issuer, err := s.recoverChequeFunc(cheque, domain)
+		if err != nil {
+			return ChequeDomain{}, err
+		}
+		if issuer == expectedIssuer {
+			return domain, nil
+		}
+	}
+	return ChequeDomain{}, ErrChequeInvalid
+}
+
+// LastChequeLegacy reports whether the last cheque received from a chequebook
+// was signed in one of the legacy domains.
+func (s *chequeStore) LastChequeLegacy(ctx context.Context, chequebook common.Address) (bool, error) {
+	domain, err := s.lastChequeDomain(ctx, chequebook)
+	if err != nil {
+		return false, err
+	}
+	return domain != s.domain, nil
+}
+
+// lastChequeDomain returns the domain the last cheque received from a
+// chequebook was signed in. The domain of a cheque stored without it is
+// recovered from its signature and stored.
+func (s *chequeStore) lastChequeDomain(ctx context.Context, chequebook common.Address) (ChequeDomain, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var domain ChequeDomain
+	err := s.store.Get(receivedChequeDomainKey(chequebook), &domain)
+	if err == nil {
+		return domain, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return ChequeDomain{}, err
+	}
+
+	cheque, err := s.LastCheque(chequebook)
+	if err != nil {
+		return ChequeDomain{}, err
+	}
+
+	expectedIssuer, err := newChequebookContract(chequebook, s.transactionService).Issuer(ctx)
+	if err != nil {
+		return ChequeDomain{}, err
+	}
+
+	domain, err = s.verifyDomain(cheque, expectedIssuer)
+	if err != nil {
+		return ChequeDomain{}, err
+	}
+	return domain, s.store.Put(receivedChequeDomainKey(chequebook), domain)
+}
+
+// RecoverCheque recovers the issuer ethereum address from a cheque signed in the given domain
+func RecoverCheque(cheque *SignedCheque, domain ChequeDomain) (common.Address, error) {
+	eip712Data := eip712DataForCheque(&cheque.Cheque, domain)
 
 	pubkey, err := crypto.RecoverEIP712(cheque.Signature, eip712Data)
 	if err != nil {
@@ -242,3 +341,41 @@ func (s *chequeStore) LastCheques() (map[common.Address]*SignedCheque, error) {
 	}
 	return result, nil
 }
+
+// MarkBounced records that the cashout transaction txHash of a cheque bounced.
+func (s *chequeStore) MarkBounced(txHash common.Hash, cheque *SignedCheque, amount *big.Int) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bounced, err := s.BouncedCheque(cheque.Chequebook)
+	if err != nil && !errors.Is(err, ErrNoCheque) {
+		return false, err
+	}
+	if bounced != nil && bounced.TxHash == txHash {
+		return false, nil
+	}
+
+	err = s.store.Put(bouncedChequeKey(cheque.Chequebook), &BouncedCheque{
+		TxHash: txHash,
+		Cheque: *cheque,
+		Amount: amount,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// BouncedCheque returns the last bounced cheque of a specific chequebook.
+func (s *chequeStore) BouncedCheque(chequebook common.Address) (*BouncedCheque, error) {
+	var bounced *BouncedCheque
+	err := s.store.Get(bouncedChequeKey(chequebook), &bounced)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		return nil, ErrNoCheque
+	}
+
+	return bounced, nil
+}
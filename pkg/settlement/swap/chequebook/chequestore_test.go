@@ -53,7 +53,7 @@ func TestReceiveCheque(t *testing.T) {
 	chequestore := chequebook.NewChequeStore(
 		store,
 		factory,
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -65,9 +65,9 @@ func TestReceiveCheque(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
-			if cid != chainID {
-				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, cid)
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
+			if domain.ChainID != chainID {
+				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, domain.ChainID)
 			}
 			if !cheque.Equal(c) {
 				t.Fatalf("recovery with wrong cheque. wanted %v, got %v", cheque, c)
@@ -145,7 +145,7 @@ func TestReceiveChequeInvalidBeneficiary(t *testing.T) {
 	chequestore := chequebook.NewChequeStore(
 		store,
 		&factoryMock{},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(),
 		nil,
@@ -179,7 +179,7 @@ func TestReceiveChequeInvalidAmount(t *testing.T) {
 				return nil
 			},
 		},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -188,7 +188,7 @@ func TestReceiveChequeInvalidAmount(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
 			return issuer, nil
 		})
 
@@ -238,7 +238,7 @@ func TestReceiveChequeInvalidChequebook(t *testing.T) {
 				return chequebook.ErrNotDeployedByFactory
 			},
 		},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -246,7 +246,7 @@ func TestReceiveChequeInvalidChequebook(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, cumulativePayout.FillBytes(make([]byte, 32)), "balance"),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
 			return issuer, nil
 		})
 
@@ -281,14 +281,14 @@ func TestReceiveChequeInvalidSignature(t *testing.T) {
 				return nil
 			},
 		},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, common.BytesToHash(issuer.Bytes()).Bytes(), "issuer"),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
 			return common.Address{}, nil
 		})
 
@@ -323,7 +323,7 @@ func TestReceiveChequeInsufficientBalance(t *testing.T) {
 				return nil
 			},
 		},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -332,7 +332,7 @@ func TestReceiveChequeInsufficientBalance(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
 			return issuer, nil
 		})
 
@@ -367,7 +367,7 @@ func TestReceiveChequeSufficientBalancePaidOut(t *testing.T) {
 				return nil
 			},
 		},
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -376,7 +376,7 @@ func TestReceiveChequeSufficientBalancePaidOut(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
 			return issuer, nil
 		})
 
@@ -427,7 +427,7 @@ func TestReceiveChequeNotEnoughValue(t *testing.T) {
 	chequestore := chequebook.NewChequeStore(
 		store,
 		factory,
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -436,9 +436,9 @@ func TestReceiveChequeNotEnoughValue(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
-			if cid != chainID {
-				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, cid)
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
+			if domain.ChainID != chainID {
+				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, domain.ChainID)
 			}
 			if !cheque.Equal(c) {
 				t.Fatalf("recovery with wrong cheque. wanted %v, got %v", cheque, c)
@@ -491,7 +491,7 @@ func TestReceiveChequeNotEnoughValueAfterDeduction(t *testing.T) {
 	chequestore := chequebook.NewChequeStore(
 		store,
 		factory,
-		chainID,
+		chequebook.DefaultChequeDomain(chainID),
 		beneficiary,
 		transactionmock.New(
 			transactionmock.WithABICallSequence(
@@ -500,9 +500,9 @@ func TestReceiveChequeNotEnoughValueAfterDeduction(t *testing.T) {
 				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
 			),
 		),
-		func(c *chequebook.SignedCheque, cid int64) (common.Address, error) {
-			if cid != chainID {
-				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, cid)
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
+			if domain.ChainID != chainID {
+				t.Fatalf("recovery with wrong chain id. wanted %d, got %d", chainID, domain.ChainID)
 			}
 			if !cheque.Equal(c) {
 				t.Fatalf("recovery with wrong cheque. wanted %v, got %v", cheque, c)
@@ -515,3 +515,221 @@ func TestReceiveChequeNotEnoughValueAfterDeduction(t *testing.T) {
 		t.Fatalf("got wrong error. wanted %v, got %v", chequebook.ErrChequeValueTooLow, err)
 	}
 }
+
+func TestMarkBounced(t *testing.T) {
+	t.Parallel()
+
+	store := storemock.NewStateStore()
+	chequebookAddress := common.HexToAddress("0xeeee")
+	txHash := common.HexToHash("0xdddd")
+	amount := big.NewInt(40)
+
+	cheque := &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      common.HexToAddress("0xffff"),
+			CumulativePayout: big.NewInt(101),
+			Chequebook:       chequebookAddress,
+		},
+		Signature: make([]byte, 65),
+	}
+
+	chequestore := chequebook.NewChequeStore(
+		store,
+		&factoryMock{},
+		chequebook.DefaultChequeDomain(1),
+		cheque.Beneficiary,
+		transactionmock.New(),
+		nil,
+	)
+
+	_, err := chequestore.BouncedCheque(chequebookAddress)
+	if !errors.Is(err, chequebook.ErrNoCheque) {
+		t.Fatalf("wrong error. wanted %v, got %v", chequebook.ErrNoCheque, err)
+	}
+
+	marked, err := chequestore.MarkBounced(txHash, cheque, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !marked {
+		t.Fatal("expected cheque to be marked as bounced")
+	}
+
+	// marking the same cashout again is a no-op
+	marked, err = chequestore.MarkBounced(txHash, cheque, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marked {
+		t.Fatal("expected cheque to be marked only once")
+	}
+
+	bounced, err := chequestore.BouncedCheque(chequebookAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounced.TxHash != txHash {
+		t.Fatalf("wrong transaction hash. wanted %v, got %v", txHash, bounced.TxHash)
+	}
+	if !bounced.Cheque.Equal(cheque) {
+		t.Fatalf("wrong cheque. wanted %v, got %v", cheque, bounced.Cheque)
+	}
+	if bounced.Amount.Cmp(amount) != 0 {
+		t.Fatalf("wrong amount. wanted %d, got %d", amount, bounced.Amount)
+	}
+}
+
+func TestReceiveChequeLegacyDomain(t *testing.T) {
+	t.Parallel()
+
+	store := storemock.NewStateStore()
+	beneficiary := common.HexToAddress("0xffff")
+	issuer := common.HexToAddress("0xbeee")
+	cumulativePayout := big.NewInt(10)
+	chequebookAddress := common.HexToAddress("0xeeee")
+	sig := make([]byte, 65)
+	chainID := int64(728126428)
+	legacyDomain := chequebook.DefaultChequeDomain(1)
+
+	var recovered []chequebook.ChequeDomain
+	chequestore := chequebook.NewChequeStore(
+		store,
+		&factoryMock{
+			verifyChequebook: func(ctx context.Context, address common.Address) error {
+				return nil
+			},
+		},
+		chequebook.DefaultChequeDomain(chainID),
+		beneficiary,
+		transactionmock.New(
+			transactionmock.WithABICallSequence(
+				transactionmock.ABICall(&chequebookABI, chequebookAddress, common.BytesToHash(issuer.Bytes()).Bytes(), "issuer"),
+				transactionmock.ABICall(&chequebookABI, chequebookAddress, cumulativePayout.FillBytes(make([]byte, 32)), "balance"),
+				transactionmock.ABICall(&chequebookABI, chequebookAddress, big.NewInt(0).FillBytes(make([]byte, 32)), "paidOut", beneficiary),
+			),
+		),
+		func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
+			recovered = append(recovered, domain)
+			// the cheque was signed before the migration
+			if domain == legacyDomain {
+				return issuer, nil
+			}
+			return common.HexToAddress("0xcccc"), nil
+		},
+		legacyDomain,
+	)
+
+	received, err := chequestore.ReceiveCheque(context.Background(), &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      beneficiary,
+			CumulativePayout: cumulativePayout,
+			Chequebook:       chequebookAddress,
+		},
+		Signature: sig,
+	}, cumulativePayout, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received.Cmp(cumulativePayout) != 0 {
+		t.Fatalf("got amount %d, want %d", received, cumulativePayout)
+	}
+
+	// the current domain is tried before the legacy ones
+	want := []chequebook.ChequeDomain{chequebook.DefaultChequeDomain(chainID), legacyDomain}
+	if len(recovered) != len(want) || recovered[0] != want[0] || recovered[1] != want[1] {
+		t.Fatalf("recovered in domains %v, want %v", recovered, want)
+	}
+
+	// the domain is stored with the cheque, so it is not recovered again
+	legacy, err := chequestore.LastChequeLegacy(context.Background(), chequebookAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !legacy {
+		t.Fatal("expected the cheque to be signed in a legacy domain")
+	}
+	if len(recovered) != len(want) {
+		t.Fatalf("recovered in %d domains, want %d", len(recovered), len(want))
+	}
+}
+
+func TestLastChequeLegacy(t *testing.T) {
+	t.Parallel()
+
+	beneficiary := common.HexToAddress("0xffff")
+	issuer := common.HexToAddress("0xbeee")
+	chequebookAddress := common.HexToAddress("0xeeee")
+	chainID := int64(728126428)
+	legacyDomain := chequebook.DefaultChequeDomain(1)
+
+	for _, tc := range []struct {
+		name     string
+		signedIn chequebook.ChequeDomain
+		want     bool
+	}{
+		{
+			name:     "current domain",
+			signedIn: chequebook.DefaultChequeDomain(chainID),
+			want:     false,
+		},
+		{
+			name:     "legacy domain",
+			signedIn: legacyDomain,
+			want:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := storemock.NewStateStore()
+			// the cheque was stored before the migration
+			err := store.Put(chequebook.LastReceivedChequeKey(chequebookAddress), &chequebook.SignedCheque{
+				Cheque: chequebook.Cheque{
+					Beneficiary:      beneficiary,
+					CumulativePayout: big.NewInt(10),
+					Chequebook:       chequebookAddress,
+				},
+				Signature: make([]byte, 65),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			chequestore := chequebook.NewChequeStore(
+				store,
+				&factoryMock{},
+				chequebook.DefaultChequeDomain(chainID),
+				beneficiary,
+				transactionmock.New(
+					transactionmock.WithABICallSequence(
+						transactionmock.ABICall(&chequebookABI, chequebookAddress, common.BytesToHash(issuer.Bytes()).Bytes(), "issuer"),
+					),
+				),
+				func(c *chequebook.SignedCheque, domain chequebook.ChequeDomain) (common.Address, error) {
+					if domain == tc.signedIn {
+						return issuer, nil
+					}
+					return common.HexToAddress("0xcccc"), nil
+				},
+				legacyDomain,
+			)
+
+			legacy, err := chequestore.LastChequeLegacy(context.Background(), chequebookAddress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if legacy != tc.want {
+				t.Fatalf("got legacy %v, want %v", legacy, tc.want)
+			}
+
+			// the recovered domain is stored with the cheque
+			var domain chequebook.ChequeDomain
+			if err := store.Get(chequebook.ReceivedChequeDomainKey(chequebookAddress), &domain); err != nil {
+				t.Fatal(err)
+			}
+			if domain != tc.signedIn {
+				t.Fatalf("got stored domain %v, want %v", domain, tc.signedIn)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chequebook
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/storage"
+)
+
+// chequeDomainsKey is the persistence key of the cheque domain history.
+const chequeDomainsKey = "swap_chequebook_cheque_domains"
+
+// ChequeDomainVersion is the default EIP712 domain version of the chequebook
+// contract, used unless another version is configured.
+const ChequeDomainVersion = "1.0"
+
+// ErrInvalidChequeDomain is the error returned if a cheque domain can not be parsed.
+var ErrInvalidChequeDomain = errors.New("invalid cheque domain")
+
+// ChequeDomain identifies the EIP712 domain cheques are signed in.
+type ChequeDomain struct {
+	Version string `json:"version"`
+	ChainID int64  `json:"chainID"`
+}
+
+// DefaultChequeDomain returns the domain of the default chequebook contract version on the given chain.
+func DefaultChequeDomain(chainID int64) ChequeDomain {
+	return ChequeDomain{
+		Version: ChequeDomainVersion,
+		ChainID: chainID,
+	}
+}
+
+// ParseChequeDomain parses a domain given as "chainID" or "version:chainID".
+// The version defaults to defaultVersion.
+func ParseChequeDomain(s, defaultVersion string) (ChequeDomain, error) {
+	version, chainID, found := strings.Cut(s, ":")
+	if !found {
+		version, chainID = defaultVersion, s
+	}
+	id, err := strconv.ParseInt(chainID, 10, 64)
+	if err != nil || version == "" {
+		return ChequeDomain{}, fmt.Errorf("%w: %q", ErrInvalidChequeDomain, s)
+	}
+	return ChequeDomain{
+		Version: version,
+		ChainID: id,
+	}, nil
+}
+
+func (d ChequeDomain) String() string {
+	return fmt.Sprintf("%s:%d", d.Version, d.ChainID)
+}
+
+// chequeDomains is the persisted history of the domains cheques were issued in.
+type chequeDomains struct {
+	Current ChequeDomain
+	Legacy  []ChequeDomain
+}
+
+// MigrateChequeDomain records current as the domain cheques are issued in.
+// If it differs from the previously recorded domain, e.g. after the node was
+// moved to another chain, the previous domain is kept as legacy domain so that
+// cheques issued before the migration still verify. It returns all legacy
+// domains.
+func MigrateChequeDomain(logger log.Logger, store storage.StateStorer, current ChequeDomain) ([]ChequeDomain, error) {
+	var domains chequeDomains
+	err := store.Get(chequeDomainsKey, &domains)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		domains.Current = current
+		return nil, store.Put(chequeDomainsKey, domains)
+	}
+
+	if domains.Current == current {
+		return domains.Legacy, nil
+	}
+
+	logger.Info("migrating cheque domain", "from", domains.Current, "to", current)
+
+	// migrating back to a legacy domain makes it current again
+	domains.Legacy = slices.DeleteFunc(domains.Legacy, func(d ChequeDomain) bool {
+		return d == current || d == domains.Current
+	})
+	domains.Legacy = append(domains.Legacy, domains.Current)
+	domains.Current = current
+
+	if err := store.Put(chequeDomainsKey, domains); err != nil {
+		return nil, err
+	}
+	return domains.Legacy, nil
+}
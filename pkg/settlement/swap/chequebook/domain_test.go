@@ -0,0 +1,66 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chequebook_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	storemock "github.com/calmw/bee-tron/pkg/statestore/mock"
+)
+
+func TestParseChequeDomain(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in   string
+		want chequebook.ChequeDomain
+		err  error
+	}{
+		{in: "1", want: chequebook.ChequeDomain{Version: "3.0", ChainID: 1}},
+		{in: "2.0:728126428", want: chequebook.ChequeDomain{Version: "2.0", ChainID: 728126428}},
+		{in: ":1", err: chequebook.ErrInvalidChequeDomain},
+		{in: "1.0:abc", err: chequebook.ErrInvalidChequeDomain},
+	} {
+		got, err := chequebook.ParseChequeDomain(tc.in, "3.0")
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("%q: got error %v, want %v", tc.in, err, tc.err)
+		}
+		if got != tc.want {
+			t.Fatalf("%q: got %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestMigrateChequeDomain(t *testing.T) {
+	t.Parallel()
+
+	store := storemock.NewStateStore()
+	ethereum := chequebook.DefaultChequeDomain(1)
+	tron := chequebook.DefaultChequeDomain(728126428)
+
+	for _, step := range []struct {
+		current chequebook.ChequeDomain
+		legacy  []chequebook.ChequeDomain
+	}{
+		{current: ethereum, legacy: nil},
+		{current: ethereum, legacy: nil},
+		{current: tron, legacy: []chequebook.ChequeDomain{ethereum}},
+		{current: tron, legacy: []chequebook.ChequeDomain{ethereum}},
+		// moving back makes the former domain current again
+		{current: ethereum, legacy: []chequebook.ChequeDomain{tron}},
+	} {
+		legacy, err := chequebook.MigrateChequeDomain(log.Noop, store, step.current)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(legacy, step.legacy) {
+			t.Fatalf("migrating to %v: got legacy domains %v, want %v", step.current, legacy, step.legacy)
+		}
+	}
+}
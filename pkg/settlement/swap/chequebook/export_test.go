@@ -3,8 +3,23 @@
 // license that can be found in the LICENSE file.
 package chequebook
 
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
 var (
-	LastIssuedChequeKey   = lastIssuedChequeKey
-	LastReceivedChequeKey = lastReceivedChequeKey
-	CashoutActionKey      = cashoutActionKey
+	LastIssuedChequeKey     = lastIssuedChequeKey
+	LastReceivedChequeKey   = lastReceivedChequeKey
+	ReceivedChequeDomainKey = receivedChequeDomainKey
+	CashoutActionKey        = cashoutActionKey
 )
+
+func (w *TopUpWatcher) Check(ctx context.Context) (*big.Int, error) {
+	return w.check(ctx)
+}
+
+func (w *TopUpWatcher) SetTimeNow(f func() time.Time) {
+	w.now = f
+}
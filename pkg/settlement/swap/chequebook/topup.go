@@ -0,0 +1,186 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chequebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/storage"
+)
+
+const (
+	topUpStateKey = "swap_chequebook_topup_state"
+
+	// DefaultTopUpInterval is the default interval at which the available balance is checked.
+	DefaultTopUpInterval = 5 * time.Minute
+	topUpTimeout         = 10 * time.Minute
+)
+
+var (
+	// ErrTopUpLimitReached is the error when the daily top-up limit is exhausted.
+	ErrTopUpLimitReached = errors.New("daily top-up limit reached")
+	// ErrInvalidTopUpConfig is the error when the top-up configuration is invalid.
+	ErrInvalidTopUpConfig = errors.New("invalid top-up configuration")
+)
+
+// topUpState is the data persisted for the top-up watcher so that daily limits survive restarts.
+type topUpState struct {
+	Day       int64    // unix day of the last top-up
+	Deposited *big.Int // amount deposited during Day
+}
+
+// TopUpWatcher monitors the available chequebook balance and deposits from
+// the node wallet when it drops below a floor.
+type TopUpWatcher struct {
+	logger     log.Logger
+	chequebook Service
+	store      storage.StateStorer
+	floor      *big.Int // available balance below which a top-up is triggered
+	amount     *big.Int // amount deposited per top-up
+	maxPerDay  *big.Int // maximum amount deposited per day
+	interval   time.Duration
+	now        func() time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTopUpWatcher creates a new TopUpWatcher. The amount deposited on every
+// top-up is clamped to what is left of maxPerDay for the current day. A zero
+// amount defaults to the floor and a zero maxPerDay to the amount.
+func NewTopUpWatcher(logger log.Logger, chequebook Service, store storage.StateStorer, floor, amount, maxPerDay *big.Int, interval time.Duration) (*TopUpWatcher, error) {
+	if floor == nil || floor.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: floor must be positive", ErrInvalidTopUpConfig)
+	}
+	if amount == nil || amount.Sign() == 0 {
+		amount = floor
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("%w: amount %d must not be negative", ErrInvalidTopUpConfig, amount)
+	}
+	if maxPerDay == nil || maxPerDay.Sign() == 0 {
+		maxPerDay = amount
+	}
+	if maxPerDay.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("%w: daily limit %d must cover at least one top-up of %d", ErrInvalidTopUpConfig, maxPerDay, amount)
+	}
+	if interval <= 0 {
+		interval = DefaultTopUpInterval
+	}
+
+	return &TopUpWatcher{
+		logger:     logger.WithName(loggerName).Register(),
+		chequebook: chequebook,
+		store:      store,
+		floor:      new(big.Int).Set(floor),
+		amount:     new(big.Int).Set(amount),
+		maxPerDay:  new(big.Int).Set(maxPerDay),
+		interval:   interval,
+		now:        time.Now,
+		quit:       make(chan struct{}),
+	}, nil
+}
+
+// Start starts watching the chequebook balance in the background.
+func (w *TopUpWatcher) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), topUpTimeout)
+			go func() {
+				select {
+				case <-w.quit:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			_, err := w.check(ctx)
+			cancel()
+			if err != nil && !errors.Is(err, ErrTopUpLimitReached) {
+				w.logger.Error(err, "chequebook top-up failed")
+			}
+
+			select {
+			case <-w.quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check deposits into the chequebook if the available balance is below the
+// floor. It returns the deposited amount which is nil if no deposit was made.
+func (w *TopUpWatcher) check(ctx context.Context) (*big.Int, error) {
+	available, err := w.chequebook.AvailableBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("available balance: %w", err)
+	}
+	if available.Cmp(w.floor) >= 0 {
+		return nil, nil
+	}
+
+	day := w.now().Unix() / int64((24 * time.Hour).Seconds())
+
+	state := topUpState{Day: day, Deposited: big.NewInt(0)}
+	var stored topUpState
+	err = w.store.Get(topUpStateKey, &stored)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil && stored.Day == day && stored.Deposited != nil {
+		state.Deposited = stored.Deposited
+	}
+
+	remaining := new(big.Int).Sub(w.maxPerDay, state.Deposited)
+	if remaining.Sign() <= 0 {
+		w.logger.Warning("chequebook available balance below floor but daily top-up limit reached", "available", available, "floor", w.floor, "limit", w.maxPerDay)
+		return nil, ErrTopUpLimitReached
+	}
+
+	amount := new(big.Int).Set(w.amount)
+	if amount.Cmp(remaining) > 0 {
+		amount.Set(remaining)
+	}
+
+	w.logger.Info("chequebook available balance below floor, depositing", "available", available, "floor", w.floor, "amount", amount)
+
+	txHash, err := w.chequebook.Deposit(ctx, amount)
+	if err != nil {
+		return nil, fmt.Errorf("deposit: %w", err)
+	}
+
+	// account the deposit before waiting so a failing wait can not lift the limit
+	state.Deposited = new(big.Int).Add(state.Deposited, amount)
+	if err := w.store.Put(topUpStateKey, state); err != nil {
+		return nil, err
+	}
+
+	if err := w.chequebook.WaitForDeposit(ctx, txHash); err != nil {
+		return nil, fmt.Errorf("wait for deposit %x: %w", txHash, err)
+	}
+
+	w.logger.Info("chequebook topped up", "amount", amount, "transaction", txHash)
+	return amount, nil
+}
+
+// Close stops the watcher.
+func (w *TopUpWatcher) Close() error {
+	close(w.quit)
+	w.wg.Wait()
+	return nil
+}
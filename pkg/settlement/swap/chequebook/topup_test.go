@@ -0,0 +1,146 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chequebook_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook/mock"
+	storemock "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTopUpWatcher(t *testing.T) {
+	t.Parallel()
+
+	store := storemock.NewStateStore()
+	available := big.NewInt(50)
+	txHash := common.HexToHash("0xab")
+
+	var deposits []*big.Int
+	chequebookService := mock.NewChequebook(
+		mock.WithChequebookAvailableBalanceFunc(func(ctx context.Context) (*big.Int, error) {
+			return available, nil
+		}),
+		mock.WithChequebookDepositFunc(func(ctx context.Context, amount *big.Int) (common.Hash, error) {
+			deposits = append(deposits, amount)
+			return txHash, nil
+		}),
+		mock.WithChequebookWaitForDepositFunc(func(ctx context.Context, hash common.Hash) error {
+			if hash != txHash {
+				t.Fatalf("waiting for wrong transaction. got %x, want %x", hash, txHash)
+			}
+			return nil
+		}),
+	)
+
+	watcher, err := chequebook.NewTopUpWatcher(log.Noop, chequebookService, store, big.NewInt(100), big.NewInt(40), big.NewInt(100), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1_000_000, 0)
+	watcher.SetTimeNow(func() time.Time { return now })
+
+	// two full top-ups and one clamped to the daily limit
+	for _, want := range []int64{40, 40, 20} {
+		deposited, err := watcher.Check(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deposited == nil || deposited.Int64() != want {
+			t.Fatalf("got deposit %v, want %d", deposited, want)
+		}
+	}
+
+	_, err = watcher.Check(context.Background())
+	if !errors.Is(err, chequebook.ErrTopUpLimitReached) {
+		t.Fatalf("got error %v, want %v", err, chequebook.ErrTopUpLimitReached)
+	}
+
+	// the limit resets on the next day
+	now = now.Add(24 * time.Hour)
+	deposited, err := watcher.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposited == nil || deposited.Int64() != 40 {
+		t.Fatalf("got deposit %v, want 40", deposited)
+	}
+
+	// no top-up above the floor
+	available = big.NewInt(100)
+	deposited, err = watcher.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposited != nil {
+		t.Fatalf("unexpected deposit of %d", deposited)
+	}
+
+	if len(deposits) != 4 {
+		t.Fatalf("got %d deposits, want 4", len(deposits))
+	}
+}
+
+func TestTopUpWatcherInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := chequebook.NewTopUpWatcher(log.Noop, mock.NewChequebook(), storemock.NewStateStore(), big.NewInt(100), big.NewInt(40), big.NewInt(10), time.Minute)
+	if !errors.Is(err, chequebook.ErrInvalidTopUpConfig) {
+		t.Fatalf("got error %v, want %v", err, chequebook.ErrInvalidTopUpConfig)
+	}
+
+	_, err = chequebook.NewTopUpWatcher(log.Noop, mock.NewChequebook(), storemock.NewStateStore(), big.NewInt(100), big.NewInt(-1), big.NewInt(0), time.Minute)
+	if !errors.Is(err, chequebook.ErrInvalidTopUpConfig) {
+		t.Fatalf("got error %v, want %v", err, chequebook.ErrInvalidTopUpConfig)
+	}
+}
+
+func TestTopUpWatcherFloorOnly(t *testing.T) {
+	t.Parallel()
+
+	var deposits []*big.Int
+	chequebookService := mock.NewChequebook(
+		mock.WithChequebookAvailableBalanceFunc(func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(50), nil
+		}),
+		mock.WithChequebookDepositFunc(func(ctx context.Context, amount *big.Int) (common.Hash, error) {
+			deposits = append(deposits, amount)
+			return common.Hash{}, nil
+		}),
+		mock.WithChequebookWaitForDepositFunc(func(ctx context.Context, hash common.Hash) error {
+			return nil
+		}),
+	)
+
+	// the amount defaults to the floor and the daily limit to the amount
+	watcher, err := chequebook.NewTopUpWatcher(log.Noop, chequebookService, storemock.NewStateStore(), big.NewInt(100), big.NewInt(0), big.NewInt(0), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deposited, err := watcher.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposited == nil || deposited.Int64() != 100 {
+		t.Fatalf("got deposit %v, want 100", deposited)
+	}
+
+	_, err = watcher.Check(context.Background())
+	if !errors.Is(err, chequebook.ErrTopUpLimitReached) {
+		t.Fatalf("got error %v, want %v", err, chequebook.ErrTopUpLimitReached)
+	}
+	if len(deposits) != 1 {
+		t.Fatalf("got %d deposits, want 1", len(deposits))
+	}
+}
@@ -17,6 +17,9 @@ type Service struct {
 	receiveCheque func(ctx context.Context, cheque *chequebook.SignedCheque, exchangeRate *big.Int, deduction *big.Int) (*big.Int, error)
 	lastCheque    func(chequebook common.Address) (*chequebook.SignedCheque, error)
 	lastCheques   func() (map[common.Address]*chequebook.SignedCheque, error)
+	markBounced   func(txHash common.Hash, cheque *chequebook.SignedCheque, amount *big.Int) (bool, error)
+	bouncedCheque func(chequebook common.Address) (*chequebook.BouncedCheque, error)
+	chequeLegacy  func(chequebook common.Address) (bool, error)
 }
 
 func WithReceiveChequeFunc(f func(ctx context.Context, cheque *chequebook.SignedCheque, exchangeRate *big.Int, deduction *big.Int) (*big.Int, error)) Option {
@@ -37,6 +40,24 @@ func WithLastChequesFunc(f func() (map[common.Address]*chequebook.SignedCheque,
 	})
 }
 
+func WithMarkBouncedFunc(f func(txHash common.Hash, cheque *chequebook.SignedCheque, amount *big.Int) (bool, error)) Option {
+	return optionFunc(func(s *Service) {
+		s.markBounced = f
+	})
+}
+
+func WithBouncedChequeFunc(f func(chequebook common.Address) (*chequebook.BouncedCheque, error)) Option {
+	return optionFunc(func(s *Service) {
+		s.bouncedCheque = f
+	})
+}
+
+func WithLastChequeLegacyFunc(f func(chequebook common.Address) (bool, error)) Option {
+	return optionFunc(func(s *Service) {
+		s.chequeLegacy = f
+	})
+}
+
 // NewChequeStore creates the mock chequeStore implementation
 func NewChequeStore(opts ...Option) chequebook.ChequeStore {
 	mock := new(Service)
@@ -58,6 +79,21 @@ func (s *Service) LastCheques() (map[common.Address]*chequebook.SignedCheque, er
 	return s.lastCheques()
 }
 
+func (s *Service) MarkBounced(txHash common.Hash, cheque *chequebook.SignedCheque, amount *big.Int) (bool, error) {
+	return s.markBounced(txHash, cheque, amount)
+}
+
+func (s *Service) BouncedCheque(chequebook common.Address) (*chequebook.BouncedCheque, error) {
+	return s.bouncedCheque(chequebook)
+}
+
+func (s *Service) LastChequeLegacy(ctx context.Context, chequebook common.Address) (bool, error) {
+	if s.chequeLegacy == nil {
+		return false, nil
+	}
+	return s.chequeLegacy(chequebook)
+}
+
 // Option is the option passed to the mock ChequeStore service
 type Option interface {
 	apply(*Service)
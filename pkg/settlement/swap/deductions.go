@@ -0,0 +1,141 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// deductionLogPrefix is the prefix of the store keys holding deduction records.
+const deductionLogPrefix = "swap_deduction_log_"
+
+const (
+	// DeductionGranted marks a deduction added to a cheque sent to a peer.
+	DeductionGranted = "granted"
+	// DeductionReceived marks a deduction contained in a cheque received from a peer.
+	DeductionReceived = "received"
+)
+
+// DeductionRecord is an entry of the deduction audit log.
+type DeductionRecord struct {
+	Peer       swarm.Address `json:"peer"`
+	Direction  string        `json:"direction"`
+	Amount     *big.Int      `json:"amount"`
+	Timestamp  int64         `json:"timestamp"`
+	ChequeHash common.Hash   `json:"chequeHash"`
+}
+
+// DeductionFilter selects deduction records. Zero fields match all records.
+// Offset skips the first matching records and a positive Limit caps the
+// number of the records returned.
+type DeductionFilter struct {
+	Peer      swarm.Address
+	Direction string
+	Offset    int
+	Limit     int
+}
+
+func (f DeductionFilter) match(record DeductionRecord) bool {
+	if !f.Peer.IsZero() && !f.Peer.Equal(record.Peer) {
+		return false
+	}
+	return f.Direction == "" || f.Direction == record.Direction
+}
+
+// DeductionLog is the audit log of the deductions granted to and received from peers.
+type DeductionLog struct {
+	store storage.StateStorer
+	now   func() time.Time
+
+	mu  sync.Mutex
+	seq int64 // disambiguates records logged at the same time
+}
+
+// NewDeductionLog creates a new DeductionLog.
+func NewDeductionLog(store storage.StateStorer) *DeductionLog {
+	return &DeductionLog{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Record logs a deduction applied to the given cheque.
+func (l *DeductionLog) Record(peer swarm.Address, direction string, cheque *chequebook.SignedCheque, amount *big.Int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.seq++
+
+	record := DeductionRecord{
+		Peer:       peer,
+		Direction:  direction,
+		Amount:     new(big.Int).Set(amount),
+		Timestamp:  now.Unix(),
+		ChequeHash: chequeHash(cheque),
+	}
+	return l.store.Put(deductionLogKey(now, l.seq), record)
+}
+
+// Deductions returns the records matching the filter, oldest first.
+func (l *DeductionLog) Deductions(filter DeductionFilter) ([]DeductionRecord, error) {
+	type keyedRecord struct {
+		key    string
+		record DeductionRecord
+	}
+
+	var records []keyedRecord
+	err := l.store.Iterate(deductionLogPrefix, func(key, value []byte) (bool, error) {
+		var record DeductionRecord
+		if err := json.Unmarshal(value, &record); err != nil {
+			return true, fmt.Errorf("decode deduction record %s: %w", string(key), err)
+		}
+		if filter.match(record) {
+			records = append(records, keyedRecord{key: string(key), record: record})
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].key < records[j].key
+	})
+
+	records = records[min(max(filter.Offset, 0), len(records)):]
+	if filter.Limit > 0 {
+		records = records[:min(filter.Limit, len(records))]
+	}
+
+	result := make([]DeductionRecord, 0, len(records))
+	for _, r := range records {
+		result = append(result, r.record)
+	}
+	return result, nil
+}
+
+// chequeHash identifies a cheque by the hash of its signature.
+func chequeHash(cheque *chequebook.SignedCheque) common.Hash {
+	if cheque == nil {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(cheque.Signature)
+}
+
+func deductionLogKey(t time.Time, seq int64) string {
+	return fmt.Sprintf("%s%020d_%020d", deductionLogPrefix, t.UnixNano(), seq)
+}
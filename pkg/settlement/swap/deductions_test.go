@@ -0,0 +1,87 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
+	mockstore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDeductionLog(t *testing.T) {
+	t.Parallel()
+
+	deductions := swap.NewDeductionLog(mockstore.NewStateStore())
+	now := time.Unix(1_000_000, 0)
+	swap.SetDeductionLogTimeNow(deductions, func() time.Time { return now })
+
+	peer1 := swarm.MustParseHexAddress("abcd")
+	peer2 := swarm.MustParseHexAddress("dcba")
+	cheque := &chequebook.SignedCheque{Signature: []byte{1, 2, 3}}
+
+	if err := deductions.Record(peer1, swap.DeductionGranted, cheque, big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+	if err := deductions.Record(peer2, swap.DeductionReceived, cheque, big.NewInt(20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := deductions.Record(peer1, swap.DeductionReceived, nil, big.NewInt(30)); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := deductions.Deductions(swap.DeductionFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d records, want 3", len(all))
+	}
+	first := all[0]
+	if !first.Peer.Equal(peer1) || first.Direction != swap.DeductionGranted || first.Amount.Int64() != 10 || first.Timestamp != 1_000_000 {
+		t.Fatalf("unexpected first record %+v", first)
+	}
+	if first.ChequeHash != crypto.Keccak256Hash(cheque.Signature) {
+		t.Fatalf("got cheque hash %x, want %x", first.ChequeHash, crypto.Keccak256Hash(cheque.Signature))
+	}
+
+	byPeer, err := deductions.Deductions(swap.DeductionFilter{Peer: peer1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byPeer) != 2 || byPeer[1].Amount.Int64() != 30 {
+		t.Fatalf("unexpected records of peer %s: %+v", peer1, byPeer)
+	}
+
+	received, err := deductions.Deductions(swap.DeductionFilter{Peer: peer1, Direction: swap.DeductionReceived})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 || received[0].Amount.Int64() != 30 {
+		t.Fatalf("unexpected received records of peer %s: %+v", peer1, received)
+	}
+
+	page, err := deductions.Deductions(swap.DeductionFilter{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || page[0].Amount.Int64() != 20 {
+		t.Fatalf("unexpected page of records: %+v", page)
+	}
+
+	past, err := deductions.Deductions(swap.DeductionFilter{Offset: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(past) != 0 {
+		t.Fatalf("got %d records past the end, want 0", len(past))
+	}
+}
@@ -3,6 +3,11 @@
 // license that can be found in the LICENSE file.
 package swap
 
+import (
+	"context"
+	"time"
+)
+
 var (
 	PeerKey            = peerKey
 	ChequebookPeerKey  = chequebookPeerKey
@@ -10,4 +15,23 @@ var (
 	BeneficiaryPeerKey = beneficiaryPeerKey
 	PeerDeductedByKey  = peerDeductedByKey
 	PeerDeductedForKey = peerDeductedForKey
+	ExchangeRateKey    = exchangeRateKey
 )
+
+func (d *WebhookDispatcher) SetRetryBackoff(backoff time.Duration) {
+	d.backoff = backoff
+}
+
+func SetAddressbookTimeNow(a Addressbook, f func() time.Time) {
+	a.(*addressbook).now = f
+}
+
+func SetDeductionLogTimeNow(l *DeductionLog, f func() time.Time) {
+	l.now = f
+}
+
+var CashoutWatchKey = cashoutWatchKey
+
+func (s *Service) CheckCashouts(ctx context.Context) {
+	s.checkCashouts(ctx)
+}
@@ -7,4 +7,5 @@ package swap
 const (
 	ExchangeRateFieldName = exchangeRateFieldName
 	DeductionFieldName    = deductionFieldName
+	ToleranceFieldName    = toleranceFieldName
 )
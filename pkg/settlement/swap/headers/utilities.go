@@ -14,6 +14,7 @@ import (
 const (
 	exchangeRateFieldName = "exchange"
 	deductionFieldName    = "deduction"
+	toleranceFieldName    = "tolerance"
 )
 
 var (
@@ -23,6 +24,8 @@ var (
 	ErrNoExchangeHeader = errors.New("no exchange header")
 	// ErrNoDeductionHeader denotes p2p.Header lacking specified field
 	ErrNoDeductionHeader = errors.New("no deduction header")
+	// ErrNoToleranceHeader denotes p2p.Header lacking specified field
+	ErrNoToleranceHeader = errors.New("no tolerance header")
 )
 
 func MakeSettlementHeaders(exchangeRate, deduction *big.Int) p2p.Headers {
@@ -33,6 +36,13 @@ func MakeSettlementHeaders(exchangeRate, deduction *big.Int) p2p.Headers {
 	}
 }
 
+// AddToleranceHeader adds the accepted exchange rate deviation, expressed
+// in basis points, to the settlement headers.
+func AddToleranceHeader(headers p2p.Headers, tolerance uint64) p2p.Headers {
+	headers[toleranceFieldName] = new(big.Int).SetUint64(tolerance).Bytes()
+	return headers
+}
+
 func ParseSettlementResponseHeaders(receivedHeaders p2p.Headers) (exchange, deduction *big.Int, err error) {
 
 	exchangeRate, err := ParseExchangeHeader(receivedHeaders)
@@ -65,3 +75,15 @@ func ParseDeductionHeader(receivedHeaders p2p.Headers) (*big.Int, error) {
 	deduced := new(big.Int).SetBytes(receivedHeaders[deductionFieldName])
 	return deduced, nil
 }
+
+func ParseToleranceHeader(receivedHeaders p2p.Headers) (uint64, error) {
+	if receivedHeaders[toleranceFieldName] == nil {
+		return 0, ErrNoToleranceHeader
+	}
+
+	tolerance := new(big.Int).SetBytes(receivedHeaders[toleranceFieldName])
+	if !tolerance.IsUint64() {
+		return 0, ErrFieldLength
+	}
+	return tolerance.Uint64(), nil
+}
@@ -85,3 +85,27 @@ func TestParseDeductionHeader(t *testing.T) {
 	}
 
 }
+
+func TestParseToleranceHeader(t *testing.T) {
+	t.Parallel()
+
+	headers := swap.AddToleranceHeader(p2p.Headers{}, 250)
+
+	if !reflect.DeepEqual(headers[swap.ToleranceFieldName], []byte{250}) {
+		t.Fatalf("Made headers not as expected, got %+v, want %+v", headers[swap.ToleranceFieldName], []byte{250})
+	}
+
+	tolerance, err := swap.ParseToleranceHeader(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tolerance != 250 {
+		t.Fatalf("Tolerance mismatch, got %v, want %v", tolerance, 250)
+	}
+
+	_, err = swap.ParseToleranceHeader(p2p.Headers{})
+	if err != swap.ErrNoToleranceHeader {
+		t.Fatalf("Expected error %v, got %v", swap.ErrNoToleranceHeader, err)
+	}
+}
@@ -15,6 +15,8 @@ type metrics struct {
 	ChequesReceived  prometheus.Counter
 	ChequesSent      prometheus.Counter
 	ChequesRejected  prometheus.Counter
+	ChequesBounced   prometheus.Counter
+	TotalBounced     prometheus.Counter
 	AvailableBalance prometheus.Gauge
 }
 
@@ -52,6 +54,18 @@ func newMetrics() metrics {
 			Name:      "cheques_rejected",
 			Help:      "Number of cheques rejected",
 		}),
+		ChequesBounced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "cheques_bounced",
+			Help:      "Number of received cheques whose cashout bounced",
+		}),
+		TotalBounced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "total_bounced",
+			Help:      "Amount of tokens left unpaid by bounced cheques",
+		}),
 		AvailableBalance: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: m.Namespace,
 			Subsystem: subsystem,
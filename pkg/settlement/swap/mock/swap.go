@@ -274,7 +274,7 @@ func (s *Service) GetDeductionByPeer(peer swarm.Address) (bool, error) {
 	return false, nil
 }
 
-func (s *Service) AddDeductionByPeer(peer swarm.Address) error {
+func (s *Service) AddDeductionByPeer(peer swarm.Address, cheque *chequebook.SignedCheque, deduction *big.Int) error {
 	s.deductionByPeers[peer.String()] = struct{}{}
 	return nil
 }
@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/postage/postagecontract"
@@ -23,6 +25,14 @@ import (
 // loggerName is the tree path name of the logger for this package.
 const loggerName = "swap"
 
+// exchangeRatePrefix is the prefix of the store keys holding the exchange
+// rates at which the cheques of a chequebook were received.
+const exchangeRatePrefix = "swap_exchange_rate_"
+
+// cashoutWatchPrefix is the prefix of the store keys holding the peers of the
+// chequebooks whose last cashout is not final yet.
+const cashoutWatchPrefix = "swap_cashout_watch_"
+
 var (
 	// ErrWrongChequebook is the error if a peer uses a different chequebook from before.
 	ErrWrongChequebook = errors.New("wrong chequebook")
@@ -31,6 +41,8 @@ var (
 	// ErrChequeValueTooLow is the error a peer issued a cheque not covering 1 accounting credit
 	ErrChequeValueTooLow = errors.New("cheque value too low")
 	ErrNoChequebook      = errors.New("no chequebook")
+	// ErrSwapDisabled is the error if swap is disabled for a peer by its settlement policy.
+	ErrSwapDisabled = errors.New("swap disabled for peer")
 )
 
 type Interface interface {
@@ -62,6 +74,14 @@ type Service struct {
 	addressbook    Addressbook
 	networkID      uint64
 	cashoutAddress common.Address
+	notifier       EventNotifier
+	policies       *settlement.PolicyStore
+	deductions     *DeductionLog
+
+	confirmedMu sync.Mutex
+	confirmed   map[common.Address]common.Hash // last cashout reported as confirmed per chequebook
+
+	bounceMu sync.Mutex // serializes the crediting of bounced cheques
 }
 
 // New creates a new swap Service.
@@ -78,11 +98,48 @@ func New(proto swapprotocol.Interface, logger log.Logger, store storage.StateSto
 		cashout:        cashout,
 		accounting:     accounting,
 		cashoutAddress: cashoutAddress,
+		deductions:     NewDeductionLog(store),
+		confirmed:      make(map[common.Address]common.Hash),
 	}
 }
 
+// SetEventNotifier sets the notifier receiving settlement events.
+func (s *Service) SetEventNotifier(notifier EventNotifier) {
+	s.notifier = notifier
+}
+
+// SetPolicyStore sets the store of per-peer settlement policies.
+func (s *Service) SetPolicyStore(policies *settlement.PolicyStore) {
+	s.policies = policies
+}
+
+// peerPolicy returns the settlement policy of the peer or the zero policy if none is set.
+func (s *Service) peerPolicy(peer swarm.Address) (settlement.PeerPolicy, error) {
+	if s.policies == nil {
+		return settlement.PeerPolicy{}, nil
+	}
+	policy, _, err := s.policies.Policy(peer)
+	return policy, err
+}
+
+func (s *Service) notify(event Event) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.Notify(event)
+}
+
 // ReceiveCheque is called by the swap protocol if a cheque is received.
 func (s *Service) ReceiveCheque(ctx context.Context, peer swarm.Address, cheque *chequebook.SignedCheque, exchangeRate, deduction *big.Int) (err error) {
+	policy, err := s.peerPolicy(peer)
+	if err != nil {
+		return err
+	}
+	if policy.DisableSwap {
+		s.metrics.ChequesRejected.Inc()
+		return fmt.Errorf("rejecting cheque: %w", ErrSwapDisabled)
+	}
+
 	// check this is the same chequebook for this peer as previously
 	expectedChequebook, known, err := s.addressbook.Chequebook(peer)
 	if err != nil {
@@ -103,6 +160,7 @@ func (s *Service) ReceiveCheque(ctx context.Context, peer swarm.Address, cheque
 		if err != nil {
 			return err
 		}
+		s.recordDeduction(peer, DeductionReceived, cheque, deduction)
 	}
 
 	decreasedAmount := new(big.Int).Sub(receivedAmount, deduction)
@@ -115,10 +173,26 @@ func (s *Service) ReceiveCheque(ctx context.Context, peer swarm.Address, cheque
 		}
 	}
 
+	err = s.store.Put(exchangeRateKey(cheque.Chequebook, cheque.CumulativePayout), exchangeRate)
+	if err != nil {
+		return err
+	}
+
 	tot, _ := big.NewFloat(0).SetInt(receivedAmount).Float64()
 	s.metrics.TotalReceived.Add(tot)
 	s.metrics.ChequesReceived.Inc()
 
+	if err := s.addressbook.Touch(peer); err != nil {
+		s.logger.Debug("touch peer failed", "peer_address", peer, "error", err)
+	}
+
+	s.notify(Event{
+		Type:       EventChequeReceived,
+		Peer:       peer.String(),
+		Chequebook: &cheque.Chequebook,
+		Amount:     receivedAmount,
+	})
+
 	return s.accounting.NotifyPaymentReceived(peer, amount)
 }
 
@@ -134,6 +208,14 @@ func (s *Service) Pay(ctx context.Context, peer swarm.Address, amount *big.Int)
 		err = ErrNoChequebook
 		return
 	}
+	policy, err := s.peerPolicy(peer)
+	if err != nil {
+		return
+	}
+	if !policy.SwapAllowed() {
+		err = ErrSwapDisabled
+		return
+	}
 	beneficiary, known, err := s.addressbook.Beneficiary(peer)
 	if err != nil {
 		return
@@ -155,6 +237,16 @@ func (s *Service) Pay(ctx context.Context, peer swarm.Address, amount *big.Int)
 	amountFloat, _ := big.NewFloat(0).SetInt(amount).Float64()
 	s.metrics.TotalSent.Add(amountFloat)
 	s.metrics.ChequesSent.Inc()
+
+	if err := s.addressbook.Touch(peer); err != nil {
+		s.logger.Debug("touch peer failed", "peer_address", peer, "error", err)
+	}
+
+	s.notify(Event{
+		Type:   EventChequeSent,
+		Peer:   peer.String(),
+		Amount: amount,
+	})
 }
 
 func (s *Service) SetAccounting(accounting settlement.Accounting) {
@@ -259,7 +351,10 @@ func (s *Service) Handshake(peer swarm.Address, beneficiary common.Address) erro
 	}
 	if known && !peer.Equal(oldPeer) {
 		s.logger.Debug("migrating swap addresses", "old_peer_address", oldPeer, "new_peer_address", peer)
-		return s.addressbook.MigratePeer(oldPeer, peer)
+		if err := s.addressbook.MigratePeer(oldPeer, peer); err != nil {
+			return err
+		}
+		return s.addressbook.Touch(peer)
 	}
 
 	_, known, err = s.addressbook.Beneficiary(peer)
@@ -268,10 +363,17 @@ func (s *Service) Handshake(peer swarm.Address, beneficiary common.Address) erro
 	}
 	if !known {
 		loggerV1.Debug("initial swap handshake", "peer_address", peer, "beneficiary_address", beneficiary)
-		return s.addressbook.PutBeneficiary(peer, beneficiary)
+		if err := s.addressbook.PutBeneficiary(peer, beneficiary); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return s.addressbook.Touch(peer)
+}
+
+// Addressbook returns the addressbook used by the service.
+func (s *Service) Addressbook() Addressbook {
+	return s.addressbook
 }
 
 // LastSentCheque returns the last sent cheque for the peer
@@ -360,7 +462,26 @@ func (s *Service) CashCheque(ctx context.Context, peer swarm.Address) (common.Ha
 	if !known {
 		return common.Hash{}, chequebook.ErrNoCheque
 	}
-	return s.cashout.CashCheque(ctx, chequebookAddress, s.cashoutAddress)
+
+	txHash, err := s.cashout.CashCheque(ctx, chequebookAddress, s.cashoutAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// the cashout is watched until it is final, so that a bounce is credited
+	// back even if its status is never requested
+	if err := s.store.Put(cashoutWatchKey(chequebookAddress), peer); err != nil {
+		s.logger.Error(err, "failed to watch cashout", "peer_address", peer, "chequebook", chequebookAddress, "tx", txHash)
+	}
+
+	s.notify(Event{
+		Type:       EventCashoutSubmitted,
+		Peer:       peer.String(),
+		Chequebook: &chequebookAddress,
+		TxHash:     &txHash,
+	})
+
+	return txHash, nil
 }
 
 // CashoutStatus gets the status of the latest cashout transaction for the peers chequebook
@@ -372,7 +493,215 @@ func (s *Service) CashoutStatus(ctx context.Context, peer swarm.Address) (*chequ
 	if !known {
 		return nil, chequebook.ErrNoCheque
 	}
-	return s.cashout.CashoutStatus(ctx, chequebookAddress)
+
+	return s.cashoutStatus(ctx, peer, chequebookAddress)
+}
+
+// cashoutStatus gets the status of the last cashout of the chequebook of the
+// peer, handling a bounce and reporting a confirmation.
+func (s *Service) cashoutStatus(ctx context.Context, peer swarm.Address, chequebookAddress common.Address) (*chequebook.CashoutStatus, error) {
+	status, err := s.cashout.CashoutStatus(ctx, chequebookAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.handleBounce(peer, status); err != nil {
+		return nil, err
+	}
+
+	s.notifyConfirmed(peer, chequebookAddress, status)
+
+	return status, nil
+}
+
+// checkCashouts checks the status of the watched cashouts and stops watching
+// the ones which are final. A cashout is final once its transaction is
+// mined, as the bounce is handled and the exchange rates of the cheques which
+// can no longer be cashed are removed by the status check.
+func (s *Service) checkCashouts(ctx context.Context) {
+	watched := make(map[common.Address]swarm.Address)
+	err := s.store.Iterate(cashoutWatchPrefix, func(key, value []byte) (bool, error) {
+		var peer swarm.Address
+		if err := peer.UnmarshalJSON(value); err != nil {
+			return true, err
+		}
+		watched[common.HexToAddress(strings.TrimPrefix(string(key), cashoutWatchPrefix))] = peer
+		return false, nil
+	})
+	if err != nil {
+		s.logger.Error(err, "failed to list watched cashouts")
+		return
+	}
+
+	for chequebookAddress, peer := range watched {
+		status, err := s.cashoutStatus(ctx, peer, chequebookAddress)
+		if err != nil {
+			s.logger.Debug("cashout status check failed", "peer_address", peer, "chequebook", chequebookAddress, "error", err)
+			continue
+		}
+		if status.Last != nil && status.Last.Result == nil && !status.Last.Reverted {
+			continue
+		}
+		if err := s.store.Delete(cashoutWatchKey(chequebookAddress)); err != nil {
+			s.logger.Error(err, "failed to stop watching cashout", "peer_address", peer, "chequebook", chequebookAddress)
+		}
+	}
+}
+
+// notifyConfirmed reports a successful cashout the first time its status is seen.
+func (s *Service) notifyConfirmed(peer swarm.Address, chequebookAddress common.Address, status *chequebook.CashoutStatus) {
+	if status.Last == nil || status.Last.Result == nil || status.Last.Result.Bounced {
+		return
+	}
+
+	s.confirmedMu.Lock()
+	if s.confirmed[chequebookAddress] == status.Last.TxHash {
+		s.confirmedMu.Unlock()
+		return
+	}
+	s.confirmed[chequebookAddress] = status.Last.TxHash
+	s.confirmedMu.Unlock()
+
+	s.notify(Event{
+		Type:       EventCashoutConfirmed,
+		Peer:       peer.String(),
+		Chequebook: &chequebookAddress,
+		TxHash:     &status.Last.TxHash,
+		Amount:     status.Last.Result.TotalPayout,
+	})
+}
+
+// handleBounce checks whether the last cashout of the peer's cheques bounced.
+// The unpaid amount of a bounced cheque is put back as debt of the peer in
+// accounting once, after which the bounce is recorded in the cheque store.
+// The exchange rates of the cheques which can no longer be cashed are removed.
+func (s *Service) handleBounce(peer swarm.Address, status *chequebook.CashoutStatus) error {
+	if status.Last == nil || status.Last.Result == nil {
+		return nil
+	}
+
+	if status.Last.Unpaid != nil && status.Last.Unpaid.Sign() > 0 {
+		if err := s.creditBounced(peer, status.Last); err != nil {
+			return err
+		}
+	}
+
+	// the chequebook records the cheque as paid out even if it bounced, so
+	// neither it nor the earlier cheques can be cashed again
+	return s.pruneExchangeRates(status.Last.Cheque.Chequebook, status.Last.Cheque.CumulativePayout)
+}
+
+// creditBounced puts the unpaid amount of the bounced cashout back as debt of
+// the peer, converted with the exchange rate at which the cashed cheque was
+// received, unless the bounce was already recorded.
+func (s *Service) creditBounced(peer swarm.Address, last *chequebook.LastCashout) error {
+	s.bounceMu.Lock()
+	defer s.bounceMu.Unlock()
+
+	chequebookAddress := last.Cheque.Chequebook
+
+	bounced, err := s.chequeStore.BouncedCheque(chequebookAddress)
+	if err != nil && !errors.Is(err, chequebook.ErrNoCheque) {
+		return err
+	}
+	if bounced != nil && bounced.TxHash == last.TxHash {
+		return nil
+	}
+
+	var exchangeRate *big.Int
+	err = s.store.Get(exchangeRateKey(chequebookAddress, last.Cheque.CumulativePayout), &exchangeRate)
+	if err != nil {
+		return fmt.Errorf("exchange rate for bounced cheque: %w", err)
+	}
+
+	unpaid := last.Unpaid
+	amount := new(big.Int).Div(unpaid, exchangeRate)
+
+	if err := s.accounting.NotifyPaymentBounced(peer, amount); err != nil {
+		return err
+	}
+
+	// the bounce is recorded only after the debt has been credited back, so
+	// that a failed attempt is retried on the next status check
+	if _, err := s.chequeStore.MarkBounced(last.TxHash, &last.Cheque, unpaid); err != nil {
+		return err
+	}
+
+	s.logger.Warning("cheque bounced", "peer_address", peer, "chequebook", chequebookAddress, "tx", last.TxHash, "unpaid", unpaid)
+
+	total, _ := big.NewFloat(0).SetInt(unpaid).Float64()
+	s.metrics.TotalBounced.Add(total)
+	s.metrics.ChequesBounced.Inc()
+
+	s.notify(Event{
+		Type:       EventCashoutBounced,
+		Peer:       peer.String(),
+		Chequebook: &chequebookAddress,
+		TxHash:     &last.TxHash,
+		Amount:     unpaid,
+	})
+
+	return nil
+}
+
+// HasUncashedCheques reports whether cheques received from the peer may still
+// be cashed. The exchange rates of the received cheques are kept until their
+// cashout is final.
+func (s *Service) HasUncashedCheques(peer swarm.Address) (bool, error) {
+	chequebookAddress, known, err := s.addressbook.Chequebook(peer)
+	if err != nil || !known {
+		return false, err
+	}
+
+	uncashed := false
+	err = s.store.Iterate(exchangeRateChequebookPrefix(chequebookAddress), func(_, _ []byte) (bool, error) {
+		uncashed = true
+		return true, nil
+	})
+	return uncashed, err
+}
+
+// pruneExchangeRates removes the exchange rates of the cheques of the
+// chequebook up to the given cumulative payout.
+func (s *Service) pruneExchangeRates(chequebookAddress common.Address, cumulativePayout *big.Int) error {
+	prefix := exchangeRateChequebookPrefix(chequebookAddress)
+
+	var keys []string
+	err := s.store.Iterate(prefix, func(key, _ []byte) (bool, error) {
+		payout, ok := new(big.Int).SetString(strings.TrimPrefix(string(key), prefix), 10)
+		if ok && payout.Cmp(cumulativePayout) <= 0 {
+			keys = append(keys, string(key))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exchangeRateChequebookPrefix returns the prefix of the exchange rate keys
+// of the cheques of a chequebook.
+func exchangeRateChequebookPrefix(chequebook common.Address) string {
+	return fmt.Sprintf("%s%x_", exchangeRatePrefix, chequebook)
+}
+
+// cashoutWatchKey returns the key of the watch of the last cashout of a
+// chequebook.
+func cashoutWatchKey(chequebook common.Address) string {
+	return fmt.Sprintf("%s%x", cashoutWatchPrefix, chequebook)
+}
+
+// exchangeRateKey returns the key of the exchange rate at which the cheque of
+// a chequebook with the given cumulative payout was received.
+func exchangeRateKey(chequebook common.Address, cumulativePayout *big.Int) string {
+	return exchangeRateChequebookPrefix(chequebook) + cumulativePayout.String()
 }
 
 func (s *Service) GetDeductionForPeer(peer swarm.Address) (bool, error) {
@@ -383,8 +712,25 @@ func (s *Service) GetDeductionByPeer(peer swarm.Address) (bool, error) {
 	return s.addressbook.GetDeductionBy(peer)
 }
 
-func (s *Service) AddDeductionByPeer(peer swarm.Address) error {
-	return s.addressbook.AddDeductionBy(peer)
+func (s *Service) AddDeductionByPeer(peer swarm.Address, cheque *chequebook.SignedCheque, deduction *big.Int) error {
+	if err := s.addressbook.AddDeductionBy(peer); err != nil {
+		return err
+	}
+	s.recordDeduction(peer, DeductionGranted, cheque, deduction)
+	return nil
+}
+
+// recordDeduction adds a deduction to the audit log. Failures are only
+// logged as the deduction has already been applied.
+func (s *Service) recordDeduction(peer swarm.Address, direction string, cheque *chequebook.SignedCheque, deduction *big.Int) {
+	if err := s.deductions.Record(peer, direction, cheque, deduction); err != nil {
+		s.logger.Error(err, "failed to record deduction", "peer_address", peer, "direction", direction)
+	}
+}
+
+// Deductions returns the audit log of granted and received deductions.
+func (s *Service) Deductions() *DeductionLog {
+	return s.deductions
 }
 
 type NoOpSwap struct {
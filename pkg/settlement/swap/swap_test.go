@@ -13,12 +13,14 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/crypto"
 	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/settlement"
 	"github.com/calmw/bee-tron/pkg/settlement/swap"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/chequebook"
 	mockchequebook "github.com/calmw/bee-tron/pkg/settlement/swap/chequebook/mock"
 	mockchequestore "github.com/calmw/bee-tron/pkg/settlement/swap/chequestore/mock"
 	"github.com/calmw/bee-tron/pkg/settlement/swap/swapprotocol"
 	mockstore "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -37,6 +39,7 @@ func (m *swapProtocolMock) EmitCheque(ctx context.Context, peer swarm.Address, b
 type testObserver struct {
 	receivedCalled chan notifyPaymentReceivedCall
 	sentCalled     chan notifyPaymentSentCall
+	bouncedCalled  chan notifyPaymentReceivedCall
 }
 
 type notifyPaymentReceivedCall struct {
@@ -54,6 +57,7 @@ func newTestObserver() *testObserver {
 	return &testObserver{
 		receivedCalled: make(chan notifyPaymentReceivedCall, 1),
 		sentCalled:     make(chan notifyPaymentSentCall, 1),
+		bouncedCalled:  make(chan notifyPaymentReceivedCall, 1),
 	}
 }
 
@@ -69,6 +73,14 @@ func (t *testObserver) NotifyPaymentReceived(peer swarm.Address, amount *big.Int
 	return nil
 }
 
+func (t *testObserver) NotifyPaymentBounced(peer swarm.Address, amount *big.Int) error {
+	t.bouncedCalled <- notifyPaymentReceivedCall{
+		peer:   peer,
+		amount: amount,
+	}
+	return nil
+}
+
 func (t *testObserver) NotifyRefreshmentSent(peer swarm.Address, attemptedAmount, amount *big.Int, timestamp int64, allegedInterval int64, receivedError error) {
 }
 
@@ -104,6 +116,7 @@ type addressbookMock struct {
 	addDeductionBy  func(peer swarm.Address) error
 	getDeductionFor func(peer swarm.Address) (bool, error)
 	getDeductionBy  func(peer swarm.Address) (bool, error)
+	touch           func(peer swarm.Address) error
 }
 
 func (m *addressbookMock) MigratePeer(oldPeer, newPeer swarm.Address) error {
@@ -139,6 +152,27 @@ func (m *addressbookMock) GetDeductionFor(peer swarm.Address) (bool, error) {
 func (m *addressbookMock) GetDeductionBy(peer swarm.Address) (bool, error) {
 	return m.getDeductionBy(peer)
 }
+func (m *addressbookMock) Touch(peer swarm.Address) error {
+	if m.touch == nil {
+		return nil
+	}
+	return m.touch(peer)
+}
+func (m *addressbookMock) Entry(peer swarm.Address) (swap.AddressbookEntry, bool, error) {
+	return swap.AddressbookEntry{}, false, nil
+}
+func (m *addressbookMock) Export() ([]swap.AddressbookEntry, error) {
+	return nil, nil
+}
+func (m *addressbookMock) Import(entries []swap.AddressbookEntry) error {
+	return nil
+}
+func (m *addressbookMock) Remove(peer swarm.Address) error {
+	return nil
+}
+func (m *addressbookMock) Prune(maxAge time.Duration, keep func(swarm.Address) (bool, error)) (int, error) {
+	return 0, nil
+}
 
 type cashoutMock struct {
 	cashCheque    func(ctx context.Context, chequebook common.Address, recipient common.Address) (common.Hash, error)
@@ -189,6 +223,7 @@ func TestReceiveCheque(t *testing.T) {
 	)
 
 	peerDeductionFor := false
+	touched := false
 
 	networkID := uint64(1)
 	addressbook := &addressbookMock{
@@ -214,6 +249,13 @@ func TestReceiveCheque(t *testing.T) {
 			}
 			return nil
 		},
+		touch: func(p swarm.Address) error {
+			touched = true
+			if !peer.Equal(p) {
+				t.Fatal("touching wrong peer")
+			}
+			return nil
+		},
 	}
 
 	observer := newTestObserver()
@@ -256,6 +298,18 @@ func TestReceiveCheque(t *testing.T) {
 		t.Fatal("add deduction for peer not called")
 	}
 
+	if !touched {
+		t.Fatal("peer not recorded as seen")
+	}
+
+	// the received cheque is not cashed yet
+	uncashed, err := swap.HasUncashedCheques(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uncashed {
+		t.Fatal("expected uncashed cheques")
+	}
 }
 
 func TestReceiveChequeReject(t *testing.T) {
@@ -381,6 +435,61 @@ func TestReceiveChequeWrongChequebook(t *testing.T) {
 
 }
 
+func TestReceiveChequeSwapDisabled(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	store := mockstore.NewStateStore()
+	peer := swarm.MustParseHexAddress("abcd")
+	cheque := &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      common.HexToAddress("0xab"),
+			CumulativePayout: big.NewInt(10),
+			Chequebook:       common.HexToAddress("0xcd"),
+		},
+		Signature: []byte{},
+	}
+
+	policies := settlement.NewPolicyStore(store)
+	err := policies.SetPolicy(peer, settlement.PeerPolicy{DisableSwap: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chequeStore := mockchequestore.NewChequeStore(
+		mockchequestore.WithReceiveChequeFunc(func(ctx context.Context, c *chequebook.SignedCheque, e *big.Int, d *big.Int) (*big.Int, error) {
+			t.Fatal("cheque store called for disabled peer")
+			return nil, nil
+		}),
+	)
+
+	observer := newTestObserver()
+	swapService := swap.New(
+		&swapProtocolMock{},
+		logger,
+		store,
+		mockchequebook.NewChequebook(),
+		chequeStore,
+		&addressbookMock{},
+		uint64(1),
+		&cashoutMock{},
+		observer,
+		common.Address{},
+	)
+	swapService.SetPolicyStore(policies)
+
+	err = swapService.ReceiveCheque(context.Background(), peer, cheque, big.NewInt(10), big.NewInt(0))
+	if !errors.Is(err, swap.ErrSwapDisabled) {
+		t.Fatalf("wrong error. wanted %v, got %v", swap.ErrSwapDisabled, err)
+	}
+
+	select {
+	case <-observer.receivedCalled:
+		t.Fatalf("observer called by error.")
+	default:
+	}
+}
+
 func TestPay(t *testing.T) {
 	t.Parallel()
 
@@ -545,6 +654,52 @@ func TestPayUnknownBeneficiary(t *testing.T) {
 	}
 }
 
+func TestPaySwapDisabled(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	store := mockstore.NewStateStore()
+	peer := swarm.MustParseHexAddress("abcd")
+
+	policies := settlement.NewPolicyStore(store)
+	err := policies.SetPolicy(peer, settlement.PeerPolicy{ForcePseudosettle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swapService := swap.New(
+		&swapProtocolMock{
+			emitCheque: func(c context.Context, a1 swarm.Address, a2 common.Address, i *big.Int, issueFunc swapprotocol.IssueFunc) (*big.Int, error) {
+				t.Fatal("cheque emitted for peer with swap disabled")
+				return nil, nil
+			},
+		},
+		logger,
+		store,
+		mockchequebook.NewChequebook(),
+		mockchequestore.NewChequeStore(),
+		&addressbookMock{},
+		uint64(1),
+		&cashoutMock{},
+		nil,
+		common.Address{},
+	)
+	swapService.SetPolicyStore(policies)
+
+	observer := newTestObserver()
+	swapService.SetAccounting(observer)
+
+	swapService.Pay(context.Background(), peer, big.NewInt(50))
+	select {
+	case call := <-observer.sentCalled:
+		if !errors.Is(call.err, swap.ErrSwapDisabled) {
+			t.Fatalf("wrong error. wanted %v, got %v", swap.ErrSwapDisabled, call.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected observer to be called")
+	}
+}
+
 func TestHandshake(t *testing.T) {
 	t.Parallel()
 
@@ -794,6 +949,362 @@ func TestCashoutStatus(t *testing.T) {
 	}
 }
 
+func TestCashoutStatusBounced(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	store := mockstore.NewStateStore()
+
+	theirChequebookAddress := common.HexToAddress("ffff")
+	peer := swarm.MustParseHexAddress("abcd")
+	exchangeRate := big.NewInt(10)
+	txHash := common.HexToHash("dddd")
+
+	cheque := &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      common.HexToAddress("0xab"),
+			CumulativePayout: big.NewInt(500),
+			Chequebook:       theirChequebookAddress,
+		},
+		Signature: []byte{},
+	}
+
+	addressbook := &addressbookMock{
+		chequebook: func(p swarm.Address) (common.Address, bool, error) {
+			return theirChequebookAddress, true, nil
+		},
+		addDeductionFor: func(p swarm.Address) error {
+			return nil
+		},
+	}
+
+	observer := newTestObserver()
+
+	var bounced *chequebook.BouncedCheque
+	chequeStore := mockchequestore.NewChequeStore(
+		mockchequestore.WithReceiveChequeFunc(func(ctx context.Context, c *chequebook.SignedCheque, e *big.Int, d *big.Int) (*big.Int, error) {
+			return c.CumulativePayout, nil
+		}),
+		mockchequestore.WithMarkBouncedFunc(func(hash common.Hash, c *chequebook.SignedCheque, amount *big.Int) (bool, error) {
+			if len(observer.bouncedCalled) == 0 {
+				t.Error("bounce recorded before the debt was credited back")
+			}
+			if bounced != nil && bounced.TxHash == hash {
+				return false, nil
+			}
+			bounced = &chequebook.BouncedCheque{TxHash: hash, Cheque: *c, Amount: amount}
+			return true, nil
+		}),
+		mockchequestore.WithBouncedChequeFunc(func(common.Address) (*chequebook.BouncedCheque, error) {
+			if bounced == nil {
+				return nil, chequebook.ErrNoCheque
+			}
+			return bounced, nil
+		}),
+	)
+
+	status := &chequebook.CashoutStatus{
+		Last: &chequebook.LastCashout{
+			TxHash: txHash,
+			Cheque: *cheque,
+			Result: &chequebook.CashChequeResult{
+				TotalPayout:      big.NewInt(100),
+				CumulativePayout: cheque.CumulativePayout,
+				Bounced:          true,
+			},
+			Unpaid: big.NewInt(400),
+		},
+		UncashedAmount: big.NewInt(0),
+	}
+
+	swapService := swap.New(
+		&swapProtocolMock{},
+		logger,
+		store,
+		mockchequebook.NewChequebook(),
+		chequeStore,
+		addressbook,
+		uint64(1),
+		&cashoutMock{
+			cashoutStatus: func(ctx context.Context, c common.Address) (*chequebook.CashoutStatus, error) {
+				return status, nil
+			},
+		},
+		observer,
+		common.Address{},
+	)
+
+	err := swapService.ReceiveCheque(context.Background(), peer, cheque, exchangeRate, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-observer.receivedCalled
+
+	// a later cheque at another exchange rate must not change the credited amount
+	laterCheque := &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      cheque.Beneficiary,
+			CumulativePayout: big.NewInt(700),
+			Chequebook:       theirChequebookAddress,
+		},
+		Signature: []byte{},
+	}
+	err = swapService.ReceiveCheque(context.Background(), peer, laterCheque, big.NewInt(20), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-observer.receivedCalled
+
+	for i := 0; i < 2; i++ {
+		_, err = swapService.CashoutStatus(context.Background(), peer)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if bounced == nil || bounced.TxHash != txHash {
+		t.Fatal("expected cheque to be marked as bounced")
+	}
+
+	select {
+	case call := <-observer.bouncedCalled:
+		if call.amount.Cmp(big.NewInt(40)) != 0 {
+			t.Fatalf("observer called with wrong amount. got %d, want %d", call.amount, 40)
+		}
+		if !call.peer.Equal(peer) {
+			t.Fatalf("observer called with wrong peer. got %v, want %v", call.peer, peer)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected observer to be called")
+	}
+
+	select {
+	case <-observer.bouncedCalled:
+		t.Fatal("expected bounce to be re-credited only once")
+	default:
+	}
+
+	// only the exchange rate of the cheque which can still be cashed is kept
+	var rate *big.Int
+	if err := store.Get(swap.ExchangeRateKey(theirChequebookAddress, cheque.CumulativePayout), &rate); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("exchange rate of the bounced cheque: got error %v, want %v", err, storage.ErrNotFound)
+	}
+	if err := store.Get(swap.ExchangeRateKey(theirChequebookAddress, laterCheque.CumulativePayout), &rate); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCashoutWatch(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	store := mockstore.NewStateStore()
+
+	theirChequebookAddress := common.HexToAddress("ffff")
+	peer := swarm.MustParseHexAddress("abcd")
+	txHash := common.HexToHash("dddd")
+
+	cheque := &chequebook.SignedCheque{
+		Cheque: chequebook.Cheque{
+			Beneficiary:      common.HexToAddress("0xab"),
+			CumulativePayout: big.NewInt(500),
+			Chequebook:       theirChequebookAddress,
+		},
+		Signature: []byte{},
+	}
+
+	addressbook := &addressbookMock{
+		chequebook: func(p swarm.Address) (common.Address, bool, error) {
+			return theirChequebookAddress, true, nil
+		},
+	}
+
+	observer := newTestObserver()
+
+	var bounced *chequebook.BouncedCheque
+	chequeStore := mockchequestore.NewChequeStore(
+		mockchequestore.WithReceiveChequeFunc(func(ctx context.Context, c *chequebook.SignedCheque, e *big.Int, d *big.Int) (*big.Int, error) {
+			return c.CumulativePayout, nil
+		}),
+		mockchequestore.WithMarkBouncedFunc(func(hash common.Hash, c *chequebook.SignedCheque, amount *big.Int) (bool, error) {
+			bounced = &chequebook.BouncedCheque{TxHash: hash, Cheque: *c, Amount: amount}
+			return true, nil
+		}),
+		mockchequestore.WithBouncedChequeFunc(func(common.Address) (*chequebook.BouncedCheque, error) {
+			if bounced == nil {
+				return nil, chequebook.ErrNoCheque
+			}
+			return bounced, nil
+		}),
+	)
+
+	status := &chequebook.CashoutStatus{
+		Last: &chequebook.LastCashout{
+			TxHash: txHash,
+			Cheque: *cheque,
+		},
+		UncashedAmount: big.NewInt(0),
+	}
+
+	swapService := swap.New(
+		&swapProtocolMock{},
+		logger,
+		store,
+		mockchequebook.NewChequebook(),
+		chequeStore,
+		addressbook,
+		uint64(1),
+		&cashoutMock{
+			cashCheque: func(ctx context.Context, c common.Address, r common.Address) (common.Hash, error) {
+				return txHash, nil
+			},
+			cashoutStatus: func(ctx context.Context, c common.Address) (*chequebook.CashoutStatus, error) {
+				return status, nil
+			},
+		},
+		observer,
+		common.Address{},
+	)
+
+	err := swapService.ReceiveCheque(context.Background(), peer, cheque, big.NewInt(10), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-observer.receivedCalled
+
+	if _, err := swapService.CashCheque(context.Background(), peer); err != nil {
+		t.Fatal(err)
+	}
+
+	var watched swarm.Address
+	if err := store.Get(swap.CashoutWatchKey(theirChequebookAddress), &watched); err != nil {
+		t.Fatal(err)
+	}
+	if !watched.Equal(peer) {
+		t.Fatalf("watched peer: got %v, want %v", watched, peer)
+	}
+
+	// a pending cashout is kept watched
+	swapService.CheckCashouts(context.Background())
+	if err := store.Get(swap.CashoutWatchKey(theirChequebookAddress), &watched); err != nil {
+		t.Fatal(err)
+	}
+
+	status.Last.Result = &chequebook.CashChequeResult{
+		TotalPayout:      big.NewInt(100),
+		CumulativePayout: cheque.CumulativePayout,
+		Bounced:          true,
+	}
+	status.Last.Unpaid = big.NewInt(400)
+
+	swapService.CheckCashouts(context.Background())
+
+	select {
+	case call := <-observer.bouncedCalled:
+		if call.amount.Cmp(big.NewInt(40)) != 0 {
+			t.Fatalf("observer called with wrong amount. got %d, want %d", call.amount, 40)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected observer to be called")
+	}
+
+	if err := store.Get(swap.CashoutWatchKey(theirChequebookAddress), &watched); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("watch of the final cashout: got error %v, want %v", err, storage.ErrNotFound)
+	}
+	var rate *big.Int
+	if err := store.Get(swap.ExchangeRateKey(theirChequebookAddress, cheque.CumulativePayout), &rate); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("exchange rate of the bounced cheque: got error %v, want %v", err, storage.ErrNotFound)
+	}
+}
+
+type eventRecorder struct {
+	events []swap.Event
+}
+
+func (r *eventRecorder) Notify(event swap.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestCashoutWatchConfirmed(t *testing.T) {
+	t.Parallel()
+
+	store := mockstore.NewStateStore()
+
+	theirChequebookAddress := common.HexToAddress("ffff")
+	peer := swarm.MustParseHexAddress("abcd")
+	txHash := common.HexToHash("dddd")
+
+	addressbook := &addressbookMock{
+		chequebook: func(p swarm.Address) (common.Address, bool, error) {
+			return theirChequebookAddress, true, nil
+		},
+	}
+
+	cheque := chequebook.Cheque{
+		Beneficiary:      common.HexToAddress("0xab"),
+		CumulativePayout: big.NewInt(500),
+		Chequebook:       theirChequebookAddress,
+	}
+
+	swapService := swap.New(
+		&swapProtocolMock{},
+		log.Noop,
+		store,
+		mockchequebook.NewChequebook(),
+		mockchequestore.NewChequeStore(),
+		addressbook,
+		uint64(1),
+		&cashoutMock{
+			cashCheque: func(ctx context.Context, c common.Address, r common.Address) (common.Hash, error) {
+				return txHash, nil
+			},
+			cashoutStatus: func(ctx context.Context, c common.Address) (*chequebook.CashoutStatus, error) {
+				return &chequebook.CashoutStatus{
+					Last: &chequebook.LastCashout{
+						TxHash: txHash,
+						Cheque: chequebook.SignedCheque{Cheque: cheque},
+						Result: &chequebook.CashChequeResult{
+							TotalPayout:      big.NewInt(500),
+							CumulativePayout: big.NewInt(500),
+						},
+						Unpaid: big.NewInt(0),
+					},
+					UncashedAmount: big.NewInt(0),
+				}, nil
+			},
+		},
+		newTestObserver(),
+		common.Address{},
+	)
+	recorder := &eventRecorder{}
+	swapService.SetEventNotifier(recorder)
+
+	if _, err := swapService.CashCheque(context.Background(), peer); err != nil {
+		t.Fatal(err)
+	}
+
+	swapService.CheckCashouts(context.Background())
+	swapService.CheckCashouts(context.Background())
+
+	want := []swap.EventType{swap.EventCashoutSubmitted, swap.EventCashoutConfirmed}
+	if len(recorder.events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(recorder.events), len(want))
+	}
+	for i, event := range recorder.events {
+		if event.Type != want[i] {
+			t.Fatalf("got event %s, want %s", event.Type, want[i])
+		}
+	}
+	if recorder.events[1].Amount.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("got amount %d, want %d", recorder.events[1].Amount, 500)
+	}
+
+	var watched swarm.Address
+	if err := store.Get(swap.CashoutWatchKey(theirChequebookAddress), &watched); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("watch of the confirmed cashout: got error %v, want %v", err, storage.ErrNotFound)
+	}
+}
+
 func TestStateStoreKeys(t *testing.T) {
 	t.Parallel()
 
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/calmw/bee-tron/pkg/log"
@@ -32,10 +33,14 @@ const (
 	streamName      = "swap" // stream for cheques
 )
 
+// maxRateTolerance is the number of basis points in one whole.
+const maxRateTolerance = 10_000
+
 var (
 	ErrNegotiateRate      = errors.New("exchange rates mismatch")
 	ErrNegotiateDeduction = errors.New("deduction values mismatch")
 	ErrHaveDeduction      = errors.New("received deduction not zero")
+	ErrInvalidTolerance   = errors.New("invalid rate tolerance")
 )
 
 type SendChequeFunc chequebook.SendChequeFunc
@@ -58,7 +63,8 @@ type Swap interface {
 	Handshake(peer swarm.Address, beneficiary common.Address) error
 	GetDeductionForPeer(peer swarm.Address) (bool, error)
 	GetDeductionByPeer(peer swarm.Address) (bool, error)
-	AddDeductionByPeer(peer swarm.Address) error
+	// AddDeductionByPeer records that the opening deduction was added to a cheque sent to the peer.
+	AddDeductionByPeer(peer swarm.Address, cheque *chequebook.SignedCheque, deduction *big.Int) error
 }
 
 // Service is the main implementation of the swap protocol.
@@ -68,6 +74,9 @@ type Service struct {
 	swap        Swap
 	priceOracle priceoracle.Service
 	beneficiary common.Address
+
+	ratesMu       sync.Mutex
+	rateTolerance uint64 // accepted exchange rate deviation in basis points
 }
 
 // New creates a new swap protocol Service.
@@ -85,6 +94,51 @@ func (s *Service) SetSwap(swap Swap) {
 	s.swap = swap
 }
 
+// SetRateTolerance sets the exchange rate deviation, in basis points, this
+// node accepts between its own rate and the rate announced by a peer. The
+// tolerance applied to a peer is the lower of the two announced values.
+func (s *Service) SetRateTolerance(tolerance uint64) error {
+	if tolerance > maxRateTolerance {
+		return ErrInvalidTolerance
+	}
+
+	s.ratesMu.Lock()
+	defer s.ratesMu.Unlock()
+
+	s.rateTolerance = tolerance
+	return nil
+}
+
+func (s *Service) tolerance() uint64 {
+	s.ratesMu.Lock()
+	defer s.ratesMu.Unlock()
+
+	return s.rateTolerance
+}
+
+// negotiateTolerance returns the tolerance agreed with the peer, which is the
+// lower of the local tolerance and the one announced in the peer headers.
+// Peers not announcing a tolerance are treated as requiring an exact match.
+func (s *Service) negotiateTolerance(headers p2p.Headers) (uint64, error) {
+	peerTolerance, err := swap.ParseToleranceHeader(headers)
+	if err != nil {
+		if errors.Is(err, swap.ErrNoToleranceHeader) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return min(s.tolerance(), peerTolerance), nil
+}
+
+// withinTolerance reports whether rate deviates from expected by at most
+// tolerance basis points of expected.
+func withinTolerance(rate, expected *big.Int, tolerance uint64) bool {
+	diff := new(big.Int).Sub(rate, expected)
+	diff.Abs(diff).Mul(diff, big.NewInt(maxRateTolerance))
+	allowed := new(big.Int).Mul(expected, new(big.Int).SetUint64(tolerance))
+	return diff.Cmp(allowed) <= 0
+}
+
 func (s *Service) Protocol() p2p.ProtocolSpec {
 	return p2p.ProtocolSpec{
 		Name:    protocolName,
@@ -158,6 +212,7 @@ func (s *Service) headler(receivedHeaders p2p.Headers, peerAddress swarm.Address
 	}
 
 	returnHeaders = swap.MakeSettlementHeaders(exchangeRate, deduction)
+	returnHeaders = swap.AddToleranceHeader(returnHeaders, s.tolerance())
 	return
 }
 
@@ -209,8 +264,13 @@ func (s *Service) EmitCheque(ctx context.Context, peer swarm.Address, beneficiar
 		return nil, err
 	}
 
-	// exchangeRate rates should match
-	if exchangeRate.Cmp(checkExchangeRate) != 0 {
+	tolerance, err := s.negotiateTolerance(returnedHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	// exchangeRate rates should match within the negotiated tolerance
+	if !withinTolerance(exchangeRate, checkExchangeRate, tolerance) {
 		return nil, ErrNegotiateRate
 	}
 
@@ -224,7 +284,10 @@ func (s *Service) EmitCheque(ctx context.Context, peer swarm.Address, beneficiar
 
 	// issue cheque call with provided callback for sending cheque to finish transaction
 
+	var sentCheque *chequebook.SignedCheque
 	balance, err = issue(ctx, beneficiary, sentAmount, func(cheque *chequebook.SignedCheque) error {
+		sentCheque = cheque
+
 		// for simplicity we use json marshaller. can be replaced by a binary encoding in the future.
 		encodedCheque, err := json.Marshal(cheque)
 		if err != nil {
@@ -245,7 +308,7 @@ func (s *Service) EmitCheque(ctx context.Context, peer swarm.Address, beneficiar
 	}
 
 	if deduction.Cmp(big.NewInt(0)) != 0 {
-		err = s.swap.AddDeductionByPeer(peer)
+		err = s.swap.AddDeductionByPeer(peer, sentCheque, deduction)
 		if err != nil {
 			return nil, err
 		}
@@ -210,6 +210,89 @@ func TestCantEmitChequeRateMismatch(t *testing.T) {
 	}
 }
 
+func TestEmitChequeRateTolerance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name               string
+		receiverTolerance  uint64
+		initiatorTolerance uint64
+		wantErr            error
+	}{
+		{
+			name:               "within tolerance",
+			receiverTolerance:  500,
+			initiatorTolerance: 300,
+		},
+		{
+			name:               "receiver tolerance too low",
+			receiverTolerance:  100,
+			initiatorTolerance: 300,
+			wantErr:            swapprotocol.ErrNegotiateRate,
+		},
+		{
+			name:               "initiator tolerance too low",
+			receiverTolerance:  500,
+			initiatorTolerance: 0,
+			wantErr:            swapprotocol.ErrNegotiateRate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := log.Noop
+			commonAddr := common.HexToAddress("0xab")
+			peerID := swarm.MustParseHexAddress("9ee7add7")
+
+			// exchange rates deviate by 2%
+			priceOracle := priceoraclemock.New(big.NewInt(51), big.NewInt(500))
+			priceOracle2 := priceoraclemock.New(big.NewInt(50), big.NewInt(500))
+			swappReceiver := swapprotocol.New(nil, logger, commonAddr, priceOracle)
+			swappReceiver.SetSwap(swapmock.NewSwap())
+			if err := swappReceiver.SetRateTolerance(tc.receiverTolerance); err != nil {
+				t.Fatal(err)
+			}
+			recorder := streamtest.New(
+				streamtest.WithProtocols(swappReceiver.Protocol()),
+				streamtest.WithBaseAddr(peerID),
+			)
+			swappInitiator := swapprotocol.New(recorder, logger, common.HexToAddress("0xdc"), priceOracle2)
+			swappInitiator.SetSwap(swapmock.NewSwap())
+			if err := swappInitiator.SetRateTolerance(tc.initiatorTolerance); err != nil {
+				t.Fatal(err)
+			}
+
+			var gotPayout *big.Int
+			issueFunc := func(ctx context.Context, beneficiary common.Address, amount *big.Int, sendChequeFunc chequebook.SendChequeFunc) (*big.Int, error) {
+				gotPayout = amount
+				return big.NewInt(13750), nil
+			}
+
+			_, err := swappInitiator.EmitCheque(context.Background(), peerID, commonAddr, big.NewInt(1250), issueFunc)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			// cheque is issued at the rate announced by the receiver: 51 * 1250 + 500
+			if gotPayout.Cmp(big.NewInt(64250)) != 0 {
+				t.Fatalf("unexpected cheque amount, expected %v, got %v", 64250, gotPayout)
+			}
+		})
+	}
+}
+
+func TestSetRateToleranceInvalid(t *testing.T) {
+	t.Parallel()
+
+	swapp := swapprotocol.New(nil, log.Noop, common.Address{}, priceoraclemock.New(big.NewInt(50), big.NewInt(500)))
+	if err := swapp.SetRateTolerance(10_001); !errors.Is(err, swapprotocol.ErrInvalidTolerance) {
+		t.Fatalf("expected error %v, got %v", swapprotocol.ErrInvalidTolerance, err)
+	}
+}
+
 func TestCantEmitChequeDeductionMismatch(t *testing.T) {
 	t.Parallel()
 
@@ -316,7 +399,7 @@ func TestCantEmitChequeIneligibleDeduction(t *testing.T) {
 
 	// mock that the initiator believes deduction was applied previously, but the accepting peer does not
 
-	err := swapInitiator.AddDeductionByPeer(peerID)
+	err := swapInitiator.AddDeductionByPeer(peerID, nil, big.NewInt(0))
 	if err != nil {
 		t.Fatal(err)
 	}
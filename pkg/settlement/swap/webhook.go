@@ -0,0 +1,201 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// WebhookSignatureHeader is the header carrying the hex encoded
+	// HMAC-SHA256 of the timestamp and the request body keyed with the
+	// webhook secret.
+	WebhookSignatureHeader = "X-Bee-Signature"
+	// WebhookTimestampHeader is the header carrying the unix time at which
+	// the request was signed, so that receivers can reject replayed requests.
+	WebhookTimestampHeader = "X-Bee-Timestamp"
+
+	webhookQueueSize      = 1024
+	webhookRequestTimeout = 10 * time.Second
+	webhookMaxAttempts    = 5
+	webhookRetryBackoff   = time.Second
+)
+
+// EventType is the type of a settlement event.
+type EventType string
+
+const (
+	EventChequeReceived   EventType = "cheque_received"
+	EventChequeSent       EventType = "cheque_sent"
+	EventCashoutSubmitted EventType = "cashout_submitted"
+	EventCashoutConfirmed EventType = "cashout_confirmed"
+	EventCashoutBounced   EventType = "cashout_bounced"
+)
+
+// Event is a settlement event reported to external systems.
+type Event struct {
+	Type       EventType       `json:"type"`
+	Peer       string          `json:"peer"`
+	Chequebook *common.Address `json:"chequebook,omitempty"`
+	TxHash     *common.Hash    `json:"transactionHash,omitempty"`
+	Amount     *big.Int        `json:"amount,omitempty"`
+	Timestamp  int64           `json:"timestamp"`
+}
+
+// EventNotifier is notified about settlement events.
+type EventNotifier interface {
+	Notify(event Event)
+}
+
+// WebhookDispatcher POSTs settlement events as JSON to a set of URLs.
+// Deliveries are asynchronous and retried with a linear backoff.
+type WebhookDispatcher struct {
+	logger      log.Logger
+	client      *http.Client
+	urls        []string
+	secret      []byte
+	maxAttempts int
+	backoff     time.Duration
+	queue       chan Event
+	quit        chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewWebhookDispatcher creates a dispatcher delivering to urls. If secret is
+// not empty every request is signed with it.
+func NewWebhookDispatcher(logger log.Logger, urls []string, secret string) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		logger:      logger.WithName(loggerName).Register(),
+		client:      &http.Client{Timeout: webhookRequestTimeout},
+		urls:        urls,
+		secret:      []byte(secret),
+		maxAttempts: webhookMaxAttempts,
+		backoff:     webhookRetryBackoff,
+		queue:       make(chan Event, webhookQueueSize),
+		quit:        make(chan struct{}),
+	}
+
+	d.wg.Add(1)
+	go d.run()
+
+	return d
+}
+
+// Notify queues the event for delivery. Events are dropped if the queue is full.
+func (d *WebhookDispatcher) Notify(event Event) {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix()
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		d.logger.Warning("webhook queue full, dropping event", "type", event.Type, "peer_address", event.Peer)
+	}
+}
+
+func (d *WebhookDispatcher) run() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.quit:
+			return
+		case event := <-d.queue:
+			body, err := json.Marshal(event)
+			if err != nil {
+				d.logger.Error(err, "webhook encode event", "type", event.Type)
+				continue
+			}
+			for _, url := range d.urls {
+				if err := d.deliver(url, body); err != nil {
+					d.logger.Warning("webhook delivery failed", "url", url, "type", event.Type, "error", err)
+				}
+			}
+		}
+	}
+}
+
+// deliver sends the body to the url, retrying until it is accepted, the
+// attempts are exhausted or the dispatcher is closed.
+func (d *WebhookDispatcher) deliver(url string, body []byte) (err error) {
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		if err = d.post(url, body); err == nil {
+			return nil
+		}
+
+		select {
+		case <-d.quit:
+			return err
+		case <-time.After(time.Duration(attempt) * d.backoff):
+		}
+	}
+	return err
+}
+
+func (d *WebhookDispatcher) post(url string, body []byte) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-d.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(d.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(d.secret, timestamp, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close stops the dispatcher. Queued events which were not delivered yet are dropped.
+func (d *WebhookDispatcher) Close() error {
+	close(d.quit)
+	d.wg.Wait()
+	return nil
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the timestamp and
+// the payload, joined by a dot.
+func SignWebhookPayload(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte{'.'})
+	_, _ = mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
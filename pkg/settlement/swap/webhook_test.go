@@ -0,0 +1,100 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swap_test
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/settlement/swap"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestWebhookDispatcher(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret"
+	chequebookAddress := common.HexToAddress("0xcd")
+
+	var attempts atomic.Int32
+	received := make(chan swap.Event, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// fail the first attempt to exercise retries
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		timestamp := r.Header.Get(swap.WebhookTimestampHeader)
+		if ts, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(ts, 0)) > time.Minute {
+			t.Errorf("got timestamp %q, want the current unix time", timestamp)
+		}
+		if got, want := r.Header.Get(swap.WebhookSignatureHeader), swap.SignWebhookPayload([]byte(secret), timestamp, body); got != want {
+			t.Errorf("got signature %q, want %q", got, want)
+		}
+		if swap.SignWebhookPayload([]byte(secret), "0", body) == r.Header.Get(swap.WebhookSignatureHeader) {
+			t.Error("signature does not cover the timestamp")
+		}
+
+		var event swap.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Error(err)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	dispatcher := swap.NewWebhookDispatcher(log.Noop, []string{server.URL}, secret)
+	dispatcher.SetRetryBackoff(10 * time.Millisecond)
+	defer dispatcher.Close()
+
+	dispatcher.Notify(swap.Event{
+		Type:       swap.EventChequeReceived,
+		Peer:       "abcd",
+		Chequebook: &chequebookAddress,
+		Amount:     big.NewInt(50),
+	})
+
+	select {
+	case event := <-received:
+		if event.Type != swap.EventChequeReceived {
+			t.Fatalf("got event type %s, want %s", event.Type, swap.EventChequeReceived)
+		}
+		if event.Peer != "abcd" {
+			t.Fatalf("got peer %s, want %s", event.Peer, "abcd")
+		}
+		if event.Chequebook == nil || *event.Chequebook != chequebookAddress {
+			t.Fatalf("got chequebook %v, want %v", event.Chequebook, chequebookAddress)
+		}
+		if event.Amount.Cmp(big.NewInt(50)) != 0 {
+			t.Fatalf("got amount %d, want %d", event.Amount, 50)
+		}
+		if event.Timestamp == 0 {
+			t.Fatal("expected timestamp to be set")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("got %d attempts, want %d", got, 2)
+	}
+}
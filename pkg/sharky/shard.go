@@ -88,6 +88,7 @@ type shard struct {
 	reads       chan read     // channel for reads
 	errc        chan error    // result for reads
 	writes      chan write    // channel for writes
+	trims       chan trim     // channel for trims
 	index       uint8         // index of the shard
 	maxDataSize int           // max size of blobs
 	file        sharkyFile    // the file handle the shard is writing data to
@@ -138,6 +139,15 @@ func (sh *shard) process() {
 			free = sh.slots.out // re-enable popping a free slot next time we can write
 			writes = nil        // disable popping a write operation until there is a free slot
 
+			// hand the trim over to the slots with the popped free slot given back
+		case op := <-sh.trims:
+			if writes != nil {
+				sh.slots.in <- slot
+				free = sh.slots.out
+				writes = nil
+			}
+			sh.slots.trims <- op
+
 			// pop a free slot
 		case slot = <-free:
 			// only if there is one can we pop a chunk to write otherwise keep back pressure on writes
@@ -192,6 +202,32 @@ func (sh *shard) write(buf []byte, slot uint32) entry {
 	}
 }
 
+// truncate cuts the shard file after the given number of slots
+func (sh *shard) truncate(slots uint32) error {
+	end, err := sh.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if size := sh.offset(slots); size < end {
+		return sh.file.Truncate(size)
+	}
+	return nil
+}
+
+// trim truncates the shard file after the last slot in use
+func (sh *shard) trim(ctx context.Context) (uint32, error) {
+	res := make(chan trimResult, 1)
+	select {
+	case sh.trims <- trim{truncate: sh.truncate, res: res}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-sh.quit:
+		return 0, ErrQuitting
+	}
+	r := <-res
+	return r.size, r.err
+}
+
 // release frees the slot allowing new entry to overwrite
 func (sh *shard) release(ctx context.Context, slot uint32) error {
 	select {
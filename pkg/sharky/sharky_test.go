@@ -290,3 +290,77 @@ func TestConcurrency(t *testing.T) {
 		})
 	}
 }
+
+// TestTrim tests that trimming a shard truncates the shard file after the
+// last used slot while the used slots stay readable and writable.
+func TestTrim(t *testing.T) {
+	t.Parallel()
+
+	const datasize = 4
+	dir := t.TempDir()
+	s, err := sharky.New(&dirFS{basedir: dir}, 1, datasize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	ctx := context.Background()
+	shardSize := func() int64 {
+		t.Helper()
+		fi, err := os.Stat(filepath.Join(dir, "shard_000"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Size()
+	}
+
+	locs := make([]sharky.Location, 16)
+	for i := range locs {
+		locs[i], err = s.Write(ctx, []byte{byte(i), 0, 0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, loc := range locs[4:] {
+		if err := s.Release(ctx, loc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ops := s.Operations()
+	if ops != 28 {
+		t.Fatalf("got %d operations, want 28", ops)
+	}
+
+	slots, err := s.Trim(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 8 {
+		t.Fatalf("got %d slots, want 8", slots)
+	}
+	if size := shardSize(); size != 8*datasize {
+		t.Fatalf("got shard size %d, want %d", size, 8*datasize)
+	}
+
+	buf := make([]byte, datasize)
+	for i, loc := range locs[:4] {
+		if err := s.Read(ctx, loc, buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf[0] != byte(i) {
+			t.Fatalf("got data %x at slot %d, want %x", buf[0], loc.Slot, i)
+		}
+	}
+
+	loc, err := s.Write(ctx, []byte{0xff, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Slot != 4 {
+		t.Fatalf("got slot %d, want 4", loc.Slot)
+	}
+
+	if _, err := s.Trim(ctx, 1); err == nil {
+		t.Fatal("expected error trimming shard out of range")
+	}
+}
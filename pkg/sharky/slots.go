@@ -9,6 +9,18 @@ import (
 	"sync"
 )
 
+// trim models a request to cut the trailing free slots
+type trim struct {
+	truncate func(size uint32) error // truncates the shard file to size slots
+	res      chan trimResult         // to put the result through
+}
+
+// trimResult models the output of a trim request
+type trimResult struct {
+	size uint32 // number of slots after the trim
+	err  error
+}
+
 type slots struct {
 	data    []byte          // byteslice serving as bitvector: i-t bit set <>
 	size    uint32          // number of slots
@@ -16,16 +28,18 @@ type slots struct {
 	file    sharkyFile      // file to persist free slots across sessions
 	in      chan uint32     // incoming channel for free slots,
 	out     chan uint32     // outgoing channel for free slots
+	trims   chan trim       // incoming channel for trim requests
 	wg      *sync.WaitGroup // count started write operations
 	limboWG sync.WaitGroup  // wait for the limbo writes to in chan after the quit is closed
 }
 
 func newSlots(file sharkyFile, wg *sync.WaitGroup) *slots {
 	return &slots{
-		file: file,
-		in:   make(chan uint32),
-		out:  make(chan uint32),
-		wg:   wg,
+		file:  file,
+		in:    make(chan uint32),
+		out:   make(chan uint32),
+		trims: make(chan trim),
+		wg:    wg,
 	}
 }
 
@@ -92,6 +106,34 @@ func (sl *slots) pop() uint32 {
 	return head
 }
 
+// used returns the number of slots up to and including the last byte of the
+// bitvector with a slot in use.
+func (sl *slots) used() uint32 {
+	for i := len(sl.data) - 1; i >= 0; i-- {
+		if sl.data[i] != 0xff {
+			return uint32(i+1) * 8
+		}
+	}
+	return 0
+}
+
+// trim cuts the free slots following the last slot in use.
+func (sl *slots) trim(truncate func(uint32) error) (uint32, error) {
+	size := sl.used()
+	if size == sl.size {
+		return size, nil
+	}
+	if err := truncate(size); err != nil {
+		return sl.size, err
+	}
+	sl.data = sl.data[:size/8]
+	sl.size = size
+	if sl.head > size {
+		sl.head = size
+	}
+	return size, nil
+}
+
 // forever loop processing.
 func (sl *slots) process(quit chan struct{}) {
 	var head uint32     // the currently pending next free slots
@@ -116,6 +158,15 @@ func (sl *slots) process(quit chan struct{}) {
 		case out <- head:
 			out = nil
 
+			// trim the shard with the pending free slot given back
+		case op := <-sl.trims:
+			if out != nil {
+				sl.push(head)
+				out = nil
+			}
+			size, err := sl.trim(op.truncate)
+			op.res <- trimResult{size: size, err: err}
+
 			// quit is effective only after all initiated releases are received
 		case <-quit:
 			if out != nil {
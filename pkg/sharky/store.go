@@ -11,6 +11,7 @@ import (
 	"io/fs"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-multierror"
 )
@@ -33,6 +34,7 @@ type Store struct {
 	shards      []*shard        // shards
 	wg          *sync.WaitGroup // count started operations
 	quit        chan struct{}   // quit channel
+	ops         atomic.Uint64   // count of reads, writes and releases
 	metrics     metrics
 }
 
@@ -93,6 +95,7 @@ func (s *Store) create(index uint8, maxDataSize int, basedir fs.FS) (*shard, err
 		reads:       make(chan read),
 		errc:        make(chan error),
 		writes:      s.writes,
+		trims:       make(chan trim),
 		index:       index,
 		maxDataSize: maxDataSize,
 		file:        file.(sharkyFile),
@@ -120,6 +123,7 @@ func (s *Store) Read(ctx context.Context, loc Location, buf []byte) (err error)
 	sh := s.shards[loc.Shard]
 	select {
 	case sh.reads <- read{ctx: ctx, buf: buf[:loc.Length], slot: loc.Slot}:
+		s.ops.Add(1)
 		s.metrics.TotalReadCalls.Inc()
 	case <-ctx.Done():
 		return ctx.Err()
@@ -157,6 +161,7 @@ func (s *Store) Write(ctx context.Context, data []byte) (loc Location, err error
 
 	select {
 	case s.writes <- write{data, c}:
+		s.ops.Add(1)
 		s.metrics.TotalWriteCalls.Inc()
 	case <-s.quit:
 		return loc, ErrQuitting
@@ -191,6 +196,7 @@ func (s *Store) Write(ctx context.Context, data []byte) (loc Location, err error
 func (s *Store) Release(ctx context.Context, loc Location) error {
 	sh := s.shards[loc.Shard]
 	err := sh.release(ctx, loc.Slot)
+	s.ops.Add(1)
 	s.metrics.TotalReleaseCalls.Inc()
 	if err == nil {
 		shard := strconv.Itoa(int(sh.index))
@@ -202,3 +208,19 @@ func (s *Store) Release(ctx context.Context, loc Location) error {
 	}
 	return err
 }
+
+// Trim truncates the shard file after its last used slot, giving the space of
+// the trailing free slots back to the file system. It returns the number of
+// slots the shard holds after the trim.
+func (s *Store) Trim(ctx context.Context, shard uint8) (uint32, error) {
+	if int(shard) >= len(s.shards) {
+		return 0, fmt.Errorf("shard %d out of range", shard)
+	}
+	return s.shards[shard].trim(ctx)
+}
+
+// Operations returns the number of reads, writes and releases served by the
+// store. Its growth over time reflects how busy the store is.
+func (s *Store) Operations() uint64 {
+	return s.ops.Load()
+}
@@ -14,6 +14,9 @@ import (
 
 const maxDuration time.Duration = math.MaxInt64
 
+// allChunks is the key of the peers skipped for all the chunks.
+const allChunks = ""
+
 type List struct {
 	mtx sync.Mutex
 
@@ -81,22 +84,111 @@ func (l *List) Add(chunk, peer swarm.Address, expire time.Duration) {
 	l.skip[chunk.ByteString()][peer.ByteString()] = t
 }
 
+// AddPeer skips the peer for all the chunks until the expiration.
+func (l *List) AddPeer(peer swarm.Address, expire time.Duration) {
+	l.Add(swarm.NewAddress([]byte(allChunks)), peer, expire)
+}
+
+// ChunkPeers returns the peers skipped for the chunk, including the ones
+// skipped for all the chunks.
 func (l *List) ChunkPeers(ch swarm.Address) (peers []swarm.Address) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
 	now := time.Now().UnixNano()
 
-	if p, ok := l.skip[ch.ByteString()]; ok {
-		peers = make([]swarm.Address, 0, len(p))
-		for peer, exp := range p {
+	peers = l.appendPeers(peers, ch.ByteString(), now)
+	if ch.ByteString() != allChunks {
+		peers = l.appendPeers(peers, allChunks, now)
+	}
+
+	return peers
+}
+
+// Must be called under lock
+func (l *List) appendPeers(peers []swarm.Address, ch string, now int64) []swarm.Address {
+	for peer, exp := range l.skip[ch] {
+		if exp > now {
+			peers = append(peers, swarm.NewAddress([]byte(peer)))
+		}
+	}
+	return peers
+}
+
+// Entry is a peer skipped for a chunk until the expiration.
+type Entry struct {
+	Chunk  swarm.Address `json:"chunk"` // empty for the peers skipped for all the chunks
+	Peer   swarm.Address `json:"peer"`
+	Expiry int64         `json:"expiry"` // unix nanoseconds
+}
+
+// Forever reports whether the peer is skipped for the chunk forever.
+func (e Entry) Forever() bool {
+	return e.Expiry == maxDuration.Nanoseconds()
+}
+
+// Entries returns the entries of the list which have not expired.
+func (l *List) Entries() []Entry {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now().UnixNano()
+
+	var entries []Entry
+	for ch, peers := range l.skip {
+		for peer, exp := range peers {
 			if exp > now {
-				peers = append(peers, swarm.NewAddress([]byte(peer)))
+				entries = append(entries, Entry{
+					Chunk:  swarm.NewAddress([]byte(ch)),
+					Peer:   swarm.NewAddress([]byte(peer)),
+					Expiry: exp,
+				})
 			}
 		}
 	}
+	return entries
+}
 
-	return peers
+// Restore adds the entries which have not expired to the list.
+func (l *List) Restore(entries []Entry) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now().UnixNano()
+
+	for _, e := range entries {
+		if e.Expiry <= now {
+			continue
+		}
+		if _, ok := l.skip[e.Chunk.ByteString()]; !ok {
+			l.skip[e.Chunk.ByteString()] = make(map[string]int64)
+		}
+		l.skip[e.Chunk.ByteString()][e.Peer.ByteString()] = e.Expiry
+	}
+}
+
+// Clear removes the entries of the peer, all the entries if the peer is
+// zero, and returns the number of the removed entries.
+func (l *List) Clear(peer swarm.Address) int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	count := 0
+	for ch, peers := range l.skip {
+		if peer.IsZero() {
+			count += len(peers)
+			delete(l.skip, ch)
+			continue
+		}
+		if _, ok := peers[peer.ByteString()]; ok {
+			delete(peers, peer.ByteString())
+			count++
+		}
+		if len(peers) == 0 {
+			delete(l.skip, ch)
+		}
+	}
+	return count
 }
 
 func (l *List) PruneExpiresAfter(ch swarm.Address, d time.Duration) int {
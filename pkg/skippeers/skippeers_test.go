@@ -142,3 +142,82 @@ func TestPruneWorker(t *testing.T) {
 		t.Fatal("peer2 should be in skiplist")
 	}
 }
+
+func TestAddPeer(t *testing.T) {
+	t.Parallel()
+
+	skipList := skippeers.NewList(0)
+	t.Cleanup(func() { skipList.Close() })
+
+	chunk := swarm.RandAddress(t)
+	peer1 := swarm.RandAddress(t)
+	peer2 := swarm.RandAddress(t)
+
+	skipList.Add(chunk, peer1, time.Minute)
+	skipList.AddPeer(peer2, time.Millisecond*50)
+
+	if peers := skipList.ChunkPeers(chunk); len(peers) != 2 || !swarm.ContainsAddress(peers, peer2) {
+		t.Fatal("peer should be in skiplist for the chunk")
+	}
+
+	if !swarm.ContainsAddress(skipList.ChunkPeers(swarm.RandAddress(t)), peer2) {
+		t.Fatal("peer should be in skiplist for any chunk")
+	}
+
+	time.Sleep(time.Millisecond * 60)
+
+	if peers := skipList.ChunkPeers(chunk); len(peers) != 1 || !swarm.ContainsAddress(peers, peer1) {
+		t.Fatal("entry should be pruned")
+	}
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	skipList := skippeers.NewList(0)
+	t.Cleanup(func() { skipList.Close() })
+
+	chunk := swarm.RandAddress(t)
+	peer1 := swarm.RandAddress(t)
+	peer2 := swarm.RandAddress(t)
+
+	skipList.Add(chunk, peer1, time.Minute)
+	skipList.Forever(chunk, peer2)
+	skipList.AddPeer(peer1, time.Minute)
+	skipList.Add(swarm.RandAddress(t), peer2, -time.Minute)
+
+	entries := skipList.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for _, e := range entries {
+		if e.Peer.Equal(peer2) != e.Forever() {
+			t.Fatalf("entry of peer %s forever %t", e.Peer, e.Forever())
+		}
+	}
+
+	restored := skippeers.NewList(0)
+	t.Cleanup(func() { restored.Close() })
+
+	restored.Restore(entries)
+	if got := restored.ChunkPeers(chunk); !swarm.ContainsAddress(got, peer1) || !swarm.ContainsAddress(got, peer2) {
+		t.Fatalf("got chunk peers %v, want %v and %v", got, peer1, peer2)
+	}
+	if got := restored.ChunkPeers(swarm.RandAddress(t)); len(got) != 1 || !got[0].Equal(peer1) {
+		t.Fatalf("got chunk peers %v, want %v", got, peer1)
+	}
+
+	if n := restored.Clear(peer1); n != 2 {
+		t.Fatalf("cleared %d entries, want 2", n)
+	}
+	if got := restored.ChunkPeers(chunk); len(got) != 1 || !got[0].Equal(peer2) {
+		t.Fatalf("got chunk peers %v, want %v", got, peer2)
+	}
+
+	if n := restored.Clear(swarm.ZeroAddress); n != 1 {
+		t.Fatalf("cleared %d entries, want 1", n)
+	}
+	if got := restored.Entries(); len(got) != 0 {
+		t.Fatalf("got %d entries, want none", len(got))
+	}
+}
@@ -29,7 +29,14 @@ func (s *Steward) IsRetrievable(_ context.Context, addr swarm.Address) (bool, er
 	return addr.Equal(s.addr), nil
 }
 
-// LastAddress returns the last address given to the Reupload method call.
+// Repair implements steward.Interface Repair method.
+// The given address is recorded and no chunk is repaired.
+func (s *Steward) Repair(_ context.Context, addr swarm.Address, _ postage.Stamper) (int, error) {
+	s.addr = addr
+	return 0, nil
+}
+
+// LastAddress returns the last address given to the Reupload or Repair method call.
 func (s *Steward) LastAddress() swarm.Address {
 	return s.addr
 }
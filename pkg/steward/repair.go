@@ -0,0 +1,207 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package steward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/calmw/bee-tron/pkg/bmt"
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/encryption"
+	"github.com/calmw/bee-tron/pkg/encryption/store"
+	"github.com/calmw/bee-tron/pkg/file"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/file/redundancy/getter"
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+)
+
+var (
+	errNoParities      = errors.New("chunk is not retrievable and has no parities")
+	errInvalidRecovery = errors.New("recovered chunk is invalid")
+)
+
+// repairConfig is the configuration of the decoders which recover the
+// missing data shards, all the siblings are fetched at once.
+var repairConfig = getter.Config{
+	Strategy:     getter.RACE,
+	FetchTimeout: getter.DefaultFetchTimeout,
+	Logger:       log.Noop,
+}
+
+// Repair implements Interface.Repair method.
+// The root chunk must be retrievable, as it has no parities.
+func (s *steward) Repair(ctx context.Context, root swarm.Address, stamper postage.Stamper) (int, error) {
+	g := &netGetter{s.netGetter}
+
+	rootChunk, err := store.New(g).Get(ctx, root)
+	if err != nil {
+		return 0, fmt.Errorf("root chunk %s: %w", root, err)
+	}
+
+	uploaderSession := s.netStore.DirectUpload()
+	r := &repairer{
+		getter:    g,
+		refLength: len(root.Bytes()),
+		put: func(ch swarm.Chunk) error {
+			stamp, err := stamper.Stamp(ch.Address(), ch.Address())
+			if err != nil {
+				return fmt.Errorf("stamping chunk %s: %w", ch.Address(), err)
+			}
+			return uploaderSession.Put(ctx, ch.WithStamp(stamp))
+		},
+	}
+
+	if err := r.repair(ctx, rootChunk.Data()); err != nil {
+		return r.repaired, errors.Join(
+			fmt.Errorf("repair of %s failed: %w", root, err),
+			uploaderSession.Cleanup(),
+		)
+	}
+
+	return r.repaired, uploaderSession.Done(root)
+}
+
+// repairer traverses the trie of a content and reconstructs the data
+// shards of the intermediate chunks which are not retrievable.
+type repairer struct {
+	getter    storage.Getter
+	refLength int
+	put       func(swarm.Chunk) error
+	repaired  int
+}
+
+// repair checks the children of the chunk with the given (decrypted) data
+// and descends into the ones which are intermediate chunks.
+func (r *repairer) repair(ctx context.Context, data []byte) error {
+	level, span := chunkSpan(data)
+	if span <= swarm.ChunkSize {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	encrypted := encryption.IsEncryptedReferenceSize(r.refLength)
+	parities := 0
+	if level != redundancy.NONE {
+		_, parities = file.ReferenceCount(span, level, encrypted)
+	}
+
+	payload := data[swarm.SpanSize:]
+	size, err := file.ChunkPayloadSize(payload)
+	if err != nil {
+		return err
+	}
+	addrs, shardCnt := file.ChunkAddresses(payload[:size], parities, r.refLength)
+
+	chunks := make([]swarm.Chunk, shardCnt)
+	var missing []int
+	for i := 0; i < shardCnt; i++ {
+		ch, err := r.getter.Get(ctx, addrs[i])
+		switch {
+		case errors.Is(err, storage.ErrNotFound), errors.Is(err, topology.ErrNotFound):
+			missing = append(missing, i)
+		case err != nil:
+			return err
+		default:
+			chunks[i] = ch
+		}
+	}
+
+	if len(missing) > 0 {
+		if parities == 0 {
+			return fmt.Errorf("%w: %s", errNoParities, addrs[missing[0]])
+		}
+		if err := r.recover(ctx, addrs, shardCnt, missing, chunks); err != nil {
+			return err
+		}
+	}
+
+	for i, ch := range chunks {
+		chunkData := ch.Data()
+		if encrypted {
+			cursor := i * r.refLength
+			key := payload[cursor+swarm.HashSize : cursor+r.refLength]
+			if chunkData, err = store.DecryptChunkData(chunkData, key); err != nil {
+				return err
+			}
+		}
+		if err := r.repair(ctx, chunkData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recover reconstructs the missing data shards of an intermediate chunk with
+// the redundancy decoder and uploads them.
+func (r *repairer) recover(ctx context.Context, addrs []swarm.Address, shardCnt int, missing []int, chunks []swarm.Chunk) error {
+	done := make(chan struct{})
+	// the recovered chunks are uploaded with a stamp instead of being saved
+	noop := storage.PutterFunc(func(context.Context, swarm.Chunk) error { return nil })
+	d := getter.New(addrs, shardCnt, r.getter, noop, func(error) { close(done) }, repairConfig)
+	// the prefetching of the decoder must not outlive the repair
+	defer func() { <-done }()
+
+	for _, i := range missing {
+		ch, err := d.Get(ctx, addrs[i])
+		if err != nil {
+			return fmt.Errorf("recover chunk %s: %w", addrs[i], err)
+		}
+		ch, err = trimRecovered(ch)
+		if err != nil {
+			return err
+		}
+		if err := r.put(ch); err != nil {
+			return err
+		}
+		chunks[i] = ch
+		r.repaired++
+	}
+
+	return nil
+}
+
+// trimRecovered cuts the zero padding off the recovered last data shard of
+// an intermediate chunk, which is shorter than the other shards unless it is
+// encrypted.
+func trimRecovered(ch swarm.Chunk) (swarm.Chunk, error) {
+	if cac.Valid(ch) {
+		return ch, nil
+	}
+
+	data := ch.Data()
+	if len(data) < swarm.SpanSize {
+		return nil, fmt.Errorf("%w: %s", errInvalidRecovery, ch.Address())
+	}
+	_, span := chunkSpan(data)
+	if span <= swarm.ChunkSize {
+		data = data[:swarm.SpanSize+span]
+	} else if size, err := file.ChunkPayloadSize(data[swarm.SpanSize:]); err == nil {
+		data = data[:swarm.SpanSize+size]
+	}
+
+	ch = swarm.NewChunk(ch.Address(), data)
+	if !cac.Valid(ch) {
+		return nil, fmt.Errorf("%w: %s", errInvalidRecovery, ch.Address())
+	}
+	return ch, nil
+}
+
+// chunkSpan returns the redundancy level and the span of the chunk data.
+func chunkSpan(data []byte) (redundancy.Level, uint64) {
+	level, span := redundancy.DecodeSpan(data[:swarm.SpanSize])
+	return level, bmt.LengthFromSpan(span)
+}
@@ -29,6 +29,12 @@ type Interface interface {
 	// IsRetrievable checks whether the content
 	// on the given address is retrievable.
 	IsRetrievable(context.Context, swarm.Address) (bool, error)
+
+	// Repair reconstructs the data chunks of the content with
+	// redundancy on the given address which are not retrievable
+	// from their parities and reuploads them. It returns the
+	// number of the repaired chunks.
+	Repair(context.Context, swarm.Address, postage.Stamper) (int, error)
 }
 
 type steward struct {
@@ -81,12 +87,38 @@ func (s *steward) Reupload(ctx context.Context, root swarm.Address, stamper post
 }
 
 // IsRetrievable implements Interface.IsRetrievable method.
+// The chunks are retrieved in batches if the retrieval supports it.
 func (s *steward) IsRetrievable(ctx context.Context, root swarm.Address) (bool, error) {
 	fn := func(a swarm.Address) error {
 		_, err := s.netGetter.RetrieveChunk(ctx, a, swarm.ZeroAddress)
 		return err
 	}
-	switch err := s.netTraverser.Traverse(ctx, root, fn); {
+
+	var batch []swarm.Address
+	flush := func() error { return nil }
+	if br, ok := s.netGetter.(retrieval.BatchRetriever); ok {
+		flush = func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			err := br.RetrieveChunks(ctx, batch, func(int, swarm.Chunk, error) {})
+			batch = batch[:0]
+			return err
+		}
+		fn = func(a swarm.Address) error {
+			batch = append(batch, a)
+			if len(batch) < retrieval.MaxBatchSize {
+				return nil
+			}
+			return flush()
+		}
+	}
+
+	err := s.netTraverser.Traverse(ctx, root, fn)
+	if err == nil {
+		err = flush()
+	}
+	switch {
 	case errors.Is(err, storage.ErrNotFound):
 		return false, nil
 	case errors.Is(err, topology.ErrNotFound):
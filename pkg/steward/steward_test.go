@@ -14,9 +14,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/calmw/bee-tron/pkg/bmt"
+	"github.com/calmw/bee-tron/pkg/file"
 	"github.com/calmw/bee-tron/pkg/file/pipeline/builder"
 	"github.com/calmw/bee-tron/pkg/file/redundancy"
 	postagetesting "github.com/calmw/bee-tron/pkg/postage/mock"
+	"github.com/calmw/bee-tron/pkg/retrieval"
 	"github.com/calmw/bee-tron/pkg/steward"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
@@ -118,6 +121,150 @@ func TestSteward(t *testing.T) {
 	}
 }
 
+func TestIsRetrievableBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx            = context.Background()
+		data           = make([]byte, 300*4096)
+		chunkStore     = inmemchunkstore.New()
+		store          = mockstorer.NewWithChunkStore(chunkStore)
+		batchRetrieval = &batchRetriever{localRetriever: &localRetriever{ChunkStore: chunkStore}}
+		s              = steward.New(store, batchRetrieval, chunkStore)
+	)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	pipe := builder.NewPipelineBuilder(ctx, chunkStore, false, redundancy.NONE)
+	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isRetrievable, err := s.IsRetrievable(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRetrievable {
+		t.Fatalf("content on %q should be retrievable", addr)
+	}
+
+	batchRetrieval.mu.Lock()
+	defer batchRetrieval.mu.Unlock()
+
+	if batchRetrieval.batches < 2 {
+		t.Fatalf("got %d batches, want at least %d", batchRetrieval.batches, 2)
+	}
+	if batchRetrieval.maxSize > retrieval.MaxBatchSize {
+		t.Fatalf("got batch of %d chunks, want at most %d", batchRetrieval.maxSize, retrieval.MaxBatchSize)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx        = context.Background()
+		data       = make([]byte, 20*swarm.ChunkSize-100)
+		chunkStore = inmemchunkstore.New()
+		store      = mockstorer.NewWithChunkStore(chunkStore)
+		s          = steward.New(store, &localRetriever{ChunkStore: chunkStore}, chunkStore)
+		stamper    = postagetesting.NewStamper()
+	)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	pipe := builder.NewPipelineBuilder(ctx, chunkStore, false, redundancy.MEDIUM)
+	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := chunkStore.Get(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	level, span := redundancy.DecodeSpan(root.Data()[:swarm.SpanSize])
+	_, parities := file.ReferenceCount(bmt.LengthFromSpan(span), level, false)
+	payload := root.Data()[swarm.SpanSize:]
+	size, err := file.ChunkPayloadSize(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs, shardCnt := file.ChunkAddresses(payload[:size], parities, swarm.HashSize)
+
+	// the first and the last, shorter data shards are lost
+	lost := []swarm.Address{addrs[0], addrs[shardCnt-1]}
+	for _, a := range lost {
+		if err := chunkStore.Delete(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the reuploaded chunks are stored again
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for op := range store.PusherFeed() {
+			if op.Chunk.Stamp() == nil {
+				t.Errorf("chunk %s reuploaded without stamp", op.Chunk.Address())
+			}
+			if err := chunkStore.Put(ctx, op.Chunk); err != nil {
+				t.Error(err)
+			}
+			if has, _ := chunkStore.Has(ctx, lost[1]); has {
+				return
+			}
+		}
+	}()
+
+	n, err := s.Repair(ctx, addr, stamper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(lost) {
+		t.Fatalf("got %d repaired chunks, want %d", n, len(lost))
+	}
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("took too long to finish")
+	}
+
+	isRetrievable, err := s.IsRetrievable(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRetrievable {
+		t.Fatalf("repaired content on %q should be retrievable", addr)
+	}
+}
+
+type batchRetriever struct {
+	*localRetriever
+	mu      sync.Mutex
+	batches int
+	maxSize int
+}
+
+func (br *batchRetriever) RetrieveChunks(ctx context.Context, addrs []swarm.Address, fn func(int, swarm.Chunk, error)) error {
+	br.mu.Lock()
+	br.batches++
+	br.maxSize = max(br.maxSize, len(addrs))
+	br.mu.Unlock()
+
+	errs := make([]error, len(addrs))
+	for i, addr := range addrs {
+		var ch swarm.Chunk
+		ch, errs[i] = br.RetrieveChunk(ctx, addr, swarm.ZeroAddress)
+		fn(i, ch, errs[i])
+	}
+	return errors.Join(errs...)
+}
+
 type localRetriever struct {
 	storage.ChunkStore
 	mu              sync.Mutex
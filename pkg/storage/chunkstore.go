@@ -6,6 +6,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
@@ -21,12 +22,31 @@ type Getter interface {
 	Get(context.Context, swarm.Address) (swarm.Chunk, error)
 }
 
+// BatchGetter is the interface that wraps the GetMany method.
+type BatchGetter interface {
+	// GetMany gets the chunks of the addresses and calls the function with
+	// the index of every address and its chunk or error as soon as the chunk
+	// is got or fails, once for every address and possibly concurrently.
+	// GetMany returns after the last call with the errors of the chunks which
+	// could not be got joined.
+	GetMany(context.Context, []swarm.Address, func(int, swarm.Chunk, error)) error
+}
+
 // Putter is the interface that wraps the basic Put method.
 type Putter interface {
 	// Put a chunk into the store alongside with its postage stamp.
 	Put(context.Context, swarm.Chunk) error
 }
 
+// BatchPutter is the interface that wraps the PutBatch method.
+type BatchPutter interface {
+	// PutBatch puts the chunks into the store in one commit and returns the
+	// number of chunks stored. Either all the chunks are stored or none,
+	// except for the chunks rejected with ErrOverwriteNewerChunk which are
+	// skipped and reported in the returned error.
+	PutBatch(context.Context, []swarm.Chunk) (int, error)
+}
+
 // Deleter is the interface that wraps the basic Delete method.
 type Deleter interface {
 	// Delete a chunk by the given swarm.Address.
@@ -56,6 +76,32 @@ func (f PutterFunc) Put(ctx context.Context, chunk swarm.Chunk) error {
 	return f(ctx, chunk)
 }
 
+// PutBatch puts the chunks with the PutBatch method of the putter if it is a
+// BatchPutter, otherwise one by one with the Put method, in which case the
+// chunks put before an error are not rolled back.
+func PutBatch(ctx context.Context, p Putter, chunks []swarm.Chunk) (int, error) {
+	if bp, ok := p.(BatchPutter); ok {
+		return bp.PutBatch(ctx, chunks)
+	}
+
+	var (
+		n       int
+		skipped error
+	)
+	for _, ch := range chunks {
+		err := p.Put(ctx, ch)
+		switch {
+		case errors.Is(err, ErrOverwriteNewerChunk):
+			skipped = errors.Join(skipped, err)
+		case err != nil:
+			return n, err
+		default:
+			n++
+		}
+	}
+	return n, skipped
+}
+
 type GetterFunc func(context.Context, swarm.Address) (swarm.Chunk, error)
 
 func (f GetterFunc) Get(ctx context.Context, address swarm.Address) (swarm.Chunk, error) {
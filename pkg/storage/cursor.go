@@ -0,0 +1,91 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("storage: invalid cursor")
+
+// Cursor is an opaque continuation token of an iteration. It allows a long
+// running iteration to be resumed after the last reported item instead of
+// restarting from the beginning. The zero Cursor starts the iteration from
+// the beginning.
+type Cursor struct {
+	last string // ID of the last reported item
+}
+
+// IsZero reports whether the cursor starts the iteration from the beginning.
+func (c Cursor) IsZero() bool {
+	return c.last == ""
+}
+
+// String returns the printable representation of the cursor which can be
+// parsed back with ParseCursor.
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.last))
+}
+
+// ParseCursor decodes the cursor returned by Cursor.String. The empty string
+// decodes to the zero Cursor.
+func ParseCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return Cursor{last: string(b)}, nil
+}
+
+// IterateWithCursorFn iterates through the Items of the store in the Key.Namespace.
+// The cursor passed along the result continues the iteration after the result.
+type IterateWithCursorFn func(Result, Cursor) (bool, error)
+
+// IterateFrom iterates the items matched by the query starting after the
+// cursor and returns the cursor of the last item passed to fn. The items
+// added or removed while the iteration is interrupted are reported only if
+// they are after the cursor. The query must load whole items in ascending
+// order, as the cursor is built from the Item IDs.
+func IterateFrom(r Reader, q Query, c Cursor, fn IterateWithCursorFn) (Cursor, error) {
+	if q.ItemProperty != QueryItem || q.Order != KeyAscendingOrder {
+		return c, fmt.Errorf("cursor requires items in ascending order: %w", ErrInvalidQuery)
+	}
+
+	// the prefix which limits the iteration, if any
+	var within string
+	start := c
+	if !start.IsZero() {
+		if !q.PrefixAtStart {
+			within = q.Prefix
+		}
+		q.Prefix = start.last
+		q.PrefixAtStart = true
+		q.SkipFirst = false
+	}
+
+	err := r.Iterate(q, func(res Result) (bool, error) {
+		id := res.Entry.ID()
+		if !start.IsZero() {
+			if id <= start.last {
+				return false, nil
+			}
+			if !strings.HasPrefix(id, within) {
+				return true, nil
+			}
+		}
+		next := Cursor{last: id}
+		stop, err := fn(res, next)
+		if err != nil {
+			return true, err
+		}
+		c = next
+		return stop, nil
+	})
+	return c, err
+}
@@ -5,6 +5,7 @@
 package inmemstore
 
 import (
+	"container/list"
 	"errors"
 	"fmt"
 	"strings"
@@ -20,13 +21,76 @@ const (
 
 // Store implements an in-memory Store. We will use the hashicorp/go-radix implementation.
 // This pkg provides a mutable radix which gives O(k) lookup and ordered iteration.
+//
+// The store is unbounded unless it is created with the WithMaxEntries or
+// WithMaxBytes options, in which case the least recently used entries are
+// evicted once a bound is exceeded.
 type Store struct {
 	st *radix.Tree
 	mu sync.RWMutex
+
+	maxEntries int
+	maxBytes   int
+
+	lruMu   sync.Mutex // lruMu guards lru, entries, size and evicted.
+	lru     *list.List // keys of the entries, most recently used in front
+	entries map[string]*list.Element
+	size    int // total size of the keys and values
+	evicted uint64
+}
+
+// Option configures the Store.
+type Option func(*Store)
+
+// WithMaxEntries bounds the number of entries of the store.
+func WithMaxEntries(n int) Option {
+	return func(s *Store) { s.maxEntries = n }
+}
+
+// WithMaxBytes bounds the total size of the keys and values of the store.
+func WithMaxBytes(n int) Option {
+	return func(s *Store) { s.maxBytes = n }
+}
+
+func New(opts ...Option) *Store {
+	s := &Store{st: radix.New()}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.bounded() {
+		s.lru = list.New()
+		s.entries = make(map[string]*list.Element)
+	}
+	return s
 }
 
-func New() *Store {
-	return &Store{st: radix.New()}
+func (s *Store) bounded() bool {
+	return s.maxEntries > 0 || s.maxBytes > 0
+}
+
+// Evicted returns the number of entries evicted to keep the store within its
+// bounds.
+func (s *Store) Evicted() uint64 {
+	if !s.bounded() {
+		return 0
+	}
+
+	s.lruMu.Lock()
+	defer s.lruMu.Unlock()
+	return s.evicted
+}
+
+// touch marks the entry of the key as the most recently used.
+func (s *Store) touch(k string) {
+	if !s.bounded() {
+		return
+	}
+
+	s.lruMu.Lock()
+	if e, ok := s.entries[k]; ok {
+		s.lru.MoveToFront(e)
+	}
+	s.lruMu.Unlock()
 }
 
 func key(i storage.Key) string {
@@ -38,12 +102,14 @@ func idFromKey(key, pfx string) string {
 }
 
 func (s *Store) Get(i storage.Item) error {
+	k := key(i)
 	s.mu.RLock()
-	val, found := s.st.Get(key(i))
+	val, found := s.st.Get(k)
 	s.mu.RUnlock()
 	if !found {
 		return storage.ErrNotFound
 	}
+	s.touch(k)
 
 	err := i.Unmarshal(val.([]byte))
 	if err != nil {
@@ -84,10 +150,51 @@ func (s *Store) put(i storage.Item) error {
 		return fmt.Errorf("failed marshaling item: %w", err)
 	}
 
-	s.st.Insert(key(i), val)
+	k := key(i)
+	old, updated := s.st.Insert(k, val)
+	if !s.bounded() {
+		return nil
+	}
+
+	s.lruMu.Lock()
+	defer s.lruMu.Unlock()
+
+	if updated {
+		s.size -= len(old.([]byte))
+		s.lru.MoveToFront(s.entries[k])
+	} else {
+		s.size += len(k)
+		s.entries[k] = s.lru.PushFront(k)
+	}
+	s.size += len(val)
+
+	// evict the least recently used entries, but never the one just put
+	for s.lru.Len() > 1 && s.overBounds() {
+		s.remove(s.lru.Back().Value.(string))
+		s.evicted++
+	}
 	return nil
 }
 
+// overBounds reports whether the store exceeds one of its bounds.
+// Must be called under lruMu.
+func (s *Store) overBounds() bool {
+	return (s.maxEntries > 0 && s.lru.Len() > s.maxEntries) ||
+		(s.maxBytes > 0 && s.size > s.maxBytes)
+}
+
+// remove deletes the entry of the key from the tree and the LRU list.
+// Must be called under both locks.
+func (s *Store) remove(k string) {
+	val, found := s.st.Delete(k)
+	if !found {
+		return
+	}
+	s.lru.Remove(s.entries[k])
+	delete(s.entries, k)
+	s.size -= len(k) + len(val.([]byte))
+}
+
 func (s *Store) Delete(i storage.Item) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -95,7 +202,14 @@ func (s *Store) Delete(i storage.Item) error {
 }
 
 func (s *Store) delete(i storage.Item) error {
-	s.st.Delete(key(i))
+	if !s.bounded() {
+		s.st.Delete(key(i))
+		return nil
+	}
+
+	s.lruMu.Lock()
+	s.remove(key(i))
+	s.lruMu.Unlock()
 	return nil
 }
 
@@ -5,12 +5,45 @@
 package inmemstore_test
 
 import (
+	"bytes"
+	"context"
+	"strconv"
 	"testing"
 
+	"github.com/calmw/bee-tron/pkg/storage"
 	inmem "github.com/calmw/bee-tron/pkg/storage/inmemstore"
 	"github.com/calmw/bee-tron/pkg/storage/storagetest"
+	"github.com/calmw/bee-tron/pkg/storage/storageutil"
 )
 
+// evictionItem is a storage.Item with an ID and a payload of any size.
+type evictionItem struct {
+	id  int
+	buf []byte
+}
+
+func (i *evictionItem) ID() string { return strconv.Itoa(i.id) }
+
+func (evictionItem) Namespace() string { return "eviction" }
+
+func (i *evictionItem) Marshal() ([]byte, error) { return bytes.Clone(i.buf), nil }
+
+func (i *evictionItem) Unmarshal(buf []byte) error {
+	i.buf = bytes.Clone(buf)
+	return nil
+}
+
+func (i *evictionItem) Clone() storage.Item {
+	if i == nil {
+		return nil
+	}
+	return &evictionItem{id: i.id, buf: bytes.Clone(i.buf)}
+}
+
+func (i evictionItem) String() string {
+	return storageutil.JoinFields(i.Namespace(), i.ID())
+}
+
 func TestStore(t *testing.T) {
 	t.Parallel()
 
@@ -30,3 +63,115 @@ func TestBatchedStore(t *testing.T) {
 func BenchmarkBatchedStore(b *testing.B) {
 	storagetest.BenchmarkBatchedStore(b, inmem.New())
 }
+
+func TestBoundedStore(t *testing.T) {
+	t.Parallel()
+
+	storagetest.TestStore(t, inmem.New(inmem.WithMaxEntries(1<<20), inmem.WithMaxBytes(1<<30)))
+}
+
+func TestStoreEviction(t *testing.T) {
+	t.Parallel()
+
+	item := func(i int) *evictionItem {
+		return &evictionItem{id: i, buf: bytes.Repeat([]byte{byte(i)}, 100)}
+	}
+	has := func(t *testing.T, s *inmem.Store, i int) bool {
+		t.Helper()
+		has, err := s.Has(item(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return has
+	}
+
+	t.Run("max entries", func(t *testing.T) {
+		t.Parallel()
+
+		s := inmem.New(inmem.WithMaxEntries(3))
+		for i := 0; i < 3; i++ {
+			if err := s.Put(item(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// reading the oldest entry makes the second one the least recently used
+		if err := s.Get(item(0)); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Put(item(3)); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, want := range []bool{true, false, true, true} {
+			if have := has(t, s, i); have != want {
+				t.Fatalf("Has(%d): want %t, have %t", i, want, have)
+			}
+		}
+		if have := s.Evicted(); have != 1 {
+			t.Fatalf("Evicted(): want 1, have %d", have)
+		}
+
+		// deleted entries free their slot
+		if err := s.Delete(item(0)); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Put(item(4)); err != nil {
+			t.Fatal(err)
+		}
+		if have := s.Evicted(); have != 1 {
+			t.Fatalf("Evicted(): want 1, have %d", have)
+		}
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		t.Parallel()
+
+		s := inmem.New(inmem.WithMaxBytes(1000))
+		for i := 0; i < 20; i++ {
+			if err := s.Put(item(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		n, err := s.Count(item(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 || n >= 10 {
+			t.Fatalf("Count(...): want between 1 and 9 entries, have %d", n)
+		}
+		if have := s.Evicted(); have != uint64(20-n) {
+			t.Fatalf("Evicted(): want %d, have %d", 20-n, have)
+		}
+		if !has(t, s, 19) {
+			t.Fatal("most recent entry evicted")
+		}
+		if has(t, s, 0) {
+			t.Fatal("oldest entry not evicted")
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+
+		s := inmem.New(inmem.WithMaxEntries(2))
+		b := s.Batch(context.Background())
+		for i := 0; i < 5; i++ {
+			if err := b.Put(item(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := b.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		n, err := s.Count(item(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 {
+			t.Fatalf("Count(...): want 2, have %d", n)
+		}
+	})
+}
@@ -7,6 +7,7 @@ package leveldbstore_test
 import (
 	"testing"
 
+	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/leveldbstore"
 	"github.com/calmw/bee-tron/pkg/storage/storagetest"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -55,3 +56,11 @@ func BenchmarkBatchedStore(b *testing.B) {
 	b.Cleanup(func() { _ = st.Close() })
 	storagetest.BenchmarkBatchedStore(b, st)
 }
+
+func TestCrashConsistency(t *testing.T) {
+	t.Parallel()
+
+	storagetest.TestCrashConsistency(t, func(path string) (storage.BatchStore, error) {
+		return leveldbstore.New(path, nil)
+	})
+}
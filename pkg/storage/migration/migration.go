@@ -31,6 +31,14 @@ var (
 // The steps are separated by groups so different lists of steps can run individually, for example,
 // two groups of migrations that run before and after the storer is initialized.
 func Migrate(s storage.IndexStore, group string, sm Steps) error {
+	return MigrateWithProgress(s, group, sm, nil, nil)
+}
+
+// MigrateWithProgress migrates the storage to the latest version like Migrate
+// and reports the progress of the steps, estimated with their plans, to p.
+// If p is in the dry run mode, the plans of the pending steps are logged
+// instead of running the steps and the version is left unchanged.
+func MigrateWithProgress(s storage.IndexStore, group string, sm Steps, plans Plans, p *Progress) error {
 	if err := ValidateVersions(sm); err != nil {
 		return err
 	}
@@ -40,11 +48,30 @@ func Migrate(s storage.IndexStore, group string, sm Steps) error {
 		return err
 	}
 
+	latest := LatestVersion(sm)
+	p.init(group, currentVersion, latest)
+
 	for nextVersion := currentVersion + 1; ; nextVersion++ {
 		stepFn, ok := sm[nextVersion]
 		if !ok {
 			return nil
 		}
+
+		var estimated uint64
+		plan := plans[nextVersion]
+		if plan.Estimate != nil && p != nil {
+			estimated, err = plan.Estimate()
+			if err != nil {
+				return fmt.Errorf("estimate migration step %d: %w", nextVersion, err)
+			}
+		}
+
+		if p.DryRun() {
+			p.planned(group, nextVersion, latest, plan, estimated)
+			continue
+		}
+
+		p.start(group, nextVersion, latest, plan, estimated)
 		err := stepFn()
 		if err != nil {
 			return err
@@ -53,6 +80,7 @@ func Migrate(s storage.IndexStore, group string, sm Steps) error {
 		if err != nil {
 			return err
 		}
+		p.finish()
 	}
 }
 
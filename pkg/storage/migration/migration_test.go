@@ -12,11 +12,13 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/calmw/bee-tron/pkg/log"
 	storage "github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/inmemstore"
 	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storage/storagetest"
 	"github.com/calmw/bee-tron/pkg/storage/storageutil"
+	"github.com/google/go-cmp/cmp"
 )
 
 var errStep = errors.New("step error")
@@ -278,6 +280,93 @@ func TestMigrate(t *testing.T) {
 	})
 }
 
+func TestMigrateWithProgress(t *testing.T) {
+	t.Parallel()
+
+	objT1 := &obj{id: 111, val: 1}
+	objT2 := &obj{id: 222, val: 2}
+
+	newSteps := func(s storage.Writer, p *migration.Progress) migration.Steps {
+		return migration.Steps{
+			1: func() error {
+				p.Add(1)
+				return s.Put(objT1)
+			},
+			2: func() error {
+				p.Add(1)
+				return s.Put(objT2)
+			},
+		}
+	}
+	plans := migration.Plans{
+		1: {Description: "put objT1", Estimate: func() (uint64, error) { return 1, nil }},
+		2: {Description: "put objT2", Estimate: func() (uint64, error) { return 2, nil }},
+	}
+
+	t.Run("migrate", func(t *testing.T) {
+		t.Parallel()
+
+		s := inmemstore.New()
+		p := migration.NewProgress(log.Noop, false)
+
+		if err := migration.MigrateWithProgress(s, "migration", newSteps(s, p), plans, p); err != nil {
+			t.Fatalf("MigrateWithProgress() unexpected error: %v", err)
+		}
+
+		assertObjectExists(t, s, objT1, objT2)
+
+		want := []migration.Status{{
+			Group:       "migration",
+			Version:     2,
+			Latest:      2,
+			Description: "put objT2",
+			Estimated:   2,
+			Done:        1,
+		}}
+		if diff := cmp.Diff(want, p.Status()); diff != "" {
+			t.Fatalf("Status() mismatch (-want +have):\n%s", diff)
+		}
+		if have := p.Status()[0].Percent(); have != 50 {
+			t.Fatalf("Percent() = %v must be 50", have)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+
+		s := inmemstore.New()
+		p := migration.NewProgress(log.Noop, true)
+
+		if err := migration.MigrateWithProgress(s, "migration", newSteps(s, p), plans, p); err != nil {
+			t.Fatalf("MigrateWithProgress() unexpected error: %v", err)
+		}
+
+		newVersion, err := migration.Version(s, "migration")
+		if err != nil {
+			t.Fatalf("Version() unexpected error: %v", err)
+		}
+		if newVersion != 0 {
+			t.Fatalf("new version = %v must be 0", newVersion)
+		}
+		for _, key := range []storage.Key{objT1, objT2} {
+			if has, _ := s.Has(key); has {
+				t.Fatalf("key = %v must not exist", key)
+			}
+		}
+
+		want := []migration.Status{{
+			Group:       "migration",
+			Latest:      2,
+			Description: "put objT2",
+			Estimated:   2,
+			DryRun:      true,
+		}}
+		if diff := cmp.Diff(want, p.Status()); diff != "" {
+			t.Fatalf("Status() mismatch (-want +have):\n%s", diff)
+		}
+	})
+}
+
 func assertObjectExists(t *testing.T, s storage.BatchStore, keys ...storage.Key) {
 	t.Helper()
 
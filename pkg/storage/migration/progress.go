@@ -0,0 +1,202 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/log"
+)
+
+type (
+	// Plan describes the mutations of a migration step.
+	Plan struct {
+		// Description is the human readable summary of the mutations.
+		Description string
+		// Estimate returns the number of items the step is expected to
+		// migrate, it may be nil if the step cannot estimate its work.
+		Estimate func() (uint64, error)
+	}
+	// Plans is a map of versions and the plans of their migration functions
+	Plans = map[uint64]Plan
+)
+
+// Status is the migration state of a group.
+type Status struct {
+	Group       string
+	Version     uint64 // version of the running or of the last run step
+	Latest      uint64 // latest version of the group
+	Description string // description of the running or of the last run step
+	Estimated   uint64 // estimated number of items of the step
+	Done        uint64 // number of items migrated by the step
+	Running     bool
+	DryRun      bool
+}
+
+// Percent returns the progress of the step as a percentage, which is only
+// meaningful if the step estimated its items.
+func (s Status) Percent() float64 {
+	switch {
+	case s.Estimated == 0 && s.Running:
+		return 0
+	case s.Estimated == 0, s.Done >= s.Estimated:
+		return 100
+	}
+	return float64(s.Done) * 100 / float64(s.Estimated)
+}
+
+// Progress tracks and logs the progress of the migrations. The steps report
+// the items they migrate with Add. A nil Progress is valid and ignores the
+// reports, so that the steps can be run without tracking.
+type Progress struct {
+	logger log.Logger
+	dryRun bool
+
+	mu       sync.Mutex
+	groups   []string
+	statuses map[string]*Status
+	current  *Status
+	logged   uint64 // last logged tenth of the progress of the current step
+}
+
+// NewProgress returns a new Progress. In the dry run mode the pending steps
+// are not run, only their plans are logged.
+func NewProgress(logger log.Logger, dryRun bool) *Progress {
+	return &Progress{
+		logger:   logger.WithName("migration").Register(),
+		dryRun:   dryRun,
+		statuses: make(map[string]*Status),
+	}
+}
+
+// DryRun reports whether the migrations only log their plans.
+func (p *Progress) DryRun() bool {
+	return p != nil && p.dryRun
+}
+
+// Add reports n items migrated by the running step.
+func (p *Progress) Add(n uint64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current == nil {
+		return
+	}
+	s := p.current
+	s.Done += n
+	if s.Estimated == 0 {
+		return
+	}
+	if tenth := uint64(s.Percent()) / 10; tenth > p.logged {
+		p.logged = tenth
+		p.logger.Info("migration step progress", "group", s.Group, "version", s.Version, "done", s.Done, "estimated", s.Estimated, "percent", tenth*10)
+	}
+}
+
+// Status returns the migration state of the groups in the order they were
+// migrated.
+func (p *Progress) Status() []Status {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]Status, 0, len(p.groups))
+	for _, g := range p.groups {
+		statuses = append(statuses, *p.statuses[g])
+	}
+	return statuses
+}
+
+// status returns the state of the group, registering it if needed.
+// Must be called under lock.
+func (p *Progress) status(group string, latest uint64) *Status {
+	s, ok := p.statuses[group]
+	if !ok {
+		s = &Status{Group: group, DryRun: p.dryRun}
+		p.statuses[group] = s
+		p.groups = append(p.groups, group)
+	}
+	s.Latest = latest
+	return s
+}
+
+// init registers the group and its current version.
+func (p *Progress) init(group string, current, latest uint64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.status(group, latest)
+	s.Version = current
+	if current < latest {
+		p.logger.Info("migration pending", "group", group, "version", current, "latest", latest, "dry_run", p.dryRun)
+	}
+}
+
+// start marks the step of the group as running.
+func (p *Progress) start(group string, version, latest uint64, plan Plan, estimated uint64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.status(group, latest)
+	s.Version = version
+	s.Description = plan.Description
+	s.Estimated = estimated
+	s.Done = 0
+	s.Running = true
+	p.current = s
+	p.logged = 0
+
+	p.logger.Info("migration step started", "group", group, "version", version, "latest", latest, "description", plan.Description, "estimated", estimated)
+}
+
+// finish marks the running step as finished.
+func (p *Progress) finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current == nil {
+		return
+	}
+	s := p.current
+	s.Running = false
+	p.current = nil
+
+	p.logger.Info("migration step finished", "group", s.Group, "version", s.Version, "migrated", s.Done)
+}
+
+// planned logs the plan of the step not run in the dry run mode.
+func (p *Progress) planned(group string, version, latest uint64, plan Plan, estimated uint64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.status(group, latest)
+	s.Description = plan.Description
+	s.Estimated = estimated
+
+	p.logger.Info("migration step planned", "group", group, "version", version, "latest", latest, "description", plan.Description, "estimated", estimated)
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pebblestore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/cockroachdb/pebble"
+)
+
+// Batch implements storage.BatchedStore interface Batch method.
+func (s *Store) Batch(ctx context.Context) storage.Batch {
+	return &Batch{
+		ctx:   ctx,
+		batch: s.db.NewBatch(),
+	}
+}
+
+type Batch struct {
+	ctx context.Context
+
+	mu    sync.Mutex // mu guards batch and done.
+	batch *pebble.Batch
+	done  bool
+}
+
+// Put implements storage.Batch interface Put method.
+func (i *Batch) Put(item storage.Item) error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	val, err := item.Marshal()
+	if err != nil {
+		return fmt.Errorf("unable to marshal item: %w", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.batch.Set(key(item), val, nil)
+}
+
+// Delete implements storage.Batch interface Delete method.
+func (i *Batch) Delete(item storage.Item) error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.batch.Delete(key(item), nil)
+}
+
+// Commit implements storage.Batch interface Commit method.
+func (i *Batch) Commit() error {
+	if err := i.ctx.Err(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.done {
+		return storage.ErrBatchCommitted
+	}
+
+	// pebble buffers the unsynced writes of the log in memory, so a
+	// committed batch would not survive a crash of the process
+	if err := i.batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("unable to commit batch: %w", err)
+	}
+	if err := i.batch.Close(); err != nil {
+		return fmt.Errorf("unable to close batch: %w", err)
+	}
+
+	i.done = true
+
+	return nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pebblestore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+const separator = "/"
+
+// key returns the Item identifier for the pebble storage.
+func key(item storage.Key) []byte {
+	return []byte(item.Namespace() + separator + item.ID())
+}
+
+// prefixUpperBound returns the smallest key greater than all the keys with
+// the given prefix, nil if there is none.
+func prefixUpperBound(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+// prefixOptions returns the iterator options bounding the iteration to the
+// keys with the given prefix.
+func prefixOptions(prefix []byte) *pebble.IterOptions {
+	if len(prefix) == 0 {
+		return nil
+	}
+	return &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	}
+}
+
+// filters is a decorator for a slice of storage.Filters
+// that helps with its evaluation.
+type filters []storage.Filter
+
+// matchAny returns true if any of the filters match the item.
+func (f filters) matchAny(k string, v []byte) bool {
+	for _, filter := range f {
+		if filter(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Storer returns the underlying db store.
+type Storer interface {
+	DB() *pebble.DB
+}
+
+var (
+	_ Storer        = (*Store)(nil)
+	_ storage.Store = (*Store)(nil)
+)
+
+type Store struct {
+	db   *pebble.DB
+	path string
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// New returns a new store the backed by pebble.
+// If path == "", the pebble will run with in memory backend storage.
+func New(path string, opts *pebble.Options) (*Store, error) {
+	if opts == nil {
+		opts = new(pebble.Options)
+	}
+	if path == "" {
+		opts = opts.Clone()
+		opts.FS = vfs.NewMem()
+	}
+
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// DB implements the Storer interface.
+func (s *Store) DB() *pebble.DB {
+	return s.db
+}
+
+// Close implements the storage.Store interface.
+// It is safe to call Close more than once, as pebble panics on closing a
+// closed database.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		s.closeErr = s.db.Close()
+	})
+	return s.closeErr
+}
+
+// get returns a copy of the value stored under the key.
+func (s *Store) get(k []byte) ([]byte, error) {
+	val, closer, err := s.db.Get(k)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return append([]byte(nil), val...), nil
+}
+
+// Get implements the storage.Store interface.
+func (s *Store) Get(item storage.Item) error {
+	val, err := s.get(key(item))
+	if err != nil {
+		return err
+	}
+
+	if err = item.Unmarshal(val); err != nil {
+		return fmt.Errorf("failed decoding value %w", err)
+	}
+
+	return nil
+}
+
+// Has implements the storage.Store interface.
+func (s *Store) Has(k storage.Key) (bool, error) {
+	_, closer, err := s.db.Get(key(k))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, closer.Close()
+}
+
+// GetSize implements the storage.Store interface.
+func (s *Store) GetSize(k storage.Key) (int, error) {
+	val, closer, err := s.db.Get(key(k))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return 0, storage.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer closer.Close()
+
+	return len(val), nil
+}
+
+// Iterate implements the storage.Store interface.
+func (s *Store) Iterate(q storage.Query, fn storage.IterateFn) error {
+	if err := q.Validate(); err != nil {
+		return fmt.Errorf("failed iteration: %w", err)
+	}
+
+	var (
+		retErr error
+		prefix string
+	)
+
+	if q.PrefixAtStart {
+		prefix = q.Factory().Namespace()
+	} else if q.Factory().Namespace() != "" {
+		// this is a small hack to make the iteration work with the
+		// old implementation of statestore. this allows us to do a
+		// full iteration without looking at the prefix.
+		prefix = q.Factory().Namespace() + separator + q.Prefix
+	}
+
+	iter, err := s.db.NewIter(prefixOptions([]byte(prefix)))
+	if err != nil {
+		return fmt.Errorf("failed iteration: %w", err)
+	}
+	defer iter.Close()
+
+	first, next := iter.First, iter.Next
+	if q.PrefixAtStart {
+		start := []byte(prefix + separator + q.Prefix)
+		first = func() bool { return iter.SeekGE(start) }
+	}
+	if q.Order == storage.KeyDescendingOrder {
+		first, next = iter.Last, iter.Prev
+	}
+
+	firstSkipped := !q.SkipFirst
+
+	for valid := first(); valid; valid = next() {
+		nextKey := append([]byte(nil), iter.Key()...)
+		nextVal := append([]byte(nil), iter.Value()...)
+
+		key := strings.TrimPrefix(string(nextKey), prefix)
+
+		if filters(q.Filters).matchAny(key, nextVal) {
+			continue
+		}
+
+		if q.SkipFirst && !firstSkipped {
+			firstSkipped = true
+			continue
+		}
+
+		var (
+			res *storage.Result
+			err error
+		)
+
+		switch q.ItemProperty {
+		case storage.QueryItemID, storage.QueryItemSize:
+			res = &storage.Result{ID: key, Size: len(nextVal)}
+		case storage.QueryItem:
+			newItem := q.Factory()
+			err = newItem.Unmarshal(nextVal)
+			res = &storage.Result{ID: key, Entry: newItem}
+		}
+
+		if err != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("failed unmarshaling: %w", err))
+			break
+		}
+
+		if res == nil {
+			retErr = errors.Join(retErr, fmt.Errorf("unknown object attribute type: %v", q.ItemProperty))
+			break
+		}
+
+		if stop, err := fn(*res); err != nil {
+			retErr = errors.Join(retErr, fmt.Errorf("iterate callback function errored: %w", err))
+			break
+		} else if stop {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		retErr = errors.Join(retErr, err)
+	}
+
+	return retErr
+}
+
+// Count implements the storage.Store interface.
+func (s *Store) Count(key storage.Key) (int, error) {
+	iter, err := s.db.NewIter(prefixOptions([]byte(key.Namespace() + separator)))
+	if err != nil {
+		return 0, err
+	}
+
+	var c int
+	for valid := iter.First(); valid; valid = iter.Next() {
+		c++
+	}
+
+	return c, errors.Join(iter.Error(), iter.Close())
+}
+
+// Put implements the storage.Store interface.
+func (s *Store) Put(item storage.Item) error {
+	value, err := item.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed serializing: %w", err)
+	}
+
+	return s.db.Set(key(item), value, pebble.NoSync)
+}
+
+// Delete implements the storage.Store interface.
+func (s *Store) Delete(item storage.Item) error {
+	// this is a small hack to make the deletion of old entries work. As they
+	// don't have a namespace, we need to check for that and use the ID as key without
+	// the separator.
+	var k []byte
+	if item.Namespace() == "" {
+		k = []byte(item.ID())
+	} else {
+		k = key(item)
+	}
+
+	return s.db.Delete(k, pebble.NoSync)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storagetest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	storage "github.com/calmw/bee-tron/pkg/storage"
+)
+
+const (
+	// crashDirEnv passes the store directory to the child process.
+	crashDirEnv = "BEE_STORAGETEST_CRASH_DIR"
+	// crashBatchItems is the number of items of a batch committed by the
+	// child process.
+	crashBatchItems = 64
+	// crashValueSize is the size of the items, large enough for a commit to
+	// take a while.
+	crashValueSize = 4096
+	// crashCommits is the number of acknowledged commits after which the
+	// child process is killed.
+	crashCommits = 16
+)
+
+// OpenStoreFunc opens the persistent store at path.
+type OpenStoreFunc func(path string) (storage.BatchStore, error)
+
+// TestCrashConsistency kills a process in the middle of committing batches
+// into the store at a temporary path and checks that, once the store is
+// reopened, each batch was applied entirely or not at all and that the
+// acknowledged commits survived.
+//
+// The test binary is run again in a child process, running only the calling
+// test, in which TestCrashConsistency commits the batches until it is killed.
+func TestCrashConsistency(t *testing.T, open OpenStoreFunc) {
+	t.Helper()
+
+	if dir := os.Getenv(crashDirEnv); dir != "" {
+		crashChild(dir, open)
+		return
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run="+runPattern(t.Name()), "-test.count=1")
+	cmd.Env = append(os.Environ(), crashDirEnv+"="+dir)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start child process: %v", err)
+	}
+
+	acked := 0
+	scanner := bufio.NewScanner(stdout)
+	for acked < crashCommits && scanner.Scan() {
+		if n, err := strconv.Atoi(strings.TrimSpace(scanner.Text())); err == nil {
+			acked = n
+		}
+	}
+	// land the kill at a random point of the following commit
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	_ = cmd.Process.Kill()
+	_ = cmd.Wait()
+
+	if acked < crashCommits {
+		t.Fatalf("child process exited after %d commits: %s", acked, stderr.String())
+	}
+
+	st, err := open(dir)
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	t.Cleanup(func() { _ = st.Close() })
+
+	items := make(map[uint64]int)
+	err = st.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(obj1) },
+	}, func(r storage.Result) (bool, error) {
+		items[r.Entry.(*obj1).SomeInt]++
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("iterate reopened store: %v", err)
+	}
+
+	for n, cnt := range items {
+		if cnt != crashBatchItems {
+			t.Errorf("batch %d partially applied: %d of %d items", n, cnt, crashBatchItems)
+		}
+	}
+	for n := 1; n <= acked; n++ {
+		if _, ok := items[uint64(n)]; !ok {
+			t.Errorf("acknowledged batch %d lost", n)
+		}
+	}
+}
+
+// crashChild commits batches into the store at dir and acknowledges each
+// commit on the standard output until the process is killed.
+func crashChild(dir string, open OpenStoreFunc) {
+	st, err := open(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "open store: %v\n", err)
+		os.Exit(1)
+	}
+
+	value := make([]byte, crashValueSize)
+	for n := 1; ; n++ {
+		batch := st.Batch(context.Background())
+		for i := 0; i < crashBatchItems; i++ {
+			item := &obj1{Id: fmt.Sprintf("%08d-%04d", n, i), SomeInt: uint64(n), Buf: value}
+			if err := batch.Put(item); err != nil {
+				fmt.Fprintf(os.Stderr, "put: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if err := batch.Commit(); err != nil {
+			fmt.Fprintf(os.Stderr, "commit: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(n)
+	}
+}
+
+// runPattern returns the -test.run pattern matching only the named test.
+func runPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storagetest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	storage "github.com/calmw/bee-tron/pkg/storage"
+)
+
+// latencyRecorder collects the durations of the benchmarked operations and
+// reports their percentiles.
+type latencyRecorder struct {
+	durations []time.Duration
+}
+
+func newLatencyRecorder(n int) *latencyRecorder {
+	return &latencyRecorder{durations: make([]time.Duration, 0, n)}
+}
+
+// measure runs fn and records its duration.
+func (r *latencyRecorder) measure(fn func() error) error {
+	start := time.Now()
+	err := fn()
+	r.durations = append(r.durations, time.Since(start))
+	return err
+}
+
+// percentile returns the p-th percentile of the sorted durations.
+func (r *latencyRecorder) percentile(p float64) time.Duration {
+	if len(r.durations) == 0 {
+		return 0
+	}
+	i := int(float64(len(r.durations)-1) * p / 100)
+	return r.durations[i]
+}
+
+// report reports the p50, p99 and maximum latency as custom metrics of the
+// benchmark.
+func (r *latencyRecorder) report(b *testing.B) {
+	b.Helper()
+
+	if len(r.durations) == 0 {
+		return
+	}
+	slices.Sort(r.durations)
+	b.ReportMetric(float64(r.percentile(50).Nanoseconds()), "p50-ns")
+	b.ReportMetric(float64(r.percentile(99).Nanoseconds()), "p99-ns")
+	b.ReportMetric(float64(r.durations[len(r.durations)-1].Nanoseconds()), "max-ns")
+}
+
+// BenchmarkLatency provides a benchmark suite reporting the throughput and
+// the latency percentiles of the reads and the writes of the storage.Store.
+func BenchmarkLatency(b *testing.B, s storage.Store) {
+	b.Run("WriteLatency", func(b *testing.B) {
+		BenchmarkWriteLatency(b, s)
+	})
+	b.Run("ReadLatency", func(b *testing.B) {
+		BenchmarkReadLatency(b, s)
+	})
+}
+
+// BenchmarkBatchLatency provides a benchmark suite reporting the throughput
+// and the latency percentiles of the batch commits of the storage.BatchStore.
+func BenchmarkBatchLatency(b *testing.B, bs storage.BatchStore) {
+	b.Run("CommitLatency", func(b *testing.B) {
+		BenchmarkCommitLatency(b, bs)
+	})
+}
+
+func BenchmarkWriteLatency(b *testing.B, db storage.Store) {
+	g := newFullRandomEntryGenerator(0, b.N)
+	r := newLatencyRecorder(b.N)
+	b.SetBytes(int64(*valueSize))
+	resetBenchmark(b)
+	for i := 0; i < b.N; i++ {
+		item := &obj1{Id: string(g.Key(i)), Buf: g.Value(i)}
+		if err := r.measure(func() error { return db.Put(item) }); err != nil {
+			b.Fatalf("write key '%s': %v", string(g.Key(i)), err)
+		}
+	}
+	b.StopTimer()
+	r.report(b)
+}
+
+func BenchmarkReadLatency(b *testing.B, db storage.Store) {
+	g := newFullRandomEntryGenerator(0, b.N)
+	doWrite(b, db, g)
+	r := newLatencyRecorder(b.N)
+	b.SetBytes(int64(*valueSize))
+	resetBenchmark(b)
+	for i := 0; i < b.N; i++ {
+		item := &obj1{Id: string(g.Key(i))}
+		if err := r.measure(func() error { return db.Get(item) }); err != nil {
+			b.Fatalf("read key '%s': %v", string(g.Key(i)), err)
+		}
+	}
+	b.StopTimer()
+	r.report(b)
+}
+
+// BenchmarkCommitLatency writes batches of batch_size items, the latency
+// reported is the one of the commits.
+func BenchmarkCommitLatency(b *testing.B, bs storage.BatchStore) {
+	size := maxInt(*batchSize, 1)
+	g := newSequentialEntryGenerator(b.N)
+	r := newLatencyRecorder((b.N + size - 1) / size)
+	b.SetBytes(int64(*valueSize))
+	resetBenchmark(b)
+	for i := 0; i < b.N; i += size {
+		batch := bs.Batch(context.Background())
+		for j := i; j < min(i+size, b.N); j++ {
+			if err := batch.Put(&obj1{Id: string(g.Key(j)), Buf: g.Value(j)}); err != nil {
+				b.Fatalf("write key '%s': %v", string(g.Key(j)), err)
+			}
+		}
+		if err := r.measure(batch.Commit); err != nil {
+			b.Fatal("commit batch", err)
+		}
+	}
+	b.StopTimer()
+	r.report(b)
+}
@@ -458,6 +458,50 @@ func TestStore(t *testing.T, s storage.Store) {
 		})
 	})
 
+	t.Run("iterate from cursor", func(t *testing.T) {
+		t.Run("obj1", func(t *testing.T) {
+			q := storage.Query{
+				Factory:      func() storage.Item { return new(obj1) },
+				Prefix:       obj1Prefix,
+				ItemProperty: storage.QueryItem,
+			}
+
+			cursor, err := storage.IterateFrom(s, q, storage.Cursor{}, func(r storage.Result, _ storage.Cursor) (bool, error) {
+				checkTestItemEqual(t, r.Entry, testObjs[0])
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error while iteration: %v", err)
+			}
+
+			cursor, err = storage.ParseCursor(cursor.String())
+			if err != nil {
+				t.Fatalf("unexpected error while parsing cursor: %v", err)
+			}
+
+			idx := 1
+			cursor, err = storage.IterateFrom(s, q, cursor, func(r storage.Result, _ storage.Cursor) (bool, error) {
+				checkTestItemEqual(t, r.Entry, testObjs[idx])
+				idx++
+				return false, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error while iteration: %v", err)
+			}
+			if idx != obj1WithPrefixCnt {
+				t.Fatalf("unexpected no of entries in iteration exp %d found %d", obj1WithPrefixCnt, idx)
+			}
+
+			_, err = storage.IterateFrom(s, q, cursor, func(r storage.Result, _ storage.Cursor) (bool, error) {
+				t.Fatalf("unexpected entry after the last cursor: %s", r.Entry.ID())
+				return true, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error while iteration: %v", err)
+			}
+		})
+	})
+
 	t.Run("iterate skip first", func(t *testing.T) {
 		t.Run("obj1", func(t *testing.T) {
 			idx := 1
@@ -847,6 +891,7 @@ func BenchmarkStore(b *testing.B, s storage.Store) {
 	b.Run("DeleteSequential", func(b *testing.B) {
 		BenchmarkDeleteSequential(b, s)
 	})
+	BenchmarkLatency(b, s)
 }
 
 // BenchmarkBatchedStore provides a benchmark suite for the
@@ -864,6 +909,7 @@ func BenchmarkBatchedStore(b *testing.B, bs storage.BatchStore) {
 	b.Run("DeleteInFixedSizeBatches", func(b *testing.B) {
 		BenchmarkDeleteInFixedSizeBatches(b, bs)
 	})
+	BenchmarkBatchLatency(b, bs)
 }
 
 func BenchmarkReadRandom(b *testing.B, db storage.Store) {
@@ -294,6 +294,10 @@ func (c *contract) sendTransaction(ctx context.Context, callData []byte, desc st
 		Description: desc,
 	}
 
+	if err := c.transactionService.Simulate(ctx, request, c.stakingContractABI.Errors); err != nil {
+		return nil, err
+	}
+
 	defer func() {
 		err = c.transactionService.UnwrapABIError(
 			ctx,
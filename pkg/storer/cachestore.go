@@ -34,24 +34,28 @@ func (db *DB) cacheWorker(ctx context.Context) {
 			return
 		case <-overCapTrigger:
 
-			size, capc := db.cacheObj.Size(), db.cacheObj.Capacity()
-			if size <= capc {
+			if db.cacheEvictionPause.isPaused() {
+				continue
+			}
+
+			evict, evictBytes := db.cacheObj.Excess()
+			if evict == 0 && evictBytes == 0 {
 				continue
 			}
 
-			evict := uint64(size - capc)
 			if evict < db.reserveOptions.cacheMinEvictCount { // evict at least a min count
 				evict = db.reserveOptions.cacheMinEvictCount
 			}
 
 			dur := captureDuration(time.Now())
-			err := db.cacheObj.RemoveOldest(ctx, db.storage, evict)
+			evicted, err := db.cacheObj.Evict(ctx, db.storage, evict, evictBytes)
 			db.metrics.MethodCallsDuration.WithLabelValues("cachestore", "RemoveOldest").Observe(dur())
+			db.metrics.NamespaceEvictions.WithLabelValues(cacheNamespace).Add(float64(evicted))
 			if err != nil {
 				db.metrics.MethodCalls.WithLabelValues("cachestore", "RemoveOldest", "failure").Inc()
 				db.logger.Warning("cache eviction failure", "error", err)
 			} else {
-				db.logger.Debug("cache eviction finished", "evicted", evict, "duration_sec", dur())
+				db.logger.Debug("cache eviction finished", "evicted", evicted, "duration_sec", dur())
 				db.metrics.MethodCalls.WithLabelValues("cachestore", "RemoveOldest", "success").Inc()
 			}
 			db.triggerCacheEviction()
@@ -116,13 +120,10 @@ func (db *DB) CacheShallowCopy(ctx context.Context, store transaction.Storage, a
 
 func (db *DB) triggerCacheEviction() {
 
-	var (
-		size = db.cacheObj.Size()
-		capc = db.cacheObj.Capacity()
-	)
-	db.metrics.CacheSize.Set(float64(size))
+	db.metrics.CacheSize.Set(float64(db.cacheObj.Size()))
+	db.metrics.CacheSizeBytes.Set(float64(db.cacheObj.SizeBytes()))
 
-	if size > capc {
+	if count, bytes := db.cacheObj.Excess(); count > 0 || bytes > 0 {
 		db.events.Trigger(cacheOverCapacity)
 	}
 }
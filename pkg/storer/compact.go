@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/calmw/bee-tron/pkg/sharky"
+	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
@@ -24,7 +25,7 @@ func Compact(ctx context.Context, basePath string, opts *Options, validate bool)
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -44,7 +45,7 @@ func Compact(ctx context.Context, basePath string, opts *Options, validate bool)
 
 	if validate {
 		logger.Info("performing chunk validation before compaction")
-		validateWork(logger, store, sharkyRecover.Read)
+		validateWork(logger, store, sharkyRecover.Read, storage.Cursor{})
 	}
 
 	logger.Info("starting compaction")
@@ -141,7 +142,7 @@ func Compact(ctx context.Context, basePath string, opts *Options, validate bool)
 
 	if validate {
 		logger.Info("performing chunk validation after compaction")
-		validateWork(logger, store, sharkyRecover.Read)
+		validateWork(logger, store, sharkyRecover.Read, storage.Cursor{})
 	}
 
 	return nil
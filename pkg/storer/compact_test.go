@@ -198,3 +198,69 @@ func TestCompactNoEvictions(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestCompactSharky expires a batch of a running store and compacts sharky
+// online, after which it is tested that the valid chunks can still be retrieved.
+func TestCompactSharky(t *testing.T) {
+	t.Parallel()
+
+	baseAddr := swarm.RandAddress(t)
+	ctx := context.Background()
+
+	opts := dbTestOps(baseAddr, 10_000, nil, nil, time.Minute)
+	opts.CacheCapacity = 0
+
+	st, err := storer.New(ctx, t.TempDir(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := st.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	st.StartReserveWorker(ctx, pullerMock.NewMockRateReporter(0), networkRadiusFunc(0))
+
+	var chunks []swarm.Chunk
+	batches := []*postage.Batch{postagetesting.MustNewBatch(), postagetesting.MustNewBatch(), postagetesting.MustNewBatch()}
+	evictBatch := batches[1]
+
+	putter := st.ReservePutter()
+
+	for b := 0; b < len(batches); b++ {
+		for i := uint64(0); i < 100; i++ {
+			ch := chunk.GenerateTestRandomChunk()
+			ch = ch.WithStamp(postagetesting.MustNewBatchStamp(batches[b].ID))
+			chunks = append(chunks, ch)
+			err := putter.Put(ctx, ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	c, unsub := st.Events().Subscribe("batchExpiryDone")
+	t.Cleanup(unsub)
+
+	err = st.EvictBatch(ctx, evictBatch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-c
+
+	relocated, err := st.CompactSharky(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if relocated == 0 {
+		t.Fatal("expected chunks to be relocated")
+	}
+
+	for _, ch := range chunks {
+		if bytes.Equal(ch.Stamp().BatchID(), evictBatch.ID) {
+			checkSaved(t, st, ch, false, false)
+		} else {
+			checkSaved(t, st, ch, true, true)
+		}
+	}
+}
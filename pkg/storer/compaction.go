@@ -0,0 +1,170 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
+	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const (
+	// compactionMinFreeSlots is the number of free slots a shard must have
+	// below its last used slot to be picked for compaction.
+	compactionMinFreeSlots = 1024
+	// compactionMinFreeRatio is the share of free slots a shard must have
+	// below its last used slot to be picked for compaction.
+	compactionMinFreeRatio = 0.2
+	// compactionMaxRelocations caps the number of chunks relocated in a round.
+	compactionMaxRelocations = 10_000
+	// compactionBusyOps is the number of sharky operations issued by others
+	// during a throttle pause above which the round is given up.
+	compactionBusyOps = 64
+)
+
+// errSharkyBusy is returned when a compaction round is given up because the
+// store is serving other operations.
+var errSharkyBusy = errors.New("sharky busy")
+
+type compactionOpts struct {
+	interval time.Duration
+	throttle time.Duration
+}
+
+// shardUsage is the usage of a sharky shard as seen from the retrieval index.
+type shardUsage struct {
+	live uint32 // number of chunks stored in the shard
+	span uint32 // number of slots up to the last used one
+}
+
+// sharkyCompactionWorker periodically relocates chunks from the tail of
+// sparse shards to their free slots and gives the freed tail back to the
+// file system.
+func (db *DB) sharkyCompactionWorker(ctx context.Context) {
+	defer db.inFlight.Done()
+
+	ticker := time.NewTicker(db.compactionOptions.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-db.quit:
+			return
+		case <-ticker.C:
+			dur := captureDuration(time.Now())
+			relocated, err := db.compactSharky(ctx, compactionMinFreeSlots, compactionMinFreeRatio)
+			db.metrics.MethodCallsDuration.WithLabelValues("sharky", "Compact").Observe(dur())
+			switch {
+			case errors.Is(err, errSharkyBusy):
+				db.logger.Debug("sharky compaction postponed", "relocated", relocated, "duration_sec", dur())
+			case err != nil:
+				db.metrics.MethodCalls.WithLabelValues("sharky", "Compact", "failure").Inc()
+				db.logger.Warning("sharky compaction failure", "relocated", relocated, "error", err)
+			default:
+				db.metrics.MethodCalls.WithLabelValues("sharky", "Compact", "success").Inc()
+				db.logger.Debug("sharky compaction finished", "relocated", relocated, "duration_sec", dur())
+			}
+		}
+	}
+}
+
+// compactSharky runs a compaction round over the shards that have at least
+// minFree free slots making up at least minRatio of the slots in use. The
+// chunks stored beyond the number of live chunks of such a shard are
+// rewritten to the lowest free slots and the shard file is trimmed after.
+// Before each relocation the worker pauses for the throttle duration and
+// gives up the round if the store served other operations in the meantime.
+func (db *DB) compactSharky(ctx context.Context, minFree uint32, minRatio float64) (int, error) {
+	sh := db.pinIntegrity.Sharky
+
+	usage := make(map[uint8]*shardUsage)
+	err := chunkstore.IterateItems(db.storage.IndexStore(), func(item *chunkstore.RetrievalIndexItem) error {
+		u, ok := usage[item.Location.Shard]
+		if !ok {
+			u = new(shardUsage)
+			usage[item.Location.Shard] = u
+		}
+		u.live++
+		if item.Location.Slot >= u.span {
+			u.span = item.Location.Slot + 1
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("iterate retrieval index: %w", err)
+	}
+
+	sparse := make(map[uint8]uint32)
+	for shard, u := range usage {
+		free := u.span - u.live
+		if free >= minFree && float64(free) >= minRatio*float64(u.span) {
+			sparse[shard] = u.live
+		}
+	}
+	if len(sparse) == 0 {
+		return 0, nil
+	}
+
+	var addrs []swarm.Address
+	err = chunkstore.IterateItems(db.storage.IndexStore(), func(item *chunkstore.RetrievalIndexItem) error {
+		live, ok := sparse[item.Location.Shard]
+		if ok && item.Location.Slot >= live && len(addrs) < compactionMaxRelocations {
+			addrs = append(addrs, item.Address)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("iterate retrieval index: %w", err)
+	}
+
+	relocated := 0
+	for _, addr := range addrs {
+		ops := sh.Operations()
+		select {
+		case <-ctx.Done():
+			return relocated, ctx.Err()
+		case <-db.quit:
+			return relocated, nil
+		case <-time.After(db.compactionOptions.throttle):
+		}
+		// the reserve sampler has to finish within the round of the
+		// storage incentives, so it takes precedence over the compaction
+		if sh.Operations()-ops > compactionBusyOps || db.sampling.Load() > 0 {
+			return relocated, errSharkyBusy
+		}
+
+		err := db.storage.Run(ctx, func(s transaction.Store) error {
+			ch, err := s.ChunkStore().Get(ctx, addr)
+			if err != nil {
+				return err
+			}
+			return s.ChunkStore().Replace(ctx, ch, false)
+		})
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			// the chunk was removed since the index was read
+		case err != nil:
+			return relocated, fmt.Errorf("relocate chunk %s: %w", addr, err)
+		default:
+			relocated++
+		}
+	}
+
+	for shard := range sparse {
+		if _, err := sh.Trim(ctx, shard); err != nil {
+			return relocated, fmt.Errorf("trim shard %d: %w", shard, err)
+		}
+	}
+
+	return relocated, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"math/bits"
+
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
+	pinstore "github.com/calmw/bee-tron/pkg/storer/internal/pinning"
+	"github.com/calmw/bee-tron/pkg/storer/internal/upload"
+	"golang.org/x/sync/errgroup"
+)
+
+// Names of the components referencing the chunks of the chunkstore.
+const (
+	UploadReferences  = "upload"
+	PinningReferences = "pinning"
+	CacheReferences   = "cache"
+	ReserveReferences = "reserve"
+)
+
+// DedupStat describes how much storage the deduplication of the chunks
+// referenced by several components saves.
+type DedupStat struct {
+	// Chunks is the number of distinct chunks stored.
+	Chunks uint64
+	// SharedChunks is the number of chunks referenced more than once.
+	SharedChunks uint64
+	// References is the number of references to the stored chunks.
+	References uint64
+	// PhysicalBytes is the size of the stored chunk data.
+	PhysicalBytes uint64
+	// LogicalBytes is the size the chunk data would take without the
+	// deduplication, each reference counted separately.
+	LogicalBytes uint64
+	// ReferencesBy is the number of references held by each component.
+	ReferencesBy map[string]uint64
+	// ReferenceHistogram is the number of chunks by their reference count,
+	// rounded up to the next power of two.
+	ReferenceHistogram map[uint32]uint64
+}
+
+// Ratio returns the ratio of the logical to the physical bytes, 1 if the
+// chunkstore is empty.
+func (s DedupStat) Ratio() float64 {
+	if s.PhysicalBytes == 0 {
+		return 1
+	}
+	return float64(s.LogicalBytes) / float64(s.PhysicalBytes)
+}
+
+// DedupReporter reports the deduplication statistics of the chunkstore.
+type DedupReporter interface {
+	// DedupStats scans the chunkstore and the indexes referencing the chunks.
+	DedupStats(context.Context) (DedupStat, error)
+}
+
+var _ DedupReporter = (*DB)(nil)
+
+// DedupStats is the implementation of the DedupReporter.DedupStats method.
+func (db *DB) DedupStats(ctx context.Context) (DedupStat, error) {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	stat := DedupStat{ReferenceHistogram: make(map[uint32]uint64)}
+	eg.Go(func() error {
+		return chunkstore.IterateItems(db.storage.IndexStore(), func(item *chunkstore.RetrievalIndexItem) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-db.quit:
+				return ErrDBQuit
+			default:
+			}
+
+			stat.Chunks++
+			stat.References += uint64(item.RefCnt)
+			stat.PhysicalBytes += uint64(item.Location.Length)
+			stat.LogicalBytes += uint64(item.RefCnt) * uint64(item.Location.Length)
+			if item.RefCnt > 1 {
+				stat.SharedChunks++
+			}
+			stat.ReferenceHistogram[refCountBucket(item.RefCnt)]++
+			return nil
+		})
+	})
+
+	var uploaded uint64
+	eg.Go(func() error {
+		return upload.IterateAll(db.storage.IndexStore(), func(storage.Item) (bool, error) {
+			select {
+			case <-ctx.Done():
+				return true, ctx.Err()
+			case <-db.quit:
+				return true, ErrDBQuit
+			default:
+			}
+			uploaded++
+			return false, nil
+		})
+	})
+
+	var pinned uint64
+	eg.Go(func() error {
+		return pinstore.IterateCollectionStats(db.storage.IndexStore(), func(cs pinstore.CollectionStat) (bool, error) {
+			select {
+			case <-ctx.Done():
+				return true, ctx.Err()
+			case <-db.quit:
+				return true, ErrDBQuit
+			default:
+			}
+			pinned += cs.Total - cs.DupInCollection
+			return false, nil
+		})
+	})
+
+	if err := eg.Wait(); err != nil {
+		return DedupStat{}, err
+	}
+
+	stat.ReferencesBy = map[string]uint64{
+		UploadReferences:  uploaded,
+		PinningReferences: pinned,
+		CacheReferences:   uint64(db.cacheObj.Size()),
+	}
+	if db.reserve != nil {
+		stat.ReferencesBy[ReserveReferences] = uint64(db.reserve.Size())
+	}
+	return stat, nil
+}
+
+// refCountBucket rounds the reference count up to the next power of two.
+func refCountBucket(cnt uint32) uint32 {
+	if cnt <= 1 {
+		return cnt
+	}
+	return 1 << bits.Len32(cnt-1)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chunktest "github.com/calmw/bee-tron/pkg/storage/testing"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDedupStats(t *testing.T) {
+	t.Parallel()
+
+	lstore, err := memStorer(t, dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, err := lstore.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := lstore.Upload(context.Background(), true, tag.TagID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(10)
+	for _, ch := range chunks {
+		if err := session.Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := session.Done(chunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+
+	// half of the uploaded and pinned chunks are cached as well
+	for _, ch := range chunks[:5] {
+		if err := lstore.Cache().Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var physical, logical uint64
+	for i, ch := range chunks {
+		size := uint64(len(ch.Data()))
+		physical += size
+		if i < 5 {
+			logical += 3 * size
+		} else {
+			logical += 2 * size
+		}
+	}
+
+	stat, err := lstore.DedupStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := storer.DedupStat{
+		Chunks:        10,
+		SharedChunks:  10,
+		References:    25,
+		PhysicalBytes: physical,
+		LogicalBytes:  logical,
+		ReferencesBy: map[string]uint64{
+			storer.UploadReferences:  10,
+			storer.PinningReferences: 10,
+			storer.CacheReferences:   5,
+			storer.ReserveReferences: 0,
+		},
+		ReferenceHistogram: map[uint32]uint64{2: 5, 4: 5},
+	}
+	if diff := cmp.Diff(want, stat); diff != "" {
+		t.Fatalf("invalid stat (+want -have):\n%s", diff)
+	}
+	if got, want := stat.Ratio(), float64(logical)/float64(physical); got != want {
+		t.Fatalf("got ratio %v, want %v", got, want)
+	}
+}
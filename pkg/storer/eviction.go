@@ -0,0 +1,154 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Names of the eviction workers which can be paused.
+const (
+	CacheEviction   = "cache"
+	ReserveEviction = "reserve"
+)
+
+// ErrUnknownEvictionWorker is returned when pausing or resuming an eviction
+// worker which does not exist.
+var ErrUnknownEvictionWorker = errors.New("storer: unknown eviction worker")
+
+// EvictionStatus is the pause state of an eviction worker.
+type EvictionStatus struct {
+	Worker string
+	Paused bool
+	// PausedUntil is the time the worker resumes on its own, zero if it is
+	// paused until resumed explicitly.
+	PausedUntil time.Time
+}
+
+// EvictionController pauses and resumes the cache and reserve eviction
+// workers, for example while a backup or a migration is running. The stores
+// may grow over their capacity while the eviction is paused.
+type EvictionController interface {
+	// PauseEviction pauses the eviction worker for the duration d, or until it
+	// is resumed if d is zero.
+	PauseEviction(worker string, d time.Duration) error
+	// ResumeEviction resumes the eviction worker, which catches up with the
+	// eviction skipped while it was paused.
+	ResumeEviction(worker string) error
+	// EvictionStatus returns the pause state of all the eviction workers.
+	EvictionStatus() []EvictionStatus
+}
+
+var _ EvictionController = (*DB)(nil)
+
+// evictionPause is the pause state of an eviction worker.
+type evictionPause struct {
+	mu     sync.Mutex
+	paused bool
+	until  time.Time
+	timer  *time.Timer
+	resume func() // wakes up the worker once resumed
+}
+
+func (p *evictionPause) isPaused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+func (p *evictionPause) pause(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.paused = true
+	p.until = time.Time{}
+	if d > 0 {
+		p.until = time.Now().Add(d)
+		p.timer = time.AfterFunc(d, p.unpause)
+	}
+}
+
+func (p *evictionPause) unpause() {
+	p.mu.Lock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	wasPaused := p.paused
+	p.paused = false
+	p.until = time.Time{}
+	p.mu.Unlock()
+
+	if wasPaused && p.resume != nil {
+		p.resume()
+	}
+}
+
+func (p *evictionPause) status(worker string) EvictionStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return EvictionStatus{Worker: worker, Paused: p.paused, PausedUntil: p.until}
+}
+
+func (db *DB) evictionPause(worker string) (*evictionPause, error) {
+	switch worker {
+	case CacheEviction:
+		return &db.cacheEvictionPause, nil
+	case ReserveEviction:
+		return &db.reserveEvictionPause, nil
+	}
+	return nil, ErrUnknownEvictionWorker
+}
+
+// PauseEviction is the implementation of the EvictionController.PauseEviction method.
+func (db *DB) PauseEviction(worker string, d time.Duration) error {
+	p, err := db.evictionPause(worker)
+	if err != nil {
+		return err
+	}
+	p.pause(d)
+	db.metrics.EvictionPaused.WithLabelValues(worker).Set(1)
+	db.logger.Info("eviction paused", "worker", worker, "duration", d)
+	return nil
+}
+
+// ResumeEviction is the implementation of the EvictionController.ResumeEviction method.
+func (db *DB) ResumeEviction(worker string) error {
+	p, err := db.evictionPause(worker)
+	if err != nil {
+		return err
+	}
+	p.unpause()
+	return nil
+}
+
+// EvictionStatus is the implementation of the EvictionController.EvictionStatus method.
+func (db *DB) EvictionStatus() []EvictionStatus {
+	return []EvictionStatus{
+		db.cacheEvictionPause.status(CacheEviction),
+		db.reserveEvictionPause.status(ReserveEviction),
+	}
+}
+
+// initEvictionPauses sets up the wake up of the eviction workers on resume.
+func (db *DB) initEvictionPauses() {
+	db.cacheEvictionPause.resume = func() {
+		db.metrics.EvictionPaused.WithLabelValues(CacheEviction).Set(0)
+		db.logger.Info("eviction resumed", "worker", CacheEviction)
+		db.triggerCacheEviction()
+	}
+	db.reserveEvictionPause.resume = func() {
+		db.metrics.EvictionPaused.WithLabelValues(ReserveEviction).Set(0)
+		db.logger.Info("eviction resumed", "worker", ReserveEviction)
+		db.events.Trigger(batchExpiry)
+		db.events.Trigger(reserveOverCapacity)
+	}
+}
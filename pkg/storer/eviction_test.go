@@ -0,0 +1,76 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/spinlock"
+	chunktesting "github.com/calmw/bee-tron/pkg/storage/testing"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestPauseCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
+	opts.CacheCapacity = 10
+
+	lstore, err := memStorer(t, opts)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lstore.PauseEviction("unknown", 0); !errors.Is(err, storer.ErrUnknownEvictionWorker) {
+		t.Fatalf("PauseEviction(...): want error %v, have %v", storer.ErrUnknownEvictionWorker, err)
+	}
+
+	if err := lstore.PauseEviction(storer.CacheEviction, 0); err != nil {
+		t.Fatalf("PauseEviction(...): unexpected error: %v", err)
+	}
+	for _, st := range lstore.EvictionStatus() {
+		if want := st.Worker == storer.CacheEviction; st.Paused != want || !st.PausedUntil.IsZero() {
+			t.Fatalf("EvictionStatus(): unexpected status %+v", st)
+		}
+	}
+
+	for _, ch := range chunktesting.GenerateTestRandomChunks(15) {
+		if err := lstore.Cache().Put(context.Background(), ch); err != nil {
+			t.Fatalf("Cache.Put(...): unexpected error: %v", err)
+		}
+	}
+
+	cacheSize := func() uint64 {
+		info, err := lstore.DebugInfo(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uint64(info.Cache.Size)
+	}
+
+	// give the worker the chance to evict while paused
+	time.Sleep(100 * time.Millisecond)
+	if size := cacheSize(); size != 15 {
+		t.Fatalf("cache evicted while paused: want size %d, have %d", 15, size)
+	}
+
+	// pausing with a duration resumes the worker on its own
+	if err := lstore.PauseEviction(storer.CacheEviction, 100*time.Millisecond); err != nil {
+		t.Fatalf("PauseEviction(...): unexpected error: %v", err)
+	}
+	err = spinlock.Wait(5*time.Second, func() bool { return cacheSize() == 10 })
+	if err != nil {
+		t.Fatalf("cache not evicted after resume: have size %d", cacheSize())
+	}
+	for _, st := range lstore.EvictionStatus() {
+		if st.Paused {
+			t.Fatalf("EvictionStatus(): unexpected status %+v", st)
+		}
+	}
+}
@@ -5,6 +5,11 @@
 package storer
 
 import (
+	"context"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/calmw/bee-tron/pkg/storer/internal/events"
 	"github.com/calmw/bee-tron/pkg/storer/internal/reserve"
 )
@@ -35,3 +40,36 @@ func (db *DB) WaitForBgCacheWorkers() (unblock func()) {
 func DefaultOptions() *Options {
 	return defaultOptions()
 }
+
+func (db *DB) CompactSharky(ctx context.Context) (int, error) {
+	return db.compactSharky(ctx, 1, 0)
+}
+
+func (db *DB) RecordUsage(ctx context.Context, now time.Time) {
+	db.recordUsage(ctx, now)
+}
+
+func IndexMigrationMarker(basePath, backend string) string {
+	marker, _ := indexMigrationMarker(basePath, backend)
+	return marker
+}
+
+// NamespaceOperations returns the count of the operations of the namespace
+// with the status.
+func (db *DB) NamespaceOperations(namespace, operation, status string) float64 {
+	return counterValue(db.metrics.NamespaceOperations.WithLabelValues(namespace, operation, status))
+}
+
+// NamespaceEvictions returns the count of the chunks evicted from the
+// namespace.
+func (db *DB) NamespaceEvictions(namespace string) float64 {
+	return counterValue(db.metrics.NamespaceEvictions.WithLabelValues(namespace))
+}
+
+func counterValue(c interface{ Write(*dto.Metric) error }) float64 {
+	m := new(dto.Metric)
+	if err := c.Write(m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
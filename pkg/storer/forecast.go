@@ -0,0 +1,260 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Names of the storage components whose usage is forecast.
+const (
+	ReserveUsage = "reserve"
+	CacheUsage   = "cache"
+	PinningUsage = "pinning"
+)
+
+const (
+	// usageWindow is the number of usage samples the growth rate is fitted to.
+	usageWindow = 48
+	// usageWebhookTimeout bounds the delivery of a usage alert.
+	usageWebhookTimeout = 10 * time.Second
+)
+
+// UsageForecast is the projected usage of a storage component. The size and
+// the capacity of the reserve and the cache are in chunks, those of the
+// pinning in bytes.
+type UsageForecast struct {
+	Component string
+	Size      uint64
+	// Capacity is zero if the component is not limited.
+	Capacity uint64
+	// GrowthRate is the change of the size per second over the recent
+	// samples, negative if the component shrinks.
+	GrowthRate float64
+	// TimeToFull is the time until the size reaches the capacity at the
+	// growth rate, negative if the component is not projected to fill up.
+	TimeToFull time.Duration
+}
+
+// Projected reports whether the component is projected to fill up.
+func (f UsageForecast) Projected() bool {
+	return f.TimeToFull >= 0
+}
+
+// UsageForecaster projects the time until the storage components are full
+// from their recent growth.
+type UsageForecaster interface {
+	// UsageForecast returns the forecasts of the storage components.
+	UsageForecast() []UsageForecast
+}
+
+var _ UsageForecaster = (*DB)(nil)
+
+// UsageAlert is POSTed as JSON to the usage webhook when a storage component
+// is projected to fill up within the alert threshold.
+type UsageAlert struct {
+	Component  string  `json:"component"`
+	Size       uint64  `json:"size"`
+	Capacity   uint64  `json:"capacity"`
+	GrowthRate float64 `json:"growthRate"`
+	TimeToFull int64   `json:"timeToFull"` // seconds
+	Timestamp  int64   `json:"timestamp"`
+}
+
+type usageSample struct {
+	at   time.Time
+	size float64
+}
+
+// usageHistory keeps the recent usage samples of the storage components.
+type usageHistory struct {
+	mu      sync.Mutex
+	samples map[string][]usageSample
+	alerted map[string]bool // components alerted about until they recover
+}
+
+type usageOpts struct {
+	interval       time.Duration
+	webhookURL     string
+	alertThreshold time.Duration
+	client         *http.Client
+}
+
+// usage returns the current size and capacity of the storage components.
+func (db *DB) usage() []UsageForecast {
+	var usage []UsageForecast
+	if db.reserve != nil {
+		usage = append(usage, UsageForecast{
+			Component: ReserveUsage,
+			Size:      uint64(db.reserve.Size()),
+			Capacity:  uint64(db.reserve.Capacity()),
+		})
+	}
+	usage = append(usage, UsageForecast{
+		Component: CacheUsage,
+		Size:      uint64(db.cacheObj.Size()),
+		Capacity:  uint64(db.cacheObj.Capacity()),
+	})
+	if db.pinQuota != nil {
+		usage = append(usage, UsageForecast{
+			Component: PinningUsage,
+			Size:      db.pinQuota.Used(),
+			Capacity:  db.pinQuota.Limit(),
+		})
+	}
+	return usage
+}
+
+// UsageForecast is the implementation of the UsageForecaster.UsageForecast method.
+func (db *DB) UsageForecast() []UsageForecast {
+	db.usageHistory.mu.Lock()
+	defer db.usageHistory.mu.Unlock()
+
+	forecasts := db.usage()
+	for i := range forecasts {
+		forecast(&forecasts[i], db.usageHistory.samples[forecasts[i].Component])
+	}
+	return forecasts
+}
+
+// recordUsage samples the usage of the storage components, updates the
+// forecast metrics and alerts about the components filling up soon.
+func (db *DB) recordUsage(ctx context.Context, now time.Time) {
+	h := &db.usageHistory
+
+	h.mu.Lock()
+	if h.samples == nil {
+		h.samples = make(map[string][]usageSample)
+		h.alerted = make(map[string]bool)
+	}
+	var alerts []UsageForecast
+	forecasts := db.usage()
+	for i := range forecasts {
+		f := &forecasts[i]
+		samples := append(h.samples[f.Component], usageSample{at: now, size: float64(f.Size)})
+		if len(samples) > usageWindow {
+			samples = samples[len(samples)-usageWindow:]
+		}
+		h.samples[f.Component] = samples
+		forecast(f, samples)
+
+		db.metrics.UsageGrowthRate.WithLabelValues(f.Component).Set(f.GrowthRate)
+		db.metrics.UsageTimeToFull.WithLabelValues(f.Component).Set(f.TimeToFull.Seconds())
+
+		near := f.Projected() && f.TimeToFull <= db.usageOptions.alertThreshold
+		if near && !h.alerted[f.Component] {
+			alerts = append(alerts, *f)
+		}
+		h.alerted[f.Component] = near
+	}
+	h.mu.Unlock()
+
+	for _, f := range alerts {
+		db.logger.Warning("storage projected to fill up", "component", f.Component, "size", f.Size, "capacity", f.Capacity, "time_to_full", f.TimeToFull)
+		if db.usageOptions.webhookURL == "" {
+			continue
+		}
+		alert := UsageAlert{
+			Component:  f.Component,
+			Size:       f.Size,
+			Capacity:   f.Capacity,
+			GrowthRate: f.GrowthRate,
+			TimeToFull: int64(f.TimeToFull.Seconds()),
+			Timestamp:  now.Unix(),
+		}
+		if err := db.postUsageAlert(ctx, alert); err != nil {
+			db.logger.Warning("usage alert delivery failed", "component", f.Component, "error", err)
+		}
+	}
+}
+
+// forecast sets the growth rate and the time to full of f from the samples.
+func forecast(f *UsageForecast, samples []usageSample) {
+	f.GrowthRate = growthRate(samples)
+	f.TimeToFull = -1
+
+	switch {
+	case f.Capacity == 0:
+	case f.Size >= f.Capacity:
+		if f.GrowthRate > 0 {
+			f.TimeToFull = 0
+		}
+	case f.GrowthRate > 0:
+		f.TimeToFull = time.Duration(float64(f.Capacity-f.Size) / f.GrowthRate * float64(time.Second))
+	}
+}
+
+// growthRate returns the least squares slope of the samples per second.
+func growthRate(samples []usageSample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+
+	var sx, sy, sxx, sxy float64
+	for _, s := range samples {
+		x := s.at.Sub(samples[0].at).Seconds()
+		sx += x
+		sy += s.size
+		sxx += x * x
+		sxy += x * s.size
+	}
+	n := float64(len(samples))
+	d := n*sxx - sx*sx
+	if d == 0 {
+		return 0
+	}
+	return (n*sxy - sx*sy) / d
+}
+
+func (db *DB) postUsageAlert(ctx context.Context, alert UsageAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, db.usageOptions.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := db.usageOptions.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// usageForecastWorker periodically samples the usage of the storage components.
+func (db *DB) usageForecastWorker(ctx context.Context) {
+	defer db.inFlight.Done()
+
+	ticker := time.NewTicker(db.usageOptions.interval)
+	defer ticker.Stop()
+
+	db.recordUsage(ctx, time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-db.quit:
+			return
+		case <-ticker.C:
+			db.recordUsage(ctx, time.Now())
+		}
+	}
+}
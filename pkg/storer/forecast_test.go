@@ -0,0 +1,101 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	chunktesting "github.com/calmw/bee-tron/pkg/storage/testing"
+	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestUsageForecast(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		alerts []storer.UsageAlert
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert storer.UsageAlert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		alerts = append(alerts, alert)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
+	opts.CacheCapacity = 100
+	opts.UsageAlertWebhook = srv.URL
+	opts.UsageAlertThreshold = 100 * time.Second
+
+	lstore, err := memStorer(t, opts)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forecast := func(component string) storer.UsageForecast {
+		t.Helper()
+		for _, f := range lstore.UsageForecast() {
+			if f.Component == component {
+				return f
+			}
+		}
+		t.Fatalf("UsageForecast(): missing component %q", component)
+		return storer.UsageForecast{}
+	}
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		if i > 0 {
+			for _, ch := range chunktesting.GenerateTestRandomChunks(10) {
+				if err := lstore.Cache().Put(context.Background(), ch); err != nil {
+					t.Fatalf("Cache.Put(...): unexpected error: %v", err)
+				}
+			}
+		}
+		lstore.RecordUsage(context.Background(), now.Add(time.Duration(i)*10*time.Second))
+	}
+
+	cache := forecast(storer.CacheUsage)
+	if cache.Size != 30 || cache.Capacity != 100 {
+		t.Fatalf("cache forecast: want size 30 and capacity 100, have %+v", cache)
+	}
+	if cache.GrowthRate != 1 {
+		t.Fatalf("cache forecast: want growth rate 1, have %v", cache.GrowthRate)
+	}
+	if cache.TimeToFull != 70*time.Second {
+		t.Fatalf("cache forecast: want time to full %v, have %v", 70*time.Second, cache.TimeToFull)
+	}
+
+	if reserve := forecast(storer.ReserveUsage); reserve.Projected() {
+		t.Fatalf("reserve forecast: unexpected projection %+v", reserve)
+	}
+	if pinning := forecast(storer.PinningUsage); pinning.Projected() {
+		t.Fatalf("pinning forecast: unexpected projection %+v", pinning)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the alert is fired once when the cache is first projected within the threshold
+	if len(alerts) != 1 {
+		t.Fatalf("want 1 alert, have %d", len(alerts))
+	}
+	if alerts[0].Component != storer.CacheUsage || alerts[0].TimeToFull != 90 {
+		t.Fatalf("unexpected alert %+v", alerts[0])
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/soc"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// gatewayRequestTimeout bounds a chunk request to the gateway so that a slow
+// gateway does not delay the fallback to the network retrieval.
+const gatewayRequestTimeout = 5 * time.Second
+
+// errInvalidGatewayChunk is returned when the gateway serves data which does
+// not match the requested address.
+var errInvalidGatewayChunk = errors.New("gateway: invalid chunk")
+
+// GatewayGetter fetches chunks from the chunk endpoint of a Bee HTTP gateway
+// or companion node. The served data is not trusted, only chunks which are
+// valid content addressed or single owner chunks are returned.
+type GatewayGetter struct {
+	client *http.Client
+	url    string
+}
+
+// NewGatewayGetter returns a GatewayGetter for the gateway API at url.
+func NewGatewayGetter(url string) *GatewayGetter {
+	return &GatewayGetter{
+		client: &http.Client{Timeout: gatewayRequestTimeout},
+		url:    strings.TrimSuffix(url, "/"),
+	}
+}
+
+// Get implements the storage.Getter interface.
+func (g *GatewayGetter) Get(ctx context.Context, address swarm.Address) (swarm.Chunk, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url+"/chunks/"+address.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gateway: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, storage.ErrNotFound
+	default:
+		return nil, fmt.Errorf("gateway: unexpected status %s", res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, swarm.SocMaxChunkSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("gateway: %w", err)
+	}
+	if len(data) > swarm.SocMaxChunkSize {
+		return nil, errInvalidGatewayChunk
+	}
+
+	ch := swarm.NewChunk(address, data)
+	if !cac.Valid(ch) && !soc.Valid(ch) {
+		return nil, errInvalidGatewayChunk
+	}
+	return ch, nil
+}
@@ -0,0 +1,402 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/leveldbstore"
+	"github.com/calmw/bee-tron/pkg/storage/pebblestore"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// Index store backends selectable with Options.IndexStore.
+const (
+	LevelDBIndexStore = "leveldb"
+	PebbleIndexStore  = "pebble"
+)
+
+// Block compressions of the levelDB index store selectable with
+// Options.LdbCompression.
+const (
+	LdbSnappyCompression = "snappy"
+	LdbNoCompression     = "none"
+)
+
+// migrateIndexBatchSize is the number of entries copied in one write
+// when migrating the index store between backends.
+const migrateIndexBatchSize = 10_000
+
+// indexMigrationMarkerSuffix is the suffix of the file marking the index
+// store the migration into has not completed yet, next to its directory.
+const indexMigrationMarkerSuffix = ".migrating"
+
+// Defaults of the pebble index store, used for the zero options.
+const (
+	defaultPebbleCacheCapacity  = uint64(64 * 1024 * 1024)
+	defaultPebbleMemTableSize   = uint64(64 * 1024 * 1024)
+	defaultPebbleOpenFilesLimit = uint64(1000)
+)
+
+var (
+	// ErrUnknownIndexStore is returned for index store backends other
+	// than LevelDBIndexStore and PebbleIndexStore.
+	ErrUnknownIndexStore = errors.New("unknown index store backend")
+	// ErrIndexStoreMismatch is returned when the localstore holds the index
+	// store of another backend than the selected one.
+	ErrIndexStoreMismatch = errors.New("index store of another backend found")
+	// ErrIndexStoreNotEmpty is returned when the index store is migrated
+	// into a backend that already holds entries.
+	ErrIndexStoreNotEmpty = errors.New("index store migration requires an empty target")
+	// ErrIndexStoreMigrationIncomplete is returned when the selected index
+	// store is the target of a migration which has not completed.
+	ErrIndexStoreMigrationIncomplete = errors.New("index store migration incomplete")
+	// ErrUnknownLdbCompression is returned for block compressions other
+	// than LdbSnappyCompression and LdbNoCompression.
+	ErrUnknownLdbCompression = errors.New("unknown levelDB compression")
+)
+
+// indexStorePath returns the directory of the index store backend.
+func indexStorePath(basePath, backend string) (string, error) {
+	switch backend {
+	case "", LevelDBIndexStore:
+		return path.Join(basePath, indexPath), nil
+	case PebbleIndexStore:
+		return path.Join(basePath, pebbleIndexPath), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownIndexStore, backend)
+}
+
+// otherIndexStore returns the backend which is not the given one.
+func otherIndexStore(backend string) string {
+	if backend == PebbleIndexStore {
+		return LevelDBIndexStore
+	}
+	return PebbleIndexStore
+}
+
+// indexMigrationMarker returns the path of the file marking the index store
+// of the backend as the target of an incomplete migration.
+func indexMigrationMarker(basePath, backend string) (string, error) {
+	storePath, err := indexStorePath(basePath, backend)
+	if err != nil {
+		return "", err
+	}
+	return storePath + indexMigrationMarkerSuffix, nil
+}
+
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+// initStore opens the index store of the backend selected in the options.
+// A localstore holding only the index store of the other backend is rejected
+// so that it is not replaced by an empty index by accident, as is an index
+// store the migration into has not completed.
+func initStore(basePath string, opts *Options) (storage.BatchStore, error) {
+	storePath, err := indexStorePath(basePath, opts.IndexStore)
+	if err != nil {
+		return nil, err
+	}
+	marker, _ := indexMigrationMarker(basePath, opts.IndexStore)
+	if exists(marker) {
+		return nil, fmt.Errorf("%w: %s, run the db migrate-index command again", ErrIndexStoreMigrationIncomplete, opts.IndexStore)
+	}
+	other := otherIndexStore(opts.IndexStore)
+	otherPath, _ := indexStorePath(basePath, other)
+	if !exists(storePath) && exists(otherPath) {
+		return nil, fmt.Errorf("%w: %s, migrate it with the db migrate-index command", ErrIndexStoreMismatch, other)
+	}
+
+	return openIndexStore(basePath, opts.IndexStore, opts)
+}
+
+// openIndexStore opens or creates the index store of the backend.
+func openIndexStore(basePath, backend string, opts *Options) (storage.BatchStore, error) {
+	storePath, err := indexStorePath(basePath, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(storePath); os.IsNotExist(err) {
+		err := os.MkdirAll(storePath, 0777)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if backend == PebbleIndexStore {
+		cache := pebble.NewCache(int64(withDefault(opts.PebbleCacheCapacity, defaultPebbleCacheCapacity)))
+		defer cache.Unref()
+
+		store, err := pebblestore.New(storePath, &pebble.Options{
+			Cache:        cache,
+			MaxOpenFiles: int(withDefault(opts.PebbleOpenFilesLimit, defaultPebbleOpenFilesLimit)),
+			MemTableSize: withDefault(opts.PebbleMemTableSize, defaultPebbleMemTableSize),
+			Levels:       []pebble.LevelOptions{{FilterPolicy: bloom.FilterPolicy(10)}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed creating pebble index store: %w", err)
+		}
+		return store, nil
+	}
+
+	ldbOpts, err := levelDBOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	store, err := leveldbstore.New(storePath, ldbOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
+	}
+
+	return store, nil
+}
+
+// withDefault returns the value, or the default if the value is zero.
+func withDefault(value, def uint64) uint64 {
+	if value == 0 {
+		return def
+	}
+	return value
+}
+
+// levelDBOptions returns the options of the levelDB index store.
+func levelDBOptions(opts *Options) (*opt.Options, error) {
+	compression := opt.SnappyCompression
+	switch opts.LdbCompression {
+	case "", LdbSnappyCompression:
+	case LdbNoCompression:
+		compression = opt.NoCompression
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownLdbCompression, opts.LdbCompression)
+	}
+
+	bloomFilterBits := opts.LdbBloomFilterBits
+	if bloomFilterBits == 0 {
+		bloomFilterBits = defaultBloomFilterBits
+	}
+
+	return &opt.Options{
+		OpenFilesCacheCapacity: int(opts.LdbOpenFilesLimit),
+		BlockCacheCapacity:     int(opts.LdbBlockCacheCapacity),
+		WriteBuffer:            int(opts.LdbWriteBufferSize),
+		DisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
+		CompactionL0Trigger:    8,
+		CompactionTableSize:    int(opts.LdbCompactionTableSize),
+		CompactionTotalSize:    int(opts.LdbCompactionTotalSize),
+		Compression:            compression,
+		Filter:                 filter.NewBloomFilter(bloomFilterBits),
+	}, nil
+}
+
+// iterateIndexStore calls fn with every key and value of the index store.
+// The key and value are only valid until fn returns.
+func iterateIndexStore(store storage.Store, fn func(key, value []byte) error) error {
+	switch s := store.(type) {
+	case *leveldbstore.Store:
+		iter := s.DB().NewIterator(nil, nil)
+		defer iter.Release()
+		for iter.Next() {
+			if err := fn(iter.Key(), iter.Value()); err != nil {
+				return err
+			}
+		}
+		return iter.Error()
+	case *pebblestore.Store:
+		iter, err := s.DB().NewIter(nil)
+		if err != nil {
+			return err
+		}
+		for valid := iter.First(); valid; valid = iter.Next() {
+			if err := fn(iter.Key(), iter.Value()); err != nil {
+				return errors.Join(err, iter.Close())
+			}
+		}
+		return errors.Join(iter.Error(), iter.Close())
+	}
+	return fmt.Errorf("%w: %T", ErrUnknownIndexStore, store)
+}
+
+// indexStoreWriter returns the functions to add an entry to the pending
+// writes of the index store and to flush them.
+func indexStoreWriter(store storage.Store) (put func(key, value []byte) error, flush func() error, err error) {
+	switch s := store.(type) {
+	case *leveldbstore.Store:
+		batch := new(leveldb.Batch)
+		put = func(key, value []byte) error {
+			batch.Put(key, value)
+			return nil
+		}
+		flush = func() error {
+			if err := s.DB().Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+				return err
+			}
+			batch.Reset()
+			return nil
+		}
+		return put, flush, nil
+	case *pebblestore.Store:
+		batch := s.DB().NewBatch()
+		put = func(key, value []byte) error {
+			return batch.Set(key, value, nil)
+		}
+		flush = func() error {
+			if err := batch.Commit(pebble.Sync); err != nil {
+				return err
+			}
+			batch.Reset()
+			return nil
+		}
+		return put, flush, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %T", ErrUnknownIndexStore, store)
+}
+
+// MigrateIndexStore copies the index store of the localstore at basePath
+// from one backend to the other. The source index store is left in place
+// and has to be removed by the operator once the node runs with the
+// target backend. The target is marked until the migration completes, so
+// that it is not opened by the node and is cleared when the migration is
+// run again after an interruption.
+func MigrateIndexStore(ctx context.Context, basePath, from, to string, opts *Options) (err error) {
+	logger := opts.Logger
+
+	if from == to {
+		return fmt.Errorf("index store is already backed by %s", to)
+	}
+	fromPath, err := indexStorePath(basePath, from)
+	if err != nil {
+		return err
+	}
+	toPath, err := indexStorePath(basePath, to)
+	if err != nil {
+		return err
+	}
+	if !exists(fromPath) {
+		return fmt.Errorf("no %s index store found in %s", from, basePath)
+	}
+
+	marker, _ := indexMigrationMarker(basePath, to)
+	if exists(marker) {
+		logger.Warning("clearing the index store of an incomplete migration", "backend", to)
+		if err := os.RemoveAll(toPath); err != nil {
+			return fmt.Errorf("clear incomplete index store: %w", err)
+		}
+	} else if exists(toPath) {
+		// an index store not marked as incomplete is only replaced if empty
+		dst, err := openIndexStore(basePath, to, opts)
+		if err != nil {
+			return err
+		}
+		empty, err := isIndexStoreEmpty(dst)
+		if err := errors.Join(err, dst.Close()); err != nil {
+			return err
+		}
+		if !empty {
+			return ErrIndexStoreNotEmpty
+		}
+	}
+	if err := writeMarker(marker); err != nil {
+		return fmt.Errorf("mark index store migration: %w", err)
+	}
+
+	src, err := openIndexStore(basePath, from, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, src.Close())
+	}()
+
+	logger.Info("migrating index store", "from", from, "to", to)
+
+	start := time.Now()
+	n, err := copyIndexStore(ctx, logger, src, basePath, to, opts)
+	if err != nil {
+		return fmt.Errorf("migrate index store: %w", err)
+	}
+	// the target is complete once all its entries are written and it is
+	// closed
+	if err := os.Remove(marker); err != nil {
+		return fmt.Errorf("mark index store migration: %w", err)
+	}
+
+	logger.Info("index store migrated", "from", from, "to", to, "entries", n, "duration", time.Since(start))
+
+	return nil
+}
+
+// copyIndexStore copies the entries of the source index store into the index
+// store of the backend, which is closed on return.
+func copyIndexStore(ctx context.Context, logger log.Logger, src storage.Store, basePath, backend string, opts *Options) (n int, err error) {
+	dst, err := openIndexStore(basePath, backend, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, dst.Close())
+	}()
+
+	put, flush, err := indexStoreWriter(dst)
+	if err != nil {
+		return 0, err
+	}
+
+	err = iterateIndexStore(src, func(key, value []byte) error {
+		if err := put(key, value); err != nil {
+			return err
+		}
+		n++
+		if n%migrateIndexBatchSize != 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		logger.Info("migrating index store", "entries", n)
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, flush()
+}
+
+// isIndexStoreEmpty reports whether the index store holds no entries.
+func isIndexStoreEmpty(store storage.Store) (bool, error) {
+	errStop := errors.New("stop")
+	err := iterateIndexStore(store, func(_, _ []byte) error {
+		return errStop
+	})
+	if errors.Is(err, errStop) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// writeMarker creates the empty marker file and syncs it to the disk.
+func writeMarker(p string) error {
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	return errors.Join(f.Sync(), f.Close())
+}
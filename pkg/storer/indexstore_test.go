@@ -0,0 +1,151 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	pullerMock "github.com/calmw/bee-tron/pkg/puller/mock"
+	chunk "github.com/calmw/bee-tron/pkg/storage/testing"
+	"github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// TestMigrateIndexStore puts chunks into a localstore backed by leveldb,
+// migrates the index store to pebble and tests that the chunks can still be
+// retrieved with the pebble backend.
+func TestMigrateIndexStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	basePath := t.TempDir()
+
+	opts := dbTestOps(swarm.RandAddress(t), 10_000, nil, nil, time.Minute)
+	opts.CacheCapacity = 0
+
+	st, err := storer.New(ctx, basePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.StartReserveWorker(ctx, pullerMock.NewMockRateReporter(0), networkRadiusFunc(0))
+
+	batch := postagetesting.MustNewBatch()
+	chunks := make([]swarm.Chunk, 0, 100)
+	putter := st.ReservePutter()
+	for i := 0; i < cap(chunks); i++ {
+		ch := chunk.GenerateTestRandomChunk().WithStamp(postagetesting.MustNewBatchStamp(batch.ID))
+		if err := putter.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks, ch)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.IndexStore = storer.PebbleIndexStore
+	if _, err := storer.New(ctx, basePath, opts); !errors.Is(err, storer.ErrIndexStoreMismatch) {
+		t.Fatalf("got error %v, want %v", err, storer.ErrIndexStoreMismatch)
+	}
+
+	err = storer.MigrateIndexStore(ctx, basePath, storer.LevelDBIndexStore, storer.PebbleIndexStore, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err = storer.New(ctx, basePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range chunks {
+		stampHash, err := ch.Stamp().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		has, err := st.ReserveHas(ch.Address(), ch.Stamp().BatchID(), stampHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !has {
+			t.Fatal("store should have chunk")
+		}
+		checkSaved(t, st, ch, true, true)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = storer.MigrateIndexStore(ctx, basePath, storer.LevelDBIndexStore, storer.PebbleIndexStore, opts)
+	if !errors.Is(err, storer.ErrIndexStoreNotEmpty) {
+		t.Fatalf("got error %v, want %v", err, storer.ErrIndexStoreNotEmpty)
+	}
+
+	// an interrupted migration leaves the target marked
+	marker := storer.IndexMigrationMarker(basePath, storer.PebbleIndexStore)
+	if err := os.WriteFile(marker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storer.New(ctx, basePath, opts); !errors.Is(err, storer.ErrIndexStoreMigrationIncomplete) {
+		t.Fatalf("got error %v, want %v", err, storer.ErrIndexStoreMigrationIncomplete)
+	}
+
+	// the incomplete target is cleared when the migration is run again
+	err = storer.MigrateIndexStore(ctx, basePath, storer.LevelDBIndexStore, storer.PebbleIndexStore, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("marker of the completed migration not removed: %v", err)
+	}
+	st, err = storer.New(ctx, basePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range chunks {
+		checkSaved(t, st, ch, true, true)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLevelDBTuning tests that a localstore opens with tuned levelDB options
+// and that an unknown compression is rejected.
+func TestLevelDBTuning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	opts := dbTestOps(swarm.RandAddress(t), 1000, nil, nil, time.Minute)
+	opts.LdbBloomFilterBits = 10
+	opts.LdbCompactionTableSize = 8 * 1024 * 1024
+	opts.LdbCompactionTotalSize = 80 * 1024 * 1024
+	opts.LdbCompression = storer.LdbNoCompression
+
+	st, err := storer.New(ctx, t.TempDir(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := chunk.GenerateTestRandomChunk()
+	if err := st.Cache().Put(ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Lookup().Get(ctx, ch.Address()); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.LdbCompression = "zstd"
+	if _, err := storer.New(ctx, t.TempDir(), opts); !errors.Is(err, storer.ErrUnknownLdbCompression) {
+		t.Fatalf("got error %v, want %v", err, storer.ErrUnknownLdbCompression)
+	}
+}
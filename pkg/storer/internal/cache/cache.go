@@ -26,7 +26,17 @@ var now = time.Now
 // exported for migration
 type CacheEntryItem = cacheEntry
 
-const cacheEntrySize = swarm.HashSize + 8
+const (
+	cacheEntrySize       = swarm.HashSize + 8 + 4 + 1
+	legacyCacheEntrySize = swarm.HashSize + 8 // entries without size and flags
+
+	// unknownEntrySize is the size accounted for the entries stored before
+	// their size was recorded.
+	unknownEntrySize = swarm.SocMaxChunkSize
+
+	// maxRequeue caps the number of entries spared in one eviction pass.
+	maxRequeue = 10_000
+)
 
 var _ storage.Item = (*cacheEntry)(nil)
 
@@ -40,22 +50,61 @@ var (
 // part of the reserve but are potentially useful to store for obtaining bandwidth
 // incentives.
 type Cache struct {
-	size     atomic.Int64
-	capacity int
-	glock    *multex.Multex // blocks Get and Put ops while shallow copy is running.
+	size          atomic.Int64
+	sizeBytes     atomic.Int64
+	capacity      int
+	capacityBytes int64
+	policy        Policy
+	glock         *multex.Multex // blocks Get and Put ops while shallow copy is running.
+}
+
+// Option is a function that configures the Cache.
+type Option func(*Cache)
+
+// WithPolicy sets the eviction policy of the cache, LRU by default.
+func WithPolicy(p Policy) Option {
+	return func(c *Cache) {
+		c.policy = p
+	}
+}
+
+// WithCapacityBytes limits the total size of the cached chunks in addition
+// to their count, no limit if zero.
+func WithCapacityBytes(capacity uint64) Option {
+	return func(c *Cache) {
+		c.capacityBytes = int64(capacity)
+	}
 }
 
 // New creates a new Cache component with the specified capacity. The store is used
 // here only to read the initial state of the cache before shutdown if there was
 // any.
-func New(ctx context.Context, store storage.Reader, capacity uint64) (*Cache, error) {
-	count, err := store.Count(&cacheEntry{})
+func New(ctx context.Context, store storage.Reader, capacity uint64, opts ...Option) (*Cache, error) {
+	c := &Cache{capacity: int(capacity), policy: new(lru), glock: multex.New()}
+	for _, o := range opts {
+		o(c)
+	}
+
+	var count, bytes int64
+	err := store.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return &cacheEntry{} },
+		},
+		func(res storage.Result) (bool, error) {
+			count++
+			bytes += res.Entry.(*cacheEntry).bytes()
+			return false, nil
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed counting cache entries: %w", err)
 	}
+	c.size.Store(count)
+	c.sizeBytes.Store(bytes)
 
-	c := &Cache{capacity: int(capacity), glock: multex.New()}
-	c.size.Store(int64(count))
+	if err := c.policy.Init(store, c.capacity); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
@@ -70,6 +119,29 @@ func (c *Cache) Capacity() int64 {
 	return int64(c.capacity)
 }
 
+// SizeBytes returns the total size of the cached chunks.
+func (c *Cache) SizeBytes() int64 {
+	return c.sizeBytes.Load()
+}
+
+// CapacityBytes returns the capacity of the cache in bytes, zero if the
+// cache is only limited by the number of chunks.
+func (c *Cache) CapacityBytes() int64 {
+	return c.capacityBytes
+}
+
+// Excess returns the number of entries and bytes the cache holds above its
+// capacities.
+func (c *Cache) Excess() (count, bytes uint64) {
+	if size := c.Size(); size > c.Capacity() {
+		count = uint64(size - c.Capacity())
+	}
+	if size := c.SizeBytes(); c.capacityBytes > 0 && size > c.capacityBytes {
+		bytes = uint64(size - c.capacityBytes)
+	}
+	return count, bytes
+}
+
 // Putter returns a Storage.Putter instance which adds the chunk to the underlying
 // chunkstore and also adds a Cache entry for the chunk.
 func (c *Cache) Putter(store transaction.Storage) storage.Putter {
@@ -92,18 +164,15 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 			return nil
 		}
 
-		newEntry.AccessTimestamp = now().UnixNano()
-		err = trx.IndexStore().Put(newEntry)
+		newEntry.Size = uint32(len(chunk.Data()))
+		err = c.policy.Insert(trx.IndexStore(), newEntry)
 		if err != nil {
-			return fmt.Errorf("failed adding cache entry: %w", err)
+			return fmt.Errorf("failed adding cache order index: %w", err)
 		}
 
-		err = trx.IndexStore().Put(&cacheOrderIndex{
-			Address:         newEntry.Address,
-			AccessTimestamp: newEntry.AccessTimestamp,
-		})
+		err = trx.IndexStore().Put(newEntry)
 		if err != nil {
-			return fmt.Errorf("failed adding cache order index: %w", err)
+			return fmt.Errorf("failed adding cache entry: %w", err)
 		}
 
 		err = trx.ChunkStore().Put(ctx, chunk)
@@ -116,6 +185,7 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 		}
 
 		c.size.Add(1)
+		c.sizeBytes.Add(newEntry.bytes())
 
 		return nil
 	})
@@ -150,21 +220,9 @@ func (c *Cache) Getter(store transaction.Storage) storage.Getter {
 			return nil, fmt.Errorf("unexpected error getting indexstore entry: %w", err)
 		}
 
-		err = trx.IndexStore().Delete(&cacheOrderIndex{
-			Address:         entry.Address,
-			AccessTimestamp: entry.AccessTimestamp,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed deleting cache order index: %w", err)
-		}
-
-		entry.AccessTimestamp = now().UnixNano()
-		err = trx.IndexStore().Put(&cacheOrderIndex{
-			Address:         entry.Address,
-			AccessTimestamp: entry.AccessTimestamp,
-		})
+		err = c.policy.Access(trx.IndexStore(), entry)
 		if err != nil {
-			return nil, fmt.Errorf("failed adding cache order index: %w", err)
+			return nil, err
 		}
 
 		err = trx.IndexStore().Put(entry)
@@ -184,63 +242,130 @@ func (c *Cache) Getter(store transaction.Storage) storage.Getter {
 // RemoveOldest removes the oldest cache entries from the store. The count
 // specifies the number of entries to remove.
 func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, count uint64) error {
+	_, err := c.Evict(ctx, st, count, 0)
+	return err
+}
 
-	if count <= 0 {
-		return nil
-	}
-
-	evictItems := make([]*cacheEntry, 0, count)
-	err := st.IndexStore().Iterate(
-		storage.Query{
-			Factory:      func() storage.Item { return &cacheOrderIndex{} },
-			ItemProperty: storage.QueryItemID,
-		},
-		func(res storage.Result) (bool, error) {
-			accessTime, addr, err := idFromKey(res.ID)
-			if err != nil {
-				return false, fmt.Errorf("failed to parse cache order index %s: %w", res.ID, err)
-			}
-			entry := &cacheEntry{
-				Address:         addr,
-				AccessTimestamp: accessTime,
+// Evict removes cache entries from the store in the order of the eviction
+// policy until at least count entries making up at least the given number of
+// bytes are removed. It returns the number of removed entries, also if it
+// fails.
+func (c *Cache) Evict(ctx context.Context, st transaction.Storage, count, bytes uint64) (evicted uint64, err error) {
+
+	for count > 0 || bytes > 0 {
+		var (
+			evictItems   []*cacheEntry
+			requeueItems []*cacheEntry
+			evictCount   uint64
+			evictBytes   uint64
+		)
+		err := c.policy.Candidates(st.IndexStore(), func(entry *cacheEntry, spare bool) (bool, error) {
+			if spare {
+				requeueItems = append(requeueItems, entry)
+				return len(requeueItems) == maxRequeue, nil
 			}
 			evictItems = append(evictItems, entry)
-			count--
-			return count == 0, nil
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("failed iterating over cache order index: %w", err)
+			evictCount++
+			evictBytes += uint64(entry.bytes())
+			return evictCount >= count && evictBytes >= bytes, nil
+		})
+		if err != nil {
+			return evicted, fmt.Errorf("failed iterating over cache order index: %w", err)
+		}
+
+		if len(evictItems) == 0 && len(requeueItems) == 0 {
+			return evicted, nil
+		}
+
+		if err := c.requeue(ctx, st, requeueItems); err != nil {
+			return evicted, err
+		}
+		removed, err := c.remove(ctx, st, evictItems)
+		evicted += removed
+		if err != nil {
+			return evicted, err
+		}
+
+		count -= min(count, evictCount)
+		bytes -= min(bytes, evictBytes)
 	}
 
+	return evicted, nil
+}
+
+// requeue gives the entries spared by the eviction policy another round
+// unless they changed since they were read.
+func (c *Cache) requeue(ctx context.Context, st transaction.Storage, items []*cacheEntry) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.SetLimit(runtime.NumCPU())
 
-	for _, item := range evictItems {
+	for _, item := range items {
 		func(item *cacheEntry) {
 			eg.Go(func() error {
 				c.glock.Lock(item.Address.ByteString())
 				defer c.glock.Unlock(item.Address.ByteString())
+				return st.Run(ctx, func(s transaction.Store) error {
+					entry := &cacheEntry{Address: item.Address}
+					switch err := s.IndexStore().Get(entry); {
+					case errors.Is(err, storage.ErrNotFound):
+						return nil
+					case err != nil:
+						return err
+					case entry.AccessTimestamp != item.AccessTimestamp || entry.Flags != item.Flags:
+						return nil
+					}
+					if err := c.policy.Requeue(s.IndexStore(), entry); err != nil {
+						return err
+					}
+					return s.IndexStore().Put(entry)
+				})
+			})
+		}(item)
+	}
+
+	return eg.Wait()
+}
+
+// remove deletes the entries and their chunks and returns the number of the
+// deleted entries.
+func (c *Cache) remove(ctx context.Context, st transaction.Storage, items []*cacheEntry) (uint64, error) {
+	var removed atomic.Uint64
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(runtime.NumCPU())
+
+	for _, item := range items {
+		func(item *cacheEntry) {
+			eg.Go(func() error {
+				c.glock.Lock(item.Address.ByteString())
+				defer c.glock.Unlock(item.Address.ByteString())
+				entry := &cacheEntry{Address: item.Address}
 				err := st.Run(ctx, func(s transaction.Store) error {
+					switch err := s.IndexStore().Get(entry); {
+					case errors.Is(err, storage.ErrNotFound):
+						entry = nil
+						return nil
+					case err != nil:
+						return err
+					}
 					return errors.Join(
-						s.IndexStore().Delete(item),
-						s.IndexStore().Delete(&cacheOrderIndex{
-							Address:         item.Address,
-							AccessTimestamp: item.AccessTimestamp,
-						}),
-						s.ChunkStore().Delete(ctx, item.Address),
+						s.IndexStore().Delete(entry),
+						c.policy.Remove(s.IndexStore(), entry),
+						s.ChunkStore().Delete(ctx, entry.Address),
 					)
 				})
-				if err != nil {
+				if err != nil || entry == nil {
 					return err
 				}
 				c.size.Add(-1)
+				c.sizeBytes.Add(-entry.bytes())
+				removed.Add(1)
 				return nil
 			})
 		}(item)
 	}
 
-	return eg.Wait()
+	err := eg.Wait()
+	return removed.Load(), err
 }
 
 // ShallowCopy creates cache entries with the expectation that the chunk already exists in the chunkstore.
@@ -296,16 +421,13 @@ func (c *Cache) ShallowCopy(
 
 	err = store.Run(ctx, func(s transaction.Store) error {
 		for _, entry := range entries {
-			err = s.IndexStore().Put(entry)
+			err = c.policy.Insert(s.IndexStore(), entry)
 			if err != nil {
-				return fmt.Errorf("failed adding entry %s: %w", entry, err)
+				return fmt.Errorf("failed adding cache order index: %w", err)
 			}
-			err = s.IndexStore().Put(&cacheOrderIndex{
-				Address:         entry.Address,
-				AccessTimestamp: entry.AccessTimestamp,
-			})
+			err = s.IndexStore().Put(entry)
 			if err != nil {
-				return fmt.Errorf("failed adding cache order index: %w", err)
+				return fmt.Errorf("failed adding entry %s: %w", entry, err)
 			}
 		}
 		return nil
@@ -315,12 +437,23 @@ func (c *Cache) ShallowCopy(
 	}
 
 	c.size.Add(int64(len(entries)))
+	c.sizeBytes.Add(int64(len(entries)) * unknownEntrySize)
 	return nil
 }
 
 type cacheEntry struct {
 	Address         swarm.Address
 	AccessTimestamp int64
+	Size            uint32 // size of the chunk data, zero if unknown
+	Flags           uint8  // eviction policy flags
+}
+
+// bytes returns the size accounted for the entry.
+func (c *cacheEntry) bytes() int64 {
+	if c.Size == 0 {
+		return unknownEntrySize
+	}
+	return int64(c.Size)
 }
 
 func (c *cacheEntry) ID() string { return c.Address.ByteString() }
@@ -337,16 +470,22 @@ func (c *cacheEntry) Marshal() ([]byte, error) {
 	}
 	copy(entryBuf[:swarm.HashSize], c.Address.Bytes())
 	binary.LittleEndian.PutUint64(entryBuf[swarm.HashSize:], uint64(c.AccessTimestamp))
+	binary.LittleEndian.PutUint32(entryBuf[legacyCacheEntrySize:], c.Size)
+	entryBuf[cacheEntrySize-1] = c.Flags
 	return entryBuf, nil
 }
 
 func (c *cacheEntry) Unmarshal(buf []byte) error {
-	if len(buf) != cacheEntrySize {
+	if len(buf) != cacheEntrySize && len(buf) != legacyCacheEntrySize {
 		return errUnmarshalCacheEntryInvalidSize
 	}
 	newEntry := new(cacheEntry)
 	newEntry.Address = swarm.NewAddress(append(make([]byte, 0, swarm.HashSize), buf[:swarm.HashSize]...))
 	newEntry.AccessTimestamp = int64(binary.LittleEndian.Uint64(buf[swarm.HashSize:]))
+	if len(buf) == cacheEntrySize {
+		newEntry.Size = binary.LittleEndian.Uint32(buf[legacyCacheEntrySize:])
+		newEntry.Flags = buf[cacheEntrySize-1]
+	}
 	*c = *newEntry
 	return nil
 }
@@ -358,14 +497,18 @@ func (c *cacheEntry) Clone() storage.Item {
 	return &cacheEntry{
 		Address:         c.Address.Clone(),
 		AccessTimestamp: c.AccessTimestamp,
+		Size:            c.Size,
+		Flags:           c.Flags,
 	}
 }
 
 func (c cacheEntry) String() string {
 	return fmt.Sprintf(
-		"cacheEntry { Address: %s AccessTimestamp: %s }",
+		"cacheEntry { Address: %s AccessTimestamp: %s Size: %d Flags: %d }",
 		c.Address,
 		time.Unix(c.AccessTimestamp, 0).UTC().Format(time.RFC3339),
+		c.Size,
+		c.Flags,
 	)
 }
 
@@ -374,6 +517,7 @@ var _ storage.Item = (*cacheOrderIndex)(nil)
 type cacheOrderIndex struct {
 	AccessTimestamp int64
 	Address         swarm.Address
+	Segment         uint8 // segment of the eviction order, part of the namespace
 }
 
 func keyFromID(ts int64, addr swarm.Address) string {
@@ -395,7 +539,12 @@ func (c *cacheOrderIndex) ID() string {
 	return keyFromID(c.AccessTimestamp, c.Address)
 }
 
-func (cacheOrderIndex) Namespace() string { return "cacheOrderIndex" }
+func (c cacheOrderIndex) Namespace() string {
+	if c.Segment == segmentDefault {
+		return "cacheOrderIndex"
+	}
+	return "cacheOrderIndex" + strconv.Itoa(int(c.Segment))
+}
 
 func (cacheOrderIndex) Marshal() ([]byte, error) {
 	return nil, nil
@@ -412,13 +561,15 @@ func (c *cacheOrderIndex) Clone() storage.Item {
 	return &cacheOrderIndex{
 		AccessTimestamp: c.AccessTimestamp,
 		Address:         c.Address.Clone(),
+		Segment:         c.Segment,
 	}
 }
 
 func (c cacheOrderIndex) String() string {
 	return fmt.Sprintf(
-		"cacheOrderIndex { AccessTimestamp: %d Address: %s }",
+		"cacheOrderIndex { AccessTimestamp: %d Address: %s Segment: %d }",
 		c.AccessTimestamp,
 		c.Address.ByteString(),
+		c.Segment,
 	)
 }
@@ -490,6 +490,188 @@ func TestShallowCopyAlreadyCached(t *testing.T) {
 	verifyChunksDeleted(t, st.ChunkStore(), chunks...)
 }
 
+func TestEvictionPolicy(t *testing.T) {
+	t.Parallel()
+
+	newCache := func(t *testing.T, name string) (*inmemStorage, *cache.Cache, []swarm.Chunk) {
+		t.Helper()
+
+		policy, err := cache.NewPolicy(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st := newTestStorage(t)
+		c, err := cache.New(context.Background(), st.IndexStore(), 10, cache.WithPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		chunks := chunktest.GenerateTestRandomChunks(10)
+		for _, ch := range chunks {
+			if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return st, c, chunks
+	}
+
+	get := func(t *testing.T, st *inmemStorage, c *cache.Cache, chs ...swarm.Chunk) {
+		t.Helper()
+
+		for _, ch := range chs {
+			if _, err := c.Getter(st).Get(context.Background(), ch.Address()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	t.Run("lru evicts least recently used", func(t *testing.T) {
+		t.Parallel()
+
+		st, c, chunks := newCache(t, cache.LRUPolicy)
+		get(t, st, c, chunks[:5]...)
+
+		if err := c.RemoveOldest(context.Background(), st, 5); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[5:]...)
+		verifyChunksExist(t, st.ChunkStore(), chunks[:5]...)
+	})
+
+	t.Run("clock spares referenced", func(t *testing.T) {
+		t.Parallel()
+
+		st, c, chunks := newCache(t, cache.CLOCKPolicy)
+		get(t, st, c, chunks[0], chunks[2], chunks[4])
+
+		// accessing does not reorder the entries
+		verifyCacheOrder(t, c, st.IndexStore(), chunks...)
+
+		if err := c.RemoveOldest(context.Background(), st, 4); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[1], chunks[3], chunks[5], chunks[6])
+		verifyChunksExist(t, st.ChunkStore(), chunks[7:]...)
+		verifyCacheOrder(t, c, st.IndexStore(), append(chunks[7:], chunks[0], chunks[2], chunks[4])...)
+
+		// the second chance is taken
+		if err := c.RemoveOldest(context.Background(), st, 6); err != nil {
+			t.Fatal(err)
+		}
+		verifyCacheState(t, st.IndexStore(), c, swarm.ZeroAddress, swarm.ZeroAddress, 0)
+	})
+
+	t.Run("clock evicts all referenced", func(t *testing.T) {
+		t.Parallel()
+
+		st, c, chunks := newCache(t, cache.CLOCKPolicy)
+		get(t, st, c, chunks...)
+
+		if err := c.RemoveOldest(context.Background(), st, 3); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[:3]...)
+		verifyChunksExist(t, st.ChunkStore(), chunks[3:]...)
+	})
+
+	t.Run("slru protects hit entries", func(t *testing.T) {
+		t.Parallel()
+
+		st, c, chunks := newCache(t, cache.SLRUPolicy)
+		get(t, st, c, chunks[:3]...)
+
+		if err := c.RemoveOldest(context.Background(), st, 7); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[3:]...)
+		verifyChunksExist(t, st.ChunkStore(), chunks[:3]...)
+
+		// protected entries are evicted once the probationary ones are gone
+		if err := c.RemoveOldest(context.Background(), st, 1); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[0])
+		if c.Size() != 2 {
+			t.Fatalf("got size %d, want 2", c.Size())
+		}
+	})
+
+	t.Run("slru demotes over limit", func(t *testing.T) {
+		t.Parallel()
+
+		st, c, chunks := newCache(t, cache.SLRUPolicy)
+		get(t, st, c, chunks[1:]...)
+
+		// the protected segment holds 9 entries over its limit of 8, the
+		// least recently used one of them is demoted behind the probationary entry
+		if err := c.RemoveOldest(context.Background(), st, 1); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[0])
+
+		if err := c.RemoveOldest(context.Background(), st, 1); err != nil {
+			t.Fatal(err)
+		}
+		verifyChunksDeleted(t, st.ChunkStore(), chunks[1])
+		verifyChunksExist(t, st.ChunkStore(), chunks[2:]...)
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := cache.NewPolicy("fifo"); !errors.Is(err, cache.ErrUnknownPolicy) {
+			t.Fatalf("got error %v, want %v", err, cache.ErrUnknownPolicy)
+		}
+	})
+}
+
+func TestCapacityBytes(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+	c, err := cache.New(context.Background(), st.IndexStore(), 100, cache.WithCapacityBytes(5*swarm.ChunkWithSpanSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(10)
+	for _, ch := range chunks {
+		if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if size := c.SizeBytes(); size != 10*swarm.ChunkWithSpanSize {
+		t.Fatalf("got size %d bytes, want %d", size, 10*swarm.ChunkWithSpanSize)
+	}
+	count, bytes := c.Excess()
+	if count != 0 || bytes != 5*swarm.ChunkWithSpanSize {
+		t.Fatalf("got excess of %d entries and %d bytes, want 0 and %d", count, bytes, 5*swarm.ChunkWithSpanSize)
+	}
+
+	evicted, err := c.Evict(context.Background(), st, count, bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != 5 {
+		t.Fatalf("got %d evicted entries, want 5", evicted)
+	}
+	verifyChunksDeleted(t, st.ChunkStore(), chunks[:5]...)
+	verifyCacheState(t, st.IndexStore(), c, chunks[5].Address(), chunks[9].Address(), 5)
+	if size := c.SizeBytes(); size != 5*swarm.ChunkWithSpanSize {
+		t.Fatalf("got size %d bytes, want %d", size, 5*swarm.ChunkWithSpanSize)
+	}
+
+	// the size is restored from the store
+	c, err = cache.New(context.Background(), st.IndexStore(), 100, cache.WithCapacityBytes(5*swarm.ChunkWithSpanSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := c.SizeBytes(); size != 5*swarm.ChunkWithSpanSize {
+		t.Fatalf("got size %d bytes, want %d", size, 5*swarm.ChunkWithSpanSize)
+	}
+}
+
 func verifyCacheState(
 	t *testing.T,
 	store storage.Reader,
@@ -0,0 +1,281 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+)
+
+// Names of the eviction policies.
+const (
+	LRUPolicy   = "lru"
+	CLOCKPolicy = "clock"
+	SLRUPolicy  = "slru"
+)
+
+// ErrUnknownPolicy is returned for an eviction policy name that is not known.
+var ErrUnknownPolicy = errors.New("unknown cache eviction policy")
+
+// Policy decides the order in which the cache entries are evicted. The
+// order is kept in the index store next to the entries so that it survives
+// restarts. The methods taking a storage.IndexStore are called inside the
+// transaction of the cache operation and may change the entry, which is
+// stored by the cache afterwards.
+type Policy interface {
+	// Init prepares the policy for a cache of the given capacity.
+	Init(store storage.Reader, capacity int) error
+	// Insert places a new entry in the eviction order.
+	Insert(s storage.IndexStore, entry *CacheEntryItem) error
+	// Access updates the eviction order on a cache hit of the entry.
+	Access(s storage.IndexStore, entry *CacheEntryItem) error
+	// Remove takes the entry out of the eviction order.
+	Remove(s storage.IndexStore, entry *CacheEntryItem) error
+	// Requeue gives the entry spared by Candidates another round.
+	Requeue(s storage.IndexStore, entry *CacheEntryItem) error
+	// Candidates iterates the entries in the order of eviction. The entries
+	// passed with spare set are requeued by the cache instead of evicted.
+	Candidates(store storage.Reader, fn func(entry *CacheEntryItem, spare bool) (bool, error)) error
+}
+
+// NewPolicy returns the eviction policy of the given name, LRU if empty.
+func NewPolicy(name string) (Policy, error) {
+	switch name {
+	case "", LRUPolicy:
+		return new(lru), nil
+	case CLOCKPolicy:
+		return new(clock), nil
+	case SLRUPolicy:
+		return new(slru), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, name)
+}
+
+// Segments of the eviction order.
+const (
+	segmentDefault uint8 = iota
+	segmentProtected
+)
+
+// Flags of the cache entries.
+const (
+	flagReferenced uint8 = 1 << iota // accessed since last passed by the clock hand
+	flagProtected                    // in the protected segment of the SLRU
+)
+
+// segment returns the segment of the eviction order the entry belongs to.
+func (c *cacheEntry) segment() uint8 {
+	if c.Flags&flagProtected != 0 {
+		return segmentProtected
+	}
+	return segmentDefault
+}
+
+// orderIndex returns the position of the entry in the eviction order.
+func (c *cacheEntry) orderIndex() *cacheOrderIndex {
+	return &cacheOrderIndex{
+		AccessTimestamp: c.AccessTimestamp,
+		Address:         c.Address,
+		Segment:         c.segment(),
+	}
+}
+
+// moveToTail moves the entry to the most recently used end of the segment
+// its flags put it in, given it was in segment from before.
+func moveToTail(s storage.IndexStore, entry *cacheEntry, from uint8) error {
+	old := entry.orderIndex()
+	old.Segment = from
+	if err := s.Delete(old); err != nil {
+		return fmt.Errorf("failed deleting cache order index: %w", err)
+	}
+	entry.AccessTimestamp = now().UnixNano()
+	if err := s.Put(entry.orderIndex()); err != nil {
+		return fmt.Errorf("failed adding cache order index: %w", err)
+	}
+	return nil
+}
+
+// iterateOrder iterates the entries of a segment of the eviction order from
+// the least recently used one.
+func iterateOrder(store storage.Reader, segment uint8, fn func(entry *cacheEntry) (bool, error)) error {
+	return store.Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return &cacheOrderIndex{Segment: segment} },
+			ItemProperty: storage.QueryItemID,
+		},
+		func(res storage.Result) (bool, error) {
+			_, addr, err := idFromKey(res.ID)
+			if err != nil {
+				return false, fmt.Errorf("failed to parse cache order index %s: %w", res.ID, err)
+			}
+			entry := &cacheEntry{Address: addr}
+			switch err := store.Get(entry); {
+			case errors.Is(err, storage.ErrNotFound):
+				return false, nil // removed since the iteration started
+			case err != nil:
+				return false, fmt.Errorf("failed getting cache entry %s: %w", addr, err)
+			}
+			return fn(entry)
+		},
+	)
+}
+
+// iterateSegments iterates the entries of all the segments of the eviction
+// order, the protected segment being left over by the SLRU policy.
+func iterateSegments(store storage.Reader, fn func(entry *cacheEntry) (bool, error)) error {
+	stop := false
+	err := iterateOrder(store, segmentDefault, func(entry *cacheEntry) (bool, error) {
+		var err error
+		stop, err = fn(entry)
+		return stop, err
+	})
+	if err != nil || stop {
+		return err
+	}
+	return iterateOrder(store, segmentProtected, fn)
+}
+
+// lru evicts the least recently used entries first.
+type lru struct{}
+
+func (lru) Init(storage.Reader, int) error { return nil }
+
+func (lru) Insert(s storage.IndexStore, entry *CacheEntryItem) error {
+	entry.AccessTimestamp = now().UnixNano()
+	return s.Put(entry.orderIndex())
+}
+
+func (lru) Access(s storage.IndexStore, entry *CacheEntryItem) error {
+	return moveToTail(s, entry, entry.segment())
+}
+
+func (lru) Remove(s storage.IndexStore, entry *CacheEntryItem) error {
+	return s.Delete(entry.orderIndex())
+}
+
+func (p lru) Requeue(s storage.IndexStore, entry *CacheEntryItem) error {
+	return p.Access(s, entry)
+}
+
+func (lru) Candidates(store storage.Reader, fn func(*CacheEntryItem, bool) (bool, error)) error {
+	return iterateSegments(store, func(entry *cacheEntry) (bool, error) {
+		return fn(entry, false)
+	})
+}
+
+// clock approximates LRU by only flagging the entries on access and giving
+// the flagged entries a second chance when the clock hand passes them. An
+// access thus costs a single write instead of moving the entry in the order.
+type clock struct{ lru }
+
+func (clock) Access(_ storage.IndexStore, entry *CacheEntryItem) error {
+	entry.Flags |= flagReferenced
+	return nil
+}
+
+func (clock) Requeue(s storage.IndexStore, entry *CacheEntryItem) error {
+	entry.Flags &^= flagReferenced
+	return moveToTail(s, entry, entry.segment())
+}
+
+func (clock) Candidates(store storage.Reader, fn func(*CacheEntryItem, bool) (bool, error)) error {
+	return iterateSegments(store, func(entry *cacheEntry) (bool, error) {
+		return fn(entry, entry.Flags&flagReferenced != 0)
+	})
+}
+
+// slruProtectedRatio is the share of the cache capacity the protected
+// segment of the SLRU may hold.
+const slruProtectedRatio = 0.8
+
+// slru is a segmented LRU. New entries go to the probationary segment and
+// are promoted to the protected segment on their first hit, which keeps
+// chunks retrieved only once from flushing the frequently used ones. The
+// least recently used entries of a protected segment grown over its share
+// are demoted back to the probationary segment.
+type slru struct {
+	lru
+	limit     int64
+	protected atomic.Int64
+}
+
+func (p *slru) Init(store storage.Reader, capacity int) error {
+	count, err := store.Count(&cacheOrderIndex{Segment: segmentProtected})
+	if err != nil {
+		return fmt.Errorf("failed counting protected cache entries: %w", err)
+	}
+	p.limit = int64(float64(capacity) * slruProtectedRatio)
+	p.protected.Store(int64(count))
+	return nil
+}
+
+func (p *slru) Access(s storage.IndexStore, entry *CacheEntryItem) error {
+	from := entry.segment()
+	entry.Flags |= flagProtected
+	if err := moveToTail(s, entry, from); err != nil {
+		return err
+	}
+	if from != segmentProtected {
+		p.protected.Add(1)
+	}
+	return nil
+}
+
+func (p *slru) Remove(s storage.IndexStore, entry *CacheEntryItem) error {
+	if err := s.Delete(entry.orderIndex()); err != nil {
+		return err
+	}
+	if entry.segment() == segmentProtected {
+		p.protected.Add(-1)
+	}
+	return nil
+}
+
+func (p *slru) Requeue(s storage.IndexStore, entry *CacheEntryItem) error {
+	entry.Flags &^= flagProtected
+	if err := moveToTail(s, entry, segmentProtected); err != nil {
+		return err
+	}
+	p.protected.Add(-1)
+	return nil
+}
+
+func (p *slru) Candidates(store storage.Reader, fn func(*CacheEntryItem, bool) (bool, error)) error {
+	stop := false
+	demote := p.protected.Load() - p.limit
+	err := iterateOrder(store, segmentProtected, func(entry *cacheEntry) (bool, error) {
+		if demote <= 0 {
+			return true, nil
+		}
+		demote--
+		var err error
+		stop, err = fn(entry, true)
+		return stop, err
+	})
+	if err != nil || stop {
+		return err
+	}
+
+	err = iterateOrder(store, segmentDefault, func(entry *cacheEntry) (bool, error) {
+		var err error
+		stop, err = fn(entry, false)
+		return stop, err
+	})
+	if err != nil || stop {
+		return err
+	}
+
+	skip := p.protected.Load() - p.limit
+	return iterateOrder(store, segmentProtected, func(entry *cacheEntry) (bool, error) {
+		if skip > 0 {
+			skip--
+			return false, nil
+		}
+		return fn(entry, false)
+	})
+}
@@ -221,7 +221,7 @@ func IterateLocations(
 }
 
 // Iterate iterates over entire retrieval index with a call back.
-func IterateItems(st storage.Store, callBackFunc func(*RetrievalIndexItem) error) error {
+func IterateItems(st storage.Reader, callBackFunc func(*RetrievalIndexItem) error) error {
 	return st.Iterate(storage.Query{
 		Factory: func() storage.Item { return new(RetrievalIndexItem) },
 	}, func(r storage.Result) (bool, error) {
@@ -230,6 +230,17 @@ func IterateItems(st storage.Store, callBackFunc func(*RetrievalIndexItem) error
 	})
 }
 
+// IterateItemsFrom iterates over the retrieval index starting after the cursor
+// and returns the cursor of the last item passed to the call back, so that an
+// interrupted iteration can be resumed.
+func IterateItemsFrom(st storage.Reader, cursor storage.Cursor, fn func(*RetrievalIndexItem, storage.Cursor) (bool, error)) (storage.Cursor, error) {
+	return storage.IterateFrom(st, storage.Query{
+		Factory: func() storage.Item { return new(RetrievalIndexItem) },
+	}, cursor, func(r storage.Result, c storage.Cursor) (bool, error) {
+		return fn(r.Entry.(*RetrievalIndexItem), c)
+	})
+}
+
 // RetrievalIndexItem is the index which gives us the sharky location from the swarm.Address.
 // The RefCnt stores the reference of each time a Put operation is issued on this Address.
 type RetrievalIndexItem struct {
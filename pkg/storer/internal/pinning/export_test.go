@@ -4,13 +4,6 @@
 
 package pinstore
 
-import (
-	"fmt"
-
-	storage "github.com/calmw/bee-tron/pkg/storage"
-	"github.com/calmw/bee-tron/pkg/swarm"
-)
-
 type (
 	PinCollectionItem = pinCollectionItem
 	PinChunkItem      = pinChunkItem
@@ -26,13 +19,3 @@ var (
 )
 
 var NewUUID = newUUID
-
-func GetStat(st storage.Reader, root swarm.Address) (CollectionStat, error) {
-	collection := &pinCollectionItem{Addr: root}
-	err := st.Get(collection)
-	if err != nil {
-		return CollectionStat{}, fmt.Errorf("pin store: failed getting collection: %w", err)
-	}
-
-	return collection.Stat, nil
-}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/calmw/bee-tron/pkg/encryption"
 	storage "github.com/calmw/bee-tron/pkg/storage"
@@ -45,6 +46,9 @@ var (
 	errCollectionRootAddressIsZero = errors.New("pin store: collection root address is zero")
 	// ErrDuplicatePinCollection is returned when attempted to pin the same file repeatedly
 	ErrDuplicatePinCollection = errors.New("pin store: duplicate pin collection")
+	// ErrQuotaExceeded is returned when pinning a chunk would grow the size of
+	// the pinned chunks over the pinning quota
+	ErrQuotaExceeded = errors.New("pin store: pinning quota exceeded")
 )
 
 // creates a new UUID and returns it as a byte slice
@@ -60,14 +64,72 @@ var emptyKey = make([]byte, 32)
 type CollectionStat struct {
 	Total           uint64
 	DupInCollection uint64
+	Bytes           uint64 // size of the unique chunks, zero for collections pinned before it was recorded
+}
+
+// Quota keeps track of the size of the pinned chunks and limits it if set.
+// Chunks shared between collections are accounted for in each of them.
+type Quota struct {
+	limit uint64
+	used  atomic.Uint64
+}
+
+// NewQuota returns a Quota of limit bytes, unlimited if zero, accounting for
+// the collections already in the store.
+func NewQuota(st storage.Reader, limit uint64) (*Quota, error) {
+	q := &Quota{limit: limit}
+	err := IterateCollectionStats(st, func(stat CollectionStat) (bool, error) {
+		q.used.Add(stat.Bytes)
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pin store: failed iterating collection stats: %w", err)
+	}
+	return q, nil
+}
+
+// Limit returns the quota in bytes, zero if unlimited.
+func (q *Quota) Limit() uint64 {
+	return q.limit
+}
+
+// Used returns the size of the pinned chunks including the chunks of the
+// collections being pinned.
+func (q *Quota) Used() uint64 {
+	return q.used.Load()
+}
+
+// reserve accounts for n more bytes unless that exceeds the limit.
+func (q *Quota) reserve(n uint64) error {
+	if q == nil {
+		return nil
+	}
+	for {
+		used := q.used.Load()
+		if q.limit > 0 && used+n > q.limit {
+			return ErrQuotaExceeded
+		}
+		if q.used.CompareAndSwap(used, used+n) {
+			return nil
+		}
+	}
+}
+
+// release gives back n bytes reserved before.
+func (q *Quota) release(n uint64) {
+	if q == nil {
+		return
+	}
+	q.used.Add(-n)
 }
 
 // NewCollection returns a putter wrapped around the passed storage.
 // The putter will add the chunk to Chunk store if it doesn't exists within this collection.
 // It will create a new UUID for the collection which can be used to iterate on all the chunks
 // that are part of this collection. The root pin is only updated on successful close of this.
+// The size of the chunks is accounted for in the quota, if not nil.
 // Calls to the Putter MUST be mutex locked to prevent concurrent upload data races.
-func NewCollection(st storage.IndexStore) (internal.PutterCloserWithReference, error) {
+func NewCollection(st storage.IndexStore, quota *Quota) (internal.PutterCloserWithReference, error) {
 	newCollectionUUID := newUUID()
 	err := st.Put(&dirtyCollection{UUID: newCollectionUUID})
 	if err != nil {
@@ -75,11 +137,13 @@ func NewCollection(st storage.IndexStore) (internal.PutterCloserWithReference, e
 	}
 	return &collectionPutter{
 		collection: &pinCollectionItem{UUID: newCollectionUUID},
+		quota:      quota,
 	}, nil
 }
 
 type collectionPutter struct {
 	collection *pinCollectionItem
+	quota      *Quota
 	closed     bool
 }
 
@@ -108,16 +172,26 @@ func (c *collectionPutter) Put(ctx context.Context, st transaction.Store, ch swa
 		return nil
 	}
 
+	size := uint64(len(ch.Data()))
+	if err := c.quota.reserve(size); err != nil {
+		c.collection.Stat.Total--
+		return err
+	}
+
 	err = st.IndexStore().Put(collectionChunk)
 	if err != nil {
+		c.quota.release(size)
 		return fmt.Errorf("pin store: failed putting collection chunk: %w", err)
 	}
 
 	err = st.ChunkStore().Put(ctx, ch)
 	if err != nil {
+		c.quota.release(size)
 		return fmt.Errorf("pin store: failed putting chunk: %w", err)
 	}
 
+	c.collection.Stat.Bytes += size
+
 	return nil
 }
 
@@ -160,6 +234,8 @@ func (c *collectionPutter) Cleanup(st transaction.Storage) error {
 	if err := deleteCollectionChunks(context.Background(), st, c.collection.UUID); err != nil {
 		return fmt.Errorf("pin store: failed deleting collection chunks: %w", err)
 	}
+	c.quota.release(c.collection.Stat.Bytes)
+	c.collection.Stat.Bytes = 0
 
 	err := st.Run(context.Background(), func(s transaction.Store) error {
 		return s.IndexStore().Delete(&dirtyCollection{UUID: c.collection.UUID})
@@ -267,7 +343,8 @@ func deleteCollectionChunks(ctx context.Context, st transaction.Storage, collect
 }
 
 // DeletePin will delete the root pin and all the chunks that are part of this collection.
-func DeletePin(ctx context.Context, st transaction.Storage, root swarm.Address) error {
+// The size of the chunks is given back to the quota, if not nil.
+func DeletePin(ctx context.Context, st transaction.Storage, quota *Quota, root swarm.Address) error {
 	collection := &pinCollectionItem{Addr: root}
 
 	err := st.IndexStore().Get(collection)
@@ -279,13 +356,19 @@ func DeletePin(ctx context.Context, st transaction.Storage, root swarm.Address)
 		return err
 	}
 
-	return st.Run(ctx, func(s transaction.Store) error {
+	err = st.Run(ctx, func(s transaction.Store) error {
 		err := s.IndexStore().Delete(collection)
 		if err != nil {
 			return fmt.Errorf("pin store: failed deleting root collection: %w", err)
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	quota.release(collection.Stat.Bytes)
+	return nil
 }
 
 func IterateCollection(st storage.Reader, root swarm.Address, fn func(addr swarm.Address) (bool, error)) error {
@@ -308,6 +391,16 @@ func IterateCollection(st storage.Reader, root swarm.Address, fn func(addr swarm
 	})
 }
 
+// GetStat returns the stats of the pinning collection with the root reference.
+func GetStat(st storage.Reader, root swarm.Address) (CollectionStat, error) {
+	collection := &pinCollectionItem{Addr: root}
+	if err := st.Get(collection); err != nil {
+		return CollectionStat{}, fmt.Errorf("pin store: failed getting collection: %w", err)
+	}
+	return collection.Stat, nil
+}
+
+// IterateCollectionStats iterates the stats of all the pinning collections.
 func IterateCollectionStats(st storage.Reader, iterateFn func(st CollectionStat) (bool, error)) error {
 	return st.Iterate(
 		storage.Query{
@@ -319,8 +412,30 @@ func IterateCollectionStats(st storage.Reader, iterateFn func(st CollectionStat)
 	)
 }
 
+// IterateCollections iterates the root references of all the pinning
+// collections together with their stats.
+func IterateCollections(st storage.Reader, iterateFn func(root swarm.Address, st CollectionStat) (bool, error)) error {
+	return st.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(pinCollectionItem) },
+		},
+		func(r storage.Result) (bool, error) {
+			item := r.Entry.(*pinCollectionItem)
+			return iterateFn(item.Addr, item.Stat)
+		},
+	)
+}
+
 // pinCollectionSize represents the size of the pinCollectionItem
-const pinCollectionItemSize = encryption.ReferenceSize + uuidSize + 8 + 8
+const pinCollectionItemSize = encryption.ReferenceSize + uuidSize + 8 + 8 + 8
+
+// legacyPinCollectionItemSize represents the size of the pinCollectionItem
+// stored before the size of the collection was recorded
+const legacyPinCollectionItemSize = pinCollectionItemSize - 8
+
+// schemePinCollectionItemSize represents the size of the pinCollectionItem
+// of a collection encrypted in an authenticated scheme
+const schemePinCollectionItemSize = encryption.SchemeReferenceSize + uuidSize + 8 + 8 + 8
 
 var _ storage.Item = (*pinCollectionItem)(nil)
 
@@ -345,31 +460,44 @@ func (p *pinCollectionItem) Marshal() ([]byte, error) {
 	if len(p.UUID) == 0 {
 		return nil, errInvalidPinCollectionUUID
 	}
-	buf := make([]byte, pinCollectionItemSize)
-	copy(buf[:encryption.ReferenceSize], p.Addr.Bytes())
-	off := encryption.ReferenceSize
+	refSize, size := encryption.ReferenceSize, pinCollectionItemSize
+	if len(p.Addr.Bytes()) == encryption.SchemeReferenceSize {
+		refSize, size = encryption.SchemeReferenceSize, schemePinCollectionItemSize
+	}
+	buf := make([]byte, size)
+	copy(buf[:refSize], p.Addr.Bytes())
+	off := refSize
 	copy(buf[off:off+uuidSize], p.UUID)
-	statBufOff := encryption.ReferenceSize + uuidSize
+	statBufOff := refSize + uuidSize
 	binary.LittleEndian.PutUint64(buf[statBufOff:], p.Stat.Total)
 	binary.LittleEndian.PutUint64(buf[statBufOff+8:], p.Stat.DupInCollection)
+	binary.LittleEndian.PutUint64(buf[statBufOff+16:], p.Stat.Bytes)
 	return buf, nil
 }
 
 func (p *pinCollectionItem) Unmarshal(buf []byte) error {
-	if len(buf) != pinCollectionItemSize {
+	refSize := encryption.ReferenceSize
+	switch len(buf) {
+	case pinCollectionItemSize, legacyPinCollectionItemSize:
+	case schemePinCollectionItemSize:
+		refSize = encryption.SchemeReferenceSize
+	default:
 		return errInvalidPinCollectionSize
 	}
 	ni := new(pinCollectionItem)
 	if bytes.Equal(buf[swarm.HashSize:encryption.ReferenceSize], emptyKey) {
 		ni.Addr = swarm.NewAddress(buf[:swarm.HashSize]).Clone()
 	} else {
-		ni.Addr = swarm.NewAddress(buf[:encryption.ReferenceSize]).Clone()
+		ni.Addr = swarm.NewAddress(buf[:refSize]).Clone()
 	}
-	off := encryption.ReferenceSize
+	off := refSize
 	ni.UUID = append(make([]byte, 0, uuidSize), buf[off:off+uuidSize]...)
 	statBuf := buf[off+uuidSize:]
 	ni.Stat.Total = binary.LittleEndian.Uint64(statBuf[:8])
 	ni.Stat.DupInCollection = binary.LittleEndian.Uint64(statBuf[8:16])
+	if len(statBuf) == 24 {
+		ni.Stat.Bytes = binary.LittleEndian.Uint64(statBuf[16:24])
+	}
 	*p = *ni
 	return nil
 }
@@ -5,12 +5,14 @@
 package pinstore_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"testing"
 
+	"github.com/calmw/bee-tron/pkg/encryption"
 	storage "github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
 
@@ -71,7 +73,7 @@ func TestPinStore(t *testing.T) {
 				var putter internal.PutterCloserWithReference
 				var err error
 				err = st.Run(context.Background(), func(s transaction.Store) error {
-					putter, err = pinstore.NewCollection(s.IndexStore())
+					putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 					return err
 				})
 				if err != nil {
@@ -218,7 +220,7 @@ func TestPinStore(t *testing.T) {
 	})
 
 	t.Run("delete collection", func(t *testing.T) {
-		err := pinstore.DeletePin(context.TODO(), st, tests[0].root.Address())
+		err := pinstore.DeletePin(context.TODO(), st, nil, tests[0].root.Address())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -264,7 +266,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 			return err
 		})
 		if err != nil {
@@ -301,7 +303,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 			return err
 		})
 		if err != nil {
@@ -338,7 +340,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 			return err
 		})
 		if err != nil {
@@ -389,7 +391,7 @@ func TestCleanup(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 			return err
 		})
 		if err != nil {
@@ -432,7 +434,7 @@ func TestCleanup(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), nil)
 			return err
 		})
 		if err != nil {
@@ -514,6 +516,20 @@ func TestPinCollectionItem(t *testing.T) {
 				Stat: pinstore.CollectionStat{
 					Total:           math.MaxUint64,
 					DupInCollection: math.MaxUint64,
+					Bytes:           math.MaxUint64,
+				},
+			},
+			Factory: func() storage.Item { return new(pinstore.PinCollectionItem) },
+		},
+	}, {
+		name: "authenticated scheme reference",
+		test: &storagetest.ItemMarshalAndUnmarshalTest{
+			Item: &pinstore.PinCollectionItem{
+				Addr: swarm.NewAddress(bytes.Repeat([]byte{0xFF}, encryption.SchemeReferenceSize)),
+				UUID: pinstore.NewUUID(),
+				Stat: pinstore.CollectionStat{
+					Total: 1,
+					Bytes: 4096,
 				},
 			},
 			Factory: func() storage.Item { return new(pinstore.PinCollectionItem) },
@@ -568,3 +584,84 @@ func TestDirtyCollectionsItem(t *testing.T) {
 		},
 	})
 }
+
+func TestPinQuota(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+
+	quota, err := pinstore.NewQuota(st.IndexStore(), 3*swarm.ChunkWithSpanSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pin := func(t *testing.T, chunks ...swarm.Chunk) error {
+		t.Helper()
+
+		var putter internal.PutterCloserWithReference
+		err := st.Run(context.Background(), func(s transaction.Store) error {
+			putter, err = pinstore.NewCollection(s.IndexStore(), quota)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ch := range chunks {
+			err := st.Run(context.Background(), func(s transaction.Store) error {
+				return putter.Put(context.Background(), s, ch)
+			})
+			if err != nil {
+				return errors.Join(err, putter.Cleanup(st))
+			}
+		}
+
+		return st.Run(context.Background(), func(s transaction.Store) error {
+			return putter.Close(s.IndexStore(), chunks[0].Address())
+		})
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(2)
+	if err := pin(t, append(chunks, chunks[1])...); err != nil {
+		t.Fatal(err)
+	}
+	if used := quota.Used(); used != 2*swarm.ChunkWithSpanSize {
+		t.Fatalf("got %d used bytes, want %d", used, 2*swarm.ChunkWithSpanSize)
+	}
+
+	stat, err := pinstore.GetStat(st.IndexStore(), chunks[0].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.Bytes != 2*swarm.ChunkWithSpanSize {
+		t.Fatalf("got %d collection bytes, want %d", stat.Bytes, 2*swarm.ChunkWithSpanSize)
+	}
+
+	overQuota := chunktest.GenerateTestRandomChunks(2)
+	if err := pin(t, overQuota...); !errors.Is(err, pinstore.ErrQuotaExceeded) {
+		t.Fatalf("got error %v, want %v", err, pinstore.ErrQuotaExceeded)
+	}
+	if used := quota.Used(); used != 2*swarm.ChunkWithSpanSize {
+		t.Fatalf("got %d used bytes after cleanup, want %d", used, 2*swarm.ChunkWithSpanSize)
+	}
+
+	// the usage is restored from the store
+	restored, err := pinstore.NewQuota(st.IndexStore(), 3*swarm.ChunkWithSpanSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used := restored.Used(); used != 2*swarm.ChunkWithSpanSize {
+		t.Fatalf("got %d restored used bytes, want %d", used, 2*swarm.ChunkWithSpanSize)
+	}
+
+	if err := pinstore.DeletePin(context.Background(), st, quota, chunks[0].Address()); err != nil {
+		t.Fatal(err)
+	}
+	if used := quota.Used(); used != 0 {
+		t.Fatalf("got %d used bytes after delete, want 0", used)
+	}
+
+	if err := pin(t, overQuota...); err != nil {
+		t.Fatal(err)
+	}
+}
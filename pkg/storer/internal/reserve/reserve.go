@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sort"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -117,8 +118,6 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 		return nil
 	}
 
-	chunkType := storage.ChunkType(chunk)
-
 	bin := swarm.Proximity(r.baseAddr.Bytes(), chunk.Address().Bytes())
 
 	// bin lock
@@ -126,176 +125,271 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 	defer r.multx.Unlock(strconv.Itoa(int(bin)))
 
 	var shouldIncReserveSize bool
-
 	err = r.st.Run(ctx, func(s transaction.Store) error {
+		shouldIncReserveSize, err = r.put(ctx, s, chunk, stampHash, bin)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if shouldIncReserveSize {
+		r.size.Add(1)
+	}
+	return nil
+}
+
+// PutBatch stores the chunks in one transaction following the rules of Put.
+// The chunks rejected with storage.ErrOverwriteNewerChunk are skipped and
+// reported in the returned error, any other error rolls back the whole batch.
+// It returns the number of chunks stored.
+func (r *Reserve) PutBatch(ctx context.Context, chunks []swarm.Chunk) (int, error) {
+	type batchItem struct {
+		chunk     swarm.Chunk
+		stampHash []byte
+		bin       uint8
+	}
 
-		oldStampIndex, loadedStampIndex, err := stampindex.LoadOrStore(s.IndexStore(), reserveScope, chunk)
+	items := make([]batchItem, 0, len(chunks))
+	batches := make(map[string]struct{})
+	bins := make(map[uint8]struct{})
+	for _, ch := range chunks {
+		stampHash, err := ch.Stamp().Hash()
 		if err != nil {
-			return fmt.Errorf("load or store stamp index for chunk %v has fail: %w", chunk, err)
+			return 0, err
 		}
+		bin := swarm.Proximity(r.baseAddr.Bytes(), ch.Address().Bytes())
+		items = append(items, batchItem{chunk: ch, stampHash: stampHash, bin: bin})
+		batches[string(ch.Stamp().BatchID())] = struct{}{}
+		bins[bin] = struct{}{}
+	}
 
-		// index collision
-		if loadedStampIndex {
+	// lock the batches before the bins, as Put does, and both in order so
+	// that concurrent puts cannot deadlock
+	batchIDs := make([]string, 0, len(batches))
+	for id := range batches {
+		batchIDs = append(batchIDs, id)
+	}
+	sort.Strings(batchIDs)
+	for _, id := range batchIDs {
+		r.multx.Lock(id)
+		defer r.multx.Unlock(id)
+	}
+	binIDs := make([]int, 0, len(bins))
+	for bin := range bins {
+		binIDs = append(binIDs, int(bin))
+	}
+	sort.Ints(binIDs)
+	for _, bin := range binIDs {
+		r.multx.Lock(strconv.Itoa(bin))
+		defer r.multx.Unlock(strconv.Itoa(bin))
+	}
 
-			prev := binary.BigEndian.Uint64(oldStampIndex.StampTimestamp)
-			curr := binary.BigEndian.Uint64(chunk.Stamp().Timestamp())
-			if prev >= curr {
-				return fmt.Errorf("overwrite same chunk. prev %d cur %d batch %s: %w", prev, curr, hex.EncodeToString(chunk.Stamp().BatchID()), storage.ErrOverwriteNewerChunk)
+	var (
+		stored, newChunks int
+		skipped           error
+	)
+	err := r.st.Run(ctx, func(s transaction.Store) error {
+		for _, item := range items {
+			// check if the chunk with the same batch, stamp timestamp and
+			// index is already stored, also by this batch
+			has, err := s.IndexStore().Has(&BatchRadiusItem{
+				Bin:       item.bin,
+				BatchID:   item.chunk.Stamp().BatchID(),
+				Address:   item.chunk.Address(),
+				StampHash: item.stampHash,
+			})
+			if err != nil {
+				return err
+			}
+			if has {
+				stored++
+				continue
 			}
 
-			r.logger.Debug(
-				"replacing chunk stamp index",
-				"old_chunk", oldStampIndex.ChunkAddress,
-				"new_chunk", chunk.Address(),
-				"batch_id", hex.EncodeToString(chunk.Stamp().BatchID()),
-			)
+			isNew, err := r.put(ctx, s, item.chunk, item.stampHash, item.bin)
+			if errors.Is(err, storage.ErrOverwriteNewerChunk) {
+				// rejected before any write of the chunk
+				skipped = errors.Join(skipped, err)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			stored++
+			if isNew {
+				newChunks++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.size.Add(int64(newChunks))
+	return stored, skipped
+}
 
-			// same chunk address
-			if oldStampIndex.ChunkAddress.Equal(chunk.Address()) {
+// put stores the chunk and its reserve index items in the transaction. It
+// reports whether the chunk is new to the reserve, as opposed to replacing
+// the chunk stored with the same stamp index.
+func (r *Reserve) put(ctx context.Context, s transaction.Store, chunk swarm.Chunk, stampHash []byte, bin uint8) (bool, error) {
+	chunkType := storage.ChunkType(chunk)
 
-				oldStamp, err := chunkstamp.LoadWithStampHash(s.IndexStore(), reserveScope, oldStampIndex.ChunkAddress, oldStampIndex.StampHash)
-				if err != nil {
-					return err
-				}
+	oldStampIndex, loadedStampIndex, err := stampindex.LoadOrStore(s.IndexStore(), reserveScope, chunk)
+	if err != nil {
+		return false, fmt.Errorf("load or store stamp index for chunk %v has fail: %w", chunk, err)
+	}
 
-				oldBatchRadiusItem := &BatchRadiusItem{
-					Bin:       bin,
-					Address:   oldStampIndex.ChunkAddress,
-					BatchID:   oldStampIndex.BatchID,
-					StampHash: oldStampIndex.StampHash,
-				}
-				// load item to get the binID
-				err = s.IndexStore().Get(oldBatchRadiusItem)
-				if err != nil {
-					return err
-				}
+	// index collision
+	if loadedStampIndex {
 
-				// delete old chunk index items
-				err = errors.Join(
-					s.IndexStore().Delete(oldBatchRadiusItem),
-					s.IndexStore().Delete(&ChunkBinItem{Bin: oldBatchRadiusItem.Bin, BinID: oldBatchRadiusItem.BinID}),
-					stampindex.Delete(s.IndexStore(), reserveScope, oldStamp),
-					chunkstamp.DeleteWithStamp(s.IndexStore(), reserveScope, oldBatchRadiusItem.Address, oldStamp),
-				)
-				if err != nil {
-					return err
-				}
+		prev := binary.BigEndian.Uint64(oldStampIndex.StampTimestamp)
+		curr := binary.BigEndian.Uint64(chunk.Stamp().Timestamp())
+		if prev >= curr {
+			return false, fmt.Errorf("overwrite same chunk. prev %d cur %d batch %s: %w", prev, curr, hex.EncodeToString(chunk.Stamp().BatchID()), storage.ErrOverwriteNewerChunk)
+		}
 
-				binID, err := r.IncBinID(s.IndexStore(), bin)
-				if err != nil {
-					return err
-				}
+		r.logger.Debug(
+			"replacing chunk stamp index",
+			"old_chunk", oldStampIndex.ChunkAddress,
+			"new_chunk", chunk.Address(),
+			"batch_id", hex.EncodeToString(chunk.Stamp().BatchID()),
+		)
 
-				err = errors.Join(
-					stampindex.Store(s.IndexStore(), reserveScope, chunk),
-					chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
-					s.IndexStore().Put(&BatchRadiusItem{
-						Bin:       bin,
-						BinID:     binID,
-						Address:   chunk.Address(),
-						BatchID:   chunk.Stamp().BatchID(),
-						StampHash: stampHash,
-					}),
-					s.IndexStore().Put(&ChunkBinItem{
-						Bin:       bin,
-						BinID:     binID,
-						Address:   chunk.Address(),
-						BatchID:   chunk.Stamp().BatchID(),
-						ChunkType: chunkType,
-						StampHash: stampHash,
-					}),
-				)
-				if err != nil {
-					return err
-				}
+		// same chunk address
+		if oldStampIndex.ChunkAddress.Equal(chunk.Address()) {
 
-				if chunkType == swarm.ChunkTypeSingleOwner {
-					r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
-					return s.ChunkStore().Replace(ctx, chunk, false)
-				}
+			oldStamp, err := chunkstamp.LoadWithStampHash(s.IndexStore(), reserveScope, oldStampIndex.ChunkAddress, oldStampIndex.StampHash)
+			if err != nil {
+				return false, err
+			}
 
-				return nil
+			oldBatchRadiusItem := &BatchRadiusItem{
+				Bin:       bin,
+				Address:   oldStampIndex.ChunkAddress,
+				BatchID:   oldStampIndex.BatchID,
+				StampHash: oldStampIndex.StampHash,
+			}
+			// load item to get the binID
+			err = s.IndexStore().Get(oldBatchRadiusItem)
+			if err != nil {
+				return false, err
 			}
 
-			// An older and different chunk with the same batchID and stamp index has been previously
-			// saved to the reserve. We must do the below before saving the new chunk:
-			// 1. Delete the old chunk from the chunkstore.
-			// 2. Delete the old chunk's stamp data.
-			// 3. Delete ALL old chunk related items from the reserve.
-			// 4. Update the stamp index.
+			// delete old chunk index items
+			err = errors.Join(
+				s.IndexStore().Delete(oldBatchRadiusItem),
+				s.IndexStore().Delete(&ChunkBinItem{Bin: oldBatchRadiusItem.Bin, BinID: oldBatchRadiusItem.BinID}),
+				stampindex.Delete(s.IndexStore(), reserveScope, oldStamp),
+				chunkstamp.DeleteWithStamp(s.IndexStore(), reserveScope, oldBatchRadiusItem.Address, oldStamp),
+			)
+			if err != nil {
+				return false, err
+			}
 
-			err = r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
+			binID, err := r.IncBinID(s.IndexStore(), bin)
 			if err != nil {
-				return fmt.Errorf("failed removing older chunk %s: %w", oldStampIndex.ChunkAddress, err)
+				return false, err
 			}
 
-			// replace old stamp index.
-			err = stampindex.Store(s.IndexStore(), reserveScope, chunk)
+			err = errors.Join(
+				stampindex.Store(s.IndexStore(), reserveScope, chunk),
+				chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
+				s.IndexStore().Put(&BatchRadiusItem{
+					Bin:       bin,
+					BinID:     binID,
+					Address:   chunk.Address(),
+					BatchID:   chunk.Stamp().BatchID(),
+					StampHash: stampHash,
+				}),
+				s.IndexStore().Put(&ChunkBinItem{
+					Bin:       bin,
+					BinID:     binID,
+					Address:   chunk.Address(),
+					BatchID:   chunk.Stamp().BatchID(),
+					ChunkType: chunkType,
+					StampHash: stampHash,
+				}),
+			)
 			if err != nil {
-				return fmt.Errorf("failed updating stamp index: %w", err)
+				return false, err
 			}
+
+			if chunkType == swarm.ChunkTypeSingleOwner {
+				r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
+				return false, s.ChunkStore().Replace(ctx, chunk, false)
+			}
+
+			return false, nil
 		}
 
-		binID, err := r.IncBinID(s.IndexStore(), bin)
+		// An older and different chunk with the same batchID and stamp index has been previously
+		// saved to the reserve. We must do the below before saving the new chunk:
+		// 1. Delete the old chunk from the chunkstore.
+		// 2. Delete the old chunk's stamp data.
+		// 3. Delete ALL old chunk related items from the reserve.
+		// 4. Update the stamp index.
+
+		err = r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
 		if err != nil {
-			return err
+			return false, fmt.Errorf("failed removing older chunk %s: %w", oldStampIndex.ChunkAddress, err)
 		}
 
-		err = errors.Join(
-			chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
-			s.IndexStore().Put(&BatchRadiusItem{
-				Bin:       bin,
-				BinID:     binID,
-				Address:   chunk.Address(),
-				BatchID:   chunk.Stamp().BatchID(),
-				StampHash: stampHash,
-			}),
-			s.IndexStore().Put(&ChunkBinItem{
-				Bin:       bin,
-				BinID:     binID,
-				Address:   chunk.Address(),
-				BatchID:   chunk.Stamp().BatchID(),
-				ChunkType: chunkType,
-				StampHash: stampHash,
-			}),
-		)
+		// replace old stamp index.
+		err = stampindex.Store(s.IndexStore(), reserveScope, chunk)
 		if err != nil {
-			return err
+			return false, fmt.Errorf("failed updating stamp index: %w", err)
 		}
+	}
 
-		var has bool
-		if chunkType == swarm.ChunkTypeSingleOwner {
-			has, err = s.ChunkStore().Has(ctx, chunk.Address())
-			if err != nil {
-				return err
-			}
-			if has {
-				r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
-				err = s.ChunkStore().Replace(ctx, chunk, true)
-			} else {
-				err = s.ChunkStore().Put(ctx, chunk)
-			}
-		} else {
-			err = s.ChunkStore().Put(ctx, chunk)
-		}
+	binID, err := r.IncBinID(s.IndexStore(), bin)
+	if err != nil {
+		return false, err
+	}
+
+	err = errors.Join(
+		chunkstamp.Store(s.IndexStore(), reserveScope, chunk),
+		s.IndexStore().Put(&BatchRadiusItem{
+			Bin:       bin,
+			BinID:     binID,
+			Address:   chunk.Address(),
+			BatchID:   chunk.Stamp().BatchID(),
+			StampHash: stampHash,
+		}),
+		s.IndexStore().Put(&ChunkBinItem{
+			Bin:       bin,
+			BinID:     binID,
+			Address:   chunk.Address(),
+			BatchID:   chunk.Stamp().BatchID(),
+			ChunkType: chunkType,
+			StampHash: stampHash,
+		}),
+	)
+	if err != nil {
+		return false, err
+	}
 
+	var has bool
+	if chunkType == swarm.ChunkTypeSingleOwner {
+		has, err = s.ChunkStore().Has(ctx, chunk.Address())
 		if err != nil {
-			return err
+			return false, err
 		}
-
-		if !loadedStampIndex {
-			shouldIncReserveSize = true
+		if has {
+			r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
+			err = s.ChunkStore().Replace(ctx, chunk, true)
+		} else {
+			err = s.ChunkStore().Put(ctx, chunk)
 		}
+	} else {
+		err = s.ChunkStore().Put(ctx, chunk)
+	}
 
-		return nil
-	})
 	if err != nil {
-		return err
+		return false, err
 	}
-	if shouldIncReserveSize {
-		r.size.Add(1)
-	}
-	return nil
+
+	return !loadedStampIndex, nil
 }
 
 func (r *Reserve) Has(addr swarm.Address, batchID []byte, stampHash []byte) (bool, error) {
@@ -15,6 +15,10 @@ The rules of the transaction is as follows:
 -on commit			-> if batch_commit succeeds, release sharky_release locations from the disk
 					-> if batch_commit fails or is not called, release all sharky_write location from the disk, do nothing for sharky_release
 
+The point reads of the indexstore (Get, Has and GetSize) of a transaction observe its pending writes,
+so that several chunks and their index entries can be stored in one transaction. Iterations only
+observe the committed state.
+
 See the NewTransaction method for more details.
 */
 
@@ -29,6 +33,7 @@ import (
 	m "github.com/calmw/bee-tron/pkg/metrics"
 	"github.com/calmw/bee-tron/pkg/sharky"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/storageutil"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/prometheus/client_golang/prometheus"
@@ -84,11 +89,12 @@ type transaction struct {
 // creating the transaction.
 // By design, it is best to not batch too many writes to a single transaction, including multiple chunks writes.
 // Calls made to the transaction are NOT thread-safe.
+// The point reads of the index store of the transaction observe its pending writes.
 func (s *store) NewTransaction(ctx context.Context) (Transaction, func()) {
 
 	b := s.bstore.Batch(ctx)
 
-	index := &indexTrx{s.bstore, b, s.metrics}
+	index := &indexTrx{s.bstore, b, s.metrics, make(map[string]pendingItem)}
 	sharky := &sharkyTrx{s.sharky, s.metrics, nil, nil}
 
 	t := &transaction{
@@ -116,11 +122,11 @@ func (s *store) NewTransaction(ctx context.Context) (Transaction, func()) {
 }
 
 func (s *store) IndexStore() storage.Reader {
-	return &indexTrx{s.bstore, nil, s.metrics}
+	return &indexTrx{s.bstore, nil, s.metrics, nil}
 }
 
 func (s *store) ChunkStore() storage.ReadOnlyChunkStore {
-	indexStore := &indexTrx{s.bstore, nil, s.metrics}
+	indexStore := &indexTrx{s.bstore, nil, s.metrics, nil}
 	sharyTrx := &sharkyTrx{s.sharky, s.metrics, nil, nil}
 	return &chunkStoreTrx{indexStore, sharyTrx, s.chunkLocker, nil, s.metrics, true}
 }
@@ -276,18 +282,66 @@ type indexTrx struct {
 	store   storage.Reader
 	batch   storage.Batch
 	metrics metrics
+	pending map[string]pendingItem // writes of the transaction by key
+}
+
+// pendingItem is an index store write which is not committed yet.
+type pendingItem struct {
+	data    []byte // marshaled item
+	deleted bool
 }
 
-func (s *indexTrx) Get(i storage.Item) error           { return s.store.Get(i) }
-func (s *indexTrx) Has(k storage.Key) (bool, error)    { return s.store.Has(k) }
-func (s *indexTrx) GetSize(k storage.Key) (int, error) { return s.store.GetSize(k) }
+func pendingKey(k storage.Key) string {
+	return storageutil.JoinFields(k.Namespace(), k.ID())
+}
+
+func (s *indexTrx) Get(i storage.Item) error {
+	if p, ok := s.pending[pendingKey(i)]; ok {
+		if p.deleted {
+			return storage.ErrNotFound
+		}
+		return i.Unmarshal(append([]byte(nil), p.data...))
+	}
+	return s.store.Get(i)
+}
+func (s *indexTrx) Has(k storage.Key) (bool, error) {
+	if p, ok := s.pending[pendingKey(k)]; ok {
+		return !p.deleted, nil
+	}
+	return s.store.Has(k)
+}
+func (s *indexTrx) GetSize(k storage.Key) (int, error) {
+	if p, ok := s.pending[pendingKey(k)]; ok {
+		if p.deleted {
+			return 0, storage.ErrNotFound
+		}
+		return len(p.data), nil
+	}
+	return s.store.GetSize(k)
+}
 func (s *indexTrx) Iterate(q storage.Query, f storage.IterateFn) (err error) {
 	defer handleMetric("iterate", s.metrics)(&err)
 	return s.store.Iterate(q, f)
 }
 func (s *indexTrx) Count(k storage.Key) (int, error) { return s.store.Count(k) }
-func (s *indexTrx) Put(i storage.Item) error         { return s.batch.Put(i) }
-func (s *indexTrx) Delete(i storage.Item) error      { return s.batch.Delete(i) }
+func (s *indexTrx) Put(i storage.Item) error {
+	data, err := i.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := s.batch.Put(i); err != nil {
+		return err
+	}
+	s.pending[pendingKey(i)] = pendingItem{data: data}
+	return nil
+}
+func (s *indexTrx) Delete(i storage.Item) error {
+	if err := s.batch.Delete(i); err != nil {
+		return err
+	}
+	s.pending[pendingKey(i)] = pendingItem{deleted: true}
+	return nil
+}
 
 type sharkyTrx struct {
 	sharky       *sharky.Store
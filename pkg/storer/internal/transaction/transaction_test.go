@@ -182,7 +182,52 @@ func Test_TransactionStorage(t *testing.T) {
 
 		has, err := st.ChunkStore().Has(context.Background(), ch1.Address())
 		assert.NoError(t, err)
+		if has {
+			t.Fatal("should NOT have chunk")
+		}
+	})
+
+	t.Run("read pending writes", func(t *testing.T) {
+		t.Parallel()
+
+		ch1 := test.GenerateTestRandomChunk()
+		ch2 := test.GenerateTestRandomChunk()
+
+		tx, done := st.NewTransaction(context.Background())
+		defer done()
+
+		assert.NoError(t, tx.IndexStore().Put(&cache.CacheEntryItem{Address: ch1.Address(), AccessTimestamp: 1}))
+		assert.NoError(t, tx.ChunkStore().Put(context.Background(), ch1))
+		assert.NoError(t, tx.ChunkStore().Put(context.Background(), ch2))
+		assert.NoError(t, tx.ChunkStore().Delete(context.Background(), ch2.Address()))
+
+		item := cache.CacheEntryItem{Address: ch1.Address()}
+		assert.NoError(t, tx.IndexStore().Get(&item))
+		assert.Equal(t, item, cache.CacheEntryItem{Address: ch1.Address(), AccessTimestamp: 1})
+
+		ch1_get, err := tx.ChunkStore().Get(context.Background(), ch1.Address())
+		assert.NoError(t, err)
+		assert.Equal(t, ch1.Data(), ch1_get.Data())
+
+		has, err := tx.ChunkStore().Has(context.Background(), ch2.Address())
+		assert.NoError(t, err)
+		if has {
+			t.Fatal("should NOT have chunk")
+		}
+
+		// the committed state is not changed before the commit
+		assert.ErrorIs(t, st.IndexStore().Get(&cache.CacheEntryItem{Address: ch1.Address()}), storage.ErrNotFound)
+
+		assert.NoError(t, tx.Commit())
+
+		has, err = st.ChunkStore().Has(context.Background(), ch1.Address())
+		assert.NoError(t, err)
 		if !has {
+			t.Fatal("should have chunk")
+		}
+		has, err = st.ChunkStore().Has(context.Background(), ch2.Address())
+		assert.NoError(t, err)
+		if has {
 			t.Fatal("should NOT have chunk")
 		}
 	})
@@ -158,7 +158,7 @@ func (i TagItem) Marshal() ([]byte, error) {
 	binary.LittleEndian.PutUint64(buf[32:], i.Sent)
 	binary.LittleEndian.PutUint64(buf[40:], i.Synced)
 	addrBytes := internal.AddressBytesOrZero(i.Address)
-	if len(addrBytes) == encryption.ReferenceSize {
+	if encryption.IsEncryptedReferenceSize(len(addrBytes)) {
 		// in case of encrypted reference we use the swarm hash as the address and
 		// avoid storing the encryption key
 		addrBytes = addrBytes[:swarm.HashSize]
@@ -511,10 +511,20 @@ func (u *uploadPutter) Cleanup(st transaction.Storage) error {
 		return fmt.Errorf("failed iterating over push items: %w", err)
 	}
 
+	return errors.Join(
+		deletePushItems(st, itemsToDelete),
+		st.Run(context.Background(), func(s transaction.Store) error {
+			return s.IndexStore().Delete(&dirtyTagItem{TagID: u.tagID})
+		}),
+	)
+}
+
+// deletePushItems removes the chunks of the push items from the upload store.
+func deletePushItems(st transaction.Storage, items []*pushItem) error {
 	var eg errgroup.Group
 	eg.SetLimit(runtime.NumCPU())
 
-	for _, item := range itemsToDelete {
+	for _, item := range items {
 		func(item *pushItem) {
 			eg.Go(func() error {
 				return st.Run(context.Background(), func(s transaction.Store) error {
@@ -530,12 +540,7 @@ func (u *uploadPutter) Cleanup(st transaction.Storage) error {
 		}(item)
 	}
 
-	return errors.Join(
-		eg.Wait(),
-		st.Run(context.Background(), func(s transaction.Store) error {
-			return s.IndexStore().Delete(&dirtyTagItem{TagID: u.tagID})
-		}),
-	)
+	return eg.Wait()
 }
 
 // CleanupDirty does a best-effort cleanup of dirty tags. This is called on startup.
@@ -752,6 +757,78 @@ func DeleteTag(st storage.Writer, tagID uint64) error {
 	return nil
 }
 
+// PendingTags returns the number of chunks not yet synced of each tag.
+func PendingTags(st storage.Reader) (map[uint64]uint64, error) {
+	pending := make(map[uint64]uint64)
+	err := st.Iterate(storage.Query{
+		Factory: func() storage.Item { return &pushItem{} },
+	}, func(r storage.Result) (bool, error) {
+		pending[r.Entry.(*pushItem).TagID]++
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("uploadstore: failed to iterate push items: %w", err)
+	}
+	return pending, nil
+}
+
+// DirtyTags returns the start timestamps of the tags whose upload session was
+// not closed. The chunks of such tags are not handed over to the pusher.
+func DirtyTags(st storage.Reader) (map[uint64]int64, error) {
+	dirty := make(map[uint64]int64)
+	err := st.Iterate(storage.Query{
+		Factory: func() storage.Item { return &dirtyTagItem{} },
+	}, func(r storage.Result) (bool, error) {
+		di := r.Entry.(*dirtyTagItem)
+		dirty[di.TagID] = di.Started
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("uploadstore: failed to iterate dirty tags: %w", err)
+	}
+	return dirty, nil
+}
+
+// ResumeTag marks the upload session of the tag as closed so that its chunks
+// are handed over to the pusher.
+func ResumeTag(st storage.Writer, tagID uint64) error {
+	if err := st.Delete(&dirtyTagItem{TagID: tagID}); err != nil {
+		return fmt.Errorf("uploadstore: failed to resume tag %d: %w", tagID, err)
+	}
+	return nil
+}
+
+// PurgeTag removes all the chunks not yet synced of the tag together with the
+// tag and returns the number of removed chunks.
+func PurgeTag(st transaction.Storage, tagID uint64) (uint64, error) {
+	var items []*pushItem
+	err := st.IndexStore().Iterate(storage.Query{
+		Factory: func() storage.Item { return &pushItem{} },
+	}, func(r storage.Result) (bool, error) {
+		if pi := r.Entry.(*pushItem); pi.TagID == tagID {
+			items = append(items, pi)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("uploadstore: failed to iterate push items: %w", err)
+	}
+
+	err = errors.Join(
+		deletePushItems(st, items),
+		st.Run(context.Background(), func(s transaction.Store) error {
+			return errors.Join(
+				s.IndexStore().Delete(&dirtyTagItem{TagID: tagID}),
+				s.IndexStore().Delete(&TagItem{TagID: tagID}),
+			)
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("uploadstore: failed to purge tag %d: %w", tagID, err)
+	}
+	return uint64(len(items)), nil
+}
+
 func IterateAll(st storage.Reader, iterateFn func(item storage.Item) (bool, error)) error {
 	return st.Iterate(
 		storage.Query{
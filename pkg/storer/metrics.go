@@ -15,6 +15,24 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// Logical namespaces of the storer which the namespace metrics are broken
+// down by.
+const (
+	reserveNamespace = "reserve"
+	cacheNamespace   = "cache"
+	pinNamespace     = "pin"
+	uploadNamespace  = "upload"
+)
+
+// componentNamespaces maps the components of the method metrics to their
+// namespaces.
+var componentNamespaces = map[string]string{
+	"reserve":     reserveNamespace,
+	"cachestore":  cacheNamespace,
+	"pinstore":    pinNamespace,
+	"uploadstore": uploadNamespace,
+}
+
 // metrics groups storer related prometheus counters.
 type metrics struct {
 	MethodCalls             *prometheus.CounterVec
@@ -24,6 +42,7 @@ type metrics struct {
 	ReserveCleanup          prometheus.Counter
 	StorageRadius           prometheus.Gauge
 	CacheSize               prometheus.Gauge
+	CacheSizeBytes          prometheus.Gauge
 	EvictedChunkCount       prometheus.Counter
 	ExpiredChunkCount       prometheus.Counter
 	OverCapTriggerCount     prometheus.Counter
@@ -31,6 +50,14 @@ type metrics struct {
 	LevelDBStats            *prometheus.HistogramVec
 	ExpiryTriggersCount     prometheus.Counter
 	ExpiryRunsCount         prometheus.Counter
+	EvictionPaused          *prometheus.GaugeVec
+	ReserveSampleDuration   *prometheus.GaugeVec
+	UsageGrowthRate         *prometheus.GaugeVec
+	UsageTimeToFull         *prometheus.GaugeVec
+
+	NamespaceOperations        *prometheus.CounterVec
+	NamespaceOperationDuration *prometheus.HistogramVec
+	NamespaceEvictions         *prometheus.CounterVec
 
 	ReserveMissingBatch prometheus.Gauge
 }
@@ -106,6 +133,14 @@ func newMetrics() metrics {
 				Help:      "Number of chunks in cache.",
 			},
 		),
+		CacheSizeBytes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "cache_size_bytes",
+				Help:      "Total size of the chunks in cache.",
+			},
+		),
 		EvictedChunkCount: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
@@ -163,6 +198,113 @@ func newMetrics() metrics {
 				Help:      "Number of times the expiry worker was fired.",
 			},
 		),
+		EvictionPaused: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "eviction_paused",
+				Help:      "Whether the eviction worker is paused.",
+			},
+			[]string{"worker"},
+		),
+		ReserveSampleDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "reserve_sample_duration_seconds",
+				Help:      "Time spent in the phases of the last reserve sample, summed over the workers.",
+			},
+			[]string{"phase"},
+		),
+		UsageGrowthRate: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "usage_growth_rate",
+				Help:      "Growth of the storage component per second over the recent samples.",
+			},
+			[]string{"component"},
+		),
+		UsageTimeToFull: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "usage_time_to_full_seconds",
+				Help:      "Projected time until the storage component is full, negative if it is not filling up.",
+			},
+			[]string{"component"},
+		),
+		NamespaceOperations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "namespace_operations",
+				Help:      "Number of chunk puts and gets by namespace and status.",
+			},
+			[]string{"namespace", "operation", "status"},
+		),
+		NamespaceOperationDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "namespace_operation_duration",
+				Help:      "Duration of chunk puts and gets by namespace.",
+			},
+			[]string{"namespace", "operation"},
+		),
+		NamespaceEvictions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "namespace_evictions",
+				Help:      "Number of chunks evicted by namespace.",
+			},
+			[]string{"namespace"},
+		),
+	}
+}
+
+// observeSampleStats records the timings of a reserve sample.
+func (m metrics) observeSampleStats(s *SampleStats) {
+	m.ReserveSampleDuration.WithLabelValues("total").Set(s.TotalDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("batches_below_value").Set(s.BatchesBelowValueDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("iteration").Set(s.IterationDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("read_order").Set(s.ReadOrderDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("chunk_load").Set(s.ChunkLoadDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("transformed_address").Set(s.TaddrDuration.Seconds())
+	m.ReserveSampleDuration.WithLabelValues("valid_stamp").Set(s.ValidStampDuration.Seconds())
+}
+
+// observePut records a put of n chunks by the component in the metrics of its
+// namespace, if it has one.
+func (m metrics) observePut(component string, n int, d float64, err error) {
+	ns, ok := componentNamespaces[component]
+	if !ok {
+		return
+	}
+	m.NamespaceOperationDuration.WithLabelValues(ns, "put").Observe(d)
+	if err == nil {
+		m.NamespaceOperations.WithLabelValues(ns, "put", "success").Add(float64(n))
+	} else {
+		m.NamespaceOperations.WithLabelValues(ns, "put", "failure").Inc()
+	}
+}
+
+// observeGet records a get by the component in the metrics of its namespace,
+// if it has one.
+func (m metrics) observeGet(component string, d float64, err error) {
+	ns, ok := componentNamespaces[component]
+	if !ok {
+		return
+	}
+	m.NamespaceOperationDuration.WithLabelValues(ns, "get").Observe(d)
+	switch {
+	case err == nil:
+		m.NamespaceOperations.WithLabelValues(ns, "get", "hit").Inc()
+	case errors.Is(err, storage.ErrNotFound):
+		m.NamespaceOperations.WithLabelValues(ns, "get", "miss").Inc()
+	default:
+		m.NamespaceOperations.WithLabelValues(ns, "get", "failure").Inc()
 	}
 }
 
@@ -180,6 +322,7 @@ func (m putterWithMetrics) Put(ctx context.Context, chunk swarm.Chunk) error {
 	dur := captureDuration(time.Now())
 	err := m.Putter.Put(ctx, chunk)
 	m.metrics.MethodCallsDuration.WithLabelValues(m.component, "Put").Observe(dur())
+	m.metrics.observePut(m.component, 1, dur(), err)
 	if err == nil {
 		m.metrics.MethodCalls.WithLabelValues(m.component, "Put", "success").Inc()
 	} else {
@@ -202,6 +345,7 @@ func (m getterWithMetrics) Get(ctx context.Context, address swarm.Address) (swar
 	dur := captureDuration(time.Now())
 	chunk, err := m.Getter.Get(ctx, address)
 	m.metrics.MethodCallsDuration.WithLabelValues(m.component, "Get").Observe(dur())
+	m.metrics.observeGet(m.component, dur(), err)
 	if err == nil || errors.Is(err, storage.ErrNotFound) {
 		m.metrics.MethodCalls.WithLabelValues(m.component, "Get", "success").Inc()
 	} else {
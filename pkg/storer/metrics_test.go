@@ -0,0 +1,133 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+	postagetesting "github.com/calmw/bee-tron/pkg/postage/testing"
+	pullerMock "github.com/calmw/bee-tron/pkg/puller/mock"
+	"github.com/calmw/bee-tron/pkg/spinlock"
+	"github.com/calmw/bee-tron/pkg/storage"
+	chunk "github.com/calmw/bee-tron/pkg/storage/testing"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestNamespaceOperationMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Minute)
+	st, err := memStorer(t, opts)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunk.GenerateTestRandomChunks(3)
+	for _, ch := range chunks {
+		if err := st.Cache().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := st.Lookup().Get(ctx, ch.Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := st.Lookup().Get(ctx, swarm.RandAddress(t)); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, storage.ErrNotFound)
+	}
+
+	for _, tc := range []struct {
+		operation, status string
+		want              float64
+	}{
+		{"put", "success", 3},
+		{"get", "hit", 3},
+		{"get", "miss", 1},
+	} {
+		if got := st.NamespaceOperations("cache", tc.operation, tc.status); got != tc.want {
+			t.Fatalf("got %v cache %s operations with status %s, want %v", got, tc.operation, tc.status, tc.want)
+		}
+	}
+	if got := st.NamespaceOperations("reserve", "put", "success"); got != 0 {
+		t.Fatalf("got %v reserve put operations, want 0", got)
+	}
+}
+
+func TestNamespaceEvictionMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cache", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Minute)
+		opts.CacheCapacity = 10
+		st, err := memStorer(t, opts)()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ch := range chunk.GenerateTestRandomChunks(15) {
+			if err := st.Cache().Put(ctx, ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// every chunk removed from the cache is counted as evicted
+		err = spinlock.Wait(5*time.Second, func() bool {
+			info, err := st.DebugInfo(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return info.Cache.Size <= 10 && st.NamespaceEvictions("cache") == float64(15-info.Cache.Size)
+		})
+		if err != nil {
+			t.Fatalf("got %v cache evictions", st.NamespaceEvictions("cache"))
+		}
+		if got := st.NamespaceEvictions("reserve"); got != 0 {
+			t.Fatalf("got %v reserve evictions, want 0", got)
+		}
+	})
+
+	t.Run("reserve", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		baseAddr := swarm.RandAddress(t)
+		st, err := diskStorer(t, dbTestOps(baseAddr, 100, nil, nil, time.Minute))()
+		if err != nil {
+			t.Fatal(err)
+		}
+		st.StartReserveWorker(ctx, pullerMock.NewMockRateReporter(0), networkRadiusFunc(0))
+
+		batches := []*postage.Batch{postagetesting.MustNewBatch(), postagetesting.MustNewBatch()}
+		for _, batch := range batches {
+			for i := 0; i < 10; i++ {
+				ch := chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewBatchStamp(batch.ID))
+				if err := st.ReservePutter().Put(ctx, ch); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		c, unsub := st.Events().Subscribe("batchExpiryDone")
+		t.Cleanup(unsub)
+		if err := st.EvictBatch(ctx, batches[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		<-c
+
+		if got := st.NamespaceEvictions("reserve"); got != 10 {
+			t.Fatalf("got %v reserve evictions, want 10", got)
+		}
+		if got := st.NamespaceOperations("reserve", "put", "success"); got != 20 {
+			t.Fatalf("got %v reserve put operations, want 20", got)
+		}
+	})
+}
@@ -8,7 +8,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storage/migration"
+	"github.com/calmw/bee-tron/pkg/storer/internal/cache"
+	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
+	"github.com/calmw/bee-tron/pkg/storer/internal/reserve"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
+	"github.com/calmw/bee-tron/pkg/storer/internal/upload"
 )
 
 // AfterInitSteps lists all migration steps for localstore IndexStore after the localstore is initiated.
@@ -17,21 +21,75 @@ func AfterInitSteps(
 	sharkyNoOfShards int,
 	st transaction.Storage,
 	logger log.Logger,
+	progress *migration.Progress,
 ) migration.Steps {
 	return map[uint64]migration.StepFn{
 		1: step_01,
-		2: step_02(st),
-		3: ReserveRepairer(st, storage.ChunkType, logger),
-		4: step_04(sharkyPath, sharkyNoOfShards, st, logger),
-		5: step_05(st, logger),
-		6: step_06(st, logger),
+		2: step_02(st, progress),
+		3: ReserveRepairer(st, storage.ChunkType, logger, progress),
+		4: step_04(sharkyPath, sharkyNoOfShards, st, logger, progress),
+		5: step_05(st, logger, progress),
+		6: step_06(st, logger, progress),
 		7: resetReserveEpochTimestamp(st),
 	}
 }
 
+// AfterInitPlans describes the migration steps listed by AfterInitSteps.
+func AfterInitPlans(st storage.Reader) migration.Plans {
+	return map[uint64]migration.Plan{
+		1: {Description: "no-op"},
+		2: {
+			Description: "reset the access timestamp of the cache entries",
+			Estimate:    countItems(st, &cache.CacheEntryItem{}),
+		},
+		3: {
+			Description: "rebuild the bin indexes of the reserve",
+			Estimate:    countItems(st, &reserve.BatchRadiusItem{}),
+		},
+		4: {
+			Description: "recover the sharky free slots from the retrieval index",
+			Estimate:    countItems(st, &chunkstore.RetrievalIndexItem{}),
+		},
+		5: {
+			Description: "remove the upload items",
+			Estimate: func() (uint64, error) {
+				var n uint64
+				err := upload.IterateAll(st, func(storage.Item) (bool, error) {
+					n++
+					return false, nil
+				})
+				return n, err
+			},
+		},
+		6: {
+			Description: "add the stamp hash to the reserve and stamp index items",
+			Estimate:    countItems(st, &reserve.BatchRadiusItemV1{}),
+		},
+		7: {Description: "reset the epoch timestamp of the reserve"},
+	}
+}
+
 // BeforeInitSteps lists all migration steps for localstore IndexStore before the localstore is initiated.
-func BeforeInitSteps(st storage.BatchStore, logger log.Logger) migration.Steps {
+func BeforeInitSteps(st storage.BatchStore, logger log.Logger, progress *migration.Progress) migration.Steps {
 	return map[uint64]migration.StepFn{
-		1: RefCountSizeInc(st, logger),
+		1: RefCountSizeInc(st, logger, progress),
+	}
+}
+
+// BeforeInitPlans describes the migration steps listed by BeforeInitSteps.
+func BeforeInitPlans(st storage.Reader) migration.Plans {
+	return map[uint64]migration.Plan{
+		1: {
+			Description: "increase the capacity of the reference counter of the retrieval index",
+			Estimate:    countItems(st, &OldRetrievalIndexItem{}),
+		},
+	}
+}
+
+// countItems returns the estimate of the number of items in the namespace of the key.
+func countItems(st storage.Reader, key storage.Key) func() (uint64, error) {
+	return func() (uint64, error) {
+		n, err := st.Count(key)
+		return uint64(n), err
 	}
 }
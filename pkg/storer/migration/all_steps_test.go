@@ -24,12 +24,12 @@ func TestPreSteps(t *testing.T) {
 
 	store := internal.NewInmemStorage()
 
-	assert.NotEmpty(t, localmigration.AfterInitSteps("", 0, store, log.Noop))
+	assert.NotEmpty(t, localmigration.AfterInitSteps("", 0, store, log.Noop, nil))
 
 	t.Run("version numbers", func(t *testing.T) {
 		t.Parallel()
 
-		err := migration.ValidateVersions(localmigration.AfterInitSteps("", 0, store, log.Noop))
+		err := migration.ValidateVersions(localmigration.AfterInitSteps("", 0, store, log.Noop, nil))
 		assert.NoError(t, err)
 	})
 
@@ -38,7 +38,7 @@ func TestPreSteps(t *testing.T) {
 
 		store := internal.NewInmemStorage()
 		err := store.Run(context.Background(), func(s transaction.Store) error {
-			return migration.Migrate(s.IndexStore(), "migration", localmigration.AfterInitSteps("", 4, store, log.Noop))
+			return migration.Migrate(s.IndexStore(), "migration", localmigration.AfterInitSteps("", 4, store, log.Noop, nil))
 		})
 		assert.NoError(t, err)
 	})
@@ -49,12 +49,12 @@ func TestPostSteps(t *testing.T) {
 
 	st := inmemstore.New()
 
-	assert.NotEmpty(t, localmigration.BeforeInitSteps(st, log.Noop))
+	assert.NotEmpty(t, localmigration.BeforeInitSteps(st, log.Noop, nil))
 
 	t.Run("version numbers", func(t *testing.T) {
 		t.Parallel()
 
-		err := migration.ValidateVersions(localmigration.BeforeInitSteps(st, log.Noop))
+		err := migration.ValidateVersions(localmigration.BeforeInitSteps(st, log.Noop, nil))
 		assert.NoError(t, err)
 	})
 
@@ -63,7 +63,7 @@ func TestPostSteps(t *testing.T) {
 
 		store := inmemstore.New()
 
-		err := migration.Migrate(store, "migration", localmigration.BeforeInitSteps(store, log.Noop))
+		err := migration.Migrate(store, "migration", localmigration.BeforeInitSteps(store, log.Noop, nil))
 		assert.NoError(t, err)
 	})
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/sharky"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storage/storageutil"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -100,7 +101,7 @@ func (r OldRetrievalIndexItem) String() string {
 	return storageutil.JoinFields(r.Namespace(), r.ID())
 }
 
-func RefCountSizeInc(s storage.BatchStore, logger log.Logger) func() error {
+func RefCountSizeInc(s storage.BatchStore, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 
 		logger := logger.WithName("migration-RefCountSizeInc").Register()
@@ -148,6 +149,7 @@ func RefCountSizeInc(s storage.BatchStore, logger log.Logger) func() error {
 			if err != nil {
 				return err
 			}
+			progress.Add(uint64(end - i))
 		}
 
 		logger.Info("migration complete")
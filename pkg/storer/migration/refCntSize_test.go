@@ -37,7 +37,7 @@ func Test_RefCntSize(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	assert.NoError(t, stepFn(store, log.Noop)())
+	assert.NoError(t, stepFn(store, log.Noop, nil)())
 
 	// check if all entries are migrated.
 	for _, entry := range oldItems {
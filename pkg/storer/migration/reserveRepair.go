@@ -15,6 +15,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstamp"
 	"github.com/calmw/bee-tron/pkg/storer/internal/reserve"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
@@ -28,6 +29,7 @@ func ReserveRepairer(
 	st transaction.Storage,
 	chunkTypeFunc func(swarm.Chunk) swarm.ChunkType,
 	logger log.Logger,
+	progress *migration.Progress,
 ) func() error {
 	return func() error {
 		/*
@@ -179,6 +181,7 @@ func ReserveRepairer(
 		for _, item := range batchRadiusItems {
 			func(item *reserve.BatchRadiusItem) {
 				eg.Go(func() error {
+					defer progress.Add(1)
 
 					return st.Run(context.Background(), func(s transaction.Store) error {
 
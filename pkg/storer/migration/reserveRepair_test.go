@@ -28,7 +28,7 @@ func TestReserveRepair(t *testing.T) {
 	baseAddr := swarm.RandAddress(t)
 	stepFn := localmigration.ReserveRepairer(store, func(_ swarm.Chunk) swarm.ChunkType {
 		return swarm.ChunkTypeContentAddressed
-	}, log.Noop)
+	}, log.Noop, nil)
 
 	var chunksPO = make([][]swarm.Chunk, 5)
 	var chunksPerPO uint64 = 2
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storer/internal/cache"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -17,7 +18,7 @@ import (
 // step_02 migrates the cache to the new format.
 // the old cacheEntry item has the same key, but the value is different. So only
 // a Put is needed.
-func step_02(st transaction.Storage) func() error {
+func step_02(st transaction.Storage, progress *migration.Progress) func() error {
 
 	return func() error {
 
@@ -50,7 +51,11 @@ func step_02(st transaction.Storage) func() error {
 			}
 		}
 
-		return trx.Commit()
+		if err := trx.Commit(); err != nil {
+			return err
+		}
+		progress.Add(uint64(len(entries)))
+		return nil
 	}
 
 }
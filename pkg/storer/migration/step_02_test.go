@@ -64,7 +64,7 @@ func Test_Step_02(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	assert.NoError(t, stepFn(store)())
+	assert.NoError(t, stepFn(store, nil)())
 
 	// check if all entries are migrated.
 	for _, entry := range addrs {
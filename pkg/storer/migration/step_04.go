@@ -9,6 +9,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/sharky"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
 	"github.com/calmw/bee-tron/pkg/swarm"
@@ -21,6 +22,7 @@ func step_04(
 	sharkyNoOfShards int,
 	st transaction.Storage,
 	logger log.Logger,
+	progress *migration.Progress,
 ) func() error {
 	return func() error {
 		// for in-mem store, skip this step
@@ -45,6 +47,7 @@ func step_04(
 			if err := sharkyRecover.Add(res.Location); err != nil {
 				return err
 			}
+			progress.Add(1)
 		}
 
 		if err := sharkyRecover.Save(); err != nil {
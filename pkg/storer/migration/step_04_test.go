@@ -39,7 +39,7 @@ func Test_Step_04(t *testing.T) {
 	store := inmemstore.New()
 	storage := transaction.NewStorage(sharkyStore, store)
 
-	stepFn := localmigration.Step_04(sharkyDir, 1, storage, log.Noop)
+	stepFn := localmigration.Step_04(sharkyDir, 1, storage, log.Noop, nil)
 
 	chunks := chunktest.GenerateTestRandomChunks(10)
 
@@ -10,12 +10,13 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
 	"github.com/calmw/bee-tron/pkg/storer/internal/upload"
 )
 
 // step_05 is a migration step that removes all upload items from the store.
-func step_05(st transaction.Storage, logger log.Logger) func() error {
+func step_05(st transaction.Storage, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 
 		logger := logger.WithName("migration-step-05").Register()
@@ -33,6 +34,7 @@ func step_05(st transaction.Storage, logger log.Logger) func() error {
 					errC <- fmt.Errorf("delete upload item: %w", err)
 					return
 				}
+				progress.Add(1)
 			}
 			close(errC)
 		}()
@@ -99,7 +99,7 @@ func Test_Step_05(t *testing.T) {
 
 	wantCount(t, store.IndexStore(), 10)
 
-	err = localmigration.Step_05(store, log.Noop)()
+	err = localmigration.Step_05(store, log.Noop, nil)()
 	if err != nil {
 		t.Fatalf("step 05: %v", err)
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storage/migration"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstamp"
 	"github.com/calmw/bee-tron/pkg/storer/internal/reserve"
 	"github.com/calmw/bee-tron/pkg/storer/internal/stampindex"
@@ -23,13 +24,13 @@ import (
 )
 
 // step_06 is a migration step that adds a stampHash to all BatchRadiusItems, ChunkBinItems and StampIndexItems.
-func step_06(st transaction.Storage, logger log.Logger) func() error {
+func step_06(st transaction.Storage, logger log.Logger, progress *migration.Progress) func() error {
 	return func() error {
 		logger := logger.WithName("migration-step-06").Register()
 
 		logger.Info("start adding stampHash to BatchRadiusItems, ChunkBinItems and StampIndexItems")
 
-		seenCount, doneCount, err := addStampHash(logger, st)
+		seenCount, doneCount, err := addStampHash(logger, st, progress)
 		if err != nil {
 			return fmt.Errorf("add stamp hash migration: %w", err)
 		}
@@ -38,7 +39,7 @@ func step_06(st transaction.Storage, logger log.Logger) func() error {
 	}
 }
 
-func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, error) {
+func addStampHash(logger log.Logger, st transaction.Storage, progress *migration.Progress) (int64, int64, error) {
 
 	preBatchRadiusCnt, err := st.IndexStore().Count(&reserve.BatchRadiusItemV1{})
 	if err != nil {
@@ -170,6 +171,7 @@ func addStampHash(logger log.Logger, st transaction.Storage) (int64, int64, erro
 					return err
 				}
 				doneCount.Add(1)
+				progress.Add(1)
 				return nil
 			})
 			if err != nil {
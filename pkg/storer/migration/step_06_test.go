@@ -99,7 +99,7 @@ func Test_Step_06(t *testing.T) {
 	}
 
 	require.NoError(t, err)
-	err = localmigration.Step_06(store, log.Noop)()
+	err = localmigration.Step_06(store, log.Noop, nil)()
 	require.NoError(t, err)
 
 	has, err := store.IndexStore().Has(&reserve.EpochItem{})
@@ -28,6 +28,10 @@ type mockStorer struct {
 	activeSessions map[uint64]*storer.SessionInfo
 	chunkPushC     chan *pusher.Op
 	debugInfo      storer.Info
+	evictionPaused map[string]time.Time
+	usageForecast  []storer.UsageForecast
+	dedupStat      storer.DedupStat
+	radiusOverride storer.RadiusOverride
 }
 
 type putterSession struct {
@@ -72,6 +76,18 @@ func NewWithDebugInfo(info storer.Info) *mockStorer {
 	return st
 }
 
+func NewWithUsageForecast(forecasts []storer.UsageForecast) *mockStorer {
+	st := New()
+	st.usageForecast = forecasts
+	return st
+}
+
+func NewWithDedupStat(stat storer.DedupStat) *mockStorer {
+	st := New()
+	st.dedupStat = stat
+	return st
+}
+
 func (m *mockStorer) Upload(_ context.Context, pin bool, tagID uint64) (storer.PutterSession, error) {
 	return &putterSession{
 		chunkStore: m.chunkStore,
@@ -136,6 +152,50 @@ func (m *mockStorer) ListSessions(offset, limit int) ([]storer.SessionInfo, erro
 	return sessions, nil
 }
 
+// mockPending treats the chunks split but not synced as pending.
+func mockPending(session *storer.SessionInfo) uint64 {
+	if session.Split > session.Synced {
+		return session.Split - session.Synced
+	}
+	return 0
+}
+
+func (m *mockStorer) IncompleteSessions() ([]storer.SessionStat, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := []storer.SessionStat{}
+	for _, v := range m.activeSessions {
+		if pending := mockPending(v); pending > 0 {
+			stats = append(stats, storer.SessionStat{SessionInfo: *v, Pending: pending})
+		}
+	}
+	return stats, nil
+}
+
+func (m *mockStorer) ResumeSession(tagID uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.activeSessions[tagID]
+	if !ok || mockPending(session) == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mockStorer) PurgeSession(tagID uint64) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.activeSessions[tagID]
+	if !ok {
+		return 0, storage.ErrNotFound
+	}
+	delete(m.activeSessions, tagID)
+	return mockPending(session), nil
+}
+
 func (m *mockStorer) DeletePin(_ context.Context, address swarm.Address) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -171,6 +231,36 @@ func (m *mockStorer) HasPin(address swarm.Address) (bool, error) {
 	return false, nil
 }
 
+func (m *mockStorer) PinsUsage() ([]storer.PinUsage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := make([]storer.PinUsage, 0, len(m.pins))
+	for _, p := range m.pins {
+		usage = append(usage, storer.PinUsage{Reference: p.Clone()})
+	}
+	return usage, nil
+}
+
+func (m *mockStorer) PinQuota() (quota, used uint64) {
+	return 0, 0
+}
+
+func (m *mockStorer) VerifyPin(_ context.Context, root swarm.Address, _ bool, progress func(storer.PinVerification)) (storer.PinVerification, error) {
+	has, err := m.HasPin(root)
+	if err != nil {
+		return storer.PinVerification{}, err
+	}
+	if !has {
+		return storer.PinVerification{}, storage.ErrNotFound
+	}
+	res := storer.PinVerification{Reference: root}
+	if progress != nil {
+		progress(res)
+	}
+	return res, nil
+}
+
 func (m *mockStorer) NewCollection(ctx context.Context) (storer.PutterSession, error) {
 	return &putterSession{
 		chunkStore: m.chunkStore,
@@ -235,3 +325,72 @@ func (m *mockStorer) NeighborhoodsStat(ctx context.Context) ([]*storer.Neighborh
 func (m *mockStorer) Put(ctx context.Context, ch swarm.Chunk) error {
 	return m.chunkStore.Put(ctx, ch)
 }
+
+func (m *mockStorer) PauseEviction(worker string, d time.Duration) error {
+	if worker != storer.CacheEviction && worker != storer.ReserveEviction {
+		return storer.ErrUnknownEvictionWorker
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.evictionPaused == nil {
+		m.evictionPaused = make(map[string]time.Time)
+	}
+	var until time.Time
+	if d > 0 {
+		until = now().Add(d)
+	}
+	m.evictionPaused[worker] = until
+	return nil
+}
+
+func (m *mockStorer) ResumeEviction(worker string) error {
+	if worker != storer.CacheEviction && worker != storer.ReserveEviction {
+		return storer.ErrUnknownEvictionWorker
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.evictionPaused, worker)
+	return nil
+}
+
+func (m *mockStorer) EvictionStatus() []storer.EvictionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]storer.EvictionStatus, 0, 2)
+	for _, worker := range []string{storer.CacheEviction, storer.ReserveEviction} {
+		until, paused := m.evictionPaused[worker]
+		statuses = append(statuses, storer.EvictionStatus{Worker: worker, Paused: paused, PausedUntil: until})
+	}
+	return statuses
+}
+
+func (m *mockStorer) UsageForecast() []storer.UsageForecast {
+	return m.usageForecast
+}
+
+func (m *mockStorer) DedupStats(context.Context) (storer.DedupStat, error) {
+	return m.dedupStat, nil
+}
+
+func (m *mockStorer) SetRadiusOverride(o storer.RadiusOverride) error {
+	if o.Radius > swarm.MaxPO {
+		return storer.ErrInvalidRadiusOverride
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.radiusOverride = o
+	return nil
+}
+
+func (m *mockStorer) RadiusOverride() storer.RadiusOverride {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.radiusOverride
+}
+
+func (m *mockStorer) AutomaticRadius() uint8 { return 0 }
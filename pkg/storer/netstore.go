@@ -7,9 +7,12 @@ package storer
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/pusher"
 	"github.com/calmw/bee-tron/pkg/pushsync"
+	"github.com/calmw/bee-tron/pkg/retrieval"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/calmw/bee-tron/pkg/topology"
@@ -79,7 +82,17 @@ func (db *DB) DirectUpload() PutterSession {
 }
 
 // Download is the implementation of the NetStore.Download method.
+// The returned getter retrieves multiple chunks from the network in batches
+// if the retrieval service supports it and no gateway is configured.
 func (db *DB) Download(cache bool) storage.Getter {
+	getter := db.download(cache)
+	if _, ok := db.retrieval.(retrieval.BatchRetriever); ok && db.remoteGetter == nil {
+		return batchDownloader{Getter: getter, db: db, cache: cache}
+	}
+	return getter
+}
+
+func (db *DB) download(cache bool) storage.Getter {
 	return getterWithMetrics{
 		storage.GetterFunc(func(ctx context.Context, address swarm.Address) (ch swarm.Chunk, err error) {
 
@@ -99,28 +112,23 @@ func (db *DB) Download(cache bool) storage.Getter {
 				span.LogFields(olog.String("step", "chunk found locally"))
 				return ch, nil
 			case errors.Is(err, storage.ErrNotFound):
+				if db.remoteGetter != nil {
+					span.LogFields(olog.String("step", "retrieve chunk from gateway"))
+					remoteCh, remoteErr := db.gatewayGet(ctx, address)
+					if remoteErr == nil {
+						if cache {
+							db.cacheRetrieved(ctx, remoteCh, logger)
+						}
+						return remoteCh, nil
+					}
+					logger.Debug("retrieving chunk from gateway failed", "error", remoteErr, "chunk_address", address)
+				}
 				span.LogFields(olog.String("step", "retrieve chunk from network"))
 				if db.retrieval != nil {
 					// if chunk is not found locally, retrieve it from the network
 					ch, err = db.retrieval.RetrieveChunk(ctx, address, swarm.ZeroAddress)
 					if err == nil && cache {
-						select {
-						case <-ctx.Done():
-						case <-db.quit:
-						case db.cacheLimiter.sem <- struct{}{}:
-							db.cacheLimiter.wg.Add(1)
-							go func() {
-								defer func() {
-									<-db.cacheLimiter.sem
-									db.cacheLimiter.wg.Done()
-								}()
-
-								err := db.Cache().Put(db.cacheLimiter.ctx, ch)
-								if err != nil {
-									logger.Debug("putting chunk to cache failed", "error", err, "chunk_address", ch.Address())
-								}
-							}()
-						}
+						db.cacheRetrieved(ctx, ch, logger)
 					}
 				}
 			}
@@ -134,6 +142,89 @@ func (db *DB) Download(cache bool) storage.Getter {
 	}
 }
 
+var _ storage.BatchGetter = (*batchDownloader)(nil)
+
+// batchDownloader is the download getter which retrieves the chunks not
+// found locally in batches.
+type batchDownloader struct {
+	storage.Getter
+	db    *DB
+	cache bool
+}
+
+// GetMany implements the storage.BatchGetter interface. The chunks found
+// locally are handed over right away, the missing ones as soon as they are
+// retrieved.
+func (b batchDownloader) GetMany(ctx context.Context, addrs []swarm.Address, fn func(int, swarm.Chunk, error)) error {
+	var (
+		errs    = make([]error, len(addrs))
+		missing []swarm.Address
+		pos     []int
+	)
+	for i, addr := range addrs {
+		ch, err := b.db.Lookup().Get(ctx, addr)
+		switch {
+		case err == nil:
+			fn(i, ch, nil)
+		case errors.Is(err, storage.ErrNotFound):
+			missing = append(missing, addr)
+			pos = append(pos, i)
+		default:
+			errs[i] = err
+			fn(i, nil, err)
+		}
+	}
+	if len(missing) == 0 {
+		return errors.Join(errs...)
+	}
+
+	err := b.db.retrieval.(retrieval.BatchRetriever).RetrieveChunks(ctx, missing, func(j int, ch swarm.Chunk, err error) {
+		if err == nil && b.cache {
+			b.db.cacheRetrieved(ctx, ch, b.db.logger)
+		}
+		fn(pos[j], ch, err)
+	})
+
+	return errors.Join(append(errs, err)...)
+}
+
+// gatewayGet fetches the chunk from the remote gateway cache tier.
+func (db *DB) gatewayGet(ctx context.Context, address swarm.Address) (ch swarm.Chunk, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("netstore", "GatewayGet").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("netstore", "GatewayGet", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("netstore", "GatewayGet", "failure").Inc()
+		}
+	}()
+
+	return db.remoteGetter.Get(ctx, address)
+}
+
+// cacheRetrieved stores the chunk retrieved from outside of the node in the
+// cache in the background.
+func (db *DB) cacheRetrieved(ctx context.Context, ch swarm.Chunk, logger log.Logger) {
+	select {
+	case <-ctx.Done():
+	case <-db.quit:
+	case db.cacheLimiter.sem <- struct{}{}:
+		db.cacheLimiter.wg.Add(1)
+		go func() {
+			defer func() {
+				<-db.cacheLimiter.sem
+				db.cacheLimiter.wg.Done()
+			}()
+
+			err := db.Cache().Put(db.cacheLimiter.ctx, ch)
+			if err != nil {
+				logger.Debug("putting chunk to cache failed", "error", err, "chunk_address", ch.Address())
+			}
+		}()
+	}
+}
+
 // PusherFeed is the implementation of the NetStore.PusherFeed method.
 func (db *DB) PusherFeed() <-chan *pusher.Op {
 	return db.pusherFeed
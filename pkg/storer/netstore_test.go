@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -362,3 +364,64 @@ func TestNetStore(t *testing.T) {
 		})
 	})
 }
+
+func TestNetStoreGateway(t *testing.T) {
+	t.Parallel()
+
+	chunks := chunktesting.GenerateTestRandomChunks(3)
+	served, missing, corrupt := chunks[0], chunks[1], chunks[2]
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chunks/" + served.Address().String():
+			_, _ = w.Write(served.Data())
+		case "/chunks/" + corrupt.Address().String():
+			_, _ = w.Write(served.Data())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(gateway.Close)
+
+	opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
+	opts.CacheCapacity = 100
+	opts.RemoteGetter = storer.NewGatewayGetter(gateway.URL)
+
+	lstore, err := storer.New(context.Background(), "", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var retrieved []swarm.Address
+	lstore.SetRetrievalService(&testRetrieval{fn: func(address swarm.Address) (swarm.Chunk, error) {
+		retrieved = append(retrieved, address)
+		for _, ch := range chunks {
+			if ch.Address().Equal(address) {
+				return ch, nil
+			}
+		}
+		return nil, storage.ErrNotFound
+	}})
+
+	getter := lstore.Download(true)
+	for _, ch := range chunks {
+		readCh, err := getter.Get(context.Background(), ch.Address())
+		if err != nil {
+			t.Fatalf("download.Get(...): unexpected error: %v", err)
+		}
+		if !readCh.Equal(ch) {
+			t.Fatalf("incorrect chunk read: address %s", readCh.Address())
+		}
+	}
+
+	t.Cleanup(lstore.WaitForBgCacheWorkers())
+
+	// the chunk served by the gateway is not retrieved from the network, the
+	// missing and the corrupt ones are
+	want := []swarm.Address{missing.Address(), corrupt.Address()}
+	if len(retrieved) != len(want) || !retrieved[0].Equal(want[0]) || !retrieved[1].Equal(want[1]) {
+		t.Fatalf("retrieved from network: want %v, have %v", want, retrieved)
+	}
+
+	verifyChunks(t, lstore.Storage(), chunks, true)
+}
@@ -16,6 +16,17 @@ import (
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
+// ErrPinQuotaExceeded is returned when pinning a chunk would grow the size of
+// the pinned chunks over the pinning quota.
+var ErrPinQuotaExceeded = pinstore.ErrQuotaExceeded
+
+// PinUsage is the disk usage of a pinning collection.
+type PinUsage struct {
+	Reference swarm.Address
+	Chunks    uint64 // number of unique chunks of the collection
+	Bytes     uint64 // size of the unique chunks, zero if pinned before it was recorded
+}
+
 // NewCollection is the implementation of the PinStore.NewCollection method.
 func (db *DB) NewCollection(ctx context.Context) (PutterSession, error) {
 	var (
@@ -23,7 +34,7 @@ func (db *DB) NewCollection(ctx context.Context) (PutterSession, error) {
 		err           error
 	)
 	err = db.storage.Run(ctx, func(store transaction.Store) error {
-		pinningPutter, err = pinstore.NewCollection(store.IndexStore())
+		pinningPutter, err = pinstore.NewCollection(store.IndexStore(), db.pinQuota)
 		if err != nil {
 			return fmt.Errorf("pinstore.NewCollection: %w", err)
 		}
@@ -77,7 +88,7 @@ func (db *DB) DeletePin(ctx context.Context, root swarm.Address) (err error) {
 	unlock := db.Lock(uploadsLock)
 	defer unlock()
 
-	return pinstore.DeletePin(ctx, db.storage, root)
+	return pinstore.DeletePin(ctx, db.storage, db.pinQuota, root)
 }
 
 // Pins is the implementation of the PinStore.Pins method.
@@ -110,6 +121,38 @@ func (db *DB) HasPin(root swarm.Address) (has bool, err error) {
 	return pinstore.HasPin(db.storage.IndexStore(), root)
 }
 
+// PinsUsage is the implementation of the PinStore.PinsUsage method.
+func (db *DB) PinsUsage() (usage []PinUsage, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("pinstore", "PinsUsage").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinsUsage", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinsUsage", "failure").Inc()
+		}
+	}()
+
+	usage = make([]PinUsage, 0)
+	err = pinstore.IterateCollections(db.storage.IndexStore(), func(root swarm.Address, stat pinstore.CollectionStat) (bool, error) {
+		usage = append(usage, PinUsage{
+			Reference: root,
+			Chunks:    stat.Total - stat.DupInCollection,
+			Bytes:     stat.Bytes,
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// PinQuota is the implementation of the PinStore.PinQuota method.
+func (db *DB) PinQuota() (quota, used uint64) {
+	return db.pinQuota.Limit(), db.pinQuota.Used()
+}
+
 func (db *DB) IteratePinCollection(root swarm.Address, iterateFn func(swarm.Address) (bool, error)) error {
 	return pinstore.IterateCollection(db.storage.IndexStore(), root, iterateFn)
 }
@@ -6,12 +6,15 @@ package storer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	storage "github.com/calmw/bee-tron/pkg/storage"
 	chunktesting "github.com/calmw/bee-tron/pkg/storage/testing"
 	storer "github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
 	"github.com/calmw/bee-tron/pkg/swarm"
 )
 
@@ -170,3 +173,87 @@ func TestPinStore(t *testing.T) {
 		testPinStore(t, diskStorer(t, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)))
 	})
 }
+
+func TestVerifyPin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chunks := chunktesting.GenerateTestRandomChunks(10)
+	lost := chunks[3:5]
+
+	lstore, err := storer.New(ctx, "", dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lstore.SetRetrievalService(&testRetrieval{fn: func(addr swarm.Address) (swarm.Chunk, error) {
+		for _, ch := range lost {
+			if ch.Address().Equal(addr) {
+				return ch, nil
+			}
+		}
+		return nil, storage.ErrNotFound
+	}})
+
+	session, err := lstore.NewCollection(ctx)
+	if err != nil {
+		t.Fatalf("NewCollection(...): unexpected error: %v", err)
+	}
+	for _, ch := range chunks {
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatalf("session.Put(...): unexpected error: %v", err)
+		}
+	}
+	root := chunks[0].Address()
+	if err := session.Done(root); err != nil {
+		t.Fatalf("session.Done(...): unexpected error: %v", err)
+	}
+
+	for _, ch := range lost {
+		err := lstore.Storage().Run(ctx, func(s transaction.Store) error {
+			return s.ChunkStore().Delete(ctx, ch.Address())
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verify := func(t *testing.T, repair bool, want storer.PinVerification) {
+		t.Helper()
+
+		var reports uint64
+		have, err := lstore.VerifyPin(ctx, root, repair, func(storer.PinVerification) { reports++ })
+		if err != nil {
+			t.Fatalf("VerifyPin(...): unexpected error: %v", err)
+		}
+		want.Reference = root
+		if !have.Reference.Equal(want.Reference) || have.Total != want.Total || have.Checked != want.Checked ||
+			have.Missing != want.Missing || have.Invalid != want.Invalid || have.Repaired != want.Repaired {
+			t.Fatalf("VerifyPin(...): want %+v, have %+v", want, have)
+		}
+		if reports != want.Checked+want.Repaired {
+			t.Fatalf("VerifyPin(...): want %d progress reports, have %d", want.Checked+want.Repaired, reports)
+		}
+	}
+
+	t.Run("verify", func(t *testing.T) {
+		verify(t, false, storer.PinVerification{Total: 10, Checked: 10, Missing: 2})
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		verify(t, true, storer.PinVerification{Total: 10, Checked: 10, Missing: 2, Repaired: 2})
+		verify(t, false, storer.PinVerification{Total: 10, Checked: 10})
+
+		for _, ch := range lost {
+			if _, err := lstore.ChunkStore().Get(ctx, ch.Address()); err != nil {
+				t.Fatalf("Get(...): unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("unknown pin", func(t *testing.T) {
+		_, err := lstore.VerifyPin(ctx, swarm.RandAddress(t), false, nil)
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("VerifyPin(...): want %v, have %v", storage.ErrNotFound, err)
+		}
+	})
+}
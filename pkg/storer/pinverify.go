@@ -0,0 +1,175 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/soc"
+	storage "github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
+	pinstore "github.com/calmw/bee-tron/pkg/storer/internal/pinning"
+	"github.com/calmw/bee-tron/pkg/storer/internal/transaction"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// PinVerification is the progress of the verification of a pinning collection.
+type PinVerification struct {
+	Reference swarm.Address
+	Total     uint64 // number of unique chunks of the collection
+	Checked   uint64 // number of chunks checked so far
+	Missing   uint64 // chunks not found in the chunk store
+	Invalid   uint64 // chunks unreadable from sharky or with invalid content
+	Repaired  uint64 // missing or invalid chunks retrieved from the network
+}
+
+// brokenChunk is a chunk of a pinning collection found missing or invalid.
+type brokenChunk struct {
+	address swarm.Address
+	missing bool
+}
+
+// VerifyPin is the implementation of the PinStore.VerifyPin method.
+func (db *DB) VerifyPin(ctx context.Context, root swarm.Address, repair bool, progress func(PinVerification)) (res PinVerification, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("pinstore", "VerifyPin").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "VerifyPin", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "VerifyPin", "failure").Inc()
+		}
+	}()
+
+	stat, err := pinstore.GetStat(db.storage.IndexStore(), root)
+	if err != nil {
+		return res, err
+	}
+
+	res = PinVerification{
+		Reference: root,
+		Total:     stat.Total - stat.DupInCollection,
+	}
+	report := func() {
+		if progress != nil {
+			progress(res)
+		}
+	}
+
+	// the broken chunks are repaired after the iteration in order not to
+	// write to the store while iterating it
+	var broken []brokenChunk
+	err = pinstore.IterateCollection(db.storage.IndexStore(), root, func(addr swarm.Address) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+		missing, invalid, err := db.verifyChunk(ctx, addr)
+		if err != nil {
+			return true, err
+		}
+		res.Checked++
+		switch {
+		case missing:
+			res.Missing++
+			broken = append(broken, brokenChunk{address: addr, missing: true})
+		case invalid:
+			res.Invalid++
+			broken = append(broken, brokenChunk{address: addr})
+		}
+		report()
+		return false, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if !repair {
+		return res, nil
+	}
+
+	for _, b := range broken {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		switch err := db.repairChunk(ctx, root, b); {
+		case errors.Is(err, storage.ErrNotFound):
+			return res, err // the pin was deleted meanwhile
+		case err != nil:
+			db.logger.Debug("pin repair: unable to repair chunk", "root", root, "address", b.address, "error", err)
+		default:
+			res.Repaired++
+			report()
+		}
+	}
+
+	return res, nil
+}
+
+// verifyChunk checks that the chunk is stored, readable from sharky and that
+// its content is valid.
+func (db *DB) verifyChunk(ctx context.Context, addr swarm.Address) (missing, invalid bool, err error) {
+	has, err := chunkstore.Has(ctx, db.storage.IndexStore(), addr)
+	if err != nil {
+		return false, false, fmt.Errorf("verify chunk %s: %w", addr, err)
+	}
+	if !has {
+		return true, false, nil
+	}
+
+	ch, err := db.storage.ChunkStore().Get(ctx, addr)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return true, false, nil // removed since it was checked
+	case err != nil:
+		return false, true, nil
+	}
+
+	return false, !cac.Valid(ch) && !soc.Valid(ch), nil
+}
+
+// repairChunk retrieves the broken chunk of the pinning collection from the
+// network and stores it again.
+func (db *DB) repairChunk(ctx context.Context, root swarm.Address, b brokenChunk) error {
+	if db.retrieval == nil {
+		return errors.New("retrieval service not available")
+	}
+	ch, err := db.retrieval.RetrieveChunk(ctx, b.address, swarm.ZeroAddress)
+	if err != nil {
+		return fmt.Errorf("retrieve chunk: %w", err)
+	}
+	if !cac.Valid(ch) && !soc.Valid(ch) {
+		return fmt.Errorf("retrieved chunk %s is invalid", b.address)
+	}
+
+	unlock := db.Lock(uploadsLock)
+	defer unlock()
+
+	has, err := pinstore.HasPin(db.storage.IndexStore(), root)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return storage.ErrNotFound
+	}
+
+	return db.storage.Run(ctx, func(s transaction.Store) error {
+		// the chunk may have been stored again since it was checked
+		has, err := s.ChunkStore().Has(ctx, b.address)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !has:
+			return s.ChunkStore().Put(ctx, ch)
+		case !b.missing:
+			return s.ChunkStore().Replace(ctx, ch, false)
+		}
+		return nil
+	})
+}
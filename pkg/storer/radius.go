@@ -0,0 +1,184 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+var (
+	// ErrInvalidRadiusOverride is returned when the pinned radius or the
+	// offset of a RadiusOverride is out of range.
+	ErrInvalidRadiusOverride = errors.New("storer: invalid radius override")
+	// ErrReserveDisabled is returned when the radius of a node without a
+	// reserve is overridden.
+	ErrReserveDisabled = errors.New("storer: reserve disabled")
+)
+
+// RadiusOverride replaces the automatic adaptation of the storage radius, for
+// nodes dedicated to a neighborhood. The zero value follows the automatic
+// radius.
+type RadiusOverride struct {
+	// Pinned fixes the storage radius at Radius. The reserve may grow over
+	// its capacity since no chunks within the pinned radius are evicted.
+	Pinned bool
+	Radius uint8
+	// Offset is added to the automatic radius if the radius is not pinned.
+	Offset int
+}
+
+func (o RadiusOverride) validate() error {
+	if o.Radius > swarm.MaxPO || o.Offset > int(swarm.MaxPO) || o.Offset < -int(swarm.MaxPO) {
+		return ErrInvalidRadiusOverride
+	}
+	return nil
+}
+
+// RadiusOverrider pins the storage radius or offsets it from the automatic
+// radius.
+type RadiusOverrider interface {
+	// SetRadiusOverride applies the override to the storage radius at once.
+	SetRadiusOverride(RadiusOverride) error
+	// RadiusOverride returns the override applied to the storage radius.
+	RadiusOverride() RadiusOverride
+	// AutomaticRadius returns the storage radius the node would have without
+	// the override.
+	AutomaticRadius() uint8
+}
+
+var _ RadiusOverrider = (*DB)(nil)
+
+// radiusState is the automatic storage radius and the override applied to it.
+type radiusState struct {
+	mu       sync.Mutex
+	auto     uint8
+	override RadiusOverride
+}
+
+// storageRadius returns the storage radius for the automatic radius.
+func (s *radiusState) storageRadius(auto uint8) uint8 {
+	if s.override.Pinned {
+		return s.override.Radius
+	}
+	r := int(auto) + s.override.Offset
+	switch {
+	case r < 0:
+		return 0
+	case r > int(swarm.MaxBins):
+		return swarm.MaxBins
+	}
+	return uint8(r)
+}
+
+// initRadius derives the automatic radius from the persisted storage radius,
+// which is assumed to include the configured offset, and pins the radius.
+func (db *DB) initRadius(override RadiusOverride) error {
+	if err := override.validate(); err != nil {
+		return err
+	}
+
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+
+	db.radius.override = override
+	r := int(db.reserve.Radius())
+	if !override.Pinned {
+		r -= override.Offset
+	}
+	db.radius.auto = uint8(max(r, 0))
+
+	if !override.Pinned {
+		return nil
+	}
+	return db.applyRadius()
+}
+
+// applyRadius sets the storage radius of the reserve from the automatic
+// radius and the override. Must be called with the radius lock held.
+func (db *DB) applyRadius() error {
+	r := db.radius.storageRadius(db.radius.auto)
+	db.metrics.StorageRadius.Set(float64(r))
+	if r == db.reserve.Radius() {
+		return nil
+	}
+	return db.reserve.SetRadius(r)
+}
+
+// setAutoRadius sets the automatic radius and returns the resulting storage
+// radius.
+func (db *DB) setAutoRadius(auto uint8) (uint8, error) {
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+
+	db.radius.auto = auto
+	err := db.applyRadius()
+	return db.reserve.Radius(), err
+}
+
+// increaseRadius increases the automatic radius until the storage radius
+// grows and returns the resulting storage radius, which does not grow if the
+// radius is pinned or the maximum is reached.
+func (db *DB) increaseRadius() (uint8, error) {
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+
+	prev := db.radius.storageRadius(db.radius.auto)
+	for !db.radius.override.Pinned && db.radius.auto < swarm.MaxBins {
+		db.radius.auto++
+		if db.radius.storageRadius(db.radius.auto) > prev {
+			break
+		}
+	}
+	err := db.applyRadius()
+	return db.reserve.Radius(), err
+}
+
+// radiusPinned reports whether the storage radius is pinned.
+func (db *DB) radiusPinned() bool {
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+	return db.radius.override.Pinned
+}
+
+// SetRadiusOverride is the implementation of the RadiusOverrider.SetRadiusOverride method.
+func (db *DB) SetRadiusOverride(override RadiusOverride) error {
+	if db.reserve == nil {
+		return ErrReserveDisabled
+	}
+	if err := override.validate(); err != nil {
+		return err
+	}
+
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+
+	db.radius.override = override
+	if err := db.applyRadius(); err != nil {
+		return err
+	}
+	db.logger.Info("storage radius override", "pinned", override.Pinned, "radius", override.Radius, "offset", override.Offset, "storage_radius", db.reserve.Radius())
+	return nil
+}
+
+// RadiusOverride is the implementation of the RadiusOverrider.RadiusOverride method.
+func (db *DB) RadiusOverride() RadiusOverride {
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+	return db.radius.override
+}
+
+// AutomaticRadius is the implementation of the RadiusOverrider.AutomaticRadius method.
+func (db *DB) AutomaticRadius() uint8 {
+	if db.reserve == nil {
+		return 0
+	}
+
+	db.radius.mu.Lock()
+	defer db.radius.mu.Unlock()
+	return db.radius.auto
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestRadiusOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("option", func(t *testing.T) {
+		t.Parallel()
+
+		opts := dbTestOps(swarm.RandAddress(t), 10, nil, nil, time.Minute)
+		opts.RadiusOverride = storer.RadiusOverride{Pinned: true, Radius: 3}
+		db, err := memStorer(t, opts)()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := db.StorageRadius(); got != 3 {
+			t.Fatalf("got storage radius %d, want 3", got)
+		}
+		if got := db.AutomaticRadius(); got != 0 {
+			t.Fatalf("got automatic radius %d, want 0", got)
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memStorer(t, dbTestOps(swarm.RandAddress(t), 10, nil, nil, time.Minute))()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tc := range []struct {
+			override storer.RadiusOverride
+			want     uint8
+		}{
+			{storer.RadiusOverride{Pinned: true, Radius: 5}, 5},
+			{storer.RadiusOverride{Pinned: true, Radius: 5, Offset: 2}, 5},
+			{storer.RadiusOverride{Offset: 2}, 2},
+			{storer.RadiusOverride{Offset: -2}, 0},
+			{storer.RadiusOverride{}, 0},
+		} {
+			if err := db.SetRadiusOverride(tc.override); err != nil {
+				t.Fatal(err)
+			}
+			if got := db.StorageRadius(); got != tc.want {
+				t.Fatalf("override %+v: got storage radius %d, want %d", tc.override, got, tc.want)
+			}
+			if got := db.RadiusOverride(); got != tc.override {
+				t.Fatalf("got override %+v, want %+v", got, tc.override)
+			}
+		}
+
+		err = db.SetRadiusOverride(storer.RadiusOverride{Pinned: true, Radius: swarm.MaxPO + 1})
+		if !errors.Is(err, storer.ErrInvalidRadiusOverride) {
+			t.Fatalf("got error %v, want %v", err, storer.ErrInvalidRadiusOverride)
+		}
+	})
+
+	t.Run("no reserve", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memStorer(t, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Minute))()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = db.SetRadiusOverride(storer.RadiusOverride{Pinned: true, Radius: 1})
+		if !errors.Is(err, storer.ErrReserveDisabled) {
+			t.Fatalf("got error %v, want %v", err, storer.ErrReserveDisabled)
+		}
+	})
+}
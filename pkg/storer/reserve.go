@@ -67,11 +67,11 @@ func (db *DB) startReserveWorkers(
 		return // node shutdown
 	}
 
-	err = db.reserve.SetRadius(r)
-	if err != nil {
-		db.logger.Error(err, "reserve set radius")
-	} else {
-		db.metrics.StorageRadius.Set(float64(r))
+	// the current storage radius already includes the override
+	if r != db.reserve.Radius() {
+		if _, err := db.setAutoRadius(r); err != nil {
+			db.logger.Error(err, "reserve set radius")
+		}
 	}
 
 	// syncing can now begin now that the reserver worker is running
@@ -136,6 +136,10 @@ func (db *DB) reserveWorker(ctx context.Context) {
 			return
 		case <-batchExpiryTrigger:
 
+			if db.reserveEvictionPause.isPaused() {
+				continue
+			}
+
 			err := db.evictExpiredBatches(ctx)
 			if err != nil {
 				db.logger.Warning("reserve worker evict expired batches", "error", err)
@@ -149,6 +153,10 @@ func (db *DB) reserveWorker(ctx context.Context) {
 
 		case <-overCapTrigger:
 
+			if db.reserveEvictionPause.isPaused() {
+				continue
+			}
+
 			db.metrics.OverCapTriggerCount.Inc()
 			if err := db.unreserve(ctx); err != nil {
 				db.logger.Warning("reserve worker unreserve", "error", err)
@@ -163,12 +171,12 @@ func (db *DB) reserveWorker(ctx context.Context) {
 				continue
 			}
 
-			if count < threshold(db.reserve.Capacity()) && db.syncer.SyncRate() == 0 && radius > db.reserveOptions.minimumRadius {
-				radius--
-				if err := db.reserve.SetRadius(radius); err != nil {
+			auto := db.AutomaticRadius()
+			if count < threshold(db.reserve.Capacity()) && db.syncer.SyncRate() == 0 && radius > db.reserveOptions.minimumRadius && auto > 0 && !db.radiusPinned() {
+				radius, err := db.setAutoRadius(auto - 1)
+				if err != nil {
 					db.logger.Error(err, "reserve set radius")
 				}
-				db.metrics.StorageRadius.Set(float64(radius))
 				db.logger.Info("reserve radius decrease", "radius", radius)
 			}
 		}
@@ -231,6 +239,7 @@ func (db *DB) evictBatch(
 		} else {
 			db.metrics.MethodCalls.WithLabelValues("reserve", "EvictBatch", "failure").Inc()
 		}
+		db.metrics.NamespaceEvictions.WithLabelValues(reserveNamespace).Add(float64(evicted))
 		if upToBin == swarm.MaxBins {
 			db.metrics.ExpiredChunkCount.Add(float64(evicted))
 		} else {
@@ -270,6 +279,7 @@ func (db *DB) ReserveGet(ctx context.Context, addr swarm.Address, batchID []byte
 	dur := captureDuration(time.Now())
 	defer func() {
 		db.metrics.MethodCallsDuration.WithLabelValues("reserve", "ReserveGet").Observe(dur())
+		db.metrics.observeGet("reserve", dur(), err)
 		if err == nil || errors.Is(err, storage.ErrNotFound) {
 			db.metrics.MethodCalls.WithLabelValues("reserve", "ReserveGet", "success").Inc()
 		} else {
@@ -298,25 +308,75 @@ func (db *DB) ReserveHas(addr swarm.Address, batchID []byte, stampHash []byte) (
 
 // ReservePutter returns a Putter for inserting chunks into the reserve.
 func (db *DB) ReservePutter() storage.Putter {
-	return putterWithMetrics{
-		storage.PutterFunc(
-			func(ctx context.Context, chunk swarm.Chunk) error {
-				err := db.reserve.Put(ctx, chunk)
-				if err != nil {
-					db.logger.Debug("reserve put error", "error", err)
-					return fmt.Errorf("reserve putter.Put: %w", err)
-				}
-				db.reserveBinEvents.Trigger(string(db.po(chunk.Address())))
-				if !db.reserve.IsWithinCapacity() {
-					db.events.Trigger(reserveOverCapacity)
-				}
-				db.metrics.ReserveSize.Set(float64(db.reserve.Size()))
-				return nil
-			},
-		),
-		db.metrics,
-		"reserve",
+	return reservePutter{
+		putterWithMetrics: putterWithMetrics{
+			storage.PutterFunc(
+				func(ctx context.Context, chunk swarm.Chunk) error {
+					err := db.reserve.Put(ctx, chunk)
+					if err != nil {
+						db.logger.Debug("reserve put error", "error", err)
+						return fmt.Errorf("reserve putter.Put: %w", err)
+					}
+					db.reserveBinEvents.Trigger(string(db.po(chunk.Address())))
+					db.afterReservePut()
+					return nil
+				},
+			),
+			db.metrics,
+			"reserve",
+		},
+		db: db,
+	}
+}
+
+var _ storage.BatchPutter = (*reservePutter)(nil)
+
+// reservePutter puts chunks into the reserve one by one or in batches.
+type reservePutter struct {
+	putterWithMetrics
+	db *DB
+}
+
+// PutBatch implements the storage.BatchPutter interface.
+func (p reservePutter) PutBatch(ctx context.Context, chunks []swarm.Chunk) (n int, err error) {
+	db := p.db
+
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("reserve", "PutBatch").Observe(dur())
+		if err == nil || errors.Is(err, storage.ErrOverwriteNewerChunk) {
+			db.metrics.MethodCalls.WithLabelValues("reserve", "PutBatch", "success").Inc()
+			db.metrics.observePut("reserve", n, dur(), nil)
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("reserve", "PutBatch", "failure").Inc()
+			db.metrics.observePut("reserve", n, dur(), err)
+		}
+	}()
+
+	n, err = db.reserve.PutBatch(ctx, chunks)
+	if err != nil && !errors.Is(err, storage.ErrOverwriteNewerChunk) {
+		db.logger.Debug("reserve put batch error", "error", err)
+		return 0, fmt.Errorf("reserve putter.PutBatch: %w", err)
+	}
+
+	bins := make(map[uint8]struct{})
+	for _, ch := range chunks {
+		bins[db.po(ch.Address())] = struct{}{}
+	}
+	for bin := range bins {
+		db.reserveBinEvents.Trigger(string(bin))
+	}
+	db.afterReservePut()
+	return n, err
+}
+
+// afterReservePut triggers the eviction if the reserve is over capacity and
+// updates the size metric.
+func (db *DB) afterReservePut() {
+	if !db.reserve.IsWithinCapacity() {
+		db.events.Trigger(reserveOverCapacity)
 	}
+	db.metrics.ReserveSize.Set(float64(db.reserve.Size()))
 }
 
 func (db *DB) unreserve(ctx context.Context) (err error) {
@@ -365,6 +425,11 @@ func (db *DB) unreserve(ctx context.Context) (err error) {
 			default:
 			}
 
+			if db.reserveEvictionPause.isPaused() {
+				db.logger.Debug("stopping unreserve, eviction paused")
+				return nil
+			}
+
 			evict := target - totalEvicted
 			if evict < int(db.reserveOptions.minEvictCount) { // evict at least a min count
 				evict = int(db.reserveOptions.minEvictCount)
@@ -387,10 +452,18 @@ func (db *DB) unreserve(ctx context.Context) (err error) {
 			}
 		}
 
-		radius++
+		// chunks within a pinned radius are never evicted
+		if db.radiusPinned() {
+			db.logger.Info("unreserve stopped at the pinned radius", "evicted", totalEvicted, "radius", radius)
+			return nil
+		}
+
+		next, _ := db.increaseRadius()
+		if next <= radius {
+			break
+		}
+		radius = next
 		db.logger.Info("reserve radius increase", "radius", radius)
-		_ = db.reserve.SetRadius(radius)
-		db.metrics.StorageRadius.Set(float64(radius))
 	}
 
 	return errMaxRadius
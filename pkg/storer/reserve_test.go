@@ -90,6 +90,53 @@ func TestIndexCollision(t *testing.T) {
 	})
 }
 
+func TestReservePutBatch(t *testing.T) {
+	t.Parallel()
+
+	baseAddr := swarm.RandAddress(t)
+	storer, err := memStorer(t, dbTestOps(baseAddr, 10, nil, nil, time.Minute))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	putter, ok := storer.ReservePutter().(storage.BatchPutter)
+	if !ok {
+		t.Fatal("reserve putter does not support batches")
+	}
+
+	batch := postagetesting.MustNewBatch()
+	var chunks []swarm.Chunk
+	for i := 0; i < 5; i++ {
+		chunks = append(chunks, chunk.GenerateTestRandomChunkAt(t, baseAddr, i).WithStamp(postagetesting.MustNewBatchStamp(batch.ID)))
+	}
+	// a chunk put twice in the same batch is stored once
+	chunks = append(chunks, chunks[0])
+
+	n, err := putter.PutBatch(context.Background(), chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(chunks) {
+		t.Fatalf("want %d chunks put, got %d", len(chunks), n)
+	}
+
+	for _, ch := range chunks {
+		stampHash, err := ch.Stamp().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		has, err := storer.ReserveHas(ch.Address(), ch.Stamp().BatchID(), stampHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !has {
+			t.Fatalf("chunk %s not in reserve", ch.Address())
+		}
+	}
+
+	t.Run("reserve size", reserveSizeTest(storer.Reserve(), 5))
+}
+
 func TestReplaceOldIndex(t *testing.T) {
 	t.Parallel()
 
@@ -20,9 +20,11 @@ import (
 	"github.com/calmw/bee-tron/pkg/bmt"
 	"github.com/calmw/bee-tron/pkg/cac"
 	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/sharky"
 	"github.com/calmw/bee-tron/pkg/soc"
 	chunk "github.com/calmw/bee-tron/pkg/storage/testing"
 	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstamp"
+	"github.com/calmw/bee-tron/pkg/storer/internal/chunkstore"
 	"github.com/calmw/bee-tron/pkg/storer/internal/reserve"
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"golang.org/x/sync/errgroup"
@@ -30,6 +32,36 @@ import (
 
 const SampleSize = 16
 
+// sampleReadWindow is the number of reserve items whose chunks are read in
+// the order of their sharky location, turning the random reads of the
+// sampler into mostly sequential ones on rotational disks.
+const sampleReadWindow = 1024
+
+// sampleWorkers returns the number of workers loading and hashing the chunks.
+func (db *DB) sampleWorkers() int {
+	if db.reserveOptions.sampleWorkers > 0 {
+		return db.reserveOptions.sampleWorkers
+	}
+	return max(4, runtime.NumCPU())
+}
+
+// sampleReadItem is a reserve item along with the sharky location of its chunk.
+type sampleReadItem struct {
+	item *reserve.ChunkBinItem
+	loc  sharky.Location
+}
+
+// sortByLocation orders the items by the sharky location of their chunks.
+func sortByLocation(items []sampleReadItem) {
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i].loc, items[j].loc
+		if a.Shard != b.Shard {
+			return a.Shard < b.Shard
+		}
+		return a.Slot < b.Slot
+	})
+}
+
 type SampleItem struct {
 	TransformedAddress swarm.Address
 	ChunkAddress       swarm.Address
@@ -53,6 +85,9 @@ type Sample struct {
 // calculation within the round limits.
 // In order to optimize this we use a simple pipeline pattern:
 // Iterate chunk addresses -> Get the chunk data and calculate transformed hash -> Assemble the sample
+// The chunk addresses are handed over to the workers in windows ordered by their sharky location
+// and the sharky compaction yields to the sampler while it runs, so that the disk serves the
+// sampler first. The number of workers is set with the ReserveSampleWorkers option.
 // If the node has doubled their capacity by some factor, sampling process need to only pertain to the
 // chunks of the selected neighborhood as determined by the anchor and the "committed depth" and NOT the whole reserve.
 // The committed depth is the sum of the radius and the doubling factor.
@@ -66,6 +101,9 @@ func (db *DB) ReserveSample(
 	minBatchBalance *big.Int,
 ) (Sample, error) {
 
+	db.sampling.Add(1)
+	defer db.sampling.Add(-1)
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	allStats := &SampleStats{}
@@ -97,19 +135,49 @@ func (db *DB) ReserveSample(
 			addStats(stats)
 		}()
 
+		window := make([]sampleReadItem, 0, sampleReadWindow)
+		flush := func() error {
+			sortStart := time.Now()
+			sortByLocation(window)
+			stats.ReadOrderDuration += time.Since(sortStart)
+
+			for _, w := range window {
+				select {
+				case chunkC <- w.item:
+					stats.TotalIterated++
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			window = window[:0]
+			return nil
+		}
+
 		err := db.reserve.IterateChunksItems(db.StorageRadius(), func(ch *reserve.ChunkBinItem) (bool, error) {
 			if swarm.Proximity(ch.Address.Bytes(), anchor) < committedDepth {
 				return false, nil
 			}
-			select {
-			case chunkC <- ch:
-				stats.TotalIterated++
+
+			// chunks missing from the retrieval index are sorted first and
+			// accounted as load failures by the workers
+			item := sampleReadItem{item: ch}
+			lookupStart := time.Now()
+			rIdx := &chunkstore.RetrievalIndexItem{Address: ch.Address}
+			if err := db.storage.IndexStore().Get(rIdx); err == nil {
+				item.loc = rIdx.Location
+			}
+			stats.ReadOrderDuration += time.Since(lookupStart)
+
+			window = append(window, item)
+			if len(window) < sampleReadWindow {
 				return false, nil
-			case <-ctx.Done():
-				return false, ctx.Err()
 			}
+			return false, flush()
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		return flush()
 	})
 
 	// Phase 2: Get the chunk data and calculate transformed hash
@@ -119,7 +187,7 @@ func (db *DB) ReserveSample(
 		return swarm.NewPrefixHasher(anchor)
 	}
 
-	workers := max(4, runtime.NumCPU())
+	workers := db.sampleWorkers()
 	db.logger.Debug("reserve sampler workers", "count", workers)
 
 	for i := 0; i < workers; i++ {
@@ -259,6 +327,7 @@ func (db *DB) ReserveSample(
 	addStats(stats)
 
 	allStats.TotalDuration = time.Since(t)
+	db.metrics.observeSampleStats(allStats)
 
 	if err := g.Wait(); err != nil {
 		db.logger.Info("reserve sampler finished with error", "err", err, "duration", time.Since(t), "storage_radius", committedDepth, "consensus_time_ns", consensusTime, "stats", fmt.Sprintf("%+v", allStats))
@@ -359,6 +428,7 @@ type SampleStats struct {
 	ChunkLoadDuration         time.Duration
 	ChunkLoadFailed           int64
 	StampLoadFailed           int64
+	ReadOrderDuration         time.Duration
 }
 
 func (s *SampleStats) add(other SampleStats) {
@@ -376,6 +446,7 @@ func (s *SampleStats) add(other SampleStats) {
 	s.ChunkLoadDuration += other.ChunkLoadDuration
 	s.ChunkLoadFailed += other.ChunkLoadFailed
 	s.StampLoadFailed += other.StampLoadFailed
+	s.ReadOrderDuration += other.ReadOrderDuration
 }
 
 // RandSample returns Sample with random values.
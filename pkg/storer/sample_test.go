@@ -141,6 +141,63 @@ func TestReserveSampler(t *testing.T) {
 	})
 }
 
+func TestReserveSamplerWorkers(t *testing.T) {
+	t.Parallel()
+
+	baseAddr := swarm.RandAddress(t)
+	timeVar := uint64(time.Now().UnixNano())
+
+	var chs []swarm.Chunk
+	for po := 0; po < 8; po++ {
+		for i := 0; i < 20; i++ {
+			ch := chunk.GenerateValidRandomChunkAt(t, baseAddr, po).WithBatch(3, 2, false)
+			chs = append(chs, ch.WithStamp(postagetesting.MustNewStampWithTimestamp(timeVar-1)))
+		}
+	}
+
+	var (
+		radius uint8 = 3
+		anchor       = swarm.RandAddressAt(t, baseAddr, int(radius)).Bytes()
+	)
+
+	sample := func(t *testing.T, workers int) storer.Sample {
+		t.Helper()
+
+		opts := dbTestOps(baseAddr, 1000, nil, nil, time.Second)
+		opts.ValidStamp = func(ch swarm.Chunk) (swarm.Chunk, error) { return ch, nil }
+		opts.ReserveSampleWorkers = workers
+
+		st, err := diskStorer(t, opts)()
+		if err != nil {
+			t.Fatal(err)
+		}
+		putter := st.ReservePutter()
+		for _, ch := range chs {
+			if err := putter.Put(context.Background(), ch); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sample, err := st.ReserveSample(context.Background(), anchor, radius, timeVar, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertValidSample(t, sample, radius, anchor)
+		assertSampleNoErrors(t, sample)
+		return sample
+	}
+
+	single := sample(t, 1)
+	parallel := sample(t, 16)
+
+	if single.Stats.TotalIterated != parallel.Stats.TotalIterated {
+		t.Fatalf("iterated chunks differ: single worker %d, parallel %d", single.Stats.TotalIterated, parallel.Stats.TotalIterated)
+	}
+	if diff := cmp.Diff(single.Items, parallel.Items, cmp.AllowUnexported(postage.Stamp{})); diff != "" {
+		t.Fatalf("samples different (-single +parallel):\n%s", diff)
+	}
+}
+
 func TestReserveSamplerSisterNeighborhood(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,353 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/postage"
+	"github.com/calmw/bee-tron/pkg/soc"
+	pinstore "github.com/calmw/bee-tron/pkg/storer/internal/pinning"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+const (
+	snapshotVersion = 1
+
+	// Names of the entries of a snapshot archive. The header comes first and
+	// is followed by the reserve chunks stored as reserve/<address>/<stamp hash>
+	// and the pinned chunks stored as pinning/<root>/<address>.
+	snapshotHeaderName    = "snapshot.json"
+	snapshotReservePrefix = "reserve/"
+	snapshotPinningPrefix = "pinning/"
+
+	// maxSnapshotEntrySize bounds the size of the chunk entries read from an
+	// archive.
+	maxSnapshotEntrySize = 4 + swarm.SocMaxChunkSize + postage.StampSize
+)
+
+// ErrInvalidSnapshot is returned when a snapshot archive is malformed.
+var ErrInvalidSnapshot = errors.New("storer: invalid snapshot")
+
+// snapshotHeader is the first entry of a snapshot archive.
+type snapshotHeader struct {
+	Version       int           `json:"version"`
+	Overlay       swarm.Address `json:"overlay"`
+	StorageRadius uint8         `json:"storageRadius"`
+	CreatedAt     int64         `json:"createdAt"`
+}
+
+// SnapshotStat reports the content of an exported or imported snapshot.
+type SnapshotStat struct {
+	ReserveChunks  uint64
+	PinCollections uint64
+	PinChunks      uint64
+	// Invalid is the number of imported chunks skipped because of invalid
+	// content or postage stamps.
+	Invalid uint64
+}
+
+// ExportSnapshot writes the chunks of the reserve, together with their
+// postage stamps, and the chunks of all the pinning collections to w as a
+// tar archive which can be restored with ImportSnapshot.
+func (db *DB) ExportSnapshot(ctx context.Context, w io.Writer) (stat SnapshotStat, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("snapshot", "ExportSnapshot").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("snapshot", "ExportSnapshot", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("snapshot", "ExportSnapshot", "failure").Inc()
+		}
+	}()
+
+	tw := tar.NewWriter(w)
+
+	header, err := json.Marshal(snapshotHeader{
+		Version:       snapshotVersion,
+		Overlay:       db.baseAddr,
+		StorageRadius: db.StorageRadius(),
+		CreatedAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return stat, fmt.Errorf("snapshot: marshal header: %w", err)
+	}
+	if err := writeSnapshotEntry(tw, snapshotHeaderName, header); err != nil {
+		return stat, err
+	}
+
+	if db.reserve != nil {
+		err = db.reserve.IterateChunks(0, func(ch swarm.Chunk) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			stampHash, err := ch.Stamp().Hash()
+			if err != nil {
+				return true, fmt.Errorf("snapshot: stamp hash: %w", err)
+			}
+			data, err := marshalSnapshotChunk(ch)
+			if err != nil {
+				return true, err
+			}
+			name := snapshotReservePrefix + ch.Address().String() + "/" + hex.EncodeToString(stampHash)
+			if err := writeSnapshotEntry(tw, name, data); err != nil {
+				return true, err
+			}
+			stat.ReserveChunks++
+			return false, nil
+		})
+		if err != nil {
+			return stat, fmt.Errorf("snapshot: export reserve: %w", err)
+		}
+	}
+
+	pins, err := db.Pins()
+	if err != nil {
+		return stat, fmt.Errorf("snapshot: pins: %w", err)
+	}
+	for _, root := range pins {
+		err = pinstore.IterateCollection(db.storage.IndexStore(), root, func(addr swarm.Address) (bool, error) {
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			ch, err := db.storage.ChunkStore().Get(ctx, addr)
+			if err != nil {
+				return true, fmt.Errorf("get chunk %s: %w", addr, err)
+			}
+			data, err := marshalSnapshotChunk(ch)
+			if err != nil {
+				return true, err
+			}
+			if err := writeSnapshotEntry(tw, snapshotPinningPrefix+root.String()+"/"+addr.String(), data); err != nil {
+				return true, err
+			}
+			stat.PinChunks++
+			return false, nil
+		})
+		if err != nil {
+			return stat, fmt.Errorf("snapshot: export pinning collection %s: %w", root, err)
+		}
+		stat.PinCollections++
+	}
+
+	if err := tw.Close(); err != nil {
+		return stat, fmt.Errorf("snapshot: close archive: %w", err)
+	}
+
+	db.logger.Info("snapshot exported", "reserve_chunks", stat.ReserveChunks, "pin_collections", stat.PinCollections, "pin_chunks", stat.PinChunks)
+	return stat, nil
+}
+
+// ImportSnapshot restores a snapshot archive written by ExportSnapshot.
+// Chunks with invalid content are skipped, as are reserve chunks whose
+// postage stamps are not valid against the batch store of the node.
+func (db *DB) ImportSnapshot(ctx context.Context, r io.Reader) (stat SnapshotStat, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("snapshot", "ImportSnapshot").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("snapshot", "ImportSnapshot", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("snapshot", "ImportSnapshot", "failure").Inc()
+		}
+	}()
+
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return stat, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if hdr.Name != snapshotHeaderName {
+		return stat, fmt.Errorf("%w: missing header", ErrInvalidSnapshot)
+	}
+	var header snapshotHeader
+	if err := json.NewDecoder(tr).Decode(&header); err != nil {
+		return stat, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if header.Version != snapshotVersion {
+		return stat, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, header.Version)
+	}
+	if !header.Overlay.IsZero() && !db.baseAddr.IsZero() && !header.Overlay.Equal(db.baseAddr) {
+		db.logger.Warning("importing snapshot of another overlay", "snapshot_overlay", header.Overlay, "overlay", db.baseAddr)
+	}
+
+	collections := make(map[string]PutterSession)
+	defer func() {
+		for _, c := range collections {
+			if err := c.Cleanup(); err != nil {
+				db.logger.Debug("snapshot: pinning collection cleanup failed", "error", err)
+			}
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stat, err
+		}
+
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stat, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if hdr.Size > maxSnapshotEntrySize {
+			return stat, fmt.Errorf("%w: entry %s too large", ErrInvalidSnapshot, hdr.Name)
+		}
+		data := make([]byte, hdr.Size)
+		if _, err := io.ReadFull(tr, data); err != nil {
+			return stat, fmt.Errorf("%w: read entry %s: %w", ErrInvalidSnapshot, hdr.Name, err)
+		}
+
+		switch {
+		case strings.HasPrefix(hdr.Name, snapshotReservePrefix):
+			if db.reserve == nil {
+				return stat, fmt.Errorf("snapshot: reserve is not available")
+			}
+			parts := strings.Split(strings.TrimPrefix(hdr.Name, snapshotReservePrefix), "/")
+			if len(parts) != 2 {
+				return stat, fmt.Errorf("%w: invalid entry %s", ErrInvalidSnapshot, hdr.Name)
+			}
+			ch, err := unmarshalSnapshotChunk(parts[0], data)
+			if err != nil {
+				return stat, err
+			}
+			if ch.Stamp() == nil || !validSnapshotChunk(ch) {
+				stat.Invalid++
+				continue
+			}
+			if db.validStamp != nil {
+				if ch, err = db.validStamp(ch); err != nil {
+					db.logger.Debug("snapshot: invalid stamp", "address", parts[0], "error", err)
+					stat.Invalid++
+					continue
+				}
+			}
+			if err := db.ReservePutter().Put(ctx, ch); err != nil {
+				return stat, fmt.Errorf("snapshot: put reserve chunk %s: %w", parts[0], err)
+			}
+			stat.ReserveChunks++
+
+		case strings.HasPrefix(hdr.Name, snapshotPinningPrefix):
+			parts := strings.Split(strings.TrimPrefix(hdr.Name, snapshotPinningPrefix), "/")
+			if len(parts) != 2 {
+				return stat, fmt.Errorf("%w: invalid entry %s", ErrInvalidSnapshot, hdr.Name)
+			}
+			ch, err := unmarshalSnapshotChunk(parts[1], data)
+			if err != nil {
+				return stat, err
+			}
+			if !validSnapshotChunk(ch) {
+				stat.Invalid++
+				continue
+			}
+			collection, ok := collections[parts[0]]
+			if !ok {
+				collection, err = db.NewCollection(ctx)
+				if err != nil {
+					return stat, fmt.Errorf("snapshot: new pinning collection: %w", err)
+				}
+				collections[parts[0]] = collection
+			}
+			if err := collection.Put(ctx, ch); err != nil {
+				return stat, fmt.Errorf("snapshot: put pinned chunk %s: %w", parts[1], err)
+			}
+			stat.PinChunks++
+
+		default:
+			return stat, fmt.Errorf("%w: unknown entry %s", ErrInvalidSnapshot, hdr.Name)
+		}
+	}
+
+	for root, collection := range collections {
+		addr, err := swarm.ParseHexAddress(root)
+		if err != nil {
+			return stat, fmt.Errorf("%w: invalid root %s", ErrInvalidSnapshot, root)
+		}
+		if err := collection.Done(addr); err != nil {
+			return stat, fmt.Errorf("snapshot: pinning collection %s: %w", root, err)
+		}
+		delete(collections, root)
+		stat.PinCollections++
+	}
+
+	db.logger.Info("snapshot imported", "reserve_chunks", stat.ReserveChunks, "pin_collections", stat.PinCollections, "pin_chunks", stat.PinChunks, "invalid", stat.Invalid)
+	return stat, nil
+}
+
+func writeSnapshotEntry(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Size: int64(len(data)),
+		Mode: 0600,
+	})
+	if err != nil {
+		return fmt.Errorf("snapshot: write header of %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("snapshot: write %s: %w", name, err)
+	}
+	return nil
+}
+
+// marshalSnapshotChunk encodes the chunk as the size of its data, the data
+// and the optional postage stamp.
+func marshalSnapshotChunk(ch swarm.Chunk) ([]byte, error) {
+	buf := make([]byte, 4, 4+len(ch.Data())+postage.StampSize)
+	binary.BigEndian.PutUint32(buf, uint32(len(ch.Data())))
+	buf = append(buf, ch.Data()...)
+	if ch.Stamp() == nil {
+		return buf, nil
+	}
+	stamp, err := ch.Stamp().MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: marshal stamp: %w", err)
+	}
+	return append(buf, stamp...), nil
+}
+
+func unmarshalSnapshotChunk(address string, data []byte) (swarm.Chunk, error) {
+	addr, err := swarm.ParseHexAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid address %s", ErrInvalidSnapshot, address)
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: chunk %s too short", ErrInvalidSnapshot, address)
+	}
+	size := int(binary.BigEndian.Uint32(data))
+	data = data[4:]
+	if size > len(data) {
+		return nil, fmt.Errorf("%w: chunk %s too short", ErrInvalidSnapshot, address)
+	}
+	ch := swarm.NewChunk(addr, data[:size])
+	switch rest := data[size:]; len(rest) {
+	case 0:
+		return ch, nil
+	case postage.StampSize:
+		stamp := new(postage.Stamp)
+		if err := stamp.UnmarshalBinary(rest); err != nil {
+			return nil, fmt.Errorf("%w: chunk %s stamp: %w", ErrInvalidSnapshot, address, err)
+		}
+		return ch.WithStamp(stamp), nil
+	default:
+		return nil, fmt.Errorf("%w: chunk %s has a malformed stamp", ErrInvalidSnapshot, address)
+	}
+}
+
+func validSnapshotChunk(ch swarm.Chunk) bool {
+	return cac.Valid(ch) || soc.Valid(ch)
+}
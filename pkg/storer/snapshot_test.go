@@ -0,0 +1,117 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package storer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/postage"
+	chunktesting "github.com/calmw/bee-tron/pkg/storage/testing"
+	"github.com/calmw/bee-tron/pkg/storer"
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	baseAddr := swarm.RandAddress(t)
+
+	src, err := memStorer(t, dbTestOps(baseAddr, 100, nil, nil, time.Minute))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reserveChunks := chunktesting.GenerateTestRandomChunks(10)
+	for _, ch := range reserveChunks {
+		if err := src.ReservePutter().Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pinChunks := chunktesting.GenerateTestRandomChunks(5)
+	session, err := src.NewCollection(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range pinChunks {
+		if err := session.Put(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := pinChunks[0].Address()
+	if err := session.Done(root); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	stat, err := src.ExportSnapshot(ctx, &buf)
+	if err != nil {
+		t.Fatalf("ExportSnapshot(...): unexpected error: %v", err)
+	}
+	if want := (storer.SnapshotStat{ReserveChunks: 10, PinCollections: 1, PinChunks: 5}); stat != want {
+		t.Fatalf("ExportSnapshot(...): want %+v, have %+v", want, stat)
+	}
+
+	// the stamp of the first reserve chunk is rejected by the importing node
+	rejected := reserveChunks[0]
+	opts := dbTestOps(baseAddr, 100, nil, nil, time.Minute)
+	opts.ValidStamp = func(ch swarm.Chunk) (swarm.Chunk, error) {
+		if ch.Address().Equal(rejected.Address()) {
+			return nil, postage.ErrNotFound
+		}
+		return ch, nil
+	}
+	dst, err := memStorer(t, opts)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stat, err = dst.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ImportSnapshot(...): unexpected error: %v", err)
+	}
+	if want := (storer.SnapshotStat{ReserveChunks: 9, PinCollections: 1, PinChunks: 5, Invalid: 1}); stat != want {
+		t.Fatalf("ImportSnapshot(...): want %+v, have %+v", want, stat)
+	}
+
+	for _, ch := range reserveChunks {
+		stampHash, err := ch.Stamp().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		has, err := dst.ReserveHas(ch.Address(), ch.Stamp().BatchID(), stampHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := !ch.Address().Equal(rejected.Address()); has != want {
+			t.Fatalf("ReserveHas(%s): want %t, have %t", ch.Address(), want, has)
+		}
+	}
+
+	has, err := dst.HasPin(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !has {
+		t.Fatal("pin not imported")
+	}
+	for _, ch := range pinChunks {
+		if _, err := dst.ChunkStore().Get(ctx, ch.Address()); err != nil {
+			t.Fatalf("Get(%s): unexpected error: %v", ch.Address(), err)
+		}
+	}
+
+	t.Run("invalid archive", func(t *testing.T) {
+		_, err := dst.ImportSnapshot(ctx, bytes.NewReader([]byte("not a snapshot")))
+		if !errors.Is(err, storer.ErrInvalidSnapshot) {
+			t.Fatalf("ImportSnapshot(...): want %v, have %v", storer.ErrInvalidSnapshot, err)
+		}
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"io/fs"
 	"math/big"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -42,8 +43,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/afero"
 	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/opt"
 	"resenje.org/multex"
 )
 
@@ -78,6 +77,15 @@ type UploadStore interface {
 	DeleteSession(tagID uint64) error
 	// ListSessions will list all the Sessions currently being tracked.
 	ListSessions(offset, limit int) ([]SessionInfo, error)
+	// IncompleteSessions lists the sessions with chunks not yet synced and the
+	// sessions whose upload was never finished.
+	IncompleteSessions() ([]SessionStat, error)
+	// ResumeSession hands over the chunks of an unfinished session to the
+	// pusher.
+	ResumeSession(tagID uint64) error
+	// PurgeSession removes the session together with its chunks not yet
+	// synced and returns the number of removed chunks.
+	PurgeSession(tagID uint64) (uint64, error)
 }
 
 // PinStore is a logical component of the storer which deals with pinning
@@ -95,6 +103,16 @@ type PinStore interface {
 	// HasPin is a helper which checks if a collection exists with the root
 	// reference passed in.
 	HasPin(swarm.Address) (bool, error)
+	// PinsUsage returns the disk usage of the pinning collections.
+	PinsUsage() ([]PinUsage, error)
+	// PinQuota returns the pinning quota in bytes, zero if unlimited, and the
+	// size of the pinned chunks.
+	PinQuota() (quota, used uint64)
+	// VerifyPin checks that all the chunks of the pinning collection are
+	// stored, readable and valid, reporting the progress after each chunk.
+	// If repair is set, the missing and invalid chunks are retrieved from the
+	// network and stored again.
+	VerifyPin(ctx context.Context, root swarm.Address, repair bool, progress func(PinVerification)) (PinVerification, error)
 }
 
 // PinIterator is a helper interface which can be used to iterate over all the
@@ -201,6 +219,10 @@ func (d *dirFS) Open(path string) (fs.File, error) {
 var sharkyNoOfShards = 32
 var ErrDBQuit = errors.New("db quit")
 
+// ErrMigrationDryRun is returned by New once the plans of the pending
+// migrations are logged in the migration dry run mode.
+var ErrMigrationDryRun = errors.New("migration dry run")
+
 type closerFn func() error
 
 func (c closerFn) Close() error { return c() }
@@ -242,38 +264,16 @@ const (
 	defaultBlockCacheCapacity     = uint64(32 * 1024 * 1024)
 	defaultWriteBufferSize        = uint64(32 * 1024 * 1024)
 	defaultDisableSeeksCompaction = false
+	defaultBloomFilterBits        = 64
 	defaultCacheCapacity          = uint64(1_000_000)
 	defaultBgCacheWorkers         = 128
 	DefaultReserveCapacity        = 1 << 22 // 4194304 chunks
 
-	indexPath  = "indexstore"
-	sharkyPath = "sharky"
+	indexPath       = "indexstore"
+	pebbleIndexPath = "indexstore-pebble"
+	sharkyPath      = "sharky"
 )
 
-func initStore(basePath string, opts *Options) (*leveldbstore.Store, error) {
-	ldbBasePath := path.Join(basePath, indexPath)
-
-	if _, err := os.Stat(ldbBasePath); os.IsNotExist(err) {
-		err := os.MkdirAll(ldbBasePath, 0777)
-		if err != nil {
-			return nil, err
-		}
-	}
-	store, err := leveldbstore.New(path.Join(basePath, "indexstore"), &opt.Options{
-		OpenFilesCacheCapacity: int(opts.LdbOpenFilesLimit),
-		BlockCacheCapacity:     int(opts.LdbBlockCacheCapacity),
-		WriteBuffer:            int(opts.LdbWriteBufferSize),
-		DisableSeeksCompaction: opts.LdbDisableSeeksCompaction,
-		CompactionL0Trigger:    8,
-		Filter:                 filter.NewBloomFilter(64),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed creating levelDB index store: %w", err)
-	}
-
-	return store, nil
-}
-
 func initDiskRepository(
 	ctx context.Context,
 	basePath string,
@@ -281,15 +281,21 @@ func initDiskRepository(
 ) (transaction.Storage, *PinIntegrity, io.Closer, error) {
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed creating levelDB index store: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed creating index store: %w", err)
 	}
 
-	err = migration.Migrate(store, "core-migration", localmigration.BeforeInitSteps(store, opts.Logger))
+	err = migration.MigrateWithProgress(
+		store,
+		"core-migration",
+		localmigration.BeforeInitSteps(store, opts.Logger, opts.MigrationProgress),
+		localmigration.BeforeInitPlans(store),
+		opts.MigrationProgress,
+	)
 	if err != nil {
 		return nil, nil, nil, errors.Join(store.Close(), fmt.Errorf("failed core migration: %w", err))
 	}
 
-	if opts.LdbStats.Load() != nil {
+	if ldbStore, ok := store.(*leveldbstore.Store); ok && opts.LdbStats.Load() != nil {
 		go func() {
 			ldbStats := opts.LdbStats.Load()
 			logger := log.NewLogger(loggerName).Register()
@@ -302,7 +308,7 @@ func initDiskRepository(
 					return
 				case <-ticker.C:
 					stats := new(leveldb.DBStats)
-					switch err := store.DB().Stats(stats); {
+					switch err := ldbStore.DB().Stats(stats); {
 					case errors.Is(err, leveldb.ErrClosed):
 						return
 					case err != nil:
@@ -366,14 +372,39 @@ func initDiskRepository(
 
 const lockKeyNewSession string = "new_session"
 
+// Cache eviction policies.
+const (
+	CacheLRUPolicy   = cache.LRUPolicy
+	CacheCLOCKPolicy = cache.CLOCKPolicy
+	CacheSLRUPolicy  = cache.SLRUPolicy
+)
+
 // Options provides a container to configure different things in the storer.
 type Options struct {
-	// These are options related to levelDB. Currently, the underlying storage used is levelDB.
+	// IndexStore selects the key-value backend of the index store,
+	// LevelDBIndexStore if empty.
+	IndexStore string
+
+	// These are options of the pebble index store, the size of its block
+	// cache and memtable and its open files limit, which fall back to their
+	// defaults if zero.
+	PebbleCacheCapacity  uint64
+	PebbleMemTableSize   uint64
+	PebbleOpenFilesLimit uint64
+
+	// These are options related to levelDB. The bloom filter bits per key,
+	// the table size and total size of the first level triggering
+	// compactions and the block compression, snappy or none, fall back to
+	// their defaults if zero.
 	LdbStats                  atomic.Pointer[prometheus.HistogramVec]
 	LdbOpenFilesLimit         uint64
 	LdbBlockCacheCapacity     uint64
 	LdbWriteBufferSize        uint64
 	LdbDisableSeeksCompaction bool
+	LdbBloomFilterBits        int
+	LdbCompactionTableSize    uint64
+	LdbCompactionTotalSize    uint64
+	LdbCompression            string
 	Logger                    log.Logger
 	Tracer                    *tracing.Tracer
 
@@ -388,11 +419,54 @@ type Options struct {
 	ReserveWakeUpDuration   time.Duration
 	ReserveMinEvictCount    uint64
 	ReserveCapacityDoubling int
+	// ReserveSampleWorkers is the number of workers loading and hashing the
+	// chunks of a reserve sample, the number of CPUs but at least 4 if zero.
+	ReserveSampleWorkers int
 
 	CacheCapacity      uint64
 	CacheMinEvictCount uint64
+	// CacheCapacityBytes limits the total size of the cached chunks in
+	// addition to CacheCapacity, no limit if zero.
+	CacheCapacityBytes uint64
+	// CacheEvictionPolicy is the name of the cache eviction policy, LRU if
+	// empty.
+	CacheEvictionPolicy string
 
 	MinimumStorageRadius uint
+	// RadiusOverride pins the storage radius or offsets it from the
+	// automatic radius.
+	RadiusOverride RadiusOverride
+
+	// PinningQuota limits the total size of the pinned chunks in bytes, no
+	// limit if zero.
+	PinningQuota uint64
+
+	// SharkyCompactionInterval is the period of the background sharky
+	// compaction rounds, compaction is disabled if zero.
+	SharkyCompactionInterval time.Duration
+	// SharkyCompactionThrottle is the pause taken before each chunk
+	// relocation of a compaction round.
+	SharkyCompactionThrottle time.Duration
+
+	// RemoteGetter, if set, is a cache tier queried for the chunks missing
+	// locally before retrieving them from the network, for example a
+	// GatewayGetter.
+	RemoteGetter storage.Getter
+
+	// UsageForecastInterval is the period of the usage samples the forecasts
+	// of the storage components are based on, usage is not sampled if zero.
+	UsageForecastInterval time.Duration
+	// UsageAlertWebhook, if set, is POSTed a UsageAlert when a storage
+	// component is projected to fill up within UsageAlertThreshold.
+	UsageAlertWebhook string
+	// UsageAlertThreshold is the time to full under which the usage alerts
+	// are fired.
+	UsageAlertThreshold time.Duration
+
+	// MigrationProgress, if set, tracks the progress of the index store
+	// migrations. In its dry run mode New returns ErrMigrationDryRun after
+	// logging the plans of the pending migrations.
+	MigrationProgress *migration.Progress
 }
 
 func defaultOptions() *Options {
@@ -427,6 +501,7 @@ type DB struct {
 	multex              *multex.Multex
 	cacheObj            *cache.Cache
 	retrieval           retrieval.Interface
+	remoteGetter        storage.Getter
 	pusherFeed          chan *pusher.Op
 	quit                chan struct{}
 	cacheLimiter        cacheLimiter
@@ -445,7 +520,19 @@ type DB struct {
 	syncer           Syncer
 	reserveOptions   reserveOpts
 
+	compactionOptions compactionOpts
+	usageOptions      usageOpts
+
+	pinQuota     *pinstore.Quota
 	pinIntegrity *PinIntegrity
+
+	cacheEvictionPause   evictionPause
+	reserveEvictionPause evictionPause
+
+	usageHistory usageHistory
+	radius       radiusState
+
+	sampling atomic.Int32 // number of reserve samples in progress
 }
 
 type reserveOpts struct {
@@ -455,6 +542,7 @@ type reserveOpts struct {
 	cacheMinEvictCount uint64
 	minimumRadius      uint8
 	capacityDoubling   int
+	sampleWorkers      int
 }
 
 // New returns a newly constructed DB object which implements all the above
@@ -502,17 +590,31 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 	}
 
 	err = st.Run(ctx, func(s transaction.Store) error {
-		return migration.Migrate(
+		return migration.MigrateWithProgress(
 			s.IndexStore(),
 			"migration",
-			localmigration.AfterInitSteps(sharkyBasePath, sharkyNoOfShards, st, opts.Logger),
+			localmigration.AfterInitSteps(sharkyBasePath, sharkyNoOfShards, st, opts.Logger, opts.MigrationProgress),
+			localmigration.AfterInitPlans(s.IndexStore()),
+			opts.MigrationProgress,
 		)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed regular migration: %w", err)
 	}
+	if opts.MigrationProgress.DryRun() {
+		err = ErrMigrationDryRun
+		return nil, err
+	}
 
-	cacheObj, err := cache.New(ctx, st.IndexStore(), opts.CacheCapacity)
+	policy, err := cache.NewPolicy(opts.CacheEvictionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheObj, err := cache.New(ctx, st.IndexStore(), opts.CacheCapacity,
+		cache.WithPolicy(policy),
+		cache.WithCapacityBytes(opts.CacheCapacityBytes),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +641,7 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		dbCloser:         dbCloser,
 		batchstore:       opts.Batchstore,
 		validStamp:       opts.ValidStamp,
+		remoteGetter:     opts.RemoteGetter,
 		events:           events.NewSubscriber(),
 		reserveBinEvents: events.NewSubscriber(),
 		reserveOptions: reserveOpts{
@@ -548,6 +651,17 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 			cacheMinEvictCount: opts.CacheMinEvictCount,
 			minimumRadius:      uint8(opts.MinimumStorageRadius),
 			capacityDoubling:   opts.ReserveCapacityDoubling,
+			sampleWorkers:      opts.ReserveSampleWorkers,
+		},
+		compactionOptions: compactionOpts{
+			interval: opts.SharkyCompactionInterval,
+			throttle: opts.SharkyCompactionThrottle,
+		},
+		usageOptions: usageOpts{
+			interval:       opts.UsageForecastInterval,
+			webhookURL:     opts.UsageAlertWebhook,
+			alertThreshold: opts.UsageAlertThreshold,
+			client:         &http.Client{Timeout: usageWebhookTimeout},
 		},
 		directUploadLimiter: make(chan struct{}, pusher.ConcurrentPushes),
 		pinIntegrity:        pinIntegrity,
@@ -557,6 +671,8 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		db.validStamp = postage.ValidStamp(db.batchstore)
 	}
 
+	db.initEvictionPauses()
+
 	if opts.ReserveCapacity > 0 {
 		rs, err := reserve.New(
 			opts.Address,
@@ -570,10 +686,14 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		}
 		db.reserve = rs
 
+		if err := db.initRadius(opts.RadiusOverride); err != nil {
+			return nil, err
+		}
 		db.metrics.StorageRadius.Set(float64(rs.Radius()))
 		db.metrics.ReserveSize.Set(float64(rs.Size()))
 	}
 	db.metrics.CacheSize.Set(float64(db.cacheObj.Size()))
+	db.metrics.CacheSizeBytes.Set(float64(db.cacheObj.SizeBytes()))
 
 	// Cleanup any dirty state in upload and pinning stores, this could happen
 	// in case of dirty shutdowns
@@ -585,9 +705,24 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		return nil, err
 	}
 
+	db.pinQuota, err = pinstore.NewQuota(db.storage.IndexStore(), opts.PinningQuota)
+	if err != nil {
+		return nil, err
+	}
+
 	db.inFlight.Add(1)
 	go db.cacheWorker(ctx)
 
+	if db.compactionOptions.interval > 0 && db.pinIntegrity != nil {
+		db.inFlight.Add(1)
+		go db.sharkyCompactionWorker(ctx)
+	}
+
+	if db.usageOptions.interval > 0 {
+		db.inFlight.Add(1)
+		go db.usageForecastWorker(ctx)
+	}
+
 	return db, nil
 }
 
@@ -609,6 +744,7 @@ func (db *DB) Metrics() []prometheus.Collector {
 func (db *DB) StatusMetrics() []prometheus.Collector {
 	collectors := []prometheus.Collector{
 		db.metrics.MethodCallsDuration,
+		db.metrics.NamespaceOperationDuration,
 	}
 
 	type Collector interface {
@@ -708,8 +844,18 @@ func (db *DB) Storage() transaction.Storage {
 
 type putterSession struct {
 	storage.Putter
-	done    func(swarm.Address) error
-	cleanup func() error
+	putBatch func(context.Context, []swarm.Chunk) (int, error)
+	done     func(swarm.Address) error
+	cleanup  func() error
+}
+
+// PutBatch implements the storage.BatchPutter interface. The chunks are put
+// one by one if the session does not support batches.
+func (p *putterSession) PutBatch(ctx context.Context, chunks []swarm.Chunk) (int, error) {
+	if p.putBatch == nil {
+		return storage.PutBatch(ctx, p.Putter, chunks)
+	}
+	return p.putBatch(ctx, chunks)
 }
 
 func (p *putterSession) Done(addr swarm.Address) error { return p.done(addr) }
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	storage "github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/storer/internal"
@@ -56,7 +57,7 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 		}
 
 		if pin {
-			pinningPutter, err = pinstore.NewCollection(s.IndexStore())
+			pinningPutter, err = pinstore.NewCollection(s.IndexStore(), db.pinQuota)
 			if err != nil {
 				return fmt.Errorf("pinstore.NewCollection: %w", err)
 			}
@@ -90,6 +91,41 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 			db.metrics,
 			"uploadstore",
 		},
+		putBatch: func(ctx context.Context, chunks []swarm.Chunk) (n int, err error) {
+			dur := captureDuration(time.Now())
+			defer func() {
+				db.metrics.MethodCallsDuration.WithLabelValues("uploadstore", "PutBatch").Observe(dur())
+				db.metrics.observePut("uploadstore", n, dur(), err)
+				if err == nil {
+					db.metrics.MethodCalls.WithLabelValues("uploadstore", "PutBatch", "success").Inc()
+				} else {
+					db.metrics.MethodCalls.WithLabelValues("uploadstore", "PutBatch", "failure").Inc()
+				}
+			}()
+
+			unlock := db.Lock(uploadsLock)
+			defer unlock()
+
+			// the chunks and their upload and pinning index entries are
+			// stored in a single commit
+			err = db.storage.Run(ctx, func(s transaction.Store) error {
+				for _, chunk := range chunks {
+					if err := uploadPutter.Put(ctx, s, chunk); err != nil {
+						return err
+					}
+					if pinningPutter != nil {
+						if err := pinningPutter.Put(ctx, s, chunk); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return 0, err
+			}
+			return len(chunks), nil
+		},
 		done: func(address swarm.Address) error {
 			defer db.events.Trigger(subscribePushEventKey)
 			unlock := db.Lock(uploadsLock)
@@ -174,3 +210,110 @@ func (db *DB) ListSessions(offset, limit int) ([]SessionInfo, error) {
 
 	return tags[min(offset, len(tags)):min(offset+limit, len(tags))], nil
 }
+
+// SessionStat is the state of an upload session which is not complete.
+type SessionStat struct {
+	SessionInfo
+	// Pending is the number of chunks not yet synced.
+	Pending uint64
+	// Dirty is set if the upload of the session was never finished, either
+	// because it is in progress or because it was aborted.
+	Dirty bool
+	// Deleted is set if the session was deleted while its chunks are still
+	// pending. Only the TagID of the SessionInfo is known in this case.
+	Deleted bool
+}
+
+// IncompleteSessions is the implementation of the UploadStore.IncompleteSessions method.
+func (db *DB) IncompleteSessions() ([]SessionStat, error) {
+	unlock := db.Lock(uploadsLock)
+	defer unlock()
+
+	pending, err := upload.PendingTags(db.storage.IndexStore())
+	if err != nil {
+		return nil, err
+	}
+	dirty, err := upload.DirtyTags(db.storage.IndexStore())
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]SessionStat, 0, len(pending)+len(dirty))
+	add := func(tagID uint64) error {
+		_, isDirty := dirty[tagID]
+		stat := SessionStat{Pending: pending[tagID], Dirty: isDirty}
+		info, err := upload.TagInfo(db.storage.IndexStore(), tagID)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			stat.TagID = tagID
+			stat.Deleted = true
+		case err != nil:
+			return err
+		default:
+			stat.SessionInfo = info
+		}
+		stats = append(stats, stat)
+		return nil
+	}
+	for tagID := range pending {
+		if err := add(tagID); err != nil {
+			return nil, err
+		}
+	}
+	for tagID := range dirty {
+		if _, ok := pending[tagID]; ok {
+			continue
+		}
+		if err := add(tagID); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].TagID < stats[j].TagID
+	})
+
+	return stats, nil
+}
+
+// ResumeSession is the implementation of the UploadStore.ResumeSession method.
+func (db *DB) ResumeSession(tagID uint64) error {
+	unlock := db.Lock(uploadsLock)
+	defer unlock()
+
+	pending, err := upload.PendingTags(db.storage.IndexStore())
+	if err != nil {
+		return err
+	}
+	if pending[tagID] == 0 {
+		return fmt.Errorf("storer: no pending chunks of session %d: %w", tagID, storage.ErrNotFound)
+	}
+
+	err = db.storage.Run(context.Background(), func(s transaction.Store) error {
+		return upload.ResumeTag(s.IndexStore(), tagID)
+	})
+	if err != nil {
+		return err
+	}
+
+	db.events.Trigger(subscribePushEventKey)
+	return nil
+}
+
+// PurgeSession is the implementation of the UploadStore.PurgeSession method.
+func (db *DB) PurgeSession(tagID uint64) (uint64, error) {
+	unlock := db.Lock(uploadsLock)
+	defer unlock()
+
+	pending, err := upload.PendingTags(db.storage.IndexStore())
+	if err != nil {
+		return 0, err
+	}
+	if pending[tagID] == 0 {
+		if _, err := upload.TagInfo(db.storage.IndexStore(), tagID); err != nil {
+			return 0, err
+		}
+	}
+
+	return upload.PurgeTag(db.storage, tagID)
+}
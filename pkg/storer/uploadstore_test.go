@@ -448,3 +448,107 @@ func TestReporter(t *testing.T) {
 		testReporter(t, diskStorer(t, opts))
 	})
 }
+
+func TestIncompleteSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	lstore, err := memStorer(t, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second))()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upload := func(n int, done bool) (storer.SessionInfo, []swarm.Chunk) {
+		t.Helper()
+
+		session, err := lstore.NewSession()
+		if err != nil {
+			t.Fatalf("NewSession(): unexpected error: %v", err)
+		}
+		putter, err := lstore.Upload(ctx, false, session.TagID)
+		if err != nil {
+			t.Fatalf("Upload(...): unexpected error: %v", err)
+		}
+		chunks := chunktesting.GenerateTestRandomChunks(n)
+		for _, ch := range chunks {
+			if err := putter.Put(ctx, ch); err != nil {
+				t.Fatalf("Put(...): unexpected error: %v", err)
+			}
+		}
+		if done {
+			if err := putter.Done(swarm.ZeroAddress); err != nil {
+				t.Fatalf("Done(...): unexpected error: %v", err)
+			}
+		}
+		return session, chunks
+	}
+
+	aborted, _ := upload(3, false)
+	unsynced, unsyncedChunks := upload(2, true)
+	empty, _ := upload(0, true)
+
+	assertSessions := func(t *testing.T, want map[uint64]storer.SessionStat) {
+		t.Helper()
+
+		stats, err := lstore.IncompleteSessions()
+		if err != nil {
+			t.Fatalf("IncompleteSessions(): unexpected error: %v", err)
+		}
+		if len(stats) != len(want) {
+			t.Fatalf("IncompleteSessions(): want %d sessions, have %d", len(want), len(stats))
+		}
+		for _, have := range stats {
+			w, ok := want[have.TagID]
+			if !ok || have.Pending != w.Pending || have.Dirty != w.Dirty || have.Deleted != w.Deleted {
+				t.Fatalf("IncompleteSessions(): unexpected session %+v", have)
+			}
+		}
+	}
+
+	assertSessions(t, map[uint64]storer.SessionStat{
+		aborted.TagID:  {Pending: 3, Dirty: true},
+		unsynced.TagID: {Pending: 2},
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		if err := lstore.ResumeSession(aborted.TagID); err != nil {
+			t.Fatalf("ResumeSession(...): unexpected error: %v", err)
+		}
+		if err := lstore.ResumeSession(empty.TagID); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("ResumeSession(...): want error %v, have %v", storage.ErrNotFound, err)
+		}
+		assertSessions(t, map[uint64]storer.SessionStat{
+			aborted.TagID:  {Pending: 3},
+			unsynced.TagID: {Pending: 2},
+		})
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		purged, err := lstore.PurgeSession(unsynced.TagID)
+		if err != nil {
+			t.Fatalf("PurgeSession(...): unexpected error: %v", err)
+		}
+		if purged != 2 {
+			t.Fatalf("PurgeSession(...): want %d purged chunks, have %d", 2, purged)
+		}
+		if _, err := lstore.Session(unsynced.TagID); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("Session(...): want error %v, have %v", storage.ErrNotFound, err)
+		}
+		for _, ch := range unsyncedChunks {
+			has, err := lstore.Storage().ChunkStore().Has(ctx, ch.Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if has {
+				t.Fatalf("chunk %s not purged", ch.Address())
+			}
+		}
+		assertSessions(t, map[uint64]storer.SessionStat{
+			aborted.TagID: {Pending: 3},
+		})
+
+		if _, err := lstore.PurgeSession(1000); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("PurgeSession(...): want error %v, have %v", storage.ErrNotFound, err)
+		}
+	})
+}
@@ -31,7 +31,7 @@ func ValidateReserve(ctx context.Context, basePath string, opts *Options) error
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -52,19 +52,26 @@ func ValidateReserve(ctx context.Context, basePath string, opts *Options) error
 
 	logger.Info("performing chunk validation")
 
-	validateWork(logger, store, sharky.Read)
+	validateWork(logger, store, sharky.Read, storage.Cursor{})
 
 	return nil
 }
 
 // ValidateRetrievalIndex ensures that all retrievalIndex chunks are correctly stored in sharky.
-func ValidateRetrievalIndex(ctx context.Context, basePath string, opts *Options) error {
+// The validation starts after the cursor logged by an interrupted validation, or from the
+// beginning if the cursor is empty.
+func ValidateRetrievalIndex(ctx context.Context, basePath, cursor string, opts *Options) error {
+
+	c, err := storage.ParseCursor(cursor)
+	if err != nil {
+		return err
+	}
 
 	logger := opts.Logger
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
@@ -83,13 +90,15 @@ func ValidateRetrievalIndex(ctx context.Context, basePath string, opts *Options)
 		}
 	}()
 
-	logger.Info("performing chunk validation")
-	validateWork(logger, store, sharky.Read)
+	logger.Info("performing chunk validation", "cursor", cursor)
+	validateWork(logger, store, sharky.Read, c)
 
 	return nil
 }
 
-func validateWork(logger log.Logger, store storage.Store, readFn func(context.Context, sharky.Location, []byte) error) {
+// validateWork validates the chunks of the retrieval index after the cursor.
+// The logged cursor is the one of the last chunk handed over for validation.
+func validateWork(logger log.Logger, store storage.Store, readFn func(context.Context, sharky.Location, []byte) error, cursor storage.Cursor) {
 
 	total := 0
 	socCount := 0
@@ -97,7 +106,7 @@ func validateWork(logger log.Logger, store storage.Store, readFn func(context.Co
 
 	n := time.Now()
 	defer func() {
-		logger.Info("validation finished", "duration", time.Since(n), "invalid", invalidCount, "soc", socCount, "total", total)
+		logger.Info("validation finished", "duration", time.Since(n), "invalid", invalidCount, "soc", socCount, "total", total, "cursor", cursor.String())
 	}()
 
 	iteratateItemsC := make(chan *chunkstore.RetrievalIndexItem)
@@ -145,9 +154,9 @@ func validateWork(logger log.Logger, store storage.Store, readFn func(context.Co
 
 	s := time.Now()
 
-	_ = chunkstore.IterateItems(store, func(item *chunkstore.RetrievalIndexItem) error {
+	_, _ = chunkstore.IterateItemsFrom(store, cursor, func(*chunkstore.RetrievalIndexItem, storage.Cursor) (bool, error) {
 		total++
-		return nil
+		return false, nil
 	})
 	logger.Info("validation count finished", "duration", time.Since(s), "total", total)
 
@@ -165,13 +174,13 @@ func validateWork(logger log.Logger, store storage.Store, readFn func(context.Co
 	}
 
 	count := 0
-	_ = chunkstore.IterateItems(store, func(item *chunkstore.RetrievalIndexItem) error {
+	cursor, _ = chunkstore.IterateItemsFrom(store, cursor, func(item *chunkstore.RetrievalIndexItem, c storage.Cursor) (bool, error) {
 		iteratateItemsC <- item
 		count++
 		if count%100_000 == 0 {
-			logger.Info("..still validating chunks", "count", count, "invalid", invalidCount, "soc", socCount, "total", total, "percent", fmt.Sprintf("%.2f", (float64(count)*100.0)/float64(total)))
+			logger.Info("..still validating chunks", "count", count, "invalid", invalidCount, "soc", socCount, "total", total, "percent", fmt.Sprintf("%.2f", (float64(count)*100.0)/float64(total)), "cursor", c.String())
 		}
-		return nil
+		return false, nil
 	})
 
 	close(iteratateItemsC)
@@ -186,7 +195,7 @@ func ValidatePinCollectionChunks(ctx context.Context, basePath, pin, location st
 
 	store, err := initStore(basePath, opts)
 	if err != nil {
-		return fmt.Errorf("failed creating levelDB index store: %w", err)
+		return fmt.Errorf("failed creating index store: %w", err)
 	}
 	defer func() {
 		if err := store.Close(); err != nil {
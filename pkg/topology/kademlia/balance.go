@@ -0,0 +1,79 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology/pslice"
+)
+
+// BalancingStrategy decides how many peers are kept in every bin before the
+// bin is considered oversaturated and pruned.
+type BalancingStrategy string
+
+const (
+	// BalancingUniform keeps the same number of peers in every bin.
+	BalancingUniform BalancingStrategy = "uniform"
+	// BalancingClosestHeavy keeps fewer peers in the shallow bins and adds
+	// a peer for every bin closer to the node, favoring the bins which
+	// serve the most of the forwarding to the neighborhood.
+	BalancingClosestHeavy BalancingStrategy = "closest-heavy"
+)
+
+// Default balancing option values.
+const (
+	DefaultSaturationPeers   = defaultSaturationPeers
+	DefaultOverbookingFactor = float64(defaultOverSaturationPeers) / defaultSaturationPeers
+	DefaultPruneInterval     = defaultPruneWakeup
+	DefaultBalancingStrategy = BalancingUniform
+)
+
+// ParseBalancingStrategy returns the balancing strategy with the given name.
+func ParseBalancingStrategy(s string) (BalancingStrategy, error) {
+	switch v := BalancingStrategy(s); v {
+	case BalancingUniform, BalancingClosestHeavy:
+		return v, nil
+	}
+	return "", fmt.Errorf("unknown balancing strategy %q", s)
+}
+
+// binLimitFunc returns the number of peers above which the bin is
+// oversaturated.
+type binLimitFunc func(bin uint8) int
+
+// binLimit returns the bin limit of the strategy for the given
+// oversaturation amount, which the saturation peers are never pruned below.
+func (s BalancingStrategy) binLimit(oversaturationAmount, saturationPeers int) binLimitFunc {
+	if s == BalancingClosestHeavy {
+		return func(bin uint8) int {
+			return max(saturationPeers, oversaturationAmount/2+int(bin))
+		}
+	}
+	return func(uint8) int {
+		return oversaturationAmount
+	}
+}
+
+// overbookedPeers returns the oversaturation amount for the overbooking
+// factor, which is never fewer than the saturation peers.
+func overbookedPeers(saturationPeers int, factor float64) int {
+	return max(saturationPeers, int(math.Round(float64(saturationPeers)*factor)))
+}
+
+// binSize returns the number of the connected peers in the bin which are
+// neither excluded nor static.
+func binSize(bin uint8, connected *pslice.PSlice, exclude peerExcludeFunc, staticNode staticPeerFunc) int {
+	size := 0
+	_ = connected.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
+		if po == bin && !exclude(addr) && !staticNode(addr) {
+			size++
+		}
+		return false, false, nil
+	})
+	return size
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	im "github.com/calmw/bee-tron/pkg/topology/kademlia/internal/metrics"
+)
+
+// Default misbehavior escalation option values.
+const (
+	DefaultMisbehaviorThreshold      = 10.0
+	DefaultMisbehaviorBlockDuration  = 30 * time.Minute
+	DefaultMisbehaviorPermanentAfter = 3
+)
+
+// maxEscalationShift limits the doubling of the blocklisting duration.
+const maxEscalationShift = 16
+
+// escalationPolicy converts the accumulated misbehavior of the peers into
+// blocklisting. Every time the decayed misbehavior of a peer reaches the
+// threshold the peer is blocklisted for twice as long as the previous time,
+// and permanently once it has been blocklisted permanentAfter times. The
+// offences are counted since the node start.
+type escalationPolicy struct {
+	threshold      float64
+	duration       time.Duration
+	permanentAfter int
+
+	mu       sync.Mutex
+	offences map[string]int
+}
+
+func newEscalationPolicy(threshold float64, duration time.Duration, permanentAfter int) *escalationPolicy {
+	return &escalationPolicy{
+		threshold:      threshold,
+		duration:       duration,
+		permanentAfter: permanentAfter,
+		offences:       make(map[string]int),
+	}
+}
+
+// escalate records the offence of the peer and returns the blocklisting
+// duration, which is zero for the permanent blocklisting.
+func (p *escalationPolicy) escalate(peer swarm.Address) time.Duration {
+	p.offences[peer.ByteString()]++
+	n := p.offences[peer.ByteString()]
+
+	if p.permanentAfter > 0 && n > p.permanentAfter {
+		return 0
+	}
+	shift := min(n-1, maxEscalationShift)
+	if p.duration > math.MaxInt64>>shift {
+		return math.MaxInt64
+	}
+	return p.duration << shift
+}
+
+// escalateMisbehavior blocklists the peer if its misbehavior reached the
+// threshold of the escalation policy. The misbehavior is compared as it was
+// recorded at the time t of the report, before it decayed any further.
+func (k *Kad) escalateMisbehavior(peer swarm.Address, reason string, t time.Time) {
+	p := k.escalation
+	if p.threshold <= 0 || k.staticPeer(peer) {
+		return
+	}
+
+	p.mu.Lock()
+	ss := k.collector.Snapshot(t, peer)[peer.ByteString()]
+	if ss == nil || ss.Misbehavior < p.threshold {
+		p.mu.Unlock()
+		return
+	}
+	duration := p.escalate(peer)
+	// the peer starts over once the blocklisting expires
+	k.collector.Record(peer, im.ResetMisbehavior())
+	p.mu.Unlock()
+
+	if err := k.p2p.Blocklist(peer, duration, "misbehavior: "+reason); err != nil {
+		k.logger.Debug("blocklisting misbehaving peer failed", "peer_address", peer, "error", err)
+		return
+	}
+	k.metrics.MisbehaviorBlocklists.Inc()
+	k.logger.Debug("misbehaving peer blocklisted", "peer_address", peer, "reason", reason, "duration", duration, "permanent", duration == 0)
+}
@@ -5,6 +5,8 @@
 package kademlia
 
 import (
+	"time"
+
 	"github.com/calmw/bee-tron/pkg/swarm"
 	"github.com/calmw/bee-tron/pkg/topology"
 	"github.com/calmw/bee-tron/pkg/topology/pslice"
@@ -19,7 +21,6 @@ var (
 
 const (
 	DefaultBitSuffixLength     = defaultBitSuffixLength
-	DefaultSaturationPeers     = defaultSaturationPeers
 	DefaultOverSaturationPeers = defaultOverSaturationPeers
 )
 
@@ -94,6 +95,27 @@ func closestPeer(peers *pslice.PSlice, addr swarm.Address) (swarm.Address, error
 	return closest, nil
 }
 
+func (k *Kad) IsStaticPeer(addr swarm.Address) bool {
+	return k.staticPeer(addr)
+}
+
 func (k *Kad) Trigger() {
 	k.manageC <- struct{}{}
 }
+
+func (k *Kad) SnapshotMetrics(t time.Time) error {
+	return k.snapshotMetrics(t)
+}
+
+// EscalationDuration returns the blocklisting duration of the given offence
+// of a peer under a policy with the base duration and no permanent
+// blocklisting.
+func EscalationDuration(duration time.Duration, offence int) time.Duration {
+	p := newEscalationPolicy(1, duration, 0)
+	peer := swarm.NewAddress(make([]byte, swarm.HashSize))
+	var d time.Duration
+	for i := 0; i < offence; i++ {
+		d = p.escalate(peer)
+	}
+	return d
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	im "github.com/calmw/bee-tron/pkg/topology/kademlia/internal/metrics"
+)
+
+// Default health check option values.
+const (
+	DefaultHealthCheckInterval         = 2 * time.Minute
+	DefaultHealthCheckTimeout          = 10 * time.Second
+	DefaultHealthCheckFailureThreshold = 3
+)
+
+// healthCheckWorkers is the number of peers pinged concurrently.
+const healthCheckWorkers = 16
+
+// Pinger measures the round trip time to a connected peer.
+type Pinger interface {
+	Ping(ctx context.Context, address swarm.Address, msgs ...string) (rtt time.Duration, err error)
+}
+
+// healthFailures counts the consecutive failed health checks of the peers.
+type healthFailures struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newHealthFailures() *healthFailures {
+	return &healthFailures{counts: make(map[string]int)}
+}
+
+// inc increments and returns the number of consecutive failures of the peer.
+func (h *healthFailures) inc(peer swarm.Address) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[peer.ByteString()]++
+	return h.counts[peer.ByteString()]
+}
+
+func (h *healthFailures) reset(peer swarm.Address) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.counts, peer.ByteString())
+}
+
+// manageHealthCheck pings the connected peers in the health check interval
+// until the kademlia is halted or closed. It does nothing without a pinger or
+// if the interval is not positive.
+func (k *Kad) manageHealthCheck(ctx context.Context) {
+	defer k.wg.Done()
+
+	if k.opt.Pinger == nil || k.opt.HealthCheckInterval <= 0 {
+		return
+	}
+
+	for {
+		select {
+		case <-k.halt:
+			return
+		case <-k.quit:
+			return
+		case <-time.After(k.opt.HealthCheckInterval):
+		}
+
+		k.healthCheck(ctx)
+	}
+}
+
+// healthCheck pings all connected peers.
+func (k *Kad) healthCheck(ctx context.Context) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, healthCheckWorkers)
+	)
+	_ = k.connectedPeers.EachBin(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		select {
+		case <-k.quit:
+			return true, false, nil
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			k.checkPeerHealth(ctx, addr)
+		}()
+		return false, false, nil
+	})
+	wg.Wait()
+}
+
+// checkPeerHealth pings the peer and records its latency. The peer is marked
+// unhealthy once it fails the configured number of consecutive checks.
+func (k *Kad) checkPeerHealth(ctx context.Context, peer swarm.Address) {
+	ctx, cancel := context.WithTimeout(ctx, k.opt.HealthCheckTimeout)
+	defer cancel()
+
+	rtt, err := k.opt.Pinger.Ping(ctx, peer, "health")
+	if err == nil {
+		k.healthFailures.reset(peer)
+		k.collector.Record(peer, im.PeerLatency(rtt))
+		return
+	}
+
+	if !k.connectedPeers.Exists(peer) {
+		return
+	}
+
+	k.metrics.HealthCheckFailures.Inc()
+	failures := k.healthFailures.inc(peer)
+	k.logger.Debug("peer health check failed", "peer_address", peer, "failures", failures, "error", err)
+
+	if failures >= k.opt.HealthCheckFailureThreshold {
+		k.collector.Record(peer, im.PeerHealth(false))
+	}
+}
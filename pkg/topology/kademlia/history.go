@@ -0,0 +1,172 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/shed"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/topology"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Default metrics history option values.
+const (
+	DefaultMetricsSnapshotInterval = 5 * time.Minute
+	DefaultMetricsHistoryRetention = 24 * time.Hour
+)
+
+const metricsHistoryIndexName = "KademliaMetricsHistory"
+
+var errInvalidHistoryKey = errors.New("invalid metrics history key")
+
+// metricsHistory persists the periodic snapshots of the connected peers
+// metrics, keyed by the peer overlay and the snapshot time.
+type metricsHistory struct {
+	db    *shed.DB
+	index shed.Index
+}
+
+func newMetricsHistory(db *shed.DB) (*metricsHistory, error) {
+	index, err := db.NewIndex(metricsHistoryIndexName, shed.IndexFuncs{
+		EncodeKey: func(fields shed.Item) ([]byte, error) {
+			key := make([]byte, len(fields.Address)+8)
+			copy(key, fields.Address)
+			binary.BigEndian.PutUint64(key[len(fields.Address):], uint64(fields.StoreTimestamp))
+			return key, nil
+		},
+		DecodeKey: func(key []byte) (e shed.Item, err error) {
+			if len(key) < 8 {
+				return e, errInvalidHistoryKey
+			}
+			e.Address = key[:len(key)-8]
+			e.StoreTimestamp = int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+			return e, nil
+		},
+		EncodeValue: func(fields shed.Item) ([]byte, error) {
+			return fields.Data, nil
+		},
+		DecodeValue: func(keyItem shed.Item, value []byte) (e shed.Item, err error) {
+			e.Data = value
+			return e, nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index initialization for %q failed: %w", metricsHistoryIndexName, err)
+	}
+	return &metricsHistory{db: db, index: index}, nil
+}
+
+// put stores the records taken at the time t.
+func (h *metricsHistory) put(t time.Time, peers []swarm.Address, records []topology.MetricsRecord) error {
+	batch := new(leveldb.Batch)
+	for i, peer := range peers {
+		data, err := json.Marshal(records[i])
+		if err != nil {
+			return err
+		}
+		if err := h.index.PutInBatch(batch, shed.Item{
+			Address:        peer.Bytes(),
+			StoreTimestamp: t.UnixNano(),
+			Data:           data,
+		}); err != nil {
+			return err
+		}
+	}
+	return h.db.WriteBatch(batch)
+}
+
+// get returns up to limit of the latest records of the peer, the newest first.
+func (h *metricsHistory) get(peer swarm.Address, limit int) ([]topology.MetricsRecord, error) {
+	var records []topology.MetricsRecord
+	err := h.index.Iterate(func(item shed.Item) (bool, error) {
+		var r topology.MetricsRecord
+		if err := json.Unmarshal(item.Data, &r); err != nil {
+			return true, err
+		}
+		records = append(records, r)
+		return limit > 0 && len(records) == limit, nil
+	}, &shed.IterateOptions{
+		Prefix:  peer.Bytes(),
+		Reverse: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// prune removes the records taken before the time t.
+func (h *metricsHistory) prune(t time.Time) error {
+	batch := new(leveldb.Batch)
+	err := h.index.Iterate(func(item shed.Item) (bool, error) {
+		if item.StoreTimestamp < t.UnixNano() {
+			return false, h.index.DeleteInBatch(batch, item)
+		}
+		return false, nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return h.db.WriteBatch(batch)
+}
+
+// manageMetricsHistory periodically persists the metrics snapshots of the
+// connected peers and removes the ones older than the retention.
+func (k *Kad) manageMetricsHistory() {
+	defer k.wg.Done()
+
+	if k.opt.MetricsSnapshotInterval <= 0 {
+		return
+	}
+
+	for {
+		select {
+		case <-k.halt:
+			return
+		case <-k.quit:
+			return
+		case <-time.After(k.opt.MetricsSnapshotInterval):
+			if err := k.snapshotMetrics(time.Now()); err != nil {
+				k.logger.Debug("unable to persist metrics snapshot", "error", err)
+			}
+		}
+	}
+}
+
+// snapshotMetrics persists the metrics snapshots of the connected peers
+// taken at the time t.
+func (k *Kad) snapshotMetrics(t time.Time) error {
+	var (
+		peers   []swarm.Address
+		records []topology.MetricsRecord
+	)
+	_ = k.connectedPeers.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
+		peers = append(peers, addr)
+		records = append(records, topology.MetricsRecord{Timestamp: t.Unix(), Bin: po})
+		return false, false, nil
+	})
+
+	ss := k.collector.Snapshot(t, peers...)
+	for i, peer := range peers {
+		records[i].Metrics = createMetricsSnapshotView(ss[peer.ByteString()])
+	}
+
+	if err := k.metricsHistory.put(t, peers, records); err != nil {
+		return err
+	}
+	return k.metricsHistory.prune(t.Add(-k.opt.MetricsHistoryRetention))
+}
+
+// MetricsHistory returns up to limit of the latest persisted metrics
+// snapshots of the peer, the newest first, all of them if the limit is zero.
+func (k *Kad) MetricsHistory(peer swarm.Address, limit int) ([]topology.MetricsRecord, error) {
+	return k.metricsHistory.get(peer, limit)
+}
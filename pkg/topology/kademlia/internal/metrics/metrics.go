@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -19,7 +20,13 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
-const ewmaSmoothing = 0.1
+const (
+	ewmaSmoothing = 0.1
+
+	// misbehaviorHalfLife is the time after which the weight of the reported
+	// misbehavior of a peer is halved.
+	misbehaviorHalfLife = time.Hour
+)
 
 // PeerConnectionDirection represents peer connection direction.
 type PeerConnectionDirection string
@@ -137,6 +144,29 @@ func PeerHealth(isHealty bool) RecordOp {
 	}
 }
 
+// PeerMisbehavior adds the weight of a protocol violation reported at the
+// given time t to the misbehavior of the peer, which decays over time.
+func PeerMisbehavior(t time.Time, weight float64) RecordOp {
+	return func(cs *Counters) {
+		cs.Lock()
+		defer cs.Unlock()
+
+		cs.misbehavior = cs.decayedMisbehavior(t) + weight
+		cs.misbehaviorTimestamp = t.UnixNano()
+	}
+}
+
+// ResetMisbehavior clears the misbehavior of the peer.
+func ResetMisbehavior() RecordOp {
+	return func(cs *Counters) {
+		cs.Lock()
+		defer cs.Unlock()
+
+		cs.misbehavior = 0
+		cs.misbehaviorTimestamp = 0
+	}
+}
+
 // Snapshot represents a snapshot of peers' metrics counters.
 type Snapshot struct {
 	LastSeenTimestamp          int64
@@ -148,6 +178,7 @@ type Snapshot struct {
 	Reachability               p2p.ReachabilityStatus
 	Healthy                    bool
 	IsBootnode                 bool
+	Misbehavior                float64
 }
 
 // persistentCounters is a helper struct used for persisting selected counters.
@@ -177,6 +208,8 @@ type Counters struct {
 	latencyEWMA          time.Duration
 	ReachabilityStatus   p2p.ReachabilityStatus
 	Healthy              bool
+	misbehavior          float64
+	misbehaviorTimestamp int64
 }
 
 // UnmarshalJSON unmarshal just the persistent counters.
@@ -229,7 +262,21 @@ func (cs *Counters) snapshot(t time.Time) *Snapshot {
 		Reachability:               cs.ReachabilityStatus,
 		Healthy:                    cs.Healthy,
 		IsBootnode:                 cs.IsBootnode,
+		Misbehavior:                cs.decayedMisbehavior(t),
+	}
+}
+
+// decayedMisbehavior returns the misbehavior weight decayed until the given
+// time t. Must be called with the counters lock held.
+func (cs *Counters) decayedMisbehavior(t time.Time) float64 {
+	if cs.misbehavior == 0 {
+		return 0
+	}
+	elapsed := time.Duration(t.UnixNano() - cs.misbehaviorTimestamp)
+	if elapsed <= 0 {
+		return cs.misbehavior
 	}
+	return cs.misbehavior * math.Exp2(-float64(elapsed)/float64(misbehaviorHalfLife))
 }
 
 // NewCollector is a convenient constructor for creating new Collector.
@@ -167,6 +167,18 @@ func TestPeerMetricsCollector(t *testing.T) {
 		t.Fatalf("Snapshot(%q, ...): has health status mismatch: have %v; want %v", addr, have, want)
 	}
 
+	// Misbehavior.
+	mc.Record(addr, metrics.PeerMisbehavior(t2, 1))
+	ss = snapshot(t, mc, t2.Add(time.Hour), addr)
+	if have, want := ss.Misbehavior, 0.5; have != want {
+		t.Fatalf("Snapshot(%q, ...): misbehavior mismatch: have %v; want %v", addr, have, want)
+	}
+	mc.Record(addr, metrics.PeerMisbehavior(t2, 2))
+	ss = snapshot(t, mc, t2, addr)
+	if have, want := ss.Misbehavior, 3.0; have != want {
+		t.Fatalf("Snapshot(%q, ...): misbehavior mismatch: have %v; want %v", addr, have, want)
+	}
+
 	// Inspect.
 	have := mc.Inspect(addr)
 	want := ss
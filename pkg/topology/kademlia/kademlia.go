@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"math/rand"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/topology/kademlia/internal/waitnext"
 	"github.com/calmw/bee-tron/pkg/topology/pslice"
 	"github.com/calmw/bee-tron/pkg/util/ioutil"
+	"github.com/calmw/bee-tron/pkg/util/nbhdutil"
 	ma "github.com/multiformats/go-multiaddr"
 	"golang.org/x/sync/errgroup"
 )
@@ -45,6 +47,8 @@ const (
 	// the peerConnectionAttemptTimeout constant must be equal to or greater
 	// than 5 seconds (empirically verified).
 	peerConnectionAttemptTimeout = 15 * time.Second // timeout for establishing a new connection with peer.
+
+	changeBufferSize = 32 // the number of topology changes buffered for a subscriber
 )
 
 // Default option values
@@ -87,8 +91,14 @@ type Options struct {
 	BootnodeMode   bool
 	PruneFunc      pruneFunc
 	StaticNodes    []swarm.Address
+	StaticPeers    []ma.Multiaddr
 	ExcludeFunc    excludeFunc
 	DataDir        string
+	PeerScoreFunc  PeerScoreFunc
+	Pinger         Pinger
+	// BalancingStrategy decides the number of peers kept in every bin,
+	// BalancingUniform if empty.
+	BalancingStrategy BalancingStrategy
 
 	BitSuffixLength             *int
 	TimeToRetry                 *time.Duration
@@ -96,9 +106,23 @@ type Options struct {
 	PruneWakeup                 *time.Duration
 	SaturationPeers             *int
 	OverSaturationPeers         *int
+	OverbookingFactor           *float64 // sets OverSaturationPeers relative to SaturationPeers if it is nil
 	BootnodeOverSaturationPeers *int
 	BroadcastBinSize            *int
 	LowWaterMark                *int
+	MinPeerScore                *float64
+	HealthCheckInterval         *time.Duration
+	HealthCheckTimeout          *time.Duration
+	HealthCheckFailureThreshold *int
+	MetricsSnapshotInterval     *time.Duration
+	MetricsHistoryRetention     *time.Duration
+	// MisbehaviorThreshold is the decayed misbehavior weight at which a
+	// peer is blocklisted, zero disables the blocklisting.
+	MisbehaviorThreshold     *float64
+	MisbehaviorBlockDuration *time.Duration
+	// MisbehaviorPermanentAfter is the number of the temporary blocklistings
+	// after which a peer is blocklisted permanently, zero never.
+	MisbehaviorPermanentAfter *int
 }
 
 // kadOptions are made from Options with default values set
@@ -109,7 +133,12 @@ type kadOptions struct {
 	PruneCountFunc pruneCountFunc
 	PruneFunc      pruneFunc
 	StaticNodes    []swarm.Address
+	StaticPeers    []ma.Multiaddr
 	ExcludeFunc    excludeFunc
+	PeerScoreFunc  PeerScoreFunc
+	Pinger         Pinger
+
+	BalancingStrategy BalancingStrategy
 
 	TimeToRetry                 time.Duration
 	ShortRetry                  time.Duration
@@ -120,6 +149,15 @@ type kadOptions struct {
 	BootnodeOverSaturationPeers int
 	BroadcastBinSize            int
 	LowWaterMark                int
+	MinPeerScore                float64
+	HealthCheckInterval         time.Duration
+	HealthCheckTimeout          time.Duration
+	HealthCheckFailureThreshold int
+	MetricsSnapshotInterval     time.Duration
+	MetricsHistoryRetention     time.Duration
+	MisbehaviorThreshold        float64
+	MisbehaviorBlockDuration    time.Duration
+	MisbehaviorPermanentAfter   int
 }
 
 func newKadOptions(o Options) kadOptions {
@@ -130,7 +168,12 @@ func newKadOptions(o Options) kadOptions {
 		BootnodeMode:   o.BootnodeMode,
 		PruneFunc:      o.PruneFunc,
 		StaticNodes:    o.StaticNodes,
+		StaticPeers:    o.StaticPeers,
 		ExcludeFunc:    o.ExcludeFunc,
+		PeerScoreFunc:  o.PeerScoreFunc,
+		Pinger:         o.Pinger,
+
+		BalancingStrategy: o.BalancingStrategy,
 		// copy or use default
 		TimeToRetry:                 defaultValDuration(o.TimeToRetry, defaultTimeToRetry),
 		ShortRetry:                  defaultValDuration(o.ShortRetry, defaultShortRetry),
@@ -141,10 +184,25 @@ func newKadOptions(o Options) kadOptions {
 		BootnodeOverSaturationPeers: defaultValInt(o.BootnodeOverSaturationPeers, defaultBootNodeOverSaturationPeers),
 		BroadcastBinSize:            defaultValInt(o.BroadcastBinSize, defaultBroadcastBinSize),
 		LowWaterMark:                defaultValInt(o.LowWaterMark, defaultLowWaterMark),
+		MinPeerScore:                defaultValFloat(o.MinPeerScore, defaultMinPeerScore),
+		HealthCheckInterval:         defaultValDuration(o.HealthCheckInterval, DefaultHealthCheckInterval),
+		HealthCheckTimeout:          defaultValDuration(o.HealthCheckTimeout, DefaultHealthCheckTimeout),
+		HealthCheckFailureThreshold: defaultValInt(o.HealthCheckFailureThreshold, DefaultHealthCheckFailureThreshold),
+		MetricsSnapshotInterval:     defaultValDuration(o.MetricsSnapshotInterval, DefaultMetricsSnapshotInterval),
+		MetricsHistoryRetention:     defaultValDuration(o.MetricsHistoryRetention, DefaultMetricsHistoryRetention),
+		MisbehaviorThreshold:        defaultValFloat(o.MisbehaviorThreshold, DefaultMisbehaviorThreshold),
+		MisbehaviorBlockDuration:    defaultValDuration(o.MisbehaviorBlockDuration, DefaultMisbehaviorBlockDuration),
+		MisbehaviorPermanentAfter:   defaultValInt(o.MisbehaviorPermanentAfter, DefaultMisbehaviorPermanentAfter),
 	}
 
-	if ko.SaturationFunc == nil {
-		ko.SaturationFunc = makeSaturationFunc(ko)
+	if ko.PeerScoreFunc == nil {
+		ko.PeerScoreFunc = DefaultPeerScore
+	}
+	if ko.BalancingStrategy == "" {
+		ko.BalancingStrategy = DefaultBalancingStrategy
+	}
+	if o.OverSaturationPeers == nil && o.OverbookingFactor != nil {
+		ko.OverSaturationPeers = overbookedPeers(ko.SaturationPeers, *o.OverbookingFactor)
 	}
 
 	return ko
@@ -157,19 +215,18 @@ func defaultValInt(v *int, d int) int {
 	return *v
 }
 
-func defaultValDuration(v *time.Duration, d time.Duration) time.Duration {
+func defaultValFloat(v *float64, d float64) float64 {
 	if v == nil {
 		return d
 	}
 	return *v
 }
 
-func makeSaturationFunc(o kadOptions) binSaturationFunc {
-	os := o.OverSaturationPeers
-	if o.BootnodeMode {
-		os = o.BootnodeOverSaturationPeers
+func defaultValDuration(v *time.Duration, d time.Duration) time.Duration {
+	if v == nil {
+		return d
 	}
-	return binSaturated(os, isStaticPeer(o.StaticNodes))
+	return *v
 }
 
 // Kad is the Swarm forwarding kademlia implementation.
@@ -186,11 +243,15 @@ type Kad struct {
 	storageRadius     uint8                 // storage area of responsibility
 	depthMu           sync.RWMutex          // protect depth changes
 	manageC           chan struct{}         // trigger the manage forever loop to connect to new peers
-	peerSig           []chan struct{}
-	peerSigMtx        sync.Mutex
+	changeSubs        []chan topology.Change
+	changeSubsMtx     sync.Mutex
+	pruned            sync.Map   // peers being disconnected by pruning
 	logger            log.Logger // logger
 	bootnode          bool       // indicates whether the node is working in bootnode mode
 	collector         *im.Collector
+	peerCache         *peerCache      // recently connected peers persisted for a warm restart
+	metricsHistory    *metricsHistory // periodic snapshots of the connected peers metrics
+	db                *shed.DB
 	quit              chan struct{} // quit channel
 	halt              chan struct{} // halt channel
 	done              chan struct{} // signal that `manage` has quit
@@ -198,6 +259,10 @@ type Kad struct {
 	waitNext          *waitnext.WaitNext
 	metrics           metrics
 	staticPeer        staticPeerFunc
+	staticPeers       *staticPeers    // static peers learned on connect
+	staticC           chan struct{}   // trigger dialing the disconnected static peers
+	healthFailures    *healthFailures // consecutive failed health checks of the connected peers
+	escalation        *escalationPolicy
 	bgBroadcastCtx    context.Context
 	bgBroadcastCancel context.CancelFunc
 	reachability      p2p.ReachabilityStatus
@@ -214,6 +279,12 @@ func New(
 ) (*Kad, error) {
 	var k *Kad
 
+	if o.BalancingStrategy != "" {
+		if _, err := ParseBalancingStrategy(string(o.BalancingStrategy)); err != nil {
+			return nil, err
+		}
+	}
+
 	if o.DataDir == "" {
 		logger.Warning("using in-mem store for kademlia metrics, no state will be persisted")
 	} else {
@@ -227,6 +298,14 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("unable to create metrics collector: %w", err)
 	}
+	pc, err := newPeerCache(sdb)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create peer cache: %w", err)
+	}
+	mh, err := newMetricsHistory(sdb)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create metrics history: %w", err)
+	}
 
 	opt := newKadOptions(o)
 
@@ -244,14 +323,25 @@ func New(
 		logger:            logger.WithName(loggerName).Register(),
 		bootnode:          opt.BootnodeMode,
 		collector:         imc,
+		peerCache:         pc,
+		metricsHistory:    mh,
+		db:                sdb,
 		quit:              make(chan struct{}),
 		halt:              make(chan struct{}),
 		done:              make(chan struct{}),
 		metrics:           newMetrics(),
-		staticPeer:        isStaticPeer(opt.StaticNodes),
+		staticPeers:       newStaticPeers(),
+		staticC:           make(chan struct{}, 1),
+		healthFailures:    newHealthFailures(),
+		escalation:        newEscalationPolicy(opt.MisbehaviorThreshold, opt.MisbehaviorBlockDuration, opt.MisbehaviorPermanentAfter),
 		storageRadius:     swarm.MaxPO,
 	}
 
+	staticNode := isStaticPeer(opt.StaticNodes)
+	k.staticPeer = func(peer swarm.Address) bool {
+		return staticNode(peer) || k.staticPeers.has(peer)
+	}
+
 	if k.opt.PruneFunc == nil {
 		k.opt.PruneFunc = k.pruneOversaturatedBins
 	}
@@ -260,7 +350,11 @@ func New(
 	if k.opt.BootnodeMode {
 		os = k.opt.BootnodeOverSaturationPeers
 	}
-	k.opt.PruneCountFunc = binPruneCount(os, isStaticPeer(k.opt.StaticNodes))
+	binLimit := k.opt.BalancingStrategy.binLimit(os, k.opt.SaturationPeers)
+	k.opt.PruneCountFunc = binPruneCount(binLimit, k.staticPeer)
+	if k.opt.SaturationFunc == nil {
+		k.opt.SaturationFunc = binSaturated(binLimit, k.staticPeer)
+	}
 
 	if k.opt.ExcludeFunc == nil {
 		k.opt.ExcludeFunc = func(f ...im.ExcludeOp) peerExcludeFunc {
@@ -446,18 +540,7 @@ func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup,
 			return
 		}
 
-		k.waitNext.Set(peer.addr, time.Now().Add(k.opt.ShortRetry), 0)
-
-		k.connectedPeers.Add(peer.addr)
-
-		k.metrics.TotalOutboundConnections.Inc()
-		k.collector.Record(peer.addr, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionOutbound))
-
-		k.recalcDepth()
-
-		k.logger.Debug("connected to peer", "peer_address", peer.addr, "proximity_order", peer.po)
-		k.notifyManageLoop()
-		k.notifyPeerSig()
+		k.connectedOutbound(peer.addr, peer.po)
 	}
 
 	var (
@@ -502,6 +585,22 @@ func (k *Kad) connectionAttemptsHandler(ctx context.Context, wg *sync.WaitGroup,
 }
 
 // notifyManageLoop notifies kademlia manage loop.
+// connectedOutbound registers the peer dialed by this node as connected.
+func (k *Kad) connectedOutbound(addr swarm.Address, po uint8) {
+	k.waitNext.Set(addr, time.Now().Add(k.opt.ShortRetry), 0)
+
+	k.connectedPeers.Add(addr)
+
+	k.metrics.TotalOutboundConnections.Inc()
+	k.collector.Record(addr, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionOutbound))
+
+	k.recalcDepth()
+
+	k.logger.Debug("connected to peer", "peer_address", addr, "proximity_order", po)
+	k.notifyManageLoop()
+	k.notifyChange(topology.Change{Type: topology.ChangePeerAdded, Peer: addr, Bin: po, Reason: topology.ReasonConnected})
+}
+
 func (k *Kad) notifyManageLoop() {
 	select {
 	case k.manageC <- struct{}{}:
@@ -531,6 +630,15 @@ func (k *Kad) manage() {
 	balanceChan := make(chan *peerConnInfo)
 	go k.connectionAttemptsHandler(ctx, &wg, neighbourhoodChan, balanceChan)
 
+	k.wg.Add(1)
+	go k.manageStaticPeers(ctx)
+
+	k.wg.Add(1)
+	go k.manageHealthCheck(ctx)
+
+	k.wg.Add(1)
+	go k.manageMetricsHistory()
+
 	k.wg.Add(1)
 	go func() {
 		defer k.wg.Done()
@@ -565,6 +673,9 @@ func (k *Kad) manage() {
 					k.metrics.InternalMetricsFlushTime.Observe(time.Since(start).Seconds())
 					loggerV1.Debug("flush metrics done", "elapsed", time.Since(start))
 				}
+				if err := k.cachePeers(); err != nil {
+					k.logger.Debug("unable to cache peers", "error", err)
+				}
 			}
 		}
 	}()
@@ -668,7 +779,7 @@ func (k *Kad) manage() {
 }
 
 // pruneOversaturatedBins disconnects out of depth peers from oversaturated bins
-// while maintaining the balance of the bin and favoring healthy and reachable peers.
+// while maintaining the balance of the bin and favoring the best scored peers.
 func (k *Kad) pruneOversaturatedBins(depth uint8) {
 
 	for i := range k.commonBinPrefixes {
@@ -696,30 +807,17 @@ func (k *Kad) pruneOversaturatedBins(depth uint8) {
 			if len(peers) <= 1 {
 				continue
 			}
-
-			var disconnectPeer = swarm.ZeroAddress
-			var unreachablePeer = swarm.ZeroAddress
-			for _, peer := range peers {
-				if ss := k.collector.Inspect(peer); ss != nil {
-					if !ss.Healthy {
-						disconnectPeer = peer
-						break
-					}
-					if ss.Reachability != p2p.ReachabilityStatusPublic {
-						unreachablePeer = peer
-					}
-				}
+			// static peers are never pruned
+			peers = slices.DeleteFunc(peers, k.staticPeer)
+			if len(peers) == 0 {
+				continue
 			}
 
-			if disconnectPeer.IsZero() {
-				if unreachablePeer.IsZero() {
-					disconnectPeer = peers[rand.Intn(len(peers))]
-				} else {
-					disconnectPeer = unreachablePeer // pick unreachable peer
-				}
-			}
+			// shuffle so that equally scored peers are pruned at random
+			rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+			disconnectPeer := k.lowestScoredPeer(peers)
 
-			err := k.p2p.Disconnect(disconnectPeer, "pruned from oversaturated bin")
+			err := k.prune(disconnectPeer, "pruned from oversaturated bin")
 			if err != nil {
 				k.logger.Debug("prune disconnect failed", "error", err)
 			}
@@ -746,6 +844,9 @@ func (k *Kad) balancedSlotPeers(pseudoAddr swarm.Address, peers []swarm.Address,
 
 func (k *Kad) Start(ctx context.Context) error {
 
+	// reconnect to the peers cached before the restart at once
+	k.connectCachedPeers(ctx)
+
 	// always discover bootnodes on startup to exclude them from protocol requests
 	k.connectBootNodes(ctx)
 
@@ -864,32 +965,17 @@ func (k *Kad) connectBootNodes(ctx context.Context) {
 // binSaturated indicates whether a certain bin is saturated or not.
 // when a bin is not saturated it means we would like to proactively
 // initiate connections to other peers in the bin.
-func binSaturated(oversaturationAmount int, staticNode staticPeerFunc) binSaturationFunc {
+func binSaturated(limit binLimitFunc, staticNode staticPeerFunc) binSaturationFunc {
 	return func(bin uint8, connected *pslice.PSlice, exclude peerExcludeFunc) bool {
-		size := 0
-		_ = connected.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
-			if po == bin && !exclude(addr) && !staticNode(addr) {
-				size++
-			}
-			return false, false, nil
-		})
-
-		return size >= oversaturationAmount
+		return binSize(bin, connected, exclude, staticNode) >= limit(bin)
 	}
 }
 
 // binPruneCount counts how many peers should be pruned from a bin.
-func binPruneCount(oversaturationAmount int, staticNode staticPeerFunc) pruneCountFunc {
+func binPruneCount(limit binLimitFunc, staticNode staticPeerFunc) pruneCountFunc {
 	return func(bin uint8, connected *pslice.PSlice, exclude peerExcludeFunc) (int, int) {
-		size := 0
-		_ = connected.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
-			if po == bin && !exclude(addr) && !staticNode(addr) {
-				size++
-			}
-			return false, false, nil
-		})
-
-		return size, size - oversaturationAmount
+		size := binSize(bin, connected, exclude, staticNode)
+		return size, size - limit(bin)
 	}
 }
 
@@ -1145,20 +1231,10 @@ func (k *Kad) Pick(peer p2p.Peer) bool {
 
 func (k *Kad) binPeers(bin uint8, reachable bool) (peers []swarm.Address) {
 
-	_ = k.EachConnectedPeerRev(func(p swarm.Address, po uint8) (bool, bool, error) {
-
-		if po == bin {
-			peers = append(peers, p)
-			return false, false, nil
-		}
-
-		if po > bin {
-			return true, false, nil
-		}
-
-		return false, true, nil
-
-	}, topology.Select{Reachable: reachable})
+	_ = k.EachConnectedPeerRev(func(p swarm.Address, _ uint8) (bool, bool, error) {
+		peers = append(peers, p)
+		return false, false, nil
+	}, topology.Select{Reachable: reachable, Bins: &topology.BinRange{Min: bin, Max: bin}})
 
 	return
 }
@@ -1188,10 +1264,10 @@ func (k *Kad) Connected(ctx context.Context, peer p2p.Peer, forceConnection bool
 			if err != nil {
 				return fmt.Errorf("failed to get random peer to kick-out: %w", err)
 			}
-			_ = k.p2p.Disconnect(randPeer, "kicking out random peer to accommodate node")
+			_ = k.prune(randPeer, "kicking out random peer to accommodate node")
 			return k.onConnected(ctx, address)
 		}
-		if !forceConnection {
+		if !forceConnection && !k.staticPeer(address) {
 			return topology.ErrOversaturated
 		}
 	}
@@ -1212,7 +1288,12 @@ func (k *Kad) onConnected(ctx context.Context, addr swarm.Address) error {
 	k.recalcDepth()
 
 	k.notifyManageLoop()
-	k.notifyPeerSig()
+	k.notifyChange(topology.Change{
+		Type:   topology.ChangePeerAdded,
+		Peer:   addr,
+		Bin:    swarm.Proximity(k.base.Bytes(), addr.Bytes()),
+		Reason: topology.ReasonConnected,
+	})
 
 	return nil
 }
@@ -1228,22 +1309,54 @@ func (k *Kad) Disconnected(peer p2p.Peer) {
 	k.metrics.TotalInboundDisconnections.Inc()
 	k.collector.Record(peer.Address, im.PeerLogOut(time.Now()))
 
+	k.healthFailures.reset(peer.Address)
+
 	k.recalcDepth()
 
+	if k.staticPeer(peer.Address) {
+		k.notifyStaticPeers()
+	}
 	k.notifyManageLoop()
-	k.notifyPeerSig()
+	k.notifyChange(topology.Change{
+		Type:   topology.ChangePeerRemoved,
+		Peer:   peer.Address,
+		Bin:    swarm.Proximity(k.base.Bytes(), peer.Address.Bytes()),
+		Reason: k.disconnectReason(peer.Address),
+	})
+}
+
+// prune disconnects the peer to make room in its bin.
+func (k *Kad) prune(addr swarm.Address, reason string) error {
+	k.pruned.Store(addr.ByteString(), struct{}{})
+	defer k.pruned.Delete(addr.ByteString())
+
+	return k.p2p.Disconnect(addr, reason)
 }
 
-func (k *Kad) notifyPeerSig() {
-	k.peerSigMtx.Lock()
-	defer k.peerSigMtx.Unlock()
+// disconnectReason returns the reason the peer was disconnected for.
+func (k *Kad) disconnectReason(addr swarm.Address) topology.ChangeReason {
+	if _, ok := k.pruned.Load(addr.ByteString()); ok {
+		return topology.ReasonPruned
+	}
+	// the peer is blocklisted before it is disconnected
+	if blocked, err := k.p2p.Blocklisted(addr); err == nil && blocked {
+		return topology.ReasonBlocklisted
+	}
+	return topology.ReasonDisconnected
+}
+
+// notifyChange delivers the change to the subscribers. The change is dropped
+// for the subscribers which have not received the previous ones yet.
+func (k *Kad) notifyChange(c topology.Change) {
+	c.Radius = k.neighborhoodDepth()
+	c.Time = time.Now()
+
+	k.changeSubsMtx.Lock()
+	defer k.changeSubsMtx.Unlock()
 
-	for _, c := range k.peerSig {
-		// Every peerSig channel has a buffer capacity of 1,
-		// so every receiver will get the signal even if the
-		// select statement has the default case to avoid blocking.
+	for _, ch := range k.changeSubs {
 		select {
-		case c <- struct{}{}:
+		case ch <- c:
 		default:
 		}
 	}
@@ -1267,12 +1380,35 @@ func (k *Kad) IsReachable() bool {
 	return k.reachability == p2p.ReachabilityStatusPublic
 }
 
-// ClosestPeer returns the closest peer to a given address.
+// ClosestPeer returns the closest peer to a given address. Peers scored below
+// the minimum peer score are only returned if no other peer is found.
 func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.Select, skipPeers ...swarm.Address) (swarm.Address, error) {
 	if k.connectedPeers.Length() == 0 {
 		return swarm.Address{}, topology.ErrNotFound
 	}
 
+	lowScored := false
+	closest, err := k.closestPeer(addr, includeSelf, filter, func(peer swarm.Address) bool {
+		if swarm.ContainsAddress(skipPeers, peer) {
+			return true
+		}
+		if k.peerScore(peer) < k.opt.MinPeerScore {
+			lowScored = true
+			return true
+		}
+		return false
+	})
+	if errors.Is(err, topology.ErrNotFound) && lowScored {
+		closest, err = k.closestPeer(addr, includeSelf, filter, func(peer swarm.Address) bool {
+			return swarm.ContainsAddress(skipPeers, peer)
+		})
+	}
+	return closest, err
+}
+
+// closestPeer returns the closest peer to a given address which is not
+// skipped.
+func (k *Kad) closestPeer(addr swarm.Address, includeSelf bool, filter topology.Select, skip func(swarm.Address) bool) (swarm.Address, error) {
 	closest := swarm.ZeroAddress
 
 	if includeSelf && k.reachability == p2p.ReachabilityStatusPublic {
@@ -1283,11 +1419,13 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 
 	// iterate starting from bin 0 to the maximum bin
 	err := k.EachConnectedPeerRev(func(peer swarm.Address, bin uint8) (bool, bool, error) {
-		if swarm.ContainsAddress(skipPeers, peer) {
+		if skip(peer) {
 			return false, false, nil
 		}
 
-		if bin > prox && !closest.IsZero() {
+		// peers in the deeper bins have the same proximity order to the
+		// address and may only be preferred for their latency
+		if bin > prox && !closest.IsZero() && !filter.LowLatency {
 			return true, false, nil
 		}
 
@@ -1296,6 +1434,13 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 			return false, false, nil
 		}
 
+		if filter.LowLatency {
+			if k.preferredPeer(addr, peer, closest) {
+				closest = peer
+			}
+			return false, false, nil
+		}
+
 		closer, err := peer.Closer(addr, closest)
 		if closer {
 			closest = peer
@@ -1304,7 +1449,7 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 			k.logger.Debug("closest peer", "peer", peer, "addr", addr, "error", err)
 		}
 		return false, false, nil
-	}, filter)
+	}, topology.Select{Reachable: filter.Reachable, Healthy: filter.Healthy})
 
 	if err != nil {
 		return swarm.Address{}, err
@@ -1322,26 +1467,100 @@ func (k *Kad) ClosestPeer(addr swarm.Address, includeSelf bool, filter topology.
 	return closest, nil
 }
 
+// preferredPeer reports whether the peer is preferred over the current closest
+// peer to the address, for its higher proximity order or, within the same
+// proximity order, for its lower latency. This node is preferred over the
+// peers of the same proximity order.
+func (k *Kad) preferredPeer(addr, peer, current swarm.Address) bool {
+	po := swarm.Proximity(peer.Bytes(), addr.Bytes())
+	currentPO := swarm.Proximity(current.Bytes(), addr.Bytes())
+	switch {
+	case po != currentPO:
+		return po > currentPO
+	case current.Equal(k.base):
+		return false
+	}
+	return lowerLatency(k.peerLatency(peer), k.peerLatency(current))
+}
+
+// peerLatency returns the moving average of the peer latency, zero if it was
+// not measured yet.
+func (k *Kad) peerLatency(peer swarm.Address) time.Duration {
+	if ss := k.collector.Inspect(peer); ss != nil {
+		return ss.LatencyEWMA
+	}
+	return 0
+}
+
+// PeerLatency implements topology.PeerLatencyer interface.
+func (k *Kad) PeerLatency(peer swarm.Address) time.Duration {
+	return k.peerLatency(peer)
+}
+
+// lowerLatency reports whether the latency a is lower than b, where the
+// latencies not measured yet are the highest.
+func lowerLatency(a, b time.Duration) bool {
+	return a > 0 && (b == 0 || a < b)
+}
+
 // EachConnectedPeer implements topology.PeerIterator interface.
 func (k *Kad) EachConnectedPeer(f topology.EachPeerFunc, filter topology.Select) error {
-	excludeFunc := k.opt.ExcludeFunc(excludeFromIterator(filter)...)
-	return k.connectedPeers.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
-		if excludeFunc(addr) {
-			return false, false, nil
-		}
-		return f(addr, po)
-	})
+	return k.eachConnectedPeer(f, filter, false)
 }
 
 // EachConnectedPeerRev implements topology.PeerIterator interface.
 func (k *Kad) EachConnectedPeerRev(f topology.EachPeerFunc, filter topology.Select) error {
+	return k.eachConnectedPeer(f, filter, true)
+}
+
+// eachConnectedPeer iterates the connected peers selected by the filter from
+// the deepest bin to the shallowest one, or the other way around if reverse
+// is set.
+func (k *Kad) eachConnectedPeer(f topology.EachPeerFunc, filter topology.Select, reverse bool) error {
 	excludeFunc := k.opt.ExcludeFunc(excludeFromIterator(filter)...)
-	return k.connectedPeers.EachBinRev(func(addr swarm.Address, po uint8) (bool, bool, error) {
-		if excludeFunc(addr) {
-			return false, false, nil
+
+	bins := topology.BinRange{Min: 0, Max: swarm.MaxPO}
+	if filter.Bins != nil {
+		bins = topology.BinRange{Min: filter.Bins.Min, Max: min(filter.Bins.Max, swarm.MaxPO)}
+	}
+	if bins.Min > bins.Max {
+		return nil
+	}
+
+	for i := 0; i <= int(bins.Max-bins.Min); i++ {
+		bin := bins.Max - uint8(i)
+		if reverse {
+			bin = bins.Min + uint8(i)
 		}
-		return f(addr, po)
-	})
+
+		peers := k.connectedPeers.BinPeers(bin)
+		if filter.Random {
+			rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+		}
+
+		count := 0
+		for _, addr := range peers {
+			if filter.MaxPerBin > 0 && count == filter.MaxPerBin {
+				break
+			}
+			if excludeFunc(addr) {
+				continue
+			}
+			count++
+
+			stop, next, err := f(addr, bin)
+			if err != nil {
+				return err
+			}
+			if stop {
+				return nil
+			}
+			if next {
+				break
+			}
+		}
+	}
+	return nil
 }
 
 // Reachable sets the peer reachability status.
@@ -1373,24 +1592,26 @@ func (k *Kad) UpdatePeerHealth(peer swarm.Address, health bool, dur time.Duratio
 	k.collector.Record(peer, im.PeerHealth(health), im.PeerLatency(dur))
 }
 
-// SubscribeTopologyChange returns the channel that signals when the connected peers
-// set and depth changes. Returned function is safe to be called multiple times.
-func (k *Kad) SubscribeTopologyChange() (c <-chan struct{}, unsubscribe func()) {
-	channel := make(chan struct{}, 1)
+// SubscribeTopologyChange returns the channel that delivers the changes of
+// the connected peers set and of the storage radius. Up to changeBufferSize
+// changes are buffered for a subscriber, the later ones are dropped until the
+// subscriber catches up. Returned function is safe to be called multiple times.
+func (k *Kad) SubscribeTopologyChange() (c <-chan topology.Change, unsubscribe func()) {
+	channel := make(chan topology.Change, changeBufferSize)
 	var closeOnce sync.Once
 
-	k.peerSigMtx.Lock()
-	defer k.peerSigMtx.Unlock()
+	k.changeSubsMtx.Lock()
+	defer k.changeSubsMtx.Unlock()
 
-	k.peerSig = append(k.peerSig, channel)
+	k.changeSubs = append(k.changeSubs, channel)
 
 	unsubscribe = func() {
-		k.peerSigMtx.Lock()
-		defer k.peerSigMtx.Unlock()
+		k.changeSubsMtx.Lock()
+		defer k.changeSubsMtx.Unlock()
 
-		for i, c := range k.peerSig {
+		for i, c := range k.changeSubs {
 			if c == channel {
-				k.peerSig = append(k.peerSig[:i], k.peerSig[i+1:]...)
+				k.changeSubs = append(k.changeSubs[:i], k.changeSubs[i+1:]...)
 				break
 			}
 		}
@@ -1427,18 +1648,36 @@ func (k *Kad) neighborhoodDepth() uint8 {
 func (k *Kad) SetStorageRadius(d uint8) {
 
 	k.depthMu.Lock()
-	defer k.depthMu.Unlock()
-
 	if k.storageRadius == d {
+		k.depthMu.Unlock()
 		return
 	}
-
 	k.storageRadius = d
-	k.metrics.CurrentStorageDepth.Set(float64(k.storageRadius))
-	k.logger.Debug("kademlia set storage radius", "radius", k.storageRadius)
+	k.depthMu.Unlock()
+
+	k.metrics.CurrentStorageDepth.Set(float64(d))
+	k.logger.Debug("kademlia set storage radius", "radius", d)
 
 	k.notifyManageLoop()
-	k.notifyPeerSig()
+	k.notifyChange(topology.Change{Type: topology.ChangeRadius})
+}
+
+// SuggestNeighborhood returns the least populated neighborhood at the storage
+// radius among the known peers, in binary format, where new nodes should be
+// placed. An empty string is returned until the storage radius is known.
+func (k *Kad) SuggestNeighborhood() string {
+	radius := k.neighborhoodDepth()
+	if radius == 0 || radius >= swarm.MaxPO {
+		return ""
+	}
+
+	peers := []swarm.Address{k.base}
+	_ = k.knownPeers.EachBin(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		peers = append(peers, addr)
+		return false, false, nil
+	})
+
+	return nbhdutil.LeastPopulated(k.base, peers, radius)
 }
 
 func (k *Kad) Snapshot() *topology.KadParams {
@@ -1584,13 +1823,16 @@ func (k *Kad) Close() error {
 	err := eg.Wait()
 
 	k.logger.Info("kademlia persisting peer metrics")
+	if err := k.cachePeers(); err != nil {
+		k.logger.Debug("unable to cache peers", "error", err)
+	}
 	start := time.Now()
 	if err := k.collector.Finalize(start, false); err != nil {
 		k.logger.Debug("unable to finalize open sessions", "error", err)
 	}
 	k.logger.Debug("metrics collector finalized", "elapsed", time.Since(start))
 
-	return err
+	return errors.Join(err, k.db.Close())
 }
 
 func randomSubset(addrs []swarm.Address, count int) ([]swarm.Address, error) {
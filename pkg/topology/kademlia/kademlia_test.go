@@ -11,6 +11,7 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -545,6 +546,65 @@ func TestOversaturation(t *testing.T) {
 	}
 }
 
+func TestBalancingStrategy(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		opts  kademlia.Options
+		limit func(bin int) int
+	}{
+		{
+			name: "uniform with overbooking factor",
+			opts: kademlia.Options{
+				SaturationPeers:   ptrInt(4),
+				OverbookingFactor: ptrFloat(1.5),
+			},
+			limit: func(int) int { return 6 },
+		},
+		{
+			name: "closest heavy",
+			opts: kademlia.Options{
+				SaturationPeers:     ptrInt(4),
+				OverSaturationPeers: ptrInt(8),
+				BalancingStrategy:   kademlia.BalancingClosestHeavy,
+			},
+			limit: func(bin int) int { return 4 + bin },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.opts.ExcludeFunc = defaultExcludeFunc
+			base, kad, ab, _, signer := newTestKademlia(t, nil, nil, tc.opts)
+			if err := kad.Start(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			testutil.CleanupCloser(t, kad)
+
+			for i := 0; i < 3; i++ {
+				for j := 0; j < tc.limit(i); j++ {
+					connectOne(t, signer, kad, ab, swarm.RandAddressAt(t, base, i), nil)
+				}
+				kDepth(t, kad, i)
+			}
+
+			// the bins below depth hold no more peers than the limit
+			for i := 0; i < 2; i++ {
+				connectOne(t, signer, kad, ab, swarm.RandAddressAt(t, base, i), topology.ErrOversaturated)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := kademlia.ParseBalancingStrategy("closest"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
 func TestOversaturationBootnode(t *testing.T) {
 	t.Parallel()
 
@@ -1031,17 +1091,34 @@ func TestClosestPeer(t *testing.T) {
 func TestKademlia_SubscribeTopologyChange(t *testing.T) {
 	t.Parallel()
 
-	testSignal := func(t *testing.T, c <-chan struct{}) {
+	testSignal := func(t *testing.T, c <-chan topology.Change) topology.Change {
 		t.Helper()
 
 		select {
-		case _, ok := <-c:
+		case change, ok := <-c:
 			if !ok {
 				t.Error("closed signal channel")
 			}
+			return change
 		case <-time.After(1 * time.Second):
 			t.Error("timeout")
 		}
+		return topology.Change{}
+	}
+
+	testChange := func(t *testing.T, c <-chan topology.Change, typ topology.ChangeType, addr swarm.Address, reason topology.ChangeReason) {
+		t.Helper()
+
+		change := testSignal(t, c)
+		if change.Type != typ {
+			t.Errorf("got change type %q, want %q", change.Type, typ)
+		}
+		if !change.Peer.Equal(addr) {
+			t.Errorf("got peer %s, want %s", change.Peer, addr)
+		}
+		if change.Reason != reason {
+			t.Errorf("got reason %q, want %q", change.Reason, reason)
+		}
 	}
 
 	t.Run("single subscription", func(t *testing.T) {
@@ -1059,7 +1136,7 @@ func TestKademlia_SubscribeTopologyChange(t *testing.T) {
 		addr := swarm.RandAddressAt(t, base, 9)
 		addOne(t, sg, kad, ab, addr)
 
-		testSignal(t, c)
+		testChange(t, c, topology.ChangePeerAdded, addr, topology.ReasonConnected)
 	})
 
 	t.Run("single subscription, remove peer", func(t *testing.T) {
@@ -1077,10 +1154,35 @@ func TestKademlia_SubscribeTopologyChange(t *testing.T) {
 		addr := swarm.RandAddressAt(t, base, 9)
 		addOne(t, sg, kad, ab, addr)
 
-		testSignal(t, c)
+		testChange(t, c, topology.ChangePeerAdded, addr, topology.ReasonConnected)
 
 		removeOne(kad, addr)
-		testSignal(t, c)
+		change := testSignal(t, c)
+		if change.Type != topology.ChangePeerRemoved {
+			t.Fatalf("got change type %q, want %q", change.Type, topology.ChangePeerRemoved)
+		}
+		if !change.Peer.Equal(addr) || change.Bin != 9 || change.Reason != topology.ReasonDisconnected {
+			t.Fatalf("got change %+v", change)
+		}
+	})
+
+	t.Run("storage radius", func(t *testing.T) {
+		t.Parallel()
+
+		_, kad, _, _, _ := newTestKademlia(t, nil, nil, kademlia.Options{})
+		if err := kad.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, kad)
+
+		c, u := kad.SubscribeTopologyChange()
+		defer u()
+
+		kad.SetStorageRadius(4)
+		change := testSignal(t, c)
+		if change.Type != topology.ChangeRadius || change.Radius != 4 {
+			t.Fatalf("got change %+v, want radius 4", change)
+		}
 	})
 
 	t.Run("multiple subscriptions", func(t *testing.T) {
@@ -1577,6 +1679,209 @@ func TestBootnodeProtectedNodes(t *testing.T) {
 	}
 }
 
+func TestStaticPeers(t *testing.T) {
+	t.Parallel()
+
+	underlay, err := ma.NewMultiaddr(underlayBase + swarm.RandAddress(t).String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		conns           int32 // how many connect calls were made to the p2p mock
+		_, kad, _, _, _ = newTestKademlia(t, &conns, nil, kademlia.Options{
+			StaticPeers: []ma.Multiaddr{underlay},
+		})
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	waitPeers(t, kad, 1)
+
+	var static swarm.Address
+	_ = kad.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+		static = addr
+		return true, false, nil
+	}, topology.Select{})
+
+	// the static peer is dialed again once disconnected
+	atomic.StoreInt32(&conns, 0)
+	kad.Disconnected(p2p.Peer{Address: static})
+	waitConn(t, &conns)
+	waitPeers(t, kad, 1)
+
+	if !kad.IsStaticPeer(static) {
+		t.Fatalf("peer %s is not static", static)
+	}
+}
+
+func TestWarmRestart(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conns   int32 // how many connect calls were made to the p2p mock
+		dataDir = t.TempDir()
+		base    = swarm.RandAddress(t)
+		peers   = []swarm.Address{swarm.RandAddressAt(t, base, 2), swarm.RandAddressAt(t, base, 3)}
+
+		_, kad, ab, _, signer = newTestKademliaWithAddr(t, base, &conns, nil, kademlia.Options{DataDir: dataDir})
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, peer := range peers {
+		connectOne(t, signer, kad, ab, peer, nil)
+		kad.UpdatePeerHealth(peer, true, 0)
+	}
+	waitPeers(t, kad, len(peers))
+
+	if err := kad.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restarted node has an empty address book and no bootnodes
+	_, kad, _, _, _ = newTestKademliaWithAddr(t, base, &conns, nil, kademlia.Options{DataDir: dataDir})
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	waitPeers(t, kad, len(peers))
+	for _, peer := range peers {
+		found := false
+		_ = kad.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+			found = addr.Equal(peer)
+			return found, false, nil
+		}, topology.Select{})
+		if !found {
+			t.Fatalf("cached peer %s not reconnected", peer)
+		}
+	}
+}
+
+type pingerFunc func(context.Context, swarm.Address, ...string) (time.Duration, error)
+
+func (f pingerFunc) Ping(ctx context.Context, address swarm.Address, msgs ...string) (time.Duration, error) {
+	return f(ctx, address, msgs...)
+}
+
+func TestMetricsHistory(t *testing.T) {
+	t.Parallel()
+
+	base, kad, ab, _, signer := newTestKademlia(t, nil, nil, kademlia.Options{
+		MetricsSnapshotInterval: ptrDuration(0),
+		MetricsHistoryRetention: ptrDuration(time.Hour),
+	})
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	peer := swarm.RandAddressAt(t, base, 3)
+	addOne(t, signer, kad, ab, peer)
+	waitPeers(t, kad, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := kad.SnapshotMetrics(start.Add(time.Duration(i) * time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := kad.MetricsHistory(peer, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if want := start.Add(2 * time.Minute).Unix(); records[0].Timestamp != want {
+		t.Fatalf("got newest record timestamp %d, want %d", records[0].Timestamp, want)
+	}
+	if records[0].Bin != 3 || records[0].Metrics == nil {
+		t.Fatalf("got record %+v", records[0])
+	}
+
+	// the records older than the retention are removed
+	if err := kad.SnapshotMetrics(start.Add(time.Hour + 30*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	records, err = kad.MetricsHistory(peer, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+
+	records, err = kad.MetricsHistory(swarm.RandAddress(t), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("got %d records of an unknown peer, want none", len(records))
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	var (
+		base  = swarm.RandAddress(t)
+		bad   = swarm.RandAddressAt(t, base, 1)
+		good  = swarm.RandAddressAt(t, base, 2)
+		pings atomic.Int32
+
+		pinger = pingerFunc(func(_ context.Context, address swarm.Address, _ ...string) (time.Duration, error) {
+			pings.Add(1)
+			if address.Equal(bad) {
+				return 0, errors.New("ping failed")
+			}
+			return time.Millisecond, nil
+		})
+
+		conns                 int32 // how many connect calls were made to the p2p mock
+		_, kad, ab, _, signer = newTestKademliaWithAddr(t, base, &conns, nil, kademlia.Options{
+			Pinger:                      pinger,
+			HealthCheckInterval:         ptrDuration(10 * time.Millisecond),
+			HealthCheckFailureThreshold: ptrInt(2),
+		})
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	connectOne(t, signer, kad, ab, bad, nil)
+	connectOne(t, signer, kad, ab, good, nil)
+	kad.UpdatePeerHealth(bad, true, 0)
+	kad.UpdatePeerHealth(good, true, 0)
+
+	healthy := func() (peers []swarm.Address) {
+		_ = kad.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+			peers = append(peers, addr)
+			return false, false, nil
+		}, topology.Select{Healthy: true})
+		return peers
+	}
+
+	err := spinlock.Wait(spinLockWaitTime, func() bool {
+		peers := healthy()
+		return len(peers) == 1 && peers[0].Equal(good)
+	})
+	if err != nil {
+		t.Fatalf("healthy peers: got %v, want %v", healthy(), []swarm.Address{good})
+	}
+	if pings.Load() == 0 {
+		t.Fatal("peers not pinged")
+	}
+}
+
 func TestAnnounceBgBroadcast_FLAKY(t *testing.T) {
 	t.Parallel()
 
@@ -1716,6 +2021,210 @@ func TestAnnounceNeighborhoodToNeighbor(t *testing.T) {
 	}
 }
 
+func TestClosestPeerMisbehavior(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conns int32 // how many connect calls were made to the p2p mock
+		base  = swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+		peer1 = swarm.MustParseHexAddress("4000000000000000000000000000000000000000000000000000000000000000") // binary 0100 -> po 1 to base
+		peer2 = swarm.MustParseHexAddress("6000000000000000000000000000000000000000000000000000000000000000") // binary 0110 -> po 1 to base
+		chunk = swarm.MustParseHexAddress("7000000000000000000000000000000000000000000000000000000000000000") // binary 0111 -> closest to peer2
+
+		_, kad, ab, _, signer = newTestKademliaWithAddr(t, base, &conns, nil, kademlia.Options{})
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	connectOne(t, signer, kad, ab, peer1, nil)
+	connectOne(t, signer, kad, ab, peer2, nil)
+	waitPeers(t, kad, 2)
+
+	closestPeer := func(t *testing.T, want swarm.Address, skipPeers ...swarm.Address) {
+		t.Helper()
+
+		have, err := kad.ClosestPeer(chunk, false, topology.Select{}, skipPeers...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !have.Equal(want) {
+			t.Fatalf("ClosestPeer(...): have %s, want %s", have, want)
+		}
+	}
+
+	closestPeer(t, peer2)
+
+	kad.ReportMisbehavior(peer2, "test", 5)
+
+	// the misbehaving peer is avoided
+	closestPeer(t, peer1)
+	// unless it is the only peer left
+	closestPeer(t, peer2, peer1)
+}
+
+func TestMisbehaviorEscalation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer    = swarm.RandAddress(t)
+		blocked = make(chan time.Duration, 4)
+		p2ps    = p2pmock.New(p2pmock.WithBlocklistFunc(func(addr swarm.Address, d time.Duration, _ string) error {
+			if addr.Equal(peer) {
+				blocked <- d
+			}
+			return nil
+		}))
+	)
+
+	kad, err := kademlia.New(swarm.RandAddress(t), addressbook.New(mockstate.NewStateStore()), mock.NewDiscovery(), p2ps, log.Noop, kademlia.Options{
+		MisbehaviorThreshold:      ptrFloat(2),
+		MisbehaviorBlockDuration:  ptrDuration(time.Minute),
+		MisbehaviorPermanentAfter: ptrInt(2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	expectBlocked := func(t *testing.T, want time.Duration) {
+		t.Helper()
+
+		select {
+		case d := <-blocked:
+			if d != want {
+				t.Fatalf("blocklisted for %v, want %v", d, want)
+			}
+		default:
+			t.Fatal("peer not blocklisted")
+		}
+	}
+
+	// below the threshold the peer is not blocklisted
+	kad.ReportMisbehavior(peer, "test", 1)
+	if len(blocked) != 0 {
+		t.Fatal("peer blocklisted below the threshold")
+	}
+
+	kad.ReportMisbehavior(peer, "test", 1.5)
+	expectBlocked(t, time.Minute)
+
+	// the misbehavior starts over after the blocklisting
+	kad.ReportMisbehavior(peer, "test", 1)
+	if len(blocked) != 0 {
+		t.Fatal("peer blocklisted below the threshold")
+	}
+
+	// the repeated offences are blocklisted longer, then permanently
+	kad.ReportMisbehavior(peer, "test", 2)
+	expectBlocked(t, 2*time.Minute)
+	kad.ReportMisbehavior(peer, "test", 2)
+	expectBlocked(t, 0)
+}
+
+func TestMisbehaviorEscalationDuration(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		duration time.Duration
+		offence  int
+		want     time.Duration
+	}{
+		{duration: time.Minute, offence: 1, want: time.Minute},
+		{duration: time.Minute, offence: 3, want: 4 * time.Minute},
+		{duration: time.Minute, offence: 100, want: time.Minute << 16},
+		{duration: 40 * time.Hour, offence: 20, want: math.MaxInt64},
+	} {
+		if got := kademlia.EscalationDuration(tc.duration, tc.offence); got != tc.want {
+			t.Fatalf("duration %v offence %d: got %v, want %v", tc.duration, tc.offence, got, tc.want)
+		}
+	}
+}
+
+func TestClosestPeerLowLatency(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conns int32 // how many connect calls were made to the p2p mock
+		base  = swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000")
+		peer1 = swarm.MustParseHexAddress("4000000000000000000000000000000000000000000000000000000000000000") // binary 0100 -> po 2 to chunk
+		peer2 = swarm.MustParseHexAddress("5000000000000000000000000000000000000000000000000000000000000000") // binary 0101 -> po 2 to chunk, closer
+		peer3 = swarm.MustParseHexAddress("8000000000000000000000000000000000000000000000000000000000000000") // binary 1000 -> po 0 to chunk
+		chunk = swarm.MustParseHexAddress("7000000000000000000000000000000000000000000000000000000000000000") // binary 0111
+
+		_, kad, ab, _, signer = newTestKademliaWithAddr(t, base, &conns, nil, kademlia.Options{})
+	)
+
+	if err := kad.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, kad)
+
+	for _, peer := range []swarm.Address{peer1, peer2, peer3} {
+		connectOne(t, signer, kad, ab, peer, nil)
+	}
+	waitPeers(t, kad, 3)
+
+	kad.UpdatePeerHealth(peer1, true, 10*time.Millisecond)
+	kad.UpdatePeerHealth(peer2, true, 100*time.Millisecond)
+	kad.UpdatePeerHealth(peer3, true, time.Millisecond)
+
+	for _, tc := range []struct {
+		name   string
+		filter topology.Select
+		want   swarm.Address
+	}{
+		{"closest", topology.Select{}, peer2},
+		{"low latency", topology.Select{LowLatency: true}, peer1},
+	} {
+		have, err := kad.ClosestPeer(chunk, false, tc.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !have.Equal(tc.want) {
+			t.Fatalf("%s: ClosestPeer(...): have %s, want %s", tc.name, have, tc.want)
+		}
+	}
+}
+
+func TestDefaultPeerScore(t *testing.T) {
+	t.Parallel()
+
+	good := kademlia.PeerStats{
+		LatencyEWMA:               10 * time.Millisecond,
+		Healthy:                   true,
+		Reachable:                 true,
+		SessionConnectionDuration: time.Hour,
+	}
+
+	for _, tc := range []struct {
+		name  string
+		worse func(*kademlia.PeerStats)
+	}{
+		{"unhealthy", func(s *kademlia.PeerStats) { s.Healthy = false }},
+		{"unreachable", func(s *kademlia.PeerStats) { s.Reachable = false }},
+		{"slow", func(s *kademlia.PeerStats) { s.LatencyEWMA = time.Second }},
+		{"short session", func(s *kademlia.PeerStats) { s.SessionConnectionDuration = time.Minute }},
+		{"retried", func(s *kademlia.PeerStats) { s.SessionConnectionRetry = 3 }},
+		{"misbehaving", func(s *kademlia.PeerStats) { s.Misbehavior = 1 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			worse := good
+			tc.worse(&worse)
+			if have, want := kademlia.DefaultPeerScore(worse), kademlia.DefaultPeerScore(good); have >= want {
+				t.Fatalf("DefaultPeerScore(...): have %v, want less than %v", have, want)
+			}
+		})
+	}
+}
+
 func TestIteratorOpts(t *testing.T) {
 	t.Parallel()
 
@@ -1865,6 +2374,56 @@ func TestIteratorOpts(t *testing.T) {
 			t.Fatal("iterator returned error")
 		}
 	})
+
+	t.Run("EachConnectedPeer bin range", func(t *testing.T) {
+		t.Parallel()
+
+		var bins []uint8
+		err := kad.EachConnectedPeer(func(_ swarm.Address, bin uint8) (bool, bool, error) {
+			bins = append(bins, bin)
+			return false, false, nil
+		}, topology.Select{Bins: &topology.BinRange{Min: 2, Max: 3}})
+		if err != nil {
+			t.Fatal("iterator returned error")
+		}
+		if want := []uint8{3, 3, 3, 3, 2, 2, 2, 2}; !slices.Equal(bins, want) {
+			t.Fatalf("iterated bins: got %v, want %v", bins, want)
+		}
+	})
+
+	t.Run("EachConnectedPeerRev max per bin", func(t *testing.T) {
+		t.Parallel()
+
+		var bins []uint8
+		err := kad.EachConnectedPeerRev(func(_ swarm.Address, bin uint8) (bool, bool, error) {
+			bins = append(bins, bin)
+			return false, false, nil
+		}, topology.Select{MaxPerBin: 2, Bins: &topology.BinRange{Min: 4, Max: swarm.MaxPO}})
+		if err != nil {
+			t.Fatal("iterator returned error")
+		}
+		if want := []uint8{4, 4, 5, 5}; !slices.Equal(bins, want) {
+			t.Fatalf("iterated bins: got %v, want %v", bins, want)
+		}
+	})
+
+	t.Run("EachConnectedPeer random", func(t *testing.T) {
+		t.Parallel()
+
+		sampled := make(map[string]struct{})
+		for i := 0; i < 100 && len(sampled) < 2; i++ {
+			err := kad.EachConnectedPeer(func(addr swarm.Address, _ uint8) (bool, bool, error) {
+				sampled[addr.ByteString()] = struct{}{}
+				return false, false, nil
+			}, topology.Select{MaxPerBin: 1, Random: true, Bins: &topology.BinRange{Min: 1, Max: 1}})
+			if err != nil {
+				t.Fatal("iterator returned error")
+			}
+		}
+		if len(sampled) < 2 {
+			t.Fatal("iterator did not sample random peers")
+		}
+	})
 }
 
 type boolgen struct {
@@ -2212,3 +2771,7 @@ func ptrInt(v int) *int {
 func ptrDuration(v time.Duration) *time.Duration {
 	return &v
 }
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
@@ -31,6 +31,9 @@ type metrics struct {
 	Blocklist                             prometheus.Counter
 	ReachabilityStatus                    *prometheus.GaugeVec
 	PeersReachabilityStatus               *prometheus.GaugeVec
+	MisbehaviorReports                    prometheus.Counter
+	HealthCheckFailures                   prometheus.Counter
+	MisbehaviorBlocklists                 prometheus.Counter
 }
 
 // newMetrics is a convenient constructor for creating new metrics.
@@ -164,6 +167,24 @@ func newMetrics() metrics {
 			},
 			[]string{"peers_reachability_status"},
 		),
+		MisbehaviorReports: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "misbehavior_reports",
+			Help:      "The number of reported peer misbehaviors.",
+		}),
+		HealthCheckFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "health_check_failures",
+			Help:      "The number of failed peer health checks.",
+		}),
+		MisbehaviorBlocklists: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "misbehavior_blocklists",
+			Help:      "The number of peers blocklisted for misbehavior.",
+		}),
 	}
 }
 
@@ -44,7 +44,7 @@ type Mock struct {
 	depth        uint8
 	depthReplies []uint8
 	depthCalls   int
-	trigs        []chan struct{}
+	trigs        []chan topology.Change
 	trigMtx      sync.Mutex
 }
 
@@ -79,6 +79,8 @@ func (m *Mock) UpdatePeerHealth(swarm.Address, bool, time.Duration) {
 	panic("not implemented") // TODO: Implement
 }
 
+func (m *Mock) ReportMisbehavior(swarm.Address, string, float64) {}
+
 // PeerIterator iterates from closest bin to farthest
 func (m *Mock) SetStorageRadius(uint8) {
 	panic("not implemented")
@@ -165,8 +167,8 @@ func (m *Mock) AnnounceTo(_ context.Context, _, _ swarm.Address, _ bool) error {
 	return nil
 }
 
-func (m *Mock) SubscribeTopologyChange() (c <-chan struct{}, unsubscribe func()) {
-	channel := make(chan struct{}, 1)
+func (m *Mock) SubscribeTopologyChange() (c <-chan topology.Change, unsubscribe func()) {
+	channel := make(chan topology.Change, 1)
 	var closeOnce sync.Once
 
 	m.trigMtx.Lock()
@@ -190,13 +192,21 @@ func (m *Mock) SubscribeTopologyChange() (c <-chan struct{}, unsubscribe func())
 	return channel, unsubscribe
 }
 
+func (m *Mock) MetricsHistory(swarm.Address, int) ([]topology.MetricsRecord, error) {
+	return nil, nil
+}
+
+func (m *Mock) SuggestNeighborhood() string {
+	return ""
+}
+
 func (m *Mock) Trigger() {
 	m.trigMtx.Lock()
 	defer m.trigMtx.Unlock()
 
 	for _, c := range m.trigs {
 		select {
-		case c <- struct{}{}:
+		case c <- topology.Change{Time: time.Now()}:
 		default:
 		}
 	}
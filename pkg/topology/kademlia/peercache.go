@@ -0,0 +1,150 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/bzz"
+	"github.com/calmw/bee-tron/pkg/shed"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	peerCacheFieldName = "kademlia-peer-cache"
+
+	maxCachedPeers            = 128 // the number of peers persisted for a warm restart, the deepest bins first
+	cachedPeerConnectWorkers  = 16  // the number of cached peers dialed concurrently on start
+	cachedPeerConnectDeadline = 2 * peerConnectionAttemptTimeout
+)
+
+// cachedPeer is a recently connected, healthy peer persisted so that a
+// restarted node reconnects to it without discovering it again.
+type cachedPeer struct {
+	Address  *bzz.Address `json:"address"`
+	Bin      uint8        `json:"bin"`
+	LastSeen int64        `json:"lastSeen"`
+}
+
+// peerCache persists the cached peers.
+type peerCache struct {
+	field shed.StructField
+}
+
+func newPeerCache(db *shed.DB) (*peerCache, error) {
+	field, err := db.NewStructField(peerCacheFieldName)
+	if err != nil {
+		return nil, fmt.Errorf("field initialization for %q failed: %w", peerCacheFieldName, err)
+	}
+	return &peerCache{field: field}, nil
+}
+
+func (c *peerCache) load() ([]cachedPeer, error) {
+	var peers []cachedPeer
+	if err := c.field.Get(&peers); err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return nil, err
+	}
+	return peers, nil
+}
+
+func (c *peerCache) store(peers []cachedPeer) error {
+	return c.field.Put(peers)
+}
+
+// cachePeers persists the connected, healthy peers.
+func (k *Kad) cachePeers() error {
+	now := time.Now().Unix()
+
+	var peers []cachedPeer
+	_ = k.connectedPeers.EachBin(func(addr swarm.Address, po uint8) (bool, bool, error) {
+		if ss := k.collector.Inspect(addr); ss == nil || !ss.Healthy {
+			return false, false, nil
+		}
+		bzzAddr, err := k.addressBook.Get(addr)
+		if err != nil {
+			return false, false, nil
+		}
+		peers = append(peers, cachedPeer{Address: bzzAddr, Bin: po, LastSeen: now})
+		return false, false, nil
+	})
+
+	// EachBin iterates from the deepest bin, keep the neighborhood
+	if len(peers) > maxCachedPeers {
+		peers = peers[:maxCachedPeers]
+	}
+
+	if err := k.peerCache.store(peers); err != nil {
+		return fmt.Errorf("unable to persist cached peers: %w", err)
+	}
+	return nil
+}
+
+// connectCachedPeers dials the persisted peers, the deepest bins first.
+func (k *Kad) connectCachedPeers(ctx context.Context) {
+	peers, err := k.peerCache.load()
+	if err != nil {
+		k.logger.Debug("unable to load cached peers", "error", err)
+		return
+	}
+	if len(peers) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(peers, func(a, b cachedPeer) int {
+		return int(b.Bin) - int(a.Bin)
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, cachedPeerConnectDeadline)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		sem       = make(chan struct{}, cachedPeerConnectWorkers)
+		connected atomic.Int32
+		start     = time.Now()
+	)
+loop:
+	for _, peer := range peers {
+		if peer.Address == nil || peer.Address.Overlay.Equal(k.base) {
+			continue
+		}
+		if err := k.addressBook.Put(peer.Address.Overlay, *peer.Address); err != nil {
+			k.logger.Debug("unable to add cached peer to address book", "peer_address", peer.Address.Overlay, "error", err)
+			continue
+		}
+		k.knownPeers.Add(peer.Address.Overlay)
+
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-k.quit:
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(addr *bzz.Address) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := k.connect(ctx, addr.Overlay, addr.Underlay); err != nil {
+				k.logger.Debug("connect to cached peer failed", "peer_address", addr.Overlay, "error", err)
+				return
+			}
+			k.connectedOutbound(addr.Overlay, swarm.Proximity(k.base.Bytes(), addr.Overlay.Bytes()))
+			connected.Add(1)
+		}(peer.Address)
+	}
+	wg.Wait()
+
+	k.logger.Info("connected to cached peers", "connected", connected.Load(), "cached", len(peers), "elapsed", time.Since(start))
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	im "github.com/calmw/bee-tron/pkg/topology/kademlia/internal/metrics"
+)
+
+const (
+	defaultMinPeerScore = -1.0 // peers scored below are avoided as the closest peer
+
+	scoreHealthWeight       = 1.0
+	scoreReachabilityWeight = 0.5
+	scoreLatencyWeight      = 1.0
+	scoreStabilityWeight    = 1.0
+	scoreMisbehaviorWeight  = 1.0
+
+	// scoreLatencyReference is the latency at which a peer gets half of the
+	// latency score.
+	scoreLatencyReference = 100 * time.Millisecond
+	// scoreStabilityReference is the duration of the connection session at
+	// which a peer gets half of the stability score.
+	scoreStabilityReference = 10 * time.Minute
+)
+
+// PeerStats are the observations about a peer the peer score is computed from.
+type PeerStats struct {
+	// LatencyEWMA is the moving average of the peer latency, zero if it was
+	// not measured yet.
+	LatencyEWMA time.Duration
+	// Healthy tells whether the peer passed the last health check.
+	Healthy bool
+	// Reachable tells whether the peer is publicly reachable.
+	Reachable bool
+	// SessionConnectionDuration is the duration of the current connection
+	// session.
+	SessionConnectionDuration time.Duration
+	// SessionConnectionRetry is the number of the connection attempts of
+	// the session.
+	SessionConnectionRetry uint64
+	// Misbehavior is the weight of the reported protocol violations, which
+	// decays over time.
+	Misbehavior float64
+}
+
+// PeerScoreFunc scores a peer from its stats, peers with a higher score are
+// preferred.
+type PeerScoreFunc func(PeerStats) float64
+
+// DefaultPeerScore rewards healthy, reachable, fast and stable peers and
+// penalizes the reported misbehavior.
+func DefaultPeerScore(s PeerStats) float64 {
+	var score float64
+
+	if s.Healthy {
+		score += scoreHealthWeight
+	}
+	if s.Reachable {
+		score += scoreReachabilityWeight
+	}
+	if s.LatencyEWMA > 0 {
+		score += scoreLatencyWeight * float64(scoreLatencyReference) / float64(scoreLatencyReference+s.LatencyEWMA)
+	}
+
+	stability := float64(s.SessionConnectionDuration) / float64(scoreStabilityReference+s.SessionConnectionDuration)
+	score += scoreStabilityWeight * stability / float64(1+s.SessionConnectionRetry)
+
+	return score - scoreMisbehaviorWeight*s.Misbehavior
+}
+
+// newPeerStats creates new PeerStats from the given metrics.Snapshot.
+func newPeerStats(ss *im.Snapshot) PeerStats {
+	if ss == nil {
+		return PeerStats{}
+	}
+	return PeerStats{
+		LatencyEWMA:               ss.LatencyEWMA,
+		Healthy:                   ss.Healthy,
+		Reachable:                 ss.Reachability == p2p.ReachabilityStatusPublic,
+		SessionConnectionDuration: ss.SessionConnectionDuration,
+		SessionConnectionRetry:    ss.SessionConnectionRetry,
+		Misbehavior:               ss.Misbehavior,
+	}
+}
+
+// peerScore returns the current score of the peer.
+func (k *Kad) peerScore(peer swarm.Address) float64 {
+	return k.opt.PeerScoreFunc(newPeerStats(k.collector.Inspect(peer)))
+}
+
+// lowestScoredPeer returns the peer with the lowest score, ties are broken by
+// the order of the peers.
+func (k *Kad) lowestScoredPeer(peers []swarm.Address) swarm.Address {
+	lowest := swarm.ZeroAddress
+	var lowestScore float64
+	for _, peer := range peers {
+		if score := k.peerScore(peer); lowest.IsZero() || score < lowestScore {
+			lowest, lowestScore = peer, score
+		}
+	}
+	return lowest
+}
+
+// ReportMisbehavior implements topology.MisbehaviorReporter interface.
+func (k *Kad) ReportMisbehavior(peer swarm.Address, reason string, weight float64) {
+	now := time.Now()
+	k.collector.Record(peer, im.PeerMisbehavior(now, weight))
+	k.metrics.MisbehaviorReports.Inc()
+	k.logger.Debug("peer misbehavior reported", "peer_address", peer, "reason", reason, "weight", weight)
+
+	k.escalateMisbehavior(peer, reason, now)
+}
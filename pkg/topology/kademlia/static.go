@@ -0,0 +1,155 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kademlia
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/p2p"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	im "github.com/calmw/bee-tron/pkg/topology/kademlia/internal/metrics"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// staticPeerReconnect is the interval in which the disconnected static peers
+// are dialed again.
+const staticPeerReconnect = 15 * time.Second
+
+// staticPeers keeps the overlays of the operator configured static peers,
+// which are learned once the peers are connected.
+type staticPeers struct {
+	mu       sync.RWMutex
+	overlays map[string]ma.Multiaddr // overlay -> configured underlay
+}
+
+func newStaticPeers() *staticPeers {
+	return &staticPeers{overlays: make(map[string]ma.Multiaddr)}
+}
+
+func (s *staticPeers) add(overlay swarm.Address, addr ma.Multiaddr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overlays[overlay.ByteString()] = addr
+}
+
+func (s *staticPeers) has(overlay swarm.Address) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.overlays[overlay.ByteString()]
+	return ok
+}
+
+// connected returns the configured underlays of the static peers which are
+// connected.
+func (s *staticPeers) connected(isConnected func(swarm.Address) bool) []ma.Multiaddr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var addrs []ma.Multiaddr
+	for overlay, addr := range s.overlays {
+		if isConnected(swarm.NewAddress([]byte(overlay))) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// manageStaticPeers dials the static peers which are not connected until the
+// kademlia is halted or closed.
+func (k *Kad) manageStaticPeers(ctx context.Context) {
+	defer k.wg.Done()
+
+	if len(k.opt.StaticPeers) == 0 {
+		return
+	}
+
+	for {
+		k.connectStaticPeers(ctx)
+
+		select {
+		case <-k.halt:
+			return
+		case <-k.quit:
+			return
+		case <-k.staticC:
+		case <-time.After(staticPeerReconnect):
+		}
+	}
+}
+
+// connectStaticPeers dials the static peers which are not connected.
+func (k *Kad) connectStaticPeers(ctx context.Context) {
+	connected := k.staticPeers.connected(k.connectedPeers.Exists)
+
+	for _, addr := range k.opt.StaticPeers {
+		if containsMultiaddr(connected, addr) {
+			continue
+		}
+
+		select {
+		case <-k.halt:
+			return
+		case <-k.quit:
+			return
+		default:
+		}
+
+		if err := k.connectStaticPeer(ctx, addr); err != nil {
+			k.logger.Debug("connect to static peer failed", "peer_address", addr, "error", err)
+		}
+	}
+}
+
+// connectStaticPeer dials the static peer with the given underlay.
+func (k *Kad) connectStaticPeer(ctx context.Context, addr ma.Multiaddr) error {
+	ctx, cancel := context.WithTimeout(ctx, peerConnectionAttemptTimeout)
+	defer cancel()
+
+	k.metrics.TotalOutboundConnectionAttempts.Inc()
+
+	bzzAddress, err := k.p2p.Connect(ctx, addr)
+	switch {
+	case errors.Is(err, p2p.ErrAlreadyConnected) && bzzAddress != nil:
+		k.staticPeers.add(bzzAddress.Overlay, addr)
+		if k.connectedPeers.Exists(bzzAddress.Overlay) {
+			return nil
+		}
+	case err != nil:
+		k.metrics.TotalOutboundConnectionFailedAttempts.Inc()
+		return err
+	default:
+		k.staticPeers.add(bzzAddress.Overlay, addr)
+	}
+
+	if err := k.onConnected(ctx, bzzAddress.Overlay); err != nil {
+		_ = k.p2p.Disconnect(bzzAddress.Overlay, "failed to process static peer")
+		return err
+	}
+
+	k.metrics.TotalOutboundConnections.Inc()
+	k.collector.Record(bzzAddress.Overlay, im.PeerLogIn(time.Now(), im.PeerConnectionDirectionOutbound))
+	k.logger.Debug("connected to static peer", "peer_address", bzzAddress.Overlay, "underlay", addr)
+	return nil
+}
+
+// notifyStaticPeers triggers dialing the disconnected static peers.
+func (k *Kad) notifyStaticPeers() {
+	select {
+	case k.staticC <- struct{}{}:
+	default:
+	}
+}
+
+func containsMultiaddr(addrs []ma.Multiaddr, addr ma.Multiaddr) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,14 @@ type mock struct {
 	marshalJSONFunc func() ([]byte, error)
 	mtx             sync.Mutex
 	health          map[string]bool
+	misbehavior     map[string]float64
+	reachability    p2p.ReachabilityStatus
+	neighborhood    string
+	changes         []topology.Change
+	peerErrs        map[string]error
+	latency         time.Duration
+	peersFunc       func() []swarm.Address
+	history         map[string][]topology.MetricsRecord
 }
 
 var _ topology.Driver = (*mock)(nil)
@@ -72,6 +80,56 @@ func WithIsWithinFunc(f func(swarm.Address) bool) Option {
 	})
 }
 
+func WithSuggestedNeighborhood(neighborhood string) Option {
+	return optionFunc(func(d *mock) {
+		d.neighborhood = neighborhood
+	})
+}
+
+// WithChanges sets the topology changes delivered to every subscriber.
+func WithChanges(changes ...topology.Change) Option {
+	return optionFunc(func(d *mock) {
+		d.changes = changes
+	})
+}
+
+// WithPeerErr makes ClosestPeer return the error whenever the peer is the
+// closest one.
+func WithPeerErr(peer swarm.Address, err error) Option {
+	return optionFunc(func(d *mock) {
+		if d.peerErrs == nil {
+			d.peerErrs = make(map[string]error)
+		}
+		d.peerErrs[peer.ByteString()] = err
+	})
+}
+
+// WithLatency delays every ClosestPeer call and peer iteration.
+func WithLatency(latency time.Duration) Option {
+	return optionFunc(func(d *mock) {
+		d.latency = latency
+	})
+}
+
+// WithPeersFunc sets the function returning the connected peers on every
+// call, which replaces the static peers to simulate the churn.
+func WithPeersFunc(f func() []swarm.Address) Option {
+	return optionFunc(func(d *mock) {
+		d.peersFunc = f
+	})
+}
+
+// WithMetricsHistory sets the metrics snapshots of the peer, the newest
+// first.
+func WithMetricsHistory(peer swarm.Address, records ...topology.MetricsRecord) Option {
+	return optionFunc(func(d *mock) {
+		if d.history == nil {
+			d.history = make(map[string][]topology.MetricsRecord)
+		}
+		d.history[peer.ByteString()] = records
+	})
+}
+
 func NewTopologyDriver(opts ...Option) *mock {
 	d := new(mock)
 	for _, o := range opts {
@@ -79,6 +137,7 @@ func NewTopologyDriver(opts ...Option) *mock {
 	}
 
 	d.health = map[string]bool{}
+	d.misbehavior = map[string]float64{}
 
 	return d
 }
@@ -116,6 +175,33 @@ func (d *mock) UpdatePeerHealth(peer swarm.Address, health bool, pingDur time.Du
 	d.health[peer.ByteString()] = health
 }
 
+func (d *mock) ReportMisbehavior(peer swarm.Address, _ string, weight float64) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.misbehavior[peer.ByteString()] += weight
+}
+
+// Misbehavior returns the sum of the weights of the misbehavior reported for
+// the peer.
+func (d *mock) Misbehavior(peer swarm.Address) float64 {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.misbehavior[peer.ByteString()]
+}
+
+func (d *mock) UpdateReachability(status p2p.ReachabilityStatus) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.reachability = status
+}
+
+// Reachability returns the last reachability status of the node.
+func (d *mock) Reachability() p2p.ReachabilityStatus {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.reachability
+}
+
 func (d *mock) PeersHealth() map[string]bool {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
@@ -123,28 +209,55 @@ func (d *mock) PeersHealth() map[string]bool {
 }
 
 func (d *mock) Peers() []swarm.Address {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.connectedPeers()
+}
+
+// SetPeers replaces the connected peers.
+func (d *mock) SetPeers(peers ...swarm.Address) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.peers = peers
+}
+
+// connectedPeers returns the connected peers, must be called under lock.
+func (d *mock) connectedPeers() []swarm.Address {
+	if d.peersFunc != nil {
+		return d.peersFunc()
+	}
 	return d.peers
 }
 
+// delay waits for the configured latency.
+func (d *mock) delay() {
+	if d.latency > 0 {
+		time.Sleep(d.latency)
+	}
+}
+
 func (d *mock) ClosestPeer(addr swarm.Address, wantSelf bool, _ topology.Select, skipPeers ...swarm.Address) (peerAddr swarm.Address, err error) {
+	d.delay()
+
 	if len(skipPeers) == 0 {
 		if d.closestPeerErr != nil {
 			return d.closestPeer, d.closestPeerErr
 		}
 		if !d.closestPeer.Equal(swarm.ZeroAddress) {
-			return d.closestPeer, nil
+			return d.closestPeer, d.peerErr(d.closestPeer)
 		}
 	}
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	if len(d.peers) == 0 {
+	peers := d.connectedPeers()
+	if len(peers) == 0 {
 		return peerAddr, topology.ErrNotFound
 	}
 
 	skipPeer := false
-	for _, p := range d.peers {
+	for _, p := range peers {
 		for _, a := range skipPeers {
 			if a.Equal(p) {
 				skipPeer = true
@@ -173,15 +286,26 @@ func (d *mock) ClosestPeer(addr swarm.Address, wantSelf bool, _ topology.Select,
 		}
 	}
 
-	return peerAddr, nil
+	return peerAddr, d.peerErrs[peerAddr.ByteString()]
+}
+
+// peerErr returns the error injected for the peer.
+func (d *mock) peerErr(peer swarm.Address) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.peerErrs[peer.ByteString()]
 }
 
 func (m *mock) IsReachable() bool {
 	return true
 }
 
-func (d *mock) SubscribeTopologyChange() (c <-chan struct{}, unsubscribe func()) {
-	return c, unsubscribe
+func (d *mock) SubscribeTopologyChange() (c <-chan topology.Change, unsubscribe func()) {
+	channel := make(chan topology.Change, len(d.changes))
+	for _, change := range d.changes {
+		channel <- change
+	}
+	return channel, func() {}
 }
 
 func (m *mock) NeighborhoodDepth() uint8 {
@@ -192,6 +316,8 @@ func (m *mock) SetStorageRadius(uint8) {}
 
 // EachConnectedPeer implements topology.PeerIterator interface.
 func (d *mock) EachConnectedPeer(f topology.EachPeerFunc, _ topology.Select) (err error) {
+	d.delay()
+
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
@@ -199,7 +325,7 @@ func (d *mock) EachConnectedPeer(f topology.EachPeerFunc, _ topology.Select) (er
 		return d.peersErr
 	}
 
-	for i, p := range d.peers {
+	for i, p := range d.connectedPeers() {
 		_, _, err = f(p, uint8(i))
 		if err != nil {
 			return
@@ -211,11 +337,14 @@ func (d *mock) EachConnectedPeer(f topology.EachPeerFunc, _ topology.Select) (er
 
 // EachConnectedPeerRev implements topology.PeerIterator interface.
 func (d *mock) EachConnectedPeerRev(f topology.EachPeerFunc, _ topology.Select) (err error) {
+	d.delay()
+
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	for i := len(d.peers) - 1; i >= 0; i-- {
-		_, _, err = f(d.peers[i], uint8(i))
+	peers := d.connectedPeers()
+	for i := len(peers) - 1; i >= 0; i-- {
+		_, _, err = f(peers[i], uint8(i))
 		if err != nil {
 			return
 		}
@@ -224,10 +353,25 @@ func (d *mock) EachConnectedPeerRev(f topology.EachPeerFunc, _ topology.Select)
 	return nil
 }
 
+func (d *mock) MetricsHistory(peer swarm.Address, limit int) ([]topology.MetricsRecord, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	records := d.history[peer.ByteString()]
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
 func (d *mock) Snapshot() *topology.KadParams {
 	return new(topology.KadParams)
 }
 
+func (d *mock) SuggestNeighborhood() string {
+	return d.neighborhood
+}
+
 func (d *mock) Halt()        {}
 func (d *mock) Close() error { return nil }
 
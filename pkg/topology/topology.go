@@ -26,13 +26,75 @@ type Driver interface {
 	PeerAdder
 	ClosestPeerer
 	PeerIterator
-	SubscribeTopologyChange() (c <-chan struct{}, unsubscribe func())
+	SubscribeTopologyChange() (c <-chan Change, unsubscribe func())
 	io.Closer
 	Halter
 	Snapshot() *KadParams
 	IsReachable() bool
 	SetStorageRadiuser
 	UpdatePeerHealth(addr swarm.Address, h bool, t time.Duration)
+	MisbehaviorReporter
+	MetricsHistorian
+	NeighborhoodSuggester
+}
+
+// ChangeType is the type of the topology change.
+type ChangeType string
+
+const (
+	ChangePeerAdded   ChangeType = "added"   // a peer was connected
+	ChangePeerRemoved ChangeType = "removed" // a peer was disconnected
+	ChangeRadius      ChangeType = "radius"  // the storage radius changed
+)
+
+// ChangeReason tells why the peer was added or removed.
+type ChangeReason string
+
+const (
+	ReasonConnected    ChangeReason = "connected"
+	ReasonDisconnected ChangeReason = "disconnected"
+	ReasonPruned       ChangeReason = "pruned"
+	ReasonBlocklisted  ChangeReason = "blocklisted"
+)
+
+// Change is an event delivered to the subscribers of the topology changes.
+type Change struct {
+	Type   ChangeType    `json:"type"`
+	Peer   swarm.Address `json:"peer"`
+	Bin    uint8         `json:"bin"`
+	Reason ChangeReason  `json:"reason,omitempty"`
+	Radius uint8         `json:"radius"`
+	Time   time.Time     `json:"time"`
+}
+
+// MisbehaviorReporter is used by the protocols to report peers which violate
+// them, so that the topology can prefer better behaving peers.
+type MisbehaviorReporter interface {
+	// ReportMisbehavior reports a violation by the peer, the weight telling
+	// how severe the violation is.
+	ReportMisbehavior(peer swarm.Address, reason string, weight float64)
+}
+
+// MetricsHistorian provides the periodic snapshots of the peer metrics.
+type MetricsHistorian interface {
+	// MetricsHistory returns up to limit of the latest metrics snapshots of
+	// the peer, the newest first, all of them if the limit is zero.
+	MetricsHistory(peer swarm.Address, limit int) ([]MetricsRecord, error)
+}
+
+// NeighborhoodSuggester suggests the neighborhood new nodes should be placed in.
+type NeighborhoodSuggester interface {
+	// SuggestNeighborhood returns the least populated neighborhood at the
+	// storage radius as seen by the node, in binary format, or an empty
+	// string if there is no suggestion.
+	SuggestNeighborhood() string
+}
+
+// PeerLatencyer provides the measured latencies of the connected peers.
+type PeerLatencyer interface {
+	// PeerLatency returns the moving average of the latency of the peer,
+	// zero if it was not measured yet.
+	PeerLatency(peer swarm.Address) time.Duration
 }
 
 type PeerAdder interface {
@@ -63,6 +125,32 @@ type PeerIterator interface {
 type Select struct {
 	Reachable bool
 	Healthy   bool
+	// LowLatency makes ClosestPeer prefer the peer with the lowest latency
+	// among the peers with the same proximity order to the address. It is
+	// ignored by the peer iterators.
+	LowLatency bool
+	// Bins limits the peer iterators to the peers in the range of bins, all
+	// bins are iterated if it is nil. It is ignored by ClosestPeer.
+	Bins *BinRange
+	// MaxPerBin limits the number of peers the peer iterators call the
+	// function with in every bin, zero means no limit. It is ignored by
+	// ClosestPeer.
+	MaxPerBin int
+	// Random makes the peer iterators visit the peers of every bin in a
+	// random order, which together with MaxPerBin samples random peers from
+	// every bin. It is ignored by ClosestPeer.
+	Random bool
+}
+
+// BinRange is an inclusive range of bins.
+type BinRange struct {
+	Min uint8
+	Max uint8
+}
+
+// Contains tells whether the bin is in the range.
+func (r BinRange) Contains(bin uint8) bool {
+	return bin >= r.Min && bin <= r.Max
 }
 
 // EachPeerFunc is a callback that is called with a peer and its PO
@@ -86,6 +174,14 @@ type MetricSnapshotView struct {
 	Healthy                    bool    `json:"healthy"`
 }
 
+// MetricsRecord is a snapshot of the peer metrics taken while the peer was
+// connected.
+type MetricsRecord struct {
+	Timestamp int64               `json:"timestamp"`
+	Bin       uint8               `json:"bin"`
+	Metrics   *MetricSnapshotView `json:"metrics"`
+}
+
 type BinInfo struct {
 	BinPopulation     uint        `json:"population"`
 	BinConnected      uint        `json:"connected"`
@@ -4,6 +4,19 @@
 
 package transaction
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
 var (
-	StoredTransactionKey = storedTransactionKey
+	StoredTransactionKey  = storedTransactionKey
+	ResubmissionKey       = resubmissionKey
+	NonceKey              = nonceKey
+	PendingTransactionKey = pendingTransactionKey
+	ReceiptKey            = receiptKey
 )
+
+func StoreReceipt(s Service, txHash common.Hash, receipt *types.Receipt) error {
+	return s.(*transactionService).storeReceipt(txHash, receipt)
+}
@@ -0,0 +1,9 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package failover
+
+func (b *Backend) CheckHealth() {
+	b.checkHealth()
+}
@@ -0,0 +1,470 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package failover provides a transaction.Backend spreading over multiple
+// blockchain rpc endpoints. Requests are sent to the active endpoint only.
+// Endpoints are health-checked periodically, and if the active endpoint
+// fails or falls behind, another endpoint takes over.
+package failover
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/transaction"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "chainfailover"
+
+const (
+	// DefaultHealthCheckInterval is the default time between health checks of the endpoints.
+	DefaultHealthCheckInterval = 15 * time.Second
+	// healthCheckTimeout bounds the duration of the health check of a single endpoint.
+	healthCheckTimeout = 10 * time.Second
+	// maxBlockLag is the number of blocks an endpoint may be behind the others and still be considered healthy.
+	maxBlockLag = 5
+)
+
+// ErrNoEndpoints is returned if a backend is created without endpoints.
+var ErrNoEndpoints = errors.New("no blockchain rpc endpoints")
+
+var (
+	_ transaction.Backend        = (*Backend)(nil)
+	_ transaction.HeadSubscriber = (*Backend)(nil)
+)
+
+// Endpoint is a blockchain rpc endpoint.
+type Endpoint struct {
+	Name    string // identifies the endpoint in logs, must not contain credentials
+	Backend transaction.Backend
+}
+
+type endpoint struct {
+	Endpoint
+	healthy bool
+}
+
+// Backend is a transaction.Backend failing over between multiple endpoints.
+// Endpoints are preferred in the order they are given.
+type Backend struct {
+	logger   log.Logger
+	metrics  metrics
+	maxDelay time.Duration
+	interval time.Duration
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewBackend creates a new Backend. Endpoints are considered out of sync if
+// their latest block is older than maxDelay, and are health-checked every
+// interval.
+func NewBackend(logger log.Logger, endpoints []Endpoint, maxDelay, interval time.Duration) (*Backend, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	b := &Backend{
+		logger:   logger.WithName(loggerName).Register(),
+		metrics:  newMetrics(),
+		maxDelay: maxDelay,
+		interval: interval,
+		quit:     make(chan struct{}),
+	}
+	for _, e := range endpoints {
+		// endpoints are assumed to be healthy until the first check
+		b.endpoints = append(b.endpoints, &endpoint{Endpoint: e, healthy: true})
+	}
+	b.setActive(0)
+
+	return b, nil
+}
+
+// Start starts the periodic health checks.
+func (b *Backend) Start() {
+	b.wg.Add(1)
+	go b.healthCheckLoop()
+}
+
+// Active returns the name of the active endpoint.
+func (b *Backend) Active() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.endpoints[b.active].Name
+}
+
+func (b *Backend) healthCheckLoop() {
+	defer b.wg.Done()
+
+	b.checkHealth()
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.quit:
+			return
+		case <-ticker.C:
+			b.checkHealth()
+		}
+	}
+}
+
+// checkHealth checks all endpoints and activates the most preferred healthy one.
+func (b *Backend) checkHealth() {
+	b.mu.Lock()
+	endpoints := make([]Endpoint, len(b.endpoints))
+	for i, e := range b.endpoints {
+		endpoints[i] = e.Endpoint
+	}
+	b.mu.Unlock()
+
+	type result struct {
+		latency     time.Duration
+		blockNumber uint64
+		err         error
+	}
+
+	results := make([]result, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e Endpoint) {
+			defer wg.Done()
+			results[i].latency, results[i].blockNumber, results[i].err = b.checkEndpoint(e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, r := range results {
+		if r.err == nil && r.blockNumber > highest {
+			highest = r.blockNumber
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, r := range results {
+		e := b.endpoints[i]
+		err := r.err
+		if err == nil && r.blockNumber+maxBlockLag < highest {
+			err = errors.New("behind other endpoints")
+		}
+
+		if err != nil && e.healthy {
+			b.logger.Warning("blockchain rpc endpoint unhealthy", "endpoint", e.Name, "error", err)
+		} else if err == nil && !e.healthy {
+			b.logger.Info("blockchain rpc endpoint healthy again", "endpoint", e.Name)
+		}
+
+		e.healthy = err == nil
+
+		label := endpointLabel(i)
+		b.metrics.EndpointHealthy.WithLabelValues(label).Set(boolToFloat(e.healthy))
+		b.metrics.EndpointLatency.WithLabelValues(label).Set(r.latency.Seconds())
+		b.metrics.EndpointBlockNumber.WithLabelValues(label).Set(float64(r.blockNumber))
+	}
+
+	for i, e := range b.endpoints {
+		if e.healthy {
+			if i != b.active {
+				b.logger.Info("switching blockchain rpc endpoint", "from", b.endpoints[b.active].Name, "to", e.Name)
+				b.metrics.Failovers.Inc()
+				b.setActive(i)
+			}
+			return
+		}
+	}
+	b.logger.Error(nil, "no healthy blockchain rpc endpoint", "active", b.endpoints[b.active].Name)
+}
+
+// checkEndpoint measures the latency of the endpoint and verifies it is synced.
+func (b *Backend) checkEndpoint(e Endpoint) (time.Duration, uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	blockNumber, err := e.Backend.BlockNumber(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	latency := time.Since(start)
+
+	header, err := e.Backend.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return latency, blockNumber, err
+	}
+	if blockTime := time.Unix(int64(header.Time), 0); blockTime.Before(time.Now().Add(-b.maxDelay)) {
+		return latency, blockNumber, errors.New("not synced")
+	}
+
+	return latency, blockNumber, nil
+}
+
+// setActive activates the endpoint at index i. Must be called with the lock held.
+func (b *Backend) setActive(i int) {
+	b.active = i
+	for j := range b.endpoints {
+		value := 0.0
+		if j == i {
+			value = 1
+		}
+		b.metrics.ActiveEndpoint.WithLabelValues(endpointLabel(j)).Set(value)
+	}
+}
+
+// endpointLabel returns the metrics label of the endpoint at index i. The
+// endpoints are labeled by their index in the order of preference, as their
+// names may be shared by several endpoints of the same host.
+func endpointLabel(i int) string {
+	return strconv.Itoa(i)
+}
+
+// candidates returns the endpoints to try in order: the active one first,
+// followed by the healthy and then the unhealthy ones in order of preference.
+func (b *Backend) candidates() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	candidates := []int{b.active}
+	for _, healthy := range []bool{true, false} {
+		for i, e := range b.endpoints {
+			if i != b.active && e.healthy == healthy {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+	return candidates
+}
+
+// do calls f with the endpoints until it succeeds or fails for reasons other than the endpoint.
+func (b *Backend) do(ctx context.Context, f func(backend transaction.Backend) error) error {
+	var err error
+	for _, i := range b.candidates() {
+		e := b.endpoints[i]
+
+		err = f(e.Backend)
+		if err == nil || !isEndpointError(ctx, err) {
+			b.mu.Lock()
+			if i != b.active {
+				b.logger.Info("switching blockchain rpc endpoint", "from", b.endpoints[b.active].Name, "to", e.Name)
+				b.metrics.Failovers.Inc()
+				b.setActive(i)
+			}
+			b.mu.Unlock()
+			return err
+		}
+
+		b.logger.Warning("blockchain rpc endpoint failed", "endpoint", e.Name, "error", err)
+
+		b.mu.Lock()
+		e.healthy = false
+		b.metrics.EndpointHealthy.WithLabelValues(endpointLabel(i)).Set(0)
+		b.mu.Unlock()
+	}
+	return err
+}
+
+// isEndpointError reports whether err is caused by the endpoint rather than by the request.
+func isEndpointError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return false
+	}
+	// the endpoint answered with an error, e.g. a reverted call
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// isAlreadyKnown reports whether err is the error of the endpoints on sending
+// a transaction which is already in their pool.
+func isAlreadyKnown(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Error()), "already known")
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func (b *Backend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		code, err = backend.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (b *Backend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		result, err = backend.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		header, err = backend.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (b *Backend) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		nonce, err = backend.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (b *Backend) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		price, err = backend.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (b *Backend) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		tip, err = backend.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (b *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		gas, err = backend.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction sends the transaction to the first available endpoint. A
+// transaction already known by an endpoint after the failover is considered
+// sent, as the failed endpoint may have broadcast it before it failed.
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	attempts := 0
+	return b.do(ctx, func(backend transaction.Backend) error {
+		attempts++
+		err := backend.SendTransaction(ctx, tx)
+		if attempts > 1 && isAlreadyKnown(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+func (b *Backend) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		receipt, err = backend.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (b *Backend) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		tx, isPending, err = backend.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+func (b *Backend) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		number, err = backend.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+func (b *Backend) BalanceAt(ctx context.Context, address common.Address, block *big.Int) (balance *big.Int, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		balance, err = backend.BalanceAt(ctx, address, block)
+		return err
+	})
+	return balance, err
+}
+
+func (b *Backend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		nonce, err = backend.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+func (b *Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		logs, err = backend.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+func (b *Backend) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		chainID, err = backend.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+// SubscribeNewHead subscribes to new heads of the first available endpoint.
+// The subscription is not moved to another endpoint on failover, it fails
+// with the endpoint instead and has to be renewed by the caller.
+func (b *Backend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (sub ethereum.Subscription, err error) {
+	err = b.do(ctx, func(backend transaction.Backend) error {
+		subscriber, ok := backend.(transaction.HeadSubscriber)
+		if !ok {
+			return rpc.ErrNotificationsUnsupported
+		}
+		sub, err = subscriber.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return sub, err
+}
+
+// Close stops the health checks and closes all endpoints.
+func (b *Backend) Close() {
+	select {
+	case <-b.quit:
+		return
+	default:
+	}
+	close(b.quit)
+	b.wg.Wait()
+
+	for _, e := range b.endpoints {
+		e.Backend.Close()
+	}
+}
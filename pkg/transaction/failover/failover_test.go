@@ -0,0 +1,241 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package failover_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/transaction"
+	"github.com/calmw/bee-tron/pkg/transaction/backendmock"
+	"github.com/calmw/bee-tron/pkg/transaction/failover"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type rpcError struct{}
+
+func (rpcError) Error() string  { return "execution reverted" }
+func (rpcError) ErrorCode() int { return 3 }
+
+type alreadyKnownError struct{}
+
+func (alreadyKnownError) Error() string  { return "already known" }
+func (alreadyKnownError) ErrorCode() int { return -32000 }
+
+// endpointMock is a backend reporting the given block, which is recent, or failing if err is set.
+type endpointMock struct {
+	block atomic.Uint64
+	err   atomic.Pointer[error]
+	calls atomic.Int64
+}
+
+func (e *endpointMock) fail(err error) {
+	e.err.Store(&err)
+}
+
+func (e *endpointMock) backend() transaction.Backend {
+	return backendmock.New(
+		backendmock.WithBlockNumberFunc(func(context.Context) (uint64, error) {
+			e.calls.Add(1)
+			if err := e.err.Load(); err != nil && *err != nil {
+				return 0, *err
+			}
+			return e.block.Load(), nil
+		}),
+		backendmock.WithHeaderbyNumberFunc(func(ctx context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{Number: number, Time: uint64(time.Now().Unix())}, nil
+		}),
+	)
+}
+
+func newEndpointMock(block uint64) *endpointMock {
+	e := new(endpointMock)
+	e.block.Store(block)
+	return e
+}
+
+func newBackend(t *testing.T, primary, secondary *endpointMock) *failover.Backend {
+	t.Helper()
+
+	b, err := failover.NewBackend(log.Noop, []failover.Endpoint{
+		{Name: "primary", Backend: primary.backend()},
+		{Name: "secondary", Backend: secondary.backend()},
+	}, time.Minute, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.Close)
+	return b
+}
+
+func TestFailoverOnEndpointError(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := newEndpointMock(10), newEndpointMock(11)
+	b := newBackend(t, primary, secondary)
+
+	primary.fail(errors.New("connection refused"))
+
+	block, err := b.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block != 11 {
+		t.Fatalf("got block %d, want 11", block)
+	}
+	if active := b.Active(); active != "secondary" {
+		t.Fatalf("active endpoint %s, want secondary", active)
+	}
+
+	// the failed endpoint is tried last
+	primary.calls.Store(0)
+	if _, err := b.BlockNumber(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls := primary.calls.Load(); calls != 0 {
+		t.Fatalf("failed endpoint called %d times", calls)
+	}
+}
+
+func TestNoFailoverOnRequestError(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := newEndpointMock(10), newEndpointMock(10)
+	b := newBackend(t, primary, secondary)
+
+	primary.fail(rpcError{})
+
+	_, err := b.BlockNumber(context.Background())
+	if !errors.Is(err, rpcError{}) {
+		t.Fatalf("got error %v, want %v", err, rpcError{})
+	}
+	if calls := secondary.calls.Load(); calls != 0 {
+		t.Fatalf("secondary endpoint called %d times", calls)
+	}
+	if active := b.Active(); active != "primary" {
+		t.Fatalf("active endpoint %s, want primary", active)
+	}
+}
+
+func TestNoFailoverOnSubscriptionNotSupported(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := newEndpointMock(10), newEndpointMock(10)
+	b := newBackend(t, primary, secondary)
+
+	_, err := b.SubscribeNewHead(context.Background(), make(chan *types.Header))
+	if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		t.Fatalf("got error %v, want %v", err, rpc.ErrNotificationsUnsupported)
+	}
+	if active := b.Active(); active != "primary" {
+		t.Fatalf("active endpoint %s, want primary", active)
+	}
+
+	// the endpoint is still healthy
+	if _, err := b.BlockNumber(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls := secondary.calls.Load(); calls != 0 {
+		t.Fatalf("secondary endpoint called %d times", calls)
+	}
+}
+
+func TestAllEndpointsFailing(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := newEndpointMock(10), newEndpointMock(10)
+	b := newBackend(t, primary, secondary)
+
+	primaryErr, secondaryErr := errors.New("primary down"), errors.New("secondary down")
+	primary.fail(primaryErr)
+	secondary.fail(secondaryErr)
+
+	_, err := b.BlockNumber(context.Background())
+	if !errors.Is(err, secondaryErr) {
+		t.Fatalf("got error %v, want %v", err, secondaryErr)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	primary, secondary := newEndpointMock(100), newEndpointMock(100)
+	b := newBackend(t, primary, secondary)
+
+	primary.fail(errors.New("timeout"))
+	b.CheckHealth()
+	if active := b.Active(); active != "secondary" {
+		t.Fatalf("active endpoint %s after primary failed, want secondary", active)
+	}
+
+	// the preferred endpoint is activated again once it recovered
+	primary.fail(nil)
+	b.CheckHealth()
+	if active := b.Active(); active != "primary" {
+		t.Fatalf("active endpoint %s after primary recovered, want primary", active)
+	}
+
+	// endpoints lagging behind the others are unhealthy
+	secondary.block.Store(200)
+	b.CheckHealth()
+	if active := b.Active(); active != "secondary" {
+		t.Fatalf("active endpoint %s while primary lags behind, want secondary", active)
+	}
+}
+
+func TestSendTransactionAlreadyKnown(t *testing.T) {
+	t.Parallel()
+
+	primaryErr := errors.New("connection reset")
+	newSendBackend := func(t *testing.T, secondaryErr error) *failover.Backend {
+		t.Helper()
+
+		b, err := failover.NewBackend(log.Noop, []failover.Endpoint{
+			{Name: "primary", Backend: backendmock.New(
+				backendmock.WithSendTransactionFunc(func(context.Context, *types.Transaction) error {
+					return primaryErr
+				}),
+			)},
+			{Name: "secondary", Backend: backendmock.New(
+				backendmock.WithSendTransactionFunc(func(context.Context, *types.Transaction) error {
+					return secondaryErr
+				}),
+			)},
+		}, time.Minute, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(b.Close)
+		return b
+	}
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+
+	// the transaction may have been broadcast by the failed endpoint
+	b := newSendBackend(t, alreadyKnownError{})
+	if err := b.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("got error %v, want none", err)
+	}
+
+	// other errors of the next endpoints are returned
+	b = newSendBackend(t, rpcError{})
+	if err := b.SendTransaction(context.Background(), tx); !errors.Is(err, rpcError{}) {
+		t.Fatalf("got error %v, want %v", err, rpcError{})
+	}
+}
+
+func TestNewBackendNoEndpoints(t *testing.T) {
+	t.Parallel()
+
+	_, err := failover.NewBackend(log.Noop, nil, time.Minute, time.Hour)
+	if !errors.Is(err, failover.ErrNoEndpoints) {
+		t.Fatalf("got error %v, want %v", err, failover.ErrNoEndpoints)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package failover
+
+import (
+	m "github.com/calmw/bee-tron/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	ActiveEndpoint      *prometheus.GaugeVec
+	EndpointHealthy     *prometheus.GaugeVec
+	EndpointLatency     *prometheus.GaugeVec
+	EndpointBlockNumber *prometheus.GaugeVec
+	Failovers           prometheus.Counter
+}
+
+func newMetrics() metrics {
+	subsystem := "eth_backend"
+
+	return metrics{
+		ActiveEndpoint: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "endpoint_active",
+			Help:      "Whether the rpc endpoint is the one in use",
+		}, []string{"endpoint"}),
+		EndpointHealthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "endpoint_healthy",
+			Help:      "Whether the rpc endpoint passed its last health check",
+		}, []string{"endpoint"}),
+		EndpointLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "endpoint_latency_seconds",
+			Help:      "Latency of the rpc endpoint measured by the last health check",
+		}, []string{"endpoint"}),
+		EndpointBlockNumber: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "endpoint_block_number",
+			Help:      "Latest block number reported by the rpc endpoint",
+		}, []string{"endpoint"}),
+		Failovers: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "endpoint_failovers",
+			Help:      "Count of switches between rpc endpoints",
+		}),
+	}
+}
+
+func (b *Backend) Metrics() []prometheus.Collector {
+	return m.PrometheusCollectorsFromFields(b.metrics)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transaction
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// resubscribeInterval is the time between attempts to restore a failed new
+// head subscription.
+const resubscribeInterval = time.Minute
+
+// HeadSubscriber is implemented by backends able to push new chain heads
+// using eth_subscribe, e.g. when connected over WebSocket. Backends without
+// notification support return rpc.ErrNotificationsUnsupported.
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Heads receives the numbers of new blocks from a new head subscription.
+// While the subscription is down, the readers fall back to their own polling
+// of the block number.
+type Heads struct {
+	c          chan uint64
+	subscribed atomic.Bool
+}
+
+// C returns the channel receiving the number of the latest block whenever it
+// increases. Only the latest block number is buffered. The last block number
+// is sent again once the subscription fails, to wake up the readers waiting
+// for a new block. The channel is closed once the watch is done.
+func (h *Heads) C() <-chan uint64 {
+	return h.c
+}
+
+// Subscribed reports whether the block numbers are currently received from
+// the subscription.
+func (h *Heads) Subscribed() bool {
+	return h.subscribed.Load()
+}
+
+// WatchHeads subscribes to new heads if the backend implements HeadSubscriber
+// and supports notifications. A failed subscription is renewed every
+// resubscribeInterval, unless the backend does not support notifications.
+// The watch is done once ctx is done.
+func WatchHeads(ctx context.Context, logger log.Logger, backend any) *Heads {
+	h := &Heads{c: make(chan uint64, 1)}
+	go h.watch(ctx, logger.WithName(loggerName).Register(), backend)
+	return h
+}
+
+func (h *Heads) watch(ctx context.Context, logger log.Logger, backend any) {
+	defer close(h.c)
+
+	var last uint64
+	send := func(number uint64) {
+		// replace a block number not yet received by the reader
+		select {
+		case <-h.c:
+		default:
+		}
+		h.c <- number
+	}
+	publish := func(number uint64) {
+		if number <= last {
+			return
+		}
+		last = number
+		send(number)
+	}
+
+	subscriber, ok := backend.(HeadSubscriber)
+	if !ok {
+		logger.Info("blockchain backend does not support new head subscriptions, polling for new blocks")
+		<-ctx.Done()
+		return
+	}
+
+	for {
+		err := subscribeHeads(ctx, subscriber, func() { h.subscribed.Store(true) }, publish)
+		wasSubscribed := h.subscribed.Swap(false)
+		if ctx.Err() != nil {
+			return
+		}
+		if wasSubscribed {
+			send(last)
+		}
+
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			logger.Info("blockchain rpc endpoint does not support new head subscriptions, polling for new blocks")
+			<-ctx.Done()
+			return
+		}
+		logger.Warning("new head subscription failed, polling for new blocks", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+// subscribeHeads publishes the numbers of new heads until the subscription
+// fails or ctx is done. The subscribed function is called once the
+// subscription is established.
+func subscribeHeads(ctx context.Context, subscriber HeadSubscriber, subscribed func(), publish func(uint64)) error {
+	headerC := make(chan *types.Header)
+	sub, err := subscriber.SubscribeNewHead(ctx, headerC)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	subscribed()
+
+	for {
+		select {
+		case header := <-headerC:
+			if header != nil && header.Number != nil {
+				publish(header.Number.Uint64())
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/log"
+	"github.com/calmw/bee-tron/pkg/transaction"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type pollingBackend struct {
+	calls atomic.Uint64
+}
+
+func (b *pollingBackend) BlockNumber(context.Context) (uint64, error) {
+	return b.calls.Add(1), nil
+}
+
+type subscribingBackend struct {
+	pollingBackend
+	heads []uint64
+	err   error
+}
+
+func (b *subscribingBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for _, number := range b.heads {
+			select {
+			case ch <- &types.Header{Number: new(big.Int).SetUint64(number)}:
+			case <-quit:
+				return nil
+			}
+		}
+		<-quit
+		return nil
+	}), nil
+}
+
+// failingBackend pushes the heads on a subscription which fails once fail is
+// closed.
+type failingBackend struct {
+	subscribingBackend
+	fail chan struct{}
+}
+
+func (b *failingBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for _, number := range b.heads {
+			select {
+			case ch <- &types.Header{Number: new(big.Int).SetUint64(number)}:
+			case <-quit:
+				return nil
+			}
+		}
+		select {
+		case <-b.fail:
+			return errors.New("connection lost")
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+func TestWatchHeads(t *testing.T) {
+	t.Parallel()
+
+	testTimeout := 5 * time.Second
+
+	receive := func(t *testing.T, heads *transaction.Heads, want uint64) {
+		t.Helper()
+
+		for {
+			select {
+			case number := <-heads.C():
+				// only the latest block number is buffered, so numbers may be skipped
+				if number >= want {
+					return
+				}
+			case <-time.After(testTimeout):
+				t.Fatalf("timed out waiting for block number %d", want)
+			}
+		}
+	}
+
+	t.Run("subscription", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		backend := &subscribingBackend{heads: []uint64{5, 6, 7}}
+		heads := transaction.WatchHeads(ctx, log.Noop, backend)
+
+		receive(t, heads, 7)
+
+		if !heads.Subscribed() {
+			t.Fatal("not subscribed")
+		}
+		if calls := backend.calls.Load(); calls != 0 {
+			t.Fatalf("got %d block number calls, want none", calls)
+		}
+	})
+
+	t.Run("subscription failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		backend := &failingBackend{subscribingBackend: subscribingBackend{heads: []uint64{5}}, fail: make(chan struct{})}
+		heads := transaction.WatchHeads(ctx, log.Noop, backend)
+
+		receive(t, heads, 5)
+		close(backend.fail)
+
+		// the last block number is sent again to wake up the readers
+		receive(t, heads, 5)
+		if heads.Subscribed() {
+			t.Fatal("subscribed after the subscription failed")
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		backend any
+	}{
+		{name: "subscription not supported", backend: &subscribingBackend{err: rpc.ErrNotificationsUnsupported}},
+		{name: "subscriber not implemented", backend: &pollingBackend{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			heads := transaction.WatchHeads(ctx, log.Noop, tc.backend)
+
+			// the readers poll on their own
+			select {
+			case number := <-heads.C():
+				t.Fatalf("got block number %d", number)
+			case <-time.After(50 * time.Millisecond):
+			}
+			if heads.Subscribed() {
+				t.Fatal("subscribed")
+			}
+		})
+	}
+
+	t.Run("closed on cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		heads := transaction.WatchHeads(ctx, log.Noop, &pollingBackend{})
+		cancel()
+
+		timeout := time.After(testTimeout)
+		for {
+			select {
+			case _, ok := <-heads.C():
+				if !ok {
+					return
+				}
+			case <-timeout:
+				t.Fatal("heads channel not closed")
+			}
+		}
+	})
+}
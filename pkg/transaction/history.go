@@ -0,0 +1,198 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	receiptPrefix  = "transaction_receipt_"
+	replacedPrefix = "transaction_replaced_"
+)
+
+// Statuses of transactions in the history.
+const (
+	HistoryStatusPending  = "pending"  // the transaction is still being watched
+	HistoryStatusSuccess  = "success"  // the transaction was included and succeeded
+	HistoryStatusReverted = "reverted" // the transaction was included but reverted
+	HistoryStatusReplaced = "replaced" // the transaction was resubmitted and a replacement was included
+	HistoryStatusDropped  = "dropped"  // the transaction was cancelled or vanished
+)
+
+// StoredReceipt is the final receipt of a sent transaction.
+type StoredReceipt struct {
+	Status            uint64   // receipt status, types.ReceiptStatusSuccessful or types.ReceiptStatusFailed
+	BlockNumber       uint64   // number of the block including the transaction
+	GasUsed           uint64   // gas used by the transaction
+	EffectiveGasPrice *big.Int // price paid per gas
+	Confirmed         int64    // confirmation timestamp
+}
+
+// HistoryEntry is a sent transaction together with its outcome.
+type HistoryEntry struct {
+	TxHash      common.Hash
+	Transaction StoredTransaction
+	Method      string         // name of the called contract method, empty if unknown
+	Status      string         // one of the HistoryStatus values
+	Receipt     *StoredReceipt // nil unless the transaction was included
+	Fee         *big.Int       // fee paid by the transaction, nil unless it was included
+	ReplacedBy  *common.Hash   // the included replacement, nil unless the transaction was replaced
+}
+
+// HistoryFilter selects transactions from the history.
+type HistoryFilter struct {
+	Contract *common.Address // recipient of the transactions, all if nil
+	From     time.Time       // earliest creation time, unbounded if zero
+	To       time.Time       // latest creation time, unbounded if zero
+}
+
+func receiptKey(txHash common.Hash) string {
+	return fmt.Sprintf("%s%x", receiptPrefix, txHash)
+}
+
+func replacedKey(txHash common.Hash) string {
+	return fmt.Sprintf("%s%x", replacedPrefix, txHash)
+}
+
+func (t *transactionService) RegisterABI(contractABI abi.ABI) {
+	t.methodsMu.Lock()
+	defer t.methodsMu.Unlock()
+
+	for _, method := range contractABI.Methods {
+		t.methods[[4]byte(method.ID)] = method.RawName
+	}
+}
+
+// methodName returns the name of the method called by the transaction data.
+func (t *transactionService) methodName(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+
+	t.methodsMu.RLock()
+	defer t.methodsMu.RUnlock()
+
+	return t.methods[[4]byte(data[:4])]
+}
+
+// storeReceipt records the final receipt of a sent transaction. The receipt
+// is stored under the hash of the included transaction only, a resubmitted
+// transaction is marked as replaced by it so that the fee is counted once.
+func (t *transactionService) storeReceipt(txHash common.Hash, receipt *types.Receipt) error {
+	if receipt.TxHash != (common.Hash{}) && receipt.TxHash != txHash {
+		if err := t.store.Put(replacedKey(txHash), receipt.TxHash); err != nil {
+			return err
+		}
+		txHash = receipt.TxHash
+	}
+
+	storedReceipt := StoredReceipt{
+		Status:            receipt.Status,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		Confirmed:         time.Now().Unix(),
+	}
+	if receipt.BlockNumber != nil {
+		storedReceipt.BlockNumber = receipt.BlockNumber.Uint64()
+	}
+	return t.store.Put(receiptKey(txHash), storedReceipt)
+}
+
+func (t *transactionService) History(filter HistoryFilter) ([]HistoryEntry, error) {
+	entries := make([]HistoryEntry, 0)
+	err := t.store.Iterate(storedTransactionPrefix, func(key, value []byte) (stop bool, err error) {
+		var storedTransaction StoredTransaction
+		if err := json.Unmarshal(value, &storedTransaction); err != nil {
+			return true, err
+		}
+
+		if filter.Contract != nil && (storedTransaction.To == nil || *storedTransaction.To != *filter.Contract) {
+			return false, nil
+		}
+		created := time.Unix(storedTransaction.Created, 0)
+		if (!filter.From.IsZero() && created.Before(filter.From)) || (!filter.To.IsZero() && created.After(filter.To)) {
+			return false, nil
+		}
+
+		entries = append(entries, HistoryEntry{
+			TxHash:      common.HexToHash(strings.TrimPrefix(string(key), storedTransactionPrefix)),
+			Transaction: storedTransaction,
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the outcome is looked up after iterating as the store must not be accessed during the iteration
+	for i := range entries {
+		if err := t.completeHistoryEntry(&entries[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Transaction.Created < entries[j].Transaction.Created
+	})
+
+	return entries, nil
+}
+
+// completeHistoryEntry adds the method name, status, receipt and fee to the entry.
+func (t *transactionService) completeHistoryEntry(entry *HistoryEntry) error {
+	entry.Method = t.methodName(entry.Transaction.Data)
+	entry.Status = HistoryStatusDropped
+
+	var receipt StoredReceipt
+	err := t.store.Get(receiptKey(entry.TxHash), &receipt)
+	switch {
+	case err == nil:
+		entry.Receipt = &receipt
+		entry.Status = HistoryStatusSuccess
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			entry.Status = HistoryStatusReverted
+		}
+		if receipt.EffectiveGasPrice != nil {
+			entry.Fee = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		}
+		return nil
+	case !errors.Is(err, storage.ErrNotFound):
+		return err
+	}
+
+	var replacement common.Hash
+	err = t.store.Get(replacedKey(entry.TxHash), &replacement)
+	switch {
+	case err == nil:
+		entry.ReplacedBy = &replacement
+		entry.Status = HistoryStatusReplaced
+		return nil
+	case !errors.Is(err, storage.ErrNotFound):
+		return err
+	}
+
+	var pending struct{}
+	err = t.store.Get(pendingTransactionKey(entry.TxHash), &pending)
+	switch {
+	case err == nil:
+		entry.Status = HistoryStatusPending
+	case !errors.Is(err, storage.ErrNotFound):
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/transaction"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrExecutionReverted is returned by calls to simulated contracts matching
+// a revert condition.
+var ErrExecutionReverted = errors.New("execution reverted")
+
+// Simulator is a stateful simulation of contracts answering calls and sends
+// of the mock transaction service by contract address and method instead of
+// by an ordered list of expected calls.
+type Simulator struct {
+	mu        sync.Mutex
+	contracts map[common.Address]*SimulatedContract
+	sent      []transaction.TxRequest
+	receipts  map[common.Hash]*types.Receipt
+}
+
+// SimulatedContract holds the responses and revert conditions of the methods
+// of a simulated contract.
+type SimulatedContract struct {
+	simulator *Simulator
+	abi       *abi.ABI
+	results   map[string][]interface{} // results by method, or by method and packed arguments
+	handlers  map[string]func(args []interface{}) ([]interface{}, error)
+	onSend    map[string]func(args []interface{}) error
+	reverts   map[string][]revertCondition
+}
+
+type revertCondition struct {
+	reason string
+	cond   func(args []interface{}) bool
+}
+
+// NewSimulator creates a simulator without contracts.
+func NewSimulator() *Simulator {
+	return &Simulator{
+		contracts: make(map[common.Address]*SimulatedContract),
+		receipts:  make(map[common.Hash]*types.Receipt),
+	}
+}
+
+// Contract returns the contract simulated at the address, deploying it with
+// the given ABI if it does not exist yet.
+func (s *Simulator) Contract(address common.Address, contractABI *abi.ABI) *SimulatedContract {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.contracts[address]; ok {
+		return c
+	}
+	c := &SimulatedContract{
+		simulator: s,
+		abi:       contractABI,
+		results:   make(map[string][]interface{}),
+		handlers:  make(map[string]func(args []interface{}) ([]interface{}, error)),
+		onSend:    make(map[string]func(args []interface{}) error),
+		reverts:   make(map[string][]revertCondition),
+	}
+	s.contracts[address] = c
+	return c
+}
+
+// Sent returns the requests of all transactions sent so far.
+func (s *Simulator) Sent() []transaction.TxRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]transaction.TxRequest(nil), s.sent...)
+}
+
+// Set sets the results returned by calls of the method with any arguments.
+func (c *SimulatedContract) Set(method string, results ...interface{}) {
+	c.simulator.mu.Lock()
+	defer c.simulator.mu.Unlock()
+
+	c.results[method] = results
+}
+
+// SetFor sets the results returned by calls of the method with the given
+// arguments. They take precedence over results set with Set.
+func (c *SimulatedContract) SetFor(method string, args []interface{}, results ...interface{}) error {
+	key, err := c.resultKey(method, args)
+	if err != nil {
+		return err
+	}
+
+	c.simulator.mu.Lock()
+	defer c.simulator.mu.Unlock()
+
+	c.results[key] = results
+	return nil
+}
+
+// Handle sets a function computing the results of calls of the method. It
+// takes precedence over fixed results.
+func (c *SimulatedContract) Handle(method string, f func(args []interface{}) ([]interface{}, error)) {
+	c.simulator.mu.Lock()
+	defer c.simulator.mu.Unlock()
+
+	c.handlers[method] = f
+}
+
+// OnSend sets a function applying the state changes of successfully sent
+// transactions calling the method, e.g. by setting new results.
+func (c *SimulatedContract) OnSend(method string, f func(args []interface{}) error) {
+	c.simulator.mu.Lock()
+	defer c.simulator.mu.Unlock()
+
+	c.onSend[method] = f
+}
+
+// RevertIf makes calls and transactions of the method revert with the
+// reason if cond returns true for their arguments. A nil cond always
+// reverts.
+func (c *SimulatedContract) RevertIf(method, reason string, cond func(args []interface{}) bool) {
+	c.simulator.mu.Lock()
+	defer c.simulator.mu.Unlock()
+
+	c.reverts[method] = append(c.reverts[method], revertCondition{reason: reason, cond: cond})
+}
+
+func (c *SimulatedContract) resultKey(method string, args []interface{}) (string, error) {
+	m, ok := c.abi.Methods[method]
+	if !ok {
+		return "", fmt.Errorf("unknown method %s", method)
+	}
+	packed, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%x", method, packed), nil
+}
+
+// decode returns the simulated contract, the called method and its
+// arguments. The revert reason is not empty if the execution reverts.
+func (s *Simulator) decode(request *transaction.TxRequest) (c *SimulatedContract, method *abi.Method, args []interface{}, revertReason string, err error) {
+	if request.To == nil {
+		return nil, nil, nil, "", errors.New("contract creation not simulated")
+	}
+
+	s.mu.Lock()
+	c, ok := s.contracts[*request.To]
+	s.mu.Unlock()
+	if !ok {
+		return nil, nil, nil, "", fmt.Errorf("no contract simulated at %x", *request.To)
+	}
+
+	if len(request.Data) < 4 {
+		return nil, nil, nil, "", errors.New("missing method id")
+	}
+	method, err = c.abi.MethodById(request.Data[:4])
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+	args, err = method.Inputs.Unpack(request.Data[4:])
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	s.mu.Lock()
+	reverts := append([]revertCondition(nil), c.reverts[method.Name]...)
+	s.mu.Unlock()
+
+	// conditions are evaluated without holding the lock so they may inspect the simulation
+	for _, revert := range reverts {
+		if revert.cond == nil || revert.cond(args) {
+			return c, method, args, revert.reason, nil
+		}
+	}
+	return c, method, args, "", nil
+}
+
+func (s *Simulator) call(_ context.Context, request *transaction.TxRequest) ([]byte, error) {
+	c, method, args, revertReason, err := s.decode(request)
+	if err != nil {
+		return nil, err
+	}
+	if revertReason != "" {
+		return nil, fmt.Errorf("%w: %s", ErrExecutionReverted, revertReason)
+	}
+
+	key, err := c.resultKey(method.Name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	handler := c.handlers[method.Name]
+	results, ok := c.results[key]
+	if !ok {
+		results, ok = c.results[method.Name]
+	}
+	s.mu.Unlock()
+
+	if handler != nil {
+		if results, err = handler(args); err != nil {
+			return nil, err
+		}
+	} else if !ok {
+		return nil, fmt.Errorf("method %s not simulated", method.Name)
+	}
+
+	return method.Outputs.Pack(results...)
+}
+
+func (s *Simulator) simulate(_ context.Context, request *transaction.TxRequest) error {
+	_, _, _, revertReason, err := s.decode(request)
+	if err != nil {
+		return err
+	}
+	if revertReason != "" {
+		return &transaction.RevertError{Reason: revertReason}
+	}
+	return nil
+}
+
+// send records the transaction together with a receipt. Reverting
+// transactions are included with a failed receipt like on chain.
+func (s *Simulator) send(_ context.Context, request *transaction.TxRequest, _ int) (common.Hash, error) {
+	c, method, args, revertReason, err := s.decode(request)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	status := types.ReceiptStatusFailed
+	if revertReason == "" {
+		s.mu.Lock()
+		onSend := c.onSend[method.Name]
+		s.mu.Unlock()
+
+		if onSend != nil {
+			if err := onSend(args); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		status = types.ReceiptStatusSuccessful
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, *request)
+	txHash := common.BigToHash(big.NewInt(int64(len(s.sent))))
+	s.receipts[txHash] = &types.Receipt{
+		TxHash:      txHash,
+		Status:      status,
+		BlockNumber: big.NewInt(int64(len(s.sent))),
+	}
+	return txHash, nil
+}
+
+func (s *Simulator) waitForReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.receipts[txHash]
+	if !ok {
+		return nil, transaction.ErrUnknownTransaction
+	}
+	return receipt, nil
+}
+
+// WithSimulator answers calls, simulations, sends and receipts of the mock
+// from the simulator.
+func WithSimulator(s *Simulator) Option {
+	return optionFunc(func(m *transactionServiceMock) {
+		m.call = s.call
+		m.simulate = s.simulate
+		m.send = s.send
+		m.waitForReceipt = s.waitForReceipt
+	})
+}
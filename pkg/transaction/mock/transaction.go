@@ -26,7 +26,12 @@ type transactionServiceMock struct {
 	resendTransaction    func(ctx context.Context, txHash common.Hash) error
 	storedTransaction    func(txHash common.Hash) (*transaction.StoredTransaction, error)
 	cancelTransaction    func(ctx context.Context, originalTxHash common.Hash) (common.Hash, error)
+	resubmitTransaction  func(ctx context.Context, txHash common.Hash, maxGasFeeCap *big.Int) (common.Hash, error)
 	transactionFee       func(ctx context.Context, txHash common.Hash) (*big.Int, error)
+	nonceStatus          func(ctx context.Context) (*transaction.NonceStatus, error)
+	repairNonces         func(ctx context.Context) (*transaction.NonceStatus, error)
+	history              func(filter transaction.HistoryFilter) ([]transaction.HistoryEntry, error)
+	simulate             func(ctx context.Context, request *transaction.TxRequest) error
 }
 
 func (m *transactionServiceMock) Send(ctx context.Context, request *transaction.TxRequest, boostPercent int) (txHash common.Hash, err error) {
@@ -85,6 +90,27 @@ func (m *transactionServiceMock) CancelTransaction(ctx context.Context, original
 	return common.Hash{}, errors.New("not implemented")
 }
 
+func (m *transactionServiceMock) ResubmitTransaction(ctx context.Context, txHash common.Hash, maxGasFeeCap *big.Int) (common.Hash, error) {
+	if m.resubmitTransaction != nil {
+		return m.resubmitTransaction(ctx, txHash, maxGasFeeCap)
+	}
+	return common.Hash{}, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) NonceStatus(ctx context.Context) (*transaction.NonceStatus, error) {
+	if m.nonceStatus != nil {
+		return m.nonceStatus(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *transactionServiceMock) RepairNonces(ctx context.Context) (*transaction.NonceStatus, error) {
+	if m.repairNonces != nil {
+		return m.repairNonces(ctx)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *transactionServiceMock) Close() error {
 	return nil
 }
@@ -97,6 +123,23 @@ func (m *transactionServiceMock) TransactionFee(ctx context.Context, txHash comm
 	return big.NewInt(0), nil
 }
 
+func (m *transactionServiceMock) RegisterABI(abi.ABI) {}
+
+func (m *transactionServiceMock) History(filter transaction.HistoryFilter) ([]transaction.HistoryEntry, error) {
+	if m.history != nil {
+		return m.history(filter)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// Simulate succeeds unless a simulate function is set.
+func (m *transactionServiceMock) Simulate(ctx context.Context, request *transaction.TxRequest, _ map[string]abi.Error) error {
+	if m.simulate != nil {
+		return m.simulate(ctx, request)
+	}
+	return nil
+}
+
 func (m *transactionServiceMock) UnwrapABIError(_ context.Context, _ *transaction.TxRequest, err error, _ map[string]abi.Error) error {
 	return err
 }
@@ -152,6 +195,36 @@ func WithCancelTransactionFunc(f func(ctx context.Context, originalTxHash common
 	})
 }
 
+func WithResubmitTransactionFunc(f func(ctx context.Context, txHash common.Hash, maxGasFeeCap *big.Int) (common.Hash, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.resubmitTransaction = f
+	})
+}
+
+func WithNonceStatusFunc(f func(ctx context.Context) (*transaction.NonceStatus, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.nonceStatus = f
+	})
+}
+
+func WithRepairNoncesFunc(f func(ctx context.Context) (*transaction.NonceStatus, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.repairNonces = f
+	})
+}
+
+func WithHistoryFunc(f func(filter transaction.HistoryFilter) ([]transaction.HistoryEntry, error)) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.history = f
+	})
+}
+
+func WithSimulateFunc(f func(ctx context.Context, request *transaction.TxRequest) error) Option {
+	return optionFunc(func(s *transactionServiceMock) {
+		s.simulate = f
+	})
+}
+
 func WithTransactionFeeFunc(f func(ctx context.Context, txHash common.Hash) (*big.Int, error)) Option {
 	return optionFunc(func(s *transactionServiceMock) {
 		s.transactionFee = f
@@ -29,7 +29,14 @@ type Monitor interface {
 	io.Closer
 	// WatchTransaction watches the transaction until either there is 1 confirmation or a competing transaction with cancellationDepth confirmations.
 	WatchTransaction(txHash common.Hash, nonce uint64) (<-chan types.Receipt, <-chan error, error)
+	// SetResubmitFunc enables the resubmission of watched transactions pending for longer than stuckTimeout.
+	// Watchers of a resubmitted transaction are notified about the receipt of its replacement.
+	SetResubmitFunc(stuckTimeout time.Duration, resubmit ResubmitFunc)
 }
+
+// ResubmitFunc rebroadcasts a stuck transaction and returns the hash of the transaction replacing it.
+type ResubmitFunc func(ctx context.Context, txHash common.Hash) (common.Hash, error)
+
 type transactionMonitor struct {
 	lock       sync.Mutex
 	ctx        context.Context    // context which is used for all backend calls
@@ -45,6 +52,17 @@ type transactionMonitor struct {
 
 	watchesByNonce map[uint64]map[common.Hash][]transactionWatch // active watches grouped by nonce and tx hash
 	watchAdded     chan struct{}                                 // channel to trigger instant pending check
+	heads          *Heads                                        // new block numbers, nil if blocks are polled by the monitor
+
+	stuckTimeout time.Duration               // time after which a pending transaction is resubmitted
+	resubmit     ResubmitFunc                // function resubmitting stuck transactions, nil if disabled
+	latest       map[uint64]latestSubmission // most recent submission per watched nonce
+	replacements map[common.Hash]common.Hash // resubmitted transactions by the hash of their replacement
+}
+
+type latestSubmission struct {
+	txHash    common.Hash
+	submitted time.Time
 }
 
 type transactionWatch struct {
@@ -53,7 +71,10 @@ type transactionWatch struct {
 	errC     chan error         // error channel (primarily for cancelled transactions)
 }
 
-func NewMonitor(logger log.Logger, backend Backend, sender common.Address, pollingInterval time.Duration, cancellationDepth uint64) Monitor {
+// NewMonitor creates a new Monitor. If subscribeHeads is set, new blocks are
+// received from a new head subscription if the backend supports it, falling
+// back to polling every pollingInterval while the subscription is down.
+func NewMonitor(logger log.Logger, backend Backend, sender common.Address, pollingInterval time.Duration, cancellationDepth uint64, subscribeHeads bool) Monitor {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
 	t := &transactionMonitor{
@@ -68,6 +89,12 @@ func NewMonitor(logger log.Logger, backend Backend, sender common.Address, polli
 
 		watchesByNonce: make(map[uint64]map[common.Hash][]transactionWatch),
 		watchAdded:     make(chan struct{}, 1),
+		latest:         make(map[uint64]latestSubmission),
+		replacements:   make(map[common.Hash]common.Hash),
+	}
+
+	if subscribeHeads {
+		t.heads = WatchHeads(ctx, logger, backend)
 	}
 
 	t.wg.Add(1)
@@ -91,6 +118,11 @@ func (tm *transactionMonitor) WatchTransaction(txHash common.Hash, nonce uint64)
 		tm.watchesByNonce[nonce] = make(map[common.Hash][]transactionWatch)
 	}
 
+	// a transaction not seen before for this nonce is the most recent submission
+	if _, ok := tm.watchesByNonce[nonce][txHash]; !ok {
+		tm.latest[nonce] = latestSubmission{txHash: txHash, submitted: time.Now()}
+	}
+
 	tm.watchesByNonce[nonce][txHash] = append(tm.watchesByNonce[nonce][txHash], transactionWatch{
 		start:    time.Now(),
 		receiptC: receiptC,
@@ -130,17 +162,37 @@ func (tm *transactionMonitor) watchPending() {
 
 	var (
 		lastBlock uint64 = 0
-		added     bool   // flag if this iteration was triggered by the watchAdded channel
+		head      uint64 = 0 // latest block number received from the heads channel
+		added     bool       // flag if this iteration was triggered by the watchAdded channel
 	)
 
 	for {
 		added = false
+
+		// new blocks are either received from the heads subscription or polled
+		var (
+			poll  <-chan time.Time
+			heads <-chan uint64
+		)
+		if tm.heads != nil {
+			heads = tm.heads.C()
+		}
+		if tm.heads == nil || !tm.heads.Subscribed() {
+			poll = time.After(tm.pollingInterval)
+		}
+
 		select {
 		// if a new watch has been added check again without waiting
 		case <-tm.watchAdded:
 			added = true
+		// or a new block has been received
+		case number, ok := <-heads:
+			if !ok {
+				return
+			}
+			head = number
 		// otherwise wait
-		case <-time.After(tm.pollingInterval):
+		case <-poll:
 		// if the main context is cancelled terminate
 		case <-tm.ctx.Done():
 			return
@@ -151,8 +203,16 @@ func (tm *transactionMonitor) watchPending() {
 			continue
 		}
 
-		// switch to new head subscriptions once websockets are the norm
-		block, err := tm.backend.BlockNumber(tm.ctx)
+		var (
+			block uint64
+			err   error
+		)
+		if tm.heads != nil && tm.heads.Subscribed() {
+			block = head
+		}
+		if block == 0 {
+			block, err = tm.backend.BlockNumber(tm.ctx)
+		}
 		if err != nil {
 			tm.logger.Error(err, "could not get block number")
 			continue
@@ -168,6 +228,8 @@ func (tm *transactionMonitor) watchPending() {
 			continue
 		}
 		lastBlock = block
+
+		tm.resubmitStuck()
 	}
 }
 
@@ -235,7 +297,7 @@ func (tm *transactionMonitor) checkPending(block uint64) error {
 
 	for nonce, receipt := range confirmedNonces {
 		for txHash, watches := range tm.watchesByNonce[nonce] {
-			if receipt.TxHash == txHash {
+			if receipt.TxHash == txHash || tm.isReplacement(receipt.TxHash, txHash) {
 				for _, watch := range watches {
 					select {
 					case watch.receiptC <- *receipt:
@@ -251,7 +313,7 @@ func (tm *transactionMonitor) checkPending(block uint64) error {
 				}
 			}
 		}
-		delete(tm.watchesByNonce, nonce)
+		tm.removeNonce(nonce)
 	}
 
 	for _, nonce := range cancelledNonces {
@@ -263,12 +325,101 @@ func (tm *transactionMonitor) checkPending(block uint64) error {
 				}
 			}
 		}
-		delete(tm.watchesByNonce, nonce)
+		tm.removeNonce(nonce)
 	}
 
 	return nil
 }
 
+// removeNonce removes the watches and resubmission state of a nonce. Must be called with the lock held.
+func (tm *transactionMonitor) removeNonce(nonce uint64) {
+	for txHash := range tm.watchesByNonce[nonce] {
+		delete(tm.replacements, txHash)
+	}
+	delete(tm.watchesByNonce, nonce)
+	delete(tm.latest, nonce)
+}
+
+// isReplacement reports whether txHash was resubmitted, possibly several times, as replacement.
+// Must be called with the lock held.
+func (tm *transactionMonitor) isReplacement(replacement, txHash common.Hash) bool {
+	for {
+		replaced, ok := tm.replacements[replacement]
+		if !ok {
+			return false
+		}
+		if replaced == txHash {
+			return true
+		}
+		replacement = replaced
+	}
+}
+
+func (tm *transactionMonitor) SetResubmitFunc(stuckTimeout time.Duration, resubmit ResubmitFunc) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	tm.stuckTimeout = stuckTimeout
+	tm.resubmit = resubmit
+}
+
+// resubmitStuck resubmits the most recent transaction of every nonce pending for longer than the stuck timeout.
+func (tm *transactionMonitor) resubmitStuck() {
+	tm.lock.Lock()
+	resubmit := tm.resubmit
+	var stuck []uint64
+	if resubmit != nil {
+		for nonce, latest := range tm.latest {
+			if time.Since(latest.submitted) >= tm.stuckTimeout {
+				stuck = append(stuck, nonce)
+			}
+		}
+	}
+	tm.lock.Unlock()
+
+	for _, nonce := range stuck {
+		tm.lock.Lock()
+		latest, ok := tm.latest[nonce]
+		tm.lock.Unlock()
+		if !ok {
+			continue
+		}
+
+		tm.logger.Info("resubmitting stuck transaction", "tx", latest.txHash, "nonce", nonce, "pending_since", latest.submitted)
+
+		replacement, err := resubmit(tm.ctx, latest.txHash)
+
+		tm.lock.Lock()
+		if _, ok := tm.watchesByNonce[nonce]; !ok {
+			// confirmed or cancelled in the meantime
+			tm.lock.Unlock()
+			continue
+		}
+		if errors.Is(err, ErrMaxGasFeeCapReached) {
+			// the fee can not be bumped any further, the transaction is left pending
+			delete(tm.latest, nonce)
+			tm.lock.Unlock()
+			tm.logger.Error(err, "stuck transaction can not be resubmitted", "tx", latest.txHash, "nonce", nonce)
+			continue
+		}
+		if err != nil {
+			// retry once the transaction was pending for another stuck timeout
+			tm.latest[nonce] = latestSubmission{txHash: latest.txHash, submitted: time.Now()}
+			tm.lock.Unlock()
+			tm.logger.Warning("resubmitting stuck transaction failed", "tx", latest.txHash, "nonce", nonce, "error", err)
+			continue
+		}
+		if _, ok := tm.watchesByNonce[nonce][replacement]; !ok {
+			tm.watchesByNonce[nonce][replacement] = nil
+		}
+		tm.replacements[replacement] = latest.txHash
+		tm.latest[nonce] = latestSubmission{txHash: replacement, submitted: time.Now()}
+		tm.lock.Unlock()
+
+		tm.logger.Info("stuck transaction resubmitted", "tx", latest.txHash, "replacement", replacement, "nonce", nonce)
+	}
+}
+
 func (tm *transactionMonitor) Close() error {
 	tm.cancelFunc()
 	tm.wg.Wait()
@@ -5,7 +5,11 @@
 package transaction_test
 
 import (
+	"context"
 	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -55,6 +59,59 @@ func TestMonitorWatchTransaction(t *testing.T) {
 			sender,
 			pollingInterval,
 			cancellationDepth,
+			false,
+		)
+
+		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case receipt := <-receiptC:
+			if receipt.TxHash != txHash {
+				t.Fatal("got wrong receipt")
+			}
+		case err := <-errC:
+			t.Fatal(err)
+		case <-time.After(testTimeout):
+			t.Fatal("timed out")
+		}
+
+		err = monitor.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("single transaction confirmed with head subscription", func(t *testing.T) {
+		t.Parallel()
+
+		monitor := transaction.NewMonitor(
+			logger,
+			backendsimulation.New(
+				backendsimulation.WithBlocks(
+					backendsimulation.Block{
+						Number: 0,
+					},
+					backendsimulation.Block{
+						Number: 1,
+						Receipts: map[common.Hash]*types.Receipt{
+							txHash: {TxHash: txHash},
+						},
+						NoncesAt: map[backendsimulation.AccountAtKey]uint64{
+							{
+								BlockNumber: 1,
+								Account:     sender,
+							}: nonce + 1,
+						},
+					},
+				),
+			),
+			sender,
+			pollingInterval,
+			cancellationDepth,
+			true,
 		)
 
 		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
@@ -112,6 +169,7 @@ func TestMonitorWatchTransaction(t *testing.T) {
 			sender,
 			pollingInterval,
 			cancellationDepth,
+			false,
 		)
 
 		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
@@ -204,6 +262,7 @@ func TestMonitorWatchTransaction(t *testing.T) {
 			sender,
 			pollingInterval,
 			cancellationDepth,
+			false,
 		)
 
 		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
@@ -310,6 +369,7 @@ func TestMonitorWatchTransaction(t *testing.T) {
 			sender,
 			pollingInterval,
 			cancellationDepth,
+			false,
 		)
 
 		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
@@ -364,6 +424,7 @@ func TestMonitorWatchTransaction(t *testing.T) {
 			sender,
 			pollingInterval,
 			cancellationDepth,
+			false,
 		)
 
 		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
@@ -388,4 +449,154 @@ func TestMonitorWatchTransaction(t *testing.T) {
 		}
 	})
 
+	t.Run("stuck transaction resubmitted", func(t *testing.T) {
+		t.Parallel()
+
+		replacementHash := common.HexToHash("0x1001")
+		monitor := transaction.NewMonitor(
+			logger,
+			backendsimulation.New(
+				backendsimulation.WithBlocks(
+					backendsimulation.Block{
+						Number: 0,
+					},
+					backendsimulation.Block{
+						Number: 1,
+					},
+					backendsimulation.Block{
+						Number: 2,
+					},
+					backendsimulation.Block{
+						Number: 3,
+						Receipts: map[common.Hash]*types.Receipt{
+							replacementHash: {TxHash: replacementHash},
+						},
+						NoncesAt: map[backendsimulation.AccountAtKey]uint64{
+							{
+								BlockNumber: 3,
+								Account:     sender,
+							}: nonce + 1,
+						},
+					},
+				),
+			),
+			sender,
+			pollingInterval,
+			cancellationDepth,
+			false,
+		)
+
+		var (
+			mu       sync.Mutex
+			resubmit []common.Hash
+		)
+		monitor.SetResubmitFunc(time.Nanosecond, func(ctx context.Context, txHash common.Hash) (common.Hash, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			resubmit = append(resubmit, txHash)
+			// every further resubmission replaces the previous replacement
+			return common.BigToHash(new(big.Int).Add(replacementHash.Big(), big.NewInt(int64(len(resubmit)-1)))), nil
+		})
+
+		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case receipt := <-receiptC:
+			if receipt.TxHash != replacementHash {
+				t.Fatalf("got receipt of %v, want receipt of replacement %v", receipt.TxHash, replacementHash)
+			}
+		case err := <-errC:
+			t.Fatal(err)
+		case <-time.After(testTimeout):
+			t.Fatal("timed out")
+		}
+
+		err = monitor.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(resubmit) == 0 || resubmit[0] != txHash {
+			t.Fatalf("got resubmissions %v, want first resubmission of %v", resubmit, txHash)
+		}
+		for i := 1; i < len(resubmit); i++ {
+			want := common.BigToHash(new(big.Int).Add(replacementHash.Big(), big.NewInt(int64(i-1))))
+			if resubmit[i] != want {
+				t.Fatalf("resubmission %d of %v, want %v", i, resubmit[i], want)
+			}
+		}
+	})
+
+	t.Run("stuck transaction at max fee not resubmitted again", func(t *testing.T) {
+		t.Parallel()
+
+		monitor := transaction.NewMonitor(
+			logger,
+			backendsimulation.New(
+				backendsimulation.WithBlocks(
+					backendsimulation.Block{
+						Number: 0,
+					},
+					backendsimulation.Block{
+						Number: 1,
+					},
+					backendsimulation.Block{
+						Number: 2,
+					},
+					backendsimulation.Block{
+						Number: 3,
+						Receipts: map[common.Hash]*types.Receipt{
+							txHash: {TxHash: txHash},
+						},
+						NoncesAt: map[backendsimulation.AccountAtKey]uint64{
+							{
+								BlockNumber: 3,
+								Account:     sender,
+							}: nonce + 1,
+						},
+					},
+				),
+			),
+			sender,
+			pollingInterval,
+			cancellationDepth,
+			false,
+		)
+
+		var resubmits atomic.Int32
+		monitor.SetResubmitFunc(time.Nanosecond, func(ctx context.Context, txHash common.Hash) (common.Hash, error) {
+			resubmits.Add(1)
+			return common.Hash{}, transaction.ErrMaxGasFeeCapReached
+		})
+
+		receiptC, errC, err := monitor.WatchTransaction(txHash, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case receipt := <-receiptC:
+			if receipt.TxHash != txHash {
+				t.Fatalf("got receipt of %v, want receipt of %v", receipt.TxHash, txHash)
+			}
+		case err := <-errC:
+			t.Fatal(err)
+		case <-time.After(testTimeout):
+			t.Fatal("timed out")
+		}
+
+		err = monitor.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := resubmits.Load(); n != 1 {
+			t.Fatalf("got %d resubmissions, want 1", n)
+		}
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,6 +34,8 @@ func (m *transactionMonitorMock) WaitBlock(ctx context.Context, block *big.Int)
 	return nil, errors.New("not implemented")
 }
 
+func (m *transactionMonitorMock) SetResubmitFunc(time.Duration, transaction.ResubmitFunc) {}
+
 func (m *transactionMonitorMock) Close() error {
 	return nil
 }
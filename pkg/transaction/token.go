@@ -0,0 +1,272 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/sctx"
+	"github.com/calmw/bee-tron/pkg/util/abiutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/go-sw3-abi/sw3abi"
+	"github.com/mr-tron/base58"
+)
+
+// tokenGasLimit is the default gas limit of token transactions.
+const tokenGasLimit = 90_000
+
+// tronAddressPrefix is the first byte of base58 encoded Tron addresses.
+const tronAddressPrefix = 0x41
+
+var (
+	tokenABI = abiutil.MustParseABI(sw3abi.ERC20ABIv0_6_5)
+
+	// ErrUnknownToken denotes that a token is neither registered nor a valid
+	// contract address.
+	ErrUnknownToken = errors.New("unknown token")
+	// ErrInvalidTronAddress denotes that a base58 encoded Tron address is
+	// malformed or its checksum does not match.
+	ErrInvalidTronAddress = errors.New("invalid tron address")
+)
+
+// Token is an ERC-20 token contract. TRC-20 tokens on Tron implement the same
+// interface and are handled alike.
+type Token struct {
+	Symbol   string         // ticker of the token, e.g. BZZ
+	Address  common.Address // address of the token contract
+	Decimals uint8          // number of decimals of the token amounts
+}
+
+// TokenRegistry keeps the tokens known to the node and sends token
+// transactions through the transaction service.
+type TokenRegistry struct {
+	transactionService Service
+
+	mu        sync.RWMutex
+	bySymbol  map[string]Token
+	byAddress map[common.Address]Token
+}
+
+// NewTokenRegistry creates a registry knowing the given tokens.
+func NewTokenRegistry(transactionService Service, tokens ...Token) *TokenRegistry {
+	r := &TokenRegistry{
+		transactionService: transactionService,
+		bySymbol:           make(map[string]Token),
+		byAddress:          make(map[common.Address]Token),
+	}
+	for _, token := range tokens {
+		r.Register(token)
+	}
+	return r
+}
+
+// Register adds the token to the registry, replacing a token with the same
+// symbol or address.
+func (r *TokenRegistry) Register(token Token) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bySymbol[strings.ToUpper(token.Symbol)] = token
+	r.byAddress[token.Address] = token
+}
+
+// Tokens returns the registered tokens ordered by symbol.
+func (r *TokenRegistry) Tokens() []Token {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := make([]Token, 0, len(r.byAddress))
+	for _, token := range r.byAddress {
+		tokens = append(tokens, token)
+	}
+	slices.SortFunc(tokens, func(a, b Token) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+	return tokens
+}
+
+// Lookup returns the token identified by its symbol or by the hex or base58
+// Tron address of its contract. Unregistered contracts are remembered by
+// their address after their symbol and decimals are read from the chain, they
+// can not be looked up by the symbol they claim.
+func (r *TokenRegistry) Lookup(ctx context.Context, id string) (Token, error) {
+	r.mu.RLock()
+	token, ok := r.bySymbol[strings.ToUpper(id)]
+	r.mu.RUnlock()
+	if ok {
+		return token, nil
+	}
+
+	address, err := ParseTokenAddress(id)
+	if err != nil {
+		return Token{}, fmt.Errorf("%w: %s", ErrUnknownToken, id)
+	}
+
+	r.mu.RLock()
+	token, ok = r.byAddress[address]
+	r.mu.RUnlock()
+	if ok {
+		return token, nil
+	}
+
+	token = Token{Address: address}
+	if token.Decimals, err = r.decimals(ctx, address); err != nil {
+		return Token{}, fmt.Errorf("%w: %s: %w", ErrUnknownToken, id, err)
+	}
+	if token.Symbol, err = r.symbol(ctx, address); err != nil {
+		return Token{}, fmt.Errorf("%w: %s: %w", ErrUnknownToken, id, err)
+	}
+
+	// a discovered token is indexed by its address only, so that a contract
+	// can not take over the symbol of a registered token
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if known, ok := r.byAddress[address]; ok {
+		return known, nil
+	}
+	r.byAddress[address] = token
+	return token, nil
+}
+
+// BalanceOf returns the token balance of the owner.
+func (r *TokenRegistry) BalanceOf(ctx context.Context, token Token, owner common.Address) (*big.Int, error) {
+	results, err := r.call(ctx, token.Address, "balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := abi.ConvertType(results[0], new(big.Int)).(*big.Int)
+	if !ok || balance == nil {
+		return nil, errors.New("could not decode balance")
+	}
+	return balance, nil
+}
+
+// Transfer sends value tokens to the recipient.
+func (r *TokenRegistry) Transfer(ctx context.Context, token Token, to common.Address, value *big.Int) (common.Hash, error) {
+	return r.send(ctx, token, "token transfer", "transfer", to, value)
+}
+
+// TransferFrom sends value tokens from an owner who approved the node to the
+// recipient.
+func (r *TokenRegistry) TransferFrom(ctx context.Context, token Token, from, to common.Address, value *big.Int) (common.Hash, error) {
+	return r.send(ctx, token, "token transfer from", "transferFrom", from, to, value)
+}
+
+// Approve allows the spender to transfer up to value tokens of the node.
+func (r *TokenRegistry) Approve(ctx context.Context, token Token, spender common.Address, value *big.Int) (common.Hash, error) {
+	return r.send(ctx, token, "token approval", "approve", spender, value)
+}
+
+func (r *TokenRegistry) decimals(ctx context.Context, address common.Address) (uint8, error) {
+	results, err := r.call(ctx, address, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, ok := results[0].(uint8)
+	if !ok {
+		return 0, errors.New("could not decode decimals")
+	}
+	return decimals, nil
+}
+
+func (r *TokenRegistry) symbol(ctx context.Context, address common.Address) (string, error) {
+	results, err := r.call(ctx, address, "symbol")
+	if err != nil {
+		return "", err
+	}
+
+	symbol, ok := results[0].(string)
+	if !ok {
+		return "", errors.New("could not decode symbol")
+	}
+	return symbol, nil
+}
+
+// call calls the constant method of the token contract and returns its
+// results, at least one.
+func (r *TokenRegistry) call(ctx context.Context, address common.Address, method string, params ...interface{}) ([]interface{}, error) {
+	callData, err := tokenABI.Pack(method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := r.transactionService.Call(ctx, &TxRequest{
+		To:   &address,
+		Data: callData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := tokenABI.Unpack(method, output)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no results of token method %s", method)
+	}
+	return results, nil
+}
+
+// send sends a transaction calling the method of the token contract. The
+// result of the method is not checked as some TRC-20 tokens do not return
+// one.
+func (r *TokenRegistry) send(ctx context.Context, token Token, desc, method string, params ...interface{}) (common.Hash, error) {
+	callData, err := tokenABI.Pack(method, params...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	request := &TxRequest{
+		To:          &token.Address,
+		Data:        callData,
+		GasPrice:    sctx.GetGasPrice(ctx),
+		GasLimit:    sctx.GetGasLimitWithDefault(ctx, tokenGasLimit),
+		Value:       big.NewInt(0),
+		Description: desc,
+	}
+
+	if err := r.transactionService.Simulate(ctx, request, tokenABI.Errors); err != nil {
+		return common.Hash{}, err
+	}
+
+	return r.transactionService.Send(ctx, request, DefaultTipBoostPercent)
+}
+
+// ParseTokenAddress parses a hex encoded contract address or a base58
+// encoded Tron address, e.g. TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t.
+func ParseTokenAddress(s string) (common.Address, error) {
+	if common.IsHexAddress(s) {
+		return common.HexToAddress(s), nil
+	}
+
+	decoded, err := base58.Decode(s)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidTronAddress, err)
+	}
+	if len(decoded) != 1+common.AddressLength+4 || decoded[0] != tronAddressPrefix {
+		return common.Address{}, ErrInvalidTronAddress
+	}
+
+	payload, checksum := decoded[:1+common.AddressLength], decoded[1+common.AddressLength:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return common.Address{}, ErrInvalidTronAddress
+	}
+
+	return common.BytesToAddress(payload[1:]), nil
+}
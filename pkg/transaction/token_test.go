@@ -0,0 +1,267 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/transaction"
+	transactionmock "github.com/calmw/bee-tron/pkg/transaction/mock"
+	"github.com/calmw/bee-tron/pkg/util/abiutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/go-sw3-abi/sw3abi"
+)
+
+var tokenABI = abiutil.MustParseABI(sw3abi.ERC20ABIv0_6_5)
+
+func TestParseTokenAddress(t *testing.T) {
+	t.Parallel()
+
+	usdt := common.HexToAddress("0xa614f803b6fd780986a42c78ec9c7f77e6ded13c")
+
+	for _, tc := range []struct {
+		name    string
+		address string
+		want    common.Address
+		wantErr error
+	}{
+		{name: "hex", address: usdt.Hex(), want: usdt},
+		{name: "tron", address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", want: usdt},
+		{name: "tron wrong checksum", address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", wantErr: transaction.ErrInvalidTronAddress},
+		{name: "not base58", address: "0xinvalid", wantErr: transaction.ErrInvalidTronAddress},
+		{name: "symbol", address: "BZZ", wantErr: transaction.ErrInvalidTronAddress},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := transaction.ParseTokenAddress(tc.address)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Fatalf("got address %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenRegistry(t *testing.T) {
+	t.Parallel()
+
+	bzz := transaction.Token{Symbol: "BZZ", Address: common.HexToAddress("0xbbbb"), Decimals: 16}
+	usdtAddress := common.HexToAddress("0xa614f803b6fd780986a42c78ec9c7f77e6ded13c")
+
+	t.Run("lookup symbol", func(t *testing.T) {
+		t.Parallel()
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(), bzz)
+
+		for _, id := range []string{"BZZ", "bzz", bzz.Address.Hex()} {
+			token, err := registry.Lookup(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != bzz {
+				t.Fatalf("looked up %s as %+v, want %+v", id, token, bzz)
+			}
+		}
+	})
+
+	t.Run("lookup unknown symbol", func(t *testing.T) {
+		t.Parallel()
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(), bzz)
+
+		_, err := registry.Lookup(context.Background(), "BTC")
+		if !errors.Is(err, transaction.ErrUnknownToken) {
+			t.Fatalf("got error %v, want %v", err, transaction.ErrUnknownToken)
+		}
+	})
+
+	t.Run("lookup contract", func(t *testing.T) {
+		t.Parallel()
+
+		decimals, err := tokenABI.Methods["decimals"].Outputs.Pack(uint8(6))
+		if err != nil {
+			t.Fatal(err)
+		}
+		symbol, err := tokenABI.Methods["symbol"].Outputs.Pack("USDT")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABICallSequence(
+				transactionmock.ABICall(&tokenABI, usdtAddress, decimals, "decimals"),
+				transactionmock.ABICall(&tokenABI, usdtAddress, symbol, "symbol"),
+			),
+		), bzz)
+
+		want := transaction.Token{Symbol: "USDT", Address: usdtAddress, Decimals: 6}
+		token, err := registry.Lookup(context.Background(), "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != want {
+			t.Fatalf("got token %+v, want %+v", token, want)
+		}
+
+		// the contract is remembered by its address and not queried again
+		token, err = registry.Lookup(context.Background(), usdtAddress.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != want {
+			t.Fatalf("got token %+v, want %+v", token, want)
+		}
+
+		// but it is not registered by the symbol it claims
+		_, err = registry.Lookup(context.Background(), "usdt")
+		if !errors.Is(err, transaction.ErrUnknownToken) {
+			t.Fatalf("got error %v, want %v", err, transaction.ErrUnknownToken)
+		}
+
+		tokens := registry.Tokens()
+		if len(tokens) != 2 || tokens[0] != bzz || tokens[1] != want {
+			t.Fatalf("got tokens %+v", tokens)
+		}
+	})
+
+	t.Run("lookup contract claiming a registered symbol", func(t *testing.T) {
+		t.Parallel()
+
+		decimals, err := tokenABI.Methods["decimals"].Outputs.Pack(uint8(18))
+		if err != nil {
+			t.Fatal(err)
+		}
+		symbol, err := tokenABI.Methods["symbol"].Outputs.Pack("BZZ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fake := common.HexToAddress("0xffff")
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABICallSequence(
+				transactionmock.ABICall(&tokenABI, fake, decimals, "decimals"),
+				transactionmock.ABICall(&tokenABI, fake, symbol, "symbol"),
+			),
+		), bzz)
+
+		if _, err := registry.Lookup(context.Background(), fake.Hex()); err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := registry.Lookup(context.Background(), "BZZ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != bzz {
+			t.Fatalf("got token %+v, want %+v", token, bzz)
+		}
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		t.Parallel()
+
+		owner := common.HexToAddress("0xabcd")
+		balance := big.NewInt(1000)
+		result, err := tokenABI.Methods["balanceOf"].Outputs.Pack(balance)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABICall(&tokenABI, bzz.Address, result, "balanceOf", owner),
+		), bzz)
+
+		got, err := registry.BalanceOf(context.Background(), bzz, owner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(balance) != 0 {
+			t.Fatalf("got balance %d, want %d", got, balance)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		t.Parallel()
+
+		recipient := common.HexToAddress("0xabcd")
+		value := big.NewInt(1000)
+		txHash := common.HexToHash("0x01")
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABISend(&tokenABI, txHash, bzz.Address, big.NewInt(0), "transfer", recipient, value),
+		), bzz)
+
+		got, err := registry.Transfer(context.Background(), bzz, recipient, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != txHash {
+			t.Fatalf("got transaction %s, want %s", got, txHash)
+		}
+	})
+
+	t.Run("transfer from", func(t *testing.T) {
+		t.Parallel()
+
+		owner := common.HexToAddress("0xaaaa")
+		recipient := common.HexToAddress("0xabcd")
+		value := big.NewInt(1000)
+		txHash := common.HexToHash("0x02")
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABISend(&tokenABI, txHash, bzz.Address, big.NewInt(0), "transferFrom", owner, recipient, value),
+		), bzz)
+
+		got, err := registry.TransferFrom(context.Background(), bzz, owner, recipient, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != txHash {
+			t.Fatalf("got transaction %s, want %s", got, txHash)
+		}
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		t.Parallel()
+
+		spender := common.HexToAddress("0xabcd")
+		value := big.NewInt(1000)
+		txHash := common.HexToHash("0x03")
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithABISend(&tokenABI, txHash, bzz.Address, big.NewInt(0), "approve", spender, value),
+		), bzz)
+
+		got, err := registry.Approve(context.Background(), bzz, spender, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != txHash {
+			t.Fatalf("got transaction %s, want %s", got, txHash)
+		}
+	})
+
+	t.Run("transfer reverts", func(t *testing.T) {
+		t.Parallel()
+
+		registry := transaction.NewTokenRegistry(transactionmock.New(
+			transactionmock.WithSimulateFunc(func(ctx context.Context, request *transaction.TxRequest) error {
+				return &transaction.RevertError{Reason: "ERC20: transfer amount exceeds balance"}
+			}),
+		), bzz)
+
+		_, err := registry.Transfer(context.Background(), bzz, common.HexToAddress("0xabcd"), big.NewInt(1))
+		var revertErr *transaction.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("got error %v, want revert error", err)
+		}
+	})
+}
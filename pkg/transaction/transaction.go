@@ -33,6 +33,7 @@ const (
 	noncePrefix              = "transaction_nonce_"
 	storedTransactionPrefix  = "transaction_stored_"
 	pendingTransactionPrefix = "transaction_pending_"
+	resubmissionPrefix       = "transaction_resubmission_"
 )
 
 var (
@@ -41,11 +42,28 @@ var (
 	ErrTransactionReverted = errors.New("transaction reverted")
 	ErrUnknownTransaction  = errors.New("unknown transaction")
 	ErrAlreadyImported     = errors.New("already imported")
+	// ErrMaxGasFeeCapReached denotes that a transaction can not be
+	// resubmitted as its fee already reached the maximum.
+	ErrMaxGasFeeCapReached = errors.New("max gas fee cap reached")
 )
 
+// RevertError is returned by Simulate if the transaction would fail.
+type RevertError struct {
+	Reason string // decoded revert reason or the error reported by the node
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("transaction would revert: %s", e.Reason)
+}
+
 const (
 	DefaultTipBoostPercent = 20
 	DefaultGasLimit        = 1_000_000
+	// ResubmitFeeBumpPercent is the fee increase of resubmitted transactions.
+	ResubmitFeeBumpPercent = 20
+	// ReplacementFeeBumpPercent is the minimal fee increase nodes require to
+	// accept a replacement transaction.
+	ReplacementFeeBumpPercent = 10
 )
 
 // TxRequest describes a request for a transaction that can be executed.
@@ -56,6 +74,8 @@ type TxRequest struct {
 	GasLimit             uint64          // gas limit or 0 if it should be estimated
 	MinEstimatedGasLimit uint64          // minimum gas limit to use if the gas limit was estimated; it will not apply when this value is 0 or when GasLimit is not 0
 	GasFeeCap            *big.Int        // adds a cap to maximum fee user is willing to pay
+	GasTipCap            *big.Int        // tip for the miner or nil if the suggested tip should be used
+	Nonce                *uint64         // nonce or nil if the next nonce should be used
 	Value                *big.Int        // amount of wei to send
 	Description          string          // optional description
 }
@@ -74,6 +94,32 @@ type StoredTransaction struct {
 	Description string          // description
 }
 
+// ResubmissionAttempt is the record of an attempt to resubmit a stuck transaction.
+type ResubmissionAttempt struct {
+	TxHash      common.Hash // hash of the resubmitted transaction
+	Replacement common.Hash // hash of the replacement or zero if the attempt failed
+	GasFeeCap   *big.Int    // fee cap of the replacement
+	GasTipCap   *big.Int    // tip cap of the replacement
+	Timestamp   int64       // time of the attempt
+	Error       string      // reason of the failure, empty if the attempt succeeded
+}
+
+// NonceStatus compares the nonces of the sender known to the service with
+// the chain state.
+type NonceStatus struct {
+	LocalNonce        uint64        // next nonce according to the last transaction sent by the service, zero if unknown
+	PendingNonce      uint64        // next nonce according to the pending state of the chain
+	ConfirmedNonce    uint64        // next nonce according to the latest block
+	MissingNonces     []uint64      // nonces without a transaction blocking locally pending transactions
+	StaleTransactions []common.Hash // locally pending transactions whose nonce was already confirmed
+}
+
+// InSync reports whether no nonce gap or discrepancy was detected.
+func (s *NonceStatus) InSync() bool {
+	return len(s.MissingNonces) == 0 && len(s.StaleTransactions) == 0 &&
+		(s.LocalNonce == 0 || s.LocalNonce == s.PendingNonce)
+}
+
 // Service is the service to send transactions. It takes care of gas price, gas
 // limit and nonce management.
 type Service interface {
@@ -98,8 +144,24 @@ type Service interface {
 	ResendTransaction(ctx context.Context, txHash common.Hash) error
 	// CancelTransaction cancels a previously sent transaction by double-spending its nonce with zero-transfer one
 	CancelTransaction(ctx context.Context, originalTxHash common.Hash) (common.Hash, error)
+	// ResubmitTransaction rebroadcasts a pending transaction with fees bumped by ResubmitFeeBumpPercent,
+	// not exceeding maxGasFeeCap unless it is nil. Every attempt is recorded in the store.
+	ResubmitTransaction(ctx context.Context, txHash common.Hash, maxGasFeeCap *big.Int) (common.Hash, error)
+	// NonceStatus detects gaps and discrepancies between the locally known nonces and the chain state.
+	NonceStatus(ctx context.Context) (*NonceStatus, error)
+	// RepairNonces fills nonce gaps with zero-transfer transactions, drops stale pending transactions
+	// and re-syncs the local nonce with the chain state. It returns the status after the repair.
+	RepairNonces(ctx context.Context) (*NonceStatus, error)
 	// TransactionFee retrieves the transaction fee
 	TransactionFee(ctx context.Context, txHash common.Hash) (*big.Int, error)
+	// RegisterABI registers the methods of a contract to be named in the history.
+	RegisterABI(contractABI abi.ABI)
+	// History returns the sent transactions matching the filter ordered by creation time,
+	// together with their receipts and the names of the called methods.
+	History(filter HistoryFilter) ([]HistoryEntry, error)
+	// Simulate executes the request with eth_call against the latest block before it is sent.
+	// A *RevertError with the reason decoded using abiErrors is returned if the execution fails.
+	Simulate(ctx context.Context, request *TxRequest, abiErrors map[string]abi.Error) error
 	// UnwrapABIError tries to unwrap the ABI error if the given error is not nil.
 	// The original error is wrapped together with the ABI error if it exists.
 	UnwrapABIError(ctx context.Context, req *TxRequest, err error, abiErrors map[string]abi.Error) error
@@ -118,6 +180,9 @@ type transactionService struct {
 	store   storage.StateStorer
 	chainID *big.Int
 	monitor Monitor
+
+	methodsMu sync.RWMutex
+	methods   map[[4]byte]string // names of registered contract methods by selector
 }
 
 // NewService creates a new transaction service.
@@ -139,6 +204,7 @@ func NewService(logger log.Logger, overlayEthAddress common.Address, backend Bac
 		store:   store,
 		chainID: chainID,
 		monitor: monitor,
+		methods: make(map[[4]byte]string),
 	}
 
 	err = t.waitForAllPendingTx()
@@ -171,9 +237,14 @@ func (t *transactionService) Send(ctx context.Context, request *TxRequest, boost
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
-	nonce, err := t.nextNonce(ctx)
-	if err != nil {
-		return common.Hash{}, err
+	var nonce uint64
+	if request.Nonce != nil {
+		nonce = *request.Nonce
+	} else {
+		nonce, err = t.nextNonce(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
 	}
 
 	tx, err := t.prepareTransaction(ctx, request, nonce, boostPercent)
@@ -195,6 +266,20 @@ func (t *transactionService) Send(ctx context.Context, request *TxRequest, boost
 
 	txHash = signedTx.Hash()
 
+	// an explicit nonce may replace a pending transaction and must not
+	// lower the local nonce
+	var localNonce uint64
+	if request.Nonce != nil {
+		err = t.store.Get(nonceKey(t.sender), &localNonce)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return common.Hash{}, err
+		}
+	}
+	err = t.store.Put(nonceKey(t.sender), max(localNonce, nonce+1))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
 	err = t.store.Put(storedTransactionKey(txHash), StoredTransaction{
 		To:          signedTx.To(),
 		Data:        signedTx.Data(),
@@ -226,9 +311,12 @@ func (t *transactionService) waitForPendingTx(txHash common.Hash) {
 	t.wg.Add(1)
 	go func() {
 		defer t.wg.Done()
-		switch _, err := t.WaitForReceipt(t.ctx, txHash); {
+		switch receipt, err := t.WaitForReceipt(t.ctx, txHash); {
 		case err == nil:
 			t.logger.Info("pending transaction confirmed", "tx", txHash)
+			if err := t.storeReceipt(txHash, receipt); err != nil {
+				t.logger.Error(err, "storing receipt of pending transaction failed", "tx", txHash)
+			}
 			err = t.store.Delete(pendingTransactionKey(txHash))
 			if err != nil {
 				t.logger.Error(err, "unregistering finished pending transaction failed", "tx", txHash)
@@ -306,7 +394,7 @@ func (t *transactionService) prepareTransaction(ctx context.Context, request *Tx
 		notice that gas price does not exceed 20 as defined by max fee.
 	*/
 
-	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, request.GasPrice, boostPercent)
+	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, request.GasPrice, request.GasTipCap, boostPercent)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +411,7 @@ func (t *transactionService) prepareTransaction(ctx context.Context, request *Tx
 	}), nil
 }
 
-func (t *transactionService) suggestedFeeAndTip(ctx context.Context, gasPrice *big.Int, boostPercent int) (*big.Int, *big.Int, error) {
+func (t *transactionService) suggestedFeeAndTip(ctx context.Context, gasPrice, gasTipCap *big.Int, boostPercent int) (*big.Int, *big.Int, error) {
 	var err error
 
 	if gasPrice == nil {
@@ -334,12 +422,14 @@ func (t *transactionService) suggestedFeeAndTip(ctx context.Context, gasPrice *b
 		gasPrice = new(big.Int).Div(new(big.Int).Mul(big.NewInt(int64(boostPercent)+100), gasPrice), big.NewInt(100))
 	}
 
-	gasTipCap, err := t.backend.SuggestGasTipCap(ctx)
-	if err != nil {
-		return nil, nil, err
-	}
+	if gasTipCap == nil {
+		gasTipCap, err = t.backend.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	gasTipCap = new(big.Int).Div(new(big.Int).Mul(big.NewInt(int64(boostPercent)+100), gasTipCap), big.NewInt(100))
+		gasTipCap = new(big.Int).Div(new(big.Int).Mul(big.NewInt(int64(boostPercent)+100), gasTipCap), big.NewInt(100))
+	}
 	gasFeeCap := new(big.Int).Add(gasTipCap, gasPrice)
 
 	t.logger.Debug("prepare transaction", "gas_price", gasPrice, "gas_max_fee", gasFeeCap, "gas_max_tip", gasTipCap)
@@ -356,6 +446,14 @@ func pendingTransactionKey(txHash common.Hash) string {
 	return fmt.Sprintf("%s%x", pendingTransactionPrefix, txHash)
 }
 
+func resubmissionKey(nonce uint64) string {
+	return fmt.Sprintf("%s%d", resubmissionPrefix, nonce)
+}
+
+func nonceKey(sender common.Address) string {
+	return fmt.Sprintf("%s%x", noncePrefix, sender)
+}
+
 func (t *transactionService) nextNonce(ctx context.Context) (uint64, error) {
 	onchainNonce, err := t.backend.PendingNonceAt(ctx, t.sender)
 	if err != nil {
@@ -470,7 +568,7 @@ func (t *transactionService) ResendTransaction(ctx context.Context, txHash commo
 		return err
 	}
 
-	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, sctx.GetGasPrice(ctx), storedTransaction.GasTipBoost)
+	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, sctx.GetGasPrice(ctx), nil, storedTransaction.GasTipBoost)
 	if err != nil {
 		return err
 	}
@@ -510,7 +608,7 @@ func (t *transactionService) CancelTransaction(ctx context.Context, originalTxHa
 		return common.Hash{}, err
 	}
 
-	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, sctx.GetGasPrice(ctx), 0)
+	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, sctx.GetGasPrice(ctx), nil, 0)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -574,6 +672,136 @@ func (t *transactionService) CancelTransaction(ctx context.Context, originalTxHa
 	return txHash, err
 }
 
+func (t *transactionService) ResubmitTransaction(ctx context.Context, txHash common.Hash, maxGasFeeCap *big.Int) (common.Hash, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	storedTransaction, err := t.StoredTransaction(txHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	attempt := ResubmissionAttempt{
+		TxHash:    txHash,
+		Timestamp: time.Now().Unix(),
+	}
+
+	replacement, err := t.resubmit(ctx, storedTransaction, maxGasFeeCap, &attempt)
+	if err != nil {
+		attempt.Error = err.Error()
+	} else {
+		attempt.Replacement = replacement
+	}
+
+	if rErr := t.recordResubmission(storedTransaction.Nonce, attempt); rErr != nil {
+		t.logger.Error(rErr, "recording resubmission failed", "tx", txHash)
+	}
+
+	return replacement, err
+}
+
+// resubmit signs and sends the replacement of a stored transaction. Must be called with the lock held.
+func (t *transactionService) resubmit(ctx context.Context, storedTransaction *StoredTransaction, maxGasFeeCap *big.Int, attempt *ResubmissionAttempt) (common.Hash, error) {
+	storedFeeCap, storedTipCap := storedTransaction.GasFeeCap, storedTransaction.GasTipCap
+	if storedFeeCap == nil {
+		storedFeeCap = new(big.Int)
+	}
+	if storedTipCap == nil {
+		storedTipCap = new(big.Int)
+	}
+
+	if maxGasFeeCap != nil && storedFeeCap.Cmp(maxGasFeeCap) >= 0 {
+		return common.Hash{}, ErrMaxGasFeeCapReached
+	}
+
+	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, nil, nil, storedTransaction.GasTipBoost)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// the replacement must pay more than the original even if the suggested fees dropped
+	bump := big.NewInt(100 + ResubmitFeeBumpPercent)
+	if bumped := new(big.Int).Div(new(big.Int).Mul(bump, storedFeeCap), big.NewInt(100)); bumped.Cmp(gasFeeCap) > 0 {
+		gasFeeCap = bumped
+	}
+	if bumped := new(big.Int).Div(new(big.Int).Mul(bump, storedTipCap), big.NewInt(100)); bumped.Cmp(gasTipCap) > 0 {
+		gasTipCap = bumped
+	}
+
+	if maxGasFeeCap != nil && gasFeeCap.Cmp(maxGasFeeCap) > 0 {
+		gasFeeCap = new(big.Int).Set(maxGasFeeCap)
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+
+	// the replacement capped below the required bump would be rejected by the nodes
+	minBump := big.NewInt(100 + ReplacementFeeBumpPercent)
+	minFeeCap := new(big.Int).Div(new(big.Int).Mul(minBump, storedFeeCap), big.NewInt(100))
+	minTipCap := new(big.Int).Div(new(big.Int).Mul(minBump, storedTipCap), big.NewInt(100))
+	if gasFeeCap.Cmp(minFeeCap) < 0 || gasTipCap.Cmp(minTipCap) < 0 {
+		return common.Hash{}, ErrMaxGasFeeCapReached
+	}
+
+	attempt.GasFeeCap = gasFeeCap
+	attempt.GasTipCap = gasTipCap
+
+	signedTx, err := t.signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		Nonce:     storedTransaction.Nonce,
+		ChainID:   t.chainID,
+		To:        storedTransaction.To,
+		Value:     storedTransaction.Value,
+		Gas:       storedTransaction.GasLimit,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Data:      storedTransaction.Data,
+	}), t.chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	err = t.backend.SendTransaction(ctx, signedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	txHash := signedTx.Hash()
+	err = t.store.Put(storedTransactionKey(txHash), StoredTransaction{
+		To:          signedTx.To(),
+		Data:        signedTx.Data(),
+		GasPrice:    signedTx.GasPrice(),
+		GasLimit:    signedTx.Gas(),
+		GasTipBoost: storedTransaction.GasTipBoost,
+		GasTipCap:   signedTx.GasTipCap(),
+		GasFeeCap:   signedTx.GasFeeCap(),
+		Value:       signedTx.Value(),
+		Nonce:       signedTx.Nonce(),
+		Created:     time.Now().Unix(),
+		Description: storedTransaction.Description,
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	err = t.store.Put(pendingTransactionKey(txHash), struct{}{})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	t.waitForPendingTx(txHash)
+
+	return txHash, nil
+}
+
+func (t *transactionService) recordResubmission(nonce uint64, attempt ResubmissionAttempt) error {
+	var attempts []ResubmissionAttempt
+	err := t.store.Get(resubmissionKey(nonce), &attempts)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	return t.store.Put(resubmissionKey(nonce), append(attempts, attempt))
+}
+
 func (t *transactionService) Close() error {
 	t.cancel()
 	t.wg.Wait()
@@ -604,14 +832,47 @@ func (t *transactionService) UnwrapABIError(ctx context.Context, req *TxRequest,
 		return err
 	}
 
+	if reason, ok := decodeRevertData(derr, abiErrors); ok {
+		return fmt.Errorf("%w: %s", err, reason)
+	}
+
+	return err
+}
+
+func (t *transactionService) Simulate(ctx context.Context, request *TxRequest, abiErrors map[string]abi.Error) error {
+	_, err := t.Call(ctx, request)
+	if err == nil {
+		return nil
+	}
+
+	// only errors returned by the node are caused by the execution
+	var derr rpc.DataError
+	if !errors.As(err, &derr) {
+		return err
+	}
+
+	reason, ok := decodeRevertData(derr, abiErrors)
+	if !ok {
+		reason = derr.Error()
+	}
+	return &RevertError{Reason: reason}
+}
+
+// decodeRevertData decodes the revert reason or one of the abiErrors from
+// the data of a failed call.
+func decodeRevertData(derr rpc.DataError, abiErrors map[string]abi.Error) (string, bool) {
 	res, ok := derr.ErrorData().(string)
 	if !ok {
-		return err
+		return "", false
 	}
 	buf := common.FromHex(res)
 
 	if reason, uErr := abi.UnpackRevert(buf); uErr == nil {
-		return fmt.Errorf("%w: %s", err, reason)
+		return reason, true
+	}
+
+	if len(buf) < 4 {
+		return "", false
 	}
 
 	for _, abiError := range abiErrors {
@@ -638,11 +899,165 @@ func (t *transactionService) UnwrapABIError(ctx context.Context, req *TxRequest,
 				input.Name = fmt.Sprintf("arg%d", i)
 			}
 			params[i] = fmt.Sprintf("%s=%v", input.Name, values[i])
+		}
 
+		return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(params, ",")), true
+	}
+
+	return "", false
+}
+
+func (t *transactionService) NonceStatus(ctx context.Context) (*NonceStatus, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.nonceStatus(ctx)
+}
+
+// nonceStatus compares the stored nonce and the nonces of the pending
+// transactions with the chain state. Must be called with the lock held.
+func (t *transactionService) nonceStatus(ctx context.Context) (*NonceStatus, error) {
+	status := &NonceStatus{}
+
+	err := t.store.Get(nonceKey(t.sender), &status.LocalNonce)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	status.PendingNonce, err = t.backend.PendingNonceAt(ctx, t.sender)
+	if err != nil {
+		return nil, err
+	}
+
+	status.ConfirmedNonce, err = t.backend.NonceAt(ctx, t.sender, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingTxs, err := t.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingTxs = t.filterPendingTransactions(ctx, pendingTxs)
+
+	used := make(map[uint64]bool)
+	var next uint64
+	for _, txHash := range pendingTxs {
+		storedTransaction, err := t.StoredTransaction(txHash)
+		if err != nil {
+			return nil, err
+		}
+
+		// the nonce was used by another transaction, e.g. from an external wallet
+		if storedTransaction.Nonce < status.ConfirmedNonce {
+			status.StaleTransactions = append(status.StaleTransactions, txHash)
+			continue
 		}
 
-		return fmt.Errorf("%w: %s(%s)", err, abiError.Name, strings.Join(params, ","))
+		used[storedTransaction.Nonce] = true
+		next = max(next, storedTransaction.Nonce+1)
 	}
 
-	return err
+	// the chain does not accept any transaction above a nonce without a transaction
+	for nonce := status.PendingNonce; nonce < next; nonce++ {
+		if !used[nonce] {
+			status.MissingNonces = append(status.MissingNonces, nonce)
+		}
+	}
+
+	return status, nil
+}
+
+func (t *transactionService) RepairNonces(ctx context.Context) (*NonceStatus, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	status, err := t.nonceStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txHash := range status.StaleTransactions {
+		err := t.store.Delete(pendingTransactionKey(txHash))
+		if err != nil {
+			return nil, err
+		}
+		t.logger.Warning("dropped pending transaction with already used nonce", "tx", txHash)
+	}
+
+	for _, nonce := range status.MissingNonces {
+		txHash, err := t.fillNonce(ctx, nonce)
+		if err != nil {
+			return nil, fmt.Errorf("fill nonce %d: %w", nonce, err)
+		}
+		t.logger.Info("filled nonce gap", "nonce", nonce, "tx", txHash)
+	}
+
+	nonce, err := t.nextNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.store.Put(nonceKey(t.sender), nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.nonceStatus(ctx)
+}
+
+// fillNonce sends a zero-transfer transaction to the sender with the given
+// nonce. Must be called with the lock held.
+func (t *transactionService) fillNonce(ctx context.Context, nonce uint64) (common.Hash, error) {
+	gasFeeCap, gasTipCap, err := t.suggestedFeeAndTip(ctx, sctx.GetGasPrice(ctx), nil, DefaultTipBoostPercent)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	signedTx, err := t.signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		ChainID:   t.chainID,
+		To:        &t.sender,
+		Value:     big.NewInt(0),
+		Gas:       21000,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Data:      []byte{},
+	}), t.chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	err = t.backend.SendTransaction(ctx, signedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	txHash := signedTx.Hash()
+	err = t.store.Put(storedTransactionKey(txHash), StoredTransaction{
+		To:          signedTx.To(),
+		Data:        signedTx.Data(),
+		GasPrice:    signedTx.GasPrice(),
+		GasLimit:    signedTx.Gas(),
+		GasTipBoost: DefaultTipBoostPercent,
+		GasTipCap:   signedTx.GasTipCap(),
+		GasFeeCap:   signedTx.GasFeeCap(),
+		Value:       signedTx.Value(),
+		Nonce:       signedTx.Nonce(),
+		Created:     time.Now().Unix(),
+		Description: "nonce gap filler",
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	err = t.store.Put(pendingTransactionKey(txHash), struct{}{})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	t.waitForPendingTx(txHash)
+
+	return txHash, nil
 }
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/calmw/bee-tron/pkg/log"
 	"github.com/calmw/bee-tron/pkg/sctx"
 	storemock "github.com/calmw/bee-tron/pkg/statestore/mock"
+	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/transaction"
 	"github.com/calmw/bee-tron/pkg/transaction/backendmock"
 	"github.com/calmw/bee-tron/pkg/transaction/monitormock"
@@ -422,6 +424,79 @@ func TestTransactionSend(t *testing.T) {
 		}
 	})
 
+	t.Run("send with explicit nonce and tip", func(t *testing.T) {
+		t.Parallel()
+
+		explicitNonce := uint64(7)
+		explicitTip := big.NewInt(42)
+		request := &transaction.TxRequest{
+			To:        &recipient,
+			Data:      txData,
+			GasLimit:  estimatedGasLimit,
+			GasTipCap: explicitTip,
+			Nonce:     &explicitNonce,
+			Value:     value,
+		}
+		store := storemock.NewStateStore()
+
+		transactionService, err := transaction.NewService(logger, sender,
+			backendmock.New(
+				backendmock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+					return nil
+				}),
+				backendmock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+					return suggestedGasPrice, nil
+				}),
+				backendmock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+					t.Fatal("suggesting gas tip despite explicit tip")
+					return nil, nil
+				}),
+				backendmock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+					t.Fatal("looking up nonce despite explicit nonce")
+					return 0, nil
+				}),
+			),
+			signermock.New(
+				signermock.WithSignTxFunc(func(tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+					return tx, nil
+				}),
+				signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+					return sender, nil
+				}),
+			),
+			store,
+			chainID,
+			monitormock.New(
+				monitormock.WithWatchTransactionFunc(func(txHash common.Hash, nonce uint64) (<-chan types.Receipt, <-chan error, error) {
+					return nil, nil, nil
+				}),
+			),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		txHash, err := transactionService.Send(context.Background(), request, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		storedTransaction, err := transactionService.StoredTransaction(txHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if storedTransaction.Nonce != explicitNonce {
+			t.Fatalf("got wrong nonce in stored transaction. wanted %d, got %d", explicitNonce, storedTransaction.Nonce)
+		}
+		if storedTransaction.GasTipCap.Cmp(explicitTip) != 0 {
+			t.Fatalf("got wrong gas tip in stored transaction. wanted %d, got %d", explicitTip, storedTransaction.GasTipCap)
+		}
+		if want := new(big.Int).Add(suggestedGasPrice, explicitTip); storedTransaction.GasFeeCap.Cmp(want) != 0 {
+			t.Fatalf("got wrong gas fee cap in stored transaction. wanted %d, got %d", want, storedTransaction.GasFeeCap)
+		}
+	})
+
 	t.Run("send_no_nonce", func(t *testing.T) {
 		t.Parallel()
 
@@ -837,6 +912,222 @@ func TestTransactionCancel(t *testing.T) {
 	})
 }
 
+func TestTransactionResubmit(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	sender := common.HexToAddress("0xddff")
+	recipient := common.HexToAddress("0xbbbddd")
+	chainID := big.NewInt(5)
+	nonce := uint64(10)
+	data := []byte{1, 2, 3, 4}
+	gasPrice := big.NewInt(1000)
+	gasTip := big.NewInt(100)
+	gasFee := big.NewInt(1100)
+	gasLimit := uint64(100000)
+	value := big.NewInt(0)
+
+	pendingTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &recipient,
+		Value:     value,
+		Gas:       gasLimit,
+		GasFeeCap: gasFee,
+		GasTipCap: gasTip,
+		Data:      data,
+	})
+
+	newStore := func(t *testing.T) storage.StateStorer {
+		t.Helper()
+
+		store := storemock.NewStateStore()
+		testutil.CleanupCloser(t, store)
+
+		err := store.Put(transaction.StoredTransactionKey(pendingTx.Hash()), transaction.StoredTransaction{
+			Nonce:       nonce,
+			To:          &recipient,
+			Data:        data,
+			GasPrice:    gasFee,
+			GasLimit:    gasLimit,
+			GasFeeCap:   gasFee,
+			GasTipCap:   gasTip,
+			Value:       value,
+			Description: "test",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}
+
+	t.Run("bumped fee capped at max", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+		maxGasFeeCap := big.NewInt(1300)
+
+		// the tip is bumped by 20%, the fee cap would be 1320 but is capped
+		replacementTx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			To:        &recipient,
+			Value:     value,
+			Gas:       gasLimit,
+			GasFeeCap: maxGasFeeCap,
+			GasTipCap: big.NewInt(120),
+			Data:      data,
+		})
+
+		transactionService, err := transaction.NewService(logger, sender,
+			backendmock.New(
+				backendmock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+					if tx != replacementTx {
+						t.Fatal("not sending signed transaction")
+					}
+					return nil
+				}),
+				backendmock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+					return gasPrice, nil
+				}),
+				backendmock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+					return gasTip, nil
+				}),
+			),
+			signerMockForTransaction(t, replacementTx, recipient, chainID),
+			store,
+			chainID,
+			monitormock.New(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		replacementHash, err := transactionService.ResubmitTransaction(context.Background(), pendingTx.Hash(), maxGasFeeCap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replacementHash != replacementTx.Hash() {
+			t.Fatalf("returned wrong hash. wanted %v, got %v", replacementTx.Hash(), replacementHash)
+		}
+
+		storedTransaction, err := transactionService.StoredTransaction(replacementHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if storedTransaction.Nonce != nonce || storedTransaction.GasFeeCap.Cmp(maxGasFeeCap) != 0 || storedTransaction.Description != "test" {
+			t.Fatalf("stored wrong replacement %+v", storedTransaction)
+		}
+
+		pending, err := transactionService.PendingTransactions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pending) != 1 || pending[0] != replacementHash {
+			t.Fatalf("got pending transactions %v, want %v", pending, []common.Hash{replacementHash})
+		}
+
+		var attempts []transaction.ResubmissionAttempt
+		if err := store.Get(transaction.ResubmissionKey(nonce), &attempts); err != nil {
+			t.Fatal(err)
+		}
+		if len(attempts) != 1 {
+			t.Fatalf("got %d recorded attempts, want 1", len(attempts))
+		}
+		if a := attempts[0]; a.TxHash != pendingTx.Hash() || a.Replacement != replacementHash || a.GasFeeCap.Cmp(maxGasFeeCap) != 0 || a.GasTipCap.Cmp(big.NewInt(120)) != 0 || a.Error != "" {
+			t.Fatalf("recorded wrong attempt %+v", a)
+		}
+	})
+
+	t.Run("max fee reached", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+
+		transactionService, err := transaction.NewService(logger, sender,
+			backendmock.New(
+				backendmock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+					t.Fatal("transaction sent")
+					return nil
+				}),
+			),
+			signermock.New(
+				signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+					return sender, nil
+				}),
+			),
+			store,
+			chainID,
+			monitormock.New(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		_, err = transactionService.ResubmitTransaction(context.Background(), pendingTx.Hash(), gasFee)
+		if !errors.Is(err, transaction.ErrMaxGasFeeCapReached) {
+			t.Fatalf("got error %v, want %v", err, transaction.ErrMaxGasFeeCapReached)
+		}
+
+		var attempts []transaction.ResubmissionAttempt
+		if err := store.Get(transaction.ResubmissionKey(nonce), &attempts); err != nil {
+			t.Fatal(err)
+		}
+		if len(attempts) != 1 || attempts[0].Error != transaction.ErrMaxGasFeeCapReached.Error() || attempts[0].Replacement != (common.Hash{}) {
+			t.Fatalf("recorded wrong attempts %+v", attempts)
+		}
+	})
+
+	t.Run("max fee below the required bump", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+
+		transactionService, err := transaction.NewService(logger, sender,
+			backendmock.New(
+				backendmock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+					t.Fatal("transaction sent")
+					return nil
+				}),
+				backendmock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+					return gasPrice, nil
+				}),
+				backendmock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+					return gasTip, nil
+				}),
+			),
+			signermock.New(
+				signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+					return sender, nil
+				}),
+			),
+			store,
+			chainID,
+			monitormock.New(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		// the fee cap of the replacement must be at least 1210
+		_, err = transactionService.ResubmitTransaction(context.Background(), pendingTx.Hash(), big.NewInt(1200))
+		if !errors.Is(err, transaction.ErrMaxGasFeeCapReached) {
+			t.Fatalf("got error %v, want %v", err, transaction.ErrMaxGasFeeCapReached)
+		}
+
+		var attempts []transaction.ResubmissionAttempt
+		if err := store.Get(transaction.ResubmissionKey(nonce), &attempts); err != nil {
+			t.Fatal(err)
+		}
+		if len(attempts) != 1 || attempts[0].Error != transaction.ErrMaxGasFeeCapReached.Error() || attempts[0].Replacement != (common.Hash{}) {
+			t.Fatalf("recorded wrong attempts %+v", attempts)
+		}
+	})
+}
+
 // rpcAPIError is a copy of engine.EngineAPIError from go-ethereum pkg.
 type rpcAPIError struct {
 	code int
@@ -922,3 +1213,481 @@ func TestTransactionService_UnwrapABIError(t *testing.T) {
 		t.Fatal("wrapped error without rpc api error data")
 	}
 }
+
+func TestTransactionSimulate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = common.HexToAddress("0xddff")
+		recipient = common.HexToAddress("0xbbbddd")
+		chainID   = big.NewInt(5)
+
+		contractABI = abiutil.MustParseABI(`[{"inputs":[{"internalType":"uint256","name":"available","type":"uint256"},{"internalType":"uint256","name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`)
+		request     = &transaction.TxRequest{
+			To:    &recipient,
+			Data:  common.Hex2Bytes("abcdee"),
+			Value: big.NewInt(0),
+		}
+	)
+
+	newService := func(t *testing.T, callErr error) transaction.Service {
+		t.Helper()
+
+		transactionService, err := transaction.NewService(log.Noop, sender,
+			backendmock.New(
+				backendmock.WithCallContractFunc(func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+					if *call.To != recipient || !bytes.Equal(call.Data, request.Data) {
+						t.Fatal("simulated wrong call")
+					}
+					return nil, callErr
+				}),
+			),
+			signermock.New(
+				signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+					return sender, nil
+				}),
+			),
+			storemock.NewStateStore(),
+			chainID,
+			monitormock.New(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+		return transactionService
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		err := newService(t, nil).Simulate(context.Background(), request, contractABI.Errors)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		t.Parallel()
+
+		err := newService(t, &rpcAPIError{
+			code: 3,
+			msg:  "execution reverted",
+			err:  "0xcf4791810000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006f",
+		}).Simulate(context.Background(), request, contractABI.Errors)
+
+		var revertErr *transaction.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("got error %v, want revert error", err)
+		}
+		if want := "InsufficientBalance(available=0,required=111)"; revertErr.Reason != want {
+			t.Fatalf("got reason %q, want %q", revertErr.Reason, want)
+		}
+	})
+
+	t.Run("revert reason", func(t *testing.T) {
+		t.Parallel()
+
+		// abi encoded Error(string) with reason "not enough funds"
+		err := newService(t, &rpcAPIError{
+			code: 3,
+			msg:  "execution reverted: not enough funds",
+			err:  "0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000106e6f7420656e6f7567682066756e647300000000000000000000000000000000",
+		}).Simulate(context.Background(), request, contractABI.Errors)
+
+		var revertErr *transaction.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("got error %v, want revert error", err)
+		}
+		if want := "not enough funds"; revertErr.Reason != want {
+			t.Fatalf("got reason %q, want %q", revertErr.Reason, want)
+		}
+	})
+
+	t.Run("without data", func(t *testing.T) {
+		t.Parallel()
+
+		err := newService(t, &rpcAPIError{
+			code: -32000,
+			msg:  "insufficient funds for gas * price + value",
+		}).Simulate(context.Background(), request, contractABI.Errors)
+
+		var revertErr *transaction.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("got error %v, want revert error", err)
+		}
+		if want := "insufficient funds for gas * price + value"; revertErr.Reason != want {
+			t.Fatalf("got reason %q, want %q", revertErr.Reason, want)
+		}
+	})
+
+	t.Run("backend failure", func(t *testing.T) {
+		t.Parallel()
+
+		backendErr := errors.New("connection refused")
+		err := newService(t, backendErr).Simulate(context.Background(), request, contractABI.Errors)
+		if !errors.Is(err, backendErr) {
+			t.Fatalf("got error %v, want %v", err, backendErr)
+		}
+		var revertErr *transaction.RevertError
+		if errors.As(err, &revertErr) {
+			t.Fatal("backend failure reported as revert")
+		}
+	})
+}
+
+func TestTransactionNonceGaps(t *testing.T) {
+	t.Parallel()
+
+	logger := log.Noop
+	sender := common.HexToAddress("0xddff")
+	recipient := common.HexToAddress("0xbbbddd")
+	chainID := big.NewInt(5)
+	gasPrice := big.NewInt(1000)
+	gasTip := big.NewInt(100)
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			To:        &recipient,
+			Value:     big.NewInt(0),
+			Gas:       21000,
+			GasFeeCap: gasPrice,
+			GasTipCap: gasTip,
+		})
+	}
+
+	// the transaction with nonce 12 is queued behind the gap of nonces 10 and 11
+	// while the nonce of the transaction with nonce 8 was used by another transaction
+	queuedTx := newTx(12)
+	staleTx := newTx(8)
+
+	newStore := func(t *testing.T) storage.StateStorer {
+		t.Helper()
+
+		store := storemock.NewStateStore()
+		testutil.CleanupCloser(t, store)
+
+		for _, tx := range []*types.Transaction{queuedTx, staleTx} {
+			err := store.Put(transaction.StoredTransactionKey(tx.Hash()), transaction.StoredTransaction{
+				Nonce: tx.Nonce(),
+				To:    tx.To(),
+				Value: tx.Value(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = store.Put(transaction.PendingTransactionKey(tx.Hash()), struct{}{})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.Put(transaction.NonceKey(sender), uint64(13)); err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}
+
+	// newBackend simulates the chain, where sent transactions close the gap
+	newBackend := func() transaction.Backend {
+		var (
+			mu           sync.Mutex
+			pendingNonce = uint64(10)
+			txs          = map[common.Hash]*types.Transaction{
+				queuedTx.Hash(): queuedTx,
+				staleTx.Hash():  staleTx,
+			}
+		)
+		return backendmock.New(
+			backendmock.WithPendingNonceAtFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return pendingNonce, nil
+			}),
+			backendmock.WithNonceAtFunc(func(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+				return 10, nil
+			}),
+			backendmock.WithTransactionByHashFunc(func(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				tx, ok := txs[txHash]
+				if !ok {
+					return nil, false, ethereum.NotFound
+				}
+				return tx, true, nil
+			}),
+			backendmock.WithSendTransactionFunc(func(ctx context.Context, tx *types.Transaction) error {
+				mu.Lock()
+				defer mu.Unlock()
+				txs[tx.Hash()] = tx
+				if tx.Nonce() == pendingNonce {
+					pendingNonce = queuedTx.Nonce() + 1
+				}
+				return nil
+			}),
+			backendmock.WithSuggestGasPriceFunc(func(ctx context.Context) (*big.Int, error) {
+				return gasPrice, nil
+			}),
+			backendmock.WithSuggestGasTipCapFunc(func(ctx context.Context) (*big.Int, error) {
+				return gasTip, nil
+			}),
+		)
+	}
+
+	signer := signermock.New(
+		signermock.WithSignTxFunc(func(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+			return transaction, nil
+		}),
+		signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+			return sender, nil
+		}),
+	)
+
+	t.Run("status", func(t *testing.T) {
+		t.Parallel()
+
+		transactionService, err := transaction.NewService(logger, sender, newBackend(), signer, newStore(t), chainID, monitormock.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		status, err := transactionService.NonceStatus(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if status.LocalNonce != 13 || status.PendingNonce != 10 || status.ConfirmedNonce != 10 {
+			t.Fatalf("got nonces %+v", status)
+		}
+		if fmt.Sprint(status.MissingNonces) != "[10 11]" {
+			t.Fatalf("got missing nonces %v, want [10 11]", status.MissingNonces)
+		}
+		if len(status.StaleTransactions) != 1 || status.StaleTransactions[0] != staleTx.Hash() {
+			t.Fatalf("got stale transactions %v, want [%v]", status.StaleTransactions, staleTx.Hash())
+		}
+		if status.InSync() {
+			t.Fatal("expected nonces to be out of sync")
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		t.Parallel()
+
+		transactionService, err := transaction.NewService(logger, sender, newBackend(), signer, newStore(t), chainID, monitormock.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.CleanupCloser(t, transactionService)
+
+		status, err := transactionService.RepairNonces(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !status.InSync() {
+			t.Fatalf("expected nonces to be in sync, got %+v", status)
+		}
+		if status.LocalNonce != 13 || status.PendingNonce != 13 {
+			t.Fatalf("got nonces %+v", status)
+		}
+
+		pending, err := transactionService.PendingTransactions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the queued transaction and the two fillers
+		if len(pending) != 3 {
+			t.Fatalf("got %d pending transactions, want 3", len(pending))
+		}
+		for _, txHash := range pending {
+			if txHash == staleTx.Hash() {
+				t.Fatal("stale transaction still pending")
+			}
+		}
+	})
+}
+
+func TestTransactionHistory(t *testing.T) {
+	t.Parallel()
+
+	sender := common.HexToAddress("0xddff")
+	contract := common.HexToAddress("0xbbbddd")
+	other := common.HexToAddress("0xcccddd")
+	chainID := big.NewInt(5)
+
+	contractABI := abiutil.MustParseABI(`[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[]}]`)
+	transferData, err := contractABI.Pack("transfer", other, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confirmedTx := common.HexToHash("0x01")
+	revertedTx := common.HexToHash("0x02")
+	pendingTx := common.HexToHash("0x03")
+	otherTx := common.HexToHash("0x04")
+
+	store := storemock.NewStateStore()
+	testutil.CleanupCloser(t, store)
+
+	for _, stored := range []struct {
+		txHash  common.Hash
+		to      common.Address
+		created int64
+	}{
+		{revertedTx, contract, 200},
+		{confirmedTx, contract, 100},
+		{pendingTx, contract, 300},
+		{otherTx, other, 150},
+	} {
+		err := store.Put(transaction.StoredTransactionKey(stored.txHash), transaction.StoredTransaction{
+			To:      &stored.to,
+			Data:    transferData,
+			Created: stored.created,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for txHash, status := range map[common.Hash]uint64{confirmedTx: types.ReceiptStatusSuccessful, revertedTx: types.ReceiptStatusFailed} {
+		err := store.Put(transaction.ReceiptKey(txHash), transaction.StoredReceipt{
+			Status:            status,
+			GasUsed:           21000,
+			EffectiveGasPrice: big.NewInt(2),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	transactionService, err := transaction.NewService(log.Noop, sender, backendmock.New(), signermock.New(
+		signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+			return sender, nil
+		}),
+	), store, chainID, monitormock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, transactionService)
+
+	// the pending transaction is registered after the service started to not be watched
+	if err := store.Put(transaction.PendingTransactionKey(pendingTx), struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	transactionService.RegisterABI(contractABI)
+
+	t.Run("contract", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := transactionService.History(transaction.HistoryFilter{Contract: &contract})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []struct {
+			txHash common.Hash
+			status string
+		}{
+			{confirmedTx, transaction.HistoryStatusSuccess},
+			{revertedTx, transaction.HistoryStatusReverted},
+			{pendingTx, transaction.HistoryStatusPending},
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("got %d entries, want %d", len(entries), len(want))
+		}
+		for i, w := range want {
+			entry := entries[i]
+			if entry.TxHash != w.txHash || entry.Status != w.status || entry.Method != "transfer" {
+				t.Fatalf("got entry %d %+v, want %v with status %s", i, entry, w.txHash, w.status)
+			}
+		}
+		if entries[0].Fee.Cmp(big.NewInt(42000)) != 0 {
+			t.Fatalf("got fee %v, want 42000", entries[0].Fee)
+		}
+		if entries[2].Receipt != nil || entries[2].Fee != nil {
+			t.Fatal("pending transaction has a receipt")
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		t.Parallel()
+
+		entries, err := transactionService.History(transaction.HistoryFilter{
+			From: time.Unix(150, 0),
+			To:   time.Unix(200, 0),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(entries) != 2 || entries[0].TxHash != otherTx || entries[1].TxHash != revertedTx {
+			t.Fatalf("got entries %+v", entries)
+		}
+	})
+}
+
+func TestTransactionHistoryReplaced(t *testing.T) {
+	t.Parallel()
+
+	sender := common.HexToAddress("0xddff")
+	contract := common.HexToAddress("0xbbbddd")
+	originalTx := common.HexToHash("0x01")
+	replacementTx := common.HexToHash("0x02")
+
+	store := storemock.NewStateStore()
+	testutil.CleanupCloser(t, store)
+
+	for txHash, created := range map[common.Hash]int64{originalTx: 100, replacementTx: 200} {
+		err := store.Put(transaction.StoredTransactionKey(txHash), transaction.StoredTransaction{
+			To:      &contract,
+			Created: created,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	transactionService, err := transaction.NewService(log.Noop, sender, backendmock.New(), signermock.New(
+		signermock.WithEthereumAddressFunc(func() (common.Address, error) {
+			return sender, nil
+		}),
+	), store, big.NewInt(5), monitormock.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, transactionService)
+
+	// both the original and the replacement are notified about the receipt of the replacement
+	receipt := &types.Receipt{
+		TxHash:            replacementTx,
+		Status:            types.ReceiptStatusSuccessful,
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(2),
+	}
+	for _, txHash := range []common.Hash{originalTx, replacementTx} {
+		if err := transaction.StoreReceipt(transactionService, txHash, receipt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := transactionService.History(transaction.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	original, replacement := entries[0], entries[1]
+	if original.Status != transaction.HistoryStatusReplaced || original.ReplacedBy == nil || *original.ReplacedBy != replacementTx {
+		t.Fatalf("got original %+v, want replaced by %v", original, replacementTx)
+	}
+	if original.Receipt != nil || original.Fee != nil {
+		t.Fatal("replaced transaction has a receipt")
+	}
+	if replacement.Status != transaction.HistoryStatusSuccess || replacement.Fee.Cmp(big.NewInt(42000)) != 0 {
+		t.Fatalf("got replacement %+v, want success with fee 42000", replacement)
+	}
+}
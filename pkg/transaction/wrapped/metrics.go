@@ -27,6 +27,7 @@ type metrics struct {
 	SendTransactionCalls    prometheus.Counter
 	FilterLogsCalls         prometheus.Counter
 	ChainIDCalls            prometheus.Counter
+	SubscribeNewHeadCalls   prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -129,9 +130,19 @@ func newMetrics() metrics {
 			Name:      "calls_chain_id",
 			Help:      "Count of eth_chainId rpc calls",
 		}),
+		SubscribeNewHeadCalls: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "calls_subscribe_new_head",
+			Help:      "Count of eth_subscribe newHeads rpc calls",
+		}),
 	}
 }
 
 func (b *wrappedBackend) Metrics() []prometheus.Collector {
-	return m.PrometheusCollectorsFromFields(b.metrics)
+	collectors := m.PrometheusCollectorsFromFields(b.metrics)
+	if c, ok := b.backend.(m.Collector); ok {
+		collectors = append(collectors, c.Metrics()...)
+	}
+	return collectors
 }
@@ -13,10 +13,12 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
-	_ transaction.Backend = (*wrappedBackend)(nil)
+	_ transaction.Backend        = (*wrappedBackend)(nil)
+	_ transaction.HeadSubscriber = (*wrappedBackend)(nil)
 )
 
 type wrappedBackend struct {
@@ -202,6 +204,24 @@ func (b *wrappedBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	return chainID, nil
 }
 
+func (b *wrappedBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	subscriber, ok := b.backend.(transaction.HeadSubscriber)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	b.metrics.TotalRPCCalls.Inc()
+	b.metrics.SubscribeNewHeadCalls.Inc()
+	sub, err := subscriber.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			b.metrics.TotalRPCErrors.Inc()
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
 func (b *wrappedBackend) Close() {
 	b.backend.Close()
 }
@@ -12,6 +12,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/calmw/bee-tron/pkg/file/joiner"
 	"github.com/calmw/bee-tron/pkg/file/loadsave"
@@ -21,34 +22,93 @@ import (
 	"github.com/calmw/bee-tron/pkg/soc"
 	"github.com/calmw/bee-tron/pkg/storage"
 	"github.com/calmw/bee-tron/pkg/swarm"
+	"golang.org/x/sync/errgroup"
 )
 
+// DefaultWorkers is the default number of the references of a manifest
+// which are traversed concurrently.
+const DefaultWorkers = 8
+
 // Traverser represents service which traverse through address dependent chunks.
 type Traverser interface {
 	// Traverse iterates through each address related to the supplied one, if possible.
 	Traverse(context.Context, swarm.Address, swarm.AddressIterFunc) error
 }
 
+// Progress is the progress of a traversal.
+type Progress struct {
+	// Visited is the number of the addresses visited so far.
+	Visited uint64
+	// Depth is the depth of the reference of the last visited address,
+	// 0 for the root reference if it is not a manifest and 1 for the nodes
+	// and the entries of a manifest.
+	Depth int
+}
+
+// ProgressFunc is called after each visited address.
+type ProgressFunc func(Progress)
+
+// Options are the options of a Traverser.
+type Options struct {
+	// Workers is the number of the references of a manifest which are
+	// traversed concurrently, DefaultWorkers if not positive.
+	Workers int
+	// Progress is called after each visited address if set.
+	Progress ProgressFunc
+}
+
 // New constructs for a new Traverser.
 func New(getter storage.Getter, putter storage.Putter, rLevel redundancy.Level) Traverser {
-	return &service{getter: getter, putter: putter, rLevel: rLevel}
+	return NewWithOptions(getter, putter, rLevel, Options{})
+}
+
+// NewWithOptions constructs a new Traverser with the given options.
+func NewWithOptions(getter storage.Getter, putter storage.Putter, rLevel redundancy.Level, opts Options) Traverser {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	return &service{getter: getter, putter: putter, rLevel: rLevel, workers: opts.Workers, progress: opts.Progress}
 }
 
 // service is implementation of Traverser using storage.Storer as its storage.
 type service struct {
-	getter storage.Getter
-	putter storage.Putter
-	rLevel redundancy.Level
+	getter   storage.Getter
+	putter   storage.Putter
+	rLevel   redundancy.Level
+	workers  int
+	progress ProgressFunc
 }
 
 // Traverse implements Traverser.Traverse method.
+// The references of a manifest are traversed concurrently, but the
+// iterFn is never called concurrently.
 func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm.AddressIterFunc) error {
-	processBytes := func(ref swarm.Address) error {
+	var (
+		mu      sync.Mutex
+		visited uint64
+	)
+	visit := func(depth int) swarm.AddressIterFunc {
+		return func(a swarm.Address) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err := iterFn(a); err != nil {
+				return err
+			}
+			visited++
+			if s.progress != nil {
+				s.progress(Progress{Visited: visited, Depth: depth})
+			}
+			return nil
+		}
+	}
+
+	processBytes := func(ctx context.Context, ref swarm.Address, depth int) error {
 		j, _, err := joiner.New(ctx, s.getter, s.putter, ref, s.rLevel)
 		if err != nil {
 			return fmt.Errorf("traversal: joiner error on %q: %w", ref, err)
 		}
-		err = j.IterateChunkAddresses(iterFn)
+		err = j.IterateChunkAddresses(visit(depth))
 		if err != nil {
 			return fmt.Errorf("traversal: iterate chunk address error for %q: %w", ref, err)
 		}
@@ -63,7 +123,7 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 		}
 		if soc.Valid(ch) {
 			// if this is a SOC, the traversal will be just be the single chunk
-			return iterFn(addr)
+			return visit(0)(addr)
 		}
 	}
 
@@ -84,7 +144,17 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 		case err != nil:
 			return fmt.Errorf("traversal: unable to create manifest reference for %q: %w", addr, err)
 		default:
-			err := mf.IterateAddresses(ctx, processBytes)
+			eg, ectx := errgroup.WithContext(ctx)
+			eg.SetLimit(s.workers)
+			err := mf.IterateAddresses(ectx, func(ref swarm.Address) error {
+				eg.Go(func() error {
+					return processBytes(ectx, ref, 1)
+				})
+				return nil
+			})
+			if werr := eg.Wait(); werr != nil {
+				return fmt.Errorf("traversal: unable to process bytes for %q: %w", addr, werr)
+			}
 			if errors.Is(err, mantaray.ErrTooShort) || errors.Is(err, mantaray.ErrInvalidVersionHash) {
 				// Based on the returned errors we conclude that it might
 				// not be a manifest, so we try non-manifest processing.
@@ -98,7 +168,7 @@ func (s *service) Traverse(ctx context.Context, addr swarm.Address, iterFn swarm
 	}
 
 	// Non-manifest processing.
-	if err := processBytes(addr); err != nil {
+	if err := processBytes(ctx, addr, 0); err != nil {
 		return fmt.Errorf("traversal: unable to process bytes for %q: %w", addr, err)
 	}
 	return nil
@@ -472,6 +472,75 @@ func TestTraversalManifest(t *testing.T) {
 	}
 }
 
+func TestTraversalOptions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storerMock = inmemchunkstore.New()
+		iter       = newAddressIterator(true)
+		progress   []traversal.Progress
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ls := loadsave.New(storerMock, storerMock, pipelineFactory(storerMock, false), redundancy.DefaultLevel)
+	dirManifest, err := manifest.NewMantarayManifest(ls, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 16; i++ {
+		pipe := builder.NewPipelineBuilder(ctx, storerMock, false, 0)
+		fr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(generateSample(swarm.ChunkSize+i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dirManifest.Add(ctx, fmt.Sprintf("data/%d.txt", i), manifest.NewEntry(fr, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	address, err := dirManifest.Store(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	traverser := traversal.NewWithOptions(storerMock, storerMock, redundancy.DefaultLevel, traversal.Options{
+		Workers: 4,
+		// the progress callbacks are not called concurrently
+		Progress: func(p traversal.Progress) { progress = append(progress, p) },
+	})
+	if err := traverser.Traverse(ctx, address, iter.Next); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(progress) != iter.cnt {
+		t.Fatalf("progress calls mismatch: have %d; want %d", len(progress), iter.cnt)
+	}
+	for i, p := range progress {
+		if p.Visited != uint64(i+1) {
+			t.Fatalf("visited mismatch: have %d; want %d", p.Visited, i+1)
+		}
+		if p.Depth != 1 {
+			t.Fatalf("depth mismatch: have %d; want 1", p.Depth)
+		}
+	}
+
+	// the same addresses are visited as with a single worker
+	want := newAddressIterator(true)
+	err = traversal.NewWithOptions(storerMock, storerMock, redundancy.DefaultLevel, traversal.Options{Workers: 1}).Traverse(ctx, address, want.Next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iter.cnt != want.cnt || len(iter.seen) != len(want.seen) {
+		t.Fatalf("hash count mismatch: have %d; want %d", iter.cnt, want.cnt)
+	}
+	for hash := range want.seen {
+		if !iter.seen[hash] {
+			t.Fatalf("hash check: want %q; have none", hash)
+		}
+	}
+}
+
 func TestTraversalSOC(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,83 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nbhdutil
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+)
+
+// MaxSuggestionBits is the maximum length of the suggested neighborhood.
+const MaxSuggestionBits = 16
+
+// LeastPopulated returns the neighborhood of the given length, in binary
+// format, with the fewest peers, as seen from the base address. A kademlia
+// knows only a sample of the peers in its shallow bins, so the population of
+// a neighborhood is compared relative to the other neighborhoods in the same
+// bin, and the bins without any known peer are not considered. An empty
+// string is returned if no neighborhood can be suggested.
+func LeastPopulated(base swarm.Address, peers []swarm.Address, length uint8) string {
+	if length == 0 || len(base.Bytes()) < 4 {
+		return ""
+	}
+	length = min(length, MaxSuggestionBits)
+
+	var (
+		basePrefix = prefix(base, length)
+		counts     = make([]int, 1<<length)
+		binCounts  = make([]int, length+1)
+	)
+	for _, peer := range peers {
+		if len(peer.Bytes()) < 4 {
+			continue
+		}
+		p := prefix(peer, length)
+		counts[p]++
+		binCounts[prefixBin(p, basePrefix, length)]++
+	}
+
+	var (
+		found   bool
+		best    uint32
+		density float64
+	)
+	for p := range counts {
+		bin := prefixBin(uint32(p), basePrefix, length)
+		if binCounts[bin] == 0 {
+			continue
+		}
+		// the number of neighborhoods in the bin
+		n := 1
+		if bin < length {
+			n = 1 << (length - bin - 1)
+		}
+		d := float64(counts[p]) * float64(n) / float64(binCounts[bin])
+		if !found || d < density || (d == density && counts[p] < counts[best]) {
+			found, best, density = true, uint32(p), d
+		}
+	}
+	if !found {
+		return ""
+	}
+	return fmt.Sprintf("%0*b", length, best)
+}
+
+// prefix returns the first length bits of the address.
+func prefix(addr swarm.Address, length uint8) uint32 {
+	return binary.BigEndian.Uint32(addr.Bytes()) >> (32 - length)
+}
+
+// prefixBin returns the proximity order of the prefixes of the given length,
+// which is the length if they are equal.
+func prefixBin(a, b uint32, length uint8) uint8 {
+	x := a ^ b
+	if x == 0 {
+		return length
+	}
+	return uint8(bits.LeadingZeros32(x) - (32 - int(length)))
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nbhdutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/util/nbhdutil"
+)
+
+func TestLeastPopulated(t *testing.T) {
+	t.Parallel()
+
+	addr := func(prefix string) swarm.Address {
+		return swarm.MustParseHexAddress(prefix + strings.Repeat("0", 64-len(prefix)))
+	}
+	repeat := func(a swarm.Address, n int) []swarm.Address {
+		addrs := make([]swarm.Address, n)
+		for i := range addrs {
+			addrs[i] = a
+		}
+		return addrs
+	}
+
+	base := addr("00")
+
+	for _, tc := range []struct {
+		name   string
+		peers  []swarm.Address
+		length uint8
+		want   string
+	}{
+		{
+			name: "no peers",
+			want: "",
+		},
+		{
+			name:   "zero length",
+			peers:  []swarm.Address{addr("80")},
+			length: 0,
+			want:   "",
+		},
+		{
+			// the neighborhood 11 holds fewer peers than 10 in the same bin, the
+			// peers of the bin of the neighborhood 01 are spread evenly
+			name:   "least populated in bin",
+			peers:  append(append(repeat(addr("80"), 4), addr("c0")), repeat(addr("40"), 2)...),
+			length: 2,
+			want:   "11",
+		},
+		{
+			// a neighborhood without peers in a bin with known peers
+			name:   "empty neighborhood",
+			peers:  repeat(addr("80"), 3),
+			length: 2,
+			want:   "11",
+		},
+		{
+			name:   "length limited",
+			peers:  []swarm.Address{addr("ffff")},
+			length: 32,
+			want:   "1000000000000000",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if have := nbhdutil.LeastPopulated(base, tc.peers, tc.length); have != tc.want {
+				t.Fatalf("have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package verifier provides the verification of the integrity of all the
+// chunks of a content against their addresses.
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/calmw/bee-tron/pkg/cac"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/soc"
+	"github.com/calmw/bee-tron/pkg/storage"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/traversal"
+)
+
+// ErrCorrupt is returned to the traversal for the chunks with a content
+// which does not match their address.
+var ErrCorrupt = errors.New("verifier: corrupt chunk")
+
+// Report is the result of the verification of a content.
+type Report struct {
+	Reference swarm.Address
+	Total     int             // number of the unique chunks checked
+	Missing   []swarm.Address // chunks not found
+	Corrupt   []swarm.Address // chunks with a content not matching the address
+	// Complete is false if a missing or corrupt intermediate chunk
+	// prevented to check the chunks under it.
+	Complete bool
+}
+
+// Verifier verifies the chunks of contents.
+type Verifier interface {
+	// Verify checks the integrity of every chunk of the content of the
+	// reference, the root and intermediate chunks and the manifests included.
+	Verify(context.Context, swarm.Address) (*Report, error)
+}
+
+// New constructs a new Verifier which checks the chunks of the getter.
+func New(getter storage.Getter) Verifier {
+	return &service{getter: getter}
+}
+
+type service struct {
+	getter storage.Getter
+}
+
+// Verify implements Verifier.Verify method.
+func (s *service) Verify(ctx context.Context, addr swarm.Address) (*Report, error) {
+	c := &checker{
+		getter: s.getter,
+		status: make(map[string]error),
+	}
+
+	// the recovered chunks of the content with redundancy are not stored
+	noop := storage.PutterFunc(func(context.Context, swarm.Chunk) error { return nil })
+	err := traversal.New(c, noop, redundancy.NONE).Traverse(ctx, addr, func(a swarm.Address) error {
+		_, err := c.Get(ctx, a)
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, ErrCorrupt) {
+			return nil
+		}
+		return err
+	})
+	complete := true
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, ErrCorrupt) {
+		complete = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	return c.report(addr, complete), nil
+}
+
+// checker is a storage.Getter which records the status of the retrieved
+// chunks.
+type checker struct {
+	getter storage.Getter
+
+	mu     sync.Mutex
+	status map[string]error // nil, storage.ErrNotFound or ErrCorrupt
+}
+
+// Get implements storage.Getter interface.
+func (c *checker) Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	ch, err := c.getter.Get(ctx, addr)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		err = storage.ErrNotFound
+	case err != nil:
+		return nil, err
+	case !cac.Valid(ch) && !soc.Valid(ch):
+		ch, err = nil, ErrCorrupt
+	}
+
+	c.mu.Lock()
+	c.status[addr.ByteString()] = err
+	c.mu.Unlock()
+
+	return ch, err
+}
+
+func (c *checker) report(addr swarm.Address, complete bool) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r := &Report{
+		Reference: addr,
+		Total:     len(c.status),
+		Complete:  complete,
+	}
+	for key, err := range c.status {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			r.Missing = append(r.Missing, swarm.NewAddress([]byte(key)))
+		case errors.Is(err, ErrCorrupt):
+			r.Corrupt = append(r.Corrupt, swarm.NewAddress([]byte(key)))
+		}
+	}
+	compare := func(a, b swarm.Address) int { return bytes.Compare(a.Bytes(), b.Bytes()) }
+	slices.SortFunc(r.Missing, compare)
+	slices.SortFunc(r.Corrupt, compare)
+	return r
+}
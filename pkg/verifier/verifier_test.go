@@ -0,0 +1,140 @@
+// Copyright 2026 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package verifier_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/calmw/bee-tron/pkg/file/pipeline/builder"
+	"github.com/calmw/bee-tron/pkg/file/redundancy"
+	"github.com/calmw/bee-tron/pkg/storage/inmemchunkstore"
+	"github.com/calmw/bee-tron/pkg/swarm"
+	"github.com/calmw/bee-tron/pkg/util/testutil"
+	"github.com/calmw/bee-tron/pkg/verifier"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// upload returns the store with the content of a root chunk, 2
+	// intermediate chunks and 130 data chunks and the address of the root
+	// and an intermediate chunk.
+	upload := func(t *testing.T) (*inmemchunkstore.ChunkStore, swarm.Address, swarm.Address) {
+		t.Helper()
+
+		store := inmemchunkstore.New()
+		data := testutil.RandBytes(t, 130*swarm.ChunkSize)
+		pipe := builder.NewPipelineBuilder(ctx, store, false, redundancy.NONE)
+		root, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ch, err := store.Get(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store, root, swarm.NewAddress(ch.Data()[swarm.SpanSize : swarm.SpanSize+swarm.HashSize])
+	}
+	// child returns the address of the i-th child of the chunk
+	child := func(t *testing.T, store *inmemchunkstore.ChunkStore, addr swarm.Address, i int) swarm.Address {
+		t.Helper()
+
+		ch, err := store.Get(ctx, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor := swarm.SpanSize + i*swarm.HashSize
+		return swarm.NewAddress(ch.Data()[cursor : cursor+swarm.HashSize])
+	}
+	corrupt := func(t *testing.T, store *inmemchunkstore.ChunkStore, addr swarm.Address) {
+		t.Helper()
+
+		ch, err := store.Get(ctx, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := bytes.Clone(ch.Data())
+		data[len(data)-1]++
+		if err := store.Replace(ctx, swarm.NewChunk(addr, data), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		t.Parallel()
+
+		store, root, _ := upload(t)
+
+		got, err := verifier.New(store).Verify(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &verifier.Report{Reference: root, Total: 133, Complete: true}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got report %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("broken data chunks", func(t *testing.T) {
+		t.Parallel()
+
+		store, root, intermediate := upload(t)
+		corrupted := child(t, store, intermediate, 1)
+		corrupt(t, store, corrupted)
+		missing := child(t, store, intermediate, 2)
+		if err := store.Delete(ctx, missing); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := verifier.New(store).Verify(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &verifier.Report{
+			Reference: root,
+			Total:     133,
+			Missing:   []swarm.Address{missing},
+			Corrupt:   []swarm.Address{corrupted},
+			Complete:  true,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got report %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("broken intermediate chunk", func(t *testing.T) {
+		t.Parallel()
+
+		store, root, intermediate := upload(t)
+		corrupt(t, store, intermediate)
+
+		got, err := verifier.New(store).Verify(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Complete || !reflect.DeepEqual(got.Corrupt, []swarm.Address{intermediate}) || len(got.Missing) != 0 {
+			t.Fatalf("got report %+v, want incomplete with the corrupt intermediate chunk", got)
+		}
+	})
+
+	t.Run("missing root chunk", func(t *testing.T) {
+		t.Parallel()
+
+		root := swarm.RandAddress(t)
+		got, err := verifier.New(inmemchunkstore.New()).Verify(ctx, root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &verifier.Report{Reference: root, Total: 1, Missing: []swarm.Address{root}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got report %+v, want %+v", got, want)
+		}
+	})
+}